@@ -1,7 +1,10 @@
 // Package constants provides constants.the convention of naming is to use MixedCaps or mixedCaps rather than underscores to write multiword names. https://golang.org/doc/effective_go#mixed-caps
 package constants
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrInvalidParameter parameter is not specified or invalid
@@ -30,4 +33,96 @@ var (
 
 	// ErrNotFound ref not found
 	ErrNotFound = errors.New("ref not found")
+
+	// ErrBlobberUnreachable the request to a blobber failed before it responded
+	ErrBlobberUnreachable = errors.New(CodeBlobberUnreachable)
+
+	// ErrNotEnoughTokens the read or write pool of the client is too low
+	ErrNotEnoughTokens = errors.New(CodeNotEnoughTokens)
+
+	// ErrInvalidAuthTicket the auth ticket can't be decoded
+	ErrInvalidAuthTicket = errors.New(CodeInvalidAuthTicket)
 )
+
+// Codes of the errors, returned by ErrorCode. They are the prefix of the
+// error messages, e.g. "consensus_not_met: ...".
+const (
+	CodeBlobberUnreachable = "blobber_unreachable"
+	CodeConsensusNotMet    = "consensus_not_met"
+	CodeNotEnoughTokens    = "not_enough_tokens"
+	CodeInvalidAuthTicket  = "auth_ticket_decode_error"
+	CodeTxnRejected        = "transaction_failed"
+)
+
+// ErrConsensusNotMet is returned when fewer than Need blobbers or sharders
+// succeeded. errors.Is matches any ErrConsensusNotMet, errors.As gives the
+// counts.
+type ErrConsensusNotMet struct {
+	// Op is the failed operation, e.g. "Upload commit"
+	Op   string
+	Got  int
+	Need int
+	// Reason are the errors of the blobbers, if any
+	Reason string
+}
+
+func (e *ErrConsensusNotMet) Error() string {
+	msg := fmt.Sprintf("%s: %s failed. Required consensus %d, got %d", CodeConsensusNotMet, e.Op, e.Need, e.Got)
+	if e.Reason != "" {
+		msg += ". Error: " + e.Reason
+	}
+	return msg
+}
+
+// Is reports whether target is an ErrConsensusNotMet.
+func (e *ErrConsensusNotMet) Is(target error) bool {
+	_, ok := target.(*ErrConsensusNotMet)
+	return ok
+}
+
+// Code returns CodeConsensusNotMet.
+func (e *ErrConsensusNotMet) Code() string {
+	return CodeConsensusNotMet
+}
+
+// ErrTxnRejected is returned for a transaction rejected by the miners or
+// confirmed with a failed status. errors.Is matches any ErrTxnRejected.
+type ErrTxnRejected struct {
+	// Status is the code of the failure, CodeTxnRejected if empty, e.g.
+	// "transaction_chargeable_error" for a smart contract error
+	Status string
+	Reason string
+}
+
+func (e *ErrTxnRejected) Error() string {
+	return e.Code() + ": " + e.Reason
+}
+
+// Is reports whether target is an ErrTxnRejected.
+func (e *ErrTxnRejected) Is(target error) bool {
+	_, ok := target.(*ErrTxnRejected)
+	return ok
+}
+
+// Code returns the status of the failure.
+func (e *ErrTxnRejected) Code() string {
+	if e.Status == "" {
+		return CodeTxnRejected
+	}
+	return e.Status
+}
+
+// ErrorCode returns the code of the first error of the chain of err being
+// one of the errors above, "" if none is.
+func ErrorCode(err error) string {
+	var coded interface{ Code() string }
+	if errors.As(err, &coded) {
+		return coded.Code()
+	}
+	for _, sentinel := range []error{ErrBlobberUnreachable, ErrNotEnoughTokens, ErrInvalidAuthTicket} {
+		if errors.Is(err, sentinel) {
+			return sentinel.Error()
+		}
+	}
+	return ""
+}
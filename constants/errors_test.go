@@ -0,0 +1,45 @@
+package constants
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	thrown "github.com/0chain/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrors(t *testing.T) {
+	t.Run("Test_Consensus_Not_Met", func(t *testing.T) {
+		err := fmt.Errorf("upload: %w", &ErrConsensusNotMet{Op: "Upload", Got: 2, Need: 3})
+		require.True(t, errors.Is(err, &ErrConsensusNotMet{}))
+		require.False(t, errors.Is(err, &ErrTxnRejected{}))
+
+		var cerr *ErrConsensusNotMet
+		require.True(t, errors.As(err, &cerr))
+		require.Equal(t, 2, cerr.Got)
+		require.Equal(t, 3, cerr.Need)
+		require.Equal(t, "consensus_not_met: Upload failed. Required consensus 3, got 2", cerr.Error())
+		require.Equal(t, CodeConsensusNotMet, ErrorCode(err))
+	})
+
+	t.Run("Test_Txn_Rejected", func(t *testing.T) {
+		err := &ErrTxnRejected{Reason: "invalid nonce"}
+		require.Equal(t, "transaction_failed: invalid nonce", err.Error())
+		require.True(t, errors.Is(err, &ErrTxnRejected{}))
+
+		err = &ErrTxnRejected{Status: "chargeable_error", Reason: "no tokens"}
+		require.Equal(t, "chargeable_error", ErrorCode(err))
+	})
+
+	t.Run("Test_Sentinels", func(t *testing.T) {
+		err := thrown.Throw(ErrInvalidAuthTicket, "Error decoding the auth ticket.")
+		require.True(t, errors.Is(err, ErrInvalidAuthTicket))
+		require.Equal(t, "auth_ticket_decode_error: Error decoding the auth ticket.", err.Error())
+		require.Equal(t, CodeInvalidAuthTicket, ErrorCode(err))
+
+		require.Equal(t, CodeBlobberUnreachable, ErrorCode(thrown.Throw(ErrBlobberUnreachable, "b1")))
+		require.Equal(t, CodeNotEnoughTokens, ErrorCode(ErrNotEnoughTokens))
+		require.Empty(t, ErrorCode(errors.New("other")))
+	})
+}
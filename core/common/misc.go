@@ -2,8 +2,11 @@ package common
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/0chain/errors"
 )
@@ -41,7 +44,25 @@ const tokenUnit = 1e10
 // reParseToken is a regexp to parse string representation of token
 var reParseToken = regexp.MustCompile(`^((?:\d*\.)?\d+)\s+(SAS|sas|uZCN|uzcn|mZCN|mzcn|ZCN|zcn)$`)
 
-// Balance represents 0chain native token
+var (
+	// ErrBalanceOverflow is returned when an amount of tokens exceeds the
+	// range of Balance.
+	ErrBalanceOverflow = errors.New("balance_overflow", "amount of tokens overflows")
+	// ErrNegativeBalance is returned when an amount of tokens is negative,
+	// e.g. subtracting more tokens than there are.
+	ErrNegativeBalance = errors.New("negative_balance", "negative amount of tokens")
+)
+
+// unitDecimals are the decimals of the units of the tokens, in SAS.
+var unitDecimals = map[BalanceUnit]int{
+	SAS:  0,
+	UZCN: 4,
+	MZCN: 7,
+	ZCN:  10,
+}
+
+// Balance represents 0chain native token, in SAS. Its parsing and checked
+// arithmetic are exact, unlike float64 amounts of ZCN.
 type Balance int64
 
 func (b Balance) ToToken() float64 {
@@ -80,9 +101,126 @@ func (b Balance) AutoFormat() string {
 	return b.Format(SAS)
 }
 
-// ToBalance converts ZCN tokens to Balance.
+// FormatExact formats the balance in unit, with the decimals needed only,
+// e.g. "1.5 ZCN", unlike Format rounding them to 3.
+func (b Balance) FormatExact(unit BalanceUnit) string {
+	decimals := unitDecimals[unit]
+	sign := ""
+	if b < 0 {
+		sign = "-"
+	}
+	// the absolute value of math.MinInt64 only fits in an uint64
+	digits := strconv.FormatUint(uint64(b), 10)
+	if b < 0 {
+		digits = strconv.FormatUint(-uint64(b), 10)
+	}
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	point := len(digits) - decimals
+	amount := digits[:point]
+	if fraction := strings.TrimRight(digits[point:], "0"); fraction != "" {
+		amount += "." + fraction
+	}
+	return fmt.Sprintf("%s%s %v", sign, amount, unit)
+}
+
+// Add returns b + o, failing with ErrBalanceOverflow.
+func (b Balance) Add(o Balance) (Balance, error) {
+	sum := b + o
+	if (o > 0 && sum < b) || (o < 0 && sum > b) {
+		return 0, ErrBalanceOverflow
+	}
+	return sum, nil
+}
+
+// Sub returns b - o, failing with ErrNegativeBalance if o is more than b.
+func (b Balance) Sub(o Balance) (Balance, error) {
+	if o > b {
+		return 0, ErrNegativeBalance
+	}
+	diff := b - o
+	if o < 0 && diff < b {
+		return 0, ErrBalanceOverflow
+	}
+	return diff, nil
+}
+
+// Mul returns b * n, failing with ErrBalanceOverflow.
+func (b Balance) Mul(n int64) (Balance, error) {
+	if b == 0 || n == 0 {
+		return 0, nil
+	}
+	prod := b * Balance(n)
+	if prod/Balance(n) != b || (b == -1 && n == math.MinInt64) || (n == -1 && b == math.MinInt64) {
+		return 0, ErrBalanceOverflow
+	}
+	return prod, nil
+}
+
+// Div splits b in n parts, returning a part and the SAS left over.
+func (b Balance) Div(n int64) (Balance, Balance, error) {
+	switch {
+	case n == 0:
+		return 0, 0, errors.New("invalid_balance", "division by zero")
+	case n == -1 && b == math.MinInt64:
+		return 0, 0, ErrBalanceOverflow
+	}
+	return b / Balance(n), b % Balance(n), nil
+}
+
+// ToBalance converts ZCN tokens to Balance, rounded to the nearest SAS, see
+// BalanceFromTokens to check the amount.
 func ToBalance(token float64) Balance {
-	return Balance(token * tokenUnit)
+	return Balance(math.Round(token * tokenUnit))
+}
+
+// BalanceFromTokens converts ZCN tokens to Balance, rounded to the nearest
+// SAS, failing for negative or too large amounts.
+func BalanceFromTokens(token float64) (Balance, error) {
+	if math.IsNaN(token) || math.IsInf(token, 0) {
+		return 0, errors.New("invalid_balance", "invalid amount of tokens")
+	}
+	if token < 0 {
+		return 0, ErrNegativeBalance
+	}
+	// the decimal of the float rounded to a SAS, not truncated by the
+	// binary representation of the float
+	return ParseAmount(strconv.FormatFloat(token, 'f', unitDecimals[ZCN], 64), ZCN)
+}
+
+// ParseAmount parses an amount of tokens in unit, a decimal number, e.g.
+// "1.5". The amounts with more decimals than the unit has in SAS are rejected
+// rather than rounded.
+func ParseAmount(amount string, unit BalanceUnit) (Balance, error) {
+	decimals, ok := unitDecimals[unit]
+	if !ok {
+		return 0, errors.New("invalid_balance", "undefined balance unit")
+	}
+	whole, fraction := amount, ""
+	if i := strings.IndexByte(amount, '.'); i >= 0 {
+		whole, fraction = amount[:i], amount[i+1:]
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > decimals {
+		return 0, errors.New("invalid_balance", "too many decimals: "+amount)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	digits := whole + fraction + strings.Repeat("0", decimals-len(fraction))
+	if strings.HasPrefix(digits, "-") {
+		return 0, ErrNegativeBalance
+	}
+	sas, ok := new(big.Int).SetString(digits, 10)
+	if !ok || strings.HasPrefix(digits, "+") {
+		return 0, errors.New("invalid_balance", "invalid amount of tokens: "+amount)
+	}
+	if !sas.IsInt64() {
+		return 0, ErrBalanceOverflow
+	}
+	return Balance(sas.Int64()), nil
 }
 
 func FormatBalance(b Balance, unit BalanceUnit) string {
@@ -93,6 +231,8 @@ func AutoFormatBalance(b Balance) string {
 	return b.AutoFormat()
 }
 
+// ParseBalance parses an amount of tokens followed by its unit, e.g.
+// "1.5 ZCN" or "300 mZCN", exactly, see ParseAmount.
 func ParseBalance(str string) (Balance, error) {
 
 	matches := reParseToken.FindAllStringSubmatch(str, -1)
@@ -101,28 +241,14 @@ func ParseBalance(str string) (Balance, error) {
 		return 0, fmt.Errorf("invalid input: %s", str)
 	}
 
-	b, err := strconv.ParseFloat(matches[0][1], 64)
-	if err != nil {
-		return 0, err
-	}
-
 	var unit BalanceUnit
 
-	err = unit.Parse(matches[0][2])
+	err := unit.Parse(matches[0][2])
 	if err != nil {
 		return 0, err
 	}
 
-	switch unit {
-	case UZCN:
-		b *= 1e4
-	case MZCN:
-		b *= 1e7
-	case ZCN:
-		b *= 1e10
-	}
-
-	return Balance(b), nil
+	return ParseAmount(matches[0][1], unit)
 }
 
 const (
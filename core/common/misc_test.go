@@ -1,8 +1,10 @@
 package common
 
 import (
+	"math"
 	"testing"
 
+	"github.com/0chain/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -62,3 +64,89 @@ func TestParseBalance(t *testing.T) {
 	_, err = ParseBalance(" 10 zcn ")
 	require.EqualError(t, err, "invalid input:  10 zcn ")
 }
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		unit    BalanceUnit
+		want    Balance
+		wantErr error
+	}{
+		{name: "Test_ZCN", input: "0.29", unit: ZCN, want: 2900000000},
+		{name: "Test_mZCN", input: "300", unit: MZCN, want: 3000000000},
+		{name: "Test_uZCN", input: "1.5", unit: UZCN, want: 15000},
+		{name: "Test_SAS", input: "15000", unit: SAS, want: 15000},
+		{name: "Test_Too_Many_Decimals", input: "0.5", unit: SAS, wantErr: errors.New("invalid_balance", "too many decimals: 0.5")},
+		{name: "Test_Negative", input: "-1", unit: ZCN, wantErr: ErrNegativeBalance},
+		{name: "Test_Overflow", input: "1000000000", unit: ZCN, wantErr: ErrBalanceOverflow},
+		{name: "Test_Invalid", input: "1e3", unit: ZCN, wantErr: errors.New("invalid_balance", "invalid amount of tokens: 1e3")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAmount(tt.input, tt.unit)
+			if tt.wantErr != nil {
+				require.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	// rounding errors of floats are rejected rather than truncated
+	_, err := ParseBalance("0.00000000001 ZCN")
+	require.Error(t, err)
+}
+
+func TestBalanceFromTokens(t *testing.T) {
+	// 0.29 * 1e10 is 2899999999.9999995 as a float64
+	b, err := BalanceFromTokens(0.29)
+	require.NoError(t, err)
+	require.EqualValues(t, 2900000000, b)
+	require.EqualValues(t, 2900000000, ToBalance(0.29))
+
+	_, err = BalanceFromTokens(-1)
+	require.ErrorIs(t, err, ErrNegativeBalance)
+	_, err = BalanceFromTokens(math.Inf(1))
+	require.Error(t, err)
+	_, err = BalanceFromTokens(1e9)
+	require.ErrorIs(t, err, ErrBalanceOverflow)
+}
+
+func TestFormatExact(t *testing.T) {
+	require.Equal(t, "1.5 ZCN", Balance(15000000000).FormatExact(ZCN))
+	require.Equal(t, "0.0000000001 ZCN", Balance(1).FormatExact(ZCN))
+	require.Equal(t, "0 ZCN", Balance(0).FormatExact(ZCN))
+	require.Equal(t, "1500 mZCN", Balance(15000000000).FormatExact(MZCN))
+	require.Equal(t, "-0.5 ZCN", Balance(-5000000000).FormatExact(ZCN))
+	require.Equal(t, "-922337203.6854775808 ZCN", Balance(math.MinInt64).FormatExact(ZCN))
+}
+
+func TestBalanceArithmetic(t *testing.T) {
+	sum, err := Balance(1).Add(2)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, sum)
+	_, err = Balance(math.MaxInt64).Add(1)
+	require.ErrorIs(t, err, ErrBalanceOverflow)
+
+	_, err = Balance(1).Sub(2)
+	require.ErrorIs(t, err, ErrNegativeBalance)
+	_, err = Balance(math.MaxInt64).Sub(-1)
+	require.ErrorIs(t, err, ErrBalanceOverflow)
+
+	product, err := Balance(3).Mul(4)
+	require.NoError(t, err)
+	require.EqualValues(t, 12, product)
+	_, err = Balance(math.MaxInt64).Mul(2)
+	require.ErrorIs(t, err, ErrBalanceOverflow)
+	_, err = Balance(math.MinInt64).Mul(-1)
+	require.ErrorIs(t, err, ErrBalanceOverflow)
+
+	part, rest, err := Balance(10).Div(3)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, part)
+	require.EqualValues(t, 1, rest)
+	_, _, err = Balance(10).Div(0)
+	require.Error(t, err)
+}
@@ -70,6 +70,11 @@ type Config struct {
 	ZboxHost string `json:"zbox_host"`
 	// ZboxAppType app type name
 	ZboxAppType string `json:"zbox_app_type"`
+
+	// RemoteConfigURL url of the signed remote config document. zbox_host is used if it is empty.
+	RemoteConfigURL string `json:"remote_config_url,omitempty"`
+	// RemoteConfigPublicKey public key the remote config document is signed with
+	RemoteConfigPublicKey string `json:"remote_config_public_key,omitempty"`
 }
 
 // LoadConfigFile load and parse Config from file
@@ -155,6 +160,8 @@ func LoadConfig(v Reader) (Config, error) {
 
 	cfg.SignatureScheme = v.GetString("signature_scheme")
 	cfg.ChainID = v.GetString("chain_id")
+	cfg.RemoteConfigURL = v.GetString("remote_config_url")
+	cfg.RemoteConfigPublicKey = v.GetString("remote_config_public_key")
 
 	return cfg, nil
 
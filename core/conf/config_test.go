@@ -20,6 +20,8 @@ func TestLoadConfig(t *testing.T) {
 		reader.On("GetStringSlice", "preferred_blobbers").Return(nil)
 		reader.On("GetString", "signature_scheme").Return("")
 		reader.On("GetString", "chain_id").Return("")
+		reader.On("GetString", "remote_config_url").Return("")
+		reader.On("GetString", "remote_config_public_key").Return("")
 		reader.On("GetString", "verify_optimistic").Return("true")
 
 		return reader
@@ -48,6 +50,8 @@ func TestLoadConfig(t *testing.T) {
 				reader.On("GetStringSlice", "preferred_blobbers").Return(nil)
 				reader.On("GetString", "signature_scheme").Return("")
 				reader.On("GetString", "chain_id").Return("")
+				reader.On("GetString", "remote_config_url").Return("")
+				reader.On("GetString", "remote_config_public_key").Return("")
 				reader.On("GetString", "verify_optimistic").Return("true")
 
 				return reader
@@ -91,6 +95,8 @@ func TestLoadConfig(t *testing.T) {
 				reader.On("GetStringSlice", "preferred_blobbers").Return(nil)
 				reader.On("GetString", "signature_scheme").Return("")
 				reader.On("GetString", "chain_id").Return("")
+				reader.On("GetString", "remote_config_url").Return("")
+				reader.On("GetString", "remote_config_public_key").Return("")
 				reader.On("GetString", "verify_optimistic").Return("true")
 
 				return reader
@@ -124,6 +130,8 @@ func TestLoadConfig(t *testing.T) {
 				reader.On("GetStringSlice", "preferred_blobbers").Return(nil)
 				reader.On("GetString", "signature_scheme").Return("")
 				reader.On("GetString", "chain_id").Return("")
+				reader.On("GetString", "remote_config_url").Return("")
+				reader.On("GetString", "remote_config_public_key").Return("")
 				reader.On("GetString", "verify_optimistic").Return("false")
 
 				return reader
@@ -0,0 +1,210 @@
+package conf
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	thrown "github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/resty"
+	"github.com/0chain/gosdk/core/zcncrypto"
+)
+
+// RemoteConfigPath is the 0box endpoint serving the remote config document.
+// It is used when Config.RemoteConfigURL is empty.
+const RemoteConfigPath = "/v2/sdk/config"
+
+var (
+	// ErrInvalidRemoteConfig remote config document is malformed or its signature doesn't match
+	ErrInvalidRemoteConfig = errors.New("[conf]invalid remote config")
+	// ErrStaleRemoteConfig remote config document is older than the one already applied
+	ErrStaleRemoteConfig = errors.New("[conf]stale remote config")
+)
+
+// RemoteConfig feature flags and settings published by the network operator to
+// roll out new behaviours (endpoints, hashing modes) gradually across clients.
+//
+//	{
+//	  "version": 3,
+//	  "flags": {"paged_list": true},
+//	  "values": {"hash_mode": "blake3"},
+//	  "signature": "..."
+//	}
+//
+// Signature signs encryption.Hash of the document serialized without it.
+type RemoteConfig struct {
+	Version   int64             `json:"version"`
+	Flags     map[string]bool   `json:"flags,omitempty"`
+	Values    map[string]string `json:"values,omitempty"`
+	Signature string            `json:"signature,omitempty"`
+}
+
+// Hash returns the hash the signature is calculated on.
+func (rc *RemoteConfig) Hash() string {
+	unsigned := *rc
+	unsigned.Signature = ""
+	// encoding/json sorts map keys, so the encoding is canonical.
+	buf, _ := json.Marshal(&unsigned)
+	return encryption.Hash(buf)
+}
+
+// Verify checks the signature of the document against the publisher's public key.
+func (rc *RemoteConfig) Verify(publicKey, signatureScheme string) error {
+	if rc.Signature == "" {
+		return thrown.Throw(ErrInvalidRemoteConfig, "missing signature")
+	}
+	ss := zcncrypto.NewSignatureScheme(signatureScheme)
+	if err := ss.SetPublicKey(publicKey); err != nil {
+		return thrown.Throw(ErrInvalidRemoteConfig, err.Error())
+	}
+	ok, err := ss.Verify(rc.Signature, rc.Hash())
+	if err != nil {
+		return thrown.Throw(ErrInvalidRemoteConfig, err.Error())
+	}
+	if !ok {
+		return thrown.Throw(ErrInvalidRemoteConfig, "signature mismatch")
+	}
+	return nil
+}
+
+var (
+	remoteMu       sync.RWMutex
+	remoteConfig   *RemoteConfig
+	flagOverrides  = make(map[string]bool)
+	valueOverrides = make(map[string]string)
+)
+
+// FetchRemoteConfig download the remote config document from url, verify it
+// with publicKey and apply it. A document with a lower version than the
+// current one is rejected with ErrStaleRemoteConfig.
+func FetchRemoteConfig(ctx context.Context, url, publicKey, signatureScheme string) (*RemoteConfig, error) {
+	rc := &RemoteConfig{}
+	r := resty.New()
+	r.DoGet(ctx, url).Then(func(req *http.Request, resp *http.Response, respBody []byte, cf context.CancelFunc, err error) error {
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("remote config: %s", resp.Status)
+		}
+		if err := json.Unmarshal(respBody, rc); err != nil {
+			return thrown.Throw(ErrInvalidRemoteConfig, err.Error())
+		}
+		return nil
+	})
+	if errs := r.Wait(); len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	if err := rc.Verify(publicKey, signatureScheme); err != nil {
+		return nil, err
+	}
+	if err := ApplyRemoteConfig(rc); err != nil {
+		return nil, err
+	}
+	return rc, nil
+}
+
+// FetchRemoteConfigFromClientConfig fetch the remote config using the
+// remote_config_url and remote_config_public_key of the client config. The
+// url falls back to zbox_host + RemoteConfigPath.
+func FetchRemoteConfigFromClientConfig(ctx context.Context) (*RemoteConfig, error) {
+	c, err := GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	url := c.RemoteConfigURL
+	if url == "" {
+		if c.ZboxHost == "" {
+			return nil, thrown.Throw(ErrInvalidValue, "remote_config_url")
+		}
+		url = strings.TrimSuffix(c.ZboxHost, "/") + RemoteConfigPath
+	}
+	if c.RemoteConfigPublicKey == "" {
+		return nil, thrown.Throw(ErrInvalidValue, "remote_config_public_key")
+	}
+	scheme := c.SignatureScheme
+	if scheme == "" {
+		scheme = "bls0chain"
+	}
+	return FetchRemoteConfig(ctx, url, c.RemoteConfigPublicKey, scheme)
+}
+
+// ApplyRemoteConfig apply an already verified remote config document.
+func ApplyRemoteConfig(rc *RemoteConfig) error {
+	if rc == nil {
+		return ErrNilConfig
+	}
+	remoteMu.Lock()
+	defer remoteMu.Unlock()
+	if remoteConfig != nil && rc.Version < remoteConfig.Version {
+		return thrown.Throw(ErrStaleRemoteConfig, fmt.Sprintf("got %d, have %d", rc.Version, remoteConfig.Version))
+	}
+	remoteConfig = rc
+	return nil
+}
+
+// GetRemoteConfig get the remote config applied last, nil if none.
+func GetRemoteConfig() *RemoteConfig {
+	remoteMu.RLock()
+	defer remoteMu.RUnlock()
+	return remoteConfig
+}
+
+// SetFlagOverride force a feature flag locally, taking precedence over the remote config.
+func SetFlagOverride(name string, enabled bool) {
+	remoteMu.Lock()
+	flagOverrides[name] = enabled
+	remoteMu.Unlock()
+}
+
+// SetValueOverride force a remote config value locally.
+func SetValueOverride(name, value string) {
+	remoteMu.Lock()
+	valueOverrides[name] = value
+	remoteMu.Unlock()
+}
+
+// ClearOverrides remove all local flag and value overrides.
+func ClearOverrides() {
+	remoteMu.Lock()
+	flagOverrides = make(map[string]bool)
+	valueOverrides = make(map[string]string)
+	remoteMu.Unlock()
+}
+
+// IsFeatureEnabled resolve a feature flag: local override first, then the
+// remote config, then defaultValue.
+func IsFeatureEnabled(name string, defaultValue bool) bool {
+	remoteMu.RLock()
+	defer remoteMu.RUnlock()
+	if v, ok := flagOverrides[name]; ok {
+		return v
+	}
+	if remoteConfig != nil {
+		if v, ok := remoteConfig.Flags[name]; ok {
+			return v
+		}
+	}
+	return defaultValue
+}
+
+// GetRemoteValue resolve a remote config value the same way as IsFeatureEnabled.
+func GetRemoteValue(name, defaultValue string) string {
+	remoteMu.RLock()
+	defer remoteMu.RUnlock()
+	if v, ok := valueOverrides[name]; ok {
+		return v
+	}
+	if remoteConfig != nil {
+		if v, ok := remoteConfig.Values[name]; ok {
+			return v
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,90 @@
+package conf
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteConfig(t *testing.T) {
+	sigScheme := zcncrypto.NewSignatureScheme("ed25519")
+	w, err := sigScheme.GenerateKeys()
+	require.NoError(t, err)
+	require.NoError(t, sigScheme.SetPrivateKey(w.Keys[0].PrivateKey))
+	publicKey := w.Keys[0].PublicKey
+
+	sign := func(t *testing.T, rc *RemoteConfig) *RemoteConfig {
+		sig, err := sigScheme.Sign(rc.Hash())
+		require.NoError(t, err)
+		rc.Signature = sig
+		return rc
+	}
+
+	serve := func(t *testing.T, rc *RemoteConfig) *httptest.Server {
+		buf, err := json.Marshal(rc)
+		require.NoError(t, err)
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write(buf) //nolint
+		}))
+		t.Cleanup(s.Close)
+		return s
+	}
+
+	reset := func() {
+		remoteMu.Lock()
+		remoteConfig = nil
+		remoteMu.Unlock()
+		ClearOverrides()
+	}
+
+	t.Run("Test_Fetch_And_Override", func(t *testing.T) {
+		defer reset()
+		s := serve(t, sign(t, &RemoteConfig{
+			Version: 2,
+			Flags:   map[string]bool{"paged_list": true, "blake3": false},
+			Values:  map[string]string{"hash_mode": "sha3"},
+		}))
+
+		_, err := FetchRemoteConfig(context.TODO(), s.URL, publicKey, "ed25519")
+		require.NoError(t, err)
+
+		require.True(t, IsFeatureEnabled("paged_list", false))
+		require.False(t, IsFeatureEnabled("blake3", true))
+		require.True(t, IsFeatureEnabled("unknown", true))
+		require.Equal(t, "sha3", GetRemoteValue("hash_mode", ""))
+
+		SetFlagOverride("paged_list", false)
+		SetValueOverride("hash_mode", "blake3")
+		require.False(t, IsFeatureEnabled("paged_list", true))
+		require.Equal(t, "blake3", GetRemoteValue("hash_mode", ""))
+
+		err = ApplyRemoteConfig(&RemoteConfig{Version: 1})
+		require.True(t, errors.Is(err, ErrStaleRemoteConfig))
+	})
+
+	t.Run("Test_Tampered", func(t *testing.T) {
+		defer reset()
+		rc := sign(t, &RemoteConfig{Version: 1, Flags: map[string]bool{"paged_list": false}})
+		rc.Flags["paged_list"] = true
+		s := serve(t, rc)
+
+		_, err := FetchRemoteConfig(context.TODO(), s.URL, publicKey, "ed25519")
+		require.True(t, errors.Is(err, ErrInvalidRemoteConfig))
+		require.Nil(t, GetRemoteConfig())
+		require.False(t, IsFeatureEnabled("paged_list", false))
+	})
+
+	t.Run("Test_Unsigned", func(t *testing.T) {
+		defer reset()
+		s := serve(t, &RemoteConfig{Version: 1})
+
+		_, err := FetchRemoteConfig(context.TODO(), s.URL, publicKey, "ed25519")
+		require.True(t, errors.Is(err, ErrInvalidRemoteConfig))
+	})
+}
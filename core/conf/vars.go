@@ -41,6 +41,15 @@ func InitClientConfig(c *Config) {
 	cfg = c
 }
 
+// GetNetwork get global chain network
+func GetNetwork() (*Network, error) {
+	if network == nil {
+		return nil, ErrConfigNotInitialized
+	}
+
+	return network, nil
+}
+
 // InitChainNetwork set global chain network
 func InitChainNetwork(n *Network) {
 	if n == nil {
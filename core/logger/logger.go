@@ -5,6 +5,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"sync/atomic"
 )
 
 const (
@@ -26,8 +27,9 @@ const (
 )
 
 type Logger struct {
-	lvl      int
+	lvl      int32 // atomic, SetSubsystemLevel sets it while logging
 	prefix   string
+	name     string
 	logDebug *log.Logger
 	logInfo  *log.Logger
 	logError *log.Logger
@@ -39,6 +41,10 @@ type Logger struct {
 func (l *Logger) Init(lvl int, prefix string) {
 	l.SetLevel(lvl)
 	l.prefix = prefix
+	l.name = prefix
+	if lvl, ok := register(l); ok {
+		l.SetLevel(lvl)
+	}
 	l.logDebug = log.New(os.Stderr, prefix+": "+strDEBUG, log.Ldate|log.Ltime|log.Lmicroseconds|log.Lshortfile)
 	l.logInfo = log.New(os.Stderr, prefix+": "+strINFO, log.Ldate|log.Ltime|log.Lmicroseconds|log.Lshortfile)
 	l.logError = log.New(os.Stderr, prefix+": "+strERROR, log.Ldate|log.Ltime|log.Lmicroseconds|log.Lshortfile)
@@ -47,7 +53,11 @@ func (l *Logger) Init(lvl int, prefix string) {
 
 // SetLevel - Configures the log level. Higher the number more verbose.
 func (l *Logger) SetLevel(lvl int) {
-	l.lvl = lvl
+	atomic.StoreInt32(&l.lvl, int32(lvl))
+}
+
+func (l *Logger) level() int {
+	return int(atomic.LoadInt32(&l.lvl))
 }
 
 // syncPrefixes - syncs the logger prefixes
@@ -89,25 +99,41 @@ func (l *Logger) SetLogFile(logFile io.Writer, verbose bool) {
 }
 
 func (l *Logger) Debug(v ...interface{}) {
-	if l.lvl >= DEBUG {
+	if l.level() >= DEBUG {
+		if s := GetSink(); s != nil {
+			s.Log(DEBUG, l.name, fmt.Sprint(v...))
+			return
+		}
 		l.logDebug.Output(2, fmt.Sprint(v...))
 	}
 }
 
 func (l *Logger) Info(v ...interface{}) {
-	if l.lvl >= INFO {
+	if l.level() >= INFO {
+		if s := GetSink(); s != nil {
+			s.Log(INFO, l.name, fmt.Sprint(v...))
+			return
+		}
 		l.logInfo.Output(2, fmt.Sprint(v...))
 	}
 }
 
 func (l *Logger) Error(v ...interface{}) {
-	if l.lvl >= ERROR {
+	if l.level() >= ERROR {
+		if s := GetSink(); s != nil {
+			s.Log(ERROR, l.name, fmt.Sprint(v...))
+			return
+		}
 		l.logError.Output(2, fmt.Sprint(v...)+cReset)
 	}
 }
 
 func (l *Logger) Fatal(v ...interface{}) {
-	if l.lvl >= FATAL {
+	if l.level() >= FATAL {
+		if s := GetSink(); s != nil {
+			s.Log(FATAL, l.name, fmt.Sprint(v...))
+			return
+		}
 		l.logFatal.Output(2, fmt.Sprint(v...)+cReset)
 	}
 }
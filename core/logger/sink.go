@@ -0,0 +1,81 @@
+package logger
+
+import "sync"
+
+// Sink receives the entries of all the loggers once set with SetSink, e.g.
+// to route the sdk logs into the zap, logrus or slog logger of the host app.
+// level is one of FATAL, ERROR, INFO or DEBUG and subsystem the name the
+// logger was initialized with, e.g. "0box-sdk".
+type Sink interface {
+	Log(level int, subsystem, msg string)
+}
+
+// SinkFunc is a function implementing Sink.
+type SinkFunc func(level int, subsystem, msg string)
+
+// Log implements Sink.
+func (f SinkFunc) Log(level int, subsystem, msg string) {
+	f(level, subsystem, msg)
+}
+
+var (
+	registryMu sync.RWMutex
+	sink       Sink
+	// loggers by subsystem, for SetSubsystemLevel
+	loggers = make(map[string][]*Logger)
+	levels  = make(map[string]int)
+)
+
+// SetSink routes the entries of all the loggers to s instead of their
+// writers, nil restores the writers. The level of each logger still
+// applies.
+func SetSink(s Sink) {
+	registryMu.Lock()
+	sink = s
+	registryMu.Unlock()
+}
+
+// GetSink returns the sink set with SetSink.
+func GetSink() Sink {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return sink
+}
+
+// SetSubsystemLevel sets the level of the loggers of subsystem, including
+// the ones initialized later.
+func SetSubsystemLevel(subsystem string, lvl int) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	levels[subsystem] = lvl
+	for _, l := range loggers[subsystem] {
+		l.SetLevel(lvl)
+	}
+}
+
+// Subsystems returns the names of the initialized loggers.
+func Subsystems() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(loggers))
+	for name := range loggers {
+		names = append(names, name)
+	}
+	return names
+}
+
+// register adds l to the loggers of its subsystem and returns the level set
+// for it with SetSubsystemLevel.
+func register(l *Logger) (int, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, registered := range loggers[l.name] {
+		if registered == l {
+			lvl, ok := levels[l.name]
+			return lvl, ok
+		}
+	}
+	loggers[l.name] = append(loggers[l.name], l)
+	lvl, ok := levels[l.name]
+	return lvl, ok
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSink(t *testing.T) {
+	type entry struct {
+		level     int
+		subsystem string
+		msg       string
+	}
+	var got []entry
+	SetSink(SinkFunc(func(level int, subsystem, msg string) {
+		got = append(got, entry{level, subsystem, msg})
+	}))
+	t.Cleanup(func() { SetSink(nil) })
+
+	var l Logger
+	l.Init(INFO, "test-sdk")
+
+	l.Info("uploaded ", 3, " blocks")
+	l.Debug("filtered by level")
+	require.Equal(t, []entry{{INFO, "test-sdk", "uploaded 3 blocks"}}, got)
+
+	t.Run("Test_Subsystem_Level", func(t *testing.T) {
+		got = nil
+		SetSubsystemLevel("test-sdk", DEBUG)
+		l.Debug("now logged")
+		require.Equal(t, []entry{{DEBUG, "test-sdk", "now logged"}}, got)
+
+		var later Logger
+		later.Init(ERROR, "test-sdk")
+		later.Debug("level of the subsystem applies")
+		require.Len(t, got, 2)
+		require.Contains(t, Subsystems(), "test-sdk")
+	})
+}
@@ -0,0 +1,74 @@
+package netconf
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimit is a token bucket applied per host to the queries of the
+// sharders and miners. Requests over the limit wait for a token instead of
+// failing, until their context is done.
+type RateLimit struct {
+	// RequestsPerSecond is the rate the bucket refills at, 0 disables the
+	// limit.
+	RequestsPerSecond float64
+	// Burst is the size of the bucket, 1 if not set.
+	Burst int
+}
+
+var (
+	limitMu  sync.Mutex
+	limit    RateLimit
+	limiters = make(map[string]*rate.Limiter)
+)
+
+// SetRateLimit limits the queries of the sdk to each sharder and miner, e.g.
+// to avoid the 429 responses of the sharders to bulk tooling. The zero
+// RateLimit removes the limit.
+func SetRateLimit(l RateLimit) {
+	if l.Burst <= 0 {
+		l.Burst = 1
+	}
+	limitMu.Lock()
+	defer limitMu.Unlock()
+	limit = l
+	limiters = make(map[string]*rate.Limiter)
+}
+
+// GetRateLimit returns the limit set with SetRateLimit.
+func GetRateLimit() RateLimit {
+	limitMu.Lock()
+	defer limitMu.Unlock()
+	return limit
+}
+
+// WaitRateLimit blocks until the bucket of the host of rawURL has a token,
+// or returns the error of ctx. It returns at once if no limit is set.
+func WaitRateLimit(ctx context.Context, rawURL string) error {
+	l := hostLimiter(rawURL)
+	if l == nil {
+		return nil
+	}
+	return l.Wait(ctx)
+}
+
+func hostLimiter(rawURL string) *rate.Limiter {
+	limitMu.Lock()
+	defer limitMu.Unlock()
+	if limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+	host := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	l, ok := limiters[host]
+	if !ok {
+		l = rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), limit.Burst)
+		limiters[host] = l
+	}
+	return l
+}
@@ -0,0 +1,37 @@
+package netconf
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetRateLimit(t *testing.T) {
+	defer SetRateLimit(RateLimit{})
+
+	ctx := context.Background()
+	require.NoError(t, WaitRateLimit(ctx, "http://sharder1:7171/v1/screst"))
+	require.Nil(t, hostLimiter("http://sharder1:7171"), "no limit by default")
+
+	SetRateLimit(RateLimit{RequestsPerSecond: 1})
+	require.Equal(t, 1, GetRateLimit().Burst)
+	require.Same(t, hostLimiter("http://sharder1:7171/a"), hostLimiter("http://sharder1:7171/b"),
+		"the bucket is per host")
+	require.NotSame(t, hostLimiter("http://sharder1:7171"), hostLimiter("http://sharder2:7171"))
+
+	require.NoError(t, WaitRateLimit(ctx, "http://sharder1:7171"))
+	require.NoError(t, WaitRateLimit(ctx, "http://sharder2:7171"), "other hosts have their own bucket")
+
+	tctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, WaitRateLimit(tctx, "http://sharder1:7171"), "the request waits for a token")
+
+	SetRateLimit(RateLimit{RequestsPerSecond: 100})
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, WaitRateLimit(ctx, "http://sharder1:7171"))
+	}
+	require.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond, "requests are queued, not failed")
+}
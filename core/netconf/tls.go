@@ -0,0 +1,93 @@
+package netconf
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/0chain/errors"
+)
+
+var (
+	tlsConfig  *tls.Config
+	transports = make(map[*http.Transport]struct{})
+)
+
+// SetTLSConfig sets the TLS config of all the transports of the sdk, e.g.
+// with a client certificate and the CA of a private network, see
+// NewTLSConfig. nil restores the default config. It must be called before
+// the requests to the nodes, the idle connections are closed.
+func SetTLSConfig(cfg *tls.Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	tlsConfig = cfg
+	for t := range transports {
+		t.TLSClientConfig = cloneTLS(cfg)
+		t.CloseIdleConnections()
+	}
+}
+
+// TLSConfig returns a copy of the config set with SetTLSConfig, nil if none
+// is.
+func TLSConfig() *tls.Config {
+	mu.RLock()
+	defer mu.RUnlock()
+	return cloneTLS(tlsConfig)
+}
+
+// RegisterTransport sets the TLS config of t to the one of SetTLSConfig, now
+// and when it changes, and returns t.
+func RegisterTransport(t *http.Transport) *http.Transport {
+	mu.Lock()
+	defer mu.Unlock()
+	transports[t] = struct{}{}
+	if tlsConfig != nil {
+		t.TLSClientConfig = cloneTLS(tlsConfig)
+	}
+	return t
+}
+
+// UnregisterTransport stops updating the TLS config of t, e.g. a transport
+// with its own config or replaced by another.
+func UnregisterTransport(t *http.Transport) {
+	mu.Lock()
+	delete(transports, t)
+	mu.Unlock()
+}
+
+func cloneTLS(cfg *tls.Config) *tls.Config {
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Clone()
+}
+
+// NewTLSConfig returns a TLS config authenticating the client with the
+// certificate and key PEM files, if not empty, and trusting the servers
+// signed by the CA PEM file in addition to the system ones, if not empty.
+func NewTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "load client certificate")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	if caFile != "" {
+		pem, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "read ca")
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("invalid_ca", "no certificate found in "+caFile)
+		}
+		cfg.RootCAs = pool
+	}
+	return cfg, nil
+}
@@ -0,0 +1,78 @@
+package netconf
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTLSConfig(t *testing.T) {
+	defer SetTLSConfig(nil)
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	tr := RegisterTransport(&http.Transport{})
+	defer UnregisterTransport(tr)
+	client := &http.Client{Transport: tr}
+
+	_, err := client.Get(srv.URL)
+	require.Error(t, err, "server signed by an unknown CA")
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, ioutil.WriteFile(caFile, ca, 0600))
+
+	cfg, err := NewTLSConfig("", "", caFile)
+	require.NoError(t, err)
+	SetTLSConfig(cfg)
+	require.NotNil(t, TLSConfig())
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// transports registered later get the config too
+	late := RegisterTransport(&http.Transport{})
+	defer UnregisterTransport(late)
+	require.NotNil(t, late.TLSClientConfig)
+	require.NotSame(t, cfg, late.TLSClientConfig)
+
+	SetTLSConfig(nil)
+	require.Nil(t, tr.TLSClientConfig)
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.pem")
+	require.NoError(t, ioutil.WriteFile(empty, []byte("not a pem"), 0600))
+
+	for _, tc := range []struct {
+		name    string
+		cert    string
+		key     string
+		ca      string
+		wantErr bool
+	}{
+		{name: "Test_Defaults"},
+		{name: "Test_Missing_Client_Certificate", cert: filepath.Join(dir, "cert.pem"), key: filepath.Join(dir, "key.pem"), wantErr: true},
+		{name: "Test_Missing_CA", ca: filepath.Join(dir, "ca.pem"), wantErr: true},
+		{name: "Test_Invalid_CA", ca: empty, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := NewTLSConfig(tc.cert, tc.key, tc.ca)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, uint16(tls.VersionTLS12), cfg.MinVersion)
+		})
+	}
+}
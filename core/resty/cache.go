@@ -0,0 +1,135 @@
+package resty
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// CachedResponse is a response kept by a Cache.
+type CachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// ETag of the response, to revalidate it once stale
+	ETag     string
+	StoredAt time.Time
+}
+
+// Cache keeps the responses of the GET requests of the Resty instances it
+// is set to with WithCache, keyed by method and URL.
+type Cache interface {
+	// Get returns the response cached for key, nil if none. A response that
+	// isn't fresh is revalidated with its ETag.
+	Get(key string) (resp *CachedResponse, fresh bool)
+	// Set caches resp for key.
+	Set(key string, resp *CachedResponse)
+}
+
+// DefaultMemoryCacheSize is the number of responses kept by NewMemoryCache.
+const DefaultMemoryCacheSize = 1000
+
+// MemoryCache is a Cache in memory, its responses being fresh for TTL. The
+// least recently used responses are evicted past its size.
+type MemoryCache struct {
+	TTL time.Duration
+
+	responses *lru.Cache[string, *CachedResponse]
+}
+
+// NewMemoryCache returns a MemoryCache of DefaultMemoryCacheSize responses
+// fresh for ttl.
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return NewMemoryCacheSize(ttl, DefaultMemoryCacheSize)
+}
+
+// NewMemoryCacheSize returns a MemoryCache of size responses fresh for ttl,
+// DefaultMemoryCacheSize if size isn't positive.
+func NewMemoryCacheSize(ttl time.Duration, size int) *MemoryCache {
+	if size <= 0 {
+		size = DefaultMemoryCacheSize
+	}
+	responses, _ := lru.New[string, *CachedResponse](size)
+	return &MemoryCache{TTL: ttl, responses: responses}
+}
+
+// Get implements Cache. The stale responses without ETag are evicted.
+func (c *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	resp, ok := c.responses.Get(key)
+	if !ok {
+		return nil, false
+	}
+	fresh := time.Since(resp.StoredAt) < c.TTL
+	if !fresh && resp.ETag == "" {
+		c.responses.Remove(key)
+		return nil, false
+	}
+	return resp, fresh
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, resp *CachedResponse) {
+	c.responses.Add(key, resp)
+}
+
+func cacheKey(req *http.Request) string {
+	return req.Method + " " + req.URL.String()
+}
+
+// fromCache returns the response cached for req, fresh or to be revalidated
+// with the If-None-Match header it sets on req.
+func (r *Resty) fromCache(req *http.Request) (cached *CachedResponse, fresh bool) {
+	if r.cache == nil || req.Method != http.MethodGet {
+		return nil, false
+	}
+	cached, fresh = r.cache.Get(cacheKey(req))
+	if cached != nil && !fresh && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	return cached, fresh
+}
+
+// cachedResult is the result of req served from cached.
+func cachedResult(req *http.Request, cached *CachedResponse) Result {
+	return Result{
+		Request: req,
+		Response: &http.Response{
+			StatusCode: cached.StatusCode,
+			Header:     cached.Header.Clone(),
+			Body:       ioutil.NopCloser(bytes.NewReader(cached.Body)),
+			Request:    req,
+		},
+		ResponseBody: cached.Body,
+	}
+}
+
+// toCache caches the response of result, or serves the cached response once
+// revalidated.
+func (r *Resty) toCache(cached *CachedResponse, result *Result) {
+	resp := result.Response
+	if r.cache == nil || result.Err != nil || resp == nil || result.Request.Method != http.MethodGet {
+		return
+	}
+	key := cacheKey(result.Request)
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		revalidated := *cached
+		revalidated.StoredAt = time.Now()
+		r.cache.Set(key, &revalidated)
+		*result = cachedResult(result.Request, &revalidated)
+		return
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Cache-Control") == "no-store" {
+		return
+	}
+	r.cache.Set(key, &CachedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       result.ResponseBody,
+		ETag:       resp.Header.Get("ETag"),
+		StoredAt:   time.Now(),
+	})
+}
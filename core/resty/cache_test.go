@@ -0,0 +1,104 @@
+package resty
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResty_Cache(t *testing.T) {
+	var hits, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if req.URL.Path == "/nostore" {
+			w.Header().Set("Cache-Control", "no-store")
+		}
+		w.Header().Set("ETag", `"v1"`)
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"max_mint":"1"}`)) //nolint: errcheck
+	}))
+	defer server.Close()
+
+	get := func(c Cache, url string) string {
+		var body string
+		r := New(WithCache(c)).Then(func(req *http.Request, resp *http.Response, respBody []byte, cf context.CancelFunc, err error) error {
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			body = string(respBody)
+			return nil
+		})
+		r.DoGet(context.Background(), url)
+		require.Empty(t, r.Wait())
+		return body
+	}
+
+	t.Run("Test_Fresh_Response_Served_From_Cache", func(t *testing.T) {
+		atomic.StoreInt32(&hits, 0)
+		c := NewMemoryCache(time.Minute)
+		require.Equal(t, `{"max_mint":"1"}`, get(c, server.URL+"/config"))
+		require.Equal(t, `{"max_mint":"1"}`, get(c, server.URL+"/config"))
+		require.EqualValues(t, 1, atomic.LoadInt32(&hits))
+
+		get(c, server.URL+"/config?key=other")
+		require.EqualValues(t, 2, atomic.LoadInt32(&hits), "keyed by URL")
+	})
+
+	t.Run("Test_Stale_Response_Revalidated", func(t *testing.T) {
+		atomic.StoreInt32(&hits, 0)
+		atomic.StoreInt32(&notModified, 0)
+		c := NewMemoryCache(time.Nanosecond)
+		get(c, server.URL+"/config")
+		time.Sleep(time.Millisecond)
+		require.Equal(t, `{"max_mint":"1"}`, get(c, server.URL+"/config"))
+		require.EqualValues(t, 2, atomic.LoadInt32(&hits))
+		require.EqualValues(t, 1, atomic.LoadInt32(&notModified))
+	})
+
+	t.Run("Test_No_Store", func(t *testing.T) {
+		atomic.StoreInt32(&hits, 0)
+		c := NewMemoryCache(time.Minute)
+		get(c, server.URL+"/nostore")
+		get(c, server.URL+"/nostore")
+		require.EqualValues(t, 2, atomic.LoadInt32(&hits))
+	})
+
+	t.Run("Test_Not_Cached_Without_Cache", func(t *testing.T) {
+		atomic.StoreInt32(&hits, 0)
+		get(nil, server.URL+"/config")
+		get(nil, server.URL+"/config")
+		require.EqualValues(t, 2, atomic.LoadInt32(&hits))
+	})
+}
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCacheSize(time.Minute, 2)
+	c.Set("a", &CachedResponse{ETag: "1", StoredAt: time.Now()})
+	c.Set("b", &CachedResponse{ETag: "2", StoredAt: time.Now()})
+	_, fresh := c.Get("a")
+	require.True(t, fresh)
+
+	// b is the least recently used
+	c.Set("c", &CachedResponse{ETag: "3", StoredAt: time.Now()})
+	resp, _ := c.Get("b")
+	require.Nil(t, resp, "evicted past the size")
+	resp, _ = c.Get("a")
+	require.NotNil(t, resp)
+
+	// stale responses are kept to be revalidated with their ETag only
+	c.Set("stale", &CachedResponse{StoredAt: time.Now().Add(-time.Hour)})
+	resp, _ = c.Get("stale")
+	require.Nil(t, resp)
+	c.Set("stale", &CachedResponse{ETag: "4", StoredAt: time.Now().Add(-time.Hour)})
+	resp, fresh = c.Get("stale")
+	require.NotNil(t, resp)
+	require.False(t, fresh)
+}
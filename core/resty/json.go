@@ -0,0 +1,84 @@
+package resty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// StatusError is the error of a response with a status other than 2xx.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	// Code and Message are the ones of an error payload
+	// {"code": ..., "error": ...}, Message being the body otherwise
+	Code    string
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("%s: %d %s: %s", e.URL, e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("%s: %d %s", e.URL, e.StatusCode, e.Message)
+}
+
+func newStatusError(resp *http.Response, respBody []byte) *StatusError {
+	e := &StatusError{URL: responseURL(resp), StatusCode: resp.StatusCode}
+	var payload struct {
+		Code    string `json:"code"`
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(respBody, &payload) == nil && (payload.Error != "" || payload.Message != "") {
+		e.Code = payload.Code
+		e.Message = payload.Error
+		if e.Message == "" {
+			e.Message = payload.Message
+		}
+		return e
+	}
+	e.Message = strings.TrimSpace(string(respBody))
+	return e
+}
+
+func responseURL(resp *http.Response) string {
+	if resp.Request == nil {
+		return ""
+	}
+	return resp.Request.URL.String()
+}
+
+// DecodeJSON decodes the body of a 2xx response into v, the error of any
+// other response being a *StatusError.
+func DecodeJSON(resp *http.Response, respBody []byte, v interface{}) error {
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newStatusError(resp, respBody)
+	}
+	if err := json.Unmarshal(respBody, v); err != nil {
+		return fmt.Errorf("%s: decode response: %w", responseURL(resp), err)
+	}
+	return nil
+}
+
+// ThenJSON is Then decoding the responses with DecodeJSON into a value
+// returned by target for each of them, passed to fn with the URL and status
+// of the response. The errors of the requests, of the responses and of fn
+// are those of Wait or First.
+func (r *Resty) ThenJSON(target func() interface{}, fn func(url string, v interface{}, status int) error) *Resty {
+	return r.Then(func(req *http.Request, resp *http.Response, respBody []byte, cf context.CancelFunc, err error) error {
+		if err != nil {
+			return err
+		}
+		if resp.Request == nil {
+			resp.Request = req
+		}
+		v := target()
+		if err := DecodeJSON(resp, respBody, v); err != nil {
+			return err
+		}
+		return fn(req.URL.String(), v, resp.StatusCode)
+	})
+}
@@ -0,0 +1,92 @@
+package resty
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResty_ThenJSON(t *testing.T) {
+	type config struct {
+		MaxMint string `json:"max_mint"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/ok":
+			w.Write([]byte(`{"max_mint":"1"}`)) //nolint: errcheck
+		case "/payload":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":"invalid_request","error":"missing key"}`)) //nolint: errcheck
+		case "/text":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("not found\n")) //nolint: errcheck
+		default:
+			w.Write([]byte(`not json`)) //nolint: errcheck
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr *StatusError
+	}{
+		{name: "Test_Decoded", path: "/ok", want: "1"},
+		{name: "Test_Error_Payload", path: "/payload", wantErr: &StatusError{
+			URL: server.URL + "/payload", StatusCode: http.StatusBadRequest, Code: "invalid_request", Message: "missing key"}},
+		{name: "Test_Error_Body", path: "/text", wantErr: &StatusError{
+			URL: server.URL + "/text", StatusCode: http.StatusNotFound, Message: "not found"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			r := New(WithRetry(1)).ThenJSON(func() interface{} { return &config{} }, func(url string, v interface{}, status int) error {
+				require.Equal(t, server.URL+tt.path, url)
+				require.Equal(t, http.StatusOK, status)
+				got = v.(*config).MaxMint
+				return nil
+			})
+			errs := r.DoGet(context.Background(), server.URL+tt.path).Wait()
+			if tt.wantErr != nil {
+				require.Len(t, errs, 1)
+				var se *StatusError
+				require.True(t, errors.As(errs[0], &se))
+				require.Equal(t, tt.wantErr, se)
+				return
+			}
+			require.Empty(t, errs)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("Test_Decode_Error", func(t *testing.T) {
+		errs := New().ThenJSON(func() interface{} { return &config{} }, func(url string, v interface{}, status int) error {
+			return nil
+		}).DoGet(context.Background(), server.URL+"/bad").Wait()
+		require.Len(t, errs, 1)
+		require.Contains(t, errs[0].Error(), "decode response")
+	})
+
+	t.Run("Test_Values_Per_Response", func(t *testing.T) {
+		var (
+			mu   sync.Mutex
+			seen []*config
+		)
+		errs := New().ThenJSON(func() interface{} { return &config{} }, func(url string, v interface{}, status int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			seen = append(seen, v.(*config))
+			return nil
+		}).DoGet(context.Background(), server.URL+"/ok", server.URL+"/ok?n=2").Wait()
+		require.Empty(t, errs)
+		require.Len(t, seen, 2)
+		require.NotSame(t, seen[0], seen[1])
+	})
+}
@@ -0,0 +1,66 @@
+package resty
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// HostLimiter limits the requests in flight per host, across the Resty
+// instances it is set to with WithHostLimiter.
+type HostLimiter struct {
+	n     int
+	mu    sync.Mutex
+	hosts map[string]chan struct{}
+}
+
+// NewHostLimiter returns a HostLimiter letting n requests per host in
+// flight, without limit if n is less than 1.
+func NewHostLimiter(n int) *HostLimiter {
+	return &HostLimiter{n: n, hosts: make(map[string]chan struct{})}
+}
+
+func (l *HostLimiter) slots(host string) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s, ok := l.hosts[host]
+	if !ok {
+		s = make(chan struct{}, l.n)
+		l.hosts[host] = s
+	}
+	return s
+}
+
+// acquire waits for a request to req's host to be let in flight, release
+// must be called once it is done.
+func (l *HostLimiter) acquire(ctx context.Context, req *http.Request) (release func(), err error) {
+	if l == nil || l.n < 1 {
+		return func() {}, nil
+	}
+	s := l.slots(req.URL.Host)
+	select {
+	case s <- struct{}{}:
+		return func() { <-s }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// dispatch sends the requests, up to concurrency of them in flight.
+func (r *Resty) dispatch(reqs []*http.Request) {
+	sem := make(chan struct{}, r.concurrency)
+	for i, req := range reqs {
+		select {
+		case sem <- struct{}{}:
+		case <-r.ctx.Done():
+			for _, req := range reqs[i:] {
+				r.done <- Result{Request: req, Err: r.ctx.Err()}
+			}
+			return
+		}
+		go func(req *http.Request) {
+			defer func() { <-sem }()
+			r.httpDo(req)
+		}(req)
+	}
+}
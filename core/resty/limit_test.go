@@ -0,0 +1,122 @@
+package resty
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// inFlightClient records the maximum of requests in flight, in total and
+// per host.
+type inFlightClient struct {
+	mu       sync.Mutex
+	calls    int
+	total    int
+	maxTotal int
+	hosts    map[string]int
+	maxHosts map[string]int
+}
+
+func (c *inFlightClient) Do(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	c.mu.Lock()
+	c.calls++
+	c.total++
+	c.hosts[host]++
+	if c.total > c.maxTotal {
+		c.maxTotal = c.total
+	}
+	if c.hosts[host] > c.maxHosts[host] {
+		c.maxHosts[host] = c.hosts[host]
+	}
+	c.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	c.mu.Lock()
+	c.total--
+	c.hosts[host]--
+	c.mu.Unlock()
+	return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("ok"))}, nil
+}
+
+func TestResty_Concurrency(t *testing.T) {
+	var urls []string
+	for i := 0; i < 20; i++ {
+		urls = append(urls, "http://sharder"+strconv.Itoa(i%2)+"/v1/block/"+strconv.Itoa(i))
+	}
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		maxTotal   int
+		maxPerHost int
+	}{
+		{name: "Test_Concurrency", opts: []Option{WithConcurrency(3)}, maxTotal: 3, maxPerHost: 3},
+		{name: "Test_Host_Concurrency", opts: []Option{WithHostConcurrency(2)}, maxTotal: 4, maxPerHost: 2},
+		{name: "Test_Both", opts: []Option{WithConcurrency(3), WithHostConcurrency(1)}, maxTotal: 2, maxPerHost: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &inFlightClient{hosts: make(map[string]int), maxHosts: make(map[string]int)}
+			r := New(append(tt.opts, WithClient(c))...)
+
+			var mu sync.Mutex
+			got := 0
+			r.Then(func(req *http.Request, resp *http.Response, respBody []byte, cf context.CancelFunc, err error) error {
+				mu.Lock()
+				defer mu.Unlock()
+				got++
+				return err
+			})
+			r.DoGet(context.Background(), urls...)
+			require.Empty(t, r.Wait())
+
+			require.Equal(t, len(urls), got)
+			require.LessOrEqual(t, c.maxTotal, tt.maxTotal)
+			for host, max := range c.maxHosts {
+				require.LessOrEqual(t, max, tt.maxPerHost, host)
+			}
+		})
+	}
+
+	t.Run("Test_Shared_Host_Limiter", func(t *testing.T) {
+		c := &inFlightClient{hosts: make(map[string]int), maxHosts: make(map[string]int)}
+		l := NewHostLimiter(1)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r := New(WithClient(c), WithHostLimiter(l))
+				r.DoGet(context.Background(), urls[:4]...)
+				require.Empty(t, r.Wait())
+			}()
+		}
+		wg.Wait()
+		for host, max := range c.maxHosts {
+			require.Equal(t, 1, max, host)
+		}
+	})
+
+	t.Run("Test_Cancelled_While_Waiting", func(t *testing.T) {
+		c := &inFlightClient{hosts: make(map[string]int), maxHosts: make(map[string]int)}
+		ctx, cancel := context.WithTimeout(context.Background(), 7*time.Millisecond)
+		defer cancel()
+
+		r := New(WithClient(c), WithConcurrency(1))
+		r.DoGet(ctx, urls...)
+		r.Wait()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		require.Less(t, c.calls, len(urls), "the waiting requests aren't sent")
+	})
+}
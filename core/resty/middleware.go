@@ -0,0 +1,148 @@
+package resty
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/0chain/gosdk/core/netconf"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/core/telemetry"
+)
+
+// Doer sends a request and returns its response.
+type Doer func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the Doer sending the requests of a Resty: it can mutate
+// the requests, inspect the responses, retry the requests or record metrics.
+type Middleware func(next Doer) Doer
+
+// Use appends mw to the middlewares of r, see WithMiddleware.
+func (r *Resty) Use(mw ...Middleware) *Resty {
+	if r == nil {
+		return r
+	}
+	r.middlewares = append(r.middlewares, mw...)
+	return r
+}
+
+// doer chains the middlewares of r. Retry is the outermost middleware, then
+// come the ones of Use in their order, then RateLimit for every attempt.
+func (r *Resty) doer() Doer {
+	mws := make([]Middleware, 0, len(r.middlewares)+2)
+	mws = append(mws, Retry(r.retry))
+	mws = append(mws, r.middlewares...)
+	mws = append(mws, RateLimit())
+
+	do := Doer(r.client.Do)
+	for i := len(mws) - 1; i >= 0; i-- {
+		do = mws[i](do)
+	}
+	return do
+}
+
+// RequestInterceptor calls fn on the requests before sending them, e.g. to
+// sign them. The request fails with the error of fn.
+func RequestInterceptor(fn func(req *http.Request) error) Middleware {
+	return func(next Doer) Doer {
+		return func(req *http.Request) (*http.Response, error) {
+			if err := fn(req); err != nil {
+				return nil, err
+			}
+			return next(req)
+		}
+	}
+}
+
+// RateLimit waits for the rate limit of the host of the requests set with
+// netconf.SetRateLimit.
+func RateLimit() Middleware {
+	return func(next Doer) Doer {
+		return func(req *http.Request) (*http.Response, error) {
+			if err := netconf.WaitRateLimit(req.Context(), req.URL.String()); err != nil {
+				return nil, err
+			}
+			return next(req)
+		}
+	}
+}
+
+// Trace records a span and the latency of the requests as op with
+// telemetry, once enabled.
+func Trace(op string) Middleware {
+	return func(next Doer) Doer {
+		return func(req *http.Request) (resp *http.Response, err error) {
+			ctx, span := telemetry.StartSpan(req.Context(), op,
+				telemetry.String(telemetry.AttrNode, req.URL.Host),
+				telemetry.String(telemetry.AttrMethod, req.Method))
+			start := time.Now()
+			defer func() {
+				if resp != nil {
+					span.SetAttributes(telemetry.Int(telemetry.AttrStatus, resp.StatusCode))
+				}
+				telemetry.RecordRequest(ctx, req.URL.Host, op, time.Since(start), err)
+				telemetry.EndSpan(span, err)
+			}()
+			return next(req.WithContext(ctx))
+		}
+	}
+}
+
+// isSuccess tells if resp is a success: 200, 201, 202, 204, or 304 of a
+// revalidated cached response.
+func isSuccess(req *http.Request, resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent:
+		return true
+	case http.StatusNotModified:
+		return req.Header.Get("If-None-Match") != ""
+	}
+	return false
+}
+
+// Retry sends the requests up to retry times until they succeed, waiting a
+// second after a 429 response. The response of the last attempt is
+// returned.
+func Retry(retry int) Middleware {
+	return func(next Doer) Doer {
+		if retry < 1 {
+			return next
+		}
+		return func(request *http.Request) (resp *http.Response, err error) {
+			for i := 1; ; i++ {
+				var bodyCopy io.ReadCloser
+				if (request.Method == http.MethodPost || request.Method == http.MethodPut) && request.Body != nil {
+					// clone io.ReadCloser to fix retry issue https://github.com/golang/go/issues/36095
+					bodyCopy, _ = request.GetBody() //nolint: errcheck
+				}
+
+				resp, err = next(request)
+				if resp != nil && isSuccess(request, resp) {
+					return resp, err
+				}
+				if request.Context().Err() != nil {
+					return resp, err
+				}
+				// close body ReadClose to release resource before retrying it
+				if resp != nil && resp.Body != nil {
+					// don't close it if it is latest retry
+					if i < retry {
+						resp.Body.Close()
+					}
+				}
+
+				if i == retry {
+					return resp, err
+				}
+
+				if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+					sys.SleepContext(request.Context(), 1*time.Second) //nolint: errcheck
+				}
+
+				if (request.Method == http.MethodPost || request.Method == http.MethodPut) && request.Body != nil {
+					request.Body = bodyCopy
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package resty
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// statusClient responds with the statuses in turn, the last one afterwards.
+type statusClient struct {
+	mu       sync.Mutex
+	statuses []int
+	calls    int
+}
+
+func (c *statusClient) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	status := c.statuses[len(c.statuses)-1]
+	if c.calls < len(c.statuses) {
+		status = c.statuses[c.calls]
+	}
+	c.calls++
+	return &http.Response{StatusCode: status, Body: ioutil.NopCloser(strings.NewReader("")), Request: req}, nil
+}
+
+func TestResty_Middleware(t *testing.T) {
+	t.Run("Test_Order", func(t *testing.T) {
+		var order []string
+		record := func(name string) Middleware {
+			return func(next Doer) Doer {
+				return func(req *http.Request) (*http.Response, error) {
+					order = append(order, name+">")
+					resp, err := next(req)
+					order = append(order, "<"+name)
+					return resp, err
+				}
+			}
+		}
+		c := &statusClient{statuses: []int{http.StatusOK}}
+		r := New(WithClient(c), WithMiddleware(record("a"))).Use(record("b"))
+		require.Empty(t, r.DoGet(context.Background(), "http://sharder0/v1").Wait())
+		require.Equal(t, []string{"a>", "b>", "<b", "<a"}, order)
+	})
+
+	t.Run("Test_Called_For_Every_Attempt", func(t *testing.T) {
+		var statuses []int
+		c := &statusClient{statuses: []int{http.StatusInternalServerError, http.StatusOK}}
+		r := New(WithClient(c), WithRetry(3)).Use(func(next Doer) Doer {
+			return func(req *http.Request) (*http.Response, error) {
+				req.Header.Set("X-Attempt", "1")
+				resp, err := next(req)
+				statuses = append(statuses, resp.StatusCode)
+				return resp, err
+			}
+		})
+		require.Empty(t, r.DoGet(context.Background(), "http://sharder0/v1").Wait())
+		require.Equal(t, []int{http.StatusInternalServerError, http.StatusOK}, statuses)
+	})
+
+	t.Run("Test_Retry_Decision", func(t *testing.T) {
+		// a 404 of a sharder not synced yet is retried once more
+		retryNotFound := func(next Doer) Doer {
+			return func(req *http.Request) (*http.Response, error) {
+				resp, err := next(req)
+				if err == nil && resp.StatusCode == http.StatusNotFound {
+					resp.Body.Close()
+					return next(req)
+				}
+				return resp, err
+			}
+		}
+		c := &statusClient{statuses: []int{http.StatusNotFound, http.StatusOK}}
+		var status int
+		r := New(WithClient(c), WithRetry(1), WithMiddleware(retryNotFound)).
+			Then(func(req *http.Request, resp *http.Response, respBody []byte, cf context.CancelFunc, err error) error {
+				status = resp.StatusCode
+				return err
+			})
+		require.Empty(t, r.DoGet(context.Background(), "http://sharder0/v1").Wait())
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, 2, c.calls)
+	})
+
+	t.Run("Test_Interceptor_Error", func(t *testing.T) {
+		c := &statusClient{statuses: []int{http.StatusOK}}
+		errSign := errors.New("no wallet")
+		r := New(WithClient(c), WithRetry(1), WithRequestInterceptor(func(req *http.Request) error {
+			return errSign
+		}))
+		errs := r.DoGet(context.Background(), "http://sharder0/v1").Wait()
+		require.Len(t, errs, 1)
+		require.ErrorIs(t, errs[0], errSign)
+		require.Zero(t, c.calls)
+	})
+
+	t.Run("Test_Trace", func(t *testing.T) {
+		c := &statusClient{statuses: []int{http.StatusOK}}
+		r := New(WithClient(c), WithMiddleware(Trace("sharder.query")))
+		require.Empty(t, r.DoGet(context.Background(), "http://sharder0/v1").Wait())
+	})
+}
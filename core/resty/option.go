@@ -36,10 +36,17 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
-// WithRequestInterceptor intercept request
+// WithRequestInterceptor intercept request, see RequestInterceptor
 func WithRequestInterceptor(interceptor func(req *http.Request) error) Option {
+	return WithMiddleware(RequestInterceptor(interceptor))
+}
+
+// WithMiddleware appends mw to the middlewares wrapping the sending of the
+// requests, the first one being the outermost. They are called for every
+// attempt of a request, inside the retries.
+func WithMiddleware(mw ...Middleware) Option {
 	return func(r *Resty) {
-		r.requestInterceptor = interceptor
+		r.middlewares = append(r.middlewares, mw...)
 	}
 }
 
@@ -50,6 +57,38 @@ func WithTransport(transport *http.Transport) Option {
 	}
 }
 
+// WithConcurrency limits the requests of a call in flight to n, the others
+// waiting for their turn. No limit if n is less than 1.
+func WithConcurrency(n int) Option {
+	return func(r *Resty) {
+		if n > 0 {
+			r.concurrency = n
+		}
+	}
+}
+
+// WithHostConcurrency limits the requests in flight per host to n, not to
+// trip the rate limits of the blobbers and sharders.
+func WithHostConcurrency(n int) Option {
+	return WithHostLimiter(NewHostLimiter(n))
+}
+
+// WithHostLimiter limits the requests in flight per host with l, shared
+// by the Resty instances fanning out to the same hosts.
+func WithHostLimiter(l *HostLimiter) Option {
+	return func(r *Resty) {
+		r.hostLimiter = l
+	}
+}
+
+// WithCache caches the responses of the GET requests in c, e.g. of the
+// smart contract configs, see NewMemoryCache.
+func WithCache(c Cache) Option {
+	return func(r *Resty) {
+		r.cache = c
+	}
+}
+
 // WithClient set client
 func WithClient(c Client) Option {
 	return func(r *Resty) {
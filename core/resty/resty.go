@@ -2,6 +2,7 @@
 package resty
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"io/ioutil"
@@ -10,7 +11,7 @@ import (
 	"sync"
 	"time"
 
-	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/core/netconf"
 )
 
 // New create a Resty instance.
@@ -24,15 +25,19 @@ func New(opts ...Option) *Resty {
 	for _, option := range opts {
 		option(r)
 	}
+	if r.hostLimiter == nil {
+		r.hostLimiter = DefaultHostLimiter
+	}
 
 	if r.transport == nil {
 		if DefaultTransport == nil {
-			DefaultTransport = &http.Transport{
-				Dial: (&net.Dialer{
+			DefaultTransport = netconf.RegisterTransport(&http.Transport{
+				Proxy: netconf.Proxy(http.ProxyFromEnvironment),
+				DialContext: netconf.DialContext(&net.Dialer{
 					Timeout: DefaultDialTimeout,
-				}).Dial,
+				}),
 				TLSHandshakeTimeout: DefaultDialTimeout,
-			}
+			})
 		}
 		r.transport = DefaultTransport
 	}
@@ -62,14 +67,19 @@ type Resty struct {
 	qty        int
 	done       chan Result
 
-	transport          *http.Transport
-	client             Client
-	handle             Handle
-	requestInterceptor func(req *http.Request) error
+	transport   *http.Transport
+	client      Client
+	handle      Handle
+	middlewares []Middleware
 
 	timeout time.Duration
 	retry   int
 	header  map[string]string
+
+	// concurrency is the number of requests of Do in flight, no limit if 0
+	concurrency int
+	hostLimiter *HostLimiter
+	cache       Cache
 }
 
 // Then callback for http response
@@ -107,10 +117,26 @@ func (r *Resty) Do(ctx context.Context, method string, body io.Reader, urls ...s
 	r.qty = len(urls)
 	r.done = make(chan Result, r.qty)
 
-	var bodyReader io.Reader = body
+	// the body is buffered, each request reads its own copy of it and can
+	// be retried, see Retry
+	var buf []byte
+	if body != nil {
+		var err error
+		if buf, err = ioutil.ReadAll(body); err != nil {
+			for range urls {
+				r.done <- Result{Err: err}
+			}
+			return r
+		}
+	}
 
+	reqs := make([]*http.Request, 0, len(urls))
 	for _, url := range urls {
 
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(buf)
+		}
 		req, err := http.NewRequest(method, url, bodyReader)
 		if err != nil {
 			r.done <- Result{Request: req, Response: nil, Err: err}
@@ -124,67 +150,38 @@ func (r *Resty) Do(ctx context.Context, method string, body io.Reader, urls ...s
 		//reuse http connection if it is possible
 		req.Header.Set("Connection", "keep-alive")
 
-		if r.requestInterceptor != nil {
-			if err := r.requestInterceptor(req); err != nil {
-				r.done <- Result{Request: req, Response: nil, Err: err}
-				continue
-			}
-		}
-
-		go r.httpDo(req.WithContext(r.ctx))
+		reqs = append(reqs, req.WithContext(r.ctx))
 	}
 
+	if r.concurrency > 0 {
+		go r.dispatch(reqs)
+		return r
+	}
+	for _, req := range reqs {
+		go r.httpDo(req)
+	}
 	return r
 }
 
 func (r *Resty) httpDo(req *http.Request) {
+	cached, fresh := r.fromCache(req)
+	if fresh {
+		r.done <- cachedResult(req, cached)
+		return
+	}
+
+	release, err := r.hostLimiter.acquire(r.ctx, req)
+	if err != nil {
+		r.done <- Result{Request: req, Err: err}
+		return
+	}
+	defer release()
+
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
 
 	go func(request *http.Request) {
-		var resp *http.Response
-		var err error
-
-		if r.retry > 0 {
-
-			for i := 1; ; i++ {
-				var bodyCopy io.ReadCloser
-				if (request.Method == http.MethodPost || request.Method == http.MethodPut) && request.Body != nil {
-					// clone io.ReadCloser to fix retry issue https://github.com/golang/go/issues/36095
-					bodyCopy, _ = request.GetBody() //nolint: errcheck
-				}
-
-				resp, err = r.client.Do(request)
-				//success: 200,201,202,204
-				if resp != nil && (resp.StatusCode == http.StatusOK ||
-					resp.StatusCode == http.StatusCreated ||
-					resp.StatusCode == http.StatusAccepted ||
-					resp.StatusCode == http.StatusNoContent) {
-					break
-				}
-				// close body ReadClose to release resource before retrying it
-				if resp != nil && resp.Body != nil {
-					// don't close it if it is latest retry
-					if i < r.retry {
-						resp.Body.Close()
-					}
-				}
-
-				if i == r.retry {
-					break
-				}
-
-				if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
-					sys.Sleep(1 * time.Second)
-				}
-
-				if (request.Method == http.MethodPost || request.Method == http.MethodPut) && request.Body != nil {
-					request.Body = bodyCopy
-				}
-			}
-		} else {
-			resp, err = r.client.Do(request.WithContext(r.ctx))
-		}
+		resp, err := r.doer()(request)
 
 		result := Result{Request: request, Response: resp, Err: err}
 		if resp != nil {
@@ -197,6 +194,7 @@ func (r *Resty) httpDo(req *http.Request) {
 				result.ResponseBody = buf
 			}
 		}
+		r.toCache(cached, &result)
 		r.done <- result
 
 		wg.Done()
@@ -4,7 +4,10 @@ import (
 	"context"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -113,3 +116,32 @@ func TestResty(t *testing.T) {
 		})
 	}
 }
+
+func TestResty_DoPostBodyPerRequest(t *testing.T) {
+	const body = `{"hash":"txn","value":10}`
+
+	var (
+		mu     sync.Mutex
+		bodies []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		b, _ := ioutil.ReadAll(req.Body) //nolint: errcheck
+		mu.Lock()
+		bodies = append(bodies, string(b))
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	urls := make([]string, 0, 5)
+	for i := 0; i < cap(urls); i++ {
+		urls = append(urls, server.URL+"/"+strconv.Itoa(i))
+	}
+	errs := New().DoPost(context.Background(), strings.NewReader(body), urls...).Wait()
+	require.Empty(t, errs)
+
+	// every request gets the whole body, not a part of a shared reader
+	require.Len(t, bodies, len(urls))
+	for _, b := range bodies {
+		require.Equal(t, body, b)
+	}
+}
@@ -7,11 +7,13 @@ import (
 	"net"
 	"net/http"
 	"time"
+
+	"github.com/0chain/gosdk/core/netconf"
 )
 
 // Run the HTTP request in a goroutine and pass the response to f.
-var DefaultTransport = &http.Transport{
-	Proxy:                 http.ProxyFromEnvironment,
+var DefaultTransport = netconf.RegisterTransport(&http.Transport{
+	Proxy:                 netconf.Proxy(http.ProxyFromEnvironment),
 	MaxIdleConns:          1000,
 	IdleConnTimeout:       90 * time.Second,
 	TLSHandshakeTimeout:   10 * time.Second,
@@ -19,9 +21,9 @@ var DefaultTransport = &http.Transport{
 	MaxIdleConnsPerHost:   5,
 	ForceAttemptHTTP2:     true,
 
-	DialContext: (&net.Dialer{
+	DialContext: netconf.DialContext(&net.Dialer{
 		Timeout:   30 * time.Second,
 		KeepAlive: 30 * time.Second,
 		DualStack: true,
-	}).DialContext,
-}
+	}),
+})
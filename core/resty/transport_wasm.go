@@ -6,15 +6,17 @@ package resty
 import (
 	"net/http"
 	"time"
+
+	"github.com/0chain/gosdk/core/netconf"
 )
 
 // Run the HTTP request in a goroutine and pass the response to f.
-var DefaultTransport = &http.Transport{
-	Proxy:                 http.ProxyFromEnvironment,
+var DefaultTransport = netconf.RegisterTransport(&http.Transport{
+	Proxy:                 netconf.Proxy(http.ProxyFromEnvironment),
 	MaxIdleConns:          1000,
 	IdleConnTimeout:       90 * time.Second,
 	TLSHandshakeTimeout:   10 * time.Second,
 	ExpectContinueTimeout: 1 * time.Second,
 	MaxIdleConnsPerHost:   5,
 	ForceAttemptHTTP2:     true,
-}
+})
@@ -11,4 +11,8 @@ var (
 	DefaultRequestTimeout = 10 * time.Second
 	// DefaultRetry retry times if a request is failed with 5xx status code
 	DefaultRetry = 3
+	// DefaultHostLimiter limits the requests per host of the Resty instances
+	// without WithHostLimiter, e.g. of the fan-outs to the sharders of the
+	// sdk. No limit if nil; to be set before the sdk is used.
+	DefaultHostLimiter *HostLimiter
 )
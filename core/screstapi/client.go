@@ -0,0 +1,99 @@
+package screstapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Smart contract addresses
+const (
+	StorageAddress = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7"
+	MinerAddress   = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9"
+	FaucetAddress  = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d3"
+	VestingAddress = "2bba5b05949ea59c80aed3ac3474d7379d3be737e8eb5a968c52295e48333ead"
+	ZCNAddress     = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e0"
+)
+
+// Querier sends a GET of path, query string included, to the sharders and
+// returns the body of the response, e.g. zcncore.SCRestQuerier which goes
+// through the shared transport and returns the response the sharder quorum
+// agrees on.
+type Querier interface {
+	Query(ctx context.Context, path string) ([]byte, error)
+}
+
+// QuerierFunc is a function implementing Querier.
+type QuerierFunc func(ctx context.Context, path string) ([]byte, error)
+
+// Query implements Querier.
+func (f QuerierFunc) Query(ctx context.Context, path string) ([]byte, error) {
+	return f(ctx, path)
+}
+
+// Get queries relativePath of the smart contract at address with q and
+// returns the body of the response.
+func Get(ctx context.Context, q Querier, address, relativePath string, params url.Values) ([]byte, error) {
+	path := "/v1/screst/" + address + relativePath
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	return q.Query(ctx, path)
+}
+
+// client is embedded in the smart contract clients.
+type client struct {
+	address string
+	querier Querier
+}
+
+// get queries relativePath of the smart contract and decodes the response into out.
+func (c *client) get(ctx context.Context, relativePath string, params url.Values, out interface{}) error {
+	body, err := Get(ctx, c.querier, c.address, relativePath, params)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("screstapi: decoding %s: %w", relativePath, err)
+	}
+	return nil
+}
+
+type (
+	// StorageClient queries the storage smart contract
+	StorageClient struct{ client }
+	// MinerClient queries the miner smart contract
+	MinerClient struct{ client }
+	// FaucetClient queries the faucet smart contract
+	FaucetClient struct{ client }
+	// VestingClient queries the vesting smart contract
+	VestingClient struct{ client }
+	// ZCNClient queries the zcn (bridge) smart contract
+	ZCNClient struct{ client }
+)
+
+// NewStorageClient creates a client of the storage smart contract.
+func NewStorageClient(q Querier) *StorageClient {
+	return &StorageClient{client{address: StorageAddress, querier: q}}
+}
+
+// NewMinerClient creates a client of the miner smart contract.
+func NewMinerClient(q Querier) *MinerClient {
+	return &MinerClient{client{address: MinerAddress, querier: q}}
+}
+
+// NewFaucetClient creates a client of the faucet smart contract.
+func NewFaucetClient(q Querier) *FaucetClient {
+	return &FaucetClient{client{address: FaucetAddress, querier: q}}
+}
+
+// NewVestingClient creates a client of the vesting smart contract.
+func NewVestingClient(q Querier) *VestingClient {
+	return &VestingClient{client{address: VestingAddress, querier: q}}
+}
+
+// NewZCNClient creates a client of the zcn smart contract.
+func NewZCNClient(q Querier) *ZCNClient {
+	return &ZCNClient{client{address: ZCNAddress, querier: q}}
+}
@@ -0,0 +1,10 @@
+// Package screstapi is a typed client of the smart contracts REST endpoints
+// served by sharders under /v1/screst/<smart contract address>.
+//
+// There is one client per smart contract. Their methods, and the request
+// structs of the endpoints taking query parameters, are generated from
+// endpoints.json; response types are declared in types.go. Every query is
+// sent to the sharders by a Querier, e.g. zcncore.SCRestQuerier.
+package screstapi
+
+//go:generate go run ./gen -spec endpoints.json -out endpoints_gen.go
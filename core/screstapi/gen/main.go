@@ -0,0 +1,175 @@
+// Command gen generates the typed endpoints of the screstapi clients from
+// their JSON spec.
+//
+//	go run ./gen -spec endpoints.json -out endpoints_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"strings"
+	"text/template"
+)
+
+type (
+	param struct {
+		Name  string `json:"name"`
+		Field string `json:"field"`
+		Type  string `json:"type"`
+	}
+
+	endpoint struct {
+		Method   string  `json:"method"`
+		Path     string  `json:"path"`
+		Response string  `json:"response"`
+		Doc      string  `json:"doc"`
+		Params   []param `json:"params"`
+	}
+
+	client struct {
+		Client    string     `json:"client"`
+		Endpoints []endpoint `json:"endpoints"`
+	}
+
+	spec struct {
+		Clients []client `json:"clients"`
+	}
+)
+
+// Request is the name of the request struct of the endpoint.
+func (e endpoint) Request(c client) string {
+	return strings.TrimSuffix(c.Client, "Client") + strings.TrimPrefix(e.Method, "Get") + "Request"
+}
+
+// Encode returns the expression formatting the param as a query value.
+func (p param) Encode() string {
+	switch p.Type {
+	case "int":
+		return "strconv.FormatInt(r." + p.Field + ", 10)"
+	case "bool":
+		return "strconv.FormatBool(r." + p.Field + ")"
+	}
+	return "r." + p.Field
+}
+
+// GoType returns the type of the field of the param.
+func (p param) GoType() string {
+	if p.Type == "int" {
+		return "int64"
+	}
+	return p.Type
+}
+
+// IsSet returns the condition of the param being set, unset params are
+// not sent.
+func (p param) IsSet() string {
+	switch p.Type {
+	case "int":
+		return "r." + p.Field + " != 0"
+	case "bool":
+		return "r." + p.Field
+	}
+	return `r.` + p.Field + ` != ""`
+}
+
+var tmpl = template.Must(template.New("gen").Parse(`// Code generated by screstapi/gen. DO NOT EDIT.
+
+package screstapi
+
+import (
+	"context"
+	"net/url"
+{{- if .HasStrconv}}
+	"strconv"
+{{- end}}
+)
+{{range $c := .Clients}}{{range $e := $c.Endpoints}}{{if $e.Params}}
+// {{$e.Request $c}} are the query parameters of {{$c.Client}}.{{$e.Method}}.
+type {{$e.Request $c}} struct {
+{{- range $e.Params}}
+	{{.Field}} {{.GoType}}
+{{- end}}
+}
+
+func (r *{{$e.Request $c}}) params() url.Values {
+	params := make(url.Values)
+{{- range $e.Params}}
+	if {{.IsSet}} {
+		params.Set("{{.Name}}", {{.Encode}})
+	}
+{{- end}}
+	return params
+}
+
+// {{$e.Method}} {{$e.Doc}}.
+func (c *{{$c.Client}}) {{$e.Method}}(ctx context.Context, r *{{$e.Request $c}}) (*{{$e.Response}}, error) {
+	out := new({{$e.Response}})
+	if err := c.get(ctx, "{{$e.Path}}", r.params(), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+{{else}}
+// {{$e.Method}} {{$e.Doc}}.
+func (c *{{$c.Client}}) {{$e.Method}}(ctx context.Context) (*{{$e.Response}}, error) {
+	out := new({{$e.Response}})
+	if err := c.get(ctx, "{{$e.Path}}", nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+{{end}}{{end}}{{end}}`))
+
+func main() {
+	specPath := flag.String("spec", "endpoints.json", "endpoints spec")
+	out := flag.String("out", "endpoints_gen.go", "generated file")
+	flag.Parse()
+
+	data, err := ioutil.ReadFile(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		log.Fatal(err)
+	}
+
+	hasStrconv := false
+	for _, c := range s.Clients {
+		for _, e := range c.Endpoints {
+			if e.Method == "" || e.Path == "" || e.Response == "" {
+				log.Fatalf("%s: endpoint %q: method, path and response are required", c.Client, e.Path)
+			}
+			for _, p := range e.Params {
+				switch p.Type {
+				case "int", "bool":
+					hasStrconv = true
+				case "string":
+				default:
+					log.Fatalf("%s.%s: param %s: unsupported type %q", c.Client, e.Method, p.Name, p.Type)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, struct {
+		spec
+		HasStrconv bool
+	}{s, hasStrconv})
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(fmt.Errorf("formatting generated code: %w", err))
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
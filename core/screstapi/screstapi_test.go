@@ -0,0 +1,66 @@
+package screstapi
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   url.Values
+		wantPath string
+	}{
+		{
+			name:     "Test_No_Params",
+			wantPath: "/v1/screst/" + MinerAddress + "/getNodepool",
+		},
+		{
+			name:     "Test_Params",
+			params:   url.Values{"id": {"n1"}},
+			wantPath: "/v1/screst/" + MinerAddress + "/getNodepool?id=n1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			q := QuerierFunc(func(_ context.Context, path string) ([]byte, error) {
+				gotPath = path
+				return []byte(`{}`), nil
+			})
+
+			got, err := Get(context.Background(), q, MinerAddress, "/getNodepool", tt.params)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantPath, gotPath)
+			require.Equal(t, `{}`, string(got))
+		})
+	}
+}
+
+func TestTypedClient(t *testing.T) {
+	var gotPath string
+	sc := NewStorageClient(QuerierFunc(func(_ context.Context, path string) ([]byte, error) {
+		gotPath = path
+		return []byte(`{"challenges":[{"id":"c1","blobber_id":"b1"}]}`), nil
+	}))
+
+	got, err := sc.GetOpenChallenges(context.Background(), &StorageOpenChallengesRequest{BlobberID: "b1", Limit: 20})
+	require.NoError(t, err)
+	require.Equal(t, "/v1/screst/"+StorageAddress+"/openchallenges?blobber=b1&limit=20", gotPath)
+	require.Len(t, got.Challenges, 1)
+	require.Equal(t, "c1", got.Challenges[0].ID)
+}
+
+func TestTypedClient_error(t *testing.T) {
+	failed := errors.New("no quorum")
+	sc := NewStorageClient(QuerierFunc(func(context.Context, string) ([]byte, error) {
+		return nil, failed
+	}))
+
+	_, err := sc.GetConfig(context.Background())
+	require.ErrorIs(t, err, failed)
+}
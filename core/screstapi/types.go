@@ -0,0 +1,170 @@
+package screstapi
+
+import "encoding/json"
+
+// Config is the configuration of a smart contract, as flat key/values.
+type Config struct {
+	Fields map[string]string `json:"fields"`
+}
+
+// Terms of a blobber, prices are per GB and time unit.
+type Terms struct {
+	ReadPrice     int64   `json:"read_price"`
+	WritePrice    int64   `json:"write_price"`
+	MinLockDemand float64 `json:"min_lock_demand"`
+}
+
+// StakePoolSettings of a provider.
+type StakePoolSettings struct {
+	DelegateWallet string  `json:"delegate_wallet"`
+	MinStake       int64   `json:"min_stake"`
+	MaxStake       int64   `json:"max_stake"`
+	NumDelegates   int     `json:"num_delegates"`
+	ServiceCharge  float64 `json:"service_charge"`
+}
+
+// Blobber registered on the storage smart contract.
+type Blobber struct {
+	ID                string            `json:"id"`
+	URL               string            `json:"url"`
+	Terms             Terms             `json:"terms"`
+	Capacity          int64             `json:"capacity"`
+	Allocated         int64             `json:"allocated"`
+	LastHealthCheck   int64             `json:"last_health_check"`
+	StakePoolSettings StakePoolSettings `json:"stake_pool_settings"`
+	TotalStake        int64             `json:"total_stake"`
+}
+
+// BlobberList is a page of blobbers.
+type BlobberList struct {
+	Nodes []Blobber `json:"Nodes"`
+}
+
+// Allocation as stored by the storage smart contract. Fields not declared
+// here are kept in Raw.
+type Allocation struct {
+	ID           string          `json:"id"`
+	Tx           string          `json:"tx"`
+	DataShards   int             `json:"data_shards"`
+	ParityShards int             `json:"parity_shards"`
+	Size         int64           `json:"size"`
+	Expiration   int64           `json:"expiration_date"`
+	Owner        string          `json:"owner_id"`
+	WritePool    int64           `json:"write_pool"`
+	Finalized    bool            `json:"finalized"`
+	Canceled     bool            `json:"canceled"`
+	Raw          json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON keeps the full document in Raw.
+func (a *Allocation) UnmarshalJSON(data []byte) error {
+	type plain Allocation
+	if err := json.Unmarshal(data, (*plain)(a)); err != nil {
+		return err
+	}
+	a.Raw = append(a.Raw[:0], data...)
+	return nil
+}
+
+// ReadPool of a client.
+type ReadPool struct {
+	Balance int64 `json:"balance"`
+}
+
+// DelegatePool is the stake of a delegate in a stake pool.
+type DelegatePool struct {
+	ID         string `json:"id"`
+	Balance    int64  `json:"balance"`
+	DelegateID string `json:"delegate_id"`
+	Rewards    int64  `json:"rewards"`
+	UnStake    bool   `json:"unstake"`
+	Status     string `json:"status"`
+}
+
+// StakePool of a provider.
+type StakePool struct {
+	ID       string            `json:"pool_id"`
+	Balance  int64             `json:"balance"`
+	Delegate []DelegatePool    `json:"delegate"`
+	Rewards  int64             `json:"rewards"`
+	Settings StakePoolSettings `json:"settings"`
+}
+
+// ChallengePool of an allocation.
+type ChallengePool struct {
+	ID         string `json:"id"`
+	Balance    int64  `json:"balance"`
+	StartTime  int64  `json:"start_time"`
+	Expiration int64  `json:"expiration"`
+	Finalized  bool   `json:"finalized"`
+}
+
+// Challenge issued to a blobber.
+type Challenge struct {
+	ID             string `json:"id"`
+	Created        int64  `json:"created"`
+	AllocationID   string `json:"allocation_id"`
+	AllocationRoot string `json:"allocation_root"`
+	BlobberID      string `json:"blobber_id"`
+	Responded      bool   `json:"responded"`
+}
+
+// ChallengeList is a page of challenges.
+type ChallengeList struct {
+	Challenges []Challenge `json:"challenges"`
+}
+
+// Node is a miner or a sharder.
+type Node struct {
+	ID                string            `json:"id"`
+	N2NHost           string            `json:"n2n_host"`
+	Host              string            `json:"host"`
+	Port              int               `json:"port"`
+	PublicKey         string            `json:"public_key"`
+	ShortName         string            `json:"short_name"`
+	BuildTag          string            `json:"build_tag"`
+	TotalStake        int64             `json:"total_stake"`
+	StakePoolSettings StakePoolSettings `json:"settings"`
+}
+
+// NodeList is a page of miners or sharders.
+type NodeList struct {
+	Nodes []Node `json:"Nodes"`
+}
+
+// VestingPool is a vesting pool and its destinations.
+type VestingPool struct {
+	ID           string               `json:"pool_id"`
+	Balance      int64                `json:"balance"`
+	Left         int64                `json:"left"`
+	Description  string               `json:"description"`
+	StartTime    int64                `json:"start_time"`
+	ExpireAt     int64                `json:"expire_at"`
+	Destinations []VestingDestination `json:"destinations"`
+	ClientID     string               `json:"client_id"`
+}
+
+// VestingDestination of a vesting pool.
+type VestingDestination struct {
+	ID     string `json:"id"`
+	Wanted int64  `json:"wanted"`
+	Earned int64  `json:"earned"`
+	Vested int64  `json:"vested"`
+	Last   int64  `json:"last"`
+}
+
+// VestingClientPools lists the vesting pools of a client.
+type VestingClientPools struct {
+	Pools []string `json:"pools"`
+}
+
+// Authorizer registered on the zcn smart contract.
+type Authorizer struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// AuthorizerList lists the authorizers.
+type AuthorizerList struct {
+	Nodes []Authorizer `json:"nodes"`
+}
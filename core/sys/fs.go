@@ -31,6 +31,10 @@ type FS interface {
 
 	//MkdirAll creates a directory named path
 	MkdirAll(path string, perm os.FileMode) error
+
+	// TempFile creates a new file in dir, its name made of pattern with the
+	// last "*" replaced by a random string, and returns it with its name.
+	TempFile(dir, pattern string) (File, string, error)
 }
 
 type File interface {
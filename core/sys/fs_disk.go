@@ -53,3 +53,13 @@ func (dfs *DiskFS) MkdirAll(path string, perm os.FileMode) error {
 func (dfs *DiskFS) Stat(name string) (fs.FileInfo, error) {
 	return os.Stat(name)
 }
+
+// TempFile creates a new file in dir, its name made of pattern with the last
+// "*" replaced by a random string, and returns it with its name.
+func (dfs *DiskFS) TempFile(dir, pattern string) (File, string, error) {
+	f, err := ioutil.TempFile(dir, pattern)
+	if err != nil {
+		return nil, "", err
+	}
+	return f, f.Name(), nil
+}
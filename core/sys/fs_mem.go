@@ -4,20 +4,27 @@ import (
 	"bytes"
 	"io"
 	"io/fs"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 // MemFS implement file system on memory
 type MemFS struct {
+	mu    sync.RWMutex
 	files map[string]*MemFile
+	dirs  map[string]*MemFile
 }
 
 // NewMemFS create MemFS instance
 func NewMemFS() FS {
 	return &MemFS{
 		files: make(map[string]*MemFile),
+		dirs:  make(map[string]*MemFile),
 	}
 }
 
@@ -26,115 +33,237 @@ func NewMemFS() FS {
 // descriptor has mode O_RDONLY.
 // If there is an error, it will be of type *PathError.
 func (mfs *MemFS) Open(name string) (File, error) {
-	file := mfs.files[name]
-	if file != nil {
-		return file, nil
-	}
-
-	fileName := filepath.Base(name)
-
-	file = &MemFile{Name: fileName, Buffer: new(bytes.Buffer), Mode: fs.ModePerm, ModTime: time.Now()}
-
-	mfs.files[name] = file
-
-	return file, nil
+	return mfs.OpenFile(name, os.O_RDONLY, 0)
 }
 
+// OpenFile opens the named file with flag, creating it with perm if flag has
+// os.O_CREATE. Every call returns a file of its own offset, the content being
+// shared by all the files opened with the same name.
 func (mfs *MemFS) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	name = filepath.Clean(name)
+
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+
 	file := mfs.files[name]
-	if file != nil {
-		return file, nil
+	if file == nil {
+		if flag&os.O_CREATE == 0 {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+		}
+		file = newMemFile(filepath.Base(name), new(bytes.Buffer), perm)
+		mfs.files[name] = file
+	} else if flag&(os.O_CREATE|os.O_EXCL) == os.O_CREATE|os.O_EXCL {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrExist}
 	}
 
-	fileName := filepath.Base(name)
-	file = &MemFile{Name: fileName, Buffer: new(bytes.Buffer), Mode: perm, ModTime: time.Now()}
-
-	mfs.files[name] = file
+	if flag&os.O_TRUNC != 0 {
+		file.mu.Lock()
+		file.Buffer.Reset()
+		file.mu.Unlock()
+		file.ModTime = time.Now()
+	}
 
-	return file, nil
+	f := file.clone()
+	if flag&os.O_APPEND != 0 {
+		f.offset = f.size()
+	}
 
+	return f, nil
 }
 
 // ReadFile reads the file named by filename and returns the contents.
 func (mfs *MemFS) ReadFile(name string) ([]byte, error) {
-	file, ok := mfs.files[name]
+	mfs.mu.RLock()
+	defer mfs.mu.RUnlock()
+
+	file, ok := mfs.files[filepath.Clean(name)]
 	if ok {
-		return file.Buffer.Bytes(), nil
+		file.mu.RLock()
+		defer file.mu.RUnlock()
+		return append([]byte(nil), file.Buffer.Bytes()...), nil
 	}
 
-	return nil, os.ErrNotExist
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
 }
 
 // WriteFile writes data to a file named by filename.
 func (mfs *MemFS) WriteFile(name string, data []byte, perm os.FileMode) error {
-	fileName := filepath.Base(name)
-	file := &MemFile{Name: fileName, Buffer: new(bytes.Buffer), Mode: perm, ModTime: time.Now()}
+	name = filepath.Clean(name)
+	file := newMemFile(filepath.Base(name), bytes.NewBuffer(append([]byte(nil), data...)), perm)
 
+	mfs.mu.Lock()
 	mfs.files[name] = file
+	mfs.mu.Unlock()
 
 	return nil
 }
 
+// TempFile creates a new file in dir, its name made of pattern with the last
+// "*" replaced by a random string, and returns it with its name.
+func (mfs *MemFS) TempFile(dir, pattern string) (File, string, error) {
+	prefix, suffix := pattern, ""
+	if i := strings.LastIndex(pattern, "*"); i >= 0 {
+		prefix, suffix = pattern[:i], pattern[i+1:]
+	}
+
+	for {
+		name := filepath.Join(dir, prefix+strconv.FormatUint(uint64(rand.Uint32()), 10)+suffix)
+		f, err := mfs.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			return f, name, nil
+		}
+		if !os.IsExist(err) {
+			return nil, "", err
+		}
+	}
+}
+
 // Remove removes the named file or (empty) directory.
 // If there is an error, it will be of type *PathError.
 func (mfs *MemFS) Remove(name string) error {
-	delete(mfs.files, name)
-	return nil
+	name = filepath.Clean(name)
+
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+
+	if _, ok := mfs.files[name]; ok {
+		delete(mfs.files, name)
+		return nil
+	}
+	if _, ok := mfs.dirs[name]; ok {
+		delete(mfs.dirs, name)
+		return nil
+	}
+
+	return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
 }
 
 //MkdirAll creates a directory named path
 func (mfs *MemFS) MkdirAll(path string, perm os.FileMode) error {
-	return nil
+	mfs.mu.Lock()
+	defer mfs.mu.Unlock()
+
+	for dir := filepath.Clean(path); ; dir = filepath.Dir(dir) {
+		if _, ok := mfs.files[dir]; ok {
+			return &fs.PathError{Op: "mkdir", Path: dir, Err: fs.ErrExist}
+		}
+		if _, ok := mfs.dirs[dir]; !ok {
+			mfs.dirs[dir] = newMemFile(filepath.Base(dir), new(bytes.Buffer), fs.ModeDir|perm)
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return nil
+		}
+	}
 }
 
 // Stat returns a FileInfo describing the named file.
 // If there is an error, it will be of type *PathError.
 func (mfs *MemFS) Stat(name string) (fs.FileInfo, error) {
-	file, ok := mfs.files[name]
-	if ok {
+	name = filepath.Clean(name)
+
+	mfs.mu.RLock()
+	defer mfs.mu.RUnlock()
+
+	if file, ok := mfs.files[name]; ok {
 		return file.Stat()
 	}
+	if dir, ok := mfs.dirs[name]; ok {
+		return dir.Stat()
+	}
 
-	return nil, os.ErrNotExist
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
 }
 
+// MemFile is a file of MemFS. The files opened with the same name share their
+// content, a lock of its own guarding it, so they can be read and written from
+// several goroutines. A single MemFile, i.e. its offset, is not safe for
+// concurrent use.
 type MemFile struct {
 	Name    string
 	Buffer  *bytes.Buffer // file content
 	Mode    fs.FileMode   // FileInfo.Mode
 	ModTime time.Time     // FileInfo.ModTime
 	Sys     interface{}   // FileInfo.Sys
-	reader  io.Reader
+	offset  int64
+	mu      *sync.RWMutex // guards Buffer, shared by the clones
+}
+
+func newMemFile(name string, buf *bytes.Buffer, mode fs.FileMode) *MemFile {
+	return &MemFile{Name: name, Buffer: buf, Mode: mode, ModTime: time.Now(), mu: &sync.RWMutex{}}
+}
+
+// clone returns a file sharing the content of f, at offset 0
+func (f *MemFile) clone() *MemFile {
+	return &MemFile{Name: f.Name, Buffer: f.Buffer, Mode: f.Mode, ModTime: f.ModTime, Sys: f.Sys, mu: f.mu}
+}
+
+// size returns the size of the content of f
+func (f *MemFile) size() int64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return int64(f.Buffer.Len())
 }
 
 func (f *MemFile) Stat() (fs.FileInfo, error) {
 	return &MemFileInfo{name: f.Name, f: f}, nil
 }
+
 func (f *MemFile) Read(p []byte) (int, error) {
-	if f.reader == nil {
-		f.reader = bytes.NewReader(f.Buffer.Bytes())
-	}
-	return f.reader.Read(p)
+	f.mu.RLock()
+	defer f.mu.RUnlock()
 
+	buf := f.Buffer.Bytes()
+	if f.offset >= int64(len(buf)) {
+		return 0, io.EOF
+	}
+	n := copy(p, buf[f.offset:])
+	f.offset += int64(n)
+	return n, nil
 }
+
 func (f *MemFile) Write(p []byte) (n int, err error) {
-	return f.Buffer.Write(p)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	buf := f.Buffer.Bytes()
+	if gap := f.offset - int64(len(buf)); gap > 0 {
+		f.Buffer.Write(make([]byte, gap))
+		buf = f.Buffer.Bytes()
+	}
+
+	n = copy(buf[f.offset:], p)
+	if n < len(p) {
+		f.Buffer.Write(p[n:])
+	}
+	f.offset += int64(len(p))
 
+	return len(p), nil
 }
 
 func (f *MemFile) Sync() error {
 	return nil
 }
-func (f *MemFile) Seek(offset int64, whence int) (ret int64, err error) {
-
-	// always reset it from beginning, it only work for wasm download
-	f.reader = bytes.NewReader(f.Buffer.Bytes())
 
-	return 0, nil
+func (f *MemFile) Seek(offset int64, whence int) (ret int64, err error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = f.offset + offset
+	case io.SeekEnd:
+		abs = f.size() + offset
+	default:
+		return 0, &fs.PathError{Op: "seek", Path: f.Name, Err: fs.ErrInvalid}
+	}
+	if abs < 0 {
+		return 0, &fs.PathError{Op: "seek", Path: f.Name, Err: fs.ErrInvalid}
+	}
+	f.offset = abs
+	return abs, nil
 }
 
 func (f *MemFile) Close() error {
-	f.reader = nil
 	return nil
 }
 
@@ -147,7 +276,7 @@ func (i *MemFileInfo) Name() string {
 	return i.name
 }
 func (i *MemFileInfo) Size() int64 {
-	return int64(i.f.Buffer.Len())
+	return i.f.size()
 }
 
 func (i *MemFileInfo) Mode() fs.FileMode {
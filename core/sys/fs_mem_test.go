@@ -0,0 +1,160 @@
+package sys
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemFS(t *testing.T) {
+	mfs := NewMemFS()
+
+	_, err := mfs.Open("/tmp/a.txt")
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = mfs.Stat("/tmp/a.txt")
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.ErrorIs(t, mfs.Remove("/tmp/a.txt"), os.ErrNotExist)
+
+	require.NoError(t, mfs.MkdirAll("/tmp/dir", 0755))
+	info, err := mfs.Stat("/tmp")
+	require.NoError(t, err)
+	require.True(t, info.IsDir())
+
+	f, err := mfs.OpenFile("/tmp/a.txt", os.O_CREATE|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("hello world"))
+	require.NoError(t, err)
+
+	// write at offset
+	_, err = f.Seek(6, io.SeekStart)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("WORLD!"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	buf, err := mfs.ReadFile("/tmp/a.txt")
+	require.NoError(t, err)
+	require.Equal(t, "hello WORLD!", string(buf))
+
+	// every file has its own offset
+	r1, err := mfs.Open("/tmp/a.txt")
+	require.NoError(t, err)
+	r2, err := mfs.Open("/tmp/a.txt")
+	require.NoError(t, err)
+	_, err = r1.Seek(-6, io.SeekEnd)
+	require.NoError(t, err)
+	b1, err := ioutil.ReadAll(r1)
+	require.NoError(t, err)
+	require.Equal(t, "WORLD!", string(b1))
+	b2, err := ioutil.ReadAll(r2)
+	require.NoError(t, err)
+	require.Equal(t, "hello WORLD!", string(b2))
+
+	a, err := mfs.OpenFile("/tmp/a.txt", os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = a.Write([]byte("?"))
+	require.NoError(t, err)
+	info, err = mfs.Stat("/tmp/a.txt")
+	require.NoError(t, err)
+	require.Equal(t, int64(13), info.Size())
+	require.False(t, info.IsDir())
+
+	_, err = mfs.OpenFile("/tmp/a.txt", os.O_CREATE|os.O_EXCL, 0644)
+	require.ErrorIs(t, err, os.ErrExist)
+
+	_, err = mfs.OpenFile("/tmp/a.txt", os.O_WRONLY|os.O_TRUNC, 0)
+	require.NoError(t, err)
+	buf, err = mfs.ReadFile("/tmp/a.txt")
+	require.NoError(t, err)
+	require.Empty(t, buf)
+
+	require.NoError(t, mfs.WriteFile("/tmp/b.txt", []byte("b"), 0644))
+	buf, err = mfs.ReadFile("/tmp/b.txt")
+	require.NoError(t, err)
+	require.Equal(t, "b", string(buf))
+
+	require.NoError(t, mfs.Remove("/tmp/b.txt"))
+	_, err = mfs.Stat("/tmp/b.txt")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestTempFile(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		fs   FS
+		dir  string
+	}{
+		{name: "Test_MemFS", fs: NewMemFS(), dir: "/tmp"},
+		{name: "Test_DiskFS", fs: NewDiskFS(), dir: t.TempDir()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, name, err := tc.fs.TempFile(tc.dir, "upload-*.part")
+			require.NoError(t, err)
+			require.Equal(t, tc.dir, filepath.Dir(name))
+			require.True(t, strings.HasPrefix(filepath.Base(name), "upload-"))
+			require.True(t, strings.HasSuffix(name, ".part"))
+
+			_, err = f.Write([]byte("chunk"))
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			buf, err := tc.fs.ReadFile(name)
+			require.NoError(t, err)
+			require.Equal(t, "chunk", string(buf))
+
+			_, other, err := tc.fs.TempFile(tc.dir, "upload-*.part")
+			require.NoError(t, err)
+			require.NotEqual(t, name, other)
+		})
+	}
+}
+
+func TestMemFS_concurrentFiles(t *testing.T) {
+	mfs := NewMemFS()
+
+	w, err := mfs.OpenFile("/tmp/a.txt", os.O_CREATE|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+
+	const chunks = 100
+	chunk := []byte("0123456789")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < chunks; i++ {
+			_, err := w.Write(chunk)
+			require.NoError(t, err)
+		}
+	}()
+
+	// the files opened with the same name are read while the content grows
+	r, err := mfs.Open("/tmp/a.txt")
+	require.NoError(t, err)
+	buf := make([]byte, 7)
+	var read int
+	for read < chunks*len(chunk) {
+		n, err := r.Read(buf)
+		if err != nil {
+			require.ErrorIs(t, err, io.EOF)
+		}
+		read += n
+
+		info, err := r.Stat()
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, info.Size(), int64(read))
+		_, err = r.Seek(0, io.SeekEnd)
+		require.NoError(t, err)
+		_, err = r.Seek(int64(read), io.SeekStart)
+		require.NoError(t, err)
+	}
+	<-done
+
+	info, err := mfs.Stat("/tmp/a.txt")
+	require.NoError(t, err)
+	require.Equal(t, int64(chunks*len(chunk)), info.Size())
+}
@@ -0,0 +1,72 @@
+package sys
+
+import (
+	"context"
+	"time"
+)
+
+// Timer is a one-shot timer or a ticker created by a Scheduler.
+type Timer interface {
+	// C delivers the time the timer fired at
+	C() <-chan time.Time
+	// Stop stops the timer, it returns false if it already fired or was stopped
+	Stop() bool
+}
+
+// Scheduler creates the timers and tickers used for sleeps, retries and
+// backoff across the sdk.
+type Scheduler interface {
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Timer
+}
+
+// Sched is the scheduler of the sdk. It is backed by the time package, on
+// webassembly by a single runtime timer, see NewHeapScheduler.
+var Sched Scheduler = timeScheduler{}
+
+// After waits for d on Sched and then sends the current time on the returned channel.
+func After(d time.Duration) <-chan time.Time {
+	return Sched.NewTimer(d).C()
+}
+
+// NewTicker returns a ticker of Sched firing every d.
+func NewTicker(d time.Duration) Timer {
+	return Sched.NewTicker(d)
+}
+
+// SleepContext pauses the current goroutine for at least d, or until ctx is
+// done. Retry and backoff loops should prefer it to Sleep.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := Sched.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C():
+		return nil
+	}
+}
+
+// timeScheduler is a Scheduler of time package timers.
+type timeScheduler struct{}
+
+func (timeScheduler) NewTimer(d time.Duration) Timer {
+	return stdTimer{time.NewTimer(d)}
+}
+
+func (timeScheduler) NewTicker(d time.Duration) Timer {
+	return stdTicker{time.NewTicker(d)}
+}
+
+type stdTimer struct{ t *time.Timer }
+
+func (t stdTimer) C() <-chan time.Time { return t.t.C }
+func (t stdTimer) Stop() bool          { return t.t.Stop() }
+
+type stdTicker struct{ t *time.Ticker }
+
+func (t stdTicker) C() <-chan time.Time { return t.t.C }
+func (t stdTicker) Stop() bool          { t.t.Stop(); return true }
@@ -0,0 +1,152 @@
+package sys
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// HeapScheduler keeps its timers in a heap and runs a single runtime timer,
+// for the earliest of them. Under webassembly every runtime timer and
+// sleeping goroutine is costly, many parallel requests backing off would
+// otherwise create one each.
+type HeapScheduler struct {
+	mu     sync.Mutex
+	timers timerHeap
+	wake   *time.Timer
+}
+
+// NewHeapScheduler creates a HeapScheduler.
+func NewHeapScheduler() *HeapScheduler {
+	return &HeapScheduler{}
+}
+
+// NewTimer implements Scheduler.
+func (s *HeapScheduler) NewTimer(d time.Duration) Timer {
+	return s.add(d, 0)
+}
+
+// NewTicker implements Scheduler.
+func (s *HeapScheduler) NewTicker(d time.Duration) Timer {
+	if d <= 0 {
+		panic("sys: non-positive interval for NewTicker")
+	}
+	return s.add(d, d)
+}
+
+func (s *HeapScheduler) add(d, period time.Duration) *heapTimer {
+	t := &heapTimer{s: s, period: period, c: make(chan time.Time, 1), index: -1}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t.when = time.Now().Add(d)
+	heap.Push(&s.timers, t)
+	if t.index == 0 {
+		s.resetWake()
+	}
+	return t
+}
+
+// resetWake arms the runtime timer for the earliest timer, s.mu is held.
+func (s *HeapScheduler) resetWake() {
+	if len(s.timers) == 0 {
+		if s.wake != nil {
+			s.wake.Stop()
+		}
+		return
+	}
+	d := s.timers[0].when.Sub(time.Now())
+	if s.wake == nil {
+		s.wake = time.AfterFunc(d, s.fire)
+		return
+	}
+	s.wake.Stop()
+	s.wake.Reset(d)
+}
+
+// fire delivers the due timers and reschedules the tickers.
+func (s *HeapScheduler) fire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for len(s.timers) > 0 && !s.timers[0].when.After(now) {
+		t := s.timers[0]
+		// like time.Ticker, a tick is dropped if the previous one wasn't read
+		select {
+		case t.c <- now:
+		default:
+		}
+		if t.period == 0 {
+			heap.Pop(&s.timers)
+			continue
+		}
+		t.when = t.when.Add(t.period)
+		if !t.when.After(now) {
+			t.when = now.Add(t.period)
+		}
+		heap.Fix(&s.timers, 0)
+	}
+	s.resetWake()
+}
+
+// Len returns the number of pending timers and tickers.
+func (s *HeapScheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.timers)
+}
+
+type heapTimer struct {
+	s      *HeapScheduler
+	when   time.Time
+	period time.Duration
+	c      chan time.Time
+	index  int
+}
+
+func (t *heapTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *heapTimer) Stop() bool {
+	s := t.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t.index < 0 {
+		return false
+	}
+	first := t.index == 0
+	heap.Remove(&s.timers, t.index)
+	if first {
+		s.resetWake()
+	}
+	return true
+}
+
+// timerHeap orders timers by deadline.
+type timerHeap []*heapTimer
+
+func (h timerHeap) Len() int           { return len(h) }
+func (h timerHeap) Less(i, j int) bool { return h[i].when.Before(h[j].when) }
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *timerHeap) Push(x interface{}) {
+	t := x.(*heapTimer)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *timerHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	t.index = -1
+	*h = old[:n-1]
+	return t
+}
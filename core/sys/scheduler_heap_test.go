@@ -0,0 +1,63 @@
+package sys
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeapScheduler(t *testing.T) {
+	s := NewHeapScheduler()
+
+	t.Run("Test_Timers_Fire_In_Order", func(t *testing.T) {
+		late := s.NewTimer(40 * time.Millisecond)
+		early := s.NewTimer(10 * time.Millisecond)
+
+		first := <-early.C()
+		second := <-late.C()
+		require.True(t, second.After(first))
+		require.Equal(t, 0, s.Len())
+	})
+
+	t.Run("Test_Stop", func(t *testing.T) {
+		stopped := s.NewTimer(10 * time.Millisecond)
+		other := s.NewTimer(20 * time.Millisecond)
+		require.True(t, stopped.Stop())
+		require.False(t, stopped.Stop())
+
+		<-other.C()
+		select {
+		case <-stopped.C():
+			t.Fatal("stopped timer fired")
+		default:
+		}
+		require.False(t, other.Stop())
+	})
+
+	t.Run("Test_Ticker", func(t *testing.T) {
+		ticker := s.NewTicker(5 * time.Millisecond)
+		for i := 0; i < 3; i++ {
+			<-ticker.C()
+		}
+		require.Equal(t, 1, s.Len())
+		require.True(t, ticker.Stop())
+		require.Equal(t, 0, s.Len())
+	})
+}
+
+func TestSleepContext(t *testing.T) {
+	prev := Sched
+	Sched = NewHeapScheduler()
+	defer func() { Sched = prev }()
+
+	require.NoError(t, SleepContext(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	require.ErrorIs(t, SleepContext(ctx, time.Hour), context.Canceled)
+	require.Less(t, time.Since(start), time.Second)
+	require.Equal(t, 0, Sched.(*HeapScheduler).Len())
+}
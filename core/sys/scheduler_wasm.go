@@ -0,0 +1,15 @@
+//go:build js && wasm
+// +build js,wasm
+
+package sys
+
+import "time"
+
+func init() {
+	Sched = NewHeapScheduler()
+
+	// time.Sleep would stop the main thread
+	Sleep = func(d time.Duration) {
+		<-After(d)
+	}
+}
@@ -6,7 +6,9 @@ import (
 )
 
 var (
-	//Files file system implementation on sdk. DiskFS doesn't work on webassembly. it should be initialized with common.NewMemFS()
+	// Files file system of the sdk, the local files of the uploads and downloads
+	// being read and written with it. DiskFS doesn't work on webassembly, it
+	// should be replaced with NewMemFS() there, or in tests.
 	Files FS = NewDiskFS()
 
 	//Sleep  pauses the current goroutine for at least the duration.
@@ -0,0 +1,127 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OTel adapts an OpenTelemetry TracerProvider and MeterProvider to the ones
+// of Enable, e.g. Enable(OTel(tp, mp)). Either may be nil.
+func OTel(tp trace.TracerProvider, mp metric.MeterProvider) (TracerProvider, MeterProvider) {
+	var (
+		t TracerProvider
+		m MeterProvider
+	)
+	if tp != nil {
+		t = otelTracerProvider{tp}
+	}
+	if mp != nil {
+		m = otelMeterProvider{mp}
+	}
+	return t, m
+}
+
+type otelTracerProvider struct {
+	tp trace.TracerProvider
+}
+
+func (p otelTracerProvider) Tracer(name string) Tracer {
+	return otelTracer{p.tp.Tracer(name)}
+}
+
+type otelTracer struct {
+	t trace.Tracer
+}
+
+func (t otelTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	ctx, span := t.t.Start(ctx, name, trace.WithAttributes(otelAttributes(attrs)...))
+	return ctx, otelSpan{span}
+}
+
+type otelSpan struct {
+	s trace.Span
+}
+
+func (s otelSpan) SetAttributes(attrs ...Attribute) {
+	s.s.SetAttributes(otelAttributes(attrs)...)
+}
+
+func (s otelSpan) RecordError(err error) {
+	s.s.RecordError(err)
+	s.s.SetStatus(codes.Error, err.Error())
+}
+
+func (s otelSpan) End() {
+	s.s.End()
+}
+
+type otelMeterProvider struct {
+	mp metric.MeterProvider
+}
+
+func (p otelMeterProvider) Meter(name string) Meter {
+	return otelMeter{p.mp.Meter(name)}
+}
+
+type otelMeter struct {
+	m metric.Meter
+}
+
+// Int64Counter falls back to a no-op counter if the meter rejects name.
+func (m otelMeter) Int64Counter(name string) Int64Counter {
+	c, err := m.m.Int64Counter(name)
+	if err != nil {
+		c, _ = metric.NewNoopMeter().Int64Counter(name)
+	}
+	return otelInt64Counter{c}
+}
+
+// Float64Histogram falls back to a no-op histogram if the meter rejects name.
+func (m otelMeter) Float64Histogram(name string) Float64Histogram {
+	h, err := m.m.Float64Histogram(name, instrument.WithUnit("ms"))
+	if err != nil {
+		h, _ = metric.NewNoopMeter().Float64Histogram(name)
+	}
+	return otelFloat64Histogram{h}
+}
+
+type otelInt64Counter struct {
+	c instrument.Int64Counter
+}
+
+func (c otelInt64Counter) Add(ctx context.Context, incr int64, attrs ...Attribute) {
+	c.c.Add(ctx, incr, otelAttributes(attrs)...)
+}
+
+type otelFloat64Histogram struct {
+	h instrument.Float64Histogram
+}
+
+func (h otelFloat64Histogram) Record(ctx context.Context, value float64, attrs ...Attribute) {
+	h.h.Record(ctx, value, otelAttributes(attrs)...)
+}
+
+func otelAttributes(attrs []Attribute) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		switch v := a.Value.(type) {
+		case string:
+			kvs = append(kvs, attribute.String(a.Key, v))
+		case bool:
+			kvs = append(kvs, attribute.Bool(a.Key, v))
+		case int64:
+			kvs = append(kvs, attribute.Int64(a.Key, v))
+		case float64:
+			kvs = append(kvs, attribute.Float64(a.Key, v))
+		default:
+			kvs = append(kvs, attribute.String(a.Key, fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
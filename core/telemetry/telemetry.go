@@ -0,0 +1,213 @@
+// Package telemetry instruments the sdk with traces and metrics once enabled
+// with Enable. Its interfaces are the subset of the OpenTelemetry trace and
+// metric APIs used by the sdk, OTel adapting an OpenTelemetry TracerProvider
+// and MeterProvider to them. Until Enable is called the instrumentation is a
+// no-op.
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// InstrumentationName is the name of the tracer and meter of the sdk.
+const InstrumentationName = "github.com/0chain/gosdk"
+
+// Metrics recorded by the sdk
+const (
+	// MetricRequestDuration is the latency of the requests in milliseconds
+	MetricRequestDuration = "gosdk.request.duration"
+	// MetricRequestErrors counts the failed requests
+	MetricRequestErrors = "gosdk.request.errors"
+	// MetricRetries counts the retried requests
+	MetricRetries = "gosdk.retries"
+	// MetricBytes counts the bytes sent and received
+	MetricBytes = "gosdk.bytes"
+)
+
+// Attribute keys of the spans and metrics
+const (
+	AttrNode      = "gosdk.node"
+	AttrOperation = "gosdk.operation"
+	AttrDirection = "gosdk.direction"
+	AttrStatus    = "http.status_code"
+	AttrMethod    = "http.method"
+	AttrTxnHash   = "gosdk.txn.hash"
+	AttrChunk     = "gosdk.chunk"
+)
+
+// Directions of MetricBytes
+const (
+	DirectionSent     = "sent"
+	DirectionReceived = "received"
+)
+
+// Attribute is a key value pair describing a span or a measurement, the
+// value being a string, a bool, an int64 or a float64.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string attribute.
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int64 returns an integer attribute.
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an integer attribute.
+func Int(key string, value int) Attribute {
+	return Int64(key, int64(value))
+}
+
+// Span is an operation traced by the sdk.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts the spans of the sdk.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// TracerProvider returns the tracer of an instrumentation, e.g. an adapter
+// to an OpenTelemetry trace.TracerProvider.
+type TracerProvider interface {
+	Tracer(name string) Tracer
+}
+
+// Int64Counter is a monotonic counter.
+type Int64Counter interface {
+	Add(ctx context.Context, incr int64, attrs ...Attribute)
+}
+
+// Float64Histogram records a distribution of values.
+type Float64Histogram interface {
+	Record(ctx context.Context, value float64, attrs ...Attribute)
+}
+
+// Meter creates the instruments of the sdk.
+type Meter interface {
+	Int64Counter(name string) Int64Counter
+	Float64Histogram(name string) Float64Histogram
+}
+
+// MeterProvider returns the meter of an instrumentation, e.g. an adapter to
+// an OpenTelemetry metric.MeterProvider.
+type MeterProvider interface {
+	Meter(name string) Meter
+}
+
+type instruments struct {
+	tracer   Tracer
+	duration Float64Histogram
+	errors   Int64Counter
+	retries  Int64Counter
+	bytes    Int64Counter
+}
+
+var (
+	mu      sync.RWMutex
+	current *instruments
+)
+
+// Enable instruments the sdk with the tracer and meter of tp and mp. Either
+// may be nil to only trace or only record metrics; Enable(nil, nil)
+// disables the telemetry.
+func Enable(tp TracerProvider, mp MeterProvider) {
+	if tp == nil && mp == nil {
+		mu.Lock()
+		current = nil
+		mu.Unlock()
+		return
+	}
+
+	i := &instruments{}
+	if tp != nil {
+		i.tracer = tp.Tracer(InstrumentationName)
+	}
+	if mp != nil {
+		m := mp.Meter(InstrumentationName)
+		i.duration = m.Float64Histogram(MetricRequestDuration)
+		i.errors = m.Int64Counter(MetricRequestErrors)
+		i.retries = m.Int64Counter(MetricRetries)
+		i.bytes = m.Int64Counter(MetricBytes)
+	}
+	mu.Lock()
+	current = i
+	mu.Unlock()
+}
+
+// Enabled tells whether Enable was called with a provider.
+func Enabled() bool {
+	return get() != nil
+}
+
+func get() *instruments {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+func (noopSpan) RecordError(error)          {}
+func (noopSpan) End()                       {}
+
+// StartSpan starts a span child of the one of ctx, a no-op one if tracing
+// isn't enabled.
+func StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	if i := get(); i != nil && i.tracer != nil {
+		return i.tracer.Start(ctx, name, attrs...)
+	}
+	return ctx, noopSpan{}
+}
+
+// EndSpan records err, if any, on span and ends it.
+func EndSpan(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// RecordRequest records the latency of the request op to node, and counts
+// it as failed if err isn't nil.
+func RecordRequest(ctx context.Context, node, op string, d time.Duration, err error) {
+	i := get()
+	if i == nil || i.duration == nil {
+		return
+	}
+	attrs := []Attribute{String(AttrNode, node), String(AttrOperation, op)}
+	i.duration.Record(ctx, float64(d)/float64(time.Millisecond), attrs...)
+	if err != nil {
+		i.errors.Add(ctx, 1, attrs...)
+	}
+}
+
+// AddRetry counts a retry of the request op to node.
+func AddRetry(ctx context.Context, node, op string) {
+	i := get()
+	if i == nil || i.retries == nil {
+		return
+	}
+	i.retries.Add(ctx, 1, String(AttrNode, node), String(AttrOperation, op))
+}
+
+// AddBytes counts n bytes sent to or received from node, direction being
+// DirectionSent or DirectionReceived.
+func AddBytes(ctx context.Context, node, direction string, n int64) {
+	i := get()
+	if i == nil || i.bytes == nil || n <= 0 {
+		return
+	}
+	i.bytes.Add(ctx, n, String(AttrNode, node), String(AttrDirection, direction))
+}
@@ -0,0 +1,130 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type recorder struct {
+	mu       sync.Mutex
+	spans    []*testSpan
+	counters map[string]int64
+	values   map[string][]float64
+}
+
+func newRecorder() *recorder {
+	return &recorder{counters: make(map[string]int64), values: make(map[string][]float64)}
+}
+
+type testSpan struct {
+	name  string
+	attrs []Attribute
+	err   error
+	ended bool
+}
+
+func (s *testSpan) SetAttributes(attrs ...Attribute) { s.attrs = append(s.attrs, attrs...) }
+func (s *testSpan) RecordError(err error)            { s.err = err }
+func (s *testSpan) End()                             { s.ended = true }
+
+func (r *recorder) Tracer(string) Tracer { return r }
+func (r *recorder) Meter(string) Meter   { return r }
+
+func (r *recorder) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	s := &testSpan{name: name, attrs: attrs}
+	r.mu.Lock()
+	r.spans = append(r.spans, s)
+	r.mu.Unlock()
+	return ctx, s
+}
+
+type counter struct {
+	r    *recorder
+	name string
+}
+
+func (c counter) Add(_ context.Context, incr int64, _ ...Attribute) {
+	c.r.mu.Lock()
+	c.r.counters[c.name] += incr
+	c.r.mu.Unlock()
+}
+
+func (c counter) Record(_ context.Context, v float64, _ ...Attribute) {
+	c.r.mu.Lock()
+	c.r.values[c.name] = append(c.r.values[c.name], v)
+	c.r.mu.Unlock()
+}
+
+func (r *recorder) Int64Counter(name string) Int64Counter         { return counter{r, name} }
+func (r *recorder) Float64Histogram(name string) Float64Histogram { return counter{r, name} }
+
+func TestTelemetry(t *testing.T) {
+	ctx := context.Background()
+	defer Enable(nil, nil)
+
+	t.Run("Test_Disabled", func(t *testing.T) {
+		Enable(nil, nil)
+		require.False(t, Enabled())
+		_, span := StartSpan(ctx, "noop")
+		EndSpan(span, errors.New("ignored"))
+		RecordRequest(ctx, "node", "op", time.Second, nil)
+	})
+
+	t.Run("Test_Enabled", func(t *testing.T) {
+		r := newRecorder()
+		Enable(r, r)
+		require.True(t, Enabled())
+
+		_, span := StartSpan(ctx, "upload.chunk", Int(AttrChunk, 2))
+		EndSpan(span, errors.New("failed"))
+		require.Len(t, r.spans, 1)
+		require.Equal(t, "upload.chunk", r.spans[0].name)
+		require.EqualError(t, r.spans[0].err, "failed")
+		require.True(t, r.spans[0].ended)
+
+		RecordRequest(ctx, "b1", "GET /", 2*time.Millisecond, nil)
+		RecordRequest(ctx, "b1", "GET /", time.Millisecond, errors.New("down"))
+		AddRetry(ctx, "b1", "GET /")
+		AddBytes(ctx, "b1", DirectionReceived, 10)
+		AddBytes(ctx, "b1", DirectionSent, 0)
+
+		require.Equal(t, []float64{2, 1}, r.values[MetricRequestDuration])
+		require.EqualValues(t, 1, r.counters[MetricRequestErrors])
+		require.EqualValues(t, 1, r.counters[MetricRetries])
+		require.EqualValues(t, 10, r.counters[MetricBytes])
+	})
+
+	t.Run("Test_Metrics_Only", func(t *testing.T) {
+		r := newRecorder()
+		Enable(nil, r)
+		_, span := StartSpan(ctx, "download.block")
+		span.End()
+		require.Empty(t, r.spans)
+		AddRetry(ctx, "b1", "op")
+		require.EqualValues(t, 1, r.counters[MetricRetries])
+	})
+}
+
+func TestOTel(t *testing.T) {
+	defer Enable(nil, nil)
+
+	tp, mp := OTel(nil, nil)
+	require.Nil(t, tp)
+	require.Nil(t, mp)
+
+	Enable(OTel(trace.NewNoopTracerProvider(), metric.NewNoopMeterProvider()))
+	require.True(t, Enabled())
+
+	ctx, span := StartSpan(context.Background(), "transaction.submit", String(AttrTxnHash, "hash"), Int(AttrChunk, 1))
+	span.SetAttributes(Attribute{Key: "bool", Value: true})
+	EndSpan(span, errors.New("failed"))
+	RecordRequest(ctx, "b1", "GET /", time.Millisecond, errors.New("down"))
+	AddBytes(ctx, "b1", DirectionSent, 10)
+}
@@ -0,0 +1,319 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/core/conf"
+	"github.com/0chain/gosdk/core/resty"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/core/telemetry"
+)
+
+const (
+	// DefaultConfirmTimeout bounds SendAndConfirm when ctx has no deadline
+	DefaultConfirmTimeout = 2 * time.Minute
+	// DefaultPollInterval is the first interval between confirmation polls
+	DefaultPollInterval = time.Second
+	// DefaultMaxPollInterval caps the backoff between confirmation polls
+	DefaultMaxPollInterval = 10 * time.Second
+)
+
+// ConfirmOptions of SendAndConfirm. Zero values are replaced by the client
+// config and the defaults.
+type ConfirmOptions struct {
+	Miners   []string
+	Sharders []string
+	// MinSubmit percentage of miners that must accept the transaction
+	MinSubmit int
+	// MinConfirmation percentage of the queried sharders that must agree on the block
+	MinConfirmation int
+	// PollInterval is doubled after each poll up to MaxPollInterval
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+	// Timeout is used when ctx has no deadline
+	Timeout time.Duration
+}
+
+func (o *ConfirmOptions) withDefaults() (ConfirmOptions, error) {
+	var opts ConfirmOptions
+	if o != nil {
+		opts = *o
+	}
+	if network, _ := conf.GetNetwork(); network != nil {
+		if len(opts.Miners) == 0 {
+			opts.Miners = network.Miners
+		}
+		if len(opts.Sharders) == 0 {
+			opts.Sharders = network.Sharders
+		}
+	}
+	cfg, _ := conf.GetClientConfig()
+	if opts.MinSubmit <= 0 {
+		opts.MinSubmit = conf.DefaultMinSubmit
+		if cfg != nil {
+			opts.MinSubmit = cfg.MinSubmit
+		}
+	}
+	if opts.MinConfirmation <= 0 {
+		opts.MinConfirmation = conf.DefaultMinConfirmation
+		if cfg != nil {
+			opts.MinConfirmation = cfg.MinConfirmation
+		}
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.MaxPollInterval < opts.PollInterval {
+		opts.MaxPollInterval = DefaultMaxPollInterval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultConfirmTimeout
+	}
+	if len(opts.Miners) == 0 {
+		return opts, errors.New("send_and_confirm", "no miners")
+	}
+	if len(opts.Sharders) == 0 {
+		return opts, ErrNoAvailableSharder
+	}
+	return opts, nil
+}
+
+// Receipt of a confirmed transaction.
+type Receipt struct {
+	Hash      string `json:"hash"`
+	BlockHash string `json:"block_hash"`
+	Round     int64  `json:"round"`
+	// Status is TxnSuccess, TxnChargeableError or TxnFail
+	Status int    `json:"status"`
+	Fee    uint64 `json:"fee"`
+	Nonce  int64  `json:"nonce"`
+	// Output of the smart contract, see DecodeOutput
+	Output      string       `json:"output"`
+	Transaction *Transaction `json:"transaction"`
+}
+
+// DecodeOutput unmarshals the JSON output of the smart contract into v.
+func (r *Receipt) DecodeOutput(v interface{}) error {
+	if err := json.Unmarshal([]byte(r.Output), v); err != nil {
+		return errors.Wrap(err, "decode_output")
+	}
+	return nil
+}
+
+// Failed returns a *constants.ErrTxnRejected if the transaction was
+// confirmed but failed.
+func (r *Receipt) Failed() error {
+	switch r.Status {
+	case TxnSuccess:
+		return nil
+	case TxnChargeableError:
+		return &constants.ErrTxnRejected{Status: "transaction_chargeable_error", Reason: r.Output}
+	}
+	return &constants.ErrTxnRejected{Reason: r.Output}
+}
+
+// confirmation is the response of TXN_VERIFY_URL.
+type confirmation struct {
+	BlockHash string       `json:"block_hash"`
+	Round     int64        `json:"round"`
+	Txn       *Transaction `json:"txn"`
+}
+
+// SendAndConfirm submits the signed txn to the miners and polls the sharders
+// until it is confirmed or ctx is done. A transaction confirmed with a failed
+// status is returned with its receipt and the error of Receipt.Failed.
+func SendAndConfirm(ctx context.Context, txn *Transaction, opts *ConfirmOptions) (*Receipt, error) {
+	if txn == nil || txn.Hash == "" || txn.Signature == "" {
+		return nil, errors.New("send_and_confirm", "transaction must be hashed and signed")
+	}
+	o, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	ctx, span := telemetry.StartSpan(ctx, "transaction.submit",
+		telemetry.String(telemetry.AttrTxnHash, txn.Hash))
+	err = submit(ctx, txn, o.Miners, o.MinSubmit)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return confirm(ctx, txn.Hash, &o)
+}
+
+// Confirm polls the sharders until the transaction is confirmed or ctx is done.
+func Confirm(ctx context.Context, txnHash string, opts *ConfirmOptions) (*Receipt, error) {
+	o, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+	return confirm(ctx, txnHash, &o)
+}
+
+func confirm(ctx context.Context, txnHash string, o *ConfirmOptions) (receipt *Receipt, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "transaction.confirm",
+		telemetry.String(telemetry.AttrTxnHash, txnHash))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	interval := o.PollInterval
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		if err := sys.SleepContext(ctx, interval); err != nil {
+			if lastErr != nil {
+				return nil, errors.Wrap(lastErr, err.Error())
+			}
+			return nil, err
+		}
+		if attempt > 0 {
+			telemetry.AddRetry(ctx, "", "transaction.confirm")
+		}
+
+		receipt, err := queryConfirmation(ctx, txnHash, o.Sharders, o.MinConfirmation)
+		if err == nil {
+			return receipt, receipt.Failed()
+		}
+		lastErr = err
+
+		interval *= 2
+		if interval > o.MaxPollInterval {
+			interval = o.MaxPollInterval
+		}
+	}
+}
+
+// submit posts txn to every miner, minSubmit percent of them must accept it.
+func submit(ctx context.Context, txn *Transaction, miners []string, minSubmit int) error {
+	body, err := json.Marshal(txn)
+	if err != nil {
+		return err
+	}
+	required := int(math.Ceil(float64(minSubmit*len(miners)) / 100))
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		accepted int
+		msgs     []string
+	)
+	for _, miner := range miners {
+		wg.Add(1)
+		go func(miner, url string) {
+			defer wg.Done()
+			start := time.Now()
+			r := resty.New(resty.WithHeader(map[string]string{"Content-Type": "application/json; charset=utf-8"})).
+				Then(func(req *http.Request, resp *http.Response, respBody []byte, cf context.CancelFunc, err error) error {
+					if err != nil {
+						return err
+					}
+					if resp.StatusCode < 200 || resp.StatusCode > 299 {
+						return errors.New(strconv.Itoa(resp.StatusCode), string(respBody))
+					}
+					return nil
+				})
+			errs := r.DoPost(ctx, bytes.NewReader(body), url).Wait()
+
+			var err error
+			if len(errs) > 0 {
+				err = errs[0]
+			}
+			telemetry.RecordRequest(ctx, miner, "transaction.submit", time.Since(start), err)
+			telemetry.AddBytes(ctx, miner, telemetry.DirectionSent, int64(len(body)))
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				msgs = append(msgs, url+": "+err.Error())
+				return
+			}
+			accepted++
+		}(miner, fmt.Sprintf("%v/%v", miner, TXN_SUBMIT_URL))
+	}
+	wg.Wait()
+
+	if accepted < required {
+		return errors.Newf("transaction_send_error", "accepted by %d of %d miners, %d required: %v",
+			accepted, len(miners), required, msgs)
+	}
+	return nil
+}
+
+// queryConfirmation asks every sharder for the confirmation of txnHash,
+// minConfirmation percent of them must agree on the block.
+func queryConfirmation(ctx context.Context, txnHash string, sharders []string, minConfirmation int) (*Receipt, error) {
+	required := int(math.Ceil(float64(minConfirmation*len(sharders)) / 100))
+	if required < 1 {
+		required = 1
+	}
+
+	urls := make([]string, 0, len(sharders))
+	for _, sharder := range sharders {
+		urls = append(urls, fmt.Sprintf("%v/%v%v", sharder, TXN_VERIFY_URL, txnHash))
+	}
+
+	counts := make(map[string]int)
+	found := make(map[string]*confirmation)
+	r := resty.New(resty.WithTransport(createTransport(resty.DefaultDialTimeout))).
+		Then(func(req *http.Request, resp *http.Response, respBody []byte, cf context.CancelFunc, err error) error {
+			if err != nil {
+				return err
+			}
+			if resp.StatusCode != http.StatusOK {
+				return errors.Throw(ErrInvalidRequest, strconv.Itoa(resp.StatusCode)+": "+resp.Status)
+			}
+			cfm := &confirmation{}
+			if err := json.Unmarshal(respBody, cfm); err != nil {
+				return err
+			}
+			if cfm.Txn == nil || cfm.BlockHash == "" {
+				return ErrNoTxnDetail
+			}
+			counts[cfm.BlockHash]++
+			found[cfm.BlockHash] = cfm
+			if counts[cfm.BlockHash] >= required {
+				cf()
+			}
+			return nil
+		})
+	r.DoGet(ctx, urls...).Wait()
+
+	for blockHash, count := range counts {
+		if count < required {
+			continue
+		}
+		cfm := found[blockHash]
+		return &Receipt{
+			Hash:        txnHash,
+			BlockHash:   cfm.BlockHash,
+			Round:       cfm.Round,
+			Status:      cfm.Txn.Status,
+			Fee:         cfm.Txn.TransactionFee,
+			Nonce:       cfm.Txn.TransactionNonce,
+			Output:      cfm.Txn.TransactionOutput,
+			Transaction: cfm.Txn,
+		}, nil
+	}
+	if len(counts) == 0 {
+		return nil, ErrNoTxnDetail
+	}
+	return nil, ErrTooLessConfirmation
+}
@@ -0,0 +1,98 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendAndConfirm(t *testing.T) {
+	txn := &Transaction{Hash: "txn_hash", Signature: "sig", TransactionFee: 10, TransactionNonce: 3}
+
+	var submitted int32
+	miner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/"+TXN_SUBMIT_URL, r.URL.Path)
+		atomic.AddInt32(&submitted, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer miner.Close()
+
+	newSharder := func(status int, confirmAfter int32) *httptest.Server {
+		var polls int32
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&polls, 1) <= confirmAfter {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			confirmed := *txn
+			confirmed.Status = status
+			confirmed.TransactionOutput = `{"allocation_id":"a1"}`
+			json.NewEncoder(w).Encode(map[string]interface{}{ //nolint
+				"block_hash": "block_hash",
+				"round":      42,
+				"txn":        confirmed,
+			})
+		}))
+	}
+
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "Test_Confirmed", status: TxnSuccess},
+		{name: "Test_Confirmed_Failed", status: TxnFail, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s1, s2 := newSharder(tt.status, 1), newSharder(tt.status, 2)
+			defer s1.Close()
+			defer s2.Close()
+
+			receipt, err := SendAndConfirm(context.Background(), txn, &ConfirmOptions{
+				Miners:          []string{miner.URL},
+				Sharders:        []string{s1.URL, s2.URL},
+				MinSubmit:       100,
+				MinConfirmation: 100,
+				PollInterval:    10 * time.Millisecond,
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NotNil(t, receipt)
+			require.Equal(t, "block_hash", receipt.BlockHash)
+			require.Equal(t, int64(42), receipt.Round)
+			require.Equal(t, uint64(10), receipt.Fee)
+
+			var out struct {
+				AllocationID string `json:"allocation_id"`
+			}
+			require.NoError(t, receipt.DecodeOutput(&out))
+			require.Equal(t, "a1", out.AllocationID)
+		})
+	}
+}
+
+func TestConfirmTimeout(t *testing.T) {
+	sharder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer sharder.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := Confirm(ctx, "txn_hash", &ConfirmOptions{
+		Miners:       []string{"unused"},
+		Sharders:     []string{sharder.URL},
+		PollInterval: 10 * time.Millisecond,
+	})
+	require.Error(t, err)
+}
@@ -151,6 +151,8 @@ const (
 
 	// Faucet SC
 	FAUCETSC_UPDATE_SETTINGS = "update-settings"
+	FAUCETSC_POUR            = "pour"
+	FAUCETSC_REFILL          = "refill"
 
 	// ZCNSC smart contract
 
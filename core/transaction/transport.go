@@ -7,14 +7,18 @@ import (
 	"net"
 	"net/http"
 	"time"
+
+	"github.com/0chain/gosdk/core/netconf"
 )
 
 func createTransport(dialTimeout time.Duration) *http.Transport {
 	return &http.Transport{
-		Dial: (&net.Dialer{
+		Proxy: netconf.Proxy(nil),
+		DialContext: netconf.DialContext(&net.Dialer{
 			Timeout: dialTimeout,
-		}).Dial,
+		}),
 		TLSHandshakeTimeout: dialTimeout,
+		TLSClientConfig:     netconf.TLSConfig(),
 	}
 
 }
@@ -6,10 +6,14 @@ package transaction
 import (
 	"net/http"
 	"time"
+
+	"github.com/0chain/gosdk/core/netconf"
 )
 
 func createTransport(dialTimeout time.Duration) *http.Transport {
 	return &http.Transport{
+		Proxy:               netconf.Proxy(nil),
 		TLSHandshakeTimeout: dialTimeout,
+		TLSClientConfig:     netconf.TLSConfig(),
 	}
 }
@@ -3,6 +3,8 @@ package util
 import (
 	"bytes"
 	"io"
+	"runtime"
+	"sync"
 
 	"github.com/0chain/errors"
 )
@@ -44,29 +46,60 @@ func (fmt *FixedMerkleTree) Write(buf []byte, chunkIndex int) error {
 		merkleChunkSize = 1
 	}
 
-	total := len(buf)
-	offset := 0
-	for i := 0; i < total; i += merkleChunkSize {
-		end := i + merkleChunkSize
-		if end > len(buf) {
-			end = len(buf)
-		}
+	if len(fmt.Leaves) != 1024 {
+		fmt.initLeaves()
+	}
 
-		if len(fmt.Leaves) != 1024 {
-			fmt.initLeaves()
-		}
+	blocks := (len(buf) + merkleChunkSize - 1) / merkleChunkSize
+	workers := runtime.GOMAXPROCS(0)
+	if workers > 1024 {
+		workers = 1024
+	}
+	if workers == 1 || blocks < minParallelMerkleBlocks {
+		return fmt.writeLeaves(buf, chunkIndex, merkleChunkSize, 0, 1)
+	}
+
+	// the leaves are independent, each worker hashes the blocks of its own
+	// leaves in order
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			errs[w] = fmt.writeLeaves(buf, chunkIndex, merkleChunkSize, w, workers)
+		}(w)
+	}
+	wg.Wait()
 
-		err := fmt.Leaves[offset].AddDataBlocks(buf[i:end], chunkIndex)
-		if errors.Is(err, ErrLeafNoSequenced) {
+	for _, err := range errs {
+		if err != nil {
 			return err
 		}
+	}
+	return nil
+}
+
+// minParallelMerkleBlocks is the number of blocks below which a chunk is
+// hashed by a single goroutine.
+const minParallelMerkleBlocks = 256
+
+// writeLeaves adds the blocks of buf to the leaves first, first+step, ...
+// Block n of buf goes to the leaf n%1024.
+func (fmt *FixedMerkleTree) writeLeaves(buf []byte, chunkIndex, merkleChunkSize, first, step int) error {
+	for leaf := first; leaf < 1024; leaf += step {
+		for i := leaf * merkleChunkSize; i < len(buf); i += 1024 * merkleChunkSize {
+			end := i + merkleChunkSize
+			if end > len(buf) {
+				end = len(buf)
+			}
 
-		offset++
-		if offset >= 1024 {
-			offset = 0
+			err := fmt.Leaves[leaf].AddDataBlocks(buf[i:end], chunkIndex)
+			if errors.Is(err, ErrLeafNoSequenced) {
+				return err
+			}
 		}
 	}
-
 	return nil
 }
 
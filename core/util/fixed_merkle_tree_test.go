@@ -44,6 +44,23 @@ func TestFixedMerkleTreeWithChunkSize(t *testing.T) {
 // It will return an error if the system's secure random
 // number generator fails to function correctly, in which
 // case the caller should not continue.
+func TestFixedMerkleTreeParallelWrite(t *testing.T) {
+	chunkSize := 64 * 1024
+	data := GenerateRandomBytes(3*chunkSize + 100)
+
+	parallel := NewFixedMerkleTree(chunkSize)
+	sequential := NewFixedMerkleTree(chunkSize)
+	for i := 0; i*chunkSize < len(data); i++ {
+		end := (i + 1) * chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		require.NoError(t, parallel.Write(data[i*chunkSize:end], i))
+		require.NoError(t, sequential.writeLeaves(data[i*chunkSize:end], i, chunkSize/1024, 0, 1))
+	}
+	require.Equal(t, sequential.GetMerkleRoot(), parallel.GetMerkleRoot())
+}
+
 func GenerateRandomBytes(n int) []byte {
 	b := make([]byte, n)
 	_, err := rand.Read(b)
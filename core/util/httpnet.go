@@ -9,6 +9,8 @@ import (
 	"net/url"
 	"os"
 	"time"
+
+	"github.com/0chain/gosdk/core/netconf"
 )
 
 type GetRequest struct {
@@ -83,6 +85,10 @@ func init() {
 }
 
 func httpDo(req *http.Request, ctx context.Context, cncl context.CancelFunc, f func(*http.Response, error) error) error {
+	if err := netconf.WaitRateLimit(ctx, req.URL.String()); err != nil {
+		cncl()
+		return err
+	}
 	c := make(chan error, 1)
 	go func() { c <- f(Client.Do(req.WithContext(ctx))) }()
 	defer cncl()
@@ -0,0 +1,105 @@
+package zcncrypto
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/tyler-smith/go-bip39"
+)
+
+const (
+	// HDPurpose is the BIP-44 purpose of the derivation path
+	HDPurpose = 44
+	// HDCoinType is the coin type of the derivation path used by the sdk
+	HDCoinType = 0x7a636e // "zcn"
+
+	hdHardened = 0x80000000
+	hdSeedKey  = "0chain seed"
+)
+
+// HDPath returns the derivation path of account:
+//
+//	m/44'/8020846'/account'/0'/0'
+//
+// All levels are hardened, as BLS and ed25519 keys have no public derivation.
+// Account 0 is the wallet of the mnemonic created before HD derivation, see
+// DeriveKeys.
+func HDPath(account uint32) string {
+	return fmt.Sprintf("m/%d'/%d'/%d'/0'/0'", HDPurpose, HDCoinType, account)
+}
+
+// DeriveKeys returns the wallet of the account of mnemonic for the signature
+// scheme. Account 0 is the wallet recovered by RecoverKeys, the other ones
+// are derived from the BIP-39 seed along HDPath as in SLIP-0010: each
+// hardened index i gives I = HMAC-SHA512(chain code, 0x00 || key || i), its
+// left half being the next key and its right half the next chain code. The
+// final key is the ed25519 seed or the little endian BLS secret key.
+func DeriveKeys(scheme, mnemonic string, account uint32) (*Wallet, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("derive_keys", "invalid mnemonic")
+	}
+	if account >= hdHardened {
+		return nil, errors.New("derive_keys", "account index out of range")
+	}
+	if account == 0 {
+		return NewSignatureScheme(scheme).RecoverKeys(mnemonic)
+	}
+
+	key := deriveHDKey(bip39.NewSeed(mnemonic, ""), []uint32{HDPurpose, HDCoinType, account, 0, 0})
+
+	w := &Wallet{Keys: make([]KeyPair, 1)}
+	switch scheme {
+	case "ed25519":
+		private := ed25519.NewKeyFromSeed(key)
+		public := private.Public().(ed25519.PublicKey)
+		w.Keys[0].PrivateKey = hex.EncodeToString(private)
+		w.Keys[0].PublicKey = hex.EncodeToString(public)
+		w.ClientID = encryption.Hash([]byte(public))
+	case "bls0chain":
+		if BlsSignerInstance == nil {
+			return nil, errors.New("derive_keys", "bls is not supported on this platform")
+		}
+		sk := BlsSignerInstance.NewSecretKey()
+		if err := sk.SetLittleEndian(key); err != nil {
+			return nil, errors.Wrap(err, "derive_keys")
+		}
+		pub := sk.GetPublicKey()
+		w.Keys[0].PrivateKey = sk.SerializeToHexStr()
+		w.Keys[0].PublicKey = pub.SerializeToHexStr()
+		w.ClientID = encryption.Hash(pub.Serialize())
+	default:
+		return nil, errors.Newf("derive_keys", "unsupported signature scheme %q", scheme)
+	}
+	w.ClientKey = w.Keys[0].PublicKey
+	w.Mnemonic = mnemonic
+	w.Version = CryptoVersion
+	w.DateCreated = time.Now().Format(time.RFC3339)
+	return w, nil
+}
+
+// deriveHDKey derives the 32 bytes key of path from seed, every index hardened.
+func deriveHDKey(seed []byte, path []uint32) []byte {
+	mac := hmac.New(sha512.New, []byte(hdSeedKey))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+
+	for _, index := range path {
+		data := make([]byte, 37)
+		copy(data[1:], key)
+		binary.BigEndian.PutUint32(data[33:], index|hdHardened)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+		key, chainCode = sum[:32], sum[32:]
+	}
+	return key
+}
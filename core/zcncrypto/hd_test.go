@@ -0,0 +1,51 @@
+package zcncrypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const hdMnemonic = "glare mistake gun joke bid spare across diagram wrap cube swear cactus cave repeat you brave few best wild lion pitch pole original wasp"
+
+func TestDeriveKeys(t *testing.T) {
+	for _, scheme := range []string{"bls0chain", "ed25519"} {
+		t.Run("Test_"+scheme, func(t *testing.T) {
+			legacy, err := NewSignatureScheme(scheme).RecoverKeys(hdMnemonic)
+			require.NoError(t, err)
+			w0, err := DeriveKeys(scheme, hdMnemonic, 0)
+			require.NoError(t, err)
+			require.Equal(t, legacy.ClientID, w0.ClientID)
+
+			ids := map[string]bool{w0.ClientID: true}
+			for account := uint32(1); account <= 3; account++ {
+				w, err := DeriveKeys(scheme, hdMnemonic, account)
+				require.NoError(t, err)
+				require.False(t, ids[w.ClientID], "duplicate client id")
+				ids[w.ClientID] = true
+
+				again, err := DeriveKeys(scheme, hdMnemonic, account)
+				require.NoError(t, err)
+				require.Equal(t, w.Keys, again.Keys)
+
+				hash := Sha3Sum256("data")
+				sig, err := w.Sign(hash, scheme)
+				require.NoError(t, err)
+				verifier := NewSignatureScheme(scheme)
+				require.NoError(t, verifier.SetPublicKey(w.ClientKey))
+				ok, err := verifier.Verify(sig, hash)
+				require.NoError(t, err)
+				require.True(t, ok)
+			}
+		})
+	}
+
+	t.Run("Test_Invalid_Mnemonic", func(t *testing.T) {
+		_, err := DeriveKeys("ed25519", "not a mnemonic", 1)
+		require.Error(t, err)
+	})
+}
+
+func TestHDPath(t *testing.T) {
+	require.Equal(t, "m/44'/8020846'/5'/0'/0'", HDPath(5))
+}
@@ -0,0 +1,217 @@
+package zcncrypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/0chain/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// KeystoreVersion of the keystore envelope
+	KeystoreVersion = 1
+
+	KDFScrypt   = "scrypt"
+	KDFArgon2id = "argon2id"
+
+	keystoreCipher = "aes-256-gcm"
+	keystoreKeyLen = 32
+	keystoreSalt   = 32
+
+	// the KDF parameters above these caps are rejected, so that a crafted
+	// keystore can't exhaust the memory or the CPU of a mobile device
+	maxKDFMemory = 256 << 20 // bytes
+	maxKDFPasses = 16        // scrypt p, argon2id time
+)
+
+var (
+	// ErrWrongPassphrase is returned when a keystore can't be decrypted.
+	ErrWrongPassphrase = errors.New("keystore", "wrong passphrase or corrupted keystore")
+	// ErrNotKeystore is returned when decrypting data that isn't a keystore.
+	ErrNotKeystore = errors.New("keystore", "not a keystore")
+)
+
+// Keystore is the JSON envelope of an encrypted wallet. The wallet JSON is
+// encrypted with AES-256-GCM by a key derived from the passphrase with KDF,
+// the client id is kept in clear to find a wallet without its passphrase.
+type Keystore struct {
+	Version  int            `json:"version"`
+	ClientID string         `json:"client_id"`
+	Crypto   KeystoreCrypto `json:"crypto"`
+}
+
+// KeystoreCrypto are the encryption parameters of a keystore, binary values
+// are hex encoded.
+type KeystoreCrypto struct {
+	Cipher     string    `json:"cipher"`
+	CipherText string    `json:"ciphertext"`
+	Nonce      string    `json:"nonce"`
+	KDF        string    `json:"kdf"`
+	KDFParams  KDFParams `json:"kdfparams"`
+}
+
+// KDFParams of scrypt (N, R, P) or argon2id (Time, Memory in KiB, Threads).
+type KDFParams struct {
+	Salt    string `json:"salt"`
+	KeyLen  int    `json:"dklen"`
+	N       int    `json:"n,omitempty"`
+	R       int    `json:"r,omitempty"`
+	P       int    `json:"p,omitempty"`
+	Time    uint32 `json:"time,omitempty"`
+	Memory  uint32 `json:"memory,omitempty"`
+	Threads uint8  `json:"threads,omitempty"`
+}
+
+// DefaultScryptParams use 32MB to derive a key, in tens of milliseconds on a
+// desktop CPU and a few times longer on a phone.
+func DefaultScryptParams() KDFParams {
+	return KDFParams{KeyLen: keystoreKeyLen, N: 1 << 15, R: 8, P: 1}
+}
+
+// DefaultArgon2idParams are the parameters recommended by RFC 9106 for
+// memory constrained environments.
+func DefaultArgon2idParams() KDFParams {
+	return KDFParams{KeyLen: keystoreKeyLen, Time: 3, Memory: 64 * 1024, Threads: 4}
+}
+
+// EncryptWallet returns the keystore of w encrypted with passphrase. kdf is
+// KDFScrypt or KDFArgon2id, params without salt get a random one.
+func EncryptWallet(w *Wallet, passphrase, kdf string, params KDFParams) ([]byte, error) {
+	plain, err := json.Marshal(w)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Salt == "" {
+		salt := make([]byte, keystoreSalt)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		params.Salt = hex.EncodeToString(salt)
+	}
+	if params.KeyLen == 0 {
+		params.KeyLen = keystoreKeyLen
+	}
+	key, err := deriveKey(passphrase, kdf, params)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	// the client id is authenticated so that it can't be swapped
+	sealed := gcm.Seal(nil, nonce, plain, []byte(w.ClientID))
+
+	return json.MarshalIndent(Keystore{
+		Version:  KeystoreVersion,
+		ClientID: w.ClientID,
+		Crypto: KeystoreCrypto{
+			Cipher:     keystoreCipher,
+			CipherText: hex.EncodeToString(sealed),
+			Nonce:      hex.EncodeToString(nonce),
+			KDF:        kdf,
+			KDFParams:  params,
+		},
+	}, "", "  ")
+}
+
+// DecryptWallet returns the wallet of a keystore returned by EncryptWallet.
+func DecryptWallet(data []byte, passphrase string) (*Wallet, error) {
+	var ks Keystore
+	if err := json.Unmarshal(data, &ks); err != nil || ks.Version == 0 || ks.Crypto.CipherText == "" {
+		return nil, ErrNotKeystore
+	}
+	if ks.Version != KeystoreVersion {
+		return nil, errors.Newf("keystore", "unsupported version %d", ks.Version)
+	}
+	if ks.Crypto.Cipher != keystoreCipher {
+		return nil, errors.Newf("keystore", "unsupported cipher %q", ks.Crypto.Cipher)
+	}
+
+	key, err := deriveKey(passphrase, ks.Crypto.KDF, ks.Crypto.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, ErrNotKeystore
+	}
+	nonce, err := hex.DecodeString(ks.Crypto.Nonce)
+	if err != nil {
+		return nil, ErrNotKeystore
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, ErrNotKeystore
+	}
+	plain, err := gcm.Open(nil, nonce, sealed, []byte(ks.ClientID))
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	w := &Wallet{}
+	if err := json.Unmarshal(plain, w); err != nil {
+		return nil, errors.Wrap(err, "keystore")
+	}
+	return w, nil
+}
+
+// IsKeystore tells whether data is a keystore rather than a plaintext wallet.
+func IsKeystore(data []byte) bool {
+	var ks Keystore
+	return json.Unmarshal(data, &ks) == nil && ks.Version != 0 && ks.Crypto.CipherText != ""
+}
+
+func deriveKey(passphrase, kdf string, p KDFParams) ([]byte, error) {
+	salt, err := hex.DecodeString(p.Salt)
+	if err != nil || len(salt) == 0 {
+		return nil, errors.New("keystore", "invalid salt")
+	}
+	if p.KeyLen != keystoreKeyLen {
+		return nil, errors.Newf("keystore", "invalid key length %d", p.KeyLen)
+	}
+
+	switch kdf {
+	case KDFScrypt:
+		// scrypt uses 128 * N * r bytes
+		if p.N <= 0 || p.R <= 0 || p.P <= 0 {
+			return nil, errors.New("keystore", "invalid scrypt parameters")
+		}
+		if p.N > maxKDFMemory/128/p.R || p.P > maxKDFPasses {
+			return nil, errors.New("keystore", "scrypt parameters too costly")
+		}
+		return scrypt.Key([]byte(passphrase), salt, p.N, p.R, p.P, p.KeyLen)
+	case KDFArgon2id:
+		if p.Time == 0 || p.Memory == 0 || p.Threads == 0 {
+			return nil, errors.New("keystore", "invalid argon2id parameters")
+		}
+		// Memory is in KiB
+		if p.Memory > maxKDFMemory/1024 || p.Time > maxKDFPasses {
+			return nil, errors.New("keystore", "argon2id parameters too costly")
+		}
+		return argon2.IDKey([]byte(passphrase), salt, p.Time, p.Memory, p.Threads, uint32(p.KeyLen)), nil
+	}
+	return nil, errors.Newf("keystore", "unsupported kdf %q", kdf)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
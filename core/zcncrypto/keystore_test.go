@@ -0,0 +1,80 @@
+package zcncrypto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeystore(t *testing.T) {
+	w := &Wallet{
+		ClientID:  "client",
+		ClientKey: "public",
+		Keys:      []KeyPair{{PublicKey: "public", PrivateKey: "private"}},
+		Mnemonic:  "glare mistake gun",
+	}
+	// cheap parameters, the defaults are too slow for tests
+	tests := []struct {
+		name   string
+		kdf    string
+		params KDFParams
+	}{
+		{name: "Test_Scrypt", kdf: KDFScrypt, params: KDFParams{N: 1 << 10, R: 8, P: 1}},
+		{name: "Test_Argon2id", kdf: KDFArgon2id, params: KDFParams{Time: 1, Memory: 1024, Threads: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := EncryptWallet(w, "secret", tt.kdf, tt.params)
+			require.NoError(t, err)
+			require.True(t, IsKeystore(data))
+			require.NotContains(t, string(data), "private")
+			require.NotContains(t, string(data), "glare")
+
+			got, err := DecryptWallet(data, "secret")
+			require.NoError(t, err)
+			require.Equal(t, w, got)
+
+			_, err = DecryptWallet(data, "wrong")
+			require.ErrorIs(t, err, ErrWrongPassphrase)
+
+			var ks Keystore
+			require.NoError(t, json.Unmarshal(data, &ks))
+			ks.ClientID = "other"
+			swapped, err := json.Marshal(ks)
+			require.NoError(t, err)
+			_, err = DecryptWallet(swapped, "secret")
+			require.ErrorIs(t, err, ErrWrongPassphrase)
+		})
+	}
+
+	t.Run("Test_Costly_Params", func(t *testing.T) {
+		data, err := EncryptWallet(w, "secret", KDFScrypt, KDFParams{N: 1 << 10, R: 8, P: 1})
+		require.NoError(t, err)
+
+		for _, params := range []KDFParams{
+			{N: 1 << 20, R: 8, P: 1},
+			{N: 1 << 10, R: 8, P: 1 << 20},
+		} {
+			var ks Keystore
+			require.NoError(t, json.Unmarshal(data, &ks))
+			params.Salt, params.KeyLen = ks.Crypto.KDFParams.Salt, ks.Crypto.KDFParams.KeyLen
+			ks.Crypto.KDFParams = params
+			costly, err := json.Marshal(ks)
+			require.NoError(t, err)
+			_, err = DecryptWallet(costly, "secret")
+			require.EqualError(t, err, "keystore: scrypt parameters too costly")
+		}
+
+		_, err = EncryptWallet(w, "secret", KDFArgon2id, KDFParams{Time: 1, Memory: 1 << 20, Threads: 1})
+		require.EqualError(t, err, "keystore: argon2id parameters too costly")
+	})
+
+	t.Run("Test_Plaintext_Wallet", func(t *testing.T) {
+		data, err := json.Marshal(w)
+		require.NoError(t, err)
+		require.False(t, IsKeystore(data))
+		_, err = DecryptWallet(data, "secret")
+		require.ErrorIs(t, err, ErrNotKeystore)
+	})
+}
@@ -0,0 +1,83 @@
+package zcncrypto
+
+import (
+	"github.com/0chain/errors"
+)
+
+// Signer signs hashes for a wallet. Implementations backed by an HSM, a KMS
+// or a remote signer keep the private key out of the process memory.
+type Signer interface {
+	// PublicKey is the client key of the wallet, in hex
+	PublicKey() string
+	// Sign returns the signature of hash, in hex
+	Sign(hash string) (string, error)
+}
+
+// KeySigner signs with the in memory keys of a wallet. It is the signer used
+// when none is set. A split key wallet signs with each of its keys, the
+// signatures being aggregated.
+type KeySigner struct {
+	scheme    string
+	publicKey string
+	keys      []KeyPair
+}
+
+// NewKeySigner returns the in memory signer of w for the signature scheme,
+// "bls0chain" or "ed25519".
+func NewKeySigner(scheme string, w *Wallet) (*KeySigner, error) {
+	if w == nil || len(w.Keys) == 0 {
+		return nil, errors.New("invalid_wallet", "wallet has no keys")
+	}
+	for _, kv := range w.Keys {
+		if kv.PrivateKey == "" {
+			return nil, errors.New("invalid_wallet", "wallet has no private key")
+		}
+	}
+	return &KeySigner{scheme: scheme, publicKey: w.ClientKey, keys: w.Keys}, nil
+}
+
+// PublicKey implements Signer.
+func (s *KeySigner) PublicKey() string {
+	return s.publicKey
+}
+
+// Sign implements Signer.
+func (s *KeySigner) Sign(hash string) (string, error) {
+	var signature string
+	for _, kv := range s.keys {
+		ss := NewSignatureScheme(s.scheme)
+		if err := ss.SetPrivateKey(kv.PrivateKey); err != nil {
+			return "", err
+		}
+
+		var err error
+		if signature == "" {
+			signature, err = ss.Sign(hash)
+		} else {
+			signature, err = ss.Add(signature, hash)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return signature, nil
+}
+
+type funcSigner struct {
+	publicKey string
+	sign      func(hash string) (string, error)
+}
+
+// NewFuncSigner adapts a signing function, e.g. a call to a remote signer,
+// to Signer.
+func NewFuncSigner(publicKey string, sign func(hash string) (string, error)) Signer {
+	return &funcSigner{publicKey: publicKey, sign: sign}
+}
+
+func (s *funcSigner) PublicKey() string {
+	return s.publicKey
+}
+
+func (s *funcSigner) Sign(hash string) (string, error) {
+	return s.sign(hash)
+}
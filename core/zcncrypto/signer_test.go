@@ -0,0 +1,45 @@
+package zcncrypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySigner(t *testing.T) {
+	for _, scheme := range []string{"bls0chain", "ed25519"} {
+		t.Run("Test_"+scheme, func(t *testing.T) {
+			w, err := NewSignatureScheme(scheme).GenerateKeys()
+			require.NoError(t, err)
+
+			s, err := NewKeySigner(scheme, w)
+			require.NoError(t, err)
+			require.Equal(t, w.ClientKey, s.PublicKey())
+
+			hash := Sha3Sum256("data")
+			sig, err := s.Sign(hash)
+			require.NoError(t, err)
+
+			verifier := NewSignatureScheme(scheme)
+			require.NoError(t, verifier.SetPublicKey(w.ClientKey))
+			ok, err := verifier.Verify(sig, hash)
+			require.NoError(t, err)
+			require.True(t, ok)
+		})
+	}
+
+	t.Run("Test_No_Private_Key", func(t *testing.T) {
+		_, err := NewKeySigner("ed25519", &Wallet{Keys: []KeyPair{{PublicKey: "a"}}})
+		require.Error(t, err)
+	})
+}
+
+func TestFuncSigner(t *testing.T) {
+	s := NewFuncSigner("key", func(hash string) (string, error) {
+		return "sig:" + hash, nil
+	})
+	require.Equal(t, "key", s.PublicKey())
+	sig, err := s.Sign("h")
+	require.NoError(t, err)
+	require.Equal(t, "sig:h", sig)
+}
@@ -0,0 +1,36 @@
+//go:build !js && !wasm
+// +build !js,!wasm
+
+package zcncrypto
+
+import (
+	"github.com/0chain/errors"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+// RecoverThresholdSignature aggregates the signatures of a message by at
+// least threshold key shares, see GenerateThresholdKeyShares, into the
+// signature of the original key. ids are the hex ids of the shares, in the
+// order of sigs.
+func RecoverThresholdSignature(ids, sigs []string) (string, error) {
+	if len(ids) == 0 || len(ids) != len(sigs) {
+		return "", errors.New("recover_threshold_signature", "as many ids as signatures are required")
+	}
+
+	idVec := make([]bls.ID, len(ids))
+	sigVec := make([]bls.Sign, len(sigs))
+	for i := range ids {
+		if err := idVec[i].SetHexString(ids[i]); err != nil {
+			return "", errors.Wrap(err, "invalid share id "+ids[i])
+		}
+		if err := sigVec[i].DeserializeHexStr(sigs[i]); err != nil {
+			return "", errors.Wrap(err, "invalid signature of share "+ids[i])
+		}
+	}
+
+	var sig bls.Sign
+	if err := sig.Recover(sigVec, idVec); err != nil {
+		return "", errors.Wrap(err, "recover_threshold_signature")
+	}
+	return sig.SerializeToHexStr(), nil
+}
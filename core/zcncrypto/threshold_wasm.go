@@ -0,0 +1,12 @@
+//go:build js && wasm
+// +build js,wasm
+
+package zcncrypto
+
+import "github.com/0chain/errors"
+
+// RecoverThresholdSignature isn't supported on wasm, signatures are
+// aggregated by bls_wasm in js.
+func RecoverThresholdSignature(ids, sigs []string) (string, error) {
+	return "", errors.New("wasm_not_support", "please recover threshold signatures by bls_wasm in js")
+}
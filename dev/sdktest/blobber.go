@@ -0,0 +1,302 @@
+// Package sdktest provides in-process blobbers and sharders to run the sdk
+// against in tests, without a network.
+package sdktest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/0chain/gosdk/dev"
+	"github.com/0chain/gosdk/dev/blobber/model"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/gorilla/mux"
+)
+
+// Blobber is an in-process blobber keeping the files uploaded and committed
+// to it in memory. It serves enough of the blobber API to upload files, list
+// directories and get the meta of files, the content of the files being
+// discarded. The uploads and commits are applied once per idempotency key,
+// the retries being answered 409 Conflict. The write markers aren't verified, and no latest write marker is
+// returned with the reference paths, so that the sdk doesn't verify the
+// allocation roots either.
+type Blobber struct {
+	*dev.Server
+	ID string
+
+	mu sync.Mutex
+	// files committed by allocation and path
+	files map[string]map[string]*model.Ref
+	// pending files uploaded by connection, committed with it
+	pending map[string][]*model.Ref
+	// applied are the idempotency keys of the writes applied
+	applied map[string]bool
+	replays int
+}
+
+// NewBlobber starts a blobber, to be closed with Close.
+func NewBlobber(id string) *Blobber {
+	b := &Blobber{
+		Server:  dev.NewServer(),
+		ID:      id,
+		files:   make(map[string]map[string]*model.Ref),
+		pending: make(map[string][]*model.Ref),
+		applied: make(map[string]bool),
+	}
+
+	b.HandleFunc("/v1/file/upload/{allocation}", b.upload).Methods(http.MethodPut, http.MethodPost)
+	b.HandleFunc("/v1/connection/commit/{allocation}", b.commit).Methods(http.MethodPost)
+	b.HandleFunc("/v1/file/referencepath/{allocation}", b.referencePath).Methods(http.MethodGet)
+	b.HandleFunc("/v1/file/list/{allocation}", b.list).Methods(http.MethodGet)
+	b.HandleFunc("/v1/file/meta/{allocation}", b.meta).Methods(http.MethodPost)
+	b.HandleFunc("/v1/writemarker/lock/{allocation}", b.lock).Methods(http.MethodPost)
+	b.HandleFunc("/v1/writemarker/lock/{allocation}/{connection}", b.lock).Methods(http.MethodDelete)
+
+	return b
+}
+
+// StorageNode returns the node of the blobber for the blobbers of an
+// allocation.
+func (b *Blobber) StorageNode() *blockchain.StorageNode {
+	return &blockchain.StorageNode{ID: b.ID, Baseurl: b.URL}
+}
+
+// Files returns the paths of the files committed to allocationID, sorted.
+func (b *Blobber) Files(allocationID string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	paths := make([]string, 0, len(b.files[allocationID]))
+	for p := range b.files[allocationID] {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Replays returns the number of writes retried once applied.
+func (b *Blobber) Replays() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.replays
+}
+
+// replayed answers the retry of a write already applied, or records the
+// write as applied.
+func (b *Blobber) replayed(w http.ResponseWriter, req *http.Request) bool {
+	key := req.Header.Get(zboxutil.IDEMPOTENCY_KEY_HEADER)
+	if key == "" {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.applied[key] {
+		b.applied[key] = true
+		return false
+	}
+	b.replays++
+	w.Header().Set(zboxutil.IDEMPOTENCY_KEY_HEADER, key)
+	http.Error(w, "already applied", http.StatusConflict)
+	return true
+}
+
+func (b *Blobber) upload(w http.ResponseWriter, req *http.Request) {
+	allocationID := mux.Vars(req)["allocation"]
+
+	var form model.UploadFormData
+	if err := json.Unmarshal([]byte(req.FormValue("uploadMeta")), &form); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if b.replayed(w, req) {
+		return
+	}
+
+	connectionID := form.ConnectionID
+	if connectionID == "" {
+		connectionID = req.FormValue("connection_id")
+	}
+
+	if form.IsFinal {
+		size := form.UploadOffset
+		if _, header, err := req.FormFile("uploadFile"); err == nil {
+			size += header.Size
+		}
+
+		ref := &model.Ref{
+			Type:           model.FILE,
+			AllocationID:   allocationID,
+			Name:           path.Base(form.Path),
+			Path:           path.Clean(form.Path),
+			ContentHash:    form.ContentHash,
+			MerkleRoot:     form.MerkleRoot,
+			ActualFileSize: form.ActualSize,
+			ActualFileHash: form.ActualHash,
+			MimeType:       form.MimeType,
+			ChunkSize:      form.ChunkSize,
+			Size:           size,
+		}
+		if ref.ChunkSize == 0 {
+			ref.ChunkSize = model.CHUNK_SIZE
+		}
+
+		b.mu.Lock()
+		b.pending[connectionID] = append(b.pending[connectionID], ref)
+		b.mu.Unlock()
+	}
+
+	writeJSON(w, http.StatusOK, &model.UploadResult{
+		Filename:   form.Filename,
+		Hash:       form.ChunkHash,
+		MerkleRoot: form.MerkleRoot,
+	})
+}
+
+func (b *Blobber) commit(w http.ResponseWriter, req *http.Request) {
+	allocationID := mux.Vars(req)["allocation"]
+
+	writeMarker := &model.WriteMarker{}
+	if err := json.Unmarshal([]byte(req.FormValue("write_marker")), writeMarker); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if b.replayed(w, req) {
+		return
+	}
+
+	connectionID := req.FormValue("connection_id")
+
+	b.mu.Lock()
+	files := b.files[allocationID]
+	if files == nil {
+		files = make(map[string]*model.Ref)
+		b.files[allocationID] = files
+	}
+	for _, ref := range b.pending[connectionID] {
+		files[ref.Path] = ref
+	}
+	delete(b.pending, connectionID)
+	b.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, &model.CommitResult{
+		AllocationRoot: writeMarker.AllocationRoot,
+		WriteMarker:    writeMarker,
+		Success:        true,
+	})
+}
+
+func (b *Blobber) referencePath(w http.ResponseWriter, req *http.Request) {
+	root := b.tree(mux.Vars(req)["allocation"])
+	writeJSON(w, http.StatusOK, model.BuildReferencePathResult(root))
+}
+
+func (b *Blobber) list(w http.ResponseWriter, req *http.Request) {
+	root := b.tree(mux.Vars(req)["allocation"])
+	root.CalculateHash(context.TODO()) //nolint: errcheck
+
+	ref := find(root, path.Clean("/"+req.FormValue("path")))
+	if ref == nil {
+		http.Error(w, `{"code":"invalid_parameters","error":"invalid path"}`, http.StatusBadRequest)
+		return
+	}
+
+	list := make([]map[string]interface{}, 0, len(ref.Children))
+	for _, child := range ref.Children {
+		list = append(list, child.GetListingData(context.TODO()))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"meta_data": ref.GetListingData(context.TODO()),
+		"list":      list,
+	})
+}
+
+func (b *Blobber) meta(w http.ResponseWriter, req *http.Request) {
+	allocationID := mux.Vars(req)["allocation"]
+	pathHash := req.FormValue("path_hash")
+	if p := req.FormValue("path"); p != "" {
+		pathHash = model.GetReferenceLookup(allocationID, p)
+	}
+
+	root := b.tree(allocationID)
+	root.CalculateHash(context.TODO()) //nolint: errcheck
+
+	refs := []*model.Ref{root}
+	for len(refs) > 0 {
+		ref := refs[0]
+		refs = append(refs[1:], ref.Children...)
+		if ref.LookupHash == pathHash {
+			writeJSON(w, http.StatusOK, ref.GetListingData(context.TODO()))
+			return
+		}
+	}
+
+	http.Error(w, `{"code":"invalid_parameters","error":"file not found"}`, http.StatusBadRequest)
+}
+
+func (b *Blobber) lock(w http.ResponseWriter, req *http.Request) {
+	// WMLockStatusOK
+	writeJSON(w, http.StatusOK, map[string]interface{}{"status": 2})
+}
+
+// tree returns the directory tree of the files committed to allocationID.
+func (b *Blobber) tree(allocationID string) *model.Ref {
+	root := &model.Ref{Type: model.DIRECTORY, AllocationID: allocationID, Name: "/", Path: "/"}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	paths := make([]string, 0, len(b.files[allocationID]))
+	for p := range b.files[allocationID] {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		dir := root
+		parts := strings.Split(strings.TrimPrefix(path.Dir(p), "/"), "/")
+		for i, name := range parts {
+			if name == "" {
+				continue
+			}
+			dirPath := "/" + strings.Join(parts[:i+1], "/")
+			next := find(dir, dirPath)
+			if next == nil {
+				next = &model.Ref{Type: model.DIRECTORY, AllocationID: allocationID, Name: name, Path: dirPath}
+				dir.Children = append(dir.Children, next)
+			}
+			dir = next
+		}
+		file := *b.files[allocationID][p]
+		dir.Children = append(dir.Children, &file)
+	}
+
+	return root
+}
+
+// find returns the ref of p in the tree of ref, nil if there's none.
+func find(ref *model.Ref, p string) *model.Ref {
+	if ref.Path == p {
+		return ref
+	}
+	for _, child := range ref.Children {
+		if child.Path == p || strings.HasPrefix(p, strings.TrimSuffix(child.Path, "/")+"/") {
+			if found := find(child, p); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v) //nolint: errcheck
+}
@@ -0,0 +1,193 @@
+package sdktest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/transaction"
+	"github.com/0chain/gosdk/dev"
+	"github.com/gorilla/mux"
+)
+
+// Sharder is an in-process sharder, serving the endpoint of the miners to put
+// transactions too. The transactions put are confirmed at once in a block of
+// their own, their value being transferred between the balances of the
+// clients. The sharder doesn't return merkle paths, the confirmations can be
+// polled with transaction.Confirm but not verified optimistically.
+type Sharder struct {
+	*dev.Server
+
+	mu       sync.Mutex
+	round    int64
+	balances map[string]int64
+	nonces   map[string]int64
+	txns     map[string]*confirmation
+	order    []string
+	scRest   map[string]interface{}
+}
+
+type confirmation struct {
+	BlockHash string                   `json:"block_hash"`
+	Round     int64                    `json:"round"`
+	Txn       *transaction.Transaction `json:"txn"`
+}
+
+// NewSharder starts a sharder, to be closed with Close.
+func NewSharder() *Sharder {
+	s := &Sharder{
+		Server:   dev.NewServer(),
+		balances: make(map[string]int64),
+		nonces:   make(map[string]int64),
+		txns:     make(map[string]*confirmation),
+		scRest:   make(map[string]interface{}),
+	}
+
+	s.HandleFunc("/v1/transaction/put", s.put).Methods(http.MethodPost)
+	s.HandleFunc("/v1/transaction/get/confirmation", s.confirmation).Methods(http.MethodGet)
+	s.HandleFunc("/v1/client/get/balance", s.balance).Methods(http.MethodGet)
+	s.HandleFunc("/v1/screst/{sc}/{path}", s.screst).Methods(http.MethodGet)
+
+	return s
+}
+
+// SetBalance sets the balance of clientID, in SAS.
+func (s *Sharder) SetBalance(clientID string, balance int64) {
+	s.mu.Lock()
+	s.balances[clientID] = balance
+	s.mu.Unlock()
+}
+
+// Balance returns the balance of clientID, in SAS.
+func (s *Sharder) Balance(clientID string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.balances[clientID]
+}
+
+// Transactions returns the transactions put, in order.
+func (s *Sharder) Transactions() []*transaction.Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	txns := make([]*transaction.Transaction, 0, len(s.order))
+	for _, hash := range s.order {
+		txns = append(txns, s.txns[hash].Txn)
+	}
+	return txns
+}
+
+// HandleSCRest serves response, as JSON, to the smart contract REST queries
+// of path on scAddress, e.g. "getAllocation".
+func (s *Sharder) HandleSCRest(scAddress, path string, response interface{}) {
+	s.mu.Lock()
+	s.scRest[scAddress+"/"+path] = response
+	s.mu.Unlock()
+}
+
+// HandleSCRestFunc serves the response of handler, as JSON, to the smart
+// contract REST queries of path on scAddress. handler is called with the
+// query parameters, e.g. to serve the pages of a paginated query.
+func (s *Sharder) HandleSCRestFunc(scAddress, path string, handler func(params url.Values) interface{}) {
+	s.mu.Lock()
+	s.scRest[scAddress+"/"+path] = handler
+	s.mu.Unlock()
+}
+
+func (s *Sharder) put(w http.ResponseWriter, req *http.Request) {
+	txn := &transaction.Transaction{}
+	if err := json.NewDecoder(req.Body).Decode(txn); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	if txn.Hash == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request: missing hash"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.txns[txn.Hash]; ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "transaction already exists"})
+		return
+	}
+
+	value := int64(txn.Value + txn.TransactionFee)
+	if value > 0 && s.balances[txn.ClientID] < value {
+		txn.Status = transaction.TxnFail
+		txn.TransactionOutput = "insufficient balance"
+	} else {
+		txn.Status = transaction.TxnSuccess
+		s.balances[txn.ClientID] -= value
+		if txn.ToClientID != "" {
+			s.balances[txn.ToClientID] += int64(txn.Value)
+		}
+	}
+	if txn.TransactionNonce > s.nonces[txn.ClientID] {
+		s.nonces[txn.ClientID] = txn.TransactionNonce
+	}
+
+	s.round++
+	s.txns[txn.Hash] = &confirmation{
+		BlockHash: encryption.Hash("block:" + strconv.FormatInt(s.round, 10)),
+		Round:     s.round,
+		Txn:       txn,
+	}
+	s.order = append(s.order, txn.Hash)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"entity": txn})
+}
+
+func (s *Sharder) confirmation(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	cfm, ok := s.txns[req.FormValue("hash")]
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"code": "entity_not_found", "error": "transaction not found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, cfm)
+}
+
+func (s *Sharder) balance(w http.ResponseWriter, req *http.Request) {
+	clientID := req.FormValue("client_id")
+
+	s.mu.Lock()
+	balance, ok := s.balances[clientID]
+	nonce := s.nonces[clientID]
+	round := s.round
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "value not present"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"client_id": clientID,
+		"round":     round,
+		"balance":   balance,
+		"nonce":     nonce,
+	})
+}
+
+func (s *Sharder) screst(w http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+
+	s.mu.Lock()
+	response, ok := s.scRest[vars["sc"]+"/"+vars["path"]]
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request: " + req.URL.Path})
+		return
+	}
+	if handler, ok := response.(func(url.Values) interface{}); ok {
+		response = handler(req.URL.Query())
+	}
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,62 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/core/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharder(t *testing.T) {
+	s := NewSharder()
+	defer s.Close()
+
+	s.SetBalance("alice", 100)
+
+	opts := &transaction.ConfirmOptions{
+		Miners:          []string{s.URL},
+		Sharders:        []string{s.URL},
+		MinSubmit:       100,
+		MinConfirmation: 100,
+		PollInterval:    time.Millisecond,
+		MaxPollInterval: 10 * time.Millisecond,
+		Timeout:         5 * time.Second,
+	}
+
+	for _, tc := range []struct {
+		name    string
+		txn     *transaction.Transaction
+		balance int64
+		wantErr bool
+	}{
+		{
+			name:    "Test_Transfer",
+			txn:     &transaction.Transaction{Hash: "txn1", Signature: "sig", ClientID: "alice", ToClientID: "bob", Value: 30, TransactionFee: 1, TransactionNonce: 1},
+			balance: 69,
+		},
+		{
+			name:    "Test_Insufficient_Balance",
+			txn:     &transaction.Transaction{Hash: "txn2", Signature: "sig", ClientID: "alice", ToClientID: "bob", Value: 300, TransactionNonce: 2},
+			balance: 69,
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			receipt, err := transaction.SendAndConfirm(context.Background(), tc.txn, opts)
+			if tc.wantErr {
+				require.ErrorIs(t, err, &constants.ErrTxnRejected{})
+			} else {
+				require.NoError(t, err)
+			}
+			require.NotNil(t, receipt)
+			require.Equal(t, tc.txn.Hash, receipt.Hash)
+			require.Equal(t, tc.balance, s.Balance("alice"))
+		})
+	}
+
+	require.Equal(t, int64(30), s.Balance("bob"))
+	require.Len(t, s.Transactions(), 2)
+}
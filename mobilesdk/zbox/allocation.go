@@ -180,6 +180,41 @@ func (a *Allocation) UpdateFile(workdir, localPath, remotePath, thumbnailPath st
 	return a.sdkAllocation.StartChunkedUpload(workdir, localPath, remotePath, &StatusCallbackWrapped{Callback: statusCb}, true, false, thumbnailPath, encrypt)
 }
 
+// UploadFileWithToken - upload file/thumbnail from local path to remote path, cancelled with token
+// ## Inputs
+//   - workdir: set a workdir as ~/.zcn on mobile apps
+//   - localPath: the local full path of file. eg /usr/local/files/zcn.png
+//   - remotePath:
+//   - thumbnailPath: the local full path of thumbnail
+//   - encrypt: the file should be ecnrypted or not on uploading
+//   - statusCb: callback of status
+//   - token: cancels the upload, see NewCancellationToken
+func (a *Allocation) UploadFileWithToken(workdir, localPath, remotePath, thumbnailPath string, encrypt bool, statusCb StatusCallbackMocked, token *CancellationToken) error {
+	if a == nil || a.sdkAllocation == nil {
+		return ErrInvalidAllocation
+	}
+	return a.sdkAllocation.StartChunkedUpload(workdir, localPath, remotePath, &StatusCallbackWrapped{Callback: statusCb}, false, false, thumbnailPath, encrypt,
+		sdk.WithContext(token.context()))
+}
+
+// DownloadFileWithToken - start download file from remote path to localpath, cancelled with token
+func (a *Allocation) DownloadFileWithToken(remotePath, localPath string, statusCb StatusCallbackMocked, token *CancellationToken) error {
+	if a == nil || a.sdkAllocation == nil {
+		return ErrInvalidAllocation
+	}
+	if token.context().Err() != nil {
+		return token.context().Err()
+	}
+	err := a.sdkAllocation.DownloadFile(localPath, remotePath, &StatusCallbackWrapped{Callback: statusCb})
+	if err != nil {
+		return err
+	}
+	token.register(func() {
+		a.sdkAllocation.CancelDownload(remotePath) //nolint: errcheck
+	})
+	return nil
+}
+
 // DeleteFile - delete file from remote path
 func (a *Allocation) DeleteFile(remotePath string) error {
 	if a == nil || a.sdkAllocation == nil {
@@ -0,0 +1,64 @@
+package zbox
+
+import (
+	"context"
+	"sync"
+)
+
+// CancellationToken cancels the uploads and downloads started with it, e.g.
+// when the user leaves the screen. A token can be shared by several
+// operations, and can't be reused once cancelled.
+type CancellationToken struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	onCancel []func()
+}
+
+// NewCancellationToken create a CancellationToken
+func NewCancellationToken() *CancellationToken {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &CancellationToken{ctx: ctx, cancel: cancel}
+}
+
+// Cancel cancels the operations started with the token
+func (t *CancellationToken) Cancel() {
+	t.mu.Lock()
+	onCancel := t.onCancel
+	t.onCancel = nil
+	t.mu.Unlock()
+
+	t.cancel()
+	for _, f := range onCancel {
+		f()
+	}
+}
+
+// IsCancelled returns whether Cancel was called
+func (t *CancellationToken) IsCancelled() bool {
+	return t.ctx.Err() != nil
+}
+
+// context returns the context of the token, context.Background for a nil token
+func (t *CancellationToken) context() context.Context {
+	if t == nil {
+		return context.Background()
+	}
+	return t.ctx
+}
+
+// register calls f when the token is cancelled, at once if it already is
+func (t *CancellationToken) register(f func()) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	if t.ctx.Err() == nil {
+		t.onCancel = append(t.onCancel, f)
+		t.mu.Unlock()
+		return
+	}
+	t.mu.Unlock()
+	f()
+}
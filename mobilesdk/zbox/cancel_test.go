@@ -0,0 +1,31 @@
+package zbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancellationToken(t *testing.T) {
+	token := NewCancellationToken()
+	require.False(t, token.IsCancelled())
+
+	var called int
+	token.register(func() { called++ })
+	token.Cancel()
+	require.True(t, token.IsCancelled())
+	require.Error(t, token.context().Err())
+	require.Equal(t, 1, called)
+
+	// registered after the cancellation
+	token.register(func() { called++ })
+	require.Equal(t, 2, called)
+
+	token.Cancel()
+	require.Equal(t, 2, called, "callbacks run once")
+
+	var nilToken *CancellationToken
+	require.NoError(t, nilToken.context().Err())
+	nilToken.register(func() { called++ })
+	require.Equal(t, 2, called)
+}
@@ -0,0 +1,131 @@
+package multisig
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/conf"
+	"github.com/0chain/gosdk/core/screstapi"
+	"github.com/0chain/gosdk/core/transaction"
+	"github.com/0chain/gosdk/core/zcncrypto"
+)
+
+const (
+	// Address of the multisig smart contract
+	Address = "27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7"
+
+	registerFunc = "register"
+	voteFunc     = "vote"
+	// proposalPath is the screst endpoint of a proposal of a group
+	proposalPath = "/getProposal"
+)
+
+// ProposalState is a proposal as stored by the smart contract.
+type ProposalState struct {
+	ProposalID string   `json:"proposal_id"`
+	Transfer   Transfer `json:"transfer"`
+	// SignerSignatures are the votes received so far
+	SignerSignatures []string `json:"signer_signatures"`
+	// ClientSignature is the aggregated signature, set once executed
+	ClientSignature string `json:"client_signature"`
+	ExpirationDate  int64  `json:"expiration_date"`
+}
+
+// Executed tells whether enough signers voted for the transfer to be made.
+func (p *ProposalState) Executed() bool {
+	return p.ClientSignature != ""
+}
+
+// Client sends the transactions of the multisig smart contract and queries
+// its proposals.
+type Client struct {
+	// Querier of the sharders, for GetProposal
+	Querier screstapi.Querier
+	// Confirm are the options of the transactions, the network of the
+	// client config is used if not set
+	Confirm *transaction.ConfirmOptions
+}
+
+// NewClient creates a client of the multisig smart contract querying q,
+// e.g. zcncore.SCRestQuerier().
+func NewClient(q screstapi.Querier) *Client {
+	return &Client{Querier: q}
+}
+
+// Register registers g to the smart contract. The transaction is sent by the
+// group wallet returned by CreateGroup.
+func (c *Client) Register(ctx context.Context, g *Group, groupWallet *zcncrypto.Wallet) (*transaction.Receipt, error) {
+	if groupWallet.ClientID != g.ClientID {
+		return nil, errors.New("register", "wallet isn't the one of the group")
+	}
+	return c.send(ctx, groupWallet, registerFunc, g.registration())
+}
+
+// Vote sends the vote of a signer, from its wallet. The transfer is made
+// once Threshold signers voted.
+func (c *Client) Vote(ctx context.Context, v *Vote, signer *Signer) (*transaction.Receipt, error) {
+	return c.send(ctx, signer.Wallet(), voteFunc, v)
+}
+
+// SignAndVote signs p as signer and sends the vote.
+func (c *Client) SignAndVote(ctx context.Context, p *Proposal, signer *Signer) (*transaction.Receipt, error) {
+	v, err := p.Sign(signer)
+	if err != nil {
+		return nil, err
+	}
+	return c.Vote(ctx, v, signer)
+}
+
+// GetProposal returns the proposal proposalID of the group groupClientID.
+func (c *Client) GetProposal(ctx context.Context, groupClientID, proposalID string) (*ProposalState, error) {
+	if c.Querier == nil {
+		return nil, errors.New("get_proposal", "no querier")
+	}
+	params := url.Values{}
+	params.Set("client_id", groupClientID)
+	params.Set("proposal_id", proposalID)
+
+	body, err := c.Querier.Query(ctx, "/v1/screst/"+Address+proposalPath+"?"+params.Encode())
+	if err != nil {
+		return nil, errors.Wrap(err, "get_proposal")
+	}
+	p := &ProposalState{}
+	if err := json.Unmarshal(body, p); err != nil {
+		return nil, errors.Wrap(err, "get_proposal")
+	}
+	return p, nil
+}
+
+func (c *Client) send(ctx context.Context, w *zcncrypto.Wallet, name string, input interface{}) (*transaction.Receipt, error) {
+	cfg, err := conf.GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(transaction.SmartContractTxnData{Name: name, InputArgs: input})
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := transaction.Cache.GetNextNonce(w.ClientID)
+	txn := transaction.NewTransactionEntity(w.ClientID, cfg.ChainID, w.ClientKey, nonce)
+	txn.TransactionType = transaction.TxnTypeSmartContract
+	txn.ToClientID = Address
+	txn.TransactionData = string(data)
+
+	signer, err := zcncrypto.NewKeySigner(SignatureScheme, w)
+	if err != nil {
+		return nil, err
+	}
+	if err := txn.ComputeHashAndSign(signer.Sign); err != nil {
+		return nil, err
+	}
+
+	receipt, err := transaction.SendAndConfirm(ctx, txn, c.Confirm)
+	if err != nil {
+		transaction.Cache.Evict(w.ClientID)
+		return nil, err
+	}
+	return receipt, receipt.Failed()
+}
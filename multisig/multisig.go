@@ -0,0 +1,254 @@
+// Package multisig creates multisig wallets and signs transfers of the
+// multisig smart contract. A group key is split in N BLS threshold shares,
+// any T of them sign a transfer, their votes are aggregated by the smart
+// contract or locally with Aggregate.
+package multisig
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/zcncrypto"
+)
+
+// SignatureScheme of multisig wallets, the only one with threshold keys
+const SignatureScheme = "bls0chain"
+
+// Group is a multisig wallet. Its key is split in the keys of Signers, of
+// which Threshold must sign a transfer.
+type Group struct {
+	ClientID  string `json:"client_id"`
+	PublicKey string `json:"public_key"`
+	Threshold int    `json:"threshold"`
+	// Signers have no private key in a group shared with other signers
+	Signers []Signer `json:"signers"`
+}
+
+// Signer holds a threshold share of the group key.
+type Signer struct {
+	// ID of the share, in hex
+	ID         string `json:"id"`
+	ClientID   string `json:"client_id"`
+	PublicKey  string `json:"public_key"`
+	PrivateKey string `json:"private_key,omitempty"`
+}
+
+// Wallet returns the wallet of the signer, it signs its votes.
+func (s *Signer) Wallet() *zcncrypto.Wallet {
+	return newWallet(s.PrivateKey, s.PublicKey, "")
+}
+
+// CreateGroup generates a group key split in n shares, threshold of them
+// being required to sign. The returned wallet of the group key must be kept
+// to register the group, its mnemonic recovers the group key.
+func CreateGroup(threshold, n int) (*Group, *zcncrypto.Wallet, error) {
+	if threshold < 1 || threshold > n {
+		return nil, nil, errors.Newf("create_group", "threshold %d must be between 1 and %d", threshold, n)
+	}
+
+	groupKey := zcncrypto.NewSignatureScheme(SignatureScheme)
+	groupWallet, err := groupKey.GenerateKeys()
+	if err != nil {
+		return nil, nil, err
+	}
+	shares, err := zcncrypto.GenerateThresholdKeyShares(threshold, n, groupKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "create_group")
+	}
+
+	g := &Group{
+		ClientID:  groupWallet.ClientID,
+		PublicKey: groupWallet.ClientKey,
+		Threshold: threshold,
+		Signers:   make([]Signer, 0, n),
+	}
+	for _, share := range shares {
+		clientID, err := clientIDOf(share.GetPublicKey())
+		if err != nil {
+			return nil, nil, err
+		}
+		g.Signers = append(g.Signers, Signer{
+			ID:         share.GetID(),
+			ClientID:   clientID,
+			PublicKey:  share.GetPublicKey(),
+			PrivateKey: share.GetPrivateKey(),
+		})
+	}
+	return g, groupWallet, nil
+}
+
+// Public returns the group without the private keys of the signers.
+func (g *Group) Public() *Group {
+	public := *g
+	public.Signers = make([]Signer, len(g.Signers))
+	for i, s := range g.Signers {
+		s.PrivateKey = ""
+		public.Signers[i] = s
+	}
+	return &public
+}
+
+// Signer returns the signer of clientID.
+func (g *Group) Signer(clientID string) (*Signer, bool) {
+	for i := range g.Signers {
+		if g.Signers[i].ClientID == clientID {
+			return &g.Signers[i], true
+		}
+	}
+	return nil, false
+}
+
+// registration is the payload of the register function of the smart contract.
+type registration struct {
+	ClientID           string   `json:"client_id"`
+	SignatureScheme    string   `json:"signature_scheme"`
+	PublicKey          string   `json:"public_key"`
+	SignerThresholdIDs []string `json:"signer_threshold_ids"`
+	SignerPublicKeys   []string `json:"signer_public_keys"`
+	NumRequired        int      `json:"num_required"`
+}
+
+func (g *Group) registration() registration {
+	r := registration{
+		ClientID:        g.ClientID,
+		SignatureScheme: SignatureScheme,
+		PublicKey:       g.PublicKey,
+		NumRequired:     g.Threshold,
+	}
+	for _, s := range g.Signers {
+		r.SignerThresholdIDs = append(r.SignerThresholdIDs, s.ID)
+		r.SignerPublicKeys = append(r.SignerPublicKeys, s.PublicKey)
+	}
+	return r
+}
+
+// Transfer of tokens from the group wallet.
+type Transfer struct {
+	// ClientID is the one of the group wallet
+	ClientID   string `json:"from"`
+	ToClientID string `json:"to"`
+	Amount     uint64 `json:"amount"`
+}
+
+// Hash is the hash the signers sign.
+func (t *Transfer) Hash() string {
+	buf, _ := json.Marshal(t)
+	return encryption.Hash(buf)
+}
+
+// Proposal is a transfer the signers vote for.
+type Proposal struct {
+	ID       string   `json:"proposal_id"`
+	Transfer Transfer `json:"transfer"`
+}
+
+// NewProposal proposes to transfer amount SAS from the group to toClientID.
+func NewProposal(id string, g *Group, toClientID string, amount uint64) (*Proposal, error) {
+	if id == "" || toClientID == "" {
+		return nil, errors.New("new_proposal", "proposal id and recipient are required")
+	}
+	if amount < 1 {
+		return nil, errors.New("new_proposal", "amount must be positive")
+	}
+	return &Proposal{
+		ID:       id,
+		Transfer: Transfer{ClientID: g.ClientID, ToClientID: toClientID, Amount: amount},
+	}, nil
+}
+
+// Vote is the signature of a proposal by a signer, the payload of the vote
+// function of the smart contract.
+type Vote struct {
+	ProposalID string   `json:"proposal_id"`
+	Transfer   Transfer `json:"transfer"`
+	Signature  string   `json:"signature"`
+
+	// SignerID is the share id of the signer, for Aggregate
+	SignerID string `json:"-"`
+}
+
+// Sign signs p with the share of signer, which must have its private key.
+func (p *Proposal) Sign(signer *Signer) (*Vote, error) {
+	if signer.PrivateKey == "" {
+		return nil, errors.New("sign_proposal", "signer has no private key")
+	}
+	ss := zcncrypto.NewSignatureScheme(SignatureScheme)
+	if err := ss.SetPrivateKey(signer.PrivateKey); err != nil {
+		return nil, err
+	}
+	sig, err := ss.Sign(p.Transfer.Hash())
+	if err != nil {
+		return nil, err
+	}
+	return &Vote{ProposalID: p.ID, Transfer: p.Transfer, Signature: sig, SignerID: signer.ID}, nil
+}
+
+// Aggregate recovers the signature of the transfer by the group key from
+// the votes of at least Threshold signers and verifies it.
+func (g *Group) Aggregate(votes []*Vote) (string, error) {
+	if len(votes) < g.Threshold {
+		return "", errors.Newf("aggregate", "%d votes, %d required", len(votes), g.Threshold)
+	}
+
+	var (
+		transfer = votes[0].Transfer
+		seen     = make(map[string]bool)
+		ids      []string
+		sigs     []string
+	)
+	for _, v := range votes {
+		if v.Transfer != transfer {
+			return "", errors.New("aggregate", "votes are for different transfers")
+		}
+		if seen[v.SignerID] {
+			continue
+		}
+		seen[v.SignerID] = true
+		ids = append(ids, v.SignerID)
+		sigs = append(sigs, v.Signature)
+	}
+	if len(ids) < g.Threshold {
+		return "", errors.Newf("aggregate", "%d distinct signers, %d required", len(ids), g.Threshold)
+	}
+
+	sig, err := zcncrypto.RecoverThresholdSignature(ids[:g.Threshold], sigs[:g.Threshold])
+	if err != nil {
+		return "", err
+	}
+
+	ss := zcncrypto.NewSignatureScheme(SignatureScheme)
+	if err := ss.SetPublicKey(g.PublicKey); err != nil {
+		return "", err
+	}
+	ok, err := ss.Verify(sig, transfer.Hash())
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", errors.New("aggregate", "invalid vote signatures")
+	}
+	return sig, nil
+}
+
+func clientIDOf(publicKey string) (string, error) {
+	buf, err := hex.DecodeString(publicKey)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid public key")
+	}
+	return encryption.Hash(buf), nil
+}
+
+func newWallet(privateKey, publicKey, mnemonic string) *zcncrypto.Wallet {
+	clientID, _ := clientIDOf(publicKey)
+	return &zcncrypto.Wallet{
+		ClientID:    clientID,
+		ClientKey:   publicKey,
+		Keys:        []zcncrypto.KeyPair{{PublicKey: publicKey, PrivateKey: privateKey}},
+		Mnemonic:    mnemonic,
+		Version:     zcncrypto.CryptoVersion,
+		DateCreated: time.Now().Format(time.RFC3339),
+	}
+}
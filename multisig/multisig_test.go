@@ -0,0 +1,54 @@
+package multisig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregate(t *testing.T) {
+	g, groupWallet, err := CreateGroup(2, 3)
+	require.NoError(t, err)
+	require.Equal(t, g.ClientID, groupWallet.ClientID)
+	require.Len(t, g.Signers, 3)
+
+	p, err := NewProposal("p1", g, "to", 100)
+	require.NoError(t, err)
+
+	votes := make([]*Vote, 0, len(g.Signers))
+	for i := range g.Signers {
+		v, err := p.Sign(&g.Signers[i])
+		require.NoError(t, err)
+		votes = append(votes, v)
+	}
+
+	t.Run("Test_Any_Threshold_Signers", func(t *testing.T) {
+		for _, pair := range [][]*Vote{{votes[0], votes[1]}, {votes[1], votes[2]}, {votes[2], votes[0]}} {
+			sig, err := g.Aggregate(pair)
+			require.NoError(t, err)
+			require.NotEmpty(t, sig)
+		}
+	})
+
+	t.Run("Test_Too_Few_Signers", func(t *testing.T) {
+		_, err := g.Aggregate(votes[:1])
+		require.Error(t, err)
+
+		_, err = g.Aggregate([]*Vote{votes[0], votes[0]})
+		require.Error(t, err)
+	})
+
+	t.Run("Test_Different_Transfers", func(t *testing.T) {
+		other := *votes[1]
+		other.Transfer.Amount++
+		_, err := g.Aggregate([]*Vote{votes[0], &other})
+		require.Error(t, err)
+	})
+
+	t.Run("Test_Public_Group_Cannot_Sign", func(t *testing.T) {
+		public := g.Public()
+		require.NotEmpty(t, g.Signers[0].PrivateKey)
+		_, err := p.Sign(&public.Signers[0])
+		require.Error(t, err)
+	})
+}
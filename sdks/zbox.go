@@ -12,7 +12,6 @@ import (
 	"github.com/0chain/gosdk/constants"
 	"github.com/0chain/gosdk/core/encryption"
 	"github.com/0chain/gosdk/core/resty"
-	"github.com/0chain/gosdk/core/sys"
 	"github.com/0chain/gosdk/core/zcncrypto"
 	"github.com/0chain/gosdk/zboxcore/client"
 )
@@ -51,6 +50,15 @@ func (z *ZBox) InitWallet(js string) error {
 	return json.Unmarshal([]byte(js), &z.Wallet)
 }
 
+// Signer is the middleware signing the requests with SignRequest. Retry
+// being the outermost middleware, the request is signed again on every
+// attempt.
+func (z *ZBox) Signer(allocationID string) resty.Middleware {
+	return resty.RequestInterceptor(func(req *http.Request) error {
+		return z.SignRequest(req, allocationID)
+	})
+}
+
 // SignRequest sign request with client_id, client_key and sign
 func (z *ZBox) SignRequest(req *http.Request, allocationID string) error {
 
@@ -63,7 +71,7 @@ func (z *ZBox) SignRequest(req *http.Request, allocationID string) error {
 
 	hash := encryption.Hash(allocationID)
 
-	sign, err := sys.Sign(hash, z.SignatureScheme, client.GetClientSysKeys())
+	sign, err := client.Sign(hash)
 	if err != nil {
 		return err
 	}
@@ -115,9 +123,7 @@ func (z *ZBox) DoPost(req *Request, handle resty.Handle) *resty.Resty {
 
 	opts = append(opts, resty.WithRetry(resty.DefaultRetry))
 	opts = append(opts, resty.WithTimeout(resty.DefaultRequestTimeout))
-	opts = append(opts, resty.WithRequestInterceptor(func(r *http.Request) error {
-		return z.SignRequest(r, req.AllocationID) //nolint
-	}))
+	opts = append(opts, resty.WithMiddleware(z.Signer(req.AllocationID)))
 
 	if len(req.ContentType) > 0 {
 		opts = append(opts, resty.WithHeader(map[string]string{
@@ -0,0 +1,28 @@
+package sdks
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/zboxcore/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZBox_SignRequest_signer(t *testing.T) {
+	var signed string
+	client.SetSigner(zcncrypto.NewFuncSigner("public_key", func(hash string) (string, error) {
+		signed = hash
+		return "remote_signature", nil
+	}))
+	defer client.SetSigner(nil)
+
+	z := New("client_id", "public_key", "bls0chain", nil)
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, z.SignRequest(req, "allocation_id"))
+	require.Equal(t, encryption.Hash("allocation_id"), signed)
+	require.Equal(t, "remote_signature", req.Header.Get("X-App-Client-Signature"))
+}
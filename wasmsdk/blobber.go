@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
 	"path"
 	"path/filepath"
 	"strings"
@@ -403,6 +404,26 @@ func bulkUpload(jsonBulkUploadOptions string) ([]BulkUploadResult, error) {
 }
 
 func uploadWithJsFuncs(allocationID, remotePath string, readChunkFuncName string, fileSize int64, thumbnailBytes []byte, encrypt, isUpdate, isRepair bool, numBlocks int, callbackFuncName string) (bool, error) {
+	fileReader := jsbridge.NewFileReader(readChunkFuncName, fileSize)
+	return uploadFromReader(allocationID, remotePath, fileReader, fileSize, thumbnailBytes, encrypt, isUpdate, isRepair, numBlocks, callbackFuncName)
+}
+
+// uploadBlob upload a js File or Blob, read slice by slice instead of being copied in memory
+func uploadBlob(allocationID, remotePath string, file js.Value, thumbnailBytes []byte, encrypt, isUpdate, isRepair bool, numBlocks int, callbackFuncName string) (*FileCommandResponse, error) {
+	if !file.Truthy() {
+		return nil, RequiredArg("file")
+	}
+
+	fileReader := jsbridge.NewBlobReader(file)
+	ok, err := uploadFromReader(allocationID, remotePath, fileReader, fileReader.Size(), thumbnailBytes, encrypt, isUpdate, isRepair, numBlocks, callbackFuncName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileCommandResponse{CommandSuccess: ok}, nil
+}
+
+func uploadFromReader(allocationID, remotePath string, fileReader io.ReadSeeker, fileSize int64, thumbnailBytes []byte, encrypt, isUpdate, isRepair bool, numBlocks int, callbackFuncName string) (bool, error) {
 
 	if len(allocationID) == 0 {
 		return false, RequiredArg("allocationID")
@@ -431,8 +452,6 @@ func uploadWithJsFuncs(allocationID, remotePath string, readChunkFuncName string
 		encrypt = true
 	}
 
-	fileReader := jsbridge.NewFileReader(readChunkFuncName, fileSize)
-
 	mimeType, err := zboxutil.GetFileContentType(fileReader)
 	if err != nil {
 		return false, err
@@ -620,3 +639,70 @@ func downloadBlocks(allocationID, remotePath, authTicket, lookupHash string, num
 	return resp, nil
 
 }
+
+// downloadToStream download file to a js WritableStream, e.g. the one of
+// FileSystemFileHandle.createWritable(), block by block instead of buffering
+// the whole file in memory
+func downloadToStream(allocationID, remotePath, authTicket, lookupHash string, stream js.Value, numBlocks int, callbackFuncName string) (*DownloadCommandResponse, error) {
+
+	if len(remotePath) == 0 && len(authTicket) == 0 {
+		return nil, RequiredArg("remotePath/authTicket")
+	}
+
+	if !stream.Truthy() {
+		return nil, RequiredArg("stream")
+	}
+
+	wg := &sync.WaitGroup{}
+	statusBar := &StatusBar{wg: wg}
+	if callbackFuncName != "" {
+		callback := js.Global().Get(callbackFuncName)
+		statusBar.callback = func(totalBytes, completedBytes int, err string) {
+			callback.Invoke(totalBytes, completedBytes, err)
+		}
+	}
+	wg.Add(1)
+
+	fileName := strings.Replace(path.Base(remotePath), "/", "-", -1)
+	localPath := allocationID + "_stream_" + fileName
+
+	writer := jsbridge.NewStreamWriter(stream)
+	streamFiles.attach(localPath, writer)
+	defer streamFiles.detach(localPath)
+
+	downloader, err := sdk.CreateDownloader(allocationID, localPath, remotePath,
+		sdk.WithAuthticket(authTicket, lookupHash),
+		sdk.WithBlocks(0, 0, numBlocks))
+
+	if err != nil {
+		writer.Abort(err.Error())
+		PrintError(err.Error())
+		return nil, err
+	}
+
+	err = downloader.Start(statusBar)
+
+	if err == nil {
+		wg.Wait()
+	} else {
+		writer.Abort(err.Error())
+		PrintError("Download failed.", err.Error())
+		return nil, err
+	}
+	if !statusBar.success {
+		writer.Abort("download failed")
+		if statusBar.err != nil {
+			return nil, statusBar.err
+		}
+		return nil, errors.New("Download failed: unknown error")
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return &DownloadCommandResponse{
+		CommandSuccess: true,
+		FileName:       downloader.GetFileName(),
+	}, nil
+}
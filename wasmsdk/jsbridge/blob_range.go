@@ -0,0 +1,57 @@
+package jsbridge
+
+import (
+	"errors"
+	"io"
+)
+
+// blobRange is the position of a reader in a blob of size bytes, kept apart
+// from the js calls of BlobReader.
+type blobRange struct {
+	size   int64
+	offset int64
+}
+
+// next returns the end of the slice to read into a buffer of n bytes, or
+// io.EOF if the whole blob is read.
+func (r *blobRange) next(n int) (int64, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	end := r.offset + int64(n)
+	if end > r.size {
+		end = r.size
+	}
+	return end, nil
+}
+
+// advance moves the offset by the n bytes read, returning io.EOF once the end
+// of the blob is reached.
+func (r *blobRange) advance(n int) error {
+	r.offset += int64(n)
+	if r.offset >= r.size {
+		return io.EOF
+	}
+	return nil
+}
+
+func (r *blobRange) seek(offset int64, whence int) (int64, error) {
+
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = r.offset + offset
+	case io.SeekEnd:
+		abs = r.size + offset
+	default:
+		return 0, errors.New("BlobReader.Seek: invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("BlobReader.Seek: negative position")
+	}
+	r.offset = abs
+	return abs, nil
+}
@@ -0,0 +1,95 @@
+package jsbridge
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobRange_read(t *testing.T) {
+	tests := []struct {
+		name   string
+		size   int64
+		buf    int
+		slices [][2]int64
+	}{
+		{name: "Test_Empty", size: 0, buf: 4},
+		{name: "Test_Single_Slice", size: 3, buf: 4, slices: [][2]int64{{0, 3}}},
+		{name: "Test_Exact_Slices", size: 8, buf: 4, slices: [][2]int64{{0, 4}, {4, 8}}},
+		{name: "Test_Last_Slice_Short", size: 10, buf: 4, slices: [][2]int64{{0, 4}, {4, 8}, {8, 10}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			r := &blobRange{size: tt.size}
+
+			var slices [][2]int64
+			for {
+				end, err := r.next(tt.buf)
+				if err == io.EOF {
+					break
+				}
+				require.NoError(err)
+				slices = append(slices, [2]int64{r.offset, end})
+
+				err = r.advance(int(end - r.offset))
+				if err == io.EOF {
+					require.Equal(tt.size, r.offset, "io.EOF at the end of the blob only")
+				} else {
+					require.NoError(err)
+				}
+			}
+			require.Equal(tt.slices, slices)
+		})
+	}
+}
+
+func TestBlobRange_seek(t *testing.T) {
+	tests := []struct {
+		name    string
+		offset  int64
+		whence  int
+		want    int64
+		wantErr bool
+	}{
+		{name: "Test_Start", offset: 3, whence: io.SeekStart, want: 3},
+		{name: "Test_Current", offset: 2, whence: io.SeekCurrent, want: 6},
+		{name: "Test_End", offset: -2, whence: io.SeekEnd, want: 8},
+		{name: "Test_Past_End", offset: 2, whence: io.SeekEnd, want: 12},
+		{name: "Test_Negative", offset: -5, whence: io.SeekStart, wantErr: true},
+		{name: "Test_Invalid_Whence", offset: 0, whence: 3, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			r := &blobRange{size: 10, offset: 4}
+
+			got, err := r.seek(tt.offset, tt.whence)
+			if tt.wantErr {
+				require.Error(err)
+				require.Equal(int64(4), r.offset, "the offset is kept on error")
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.want, got)
+			require.Equal(tt.want, r.offset)
+		})
+	}
+}
+
+func TestBlobRange_readAfterSeek(t *testing.T) {
+	require := require.New(t)
+	r := &blobRange{size: 10}
+
+	_, err := r.seek(0, io.SeekEnd)
+	require.NoError(err)
+	_, err = r.next(4)
+	require.Equal(io.EOF, err)
+
+	_, err = r.seek(-3, io.SeekEnd)
+	require.NoError(err)
+	end, err := r.next(4)
+	require.NoError(err)
+	require.Equal(int64(10), end)
+}
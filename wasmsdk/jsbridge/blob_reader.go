@@ -0,0 +1,53 @@
+//go:build js && wasm
+// +build js,wasm
+
+package jsbridge
+
+import (
+	"errors"
+	"syscall/js"
+)
+
+// BlobReader reads a js Blob, e.g. a File of an <input type="file">, slice by
+// slice, the file never being loaded in memory at once.
+type BlobReader struct {
+	blob js.Value
+	blobRange
+}
+
+// NewBlobReader create BlobReader of blob
+func NewBlobReader(blob js.Value) *BlobReader {
+	return &BlobReader{
+		blob:      blob,
+		blobRange: blobRange{size: int64(blob.Get("size").Int())},
+	}
+}
+
+// Size returns the size of the blob
+func (r *BlobReader) Size() int64 {
+	return r.size
+}
+
+func (r *BlobReader) Read(p []byte) (int, error) {
+	end, err := r.next(len(p))
+	if err != nil {
+		return 0, err
+	}
+
+	//js.Value doesn't work in parallel invoke
+	jsFileReaderMutex.Lock()
+	defer jsFileReaderMutex.Unlock()
+
+	slice := r.blob.Call("slice", r.offset, end)
+	result, jsErr := Await(slice.Call("arrayBuffer"))
+	if len(jsErr) > 0 && !jsErr[0].IsNull() {
+		return 0, errors.New("blob_reader: " + jsErr[0].String())
+	}
+
+	n := js.CopyBytesToGo(p, js.Global().Get("Uint8Array").New(result[0]))
+	return n, r.advance(n)
+}
+
+func (r *BlobReader) Seek(offset int64, whence int) (int64, error) {
+	return r.seek(offset, whence)
+}
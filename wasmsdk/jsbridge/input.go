@@ -84,6 +84,8 @@ func (b *InputBuilder) Build() (InputBinder, error) {
 			b.binders[i] = withRecover(i, jsValueToStringSlice)
 		case *[]byte:
 			b.binders[i] = withRecover(i, jsValueToBytes)
+		case *js.Value:
+			b.binders[i] = withRecover(i, jsValueToValue)
 		default:
 			fmt.Printf("TYPE: %#v\n", reflect.TypeOf(v))
 			return nil, ErrBinderNotImplemented
@@ -223,3 +225,8 @@ func jsValueToBytes(jv js.Value) reflect.Value {
 
 	return reflect.ValueOf(buf)
 }
+
+// jsValueToValue passes js objects, e.g. a File or a WritableStream, as is
+func jsValueToValue(jv js.Value) reflect.Value {
+	return reflect.ValueOf(jv)
+}
@@ -0,0 +1,45 @@
+//go:build js && wasm
+// +build js,wasm
+
+package jsbridge
+
+import (
+	"errors"
+	"syscall/js"
+)
+
+// StreamWriter writes to a js WritableStream, e.g. the one of
+// FileSystemFileHandle.createWritable(), each write awaiting the stream so
+// that it is never buffered in memory.
+type StreamWriter struct {
+	writer js.Value
+}
+
+// NewStreamWriter create StreamWriter locking the stream
+func NewStreamWriter(stream js.Value) *StreamWriter {
+	return &StreamWriter{
+		writer: stream.Call("getWriter"),
+	}
+}
+
+func (w *StreamWriter) Write(p []byte) (int, error) {
+	_, err := Await(w.writer.Call("write", NewBytes(p)))
+	if len(err) > 0 && !err[0].IsNull() {
+		return 0, errors.New("stream_writer: " + err[0].String())
+	}
+	return len(p), nil
+}
+
+// Close closes the stream
+func (w *StreamWriter) Close() error {
+	_, err := Await(w.writer.Call("close"))
+	if len(err) > 0 && !err[0].IsNull() {
+		return errors.New("stream_writer: " + err[0].String())
+	}
+	return nil
+}
+
+// Abort aborts the stream, the data written being discarded
+func (w *StreamWriter) Abort(reason string) {
+	Await(w.writer.Call("abort", reason)) //nolint
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/0chain/gosdk/core/version"
 	"github.com/0chain/gosdk/core/zcncrypto"
 	"github.com/0chain/gosdk/wasmsdk/jsbridge"
+	"github.com/0chain/gosdk/zboxcore/client"
 	"github.com/0chain/gosdk/zboxcore/sdk"
 	"github.com/0chain/gosdk/zcncore"
 
@@ -26,9 +27,20 @@ var (
 	signCache = make(map[string]string)
 )
 
+// jsSigner signs with the sign function of the js proxy, which holds the keys.
+type jsSigner func(hash string) (string, error)
+
+func (s jsSigner) PublicKey() string {
+	return client.GetClientPublicKey()
+}
+
+func (s jsSigner) Sign(hash string) (string, error) {
+	return s(hash)
+}
+
 func main() {
 	fmt.Printf("0CHAIN - GOSDK (version=%v)\n", version.VERSIONSTR)
-	sys.Files = sys.NewMemFS()
+	sys.Files = streamFiles
 	sdkLogger = sdk.GetLogger()
 	zcnLogger = zcncore.GetLogger()
 
@@ -71,6 +83,7 @@ func main() {
 					// js already has signatureScheme and keys
 					return signFunc(hash)
 				}
+				client.SetSigner(jsSigner(signFunc))
 			} else {
 				PrintError("__zcn_wasm__.jsProxy.sign is not installed yet")
 			}
@@ -118,7 +131,7 @@ func main() {
 			}
 
 			sys.Sleep = func(d time.Duration) {
-				<-time.After(d)
+				<-sys.After(d)
 			}
 		} else {
 			PrintError("__zcn_wasm__.jsProxy is not installed yet")
@@ -140,18 +153,20 @@ func main() {
 				"getLookupHash":          getLookupHash,
 
 				//blobber
-				"delete":         Delete,
-				"rename":         Rename,
-				"copy":           Copy,
-				"move":           Move,
-				"share":          Share,
-				"download":       download,
-				"upload":         upload,
-				"bulkUpload":     bulkUpload,
-				"listObjects":    listObjects,
-				"createDir":      createDir,
-				"downloadBlocks": downloadBlocks,
-				"getFileStats":   getFileStats,
+				"delete":           Delete,
+				"rename":           Rename,
+				"copy":             Copy,
+				"move":             Move,
+				"share":            Share,
+				"download":         download,
+				"downloadToStream": downloadToStream,
+				"upload":           upload,
+				"uploadBlob":       uploadBlob,
+				"bulkUpload":       bulkUpload,
+				"listObjects":      listObjects,
+				"createDir":        createDir,
+				"downloadBlocks":   downloadBlocks,
+				"getFileStats":     getFileStats,
 
 				// player
 				"play":           play,
@@ -188,6 +203,7 @@ func main() {
 
 				//zcn
 				"getWalletBalance": getWalletBalance,
+				"send":             send,
 				"createReadPool":   createReadPool,
 
 				//0box api
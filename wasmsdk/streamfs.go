@@ -0,0 +1,63 @@
+package main
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/0chain/gosdk/core/sys"
+)
+
+// streamFS is the file system of the sdk, the downloads to the files attached
+// to a js stream being written to the stream instead of memory.
+type streamFS struct {
+	sys.FS
+
+	mu      sync.Mutex
+	writers map[string]io.Writer
+}
+
+var streamFiles = &streamFS{
+	FS:      sys.NewMemFS(),
+	writers: make(map[string]io.Writer),
+}
+
+func (s *streamFS) attach(name string, w io.Writer) {
+	s.mu.Lock()
+	s.writers[name] = w
+	s.mu.Unlock()
+}
+
+func (s *streamFS) detach(name string) {
+	s.mu.Lock()
+	delete(s.writers, name)
+	s.mu.Unlock()
+	s.FS.Remove(name) //nolint
+}
+
+func (s *streamFS) OpenFile(name string, flag int, perm os.FileMode) (sys.File, error) {
+	f, err := s.FS.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	w, ok := s.writers[name]
+	s.mu.Unlock()
+	if !ok {
+		return f, nil
+	}
+
+	return &streamFile{File: f, w: w}, nil
+}
+
+// streamFile writes to its stream, the other methods being the ones of the
+// empty file in memory.
+type streamFile struct {
+	sys.File
+	w io.Writer
+}
+
+func (f *streamFile) Write(p []byte) (int, error) {
+	return f.w.Write(p)
+}
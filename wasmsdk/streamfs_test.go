@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStreamFS() *streamFS {
+	return &streamFS{
+		FS:      sys.NewMemFS(),
+		writers: make(map[string]io.Writer),
+	}
+}
+
+func TestStreamFS_attached(t *testing.T) {
+	require := require.New(t)
+	s := newTestStreamFS()
+
+	var stream bytes.Buffer
+	s.attach("alloc_stream_a.txt", &stream)
+
+	f, err := s.OpenFile("alloc_stream_a.txt", os.O_CREATE|os.O_WRONLY, 0644)
+	require.NoError(err)
+	for _, block := range []string{"block1", "block2", "block3"} {
+		n, err := f.Write([]byte(block))
+		require.NoError(err)
+		require.Equal(len(block), n)
+	}
+	require.NoError(f.Close())
+
+	require.Equal("block1block2block3", stream.String())
+
+	// the blocks go to the stream, nothing is kept in memory
+	buf, err := s.ReadFile("alloc_stream_a.txt")
+	require.NoError(err)
+	require.Empty(buf)
+}
+
+func TestStreamFS_notAttached(t *testing.T) {
+	require := require.New(t)
+	s := newTestStreamFS()
+
+	var stream bytes.Buffer
+	s.attach("alloc_stream_a.txt", &stream)
+
+	f, err := s.OpenFile("b.txt", os.O_CREATE|os.O_WRONLY, 0644)
+	require.NoError(err)
+	_, err = f.Write([]byte("data"))
+	require.NoError(err)
+	require.NoError(f.Close())
+
+	buf, err := s.ReadFile("b.txt")
+	require.NoError(err)
+	require.Equal("data", string(buf))
+	require.Empty(stream.String())
+}
+
+func TestStreamFS_detach(t *testing.T) {
+	require := require.New(t)
+	s := newTestStreamFS()
+
+	var stream bytes.Buffer
+	s.attach("alloc_stream_a.txt", &stream)
+
+	f, err := s.OpenFile("alloc_stream_a.txt", os.O_CREATE|os.O_WRONLY, 0644)
+	require.NoError(err)
+	_, err = f.Write([]byte("data"))
+	require.NoError(err)
+	require.NoError(f.Close())
+
+	s.detach("alloc_stream_a.txt")
+
+	// the file in memory is removed with the stream
+	_, err = s.Stat("alloc_stream_a.txt")
+	require.Error(err)
+
+	// once detached, the writes go to memory
+	f, err = s.OpenFile("alloc_stream_a.txt", os.O_CREATE|os.O_WRONLY, 0644)
+	require.NoError(err)
+	_, err = f.Write([]byte("more"))
+	require.NoError(err)
+	require.NoError(f.Close())
+
+	require.Equal("data", stream.String())
+	buf, err := s.ReadFile("alloc_stream_a.txt")
+	require.NoError(err)
+	require.Equal("more", string(buf))
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
+
+func TestStreamFS_writeError(t *testing.T) {
+	s := newTestStreamFS()
+	s.attach("alloc_stream_a.txt", failingWriter{})
+
+	f, err := s.OpenFile("alloc_stream_a.txt", os.O_CREATE|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("data"))
+	require.ErrorIs(t, err, io.ErrClosedPipe)
+}
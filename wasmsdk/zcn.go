@@ -4,6 +4,9 @@
 package main
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/0chain/gosdk/zboxcore/sdk"
 	"github.com/0chain/gosdk/zcncore"
 )
@@ -35,3 +38,40 @@ func createReadPool() (string, error) {
 	hash, _, err := sdk.CreateReadPool()
 	return hash, err
 }
+
+// send transfers tokens from the wallet to toClientID, and returns the hash of the verified transaction
+func send(toClientID string, tokens, fee float64, desc string) (string, error) {
+	wg := &sync.WaitGroup{}
+	cb := &transactionCallback{wg: wg}
+	txn, err := zcncore.NewTransaction(cb, zcncore.ConvertToValue(fee), 0)
+	if err != nil {
+		return "", err
+	}
+
+	wg.Add(1)
+	err = txn.Send(toClientID, zcncore.ConvertToValue(tokens), desc)
+	if err != nil {
+		return "", err
+	}
+
+	wg.Wait()
+
+	if !cb.success {
+		return "", fmt.Errorf("send: %s", cb.errMsg)
+	}
+
+	cb.success = false
+	wg.Add(1)
+	err = txn.Verify()
+	if err != nil {
+		return "", err
+	}
+
+	wg.Wait()
+
+	if !cb.success {
+		return "", fmt.Errorf("send: %s", cb.errMsg)
+	}
+
+	return txn.GetTransactionHash(), nil
+}
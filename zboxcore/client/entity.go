@@ -18,6 +18,7 @@ var (
 	client  *Client
 	clients []*Client
 	Sign    SignFunc
+	signer  zcncrypto.Signer
 )
 
 func init() {
@@ -28,6 +29,14 @@ func init() {
 	sys.Sign = SignHash
 	// initialize SignFunc as default implementation
 	Sign = func(hash string) (string, error) {
+		if signer != nil {
+			return signer.Sign(hash)
+		}
+		if ks, err := zcncrypto.NewKeySigner(client.SignatureScheme, client.Wallet); err == nil {
+			return ks.Sign(hash)
+		}
+		// the keys aren't in the wallet, e.g. held by the js proxy of the
+		// wasm sdk which replaces sys.Sign
 		return sys.Sign(hash, client.SignatureScheme, GetClientSysKeys())
 	}
 
@@ -41,6 +50,18 @@ func PopulateClient(clientjson string, signatureScheme string) error {
 	return err
 }
 
+// SetSigner makes the default Sign use s instead of a KeySigner over the keys
+// of the client, which then only needs its client id and public key. nil
+// restores the KeySigner.
+func SetSigner(s zcncrypto.Signer) {
+	signer = s
+}
+
+// GetSigner returns the signer set by SetSigner.
+func GetSigner() zcncrypto.Signer {
+	return signer
+}
+
 func SetClientNonce(nonce int64) {
 	client.Nonce = nonce
 }
@@ -1,9 +1,9 @@
+// Package encoder erasure codes the data of the files uploaded to and
+// downloaded from the blobbers. The coding uses the SIMD (SSSE3, AVX2, AVX512
+// and GFNI) paths of reedsolomon the CPU supports.
 package encoder
 
 import (
-	"bufio"
-	"bytes"
-
 	"errors"
 
 	l "github.com/0chain/gosdk/zboxcore/logger"
@@ -11,48 +11,55 @@ import (
 	"github.com/klauspost/reedsolomon"
 )
 
+// ErrInvalidInput is returned when the shards or their size can't be
+// decoded.
+var ErrInvalidInput = errors.New("Invalid input length")
+
+// ErrCorrupted is returned when the shards don't match their parity.
+var ErrCorrupted = errors.New("shards don't match their parity")
+
 type StreamEncoder struct {
 	iDataShards   int
 	iParityShards int
 	erasureCode   reedsolomon.Encoder
-	data          [][]byte
 }
 
 // Creates New encoder instance and return index for further access
 func NewEncoder(iDataShards, iParityShards int) (*StreamEncoder, error) {
-	e := &StreamEncoder{}
-	var err error
-
-	e.erasureCode, err = reedsolomon.New(iDataShards, iParityShards, reedsolomon.WithAutoGoroutines(64*1024))
+	erasureCode, err := reedsolomon.New(iDataShards, iParityShards, reedsolomon.WithAutoGoroutines(64*1024))
 	if err != nil {
 		return nil, err
 	}
-	e.iDataShards = iDataShards
-	e.iParityShards = iParityShards
-	return e, nil
+	return &StreamEncoder{
+		iDataShards:   iDataShards,
+		iParityShards: iParityShards,
+		erasureCode:   erasureCode,
+	}, nil
 }
 
 // Encodes and returns the shards on success and error on fails
 func (e *StreamEncoder) Encode(in []byte) ([][]byte, error) {
-	var err error
-	e.data, err = e.erasureCode.Split(in)
+	shards, err := e.erasureCode.Split(in)
 	if err != nil {
 		l.Logger.Error("Split failed", err.Error())
 		return [][]byte{}, err
 	}
 
-	err = e.erasureCode.Encode(e.data)
+	err = e.erasureCode.Encode(shards)
 	if err != nil {
 		l.Logger.Error("Encode failed", err.Error())
 		return [][]byte{}, err
 	}
-	return e.data, nil
+	return shards, nil
 }
 
+// Decode reconstructs the missing shards of in, nil or empty ones, verifies
+// them against the parity and returns the data of the shards of shardSize
+// bytes.
 func (e *StreamEncoder) Decode(in [][]byte, shardSize int) ([]byte, error) {
 	// Verify the input
 	if (len(in) < e.iDataShards+e.iParityShards) || (shardSize <= 0) {
-		return []byte{}, errors.New("Invalid input length")
+		return []byte{}, ErrInvalidInput
 	}
 
 	err := e.erasureCode.Reconstruct(in)
@@ -60,21 +67,27 @@ func (e *StreamEncoder) Decode(in [][]byte, shardSize int) ([]byte, error) {
 		l.Logger.Error("Reconstruct failed -", err)
 		return []byte{}, err
 	}
-	_, err = e.erasureCode.Verify(in)
+	ok, err := e.erasureCode.Verify(in)
+	if err == nil && !ok {
+		err = ErrCorrupted
+	}
 	if err != nil {
 		l.Logger.Error("Verification failed after reconstruction, data likely corrupted.", err.Error())
 		return []byte{}, err
 	}
 
-	var bytesBuf bytes.Buffer
-	bufWriter := bufio.NewWriter(&bytesBuf)
-	bufWriter = bufio.NewWriterSize(bufWriter, (shardSize * e.iDataShards))
-	err = e.erasureCode.Join(bufWriter, in, (shardSize * e.iDataShards))
-	if err != nil {
-		l.Logger.Error("join failed", err.Error())
-		return []byte{}, err
+	// the shards are joined once, in a buffer of the size of the data
+	size := shardSize * e.iDataShards
+	out := make([]byte, 0, size)
+	for _, shard := range in[:e.iDataShards] {
+		if len(out)+len(shard) > size {
+			shard = shard[:size-len(out)]
+		}
+		out = append(out, shard...)
+	}
+	if len(out) < size {
+		l.Logger.Error("join failed", reedsolomon.ErrShortData.Error())
+		return []byte{}, reedsolomon.ErrShortData
 	}
-	bufWriter.Flush()
-	outBuf := bytesBuf.Bytes()
-	return outBuf, nil
+	return out, nil
 }
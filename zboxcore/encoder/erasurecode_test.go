@@ -0,0 +1,29 @@
+package encoder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	e, err := NewEncoder(2, 1)
+	require.NoError(t, err)
+
+	data := []byte("erasure coded data")
+	shards, err := e.Encode(data)
+	require.NoError(t, err)
+
+	shards[0] = nil
+	got, err := e.Decode(shards, len(shards[1]))
+	require.NoError(t, err)
+	require.Len(t, got, 2*len(shards[1]))
+	require.Equal(t, data, got[:len(data)])
+
+	shards[1][0] ^= 0xff
+	_, err = e.Decode(shards, len(shards[1]))
+	require.ErrorIs(t, err, ErrCorrupted)
+
+	_, err = e.Decode(shards[:2], len(shards[1]))
+	require.ErrorIs(t, err, ErrInvalidInput)
+}
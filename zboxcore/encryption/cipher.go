@@ -0,0 +1,140 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"runtime"
+	"sync"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/sys/cpu"
+)
+
+// CipherSuite is the AEAD used for the symmetric encryption of file chunks.
+// Both suites take the same 32 bytes key and 12 bytes nonce and add the same
+// 16 bytes tag, so the chunk layout doesn't depend on the suite.
+type CipherSuite string
+
+const (
+	// CipherAuto selects AES-GCM when the CPU accelerates it, ChaCha20-Poly1305 otherwise.
+	// Like CipherChaCha20Poly1305 it must be opted in, see SetCipher.
+	CipherAuto CipherSuite = "auto"
+	// CipherAESGCM is AES-256 in GCM mode, the suite of all previous SDK
+	// versions and the default.
+	CipherAESGCM CipherSuite = "aes-256-gcm"
+	// CipherChaCha20Poly1305 is fast in software, e.g. on ARM cores without crypto extensions.
+	CipherChaCha20Poly1305 CipherSuite = "chacha20-poly1305"
+)
+
+// ErrUnknownCipher is returned by SetCipher for an unsupported suite.
+var ErrUnknownCipher = errors.New("encryption: unknown cipher suite")
+
+// hasAESHardware tells if crypto/aes runs AES-GCM on dedicated instructions.
+var hasAESHardware = cpu.X86.HasAES && cpu.X86.HasPCLMULQDQ ||
+	cpu.ARM64.HasAES && cpu.ARM64.HasPMULL ||
+	cpu.S390X.HasAES && cpu.S390X.HasAESGCM ||
+	runtime.GOARCH == "ppc64le"
+
+var (
+	cipherMu         sync.RWMutex
+	configuredCipher = CipherAESGCM
+)
+
+// CipherInfo describes the encryption path of this process, for diagnostics.
+type CipherInfo struct {
+	Configured  CipherSuite `json:"configured"`
+	Selected    CipherSuite `json:"selected"`
+	HardwareAES bool        `json:"hardware_aes"`
+	Arch        string      `json:"arch"`
+}
+
+// SetCipher sets the suite used to encrypt new chunks, CipherAESGCM by
+// default. Decryption accepts both suites whatever the setting. Readers on
+// SDK versions without ChaCha20-Poly1305 support can only decrypt AES-GCM
+// chunks, only opt in to CipherChaCha20Poly1305 or CipherAuto when all the
+// readers of the files support it.
+func SetCipher(suite CipherSuite) error {
+	switch suite {
+	case CipherAuto, CipherAESGCM, CipherChaCha20Poly1305:
+	default:
+		return ErrUnknownCipher
+	}
+	cipherMu.Lock()
+	configuredCipher = suite
+	cipherMu.Unlock()
+	return nil
+}
+
+// GetCipherInfo returns the configured and selected suites.
+func GetCipherInfo() CipherInfo {
+	cipherMu.RLock()
+	configured := configuredCipher
+	cipherMu.RUnlock()
+	return CipherInfo{
+		Configured:  configured,
+		Selected:    selectCipher(configured, hasAESHardware),
+		HardwareAES: hasAESHardware,
+		Arch:        runtime.GOARCH,
+	}
+}
+
+func selectCipher(configured CipherSuite, hardwareAES bool) CipherSuite {
+	if configured != CipherAuto {
+		return configured
+	}
+	if hardwareAES {
+		return CipherAESGCM
+	}
+	return CipherChaCha20Poly1305
+}
+
+func newAEAD(suite CipherSuite, key []byte) (cipher.AEAD, error) {
+	switch suite {
+	case CipherAESGCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	case CipherChaCha20Poly1305:
+		return chacha20poly1305.New(key)
+	}
+	return nil, ErrUnknownCipher
+}
+
+// symSeal encrypts message with the selected suite.
+func symSeal(key, nonce, message []byte) ([]byte, error) {
+	aead, err := newAEAD(GetCipherInfo().Selected, key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nil, nonce, message, nil), nil
+}
+
+// symOpen decrypts ciphertext sealed with either suite. The suite isn't
+// stored with the chunk, the selected one is tried first and the tag check
+// rejects the wrong one.
+func symOpen(key, nonce, ciphertext []byte) ([]byte, error) {
+	first := GetCipherInfo().Selected
+	second := CipherAESGCM
+	if first == CipherAESGCM {
+		second = CipherChaCha20Poly1305
+	}
+
+	var firstErr error
+	for _, suite := range []CipherSuite{first, second} {
+		aead, err := newAEAD(suite, key)
+		if err != nil {
+			return nil, err
+		}
+		plain, err := aead.Open(nil, nonce, ciphertext, nil)
+		if err == nil {
+			return plain, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
@@ -2,8 +2,6 @@ package encryption
 
 import (
 	"bytes"
-	"crypto/aes"
-	"crypto/cipher"
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/base64"
@@ -368,26 +366,12 @@ func (pre *PREEncryptionScheme) encrypt(msg []byte) (*PREEncryptedMessage, error
 	return C, nil                                                                             // return C = (C1,C2,C3,C4,tagA)
 }
 
-//---------------------------------Symmetric Encryption using AES with GCM mode---------------------------------
+//---------------------------------Symmetric Encryption, AES-GCM or ChaCha20-Poly1305, see SetCipher---------------------------------
 func (pre *PREEncryptionScheme) SymEnc(group kyber.Group, message []byte, keyhash []byte) ([]byte, error) {
 	len := 32 + 12
 	key := keyhash[:32]
 	nonce := keyhash[32:len]
-	aes, err := aes.NewCipher(key)
-	if err != nil {
-		return nil, err
-	}
-	aesgcm, err := cipher.NewGCM(aes)
-	if err != nil {
-		return nil, err
-	}
-	c := aesgcm.Seal(nil, nonce, message, nil)
-	var ctx bytes.Buffer
-	_, err = ctx.Write(c)
-	if err != nil {
-		return nil, err
-	}
-	return ctx.Bytes(), nil
+	return symSeal(key, nonce, message)
 }
 
 func UnmarshallPublicKey(publicKey string) (kyber.Point, error) {
@@ -404,22 +388,12 @@ func UnmarshallPublicKey(publicKey string) (kyber.Point, error) {
 	return point, nil
 }
 
-//---------------------------------Symmetric Decryption using AES with GCM mode---------------------------------
+//---------------------------------Symmetric Decryption, accepts both suites---------------------------------
 func (pre *PREEncryptionScheme) SymDec(group kyber.Group, ctx []byte, keyhash []byte) ([]byte, error) {
 	len := 32 + 12
 	key := keyhash[:32]
 	nonce := keyhash[32:len]
-
-	// Decrypt message using AES-GCM
-	aes, err := aes.NewCipher(key)
-	if err != nil {
-		return nil, err
-	}
-	aesgcm, err := cipher.NewGCM(aes)
-	if err != nil {
-		return nil, err
-	}
-	return aesgcm.Open(nil, nonce, ctx, nil)
+	return symOpen(key, nonce, ctx)
 }
 
 func (pre *PREEncryptionScheme) Encrypt(data []byte) (*EncryptedMessage, error) {
@@ -46,6 +46,37 @@ func TestEncryptDecrypt(t *testing.T) {
 	require.Equal(t, string(decrypted), dataToEncrypt)
 }
 
+func TestEncryptDecryptAcrossCiphers(t *testing.T) {
+	mnemonic := "travel twenty hen negative fresh sentence hen flat swift embody increase juice eternal satisfy want vessel matter honey video begin dutch trigger romance assault"
+	require.Equal(t, CipherAESGCM, GetCipherInfo().Selected, "AES-GCM is the default whatever the CPU")
+	defer SetCipher(CipherAESGCM) //nolint: errcheck
+
+	suites := []CipherSuite{CipherAESGCM, CipherChaCha20Poly1305}
+	for _, encSuite := range suites {
+		for _, decSuite := range suites {
+			t.Run(string(encSuite)+"_to_"+string(decSuite), func(t *testing.T) {
+				encscheme := NewEncryptionScheme()
+				_, err := encscheme.Initialize(mnemonic)
+				require.NoError(t, err)
+				encscheme.InitForEncryption("filetype:audio")
+
+				require.NoError(t, SetCipher(encSuite))
+				encMessage, err := encscheme.Encrypt([]byte("encrypted_data_uttam"))
+				require.NoError(t, err)
+
+				require.NoError(t, SetCipher(decSuite))
+				decrypted, err := encscheme.Decrypt(encMessage)
+				require.NoError(t, err)
+				require.Equal(t, "encrypted_data_uttam", string(decrypted))
+			})
+		}
+	}
+
+	require.ErrorIs(t, SetCipher("des"), ErrUnknownCipher)
+	require.Equal(t, CipherAESGCM, selectCipher(CipherAuto, true))
+	require.Equal(t, CipherChaCha20Poly1305, selectCipher(CipherAuto, false))
+}
+
 func TestReEncryptionAndDecryptionForShareData(t *testing.T) {
 	client_mnemonic := "travel twenty hen negative fresh sentence hen flat swift embody increase juice eternal satisfy want vessel matter honey video begin dutch trigger romance assault"
 	client_encscheme := NewEncryptionScheme()
@@ -9,6 +9,16 @@ type ListResult struct {
 	AllocationRoot string                   `json:"allocation_root"`
 	Meta           map[string]interface{}   `json:"meta_data"`
 	Entities       []map[string]interface{} `json:"list"`
+	// Pagination is only set by blobbers that honour the offset/limit list
+	// parameters. It is nil when the blobber returned the whole directory.
+	Pagination *ListPagination `json:"pagination,omitempty"`
+}
+
+// ListPagination echoes the page a blobber applied to a list request.
+type ListPagination struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+	Total  int `json:"total"`
 }
 
 func (lr *ListResult) GetDirTree(allocationID string) (*Ref, error) {
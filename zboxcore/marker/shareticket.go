@@ -0,0 +1,146 @@
+package marker
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/zboxcore/client"
+)
+
+// ShareTicketVersion is the current version of the compact share ticket
+// encoding. Verifiers reject tickets with an unknown version.
+const ShareTicketVersion = 1
+
+// shareTicketPrefix lets gateways tell compact tickets apart from the
+// legacy base64 encoded AuthTicket json.
+const shareTicketPrefix = "zst1."
+
+// ShareTicket is a compact, self-describing share ticket. Unlike AuthTicket
+// it carries the owner's public key so that third parties (CDNs, gateways)
+// can verify it without access to the chain or any wallet keys.
+type ShareTicket struct {
+	Version        int    `json:"v"`
+	AllocationID   string `json:"a"`
+	OwnerID        string `json:"o"`
+	OwnerPublicKey string `json:"k"`
+	ClientID       string `json:"c,omitempty"`
+	FilePathHash   string `json:"p"`
+	ActualFileHash string `json:"h,omitempty"`
+	FileName       string `json:"n"`
+	RefType        string `json:"t"`
+	Encrypted      bool   `json:"x,omitempty"`
+	Expiration     int64  `json:"e,omitempty"`
+	Timestamp      int64  `json:"ts"`
+	Signature      string `json:"s"`
+}
+
+// NewShareTicket builds an unsigned ShareTicket from an AuthTicket.
+func NewShareTicket(at *AuthTicket, ownerPublicKey string) *ShareTicket {
+	return &ShareTicket{
+		Version:        ShareTicketVersion,
+		AllocationID:   at.AllocationID,
+		OwnerID:        at.OwnerID,
+		OwnerPublicKey: ownerPublicKey,
+		ClientID:       at.ClientID,
+		FilePathHash:   at.FilePathHash,
+		ActualFileHash: at.ActualFileHash,
+		FileName:       at.FileName,
+		RefType:        at.RefType,
+		Encrypted:      at.Encrypted,
+		Expiration:     at.Expiration,
+		Timestamp:      at.Timestamp,
+	}
+}
+
+func (st *ShareTicket) GetHashData() string {
+	return fmt.Sprintf("%v:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v",
+		st.Version,
+		st.AllocationID,
+		st.OwnerID,
+		st.OwnerPublicKey,
+		st.ClientID,
+		st.FilePathHash,
+		st.ActualFileHash,
+		st.FileName,
+		st.RefType,
+		st.Encrypted,
+		st.Expiration,
+		st.Timestamp,
+	)
+}
+
+// Sign signs the ticket with the current client's keys.
+func (st *ShareTicket) Sign() error {
+	var err error
+	hash := encryption.Hash(st.GetHashData())
+	st.Signature, err = client.Sign(hash)
+	return err
+}
+
+// Encode returns the url-safe string form of the ticket.
+func (st *ShareTicket) Encode() (string, error) {
+	buf, err := json.Marshal(st)
+	if err != nil {
+		return "", errors.Wrap(err, "share_ticket_encode")
+	}
+	return shareTicketPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// DecodeShareTicket parses an encoded ticket without verifying it.
+func DecodeShareTicket(encoded string) (*ShareTicket, error) {
+	if !strings.HasPrefix(encoded, shareTicketPrefix) {
+		return nil, errors.New("share_ticket_decode", "missing share ticket prefix")
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(encoded, shareTicketPrefix))
+	if err != nil {
+		return nil, errors.Wrap(err, "share_ticket_decode")
+	}
+	st := &ShareTicket{}
+	if err := json.Unmarshal(buf, st); err != nil {
+		return nil, errors.Wrap(err, "share_ticket_decode")
+	}
+	return st, nil
+}
+
+// VerifyShareTicket decodes and fully verifies a ticket: version, expiry
+// relative to now, that the embedded public key belongs to the owner, and
+// the owner's signature. It needs no wallet or network access.
+func VerifyShareTicket(encoded, signatureScheme string, now time.Time) (*ShareTicket, error) {
+	st, err := DecodeShareTicket(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if st.Version != ShareTicketVersion {
+		return nil, errors.New("share_ticket_version", fmt.Sprintf("unsupported share ticket version %d", st.Version))
+	}
+	if st.Expiration > 0 && now.Unix() > st.Expiration {
+		return nil, errors.New("share_ticket_expired", "share ticket has expired")
+	}
+	pub, err := hex.DecodeString(st.OwnerPublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "share_ticket_owner_key")
+	}
+	if encryption.Hash(pub) != st.OwnerID {
+		return nil, errors.New("share_ticket_owner_key", "public key does not belong to the owner")
+	}
+
+	sigScheme := zcncrypto.NewSignatureScheme(signatureScheme)
+	if err := sigScheme.SetPublicKey(st.OwnerPublicKey); err != nil {
+		return nil, errors.Wrap(err, "share_ticket_owner_key")
+	}
+	ok, err := sigScheme.Verify(st.Signature, encryption.Hash(st.GetHashData()))
+	if err != nil {
+		return nil, errors.Wrap(err, "share_ticket_signature")
+	}
+	if !ok {
+		return nil, errors.New("share_ticket_signature", "invalid share ticket signature")
+	}
+	return st, nil
+}
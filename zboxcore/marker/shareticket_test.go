@@ -0,0 +1,77 @@
+package marker
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyShareTicket(t *testing.T) {
+	sigScheme := zcncrypto.NewSignatureScheme("ed25519")
+	w, err := sigScheme.GenerateKeys()
+	require.NoError(t, err)
+	require.NoError(t, sigScheme.SetPrivateKey(w.Keys[0].PrivateKey))
+
+	now := time.Now()
+	newTicket := func() *ShareTicket {
+		return NewShareTicket(&AuthTicket{
+			OwnerID:      w.ClientID,
+			AllocationID: "allocation",
+			FilePathHash: "path hash",
+			FileName:     "file.txt",
+			RefType:      "f",
+			Expiration:   now.Add(time.Hour).Unix(),
+			Timestamp:    now.Unix(),
+		}, w.ClientKey)
+	}
+	sign := func(t *testing.T, st *ShareTicket) string {
+		var err error
+		st.Signature, err = sigScheme.Sign(encryption.Hash(st.GetHashData()))
+		require.NoError(t, err)
+		encoded, err := st.Encode()
+		require.NoError(t, err)
+		return encoded
+	}
+
+	t.Run("Test_Valid", func(t *testing.T) {
+		encoded := sign(t, newTicket())
+		require.True(t, strings.HasPrefix(encoded, shareTicketPrefix))
+		st, err := VerifyShareTicket(encoded, "ed25519", now)
+		require.NoError(t, err)
+		require.Equal(t, "file.txt", st.FileName)
+	})
+
+	t.Run("Test_Expired", func(t *testing.T) {
+		_, err := VerifyShareTicket(sign(t, newTicket()), "ed25519", now.Add(2*time.Hour))
+		require.Error(t, err)
+	})
+
+	t.Run("Test_Tampered", func(t *testing.T) {
+		st := newTicket()
+		encoded := sign(t, st)
+		st.FileName = "other.txt"
+		tampered, err := st.Encode()
+		require.NoError(t, err)
+		require.NotEqual(t, encoded, tampered)
+		_, err = VerifyShareTicket(tampered, "ed25519", now)
+		require.Error(t, err)
+	})
+
+	t.Run("Test_Foreign_Owner_Key", func(t *testing.T) {
+		st := newTicket()
+		st.OwnerID = "someone else"
+		_, err := VerifyShareTicket(sign(t, st), "ed25519", now)
+		require.Error(t, err)
+	})
+
+	t.Run("Test_Unknown_Version", func(t *testing.T) {
+		st := newTicket()
+		st.Version = ShareTicketVersion + 1
+		_, err := VerifyShareTicket(sign(t, st), "ed25519", now)
+		require.Error(t, err)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
@@ -18,10 +19,12 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/0chain/errors"
 	thrown "github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
 	"github.com/0chain/gosdk/core/common"
 	"github.com/0chain/gosdk/core/sys"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
@@ -90,6 +93,7 @@ type ConsolidatedFileMeta struct {
 	EncryptedKey    string
 	CommitMetaTxns  []fileref.CommitMetaTxn
 	Collaborators   []fileref.Collaborator
+	CustomMeta      map[string]string
 }
 
 type AllocationStats struct {
@@ -134,8 +138,25 @@ type BlobberAllocation struct {
 	Returned        common.Balance `json:"returned"`
 	ChallengeReward common.Balance `json:"challenge_reward"`
 	FinalReward     common.Balance `json:"final_reward"`
-}
-
+	// Stats of the blobber for the allocation, see GetChallengeStats
+	Stats *AllocationStats `json:"stats,omitempty"`
+}
+
+// Allocation is a storage allocation and the operations on its files.
+//
+// The operations of an Allocation are safe for concurrent use once
+// InitAllocation returned: each one carries its own request, with its
+// context, blobbers and consensus, and the state shared between them, the
+// uploads and downloads in progress, the blobber ranking and the timeouts, is
+// guarded. The exported fields are the allocation as last fetched from the
+// chain and must not be changed, e.g. by GetAllocationUpdates, while
+// operations are running.
+//
+// Most operations have a Ctx variant taking a context, e.g. ListDirCtx, the
+// plain one calling it with context.Background(). Once the context is done,
+// the requests to the blobbers in flight are cancelled and the operation
+// fails; only its cancellation is used, the timeouts of the requests being
+// the ones of the allocation, see SetTimeouts.
 type Allocation struct {
 	ID             string                    `json:"id"`
 	Tx             string                    `json:"tx"`
@@ -182,6 +203,10 @@ type Allocation struct {
 	downloadProgressMap     map[string]*DownloadRequest
 	repairRequestInProgress *RepairRequest
 	initialized             bool
+	ranking                 *blobberRanking
+	timeouts                *Timeouts
+	verifyDownloads         int32
+	reEncryptionKeys        atomic.Value // map[string]string by remote path, see SetReEncryptionKeys
 
 	// conseususes
 	consensusThreshold int
@@ -192,18 +217,27 @@ func (a *Allocation) GetStats() *AllocationStats {
 	return a.Stats
 }
 
+// GetBlobberStats returns the stats of the allocation on each of its blobbers
+// by url. Blobbers which fail to answer are left out.
 func (a *Allocation) GetBlobberStats() map[string]*BlobberAllocationStats {
+	return a.GetBlobberStatsCtx(context.Background())
+}
+
+// GetBlobberStatsCtx returns the stats the blobbers sent before ctx is done.
+func (a *Allocation) GetBlobberStatsCtx(ctx context.Context) map[string]*BlobberAllocationStats {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	numList := len(a.Blobbers)
 	wg := &sync.WaitGroup{}
 	wg.Add(numList)
 	rspCh := make(chan *BlobberAllocationStats, numList)
 	for _, blobber := range a.Blobbers {
-		go getAllocationDataFromBlobber(blobber, a.Tx, rspCh, wg)
+		go getAllocationDataFromBlobber(ctx, blobber, a.Tx, rspCh, wg)
 	}
 	wg.Wait()
+	close(rspCh)
 	result := make(map[string]*BlobberAllocationStats, len(a.Blobbers))
-	for i := 0; i < numList; i++ {
-		resp := <-rspCh
+	for resp := range rspCh {
 		result[resp.BlobberURL] = resp
 	}
 	return result
@@ -213,10 +247,11 @@ func (a *Allocation) InitAllocation() {
 	a.uploadChan = make(chan *UploadRequest, 10)
 	a.downloadChan = make(chan *DownloadRequest, 10)
 	a.repairChan = make(chan *RepairRequest, 1)
-	a.ctx, a.ctxCancelF = context.WithCancel(context.Background())
+	a.ctx, a.ctxCancelF = context.WithCancel(withAllocationTimeouts(lifecycleContext(), a))
 	a.uploadProgressMap = make(map[string]*UploadRequest)
 	a.downloadProgressMap = make(map[string]*DownloadRequest)
 	a.mutex = &sync.Mutex{}
+	a.ranking = &blobberRanking{}
 	a.fullconsensus, a.consensusThreshold = a.getConsensuses()
 	a.startWorker(a.ctx)
 	InitCommitWorker(a.Blobbers)
@@ -257,18 +292,37 @@ func (a *Allocation) dispatchWork(ctx context.Context) {
 // UpdateFile [Deprecated]please use CreateChunkedUpload
 func (a *Allocation) UpdateFile(workdir, localpath string, remotepath string,
 	status StatusCallback) error {
+	return a.UpdateFileCtx(context.Background(), workdir, localpath, remotepath, status)
+}
+
+// UpdateFileCtx replaces the file at remotepath with localpath.
+func (a *Allocation) UpdateFileCtx(ctx context.Context, workdir, localpath string, remotepath string,
+	status StatusCallback) error {
 
-	return a.StartChunkedUpload(workdir, localpath, remotepath, status, true, false, "", false)
+	return a.StartChunkedUploadCtx(ctx, workdir, localpath, remotepath, status, true, false, "", false)
 }
 
 // UploadFile [Deprecated]please use CreateChunkedUpload
 func (a *Allocation) UploadFile(workdir, localpath string, remotepath string,
 	status StatusCallback) error {
+	return a.UploadFileCtx(context.Background(), workdir, localpath, remotepath, status)
+}
+
+// UploadFileCtx uploads localpath to remotepath.
+func (a *Allocation) UploadFileCtx(ctx context.Context, workdir, localpath string, remotepath string,
+	status StatusCallback) error {
 
-	return a.StartChunkedUpload(workdir, localpath, remotepath, status, false, false, "", false)
+	return a.StartChunkedUploadCtx(ctx, workdir, localpath, remotepath, status, false, false, "", false)
 }
 
 func (a *Allocation) CreateDir(remotePath string) error {
+	return a.CreateDirCtx(context.Background(), remotePath)
+}
+
+// CreateDirCtx creates the directory remotePath on all the blobbers.
+func (a *Allocation) CreateDirCtx(ctx context.Context, remotePath string) error {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if !a.isInitialized() {
 		return notInitialized
 	}
@@ -289,7 +343,7 @@ func (a *Allocation) CreateDir(remotePath string) error {
 		mu:           &sync.Mutex{},
 		dirMask:      zboxutil.NewUint128(1).Lsh(uint64(len(a.Blobbers))).Sub64(1),
 		connectionID: zboxutil.NewConnectionId(),
-		ctx:          a.ctx,
+		ctx:          ctx,
 		remotePath:   remotePath,
 		wg:           &sync.WaitGroup{},
 		Consensus: Consensus{
@@ -299,22 +353,37 @@ func (a *Allocation) CreateDir(remotePath string) error {
 	}
 
 	err := req.ProcessDir(a)
+	InvalidateMetadataCache(a.ID)
 	return err
 }
 
 func (a *Allocation) RepairFile(localpath string, remotepath string,
 	status StatusCallback) error {
+	return a.RepairFileCtx(context.Background(), localpath, remotepath, status)
+}
+
+// RepairFileCtx uploads localpath to the blobbers missing the file at
+// remotepath.
+func (a *Allocation) RepairFileCtx(ctx context.Context, localpath string, remotepath string,
+	status StatusCallback) error {
 
 	idr, _ := homedir.Dir()
-	return a.StartChunkedUpload(idr, localpath, remotepath, status, false, true,
+	return a.StartChunkedUploadCtx(ctx, idr, localpath, remotepath, status, false, true,
 		"", false)
 }
 
 // UpdateFileWithThumbnail [Deprecated]please use CreateChunkedUpload
 func (a *Allocation) UpdateFileWithThumbnail(workdir, localpath string, remotepath string,
 	thumbnailpath string, status StatusCallback) error {
+	return a.UpdateFileWithThumbnailCtx(context.Background(), workdir, localpath, remotepath, thumbnailpath, status)
+}
+
+// UpdateFileWithThumbnailCtx replaces the file at remotepath and its
+// thumbnail with localpath and thumbnailpath.
+func (a *Allocation) UpdateFileWithThumbnailCtx(ctx context.Context, workdir, localpath string, remotepath string,
+	thumbnailpath string, status StatusCallback) error {
 
-	return a.StartChunkedUpload(workdir, localpath, remotepath, status, true, false,
+	return a.StartChunkedUploadCtx(ctx, workdir, localpath, remotepath, status, true, false,
 		thumbnailpath, false)
 }
 
@@ -322,30 +391,58 @@ func (a *Allocation) UpdateFileWithThumbnail(workdir, localpath string, remotepa
 func (a *Allocation) UploadFileWithThumbnail(workdir string, localpath string,
 	remotepath string, thumbnailpath string,
 	status StatusCallback) error {
+	return a.UploadFileWithThumbnailCtx(context.Background(), workdir, localpath, remotepath, thumbnailpath, status)
+}
 
-	return a.StartChunkedUpload(workdir, localpath, remotepath, status, false, false,
+// UploadFileWithThumbnailCtx uploads localpath to remotepath, with
+// thumbnailpath as its thumbnail.
+func (a *Allocation) UploadFileWithThumbnailCtx(ctx context.Context, workdir string, localpath string,
+	remotepath string, thumbnailpath string,
+	status StatusCallback) error {
+
+	return a.StartChunkedUploadCtx(ctx, workdir, localpath, remotepath, status, false, false,
 		thumbnailpath, false)
 }
 
 // EncryptAndUpdateFile [Deprecated]please use CreateChunkedUpload
 func (a *Allocation) EncryptAndUpdateFile(workdir string, localpath string, remotepath string,
 	status StatusCallback) error {
+	return a.EncryptAndUpdateFileCtx(context.Background(), workdir, localpath, remotepath, status)
+}
+
+// EncryptAndUpdateFileCtx replaces the file at remotepath with localpath
+// encrypted.
+func (a *Allocation) EncryptAndUpdateFileCtx(ctx context.Context, workdir string, localpath string, remotepath string,
+	status StatusCallback) error {
 
-	return a.StartChunkedUpload(workdir, localpath, remotepath, status, true, false, "", true)
+	return a.StartChunkedUploadCtx(ctx, workdir, localpath, remotepath, status, true, false, "", true)
 }
 
 // EncryptAndUploadFile [Deprecated]please use CreateChunkedUpload
 func (a *Allocation) EncryptAndUploadFile(workdir string, localpath string, remotepath string,
 	status StatusCallback) error {
+	return a.EncryptAndUploadFileCtx(context.Background(), workdir, localpath, remotepath, status)
+}
+
+// EncryptAndUploadFileCtx uploads localpath encrypted to remotepath.
+func (a *Allocation) EncryptAndUploadFileCtx(ctx context.Context, workdir string, localpath string, remotepath string,
+	status StatusCallback) error {
 
-	return a.StartChunkedUpload(workdir, localpath, remotepath, status, false, false, "", true)
+	return a.StartChunkedUploadCtx(ctx, workdir, localpath, remotepath, status, false, false, "", true)
 }
 
 // EncryptAndUpdateFileWithThumbnail [Deprecated]please use CreateChunkedUpload
 func (a *Allocation) EncryptAndUpdateFileWithThumbnail(workdir string, localpath string,
 	remotepath string, thumbnailpath string, status StatusCallback) error {
+	return a.EncryptAndUpdateFileWithThumbnailCtx(context.Background(), workdir, localpath, remotepath, thumbnailpath, status)
+}
+
+// EncryptAndUpdateFileWithThumbnailCtx replaces the file at remotepath and
+// its thumbnail with localpath and thumbnailpath, both encrypted.
+func (a *Allocation) EncryptAndUpdateFileWithThumbnailCtx(ctx context.Context, workdir string, localpath string,
+	remotepath string, thumbnailpath string, status StatusCallback) error {
 
-	return a.StartChunkedUpload(workdir, localpath, remotepath, status, true, false,
+	return a.StartChunkedUploadCtx(ctx, workdir, localpath, remotepath, status, true, false,
 		thumbnailpath, true)
 }
 
@@ -358,8 +455,21 @@ func (a *Allocation) EncryptAndUploadFileWithThumbnail(
 
 	status StatusCallback,
 ) error {
+	return a.EncryptAndUploadFileWithThumbnailCtx(context.Background(), workdir, localpath, remotepath, thumbnailpath, status)
+}
 
-	return a.StartChunkedUpload(workdir,
+// EncryptAndUploadFileWithThumbnailCtx uploads localpath encrypted to
+// remotepath, with thumbnailpath encrypted as its thumbnail.
+func (a *Allocation) EncryptAndUploadFileWithThumbnailCtx(ctx context.Context,
+	workdir string,
+	localpath string,
+	remotepath string,
+	thumbnailpath string,
+
+	status StatusCallback,
+) error {
+
+	return a.StartChunkedUploadCtx(ctx, workdir,
 		localpath,
 		remotepath,
 		status,
@@ -377,14 +487,35 @@ func (a *Allocation) StartChunkedUpload(workdir, localPath string,
 	isRepair bool,
 	thumbnailPath string,
 	encryption bool,
+	opts ...ChunkedUploadOption,
+) error {
+	return a.StartChunkedUploadCtx(context.Background(), workdir, localPath, remotePath, status, isUpdate, isRepair, thumbnailPath, encryption, opts...)
+}
 
+// StartChunkedUploadCtx uploads localPath to remotePath chunk by chunk and
+// commits it on the blobbers, returning once it's committed.
+func (a *Allocation) StartChunkedUploadCtx(ctx context.Context, workdir, localPath string,
+	remotePath string,
+	status StatusCallback,
+	isUpdate bool,
+	isRepair bool,
+	thumbnailPath string,
+	encryption bool,
+	opts ...ChunkedUploadOption,
 ) error {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 
 	if !a.isInitialized() {
 		return notInitialized
 	}
+	done, err := beginOp()
+	if err != nil {
+		return err
+	}
+	defer done()
 
-	fileReader, err := os.Open(localPath)
+	fileReader, err := sys.Files.Open(localPath)
 	if err != nil {
 		return err
 	}
@@ -419,6 +550,7 @@ func (a *Allocation) StartChunkedUpload(workdir, localPath string,
 	}
 
 	options := []ChunkedUploadOption{
+		WithContext(ctx),
 		WithEncrypt(encryption),
 		WithStatusCallback(status),
 	}
@@ -431,6 +563,7 @@ func (a *Allocation) StartChunkedUpload(workdir, localPath string,
 
 		options = append(options, WithThumbnail(buf))
 	}
+	options = append(options, opts...)
 
 	ChunkedUpload, err := CreateChunkedUpload(workdir,
 		a, fileMeta, fileReader,
@@ -520,7 +653,7 @@ func (a *Allocation) uploadOrUpdateFile(localpath string,
 			return errors.New("", "Repair not required")
 		}
 
-		file, _ := ioutil.ReadFile(localpath)
+		file, _ := sys.Files.ReadFile(localpath)
 		hash := sha256.New()
 		hash.Write(file)
 		contentHash := hex.EncodeToString(hash.Sum(nil))
@@ -538,6 +671,11 @@ func (a *Allocation) uploadOrUpdateFile(localpath string,
 		return fmt.Errorf("allocation requires [%v] blobbers, which is greater than the maximum permitted number of [%v]. reduce number of data or parity shards and try again", uploadReq.fullconsensus, uploadReq.GetMaxBlobbersSupported())
 	}
 
+	uploadReq.opDone, err = beginOp()
+	if err != nil {
+		return err
+	}
+	uploadReq.ctx, uploadReq.ctxCncl = a.opContext(nil)
 	go func() {
 		a.uploadChan <- uploadReq
 		a.mutex.Lock()
@@ -548,6 +686,14 @@ func (a *Allocation) uploadOrUpdateFile(localpath string,
 }
 
 func (a *Allocation) RepairRequired(remotepath string) (zboxutil.Uint128, bool, *fileref.FileRef, error) {
+	return a.RepairRequiredCtx(context.Background(), remotepath)
+}
+
+// RepairRequiredCtx returns the blobbers holding the file at remotepath, and
+// whether it is missing on some of them and needs a repair.
+func (a *Allocation) RepairRequiredCtx(ctx context.Context, remotepath string) (zboxutil.Uint128, bool, *fileref.FileRef, error) {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if !a.isInitialized() {
 		return zboxutil.Uint128{}, false, nil, notInitialized
 	}
@@ -558,7 +704,7 @@ func (a *Allocation) RepairRequired(remotepath string) (zboxutil.Uint128, bool,
 	listReq.blobbers = a.Blobbers
 	listReq.fullconsensus = a.fullconsensus
 	listReq.consensusThresh = a.consensusThreshold
-	listReq.ctx = a.ctx
+	listReq.ctx = ctx
 	listReq.remotefilepath = remotepath
 	found, fileRef, _ := listReq.getFileConsensusFromBlobbers()
 	if fileRef == nil {
@@ -571,18 +717,112 @@ func (a *Allocation) RepairRequired(remotepath string) (zboxutil.Uint128, bool,
 }
 
 func (a *Allocation) DownloadFile(localPath string, remotePath string, status StatusCallback) error {
-	return a.downloadFile(localPath, remotePath, DOWNLOAD_CONTENT_FULL, 1, 0, numBlockDownloads, status)
+	return a.DownloadFileCtx(context.Background(), localPath, remotePath, status)
+}
+
+// DownloadFileCtx downloads remotePath to localPath in the background,
+// status being told of its progress. ctx is kept for the whole download,
+// after DownloadFileCtx returned.
+func (a *Allocation) DownloadFileCtx(ctx context.Context, localPath string, remotePath string, status StatusCallback) error {
+	return a.downloadFileCtx(ctx, localPath, remotePath, DOWNLOAD_CONTENT_FULL, 1, 0, numBlockDownloads, status)
 }
 
 func (a *Allocation) DownloadFileByBlock(localPath string, remotePath string, startBlock int64, endBlock int64, numBlocks int, status StatusCallback) error {
-	return a.downloadFile(localPath, remotePath, DOWNLOAD_CONTENT_FULL, startBlock, endBlock, numBlocks, status)
+	return a.DownloadFileByBlockCtx(context.Background(), localPath, remotePath, startBlock, endBlock, numBlocks, status)
+}
+
+// DownloadFileByBlockCtx downloads the blocks from startBlock to endBlock of
+// remotePath to localPath in the background, like DownloadFileCtx.
+func (a *Allocation) DownloadFileByBlockCtx(ctx context.Context, localPath string, remotePath string, startBlock int64, endBlock int64, numBlocks int, status StatusCallback) error {
+	return a.downloadFileCtx(ctx, localPath, remotePath, DOWNLOAD_CONTENT_FULL, startBlock, endBlock, numBlocks, status)
 }
 
 func (a *Allocation) DownloadThumbnail(localPath string, remotePath string, status StatusCallback) error {
-	return a.downloadFile(localPath, remotePath, DOWNLOAD_CONTENT_THUMB, 1, 0, numBlockDownloads, status)
+	return a.DownloadThumbnailCtx(context.Background(), localPath, remotePath, status)
+}
+
+// DownloadThumbnailCtx downloads the thumbnail of remotePath to localPath in
+// the background, like DownloadFileCtx.
+func (a *Allocation) DownloadThumbnailCtx(ctx context.Context, localPath string, remotePath string, status StatusCallback) error {
+	return a.downloadFileCtx(ctx, localPath, remotePath, DOWNLOAD_CONTENT_THUMB, 1, 0, numBlockDownloads, status)
+}
+
+// DownloadThumbnailTo writes the thumbnail of the file at remotePath to w.
+// Only the thumbnail shards are fetched from the blobbers. Unlike
+// DownloadThumbnail, it returns once the thumbnail is written.
+func (a *Allocation) DownloadThumbnailTo(remotePath string, w io.Writer) error {
+	return a.DownloadThumbnailToCtx(context.Background(), remotePath, w)
+}
+
+// DownloadThumbnailToCtx writes the thumbnail of remotePath to w, returning
+// once it is written or ctx is done.
+func (a *Allocation) DownloadThumbnailToCtx(ctx context.Context, remotePath string, w io.Writer) error {
+	if !a.isInitialized() {
+		return notInitialized
+	}
+	return a.downloadToCtx(ctx, remotePath, DOWNLOAD_CONTENT_THUMB, w)
+}
+
+// downloadTo writes the content of the file at remotePath to w, the
+// thumbnail or the whole file as per contentMode, and returns once it's
+// written.
+func (a *Allocation) downloadTo(remotePath string, contentMode string, w io.Writer) error {
+	return a.downloadToCtx(context.Background(), remotePath, contentMode, w)
+}
+
+// downloadToCtx is downloadTo with the context of the caller.
+func (a *Allocation) downloadToCtx(ctx context.Context, remotePath string, contentMode string, w io.Writer) error {
+	if len(a.Blobbers) == 0 {
+		return noBLOBBERS
+	}
+
+	status := &syncStatusCallback{}
+	downloadReq := a.newDownloadRequest(ctx, remotePath, contentMode, 1, 0, numBlockDownloads, status)
+	downloadReq.writer = w
+	defer downloadReq.ctxCncl()
+	downloadReq.processDownload(downloadReq.ctx)
+	return status.err
+}
+
+// newDownloadRequest returns the request downloading the blocks from
+// startBlock to endBlock of the file at remotePath, counted from 1. endBlock
+// 0 is the last block of the file.
+func (a *Allocation) newDownloadRequest(ctx context.Context, remotePath string, contentMode string,
+	startBlock int64, endBlock int64, numBlocks int,
+	status StatusCallback) *DownloadRequest {
+
+	downloadReq := &DownloadRequest{}
+	downloadReq.maskMu = &sync.Mutex{}
+	downloadReq.allocationID = a.ID
+	downloadReq.allocationTx = a.Tx
+	downloadReq.allocOwnerID = a.Owner
+	downloadReq.ctx, downloadReq.ctxCncl = a.opContext(ctx)
+	downloadReq.remotefilepath = remotePath
+	downloadReq.statusCallback = status
+	downloadReq.downloadMask = zboxutil.NewUint128(1).Lsh(uint64(len(a.Blobbers))).Sub64(1)
+	downloadReq.blobbers = a.Blobbers
+	downloadReq.blobberOrder = a.ranking.order(a.Blobbers)
+	downloadReq.datashards = a.DataShards
+	downloadReq.parityshards = a.ParityShards
+	downloadReq.startBlock = startBlock - 1
+	downloadReq.endBlock = endBlock
+	downloadReq.numBlocks = int64(numBlocks)
+	downloadReq.fullconsensus = a.fullconsensus
+	downloadReq.consensusThresh = a.consensusThreshold
+	downloadReq.verifyDownloads = a.isVerifyingDownloads()
+	downloadReq.reEncryptionKey = a.reEncryptionKey(remotePath)
+	downloadReq.contentMode = contentMode
+	return downloadReq
 }
 
 func (a *Allocation) downloadFile(localPath string, remotePath string, contentMode string,
+	startBlock int64, endBlock int64, numBlocks int,
+	status StatusCallback) error {
+	return a.downloadFileCtx(context.Background(), localPath, remotePath, contentMode, startBlock, endBlock, numBlocks, status)
+}
+
+// downloadFileCtx is downloadFile with the context of the caller.
+func (a *Allocation) downloadFileCtx(ctx context.Context, localPath string, remotePath string, contentMode string,
 	startBlock int64, endBlock int64, numBlocks int,
 	status StatusCallback) error {
 	if !a.isInitialized() {
@@ -610,30 +850,14 @@ func (a *Allocation) downloadFile(localPath string, remotePath string, contentMo
 		return noBLOBBERS
 	}
 
-	downloadReq := &DownloadRequest{}
-	downloadReq.maskMu = &sync.Mutex{}
-	downloadReq.allocationID = a.ID
-	downloadReq.allocationTx = a.Tx
-	downloadReq.allocOwnerID = a.Owner
-	downloadReq.ctx, downloadReq.ctxCncl = context.WithCancel(a.ctx)
+	downloadReq := a.newDownloadRequest(ctx, remotePath, contentMode, startBlock, endBlock, numBlocks, status)
 	downloadReq.localpath = localPath
-	downloadReq.remotefilepath = remotePath
-	downloadReq.statusCallback = status
-	downloadReq.downloadMask = zboxutil.NewUint128(1).Lsh(uint64(len(a.Blobbers))).Sub64(1)
-	downloadReq.blobbers = a.Blobbers
-	downloadReq.datashards = a.DataShards
-	downloadReq.parityshards = a.ParityShards
-	downloadReq.startBlock = startBlock - 1
-	downloadReq.endBlock = endBlock
-	downloadReq.numBlocks = int64(numBlocks)
-	downloadReq.fullconsensus = a.fullconsensus
-	downloadReq.consensusThresh = a.consensusThreshold
 	downloadReq.completedCallback = func(remotepath string, remotepathhash string) {
+		downloadReq.ctxCncl()
 		a.mutex.Lock()
 		defer a.mutex.Unlock()
 		delete(a.downloadProgressMap, remotepath)
 	}
-	downloadReq.contentMode = contentMode
 	go func() {
 		a.downloadChan <- downloadReq
 		a.mutex.Lock()
@@ -644,17 +868,25 @@ func (a *Allocation) downloadFile(localPath string, remotePath string, contentMo
 }
 
 func (a *Allocation) ListDirFromAuthTicket(authTicket string, lookupHash string) (*ListResult, error) {
+	return a.ListDirFromAuthTicketCtx(context.Background(), authTicket, lookupHash)
+}
+
+// ListDirFromAuthTicketCtx lists the shared directory of lookupHash with
+// authTicket.
+func (a *Allocation) ListDirFromAuthTicketCtx(ctx context.Context, authTicket string, lookupHash string) (*ListResult, error) {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if !a.isInitialized() {
 		return nil, notInitialized
 	}
 	sEnc, err := base64.StdEncoding.DecodeString(authTicket)
 	if err != nil {
-		return nil, errors.New("auth_ticket_decode_error", "Error decoding the auth ticket."+err.Error())
+		return nil, errors.Throw(constants.ErrInvalidAuthTicket, "Error decoding the auth ticket."+err.Error())
 	}
 	at := &marker.AuthTicket{}
 	err = json.Unmarshal(sEnc, at)
 	if err != nil {
-		return nil, errors.New("auth_ticket_decode_error", "Error unmarshaling the auth ticket."+err.Error())
+		return nil, errors.Throw(constants.ErrInvalidAuthTicket, "Error unmarshaling the auth ticket."+err.Error())
 	}
 	if len(at.FilePathHash) == 0 || len(lookupHash) == 0 {
 		return nil, errors.New("invalid_path", "Invalid path for the list")
@@ -666,7 +898,7 @@ func (a *Allocation) ListDirFromAuthTicket(authTicket string, lookupHash string)
 	listReq.blobbers = a.Blobbers
 	listReq.fullconsensus = a.fullconsensus
 	listReq.consensusThresh = a.consensusThreshold
-	listReq.ctx = a.ctx
+	listReq.ctx = ctx
 	listReq.remotefilepathhash = lookupHash
 	listReq.authToken = at
 	ref, err := listReq.GetListFromBlobbers()
@@ -682,6 +914,14 @@ func (a *Allocation) ListDirFromAuthTicket(authTicket string, lookupHash string)
 }
 
 func (a *Allocation) ListDir(path string) (*ListResult, error) {
+	return a.ListDirCtx(context.Background(), path)
+}
+
+// ListDirCtx lists the directory at path, with the consensus of the
+// blobbers.
+func (a *Allocation) ListDirCtx(ctx context.Context, path string) (*ListResult, error) {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if !a.isInitialized() {
 		return nil, notInitialized
 	}
@@ -699,14 +939,23 @@ func (a *Allocation) ListDir(path string) (*ListResult, error) {
 	listReq.blobbers = a.Blobbers
 	listReq.fullconsensus = a.fullconsensus
 	listReq.consensusThresh = a.consensusThreshold
-	listReq.ctx = a.ctx
+	listReq.ctx = ctx
 	listReq.remotefilepath = path
+	mc := getMetadataCache()
+	if mc != nil {
+		if ref, ok := mc.lists.get(pathCacheKey(a.ID, path)); ok {
+			return ref.clone(), nil
+		}
+	}
 	ref, err := listReq.GetListFromBlobbers()
 	if err != nil {
 		return nil, err
 	}
 
 	if ref != nil {
+		if mc != nil {
+			mc.lists.add(pathCacheKey(a.ID, path), ref.clone())
+		}
 		return ref, nil
 	}
 	return nil, errors.New("list_request_failed", "Failed to get list response from the blobbers")
@@ -715,6 +964,13 @@ func (a *Allocation) ListDir(path string) (*ListResult, error) {
 // This function will retrieve paginated objectTree and will handle concensus; Required tree should be made in application side.
 // TODO use allocation context
 func (a *Allocation) GetRefs(path, offsetPath, updatedDate, offsetDate, fileType, refType string, level, pageLimit int) (*ObjectTreeResult, error) {
+	return a.GetRefsCtx(context.Background(), path, offsetPath, updatedDate, offsetDate, fileType, refType, level, pageLimit)
+}
+
+// GetRefsCtx returns a page of the refs under path.
+func (a *Allocation) GetRefsCtx(ctx context.Context, path, offsetPath, updatedDate, offsetDate, fileType, refType string, level, pageLimit int) (*ObjectTreeResult, error) {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if len(path) == 0 || !zboxutil.IsRemoteAbs(path) {
 		return nil, errors.New("invalid_path", fmt.Sprintf("Absolute path required. Path provided: %v", path))
 	}
@@ -734,7 +990,7 @@ func (a *Allocation) GetRefs(path, offsetPath, updatedDate, offsetDate, fileType
 		fileType:       fileType,
 		refType:        refType,
 		wg:             &sync.WaitGroup{},
-		ctx:            a.ctx,
+		ctx:            ctx,
 	}
 	oTreeReq.fullconsensus = a.fullconsensus
 	oTreeReq.consensusThresh = a.consensusThreshold
@@ -743,6 +999,13 @@ func (a *Allocation) GetRefs(path, offsetPath, updatedDate, offsetDate, fileType
 }
 
 func (a *Allocation) GetRecentlyAddedRefs(page int, fromDate int64, pageLimit int) (*RecentlyAddedRefResult, error) {
+	return a.GetRecentlyAddedRefsCtx(context.Background(), page, fromDate, pageLimit)
+}
+
+// GetRecentlyAddedRefsCtx returns a page of the refs added since fromDate.
+func (a *Allocation) GetRecentlyAddedRefsCtx(ctx context.Context, page int, fromDate int64, pageLimit int) (*RecentlyAddedRefResult, error) {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if !a.isInitialized() {
 		return nil, notInitialized
 	}
@@ -760,7 +1023,7 @@ func (a *Allocation) GetRecentlyAddedRefs(page int, fromDate int64, pageLimit in
 		blobbers:     a.Blobbers,
 		offset:       offset,
 		fromDate:     fromDate,
-		ctx:          a.ctx,
+		ctx:          ctx,
 		wg:           &sync.WaitGroup{},
 		pageLimit:    pageLimit,
 		Consensus: Consensus{
@@ -772,10 +1035,25 @@ func (a *Allocation) GetRecentlyAddedRefs(page int, fromDate int64, pageLimit in
 }
 
 func (a *Allocation) GetFileMeta(path string) (*ConsolidatedFileMeta, error) {
+	return a.GetFileMetaCtx(context.Background(), path)
+}
+
+// GetFileMetaCtx returns the meta of the file at path agreed on by the
+// blobbers.
+func (a *Allocation) GetFileMetaCtx(ctx context.Context, path string) (*ConsolidatedFileMeta, error) {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if !a.isInitialized() {
 		return nil, notInitialized
 	}
 
+	mc := getMetadataCache()
+	if mc != nil {
+		if result, ok := mc.fileMetas.get(pathCacheKey(a.ID, path)); ok {
+			return result.clone(), nil
+		}
+	}
+
 	result := &ConsolidatedFileMeta{}
 	listReq := &ListRequest{}
 	listReq.allocationID = a.ID
@@ -783,7 +1061,7 @@ func (a *Allocation) GetFileMeta(path string) (*ConsolidatedFileMeta, error) {
 	listReq.blobbers = a.Blobbers
 	listReq.fullconsensus = a.fullconsensus
 	listReq.consensusThresh = a.consensusThreshold
-	listReq.ctx = a.ctx
+	listReq.ctx = ctx
 	listReq.remotefilepath = path
 	_, ref, _ := listReq.getFileConsensusFromBlobbers()
 	if ref != nil {
@@ -799,12 +1077,24 @@ func (a *Allocation) GetFileMeta(path string) (*ConsolidatedFileMeta, error) {
 		result.Collaborators = ref.Collaborators
 		result.ActualFileSize = ref.Size
 		result.ActualNumBlocks = ref.NumBlocks
+		result.CustomMeta = decodeCustomMeta(ref.CustomMeta)
+		if mc != nil {
+			mc.fileMetas.add(pathCacheKey(a.ID, path), result.clone())
+		}
 		return result, nil
 	}
 	return nil, errors.New("file_meta_error", "Error getting the file meta data from blobbers")
 }
 
 func (a *Allocation) GetFileMetaFromAuthTicket(authTicket string, lookupHash string) (*ConsolidatedFileMeta, error) {
+	return a.GetFileMetaFromAuthTicketCtx(context.Background(), authTicket, lookupHash)
+}
+
+// GetFileMetaFromAuthTicketCtx returns the meta of the shared file of
+// lookupHash with authTicket.
+func (a *Allocation) GetFileMetaFromAuthTicketCtx(ctx context.Context, authTicket string, lookupHash string) (*ConsolidatedFileMeta, error) {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if !a.isInitialized() {
 		return nil, notInitialized
 	}
@@ -812,12 +1102,12 @@ func (a *Allocation) GetFileMetaFromAuthTicket(authTicket string, lookupHash str
 	result := &ConsolidatedFileMeta{}
 	sEnc, err := base64.StdEncoding.DecodeString(authTicket)
 	if err != nil {
-		return nil, errors.New("auth_ticket_decode_error", "Error decoding the auth ticket."+err.Error())
+		return nil, errors.Throw(constants.ErrInvalidAuthTicket, "Error decoding the auth ticket."+err.Error())
 	}
 	at := &marker.AuthTicket{}
 	err = json.Unmarshal(sEnc, at)
 	if err != nil {
-		return nil, errors.New("auth_ticket_decode_error", "Error unmarshaling the auth ticket."+err.Error())
+		return nil, errors.Throw(constants.ErrInvalidAuthTicket, "Error unmarshaling the auth ticket."+err.Error())
 	}
 	if len(at.FilePathHash) == 0 || len(lookupHash) == 0 {
 		return nil, errors.New("invalid_path", "Invalid path for the list")
@@ -829,7 +1119,7 @@ func (a *Allocation) GetFileMetaFromAuthTicket(authTicket string, lookupHash str
 	listReq.blobbers = a.Blobbers
 	listReq.fullconsensus = a.fullconsensus
 	listReq.consensusThresh = a.consensusThreshold
-	listReq.ctx = a.ctx
+	listReq.ctx = ctx
 	listReq.remotefilepathhash = lookupHash
 	listReq.authToken = at
 	_, ref, _ := listReq.getFileConsensusFromBlobbers()
@@ -844,12 +1134,20 @@ func (a *Allocation) GetFileMetaFromAuthTicket(authTicket string, lookupHash str
 		result.CommitMetaTxns = ref.CommitMetaTxns
 		result.ActualFileSize = ref.Size
 		result.ActualNumBlocks = ref.NumBlocks
+		result.CustomMeta = decodeCustomMeta(ref.CustomMeta)
 		return result, nil
 	}
 	return nil, errors.New("file_meta_error", "Error getting the file meta data from blobbers")
 }
 
 func (a *Allocation) GetFileStats(path string) (map[string]*FileStats, error) {
+	return a.GetFileStatsCtx(context.Background(), path)
+}
+
+// GetFileStatsCtx returns the stats of the file at path on each blobber.
+func (a *Allocation) GetFileStatsCtx(ctx context.Context, path string) (map[string]*FileStats, error) {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if !a.isInitialized() {
 		return nil, notInitialized
 	}
@@ -867,7 +1165,7 @@ func (a *Allocation) GetFileStats(path string) (map[string]*FileStats, error) {
 	listReq.blobbers = a.Blobbers
 	listReq.fullconsensus = a.fullconsensus
 	listReq.consensusThresh = a.consensusThreshold
-	listReq.ctx = a.ctx
+	listReq.ctx = ctx
 	listReq.remotefilepath = path
 	ref := listReq.getFileStatsFromBlobbers()
 	if ref != nil {
@@ -877,10 +1175,22 @@ func (a *Allocation) GetFileStats(path string) (map[string]*FileStats, error) {
 }
 
 func (a *Allocation) DeleteFile(path string) error {
-	return a.deleteFile(path, a.consensusThreshold, a.fullconsensus)
+	return a.DeleteFileCtx(context.Background(), path)
+}
+
+// DeleteFileCtx deletes the file or directory at path.
+func (a *Allocation) DeleteFileCtx(ctx context.Context, path string) error {
+	return a.deleteFileCtx(ctx, path, a.consensusThreshold, a.fullconsensus)
 }
 
 func (a *Allocation) deleteFile(path string, threshConsensus, fullConsensus int) error {
+	return a.deleteFileCtx(context.Background(), path, threshConsensus, fullConsensus)
+}
+
+// deleteFileCtx is deleteFile with the context of the caller.
+func (a *Allocation) deleteFileCtx(ctx context.Context, path string, threshConsensus, fullConsensus int) error {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if !a.isInitialized() {
 		return notInitialized
 	}
@@ -900,16 +1210,24 @@ func (a *Allocation) deleteFile(path string, threshConsensus, fullConsensus int)
 	req.allocationID = a.ID
 	req.allocationTx = a.Tx
 	req.consensus.Init(threshConsensus, fullConsensus)
-	req.ctx = a.ctx
+	req.ctx = ctx
 	req.remotefilepath = path
 	req.connectionID = zboxutil.NewConnectionId()
 	req.deleteMask = zboxutil.NewUint128(1).Lsh(uint64(len(a.Blobbers))).Sub64(1)
 	req.maskMu = &sync.Mutex{}
 	err := req.ProcessDelete()
+	InvalidateMetadataCache(a.ID)
 	return err
 }
 
 func (a *Allocation) RenameObject(path string, destName string) error {
+	return a.RenameObjectCtx(context.Background(), path, destName)
+}
+
+// RenameObjectCtx renames the file or directory at path to destName.
+func (a *Allocation) RenameObjectCtx(ctx context.Context, path string, destName string) error {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if !a.isInitialized() {
 		return notInitialized
 	}
@@ -941,15 +1259,23 @@ func (a *Allocation) RenameObject(path string, destName string) error {
 	req.newName = destName
 	req.consensus.fullconsensus = a.fullconsensus
 	req.consensus.consensusThresh = a.consensusThreshold
-	req.ctx = a.ctx
+	req.ctx = ctx
 	req.remotefilepath = path
 	req.renameMask = zboxutil.NewUint128(1).Lsh(uint64(len(a.Blobbers))).Sub64(1)
 	req.maskMU = &sync.Mutex{}
 	req.connectionID = zboxutil.NewConnectionId()
+	defer InvalidateMetadataCache(a.ID)
 	return req.ProcessRename()
 }
 
 func (a *Allocation) MoveObject(srcPath string, destPath string) error {
+	return a.MoveObjectCtx(context.Background(), srcPath, destPath)
+}
+
+// MoveObjectCtx moves the file or directory at srcPath to destPath.
+func (a *Allocation) MoveObjectCtx(ctx context.Context, srcPath string, destPath string) error {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if !a.isInitialized() {
 		return notInitialized
 	}
@@ -979,15 +1305,23 @@ func (a *Allocation) MoveObject(srcPath string, destPath string) error {
 	req.destPath = destPath
 	req.fullconsensus = a.fullconsensus
 	req.consensusThresh = a.consensusThreshold
-	req.ctx = a.ctx
+	req.ctx = ctx
 	req.remotefilepath = srcPath
 	req.moveMask = zboxutil.NewUint128(1).Lsh(uint64(len(a.Blobbers))).Sub64(1)
 	req.maskMU = &sync.Mutex{}
 	req.connectionID = zboxutil.NewConnectionId()
+	defer InvalidateMetadataCache(a.ID)
 	return req.ProcessMove()
 }
 
 func (a *Allocation) CopyObject(path string, destPath string) error {
+	return a.CopyObjectCtx(context.Background(), path, destPath)
+}
+
+// CopyObjectCtx copies the file or directory at path to destPath.
+func (a *Allocation) CopyObjectCtx(ctx context.Context, path string, destPath string) error {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if !a.isInitialized() {
 		return notInitialized
 	}
@@ -1017,11 +1351,12 @@ func (a *Allocation) CopyObject(path string, destPath string) error {
 	req.destPath = destPath
 	req.fullconsensus = a.fullconsensus
 	req.consensusThresh = a.consensusThreshold
-	req.ctx = a.ctx
+	req.ctx = ctx
 	req.remotefilepath = path
 	req.copyMask = zboxutil.NewUint128(1).Lsh(uint64(len(a.Blobbers))).Sub64(1)
 	req.maskMU = &sync.Mutex{}
 	req.connectionID = zboxutil.NewConnectionId()
+	defer InvalidateMetadataCache(a.ID)
 	return req.ProcessCopy()
 }
 
@@ -1033,6 +1368,13 @@ func (a *Allocation) GetAuthTicketForShare(
 }
 
 func (a *Allocation) RevokeShare(path string, refereeClientID string) error {
+	return a.RevokeShareCtx(context.Background(), path, refereeClientID)
+}
+
+// RevokeShareCtx revokes the share of path with refereeClientID.
+func (a *Allocation) RevokeShareCtx(ctx context.Context, path string, refereeClientID string) error {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	success := make(chan int, len(a.Blobbers))
 	notFound := make(chan int, len(a.Blobbers))
 	wg := &sync.WaitGroup{}
@@ -1050,7 +1392,7 @@ func (a *Allocation) RevokeShare(path string, refereeClientID string) error {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			err := zboxutil.HttpDo(a.ctx, a.ctxCancelF, httpreq, func(resp *http.Response, err error) error {
+			err := zboxutil.HttpDoBlobber(ctx, cancel, httpreq, func(resp *http.Response, err error) error {
 				if err != nil {
 					l.Logger.Error("Revoke share : ", err)
 					return err
@@ -1093,6 +1435,16 @@ func (a *Allocation) RevokeShare(path string, refereeClientID string) error {
 
 func (a *Allocation) GetAuthTicket(path, filename string,
 	referenceType, refereeClientID, refereeEncryptionPublicKey string, expiration int64, availableAfter *time.Time) (string, error) {
+	return a.GetAuthTicketCtx(context.Background(), path, filename, referenceType, refereeClientID, refereeEncryptionPublicKey, expiration, availableAfter)
+}
+
+// GetAuthTicketCtx shares path with refereeClientID and returns the auth
+// ticket, the file being re-encrypted for refereeEncryptionPublicKey if it
+// is encrypted.
+func (a *Allocation) GetAuthTicketCtx(ctx context.Context, path, filename string,
+	referenceType, refereeClientID, refereeEncryptionPublicKey string, expiration int64, availableAfter *time.Time) (string, error) {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 
 	if !a.isInitialized() {
 		return "", notInitialized
@@ -1113,7 +1465,7 @@ func (a *Allocation) GetAuthTicket(path, filename string,
 		allocationID:      a.ID,
 		allocationTx:      a.Tx,
 		blobbers:          a.Blobbers,
-		ctx:               a.ctx,
+		ctx:               ctx,
 		remotefilepath:    path,
 		remotefilename:    filename,
 	}
@@ -1134,7 +1486,7 @@ func (a *Allocation) GetAuthTicket(path, filename string,
 		return "", err
 	}
 
-	if err := a.UploadAuthTicketToBlobber(string(atBytes), refereeEncryptionPublicKey, availableAfter); err != nil {
+	if err := a.UploadAuthTicketToBlobberCtx(ctx, string(atBytes), refereeEncryptionPublicKey, availableAfter); err != nil {
 		return "", err
 	}
 
@@ -1152,6 +1504,13 @@ func (a *Allocation) GetAuthTicket(path, filename string,
 }
 
 func (a *Allocation) UploadAuthTicketToBlobber(authTicket string, clientEncPubKey string, availableAfter *time.Time) error {
+	return a.UploadAuthTicketToBlobberCtx(context.Background(), authTicket, clientEncPubKey, availableAfter)
+}
+
+// UploadAuthTicketToBlobberCtx registers authTicket on the blobbers.
+func (a *Allocation) UploadAuthTicketToBlobberCtx(ctx context.Context, authTicket string, clientEncPubKey string, availableAfter *time.Time) error {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	success := make(chan int, len(a.Blobbers))
 	wg := &sync.WaitGroup{}
 	for idx := range a.Blobbers {
@@ -1182,7 +1541,7 @@ func (a *Allocation) UploadAuthTicketToBlobber(authTicket string, clientEncPubKe
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			err := zboxutil.HttpDo(a.ctx, a.ctxCancelF, httpreq, func(resp *http.Response, err error) error {
+			err := zboxutil.HttpDoBlobber(ctx, cancel, httpreq, func(resp *http.Response, err error) error {
 				if err != nil {
 					l.Logger.Error("Insert share info : ", err)
 					return err
@@ -1218,16 +1577,21 @@ func (a *Allocation) UploadAuthTicketToBlobber(authTicket string, clientEncPubKe
 }
 
 func (a *Allocation) CancelUpload(localpath string) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
 	if uploadReq, ok := a.uploadProgressMap[localpath]; ok {
-		uploadReq.isUploadCanceled = true
+		atomic.StoreInt32(&uploadReq.isUploadCanceled, 1)
+		uploadReq.ctxCncl()
 		return nil
 	}
 	return errors.New("local_path_not_found", "Invalid path. No upload in progress for the path "+localpath)
 }
 
 func (a *Allocation) CancelDownload(remotepath string) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
 	if downloadReq, ok := a.downloadProgressMap[remotepath]; ok {
-		downloadReq.isDownloadCanceled = true
+		atomic.StoreInt32(&downloadReq.isDownloadCanceled, 1)
 		downloadReq.ctxCncl()
 		return nil
 	}
@@ -1237,16 +1601,32 @@ func (a *Allocation) CancelDownload(remotepath string) error {
 func (a *Allocation) DownloadThumbnailFromAuthTicket(localPath string,
 	authTicket string, remoteLookupHash string, remoteFilename string,
 	status StatusCallback) error {
+	return a.DownloadThumbnailFromAuthTicketCtx(context.Background(), localPath, authTicket, remoteLookupHash, remoteFilename, status)
+}
 
-	return a.downloadFromAuthTicket(localPath, authTicket, remoteLookupHash,
+// DownloadThumbnailFromAuthTicketCtx downloads the thumbnail of the shared
+// file of remoteLookupHash to localPath in the background, like
+// DownloadFileCtx.
+func (a *Allocation) DownloadThumbnailFromAuthTicketCtx(ctx context.Context, localPath string,
+	authTicket string, remoteLookupHash string, remoteFilename string,
+	status StatusCallback) error {
+
+	return a.downloadFromAuthTicketCtx(ctx, localPath, authTicket, remoteLookupHash,
 		1, 0, numBlockDownloads, remoteFilename, DOWNLOAD_CONTENT_THUMB,
 		status)
 }
 
 func (a *Allocation) DownloadFromAuthTicket(localPath string, authTicket string,
 	remoteLookupHash string, remoteFilename string, status StatusCallback) error {
+	return a.DownloadFromAuthTicketCtx(context.Background(), localPath, authTicket, remoteLookupHash, remoteFilename, status)
+}
+
+// DownloadFromAuthTicketCtx downloads the shared file of remoteLookupHash to
+// localPath in the background, like DownloadFileCtx.
+func (a *Allocation) DownloadFromAuthTicketCtx(ctx context.Context, localPath string, authTicket string,
+	remoteLookupHash string, remoteFilename string, status StatusCallback) error {
 
-	return a.downloadFromAuthTicket(localPath, authTicket, remoteLookupHash,
+	return a.downloadFromAuthTicketCtx(ctx, localPath, authTicket, remoteLookupHash,
 		1, 0, numBlockDownloads, remoteFilename, DOWNLOAD_CONTENT_FULL,
 		status)
 }
@@ -1255,8 +1635,18 @@ func (a *Allocation) DownloadFromAuthTicketByBlocks(localPath string,
 	authTicket string, startBlock int64, endBlock int64, numBlocks int,
 	remoteLookupHash string, remoteFilename string,
 	status StatusCallback) error {
+	return a.DownloadFromAuthTicketByBlocksCtx(context.Background(), localPath, authTicket, startBlock, endBlock, numBlocks, remoteLookupHash, remoteFilename, status)
+}
 
-	return a.downloadFromAuthTicket(localPath, authTicket, remoteLookupHash,
+// DownloadFromAuthTicketByBlocksCtx downloads the blocks from startBlock to
+// endBlock of the shared file of remoteLookupHash to localPath in the
+// background, like DownloadFileCtx.
+func (a *Allocation) DownloadFromAuthTicketByBlocksCtx(ctx context.Context, localPath string,
+	authTicket string, startBlock int64, endBlock int64, numBlocks int,
+	remoteLookupHash string, remoteFilename string,
+	status StatusCallback) error {
+
+	return a.downloadFromAuthTicketCtx(ctx, localPath, authTicket, remoteLookupHash,
 		startBlock, endBlock, numBlocks, remoteFilename, DOWNLOAD_CONTENT_FULL,
 		status)
 }
@@ -1265,18 +1655,26 @@ func (a *Allocation) downloadFromAuthTicket(localPath string, authTicket string,
 	remoteLookupHash string, startBlock int64, endBlock int64, numBlocks int,
 	remoteFilename string, contentMode string,
 	status StatusCallback) error {
+	return a.downloadFromAuthTicketCtx(context.Background(), localPath, authTicket, remoteLookupHash, startBlock, endBlock, numBlocks, remoteFilename, contentMode, status)
+}
+
+// downloadFromAuthTicketCtx is downloadFromAuthTicket with the context of the caller.
+func (a *Allocation) downloadFromAuthTicketCtx(ctx context.Context, localPath string, authTicket string,
+	remoteLookupHash string, startBlock int64, endBlock int64, numBlocks int,
+	remoteFilename string, contentMode string,
+	status StatusCallback) error {
 
 	if !a.isInitialized() {
 		return notInitialized
 	}
 	sEnc, err := base64.StdEncoding.DecodeString(authTicket)
 	if err != nil {
-		return errors.New("auth_ticket_decode_error", "Error decoding the auth ticket."+err.Error())
+		return errors.Throw(constants.ErrInvalidAuthTicket, "Error decoding the auth ticket."+err.Error())
 	}
 	at := &marker.AuthTicket{}
 	err = json.Unmarshal(sEnc, at)
 	if err != nil {
-		return errors.New("auth_ticket_decode_error", "Error unmarshaling the auth ticket."+err.Error())
+		return errors.Throw(constants.ErrInvalidAuthTicket, "Error unmarshaling the auth ticket."+err.Error())
 	}
 
 	if stat, err := sys.Files.Stat(localPath); err == nil {
@@ -1299,13 +1697,14 @@ func (a *Allocation) downloadFromAuthTicket(localPath string, authTicket string,
 	downloadReq.allocationID = a.ID
 	downloadReq.allocationTx = a.Tx
 	downloadReq.allocOwnerID = a.Owner
-	downloadReq.ctx, downloadReq.ctxCncl = context.WithCancel(a.ctx)
+	downloadReq.ctx, downloadReq.ctxCncl = a.opContext(ctx)
 	downloadReq.localpath = localPath
 	downloadReq.remotefilepathhash = remoteLookupHash
 	downloadReq.authTicket = at
 	downloadReq.statusCallback = status
 	downloadReq.downloadMask = zboxutil.NewUint128(1).Lsh(uint64(len(a.Blobbers))).Sub64(1)
 	downloadReq.blobbers = a.Blobbers
+	downloadReq.blobberOrder = a.ranking.order(a.Blobbers)
 	downloadReq.datashards = a.DataShards
 	downloadReq.parityshards = a.ParityShards
 	downloadReq.contentMode = contentMode
@@ -1314,7 +1713,9 @@ func (a *Allocation) downloadFromAuthTicket(localPath string, authTicket string,
 	downloadReq.numBlocks = int64(numBlocks)
 	downloadReq.fullconsensus = a.fullconsensus
 	downloadReq.consensusThresh = a.consensusThreshold
+	downloadReq.verifyDownloads = a.isVerifyingDownloads()
 	downloadReq.completedCallback = func(remotepath string, remotepathHash string) {
+		downloadReq.ctxCncl()
 		a.mutex.Lock()
 		defer a.mutex.Unlock()
 		delete(a.downloadProgressMap, remotepathHash)
@@ -1350,6 +1751,11 @@ func (a *Allocation) StartRepair(localRootPath, pathToRepair string, statusCB St
 		a.repairRequestInProgress = nil
 	}
 
+	repairReq.opDone, err = beginOp()
+	if err != nil {
+		return err
+	}
+	repairReq.ctx, repairReq.ctxCncl = a.opContext(nil)
 	go func() {
 		a.repairChan <- repairReq
 		a.mutex.Lock()
@@ -1360,14 +1766,24 @@ func (a *Allocation) StartRepair(localRootPath, pathToRepair string, statusCB St
 }
 
 func (a *Allocation) CancelRepair() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
 	if a.repairRequestInProgress != nil {
-		a.repairRequestInProgress.isRepairCanceled = true
+		atomic.StoreInt32(&a.repairRequestInProgress.isRepairCanceled, 1)
+		a.repairRequestInProgress.ctxCncl()
 		return nil
 	}
 	return errors.New("invalid_cancel_repair_request", "No repair in progress for the allocation")
 }
 
 func (a *Allocation) AddCollaborator(filePath, collaboratorID string) error {
+	return a.AddCollaboratorCtx(context.Background(), filePath, collaboratorID)
+}
+
+// AddCollaboratorCtx adds collaboratorID to the file at filePath.
+func (a *Allocation) AddCollaboratorCtx(ctx context.Context, filePath, collaboratorID string) error {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if !a.isInitialized() {
 		return notInitialized
 	}
@@ -1376,6 +1792,7 @@ func (a *Allocation) AddCollaborator(filePath, collaboratorID string) error {
 		path:           filePath,
 		collaboratorID: collaboratorID,
 		a:              a,
+		ctx:            ctx,
 		consensus: Consensus{
 			fullconsensus:   a.fullconsensus,
 			consensusThresh: a.consensusThreshold,
@@ -1389,6 +1806,13 @@ func (a *Allocation) AddCollaborator(filePath, collaboratorID string) error {
 }
 
 func (a *Allocation) RemoveCollaborator(filePath, collaboratorID string) error {
+	return a.RemoveCollaboratorCtx(context.Background(), filePath, collaboratorID)
+}
+
+// RemoveCollaboratorCtx removes collaboratorID from the file at filePath.
+func (a *Allocation) RemoveCollaboratorCtx(ctx context.Context, filePath, collaboratorID string) error {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
 	if !a.isInitialized() {
 		return notInitialized
 	}
@@ -1397,6 +1821,7 @@ func (a *Allocation) RemoveCollaborator(filePath, collaboratorID string) error {
 		path:           filePath,
 		collaboratorID: collaboratorID,
 		a:              a,
+		ctx:            ctx,
 		consensus: Consensus{
 			fullconsensus:   a.fullconsensus,
 			consensusThresh: a.consensusThreshold,
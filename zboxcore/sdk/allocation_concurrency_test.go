@@ -0,0 +1,101 @@
+package sdk
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/dev/sdktest"
+	zclient "github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAllocation_ConcurrentOperations runs mixed operations on one Allocation
+// in parallel, run it with -race.
+func TestAllocation_ConcurrentOperations(t *testing.T) {
+	prev := zboxutil.Client
+	zboxutil.Client = &http.Client{}
+	defer func() { zboxutil.Client = prev }()
+
+	zclient.GetClient().Wallet = &zcncrypto.Wallet{
+		ClientID:  mockClientId,
+		ClientKey: mockClientKey,
+	}
+
+	a := &Allocation{
+		ID:           "TestAllocation_ConcurrentOperations",
+		Tx:           "TestAllocation_ConcurrentOperations",
+		DataShards:   2,
+		ParityShards: 1,
+		Size:         2 * GB,
+	}
+	setupMockAllocation(t, a)
+	for i := 0; i < a.DataShards+a.ParityShards; i++ {
+		b := sdktest.NewBlobber(mockBlobberId + strconv.Itoa(i))
+		defer b.Close()
+		a.Blobbers = append(a.Blobbers, b.StorageNode())
+	}
+
+	upload := func(name string) error {
+		content := []byte(name)
+		fileMeta := FileMeta{
+			Path:       "/tmp/" + name,
+			ActualSize: int64(len(content)),
+			MimeType:   "text/plain",
+			RemoteName: name,
+			RemotePath: "/docs/" + name,
+		}
+		chunkedUpload, err := CreateChunkedUpload(t.TempDir(), a, fileMeta, bytes.NewReader(content), false, false)
+		if err != nil {
+			return err
+		}
+		chunkedUpload.progressStorer = &nopeChunkedUploadProgressStorer{}
+		return chunkedUpload.Start()
+	}
+	require.NoError(t, upload("seed.txt"))
+
+	const files = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*files)
+	for i := 0; i < files; i++ {
+		wg.Add(4)
+		go func(i int) {
+			defer wg.Done()
+			errs <- upload(fmt.Sprintf("file%d.txt", i))
+		}(i)
+		go func() {
+			defer wg.Done()
+			if _, err := a.ListDir("/docs"); err != nil {
+				errs <- err
+				return
+			}
+			_, err := a.GetFileMeta("/docs/seed.txt")
+			errs <- err
+		}()
+		go func(i int) {
+			defer wg.Done()
+			_ = a.CancelUpload(fmt.Sprintf("/tmp/file%d.txt", i))
+			_ = a.CancelDownload(fmt.Sprintf("/docs/file%d.txt", i))
+			_ = a.CancelRepair()
+		}(i)
+		go func() {
+			defer wg.Done()
+			a.SetTimeouts(a.GetTimeouts())
+			a.VerifyDownloads(!a.isVerifyingDownloads())
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	list, err := a.ListDir("/docs")
+	require.NoError(t, err)
+	require.Len(t, list.Children, files+1)
+}
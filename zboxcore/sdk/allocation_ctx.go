@@ -0,0 +1,26 @@
+package sdk
+
+import "context"
+
+// opContext returns the context of an operation called with ctx. It carries
+// the timeouts of the allocation and is done when ctx or the allocation
+// context is, cancelling the requests to the blobbers in flight; the values
+// of ctx aren't carried. cancel must be called once the operation is over.
+func (a *Allocation) opContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	parent := a.ctx
+	if parent == nil {
+		parent = withAllocationTimeouts(context.Background(), a)
+	}
+	opCtx, cancel := context.WithCancel(parent)
+	if ctx == nil || ctx.Done() == nil {
+		return opCtx, cancel
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancel()
+		case <-opCtx.Done():
+		}
+	}()
+	return opCtx, cancel
+}
@@ -0,0 +1,68 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocation_opContext(t *testing.T) {
+	t.Run("Test_Cancelled_With_Caller", func(t *testing.T) {
+		a := &Allocation{}
+		a.ctx, a.ctxCancelF = context.WithCancel(withAllocationTimeouts(context.Background(), a))
+		defer a.ctxCancelF()
+		a.SetTimeouts(Timeouts{Meta: time.Minute})
+
+		caller, cancelCaller := context.WithCancel(context.Background())
+		ctx, cancel := a.opContext(caller)
+		defer cancel()
+		require.Equal(t, time.Minute, timeoutsOf(ctx).Meta)
+		require.NoError(t, ctx.Err())
+
+		cancelCaller()
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			require.Fail(t, "operation context not cancelled with the caller")
+		}
+		require.NoError(t, a.ctx.Err(), "the allocation context is left alone")
+	})
+
+	t.Run("Test_Cancelled_With_Allocation", func(t *testing.T) {
+		a := &Allocation{}
+		a.ctx, a.ctxCancelF = context.WithCancel(context.Background())
+
+		ctx, cancel := a.opContext(context.Background())
+		defer cancel()
+		a.ctxCancelF()
+		<-ctx.Done()
+	})
+
+	t.Run("Test_Uninitialized_Allocation", func(t *testing.T) {
+		a := &Allocation{}
+		a.SetTimeouts(Timeouts{List: time.Minute})
+
+		ctx, cancel := a.opContext(context.Background())
+		require.Equal(t, time.Minute, timeoutsOf(ctx).List)
+		cancel()
+		require.Error(t, ctx.Err())
+	})
+
+	t.Run("Test_Cancelled_Caller_Fails_Fast", func(t *testing.T) {
+		a := &Allocation{}
+		a.ctx, a.ctxCancelF = context.WithCancel(context.Background())
+		defer a.ctxCancelF()
+
+		caller, cancelCaller := context.WithCancel(context.Background())
+		cancelCaller()
+		ctx, cancel := a.opContext(caller)
+		defer cancel()
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			require.Fail(t, "operation context not cancelled")
+		}
+	})
+}
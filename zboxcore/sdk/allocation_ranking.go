@@ -0,0 +1,146 @@
+package sdk
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+)
+
+// rankingDecay is the weight of the newest probe in the moving averages
+const rankingDecay = 0.3
+
+// BlobberRank is the probed latency and error rate of an allocation blobber.
+type BlobberRank struct {
+	BlobberID string        `json:"blobber_id"`
+	Baseurl   string        `json:"url"`
+	RTT       time.Duration `json:"rtt"`
+	ErrorRate float64       `json:"error_rate"`
+	Samples   int           `json:"samples"`
+}
+
+// score is the moving average of the latency, a failed probe counting as a
+// probe that timed out.
+func (r *BlobberRank) score() time.Duration {
+	return r.RTT + time.Duration(r.ErrorRate*float64(blobberProbeTimeout))
+}
+
+type blobberRanking struct {
+	mu    sync.RWMutex
+	ranks map[string]*BlobberRank
+}
+
+func (br *blobberRanking) record(blobber *blockchain.StorageNode, rtt time.Duration, err error) {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	if br.ranks == nil {
+		br.ranks = make(map[string]*BlobberRank)
+	}
+	r, ok := br.ranks[blobber.ID]
+	if !ok {
+		r = &BlobberRank{BlobberID: blobber.ID, Baseurl: blobber.Baseurl}
+		br.ranks[blobber.ID] = r
+	}
+
+	failed := 0.0
+	if err != nil {
+		failed = 1
+	}
+	if r.Samples == 0 {
+		r.ErrorRate = failed
+		if err == nil {
+			r.RTT = rtt
+		}
+	} else {
+		r.ErrorRate = rankingDecay*failed + (1-rankingDecay)*r.ErrorRate
+		if err == nil {
+			r.RTT = time.Duration(rankingDecay*float64(rtt) + (1-rankingDecay)*float64(r.RTT))
+		}
+	}
+	r.Samples++
+}
+
+// order returns the positions of blobbers sorted fastest first, nil if none
+// was probed yet. Blobbers without probes go last.
+func (br *blobberRanking) order(blobbers []*blockchain.StorageNode) []int {
+	if br == nil {
+		return nil
+	}
+	br.mu.RLock()
+	defer br.mu.RUnlock()
+	if len(br.ranks) == 0 {
+		return nil
+	}
+
+	positions := make([]int, len(blobbers))
+	for i := range positions {
+		positions[i] = i
+	}
+	sort.SliceStable(positions, func(i, j int) bool {
+		ri, iok := br.ranks[blobbers[positions[i]].ID]
+		rj, jok := br.ranks[blobbers[positions[j]].ID]
+		if iok != jok {
+			return iok
+		}
+		return iok && ri.score() < rj.score()
+	})
+	return positions
+}
+
+// ProbeBlobbers measures the round trip time of every blobber of the
+// allocation once and updates its ranking.
+func (a *Allocation) ProbeBlobbers(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, blobber := range a.Blobbers {
+		wg.Add(1)
+		go func(blobber *blockchain.StorageNode) {
+			defer wg.Done()
+			rtt, err := probeBlobber(ctx, blobber.Baseurl)
+			if a.ranking != nil {
+				a.ranking.record(blobber, rtt, err)
+			}
+		}(blobber)
+	}
+	wg.Wait()
+}
+
+// StartBlobberProber probes the allocation blobbers every interval in the
+// background until ctx is done. Downloads then request blocks from the
+// fastest blobbers needed for reconstruction first. Listings still ask every
+// blobber since sizes are summed over all of them.
+func (a *Allocation) StartBlobberProber(ctx context.Context, interval time.Duration) {
+	stop := stopping()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			a.ProbeBlobbers(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// GetBlobberRanking returns the probed blobbers of the allocation, fastest first.
+func (a *Allocation) GetBlobberRanking() []BlobberRank {
+	if a.ranking == nil {
+		return nil
+	}
+	order := a.ranking.order(a.Blobbers)
+	a.ranking.mu.RLock()
+	defer a.ranking.mu.RUnlock()
+	ranks := make([]BlobberRank, 0, len(order))
+	for _, pos := range order {
+		if r, ok := a.ranking.ranks[a.Blobbers[pos].ID]; ok {
+			ranks = append(ranks, *r)
+		}
+	}
+	return ranks
+}
@@ -0,0 +1,44 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobberRanking(t *testing.T) {
+	blobbers := []*blockchain.StorageNode{
+		{ID: "slow", Baseurl: "http://slow"},
+		{ID: "unprobed", Baseurl: "http://unprobed"},
+		{ID: "fast", Baseurl: "http://fast"},
+		{ID: "flaky", Baseurl: "http://flaky"},
+	}
+	br := &blobberRanking{}
+	require.Nil(t, br.order(blobbers))
+
+	br.record(blobbers[0], 400*time.Millisecond, nil)
+	br.record(blobbers[2], 20*time.Millisecond, nil)
+	br.record(blobbers[3], 10*time.Millisecond, nil)
+	br.record(blobbers[3], 0, errors.New("timeout"))
+
+	order := br.order(blobbers)
+	require.Equal(t, []int{2, 0, 3, 1}, order)
+
+	a := &Allocation{Blobbers: blobbers, ranking: br}
+	ranks := a.GetBlobberRanking()
+	require.Len(t, ranks, 3)
+	require.Equal(t, "fast", ranks[0].BlobberID)
+	require.Equal(t, 2, ranks[2].Samples)
+	require.InDelta(t, rankingDecay, ranks[2].ErrorRate, 1e-9)
+
+	req := &DownloadRequest{blobberOrder: order}
+	mask := zboxutil.NewUint128(1).Lsh(4).Sub64(1).And(zboxutil.NewUint128(1).Lsh(0).Not())
+	require.Equal(t, []uint64{2, 3, 1}, req.orderedPositions(mask))
+
+	req.blobberOrder = nil
+	require.Equal(t, []uint64{1, 2, 3}, req.orderedPositions(mask))
+}
@@ -0,0 +1,175 @@
+package sdk
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+const defaultSearchPageLimit = 100
+
+// SearchQuery describes a recursive search below Root. Zero values are
+// ignored, so an empty query matches every file and directory.
+type SearchQuery struct {
+	// Root is the directory to search under. Defaults to "/".
+	Root string
+	// Name is matched as a glob (path.Match syntax) when it contains any of
+	// "*?[", otherwise as a case-insensitive substring of the file name.
+	Name string
+	// Type restricts results to fileref.FILE or fileref.DIRECTORY.
+	Type string
+	// MimeType matches exactly, or as a prefix when it ends with "/"
+	// (e.g. "image/").
+	MimeType string
+	// MinSize and MaxSize bound the actual file size in bytes. MaxSize 0
+	// means unbounded.
+	MinSize int64
+	MaxSize int64
+	// ModifiedAfter and ModifiedBefore bound the update time.
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+	// CustomMeta matches files carrying all of the given key/value pairs
+	// (see WithCustomMeta).
+	CustomMeta map[string]string
+	// Hash matches the hash of the content of files exactly.
+	Hash string
+	// PageLimit is the number of refs requested from blobbers per round
+	// trip. Defaults to 100.
+	PageLimit int
+}
+
+// SearchResult is a single match of Allocation.Search.
+type SearchResult struct {
+	Name       string            `json:"name"`
+	Path       string            `json:"path"`
+	Type       string            `json:"type"`
+	LookupHash string            `json:"lookup_hash"`
+	Hash       string            `json:"hash,omitempty"`
+	MimeType   string            `json:"mimetype,omitempty"`
+	Size       int64             `json:"size"`
+	CreatedAt  common.Timestamp  `json:"created_at"`
+	UpdatedAt  common.Timestamp  `json:"updated_at"`
+	CustomMeta map[string]string `json:"custom_meta,omitempty"`
+	// Encrypted is set for encrypted files, if the blobbers tell
+	Encrypted bool `json:"encrypted,omitempty"`
+}
+
+func (q *SearchQuery) validate() error {
+	if q.Name != "" && strings.ContainsAny(q.Name, "*?[") {
+		if _, err := path.Match(q.Name, ""); err != nil {
+			return errors.New("invalid_query", "bad name pattern: "+err.Error())
+		}
+	}
+	if q.MaxSize > 0 && q.MinSize > q.MaxSize {
+		return errors.New("invalid_query", "min size is greater than max size")
+	}
+	if !q.ModifiedAfter.IsZero() && !q.ModifiedBefore.IsZero() && q.ModifiedAfter.After(q.ModifiedBefore) {
+		return errors.New("invalid_query", "modified-after is later than modified-before")
+	}
+	return nil
+}
+
+func (q *SearchQuery) match(ref *ORef) bool {
+	if q.Type != "" && ref.Type != q.Type {
+		return false
+	}
+	if q.Name != "" {
+		if strings.ContainsAny(q.Name, "*?[") {
+			if ok, _ := path.Match(q.Name, ref.Name); !ok {
+				return false
+			}
+		} else if !strings.Contains(strings.ToLower(ref.Name), strings.ToLower(q.Name)) {
+			return false
+		}
+	}
+	if q.MimeType != "" {
+		if strings.HasSuffix(q.MimeType, "/") {
+			if !strings.HasPrefix(ref.MimeType, q.MimeType) {
+				return false
+			}
+		} else if ref.MimeType != q.MimeType {
+			return false
+		}
+	}
+	if q.Hash != "" && ref.ActualFileHash != q.Hash {
+		return false
+	}
+	if ref.ActualFileSize < q.MinSize {
+		return false
+	}
+	if q.MaxSize > 0 && ref.ActualFileSize > q.MaxSize {
+		return false
+	}
+	updated := time.Unix(int64(ref.UpdatedAt), 0)
+	if !q.ModifiedAfter.IsZero() && updated.Before(q.ModifiedAfter) {
+		return false
+	}
+	if !q.ModifiedBefore.IsZero() && updated.After(q.ModifiedBefore) {
+		return false
+	}
+	if len(q.CustomMeta) > 0 && !matchCustomMeta(q.CustomMeta, decodeCustomMeta(ref.CustomMeta)) {
+		return false
+	}
+	return true
+}
+
+// Search walks the object tree below query.Root and returns every ref that
+// matches the query. Each page is fetched from all blobbers in parallel and
+// only refs agreed on by the consensus threshold are considered.
+func (a *Allocation) Search(query SearchQuery) ([]*SearchResult, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	if err := query.validate(); err != nil {
+		return nil, err
+	}
+	root := query.Root
+	if root == "" {
+		root = "/"
+	}
+	root = zboxutil.RemoteClean(root)
+	if !zboxutil.IsRemoteAbs(root) {
+		return nil, errors.New("invalid_path", "Path should be valid and absolute")
+	}
+	pageLimit := query.PageLimit
+	if pageLimit <= 0 {
+		pageLimit = defaultSearchPageLimit
+	}
+
+	var results []*SearchResult
+	offsetPath := ""
+	for {
+		oResult, err := a.GetRefs(root, offsetPath, "", "", query.Type, "regular", 0, pageLimit)
+		if err != nil {
+			return nil, err
+		}
+		for i := range oResult.Refs {
+			ref := &oResult.Refs[i]
+			if ref.Path == root || !query.match(ref) {
+				continue
+			}
+			results = append(results, &SearchResult{
+				Name:       ref.Name,
+				Path:       ref.Path,
+				Type:       ref.Type,
+				LookupHash: ref.LookupHash,
+				Hash:       ref.ActualFileHash,
+				MimeType:   ref.MimeType,
+				Size:       ref.ActualFileSize,
+				CreatedAt:  ref.CreatedAt,
+				UpdatedAt:  ref.UpdatedAt,
+				CustomMeta: decodeCustomMeta(ref.CustomMeta),
+				Encrypted:  ref.EncryptedKey != "",
+			})
+		}
+		if len(oResult.Refs) < pageLimit || oResult.OffsetPath == "" || oResult.OffsetPath == offsetPath {
+			break
+		}
+		offsetPath = oResult.OffsetPath
+	}
+	return results, nil
+}
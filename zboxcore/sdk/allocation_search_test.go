@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchQuery_match(t *testing.T) {
+	now := time.Now()
+	ref := &ORef{SimilarField: SimilarField{
+		Type:           fileref.FILE,
+		Name:           "Holiday-2022.JPG",
+		Path:           "/photos/Holiday-2022.JPG",
+		MimeType:       "image/jpeg",
+		ActualFileSize: 2 * MB,
+		UpdatedAt:      common.Timestamp(now.Unix()),
+		CustomMeta:     `{"album":"summer","camera":"x100"}`,
+		ActualFileHash: "abcd",
+	}}
+
+	tests := []struct {
+		name  string
+		query SearchQuery
+		want  bool
+	}{
+		{name: "Test_Empty_Query", query: SearchQuery{}, want: true},
+		{name: "Test_Substring_Case_Insensitive", query: SearchQuery{Name: "holiday"}, want: true},
+		{name: "Test_Substring_Miss", query: SearchQuery{Name: "birthday"}, want: false},
+		{name: "Test_Glob", query: SearchQuery{Name: "*.JPG"}, want: true},
+		{name: "Test_Glob_Miss", query: SearchQuery{Name: "*.png"}, want: false},
+		{name: "Test_Type_Miss", query: SearchQuery{Type: fileref.DIRECTORY}, want: false},
+		{name: "Test_Mime_Prefix", query: SearchQuery{MimeType: "image/"}, want: true},
+		{name: "Test_Mime_Exact_Miss", query: SearchQuery{MimeType: "image/png"}, want: false},
+		{name: "Test_Size_Range", query: SearchQuery{MinSize: MB, MaxSize: 3 * MB}, want: true},
+		{name: "Test_Size_Too_Small", query: SearchQuery{MinSize: 3 * MB}, want: false},
+		{name: "Test_Size_Too_Large", query: SearchQuery{MaxSize: MB}, want: false},
+		{name: "Test_Modified_After", query: SearchQuery{ModifiedAfter: now.Add(-time.Hour)}, want: true},
+		{name: "Test_Modified_Before_Miss", query: SearchQuery{ModifiedBefore: now.Add(-time.Hour)}, want: false},
+		{name: "Test_Custom_Meta", query: SearchQuery{CustomMeta: map[string]string{"album": "summer"}}, want: true},
+		{name: "Test_Custom_Meta_Miss", query: SearchQuery{CustomMeta: map[string]string{"album": "winter"}}, want: false},
+		{name: "Test_Hash", query: SearchQuery{Hash: "abcd"}, want: true},
+		{name: "Test_Hash_Miss", query: SearchQuery{Hash: "abce"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.query.validate())
+			require.Equal(t, tt.want, tt.query.match(ref))
+		})
+	}
+}
+
+func TestSearchQuery_validate(t *testing.T) {
+	require.Error(t, (&SearchQuery{Name: "[a-"}).validate())
+	require.Error(t, (&SearchQuery{MinSize: 10, MaxSize: 5}).validate())
+	now := time.Now()
+	require.Error(t, (&SearchQuery{ModifiedAfter: now, ModifiedBefore: now.Add(-time.Second)}).validate())
+}
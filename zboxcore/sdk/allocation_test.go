@@ -14,6 +14,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/0chain/gosdk/dev/blobber"
 	"github.com/0chain/gosdk/dev/blobber/model"
@@ -304,6 +305,7 @@ func TestDoNotThrowErrorWhenBlobbersRequiredLessThanLimit(t *testing.T) {
 	allocation.DataShards = 5
 	allocation.ParityShards = 4
 	allocation.fullconsensus, allocation.consensusThreshold = allocation.getConsensuses()
+	setupMockAllocation(t, allocation)
 
 	err := allocation.uploadOrUpdateFile("", "/", nil, false, "", false, false)
 
@@ -391,11 +393,15 @@ func TestAllocation_dispatchWork(t *testing.T) {
 		cancelFn()
 	})
 	t.Run("Test_Cover_Upload_Request", func(t *testing.T) {
+		ctx, ctxCncl := context.WithCancel(context.Background())
 		go a.dispatchWork(context.Background())
 		a.uploadChan <- &UploadRequest{
 			file:      []*fileref.FileRef{},
 			filemeta:  &UploadFileMeta{},
-			Consensus: Consensus{}}
+			Consensus: Consensus{},
+			ctx:       ctx,
+			ctxCncl:   ctxCncl,
+			opDone:    func() {}}
 	})
 	t.Run("Test_Cover_Download_Request", func(t *testing.T) {
 		ctx, ctxCncl := context.WithCancel(context.Background())
@@ -403,8 +409,9 @@ func TestAllocation_dispatchWork(t *testing.T) {
 		a.downloadChan <- &DownloadRequest{ctx: ctx, ctxCncl: ctxCncl}
 	})
 	t.Run("Test_Cover_Repair_Request", func(t *testing.T) {
+		ctx, ctxCncl := context.WithCancel(context.Background())
 		go a.dispatchWork(context.Background())
-		a.repairChan <- &RepairRequest{listDir: &ListResult{}}
+		a.repairChan <- &RepairRequest{listDir: &ListResult{}, ctx: ctx, ctxCncl: ctxCncl, opDone: func() {}}
 	})
 }
 
@@ -481,6 +488,41 @@ func TestAllocation_GetBlobberStats(t *testing.T) {
 	}
 }
 
+func TestAllocation_GetBlobberStats_unreachable(t *testing.T) {
+	stats, err := json.Marshal(&BlobberAllocationStats{ID: mockAllocationId, Tx: mockAllocationTxId})
+	require.NoError(t, err)
+
+	var mockClient = mocks.HttpClient{}
+	mockClient.On("Do", mock.Anything).Return(func(req *http.Request) *http.Response {
+		switch req.URL.Host {
+		case "b0":
+			return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(stats))}
+		case "b1":
+			return &http.Response{StatusCode: http.StatusInternalServerError, Body: ioutil.NopCloser(strings.NewReader(""))}
+		}
+		// b2 never responds
+		<-req.Context().Done()
+		return nil
+	}, func(req *http.Request) error {
+		return req.Context().Err()
+	})
+	zboxutil.Client = &mockClient
+
+	a := &Allocation{ID: mockAllocationId, Tx: mockAllocationTxId}
+	for i := 0; i < 3; i++ {
+		a.Blobbers = append(a.Blobbers, &blockchain.StorageNode{
+			ID:      "b" + strconv.Itoa(i),
+			Baseurl: "http://b" + strconv.Itoa(i),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	got := a.GetBlobberStatsCtx(ctx)
+	require.Len(t, got, 1)
+	require.Equal(t, "b0", got["http://b0"].BlobberID)
+}
+
 func TestAllocation_isInitialized(t *testing.T) {
 	tests := []struct {
 		name                                        string
@@ -1526,8 +1568,11 @@ func TestAllocation_CancelUpload(t *testing.T) {
 				localpath: localPath,
 			},
 			setup: func(t *testing.T, a *Allocation) (teardown func(t *testing.T)) {
-				a.uploadProgressMap[localPath] = &UploadRequest{}
-				return nil
+				ctx, ctxCncl := context.WithCancel(context.Background())
+				a.uploadProgressMap[localPath] = &UploadRequest{ctx: ctx, ctxCncl: ctxCncl}
+				return func(t *testing.T) {
+					require.Error(t, ctx.Err(), "the requests of the upload are cancelled")
+				}
 			},
 		},
 	}
@@ -2395,8 +2440,11 @@ func TestAllocation_CancelRepair(t *testing.T) {
 		{
 			name: "Test_Success",
 			setup: func(t *testing.T, a *Allocation) (teardown func(t *testing.T)) {
-				a.repairRequestInProgress = &RepairRequest{}
-				return nil
+				ctx, ctxCncl := context.WithCancel(context.Background())
+				a.repairRequestInProgress = &RepairRequest{ctx: ctx, ctxCncl: ctxCncl}
+				return func(t *testing.T) {
+					require.Error(t, ctx.Err(), "the requests of the repair are cancelled")
+				}
 			},
 		},
 	}
@@ -2450,6 +2498,9 @@ func setupMockAllocation(t *testing.T, a *Allocation) {
 				if uploadReq.wg != nil {
 					uploadReq.wg.Done()
 				}
+				if uploadReq.opDone != nil {
+					uploadReq.opDone()
+				}
 				t.Logf("received a upload request for %v %v\n", uploadReq.filepath, uploadReq.remotefilepath)
 			case downloadReq := <-a.downloadChan:
 				if downloadReq.completedCallback != nil {
@@ -2466,6 +2517,9 @@ func setupMockAllocation(t *testing.T, a *Allocation) {
 				if repairReq.wg != nil {
 					repairReq.wg.Done()
 				}
+				if repairReq.opDone != nil {
+					repairReq.opDone()
+				}
 				t.Logf("received a repair request for %v\n", repairReq.listDir.Path)
 			}
 		}
@@ -0,0 +1,125 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	l "github.com/0chain/gosdk/zboxcore/logger"
+)
+
+// ErrAllocationChanged is matched by errors.Is for an *AllocationChangedError.
+var ErrAllocationChanged = errors.New("allocation changed")
+
+// RepairCheckpoint is how far a repair got before it stopped. Files are
+// repaired one at a time, so every file up to LastPath is consistent. Repair
+// skips files that don't need it, restarting it on the reloaded allocation
+// resumes the work.
+type RepairCheckpoint struct {
+	RootPath      string `json:"root_path"`
+	LastPath      string `json:"last_path,omitempty"`
+	FilesRepaired int    `json:"files_repaired"`
+}
+
+// AllocationChangedError stops a repair, or a sync run checking CheckTopology,
+// when the allocation was updated on chain under it: a blobber was added or
+// replaced, or the allocation was finalized or canceled.
+type AllocationChangedError struct {
+	AllocationID string `json:"allocation_id"`
+	Reason       string `json:"reason"`
+	// Checkpoint of the repair, nil outside of a repair
+	Checkpoint *RepairCheckpoint `json:"checkpoint,omitempty"`
+}
+
+func (e *AllocationChangedError) Error() string {
+	return fmt.Sprintf("allocation %s changed: %s", e.AllocationID, e.Reason)
+}
+
+// Is makes errors.Is(err, ErrAllocationChanged) true.
+func (e *AllocationChangedError) Is(target error) bool {
+	return target == ErrAllocationChanged
+}
+
+// topologyChange describes how current differs from the allocation a was
+// loaded as, "" if its blobbers and state are the same.
+func topologyChange(a, current *Allocation) string {
+	if current.Finalized && !a.Finalized {
+		return "finalized"
+	}
+	if current.Canceled && !a.Canceled {
+		return "canceled"
+	}
+
+	known := make(map[string]bool, len(a.Blobbers))
+	for _, b := range a.Blobbers {
+		known[b.ID] = true
+	}
+	var added []string
+	for _, b := range current.Blobbers {
+		if !known[b.ID] {
+			added = append(added, b.ID)
+		}
+		delete(known, b.ID)
+	}
+	removed := make([]string, 0, len(known))
+	for id := range known {
+		removed = append(removed, id)
+	}
+	sort.Strings(removed)
+
+	var reasons []string
+	if len(added) > 0 {
+		reasons = append(reasons, "blobbers added: "+strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		reasons = append(reasons, "blobbers removed: "+strings.Join(removed, ", "))
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// CheckTopology reloads the allocation from the chain and returns an
+// *AllocationChangedError if its blobbers or state differ from a. Long
+// running operations, e.g. a sync applying a diff, call it between steps.
+func (a *Allocation) CheckTopology() error {
+	if !a.isInitialized() {
+		return notInitialized
+	}
+	current := &Allocation{ID: a.ID}
+	if err := GetAllocationUpdates(current); err != nil {
+		return err
+	}
+	if reason := topologyChange(a, current); reason != "" {
+		return &AllocationChangedError{AllocationID: a.ID, Reason: reason}
+	}
+	return nil
+}
+
+// watchTopology calls onChange once, the first time CheckTopology reports a
+// change. It checks every watch interval until ctx is done.
+func (a *Allocation) watchTopology(ctx context.Context, onChange func(*AllocationChangedError)) {
+	ticker := time.NewTicker(getWatchInterval())
+	defer ticker.Stop()
+	stop := stopping()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		err := a.CheckTopology()
+		var changed *AllocationChangedError
+		if errors.As(err, &changed) {
+			onChange(changed)
+			return
+		}
+		if err != nil {
+			l.Logger.Error("watch topology: ", err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopologyChange(t *testing.T) {
+	blobbers := func(ids ...string) []*blockchain.StorageNode {
+		nodes := make([]*blockchain.StorageNode, 0, len(ids))
+		for _, id := range ids {
+			nodes = append(nodes, &blockchain.StorageNode{ID: id})
+		}
+		return nodes
+	}
+	a := &Allocation{Blobbers: blobbers("b1", "b2", "b3")}
+
+	tests := []struct {
+		name    string
+		current *Allocation
+		want    string
+	}{
+		{name: "Test_Unchanged", current: &Allocation{Blobbers: blobbers("b3", "b1", "b2")}},
+		{name: "Test_Finalized", current: &Allocation{Blobbers: blobbers("b1", "b2", "b3"), Finalized: true}, want: "finalized"},
+		{name: "Test_Canceled", current: &Allocation{Blobbers: blobbers("b1", "b2", "b3"), Canceled: true}, want: "canceled"},
+		{name: "Test_Blobber_Added", current: &Allocation{Blobbers: blobbers("b1", "b2", "b3", "b4")}, want: "blobbers added: b4"},
+		{name: "Test_Blobber_Replaced", current: &Allocation{Blobbers: blobbers("b1", "b4", "b3")}, want: "blobbers added: b4; blobbers removed: b2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, topologyChange(a, tt.current))
+		})
+	}
+}
+
+func TestAllocationChangedError(t *testing.T) {
+	var err error = &AllocationChangedError{AllocationID: "alloc", Reason: "finalized"}
+	require.True(t, errors.Is(fmt.Errorf("repair: %w", err), ErrAllocationChanged))
+
+	var changed *AllocationChangedError
+	require.True(t, errors.As(err, &changed))
+	require.Equal(t, "allocation alloc changed: finalized", changed.Error())
+}
@@ -0,0 +1,161 @@
+package sdk
+
+import (
+	"path"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/encryption"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// OwnershipTransfer is the result of Allocation.TransferOwnership.
+type OwnershipTransfer struct {
+	Hash  string `json:"hash"`
+	Nonce int64  `json:"nonce"`
+	// AuthTickets by remote path of the encrypted files, shared with the new
+	// owner through proxy re-encryption. Encrypted files stay encrypted with
+	// the previous owner's key, the new owner reads them with these tickets.
+	AuthTickets map[string]string `json:"auth_tickets,omitempty"`
+	// ReEncryptionKeys by remote path of the encrypted files, the keys of the
+	// files re-encrypted for the new owner. As the owner of the allocation,
+	// the new owner downloads the files as they were uploaded and decrypts
+	// them with these keys, see SetReEncryptionKeys.
+	ReEncryptionKeys map[string]string `json:"re_encryption_keys,omitempty"`
+}
+
+// SetReEncryptionKeys sets the keys, by remote path, decrypting the files
+// encrypted by a previous owner of the allocation, see OwnershipTransfer.
+func (a *Allocation) SetReEncryptionKeys(keys map[string]string) {
+	copied := make(map[string]string, len(keys))
+	for remotePath, key := range keys {
+		copied[zboxutil.RemoteClean(remotePath)] = key
+	}
+	a.reEncryptionKeys.Store(copied)
+}
+
+func (a *Allocation) reEncryptionKey(remotePath string) string {
+	keys, _ := a.reEncryptionKeys.Load().(map[string]string)
+	return keys[zboxutil.RemoteClean(remotePath)]
+}
+
+// encryptedFiles returns the remote paths of the encrypted files of the
+// allocation. Blobbers may omit the encrypted key from the refs, the meta of
+// the files without one is checked, failing if it can't be fetched.
+func (a *Allocation) encryptedFiles() ([]string, error) {
+	var paths []string
+	offsetPath := ""
+	for {
+		oResult, err := a.GetRefs("/", offsetPath, "", "", fileref.FILE, "regular", 0, defaultSearchPageLimit)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range oResult.Refs {
+			if ref.Type != fileref.FILE {
+				continue
+			}
+			encryptedKey := ref.EncryptedKey
+			if encryptedKey == "" {
+				meta, err := a.GetFileMeta(ref.Path)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to check the encryption of "+ref.Path)
+				}
+				encryptedKey = meta.EncryptedKey
+			}
+			if encryptedKey != "" {
+				paths = append(paths, ref.Path)
+			}
+		}
+		if len(oResult.Refs) < defaultSearchPageLimit || oResult.OffsetPath == "" || oResult.OffsetPath == offsetPath {
+			return paths, nil
+		}
+		offsetPath = oResult.OffsetPath
+	}
+}
+
+// shareEncryptedFiles re-encrypts the keys of the encrypted files for the
+// new owner and registers them with the blobbers. It returns the auth
+// tickets and the re-encryption keys by remote path.
+func (a *Allocation) shareEncryptedFiles(encrypted []string, newOwnerID, newOwnerEncryptionPubKey string) (tickets, reKeys map[string]string, err error) {
+	if len(encrypted) == 0 {
+		return nil, nil, nil
+	}
+	encScheme := encryption.NewEncryptionScheme()
+	if _, err := encScheme.Initialize(client.GetClient().Mnemonic); err != nil {
+		return nil, nil, err
+	}
+
+	tickets = make(map[string]string, len(encrypted))
+	reKeys = make(map[string]string, len(encrypted))
+	for _, remotePath := range encrypted {
+		reKey, err := encScheme.GetReGenKey(newOwnerEncryptionPubKey, "filetype:audio")
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to re-encrypt "+remotePath)
+		}
+		at, err := a.GetAuthTicket(remotePath, path.Base(remotePath), fileref.FILE,
+			newOwnerID, newOwnerEncryptionPubKey, 0, nil)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to re-encrypt "+remotePath)
+		}
+		tickets[remotePath] = at
+		reKeys[remotePath] = reKey
+	}
+	return tickets, reKeys, nil
+}
+
+func (a *Allocation) validateTransfer(newOwnerID, newOwnerPubKey string) error {
+	if newOwnerID == "" || newOwnerPubKey == "" {
+		return errors.New("invalid_transfer", "new owner id and public key are required")
+	}
+	if newOwnerID == a.Owner {
+		return errors.New("invalid_transfer", "new owner is already the owner of the allocation")
+	}
+	return nil
+}
+
+// TransferOwnership transfers the allocation to newOwnerID. The client must
+// be a curator of the allocation, see AddCurator.
+//
+// Encrypted files can only be decrypted with the key of the owner that
+// uploaded them, so before the transfer their keys are re-encrypted for
+// newOwnerEncryptionPubKey, the encryption public key of the new owner, and
+// returned. They are also registered with the blobbers as shares and the
+// matching auth tickets are returned. newOwnerEncryptionPubKey is only
+// required if the allocation has encrypted files, which the client must then
+// own. The transfer is aborted if it can't be told which files are
+// encrypted.
+func (a *Allocation) TransferOwnership(newOwnerID, newOwnerPubKey, newOwnerEncryptionPubKey string) (*OwnershipTransfer, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	if err := a.validateTransfer(newOwnerID, newOwnerPubKey); err != nil {
+		return nil, err
+	}
+
+	encrypted, err := a.encryptedFiles()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list encrypted files")
+	}
+	if len(encrypted) > 0 && newOwnerEncryptionPubKey == "" {
+		return nil, errors.New("invalid_transfer", "encryption public key of the new owner is required to transfer encrypted files")
+	}
+	if len(encrypted) > 0 && client.GetClientID() != a.Owner {
+		return nil, errors.New("invalid_transfer", "only the owner can re-encrypt the encrypted files")
+	}
+
+	transfer := &OwnershipTransfer{}
+	transfer.AuthTickets, transfer.ReEncryptionKeys, err = a.shareEncryptedFiles(encrypted, newOwnerID, newOwnerEncryptionPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer.Hash, transfer.Nonce, err = CuratorTransferAllocation(a.ID, newOwnerID, newOwnerPubKey)
+	if err != nil {
+		return transfer, err
+	}
+	if err := GetAllocationUpdates(a); err != nil {
+		return transfer, errors.Wrap(err, "allocation transferred but reloading it failed")
+	}
+	return transfer, nil
+}
@@ -0,0 +1,254 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	zclient "github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/encryption"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/marker"
+	"github.com/0chain/gosdk/zboxcore/mocks"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	mockOwnerMnemonic    = "inside february piece turkey offer merry select combine tissue wave wet shift room afraid december gown mean brick speak grant gain become toy clown"
+	mockNewOwnerMnemonic = "travel twenty hen negative fresh sentence hen flat swift embody increase juice eternal satisfy want vessel matter honey video begin dutch trigger romance assault"
+	mockNewOwnerId       = "mock new owner id"
+)
+
+func TestAllocation_validateTransfer(t *testing.T) {
+	a := &Allocation{Owner: mockClientId}
+	tests := []struct {
+		name       string
+		newOwnerID string
+		pubKey     string
+		wantErr    bool
+	}{
+		{name: "Test_Valid", newOwnerID: mockNewOwnerId, pubKey: "pub key"},
+		{name: "Test_Missing_Owner", pubKey: "pub key", wantErr: true},
+		{name: "Test_Missing_Public_Key", newOwnerID: mockNewOwnerId, wantErr: true},
+		{name: "Test_Same_Owner", newOwnerID: mockClientId, pubKey: "pub key", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := a.validateTransfer(tt.newOwnerID, tt.pubKey)
+			require.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestAllocation_shareEncryptedFiles(t *testing.T) {
+	const (
+		remotePath = "/secret.txt"
+		data       = "data only the owner of the allocation can read"
+	)
+
+	client := zclient.GetClient()
+	prevWallet, prevMnemonic := client.Wallet, client.Mnemonic
+	client.Wallet = &zcncrypto.Wallet{ClientID: mockClientId, ClientKey: mockClientKey}
+	client.Mnemonic = mockOwnerMnemonic
+	defer func() {
+		client.Wallet, client.Mnemonic = prevWallet, prevMnemonic
+	}()
+
+	// the owner uploaded the file encrypted with its key
+	ownerScheme := encryption.NewEncryptionScheme()
+	_, err := ownerScheme.Initialize(mockOwnerMnemonic)
+	require.NoError(t, err)
+	ownerScheme.InitForEncryption("filetype:audio")
+	encMsg, err := ownerScheme.Encrypt([]byte(data))
+	require.NoError(t, err)
+
+	newOwnerScheme := encryption.NewEncryptionScheme()
+	_, err = newOwnerScheme.Initialize(mockNewOwnerMnemonic)
+	require.NoError(t, err)
+	newOwnerPubKey, err := newOwnerScheme.GetPublicKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		// refsKey is the encrypted key in the refs, blobbers may omit it
+		refsKey string
+		// metaStatus is the status of the file meta requests
+		metaStatus int
+		wantErr    bool
+	}{
+		{name: "Test_Key_In_Refs", refsKey: encMsg.EncryptedKey, metaStatus: http.StatusOK},
+		{name: "Test_Key_In_Meta", metaStatus: http.StatusOK},
+		{name: "Test_Meta_Failing", metaStatus: http.StatusInternalServerError, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				mu      sync.Mutex
+				tickets []string
+			)
+			respond := func(status int, body []byte) *http.Response {
+				return &http.Response{StatusCode: status, Body: ioutil.NopCloser(bytes.NewReader(body))}
+			}
+			refs, err := json.Marshal(ObjectTreeResult{Refs: []ORef{{
+				SimilarField: SimilarField{Type: fileref.FILE, Path: remotePath, Name: "secret.txt"},
+				EncryptedKey: tt.refsKey,
+			}}})
+			require.NoError(err)
+			meta, err := json.Marshal(fileref.FileRef{
+				Ref:          fileref.Ref{Type: fileref.FILE, Name: "secret.txt", Path: remotePath},
+				EncryptedKey: encMsg.EncryptedKey,
+			})
+			require.NoError(err)
+
+			var mockClient = mocks.HttpClient{}
+			mockClient.On("Do", mock.Anything).Return(func(req *http.Request) *http.Response {
+				switch {
+				case strings.Contains(req.URL.Path, zboxutil.REFS_ENDPOINT):
+					return respond(http.StatusOK, refs)
+				case strings.Contains(req.URL.Path, zboxutil.FILE_META_ENDPOINT):
+					return respond(tt.metaStatus, meta)
+				case strings.Contains(req.URL.Path, zboxutil.SHARE_ENDPOINT):
+					require.NoError(req.ParseMultipartForm(1 << 20))
+					mu.Lock()
+					tickets = append(tickets, req.FormValue("auth_ticket"))
+					mu.Unlock()
+					return respond(http.StatusOK, []byte("{}"))
+				}
+				return respond(http.StatusNotFound, nil)
+			}, nil)
+			zboxutil.Client = &mockClient
+
+			a := &Allocation{ID: mockAllocationId, Tx: mockAllocationTxId, Owner: mockClientId, DataShards: 1, ParityShards: 1}
+			a.InitAllocation()
+			sdkInitialized = true
+			for i := 0; i < numBlobbers; i++ {
+				a.Blobbers = append(a.Blobbers, &blockchain.StorageNode{
+					ID:      mockBlobberId + strconv.Itoa(i),
+					Baseurl: "TestAllocation_shareEncryptedFiles" + mockBlobberUrl + strconv.Itoa(i),
+				})
+			}
+
+			encrypted, err := a.encryptedFiles()
+			if tt.wantErr {
+				require.Error(err, "the transfer fails closed")
+				return
+			}
+			require.NoError(err)
+			require.Equal([]string{remotePath}, encrypted)
+
+			shared, reKeys, err := a.shareEncryptedFiles(encrypted, mockNewOwnerId, newOwnerPubKey)
+			require.NoError(err)
+			require.Contains(shared, remotePath)
+			require.Len(tickets, numBlobbers)
+
+			// the blobbers re-encrypt the file with the key registered for
+			// the new owner, who decrypts it with its own keys
+			var at marker.AuthTicket
+			require.NoError(json.Unmarshal([]byte(tickets[0]), &at))
+			require.Equal(mockNewOwnerId, at.ClientID)
+			require.NotEmpty(at.ReEncryptionKey)
+
+			// the keys of the blobber play no part in the re-encryption
+			blobberScheme := encryption.NewEncryptionScheme()
+			_, err = blobberScheme.Initialize(mockNewOwnerMnemonic)
+			require.NoError(err)
+			require.NoError(blobberScheme.InitForDecryption("filetype:audio", encMsg.EncryptedKey))
+			reEncMsg, err := blobberScheme.ReEncrypt(encMsg, at.ReEncryptionKey, newOwnerPubKey)
+			require.NoError(err)
+
+			require.NoError(newOwnerScheme.InitForDecryption("filetype:audio", encMsg.EncryptedKey))
+			decrypted, err := newOwnerScheme.ReDecrypt(reEncMsg)
+			require.NoError(err)
+			require.Equal(data, string(decrypted))
+
+			// as the owner, the new owner downloads the file as uploaded and
+			// decrypts it with the re-encrypted key
+			require.Contains(reKeys, remotePath)
+			a.SetReEncryptionKeys(reKeys)
+			asUploaded := *encMsg
+			asUploaded.ReEncryptionKey = a.reEncryptionKey(remotePath)
+			decrypted, err = newOwnerScheme.Decrypt(&asUploaded)
+			require.NoError(err)
+			require.Equal(data, string(decrypted))
+		})
+	}
+}
+
+func TestAllocation_TransferOwnership_notOwner(t *testing.T) {
+	refs, err := json.Marshal(ObjectTreeResult{Refs: []ORef{{
+		SimilarField: SimilarField{Type: fileref.FILE, Path: "/secret.txt", Name: "secret.txt"},
+		EncryptedKey: "encrypted key",
+	}}})
+	require.NoError(t, err)
+
+	var mockClient = mocks.HttpClient{}
+	mockClient.On("Do", mock.Anything).Return(func(req *http.Request) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(refs))}
+	}, nil)
+	zboxutil.Client = &mockClient
+
+	client := zclient.GetClient()
+	prevWallet := client.Wallet
+	client.Wallet = &zcncrypto.Wallet{ClientID: "curator"}
+	defer func() { client.Wallet = prevWallet }()
+
+	a := &Allocation{ID: mockAllocationId, Tx: mockAllocationTxId, Owner: mockClientId, DataShards: 1, ParityShards: 1}
+	a.InitAllocation()
+	sdkInitialized = true
+	for i := 0; i < numBlobbers; i++ {
+		a.Blobbers = append(a.Blobbers, &blockchain.StorageNode{
+			Baseurl: "TestAllocation_TransferOwnership_notOwner" + mockBlobberUrl + strconv.Itoa(i),
+		})
+	}
+
+	_, err = a.TransferOwnership(mockNewOwnerId, "pub key", "encryption pub key")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "only the owner can re-encrypt")
+}
+
+func TestAllocation_TransferOwnership_missingEncryptionKey(t *testing.T) {
+	refs, err := json.Marshal(ObjectTreeResult{Refs: []ORef{{
+		SimilarField: SimilarField{Type: fileref.FILE, Path: "/secret.txt", Name: "secret.txt"},
+		EncryptedKey: "encrypted key",
+	}}})
+	require.NoError(t, err)
+
+	var mockClient = mocks.HttpClient{}
+	mockClient.On("Do", mock.Anything).Return(func(req *http.Request) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(refs))}
+	}, nil)
+	zboxutil.Client = &mockClient
+
+	a := &Allocation{ID: mockAllocationId, Tx: mockAllocationTxId, Owner: mockClientId, DataShards: 1, ParityShards: 1}
+	a.InitAllocation()
+	sdkInitialized = true
+	for i := 0; i < numBlobbers; i++ {
+		a.Blobbers = append(a.Blobbers, &blockchain.StorageNode{
+			Baseurl: "TestAllocation_TransferOwnership" + mockBlobberUrl + strconv.Itoa(i),
+		})
+	}
+
+	_, err = a.TransferOwnership(mockNewOwnerId, "pub key", "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "encryption public key of the new owner is required")
+}
+
+func TestCreateFreeAllocation_notInitialized(t *testing.T) {
+	prev := sdkInitialized
+	sdkInitialized = false
+	defer func() { sdkInitialized = prev }()
+
+	_, _, err := CreateFreeAllocation("marker", 0)
+	require.Equal(t, sdkNotInitialized, err)
+}
@@ -0,0 +1,134 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/0chain/errors"
+)
+
+// UpdateAllocationOptions describes an allocation update. Size and Expiry
+// are added to the current values; blobbers are referenced by id.
+type UpdateAllocationOptions struct {
+	// Size in bytes to add, negative to shrink
+	Size int64
+	// Expiry in seconds to add
+	Expiry int64
+	// Lock tokens added to the write pool
+	Lock         uint64
+	SetImmutable bool
+	UpdateTerms  bool
+
+	// AddBlobberID blobber to add. Together with RemoveBlobberID it replaces
+	// a blobber.
+	AddBlobberID string
+	// RemoveBlobberID blobber to remove, only allowed with AddBlobberID
+	RemoveBlobberID string
+
+	// RepairLocalPath is where files are staged while the shards of the new
+	// blobber are repaired. A temp directory is used if empty.
+	RepairLocalPath string
+	// RepairStatusCB receives the progress of the repair, if any
+	RepairStatusCB StatusCallback
+}
+
+func (a *Allocation) hasBlobber(id string) bool {
+	for _, b := range a.Blobbers {
+		if b.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Allocation) validateUpdate(opts *UpdateAllocationOptions) error {
+	if opts.Size == 0 && opts.Expiry == 0 && opts.Lock == 0 && !opts.SetImmutable &&
+		!opts.UpdateTerms && opts.AddBlobberID == "" {
+		return errors.New("invalid_update", "nothing to update")
+	}
+	if opts.RemoveBlobberID != "" {
+		if opts.AddBlobberID == "" {
+			return errors.New("invalid_update", "a blobber can only be removed by replacing it")
+		}
+		if !a.hasBlobber(opts.RemoveBlobberID) {
+			return errors.New("invalid_update", "blobber "+opts.RemoveBlobberID+" is not part of the allocation")
+		}
+	}
+	if opts.AddBlobberID != "" && a.hasBlobber(opts.AddBlobberID) {
+		return errors.New("invalid_update", "blobber "+opts.AddBlobberID+" is already part of the allocation")
+	}
+	return nil
+}
+
+// UpdateAllocation submits the update transaction, waits for its
+// confirmation and reloads the allocation. When a blobber was added, a repair
+// of the whole allocation is started to upload the shards it is missing.
+func (a *Allocation) UpdateAllocation(opts UpdateAllocationOptions) (string, error) {
+	if !a.isInitialized() {
+		return "", notInitialized
+	}
+	if err := a.validateUpdate(&opts); err != nil {
+		return "", err
+	}
+
+	hash, _, err := UpdateAllocation(a.Name, opts.Size, opts.Expiry, a.ID, opts.Lock,
+		opts.SetImmutable, opts.UpdateTerms, opts.AddBlobberID, opts.RemoveBlobberID)
+	if err != nil {
+		return hash, err
+	}
+
+	if err := GetAllocationUpdates(a); err != nil {
+		return hash, errors.Wrap(err, "allocation updated but reloading it failed")
+	}
+	InitCommitWorker(a.Blobbers)
+	InitBlockDownloader(a.Blobbers)
+
+	if opts.AddBlobberID == "" {
+		return hash, nil
+	}
+
+	localPath := opts.RepairLocalPath
+	if localPath == "" {
+		localPath = filepath.Join(os.TempDir(), "repair-"+a.ID)
+	}
+	statusCB := opts.RepairStatusCB
+	if statusCB == nil {
+		statusCB = discardStatusCallback{}
+	}
+	if err := a.StartRepair(localPath, "/", statusCB); err != nil {
+		return hash, errors.Wrap(err, "allocation updated but starting the repair failed")
+	}
+	return hash, nil
+}
+
+// discardStatusCallback is used when the caller doesn't follow the repair.
+type discardStatusCallback struct{}
+
+func (discardStatusCallback) Started(allocationId, filePath string, op int, totalBytes int) {}
+func (discardStatusCallback) InProgress(allocationId, filePath string, op int, completedBytes int, data []byte) {
+}
+func (discardStatusCallback) Error(allocationID string, filePath string, op int, err error) {}
+func (discardStatusCallback) Completed(allocationId, filePath string, filename string, mimetype string, size int, op int) {
+}
+func (discardStatusCallback) RepairCompleted(filesRepaired int) {}
+
+// syncStatusCallback keeps the error of an operation run synchronously.
+type syncStatusCallback struct {
+	discardStatusCallback
+	err error
+}
+
+func (cb *syncStatusCallback) Error(allocationID string, filePath string, op int, err error) {
+	cb.err = err
+}
+
+// ReplaceBlobber swaps a failed blobber for a new one and repairs the
+// allocation onto it.
+func (a *Allocation) ReplaceBlobber(failedBlobberID, newBlobberID string, lock uint64, statusCB StatusCallback) (string, error) {
+	return a.UpdateAllocation(UpdateAllocationOptions{
+		Lock:            lock,
+		AddBlobberID:    newBlobberID,
+		RemoveBlobberID: failedBlobberID,
+		RepairStatusCB:  statusCB,
+	})
+}
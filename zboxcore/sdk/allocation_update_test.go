@@ -0,0 +1,36 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocation_validateUpdate(t *testing.T) {
+	a := &Allocation{Blobbers: []*blockchain.StorageNode{{ID: "b1"}, {ID: "b2"}}}
+
+	tests := []struct {
+		name    string
+		opts    UpdateAllocationOptions
+		wantErr bool
+	}{
+		{name: "Test_Nothing_To_Update", opts: UpdateAllocationOptions{}, wantErr: true},
+		{name: "Test_Extend", opts: UpdateAllocationOptions{Size: 1024, Expiry: 3600}},
+		{name: "Test_Add_Blobber", opts: UpdateAllocationOptions{AddBlobberID: "b3"}},
+		{name: "Test_Add_Existing_Blobber", opts: UpdateAllocationOptions{AddBlobberID: "b1"}, wantErr: true},
+		{name: "Test_Remove_Only", opts: UpdateAllocationOptions{RemoveBlobberID: "b1"}, wantErr: true},
+		{name: "Test_Replace", opts: UpdateAllocationOptions{AddBlobberID: "b3", RemoveBlobberID: "b1"}},
+		{name: "Test_Replace_Unknown", opts: UpdateAllocationOptions{AddBlobberID: "b3", RemoveBlobberID: "b9"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := a.validateUpdate(&tt.opts)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
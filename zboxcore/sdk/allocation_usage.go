@@ -0,0 +1,236 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// DirSize is the size of a directory of an allocation and of its
+// subdirectories, computed from the object trees of the blobbers.
+type DirSize struct {
+	Path     string `json:"path"`
+	NumFiles int64  `json:"num_files"`
+	NumDirs  int64  `json:"num_dirs"`
+	// Size is the size the files take on each blobber
+	Size int64 `json:"size"`
+	// ActualSize is the size of the files before erasure coding
+	ActualSize int64 `json:"actual_size"`
+}
+
+// add counts ref if it's under the directory, but the directory itself.
+func (d *DirSize) add(ref *ORef) {
+	if d.Path != "/" && !strings.HasPrefix(ref.Path, d.Path+"/") {
+		return
+	}
+	switch {
+	case ref.Path == "/":
+	case ref.Type == fileref.FILE:
+		d.NumFiles++
+		d.Size += ref.Size
+		d.ActualSize += ref.ActualFileSize
+	case ref.Type == fileref.DIRECTORY:
+		d.NumDirs++
+	}
+}
+
+// BlobberUsage is the usage of an allocation on one of its blobbers.
+type BlobberUsage struct {
+	BlobberID  string `json:"blobber_id"`
+	BlobberURL string `json:"blobber_url"`
+	// Capacity is the size the blobber keeps for the allocation
+	Capacity  int64 `json:"capacity"`
+	UsedSize  int64 `json:"used_size"`
+	Remaining int64 `json:"remaining"`
+	// NumFiles is the number of files the blobber holds, it may differ from
+	// Files of the Usage while the blobber is behind the others
+	NumFiles int64 `json:"num_files"`
+	// Unreachable is set for a blobber which didn't return its stats, its
+	// used size being unknown
+	Unreachable bool `json:"unreachable,omitempty"`
+}
+
+// Usage is the quota of an allocation and how much of it is used.
+type Usage struct {
+	AllocationID string `json:"allocation_id"`
+	// Size is the quota of the allocation
+	Size      int64 `json:"size"`
+	UsedSize  int64 `json:"used_size"`
+	Remaining int64 `json:"remaining"`
+	// Files are the files and directories of the allocation
+	Files     DirSize        `json:"files"`
+	WritePool common.Balance `json:"write_pool"`
+	// ReadPool of the client, read pools are not tied to an allocation
+	ReadPool common.Balance `json:"read_pool"`
+	Blobbers []BlobberUsage `json:"blobbers"`
+}
+
+// GetDirSize returns the size of the directory at path, see DirSize.
+func (a *Allocation) GetDirSize(path string) (*DirSize, error) {
+	return a.GetDirSizeCtx(context.Background(), path)
+}
+
+// GetDirSizeCtx sums the sizes of the files under path, page by page.
+func (a *Allocation) GetDirSizeCtx(ctx context.Context, path string) (*DirSize, error) {
+	if len(path) == 0 || !zboxutil.IsRemoteAbs(path) {
+		return nil, errors.New("invalid_path", fmt.Sprintf("Absolute path required. Path provided: %v", path))
+	}
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+
+	size := &DirSize{Path: zboxutil.RemoteClean(path)}
+	err := size.collect(func(offsetPath string) (*ObjectTreeResult, error) {
+		return a.GetRefsCtx(ctx, size.Path, offsetPath, "", "", "", "regular", 0, defaultSearchPageLimit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return size, nil
+}
+
+// collect adds the refs of the pages getRefs returns, from the first one
+// until the last.
+func (d *DirSize) collect(getRefs func(offsetPath string) (*ObjectTreeResult, error)) error {
+	offsetPath := ""
+	for {
+		oResult, err := getRefs(offsetPath)
+		if err != nil {
+			return err
+		}
+		for i := range oResult.Refs {
+			d.add(&oResult.Refs[i])
+		}
+		if len(oResult.Refs) < defaultSearchPageLimit || oResult.OffsetPath == "" || oResult.OffsetPath == offsetPath {
+			return nil
+		}
+		offsetPath = oResult.OffsetPath
+	}
+}
+
+// blobberDirSizes returns the size of the directory at path on each blobber
+// by url, without consensus. Blobbers which fail to answer are left out.
+func (a *Allocation) blobberDirSizes(ctx context.Context, path string) map[string]*DirSize {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
+	sizes := make([]*DirSize, len(a.Blobbers))
+	wg := &sync.WaitGroup{}
+	for i := range a.Blobbers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			oTreeReq := &ObjectTreeRequest{
+				allocationID:   a.ID,
+				allocationTx:   a.Tx,
+				blobbers:       a.Blobbers[i : i+1],
+				remotefilepath: path,
+				pageLimit:      defaultSearchPageLimit,
+				refType:        "regular",
+				wg:             &sync.WaitGroup{},
+				ctx:            ctx,
+			}
+			oTreeReq.consensusThresh = 1
+			size := &DirSize{Path: path}
+			err := size.collect(func(offsetPath string) (*ObjectTreeResult, error) {
+				oTreeReq.offsetPath = offsetPath
+				return oTreeReq.GetRefs()
+			})
+			if err == nil {
+				sizes[i] = size
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	result := make(map[string]*DirSize, len(a.Blobbers))
+	for i, size := range sizes {
+		if size != nil {
+			result[a.Blobbers[i].Baseurl] = size
+		}
+	}
+	return result
+}
+
+// GetUsage returns the quota and the usage of the allocation: its size and
+// used size on the chain, its files, the balances of its write pool and of
+// the read pool of the client, and its usage on each of its blobbers.
+func (a *Allocation) GetUsage() (*Usage, error) {
+	return a.GetUsageCtx(context.Background())
+}
+
+// GetUsageCtx returns the quota and the usage of the allocation, see GetUsage.
+func (a *Allocation) GetUsageCtx(ctx context.Context) (*Usage, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+
+	alloc := &Allocation{ID: a.ID}
+	if err := GetAllocationUpdates(alloc); err != nil {
+		return nil, err
+	}
+	readPool, err := GetReadPoolInfo("")
+	if err != nil {
+		return nil, err
+	}
+	files, err := a.GetDirSizeCtx(ctx, "/")
+	if err != nil {
+		return nil, err
+	}
+
+	u := newUsage(alloc, *files, readPool.Balance, a.GetBlobberStatsCtx(ctx), a.blobberDirSizes(ctx, "/"))
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// newUsage builds the usage of alloc, as last fetched from the chain, with
+// the stats and the files of its blobbers by url.
+func newUsage(alloc *Allocation, files DirSize, readPool common.Balance, stats map[string]*BlobberAllocationStats, blobberFiles map[string]*DirSize) *Usage {
+	u := &Usage{
+		AllocationID: alloc.ID,
+		Size:         alloc.Size,
+		Files:        files,
+		WritePool:    alloc.WritePool,
+		ReadPool:     readPool,
+	}
+	if alloc.Stats != nil {
+		u.UsedSize = alloc.Stats.UsedSize
+	}
+	u.Remaining = remaining(u.Size, u.UsedSize)
+
+	capacities := make(map[string]int64, len(alloc.BlobberDetails))
+	for _, d := range alloc.BlobberDetails {
+		capacities[d.BlobberID] = d.Size
+	}
+	for _, b := range alloc.Blobbers {
+		bu := BlobberUsage{BlobberID: b.ID, BlobberURL: b.Baseurl, Capacity: capacities[b.ID]}
+		if s, ok := stats[b.Baseurl]; ok {
+			if bu.Capacity == 0 {
+				bu.Capacity = int64(s.BlobberSize)
+			}
+			bu.UsedSize = int64(s.BlobberSizeUsed)
+		} else {
+			bu.Unreachable = true
+		}
+		if f, ok := blobberFiles[b.Baseurl]; ok {
+			bu.NumFiles = f.NumFiles
+		}
+		bu.Remaining = remaining(bu.Capacity, bu.UsedSize)
+		u.Blobbers = append(u.Blobbers, bu)
+	}
+	return u
+}
+
+func remaining(size, used int64) int64 {
+	if used >= size {
+		return 0
+	}
+	return size - used
+}
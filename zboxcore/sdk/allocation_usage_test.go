@@ -0,0 +1,136 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/mocks"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirSize_add(t *testing.T) {
+	refs := []ORef{
+		{SimilarField: SimilarField{Path: "/", Type: fileref.DIRECTORY}},
+		{SimilarField: SimilarField{Path: "/photos", Type: fileref.DIRECTORY}},
+		{SimilarField: SimilarField{Path: "/photos/a.jpg", Type: fileref.FILE, Size: 10, ActualFileSize: 20}},
+		{SimilarField: SimilarField{Path: "/photos/2022", Type: fileref.DIRECTORY}},
+		{SimilarField: SimilarField{Path: "/photos/2022/b.jpg", Type: fileref.FILE, Size: 5, ActualFileSize: 10}},
+		{SimilarField: SimilarField{Path: "/photos-old/c.jpg", Type: fileref.FILE, Size: 1, ActualFileSize: 2}},
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want DirSize
+	}{
+		{name: "Test_Root", path: "/", want: DirSize{Path: "/", NumFiles: 3, NumDirs: 2, Size: 16, ActualSize: 32}},
+		{name: "Test_Dir", path: "/photos", want: DirSize{Path: "/photos", NumFiles: 2, NumDirs: 1, Size: 15, ActualSize: 30}},
+		{name: "Test_Subdir", path: "/photos/2022", want: DirSize{Path: "/photos/2022", NumFiles: 1, Size: 5, ActualSize: 10}},
+		{name: "Test_Empty", path: "/docs", want: DirSize{Path: "/docs"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DirSize{Path: tt.path}
+			for i := range refs {
+				got.add(&refs[i])
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_newUsage(t *testing.T) {
+	alloc := &Allocation{
+		ID:        "allocation",
+		Size:      3 * GB,
+		Stats:     &AllocationStats{UsedSize: GB},
+		WritePool: common.Balance(100),
+		Blobbers: []*blockchain.StorageNode{
+			{ID: "b0", Baseurl: "http://b0"},
+			{ID: "b1", Baseurl: "http://b1"},
+			{ID: "b2", Baseurl: "http://b2"},
+		},
+		BlobberDetails: []*BlobberAllocation{
+			{BlobberID: "b0", Size: GB},
+			{BlobberID: "b1", Size: GB},
+		},
+	}
+	stats := map[string]*BlobberAllocationStats{
+		"http://b0": {BlobberSize: int(2 * GB), BlobberSizeUsed: int(GB / 2)},
+		"http://b1": {BlobberSize: int(GB), BlobberSizeUsed: int(2 * GB)},
+	}
+	files := DirSize{Path: "/", NumFiles: 2}
+
+	blobberFiles := map[string]*DirSize{
+		"http://b0": {Path: "/", NumFiles: 2},
+		"http://b1": {Path: "/", NumFiles: 1},
+	}
+
+	u := newUsage(alloc, files, common.Balance(7), stats, blobberFiles)
+	require.Equal(t, &Usage{
+		AllocationID: "allocation",
+		Size:         3 * GB,
+		UsedSize:     GB,
+		Remaining:    2 * GB,
+		Files:        files,
+		WritePool:    common.Balance(100),
+		ReadPool:     common.Balance(7),
+		Blobbers: []BlobberUsage{
+			{BlobberID: "b0", BlobberURL: "http://b0", Capacity: GB, UsedSize: GB / 2, Remaining: GB / 2, NumFiles: 2},
+			{BlobberID: "b1", BlobberURL: "http://b1", Capacity: GB, UsedSize: 2 * GB, NumFiles: 1},
+			{BlobberID: "b2", BlobberURL: "http://b2", Unreachable: true},
+		},
+	}, u)
+}
+
+func TestAllocation_blobberDirSizes(t *testing.T) {
+	refs := func(paths ...string) []byte {
+		result := ObjectTreeResult{}
+		for _, p := range paths {
+			result.Refs = append(result.Refs, ORef{SimilarField: SimilarField{Path: p, Type: fileref.FILE}})
+		}
+		b, err := json.Marshal(result)
+		require.NoError(t, err)
+		return b
+	}
+	bodies := map[string][]byte{
+		"b0": refs("/a.txt", "/b.txt"),
+		// b1 is missing a file the others have
+		"b1": refs("/a.txt"),
+	}
+
+	var mockClient = mocks.HttpClient{}
+	mockClient.On("Do", mock.Anything).Return(func(req *http.Request) *http.Response {
+		body, ok := bodies[req.URL.Host]
+		if !ok {
+			return &http.Response{StatusCode: http.StatusInternalServerError, Body: ioutil.NopCloser(strings.NewReader(""))}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(body))}
+	}, nil)
+	zboxutil.Client = &mockClient
+
+	a := &Allocation{ID: mockAllocationId, Tx: mockAllocationTxId}
+	for i := 0; i < 3; i++ {
+		a.Blobbers = append(a.Blobbers, &blockchain.StorageNode{
+			ID:      "b" + strconv.Itoa(i),
+			Baseurl: "http://b" + strconv.Itoa(i),
+		})
+	}
+
+	got := a.blobberDirSizes(context.Background(), "/")
+	require.Equal(t, map[string]*DirSize{
+		"http://b0": {Path: "/", NumFiles: 2},
+		"http://b1": {Path: "/", NumFiles: 1},
+	}, got)
+}
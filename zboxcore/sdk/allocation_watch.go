@@ -0,0 +1,181 @@
+package sdk
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	l "github.com/0chain/gosdk/zboxcore/logger"
+)
+
+// ChangeType is the kind of change reported by WatchChanges.
+type ChangeType string
+
+const (
+	ChangeAdded   ChangeType = "added"
+	ChangeUpdated ChangeType = "updated"
+	ChangeDeleted ChangeType = "deleted"
+)
+
+// ChangeEvent describes a single file or directory change in an allocation.
+type ChangeEvent struct {
+	Type       ChangeType       `json:"type"`
+	Path       string           `json:"path"`
+	RefType    string           `json:"ref_type"`
+	LookupHash string           `json:"lookup_hash"`
+	Hash       string           `json:"hash,omitempty"`
+	UpdatedAt  common.Timestamp `json:"updated_at"`
+	// AllocationRoot is the root of the write marker the change was seen in.
+	AllocationRoot string `json:"allocation_root"`
+}
+
+var (
+	watchIntervalMu sync.RWMutex
+	watchInterval   = 30 * time.Second
+)
+
+// SetWatchInterval sets how often WatchChanges polls blobbers for a new write marker.
+func SetWatchInterval(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	watchIntervalMu.Lock()
+	watchInterval = d
+	watchIntervalMu.Unlock()
+}
+
+func getWatchInterval() time.Duration {
+	watchIntervalMu.RLock()
+	defer watchIntervalMu.RUnlock()
+	return watchInterval
+}
+
+type refSnapshot map[string]SimilarField
+
+// WatchChanges polls the allocation's latest write marker and, whenever it
+// moves, diffs the object tree against the previous one and emits add,
+// update and delete events. The first poll only records a baseline. The
+// channel is closed when ctx is done.
+func (a *Allocation) WatchChanges(ctx context.Context) (<-chan ChangeEvent, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+
+	root, err := a.latestAllocationRoot()
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := a.snapshotRefs()
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ChangeEvent, 64)
+	stop := stopping()
+	go func() {
+		defer close(ch)
+		ticker := time.NewTicker(getWatchInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+
+			latest, err := a.latestAllocationRoot()
+			if err != nil {
+				l.Logger.Error("watch changes: ", err)
+				continue
+			}
+			if latest == root {
+				continue
+			}
+			next, err := a.snapshotRefs()
+			if err != nil {
+				l.Logger.Error("watch changes: ", err)
+				continue
+			}
+			for _, ev := range diffRefSnapshots(snapshot, next) {
+				ev.AllocationRoot = latest
+				select {
+				case ch <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+			root, snapshot = latest, next
+		}
+	}()
+	return ch, nil
+}
+
+// latestAllocationRoot returns the allocation root of the latest write marker
+// agreed on by blobbers, or "" for an allocation that was never written to.
+func (a *Allocation) latestAllocationRoot() (string, error) {
+	oResult, err := a.GetRefs("/", "", "", "", "", "regular", 0, 1)
+	if err != nil {
+		return "", err
+	}
+	if oResult.LatestWM == nil {
+		return "", nil
+	}
+	return oResult.LatestWM.AllocationRoot, nil
+}
+
+func (a *Allocation) snapshotRefs() (refSnapshot, error) {
+	snapshot := make(refSnapshot)
+	offsetPath := ""
+	for {
+		oResult, err := a.GetRefs("/", offsetPath, "", "", "", "regular", 0, defaultSearchPageLimit)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range oResult.Refs {
+			snapshot[ref.Path] = ref.SimilarField
+		}
+		if len(oResult.Refs) < defaultSearchPageLimit || oResult.OffsetPath == "" || oResult.OffsetPath == offsetPath {
+			return snapshot, nil
+		}
+		offsetPath = oResult.OffsetPath
+	}
+}
+
+func newChangeEvent(t ChangeType, ref SimilarField) ChangeEvent {
+	return ChangeEvent{
+		Type:       t,
+		Path:       ref.Path,
+		RefType:    ref.Type,
+		LookupHash: ref.LookupHash,
+		Hash:       ref.ActualFileHash,
+		UpdatedAt:  ref.UpdatedAt,
+	}
+}
+
+// diffRefSnapshots returns the changes from prev to next ordered by path.
+func diffRefSnapshots(prev, next refSnapshot) []ChangeEvent {
+	var events []ChangeEvent
+	for p, ref := range next {
+		old, ok := prev[p]
+		switch {
+		case !ok:
+			events = append(events, newChangeEvent(ChangeAdded, ref))
+		case ref.Type == fileref.FILE && (old.ActualFileHash != ref.ActualFileHash || old.UpdatedAt != ref.UpdatedAt):
+			events = append(events, newChangeEvent(ChangeUpdated, ref))
+		}
+	}
+	for p, ref := range prev {
+		if _, ok := next[p]; !ok {
+			events = append(events, newChangeEvent(ChangeDeleted, ref))
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Path < events[j].Path
+	})
+	return events
+}
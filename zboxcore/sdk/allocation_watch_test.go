@@ -0,0 +1,35 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffRefSnapshots(t *testing.T) {
+	prev := refSnapshot{
+		"/docs":         {Type: fileref.DIRECTORY, Path: "/docs"},
+		"/docs/a.txt":   {Type: fileref.FILE, Path: "/docs/a.txt", ActualFileHash: "a1", UpdatedAt: 1},
+		"/docs/b.txt":   {Type: fileref.FILE, Path: "/docs/b.txt", ActualFileHash: "b1", UpdatedAt: 1},
+		"/old/gone.txt": {Type: fileref.FILE, Path: "/old/gone.txt", ActualFileHash: "g1", UpdatedAt: 1},
+	}
+	next := refSnapshot{
+		"/docs":       {Type: fileref.DIRECTORY, Path: "/docs", UpdatedAt: 2},
+		"/docs/a.txt": {Type: fileref.FILE, Path: "/docs/a.txt", ActualFileHash: "a1", UpdatedAt: 1},
+		"/docs/b.txt": {Type: fileref.FILE, Path: "/docs/b.txt", ActualFileHash: "b2", UpdatedAt: 2},
+		"/docs/c.txt": {Type: fileref.FILE, Path: "/docs/c.txt", ActualFileHash: "c1", UpdatedAt: 2},
+	}
+
+	events := diffRefSnapshots(prev, next)
+	require.Len(t, events, 3)
+	require.Equal(t, ChangeUpdated, events[0].Type)
+	require.Equal(t, "/docs/b.txt", events[0].Path)
+	require.Equal(t, "b2", events[0].Hash)
+	require.Equal(t, ChangeAdded, events[1].Type)
+	require.Equal(t, "/docs/c.txt", events[1].Path)
+	require.Equal(t, ChangeDeleted, events[2].Type)
+	require.Equal(t, "/old/gone.txt", events[2].Path)
+
+	require.Empty(t, diffRefSnapshots(next, next))
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 
 	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
 	"github.com/0chain/gosdk/zboxcore/fileref"
 	"github.com/0chain/gosdk/zboxcore/marker"
 )
@@ -22,12 +23,12 @@ func InitAuthTicket(authTicket string) *AuthTicket {
 func (at *AuthTicket) IsDir() (bool, error) {
 	sEnc, err := base64.StdEncoding.DecodeString(at.b64Ticket)
 	if err != nil {
-		return false, errors.New("auth_ticket_decode_error", "Error decoding the auth ticket."+err.Error())
+		return false, errors.Throw(constants.ErrInvalidAuthTicket, "Error decoding the auth ticket."+err.Error())
 	}
 	authTicket := &marker.AuthTicket{}
 	err = json.Unmarshal(sEnc, authTicket)
 	if err != nil {
-		return false, errors.New("auth_ticket_decode_error", "Error unmarshaling the auth ticket."+err.Error())
+		return false, errors.Throw(constants.ErrInvalidAuthTicket, "Error unmarshaling the auth ticket."+err.Error())
 	}
 	return authTicket.RefType == fileref.DIRECTORY, nil
 }
@@ -35,12 +36,12 @@ func (at *AuthTicket) IsDir() (bool, error) {
 func (at *AuthTicket) GetFileName() (string, error) {
 	sEnc, err := base64.StdEncoding.DecodeString(at.b64Ticket)
 	if err != nil {
-		return "", errors.New("auth_ticket_decode_error", "Error decoding the auth ticket."+err.Error())
+		return "", errors.Throw(constants.ErrInvalidAuthTicket, "Error decoding the auth ticket."+err.Error())
 	}
 	authTicket := &marker.AuthTicket{}
 	err = json.Unmarshal(sEnc, authTicket)
 	if err != nil {
-		return "", errors.New("auth_ticket_decode_error", "Error unmarshaling the auth ticket."+err.Error())
+		return "", errors.Throw(constants.ErrInvalidAuthTicket, "Error unmarshaling the auth ticket."+err.Error())
 	}
 	return authTicket.FileName, nil
 }
@@ -48,12 +49,12 @@ func (at *AuthTicket) GetFileName() (string, error) {
 func (at *AuthTicket) GetLookupHash() (string, error) {
 	sEnc, err := base64.StdEncoding.DecodeString(at.b64Ticket)
 	if err != nil {
-		return "", errors.New("auth_ticket_decode_error", "Error decoding the auth ticket."+err.Error())
+		return "", errors.Throw(constants.ErrInvalidAuthTicket, "Error decoding the auth ticket."+err.Error())
 	}
 	authTicket := &marker.AuthTicket{}
 	err = json.Unmarshal(sEnc, authTicket)
 	if err != nil {
-		return "", errors.New("auth_ticket_decode_error", "Error unmarshaling the auth ticket."+err.Error())
+		return "", errors.Throw(constants.ErrInvalidAuthTicket, "Error unmarshaling the auth ticket."+err.Error())
 	}
 	return authTicket.FilePathHash, nil
 }
@@ -61,12 +62,12 @@ func (at *AuthTicket) GetLookupHash() (string, error) {
 func (at *AuthTicket) Unmarshall() (*marker.AuthTicket, error) {
 	sEnc, err := base64.StdEncoding.DecodeString(at.b64Ticket)
 	if err != nil {
-		return nil, errors.New("auth_ticket_decode_error", "Error decoding the auth ticket."+err.Error())
+		return nil, errors.Throw(constants.ErrInvalidAuthTicket, "Error decoding the auth ticket."+err.Error())
 	}
 	authTicket := &marker.AuthTicket{}
 	err = json.Unmarshal(sEnc, authTicket)
 	if err != nil {
-		return nil, errors.New("auth_ticket_decode_error", "Error unmarshaling the auth ticket."+err.Error())
+		return nil, errors.Throw(constants.ErrInvalidAuthTicket, "Error unmarshaling the auth ticket."+err.Error())
 	}
 	return authTicket, nil
 }
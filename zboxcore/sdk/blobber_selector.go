@@ -0,0 +1,302 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/transaction"
+	"github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// blobberProbeTimeout bounds a single latency probe
+const blobberProbeTimeout = 5 * time.Second
+
+// BlobberSelectionRequest describes the allocation blobbers are selected for.
+type BlobberSelectionRequest struct {
+	DataShards   int
+	ParityShards int
+	Size         int64
+	Expiry       int64
+	ReadPrice    PriceRange
+	WritePrice   PriceRange
+	// Latency holds the probed round trip time per blobber id. It is only
+	// filled for selectors that rank by latency; unreachable blobbers are
+	// missing.
+	Latency map[string]time.Duration
+}
+
+// BlobberSelector picks blobbers for a new allocation on the client side.
+// Blobbers are first checked against the price ranges, free capacity and
+// offer duration of the request, then Filter drops the ones the strategy
+// doesn't want and Rank orders the rest best first. The first
+// DataShards+ParityShards blobbers are used.
+type BlobberSelector interface {
+	Filter(req *BlobberSelectionRequest, b *Blobber) bool
+	Rank(req *BlobberSelectionRequest, blobbers []*Blobber) []*Blobber
+}
+
+// latencySelector is implemented by selectors that need Latency probed.
+type latencySelector interface {
+	usesLatency() bool
+}
+
+var (
+	// LowestPrice ranks blobbers by write price, then read price.
+	LowestPrice BlobberSelector = lowestPriceSelector{}
+	// LowestLatency ranks reachable blobbers by probed round trip time.
+	LowestLatency BlobberSelector = lowestLatencySelector{}
+	// Balanced ranks reachable blobbers by their combined position in price,
+	// latency and free capacity order.
+	Balanced BlobberSelector = balancedSelector{}
+)
+
+type lowestPriceSelector struct{}
+
+func (lowestPriceSelector) Filter(*BlobberSelectionRequest, *Blobber) bool { return true }
+
+func (lowestPriceSelector) Rank(_ *BlobberSelectionRequest, blobbers []*Blobber) []*Blobber {
+	sort.SliceStable(blobbers, func(i, j int) bool {
+		return lessPrice(blobbers[i], blobbers[j])
+	})
+	return blobbers
+}
+
+type lowestLatencySelector struct{}
+
+func (lowestLatencySelector) usesLatency() bool { return true }
+
+func (lowestLatencySelector) Filter(req *BlobberSelectionRequest, b *Blobber) bool {
+	_, ok := req.Latency[string(b.ID)]
+	return ok
+}
+
+func (lowestLatencySelector) Rank(req *BlobberSelectionRequest, blobbers []*Blobber) []*Blobber {
+	sort.SliceStable(blobbers, func(i, j int) bool {
+		return req.Latency[string(blobbers[i].ID)] < req.Latency[string(blobbers[j].ID)]
+	})
+	return blobbers
+}
+
+type balancedSelector struct{}
+
+func (balancedSelector) usesLatency() bool { return true }
+
+func (balancedSelector) Filter(req *BlobberSelectionRequest, b *Blobber) bool {
+	_, ok := req.Latency[string(b.ID)]
+	return ok
+}
+
+func (balancedSelector) Rank(req *BlobberSelectionRequest, blobbers []*Blobber) []*Blobber {
+	score := make(map[*Blobber]int, len(blobbers))
+	addRanks := func(less func(a, b *Blobber) bool) {
+		ordered := append([]*Blobber(nil), blobbers...)
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return less(ordered[i], ordered[j])
+		})
+		for i, b := range ordered {
+			score[b] += i
+		}
+	}
+	addRanks(lessPrice)
+	addRanks(func(a, b *Blobber) bool {
+		return req.Latency[string(a.ID)] < req.Latency[string(b.ID)]
+	})
+	addRanks(func(a, b *Blobber) bool {
+		return freeCapacity(a) > freeCapacity(b)
+	})
+
+	sort.SliceStable(blobbers, func(i, j int) bool {
+		return score[blobbers[i]] < score[blobbers[j]]
+	})
+	return blobbers
+}
+
+// NearestTo ranks blobbers by their distance to the given coordinates.
+// Blobbers that didn't declare a location are ranked last.
+func NearestTo(latitude, longitude float64) BlobberSelector {
+	return nearestSelector{lat: latitude, lon: longitude}
+}
+
+type nearestSelector struct {
+	lat, lon float64
+}
+
+func (nearestSelector) Filter(*BlobberSelectionRequest, *Blobber) bool { return true }
+
+func (s nearestSelector) Rank(_ *BlobberSelectionRequest, blobbers []*Blobber) []*Blobber {
+	distance := func(b *Blobber) float64 {
+		if b.Geolocation == (Geolocation{}) {
+			return math.Inf(1)
+		}
+		return haversine(s.lat, s.lon, b.Geolocation.Latitude, b.Geolocation.Longitude)
+	}
+	sort.SliceStable(blobbers, func(i, j int) bool {
+		return distance(blobbers[i]) < distance(blobbers[j])
+	})
+	return blobbers
+}
+
+func lessPrice(a, b *Blobber) bool {
+	if a.Terms.WritePrice != b.Terms.WritePrice {
+		return a.Terms.WritePrice < b.Terms.WritePrice
+	}
+	return a.Terms.ReadPrice < b.Terms.ReadPrice
+}
+
+func freeCapacity(b *Blobber) int64 {
+	return int64(b.Capacity) - int64(b.Allocated)
+}
+
+// haversine returns the great circle distance in kilometers.
+func haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadius = 6371
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// acceptsTerms checks the blobber against the prices, size and duration of the allocation.
+func (req *BlobberSelectionRequest) acceptsTerms(b *Blobber, now time.Time) bool {
+	if uint64(b.Terms.WritePrice) < req.WritePrice.Min || uint64(b.Terms.WritePrice) > req.WritePrice.Max {
+		return false
+	}
+	if uint64(b.Terms.ReadPrice) < req.ReadPrice.Min || uint64(b.Terms.ReadPrice) > req.ReadPrice.Max {
+		return false
+	}
+	if req.DataShards > 0 {
+		shardSize := (req.Size + int64(req.DataShards) - 1) / int64(req.DataShards)
+		if freeCapacity(b) < shardSize {
+			return false
+		}
+	}
+	if req.Expiry > 0 && b.Terms.MaxOfferDuration < time.Unix(req.Expiry, 0).Sub(now) {
+		return false
+	}
+	return true
+}
+
+// SelectBlobbers returns the ids of the DataShards+ParityShards blobbers the
+// selector ranks best.
+func SelectBlobbers(selector BlobberSelector, req *BlobberSelectionRequest, blobbers []*Blobber) ([]string, error) {
+	if ls, ok := selector.(latencySelector); ok && ls.usesLatency() && req.Latency == nil {
+		req.Latency = ProbeBlobberLatency(context.Background(), blobbers)
+	}
+
+	now := time.Now()
+	candidates := make([]*Blobber, 0, len(blobbers))
+	for _, b := range blobbers {
+		if req.acceptsTerms(b, now) && selector.Filter(req, b) {
+			candidates = append(candidates, b)
+		}
+	}
+
+	need := req.DataShards + req.ParityShards
+	if len(candidates) < need {
+		return nil, errors.New("not_enough_blobbers",
+			fmt.Sprintf("need %d blobbers, only %d match", need, len(candidates)))
+	}
+
+	ranked := selector.Rank(req, candidates)
+	ids := make([]string, 0, need)
+	for _, b := range ranked[:need] {
+		ids = append(ids, string(b.ID))
+	}
+	return ids, nil
+}
+
+// ProbeBlobberLatency measures the round trip time to each blobber
+// concurrently. Blobbers that don't answer are left out.
+func ProbeBlobberLatency(ctx context.Context, blobbers []*Blobber) map[string]time.Duration {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		latency = make(map[string]time.Duration, len(blobbers))
+	)
+	for _, b := range blobbers {
+		wg.Add(1)
+		go func(b *Blobber) {
+			defer wg.Done()
+			rtt, err := probeBlobber(ctx, b.BaseURL)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			latency[string(b.ID)] = rtt
+			mu.Unlock()
+		}(b)
+	}
+	wg.Wait()
+	return latency
+}
+
+// probeBlobber times a request to the blobber's base url. Any http response
+// counts as reachable.
+func probeBlobber(ctx context.Context, baseURL string) (time.Duration, error) {
+	req, err := http.NewRequest(http.MethodGet, baseURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	ctx, cncl := context.WithTimeout(ctx, blobberProbeTimeout)
+	defer cncl()
+
+	start := time.Now()
+	err = zboxutil.HttpDoBlobber(ctx, cncl, req, func(resp *http.Response, err error) error {
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	})
+	return time.Since(start), err
+}
+
+func createAllocationWithSelector(options CreateAllocationOptions) (
+	hash string, nonce int64, txn *transaction.Transaction, err error) {
+
+	if !sdkInitialized {
+		return "", 0, nil, sdkNotInitialized
+	}
+
+	blobbers, err := GetBlobbers(true)
+	if err != nil {
+		return "", 0, nil, errors.Wrap(err, "failed to get blobbers")
+	}
+	ids, err := SelectBlobbers(options.BlobberSelector, &BlobberSelectionRequest{
+		DataShards:   options.DataShards,
+		ParityShards: options.ParityShards,
+		Size:         options.Size,
+		Expiry:       options.Expiry,
+		ReadPrice:    options.ReadPrice,
+		WritePrice:   options.WritePrice,
+	}, blobbers)
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	var sn = transaction.SmartContractTxnData{
+		Name: transaction.NEW_ALLOCATION_REQUEST,
+		InputArgs: map[string]interface{}{
+			"name":              options.Name,
+			"owner_id":          client.GetClientID(),
+			"owner_public_key":  client.GetClientPublicKey(),
+			"data_shards":       options.DataShards,
+			"parity_shards":     options.ParityShards,
+			"size":              options.Size,
+			"expiration_date":   options.Expiry,
+			"blobbers":          ids,
+			"read_price_range":  options.ReadPrice,
+			"write_price_range": options.WritePrice,
+		},
+	}
+	hash, _, nonce, txn, err = smartContractTxnValue(sn, options.Lock)
+	return
+}
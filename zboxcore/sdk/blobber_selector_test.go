@@ -0,0 +1,93 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectBlobbers(t *testing.T) {
+	newBlobber := func(id string, writePrice, capacity int64, loc Geolocation) *Blobber {
+		return &Blobber{
+			ID:          common.Key(id),
+			Capacity:    common.Size(capacity),
+			Geolocation: loc,
+			Terms: Terms{
+				ReadPrice:        1,
+				WritePrice:       common.Balance(writePrice),
+				MaxOfferDuration: 24 * time.Hour,
+			},
+		}
+	}
+	blobbers := func() []*Blobber {
+		return []*Blobber{
+			newBlobber("cheap_slow", 1, 1000, Geolocation{Latitude: 40.7, Longitude: -74}),
+			newBlobber("mid_fast", 2, 2000, Geolocation{Latitude: 51.5, Longitude: -0.1}),
+			newBlobber("pricey_fastest", 5, 5000, Geolocation{Latitude: 48.9, Longitude: 2.3}),
+			newBlobber("too_pricey", 50, 1000, Geolocation{}),
+			newBlobber("full", 1, 10, Geolocation{}),
+			newBlobber("unreachable", 1, 1000, Geolocation{}),
+		}
+	}
+	newRequest := func() *BlobberSelectionRequest {
+		return &BlobberSelectionRequest{
+			DataShards:   1,
+			ParityShards: 1,
+			Size:         100,
+			Expiry:       time.Now().Add(time.Hour).Unix(),
+			ReadPrice:    PriceRange{Min: 0, Max: 10},
+			WritePrice:   PriceRange{Min: 0, Max: 10},
+			Latency: map[string]time.Duration{
+				"cheap_slow":     300 * time.Millisecond,
+				"mid_fast":       50 * time.Millisecond,
+				"pricey_fastest": 10 * time.Millisecond,
+				"too_pricey":     time.Millisecond,
+				"full":           time.Millisecond,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		selector BlobberSelector
+		want     []string
+	}{
+		{
+			name:     "Test_Lowest_Price",
+			selector: LowestPrice,
+			want:     []string{"cheap_slow", "unreachable"},
+		},
+		{
+			name:     "Test_Lowest_Latency",
+			selector: LowestLatency,
+			want:     []string{"pricey_fastest", "mid_fast"},
+		},
+		{
+			name:     "Test_Balanced",
+			selector: Balanced,
+			want:     []string{"pricey_fastest", "mid_fast"},
+		},
+		{
+			name:     "Test_Nearest_To_Paris",
+			selector: NearestTo(48.8, 2.3),
+			want:     []string{"pricey_fastest", "mid_fast"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := SelectBlobbers(tt.selector, newRequest(), blobbers())
+			require.NoError(t, err)
+			require.Equal(t, tt.want, ids)
+		})
+	}
+
+	t.Run("Test_Not_Enough_Blobbers", func(t *testing.T) {
+		req := newRequest()
+		req.ParityShards = 5
+		_, err := SelectBlobbers(LowestPrice, req, blobbers())
+		require.Error(t, err)
+	})
+}
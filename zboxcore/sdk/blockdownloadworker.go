@@ -10,8 +10,11 @@ import (
 	"time"
 
 	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
 	"github.com/0chain/gosdk/core/common"
 	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/core/telemetry"
+	"github.com/0chain/gosdk/core/util"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
 	"github.com/0chain/gosdk/zboxcore/client"
 	"github.com/0chain/gosdk/zboxcore/fileref"
@@ -39,8 +42,12 @@ type BlockDownloadRequest struct {
 	contentMode        string
 	numBlocks          int64
 	authTicket         *marker.AuthTicket
-	ctx                context.Context
-	result             chan *downloadBlock
+	// verify checks the blocks against contentRoot, the content hash of the
+	// file committed by the blobber
+	verify      bool
+	contentRoot string
+	ctx         context.Context
+	result      chan *downloadBlock
 }
 
 type downloadBlock struct {
@@ -51,6 +58,9 @@ type downloadBlock struct {
 	idx         int
 	err         error
 	NumBlocks   int64 `json:"num_of_blocks"`
+	// MerkleProofs are the paths of the blocks to the content hash of the
+	// file, returned when the download is verified
+	MerkleProofs []*util.MTPath `json:"merkle_proofs,omitempty"`
 }
 
 var downloadBlockChan map[string]chan *BlockDownloadRequest
@@ -101,8 +111,16 @@ func (req *BlockDownloadRequest) downloadBlobberBlock() {
 		return
 	}
 	retry := 0
+	resigned := false
 	var err error
-	for retry < 3 {
+	ctx, span := telemetry.StartSpan(req.ctx, "download.block",
+		telemetry.String(telemetry.AttrNode, req.blobber.Baseurl),
+		telemetry.Int64(telemetry.AttrChunk, req.blockNum))
+	defer func() { telemetry.EndSpan(span, err) }()
+	for attempt := 0; retry < 3; attempt++ {
+		if attempt > 0 {
+			telemetry.AddRetry(ctx, req.blobber.Baseurl, "download.block")
+		}
 
 		if req.blobber.IsSkip() {
 			req.result <- &downloadBlock{Success: false, idx: req.blobberIdx,
@@ -116,7 +134,7 @@ func (req *BlockDownloadRequest) downloadBlobberBlock() {
 		rm.BlobberID = req.blobber.ID
 		rm.AllocationID = req.allocationID
 		rm.OwnerID = req.allocOwnerID
-		rm.Timestamp = common.Now()
+		rm.Timestamp = zboxutil.ServerNow(req.blobber.Baseurl)
 		rm.ReadCounter = getBlobberReadCtr(req.allocationID, req.blobber.ID) + req.numBlocks
 		err = rm.Sign()
 		if err != nil {
@@ -153,15 +171,16 @@ func (req *BlockDownloadRequest) downloadBlobberBlock() {
 		if len(req.contentMode) > 0 {
 			header.DownloadMode = req.contentMode
 		}
+		header.VerifyDownload = req.verify
 
-		ctx, cncl := context.WithTimeout(req.ctx, (time.Second * 30))
+		ctx, cncl := context.WithTimeout(ctx, timeoutsOf(ctx).DownloadBlock)
 		shouldRetry := false
 
 		header.ToHeader(httpreq)
 
 		lastBlobberReadCounter := getBlobberReadCtr(req.allocationID, req.blobber.ID)
 
-		err = zboxutil.HttpDo(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
+		err = zboxutil.HttpDoBlobber(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
 			if err != nil {
 				return err
 			}
@@ -169,6 +188,12 @@ func (req *BlockDownloadRequest) downloadBlobberBlock() {
 				defer resp.Body.Close()
 			}
 
+			if !resigned && zboxutil.IsTimestampRejected(resp) {
+				// signed again with the time of the blobber
+				resigned, shouldRetry = true, true
+				return errors.New(zboxutil.TimestampRejectedCode, "read marker timestamp rejected")
+			}
+
 			var rspData downloadBlock
 
 			respBody, err := ioutil.ReadAll(resp.Body)
@@ -197,7 +222,7 @@ func (req *BlockDownloadRequest) downloadBlobberBlock() {
 				if bytes.Contains(respBody, []byte(NotEnoughTokens)) {
 					shouldRetry, retry = false, 3 // don't repeat
 					req.blobber.SetSkip(true)
-					return errors.New(NotEnoughTokens, "")
+					return constants.ErrNotEnoughTokens
 				}
 
 				if bytes.Contains(respBody, []byte(LockExists)) {
@@ -213,6 +238,16 @@ func (req *BlockDownloadRequest) downloadBlobberBlock() {
 			rspData.idx = req.blobberIdx
 			rspData.Success = true
 
+			if req.verify {
+				// the blocks come with their proofs
+				if err := json.Unmarshal(respBody, &rspData); err != nil {
+					retry = 3
+					return errors.Wrap(ErrInvalidMerkleProof, err)
+				}
+				respBody = rspData.RawData
+				rspData.RawData = nil
+			}
+
 			if req.encryptedKey != "" {
 				if req.authTicket != nil {
 					// ReEncryptionHeaderSize for the additional header bytes for ReEncrypt,  where chunk_size - EncryptionHeaderSize is the encrypted data size
@@ -224,7 +259,16 @@ func (req *BlockDownloadRequest) downloadBlobberBlock() {
 				rspData.BlockChunks = req.splitData(respBody, req.chunkSize)
 			}
 
+			if req.verify {
+				if err := verifyBlockProofs(rspData.BlockChunks, rspData.MerkleProofs, req.blockNum, req.contentRoot); err != nil {
+					// the blobber would return the same blocks again
+					retry = 3
+					return err
+				}
+			}
+
 			incBlobberReadCtr(req.allocationID, req.blobber.ID, req.numBlocks)
+			recordReadMarker(rm)
 			req.result <- &rspData
 			return nil
 		})
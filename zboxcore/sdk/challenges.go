@@ -0,0 +1,153 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zcncore"
+)
+
+const (
+	// challengesPageLimit is the page size used by GetOpenChallenges
+	challengesPageLimit = 20
+	// maxChallengesPages bounds the pages GetOpenChallenges requests
+	maxChallengesPages = 1000
+)
+
+// Challenge is a storage challenge issued to a blobber.
+type Challenge struct {
+	ID             string           `json:"id"`
+	Created        common.Timestamp `json:"created"`
+	Seed           int64            `json:"seed"`
+	AllocationID   string           `json:"allocation_id"`
+	AllocationRoot string           `json:"allocation_root"`
+	BlobberID      string           `json:"blobber_id"`
+	Responded      bool             `json:"responded"`
+	ValidatorIDs   []string         `json:"validator_ids,omitempty"`
+}
+
+// ChallengeCounts are the challenges of an allocation, or of one of its
+// blobbers, by outcome.
+type ChallengeCounts struct {
+	Total                    int64  `json:"total"`
+	Open                     int64  `json:"open"`
+	Passed                   int64  `json:"passed"`
+	Failed                   int64  `json:"failed"`
+	LatestClosedChallengeTxn string `json:"latest_closed_challenge,omitempty"`
+}
+
+// PassRate is the share of the closed challenges that passed, 1 when none closed yet.
+func (c ChallengeCounts) PassRate() float64 {
+	closed := c.Passed + c.Failed
+	if closed == 0 {
+		return 1
+	}
+	return float64(c.Passed) / float64(closed)
+}
+
+// ChallengeStats are the challenge outcomes of an allocation and its blobbers.
+type ChallengeStats struct {
+	AllocationID string `json:"allocation_id"`
+	ChallengeCounts
+	// Blobbers by blobber id, blobbers the smart contract returns no stats for are omitted
+	Blobbers map[string]ChallengeCounts `json:"blobbers"`
+}
+
+func newChallengeCounts(s *AllocationStats) ChallengeCounts {
+	if s == nil {
+		return ChallengeCounts{}
+	}
+	return ChallengeCounts{
+		Total:                    s.TotalChallenges,
+		Open:                     s.OpenChallenges,
+		Passed:                   s.SuccessChallenges,
+		Failed:                   s.FailedChallenges,
+		LatestClosedChallengeTxn: s.LastestClosedChallengeTxn,
+	}
+}
+
+// GetOpenChallengesPage returns a page of the open challenges of the blobber.
+func GetOpenChallengesPage(blobberID string, offset, limit int) ([]*Challenge, error) {
+	if !sdkInitialized {
+		return nil, sdkNotInitialized
+	}
+
+	b, err := zcncore.MakeSCRestAPICall(STORAGE_SCADDRESS, "/openchallenges", map[string]string{
+		"blobber": blobberID,
+		"offset":  strconv.Itoa(offset),
+		"limit":   strconv.Itoa(limit),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting open challenges:")
+	}
+	if len(b) == 0 {
+		return nil, errors.New("", "empty response")
+	}
+
+	var wrap struct {
+		Challenges []*Challenge `json:"challenges"`
+	}
+	if err = json.Unmarshal(b, &wrap); err != nil {
+		return nil, errors.Wrap(err, "error decoding response:")
+	}
+	return wrap.Challenges, nil
+}
+
+// GetOpenChallenges returns all the open challenges of the blobber.
+func GetOpenChallenges(blobberID string) ([]*Challenge, error) {
+	var all []*Challenge
+	firstIDs := make(map[string]bool)
+	for page := 0; page < maxChallengesPages; page++ {
+		challenges, err := GetOpenChallengesPage(blobberID, page*challengesPageLimit, challengesPageLimit)
+		if err != nil {
+			return all, err
+		}
+		// a sharder ignoring the paging returns all the challenges on every
+		// page, or the same page again
+		if len(challenges) > challengesPageLimit {
+			return challenges, nil
+		}
+		if len(challenges) > 0 {
+			if firstIDs[challenges[0].ID] {
+				return all, nil
+			}
+			firstIDs[challenges[0].ID] = true
+		}
+		all = append(all, challenges...)
+		// a short page is the last one
+		if len(challenges) < challengesPageLimit {
+			return all, nil
+		}
+	}
+	return all, errors.New("too_many_pages", fmt.Sprintf("more than %d pages of open challenges", maxChallengesPages))
+}
+
+// GetChallengeStats returns the challenge outcomes of the allocation and of
+// each of its blobbers.
+func GetChallengeStats(allocationID string) (*ChallengeStats, error) {
+	if !sdkInitialized {
+		return nil, sdkNotInitialized
+	}
+	alloc := &Allocation{ID: allocationID}
+	if err := GetAllocationUpdates(alloc); err != nil {
+		return nil, err
+	}
+	return newChallengeStats(alloc), nil
+}
+
+func newChallengeStats(alloc *Allocation) *ChallengeStats {
+	stats := &ChallengeStats{
+		AllocationID:    alloc.ID,
+		ChallengeCounts: newChallengeCounts(alloc.Stats),
+		Blobbers:        make(map[string]ChallengeCounts, len(alloc.BlobberDetails)),
+	}
+	for _, d := range alloc.BlobberDetails {
+		if d.Stats != nil {
+			stats.Blobbers[d.BlobberID] = newChallengeCounts(d.Stats)
+		}
+	}
+	return stats
+}
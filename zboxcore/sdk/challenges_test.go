@@ -0,0 +1,154 @@
+package sdk
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/0chain/gosdk/dev/sdktest"
+	"github.com/0chain/gosdk/zcncore"
+	"github.com/stretchr/testify/require"
+)
+
+func setupChallengesSharder(t *testing.T) *sdktest.Sharder {
+	s := sdktest.NewSharder()
+	prev := zcncore.GetNetwork()
+	zcncore.SetNetwork([]string{s.URL}, []string{s.URL})
+	prevInitialized := sdkInitialized
+	sdkInitialized = true
+	t.Cleanup(func() {
+		sdkInitialized = prevInitialized
+		zcncore.SetNetwork(prev.Miners, prev.Sharders)
+		s.Close()
+	})
+	return s
+}
+
+func TestGetOpenChallenges(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		wantPages int
+	}{
+		{name: "Test_None", total: 0, wantPages: 1},
+		{name: "Test_Short_Page", total: 3, wantPages: 1},
+		{name: "Test_Full_Pages", total: 2 * challengesPageLimit, wantPages: 3},
+		{name: "Test_Last_Page_Short", total: 2*challengesPageLimit + 5, wantPages: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := setupChallengesSharder(t)
+
+			var pages int
+			s.HandleSCRestFunc(STORAGE_SCADDRESS, "openchallenges", func(params url.Values) interface{} {
+				pages++
+				require.Equal(t, "blobber1", params.Get("blobber"))
+				offset, err := strconv.Atoi(params.Get("offset"))
+				require.NoError(t, err)
+				limit, err := strconv.Atoi(params.Get("limit"))
+				require.NoError(t, err)
+
+				var challenges []*Challenge
+				for i := offset; i < tt.total && i < offset+limit; i++ {
+					challenges = append(challenges, &Challenge{ID: strconv.Itoa(i), BlobberID: "blobber1"})
+				}
+				return map[string]interface{}{"challenges": challenges}
+			})
+
+			challenges, err := GetOpenChallenges("blobber1")
+			require.NoError(t, err)
+			require.Len(t, challenges, tt.total)
+			for i, c := range challenges {
+				require.Equal(t, strconv.Itoa(i), c.ID, "challenges are returned in order")
+			}
+			require.Equal(t, tt.wantPages, pages)
+		})
+	}
+}
+
+func TestGetOpenChallenges_pagingIgnored(t *testing.T) {
+	tests := []struct {
+		name string
+		// page returns the challenges of the sharder whatever the paging
+		page func() []*Challenge
+		want int
+	}{
+		{name: "Test_All_At_Once", page: func() []*Challenge { return newChallenges(2*challengesPageLimit + 5) }, want: 2*challengesPageLimit + 5},
+		{name: "Test_Same_Page", page: func() []*Challenge { return newChallenges(challengesPageLimit) }, want: challengesPageLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := setupChallengesSharder(t)
+			var pages int
+			s.HandleSCRestFunc(STORAGE_SCADDRESS, "openchallenges", func(params url.Values) interface{} {
+				pages++
+				return map[string]interface{}{"challenges": tt.page()}
+			})
+
+			challenges, err := GetOpenChallenges("blobber1")
+			require.NoError(t, err)
+			require.Len(t, challenges, tt.want)
+			require.LessOrEqual(t, pages, 2)
+		})
+	}
+}
+
+func newChallenges(n int) []*Challenge {
+	challenges := make([]*Challenge, n)
+	for i := range challenges {
+		challenges[i] = &Challenge{ID: strconv.Itoa(i), BlobberID: "blobber1"}
+	}
+	return challenges
+}
+
+func TestGetOpenChallenges_Error(t *testing.T) {
+	setupChallengesSharder(t)
+
+	_, err := GetOpenChallenges("blobber1")
+	require.Error(t, err)
+}
+
+func TestGetChallengeStats(t *testing.T) {
+	s := setupChallengesSharder(t)
+	s.HandleSCRest(STORAGE_SCADDRESS, "allocation", map[string]interface{}{
+		"id": "alloc1",
+		"stats": map[string]interface{}{
+			"total_challenges":        10,
+			"num_open_challenges":     2,
+			"num_success_challenges":  6,
+			"num_failed_challenges":   2,
+			"latest_closed_challenge": "txn1",
+		},
+		"blobber_details": []map[string]interface{}{
+			{"blobber_id": "blobber1", "stats": map[string]interface{}{
+				"total_challenges":       4,
+				"num_success_challenges": 4,
+			}},
+			{"blobber_id": "blobber2", "stats": map[string]interface{}{
+				"total_challenges":       6,
+				"num_open_challenges":    2,
+				"num_success_challenges": 2,
+				"num_failed_challenges":  2,
+			}},
+			// no stats returned for this one
+			{"blobber_id": "blobber3"},
+		},
+	})
+
+	stats, err := GetChallengeStats("alloc1")
+	require.NoError(t, err)
+	require.Equal(t, "alloc1", stats.AllocationID)
+	require.Equal(t, ChallengeCounts{Total: 10, Open: 2, Passed: 6, Failed: 2, LatestClosedChallengeTxn: "txn1"}, stats.ChallengeCounts)
+	require.Equal(t, 0.75, stats.PassRate())
+	require.Equal(t, map[string]ChallengeCounts{
+		"blobber1": {Total: 4, Passed: 4},
+		"blobber2": {Total: 6, Open: 2, Passed: 2, Failed: 2},
+	}, stats.Blobbers)
+	require.Equal(t, 1.0, stats.Blobbers["blobber1"].PassRate())
+	require.Equal(t, 0.5, stats.Blobbers["blobber2"].PassRate())
+}
+
+func TestChallengeCounts_PassRate(t *testing.T) {
+	require.Equal(t, 1.0, ChallengeCounts{Open: 3}.PassRate(), "no challenge closed yet")
+	require.Equal(t, 0.0, ChallengeCounts{Failed: 3}.PassRate())
+}
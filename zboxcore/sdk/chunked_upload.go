@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
-	"fmt"
 	"io"
 	"net/http"
 	"path/filepath"
@@ -40,6 +39,7 @@ var (
 
 	ErrInvalidChunkSize              = errors.New("chunk: chunk size is too small. it must greater than 272 if file is uploaded with encryption")
 	ErrNoEnoughSpaceLeftInAllocation = errors.New("alloc: no enough space left in allocation")
+	ErrInvalidCustomMeta             = errors.New("meta: custom meta must have non-empty keys and be at most 4KB encoded")
 )
 
 // DefaultChunkSize default chunk size for file and thumbnail
@@ -148,11 +148,13 @@ func CreateChunkedUpload(
 		uploadMask:      uploadMask,
 		chunkSize:       DefaultChunkSize,
 		chunkNumber:     1,
+		maxUploadWindow: DefaultUploadWindow,
+		readAheadSize:   DefaultReadAhead,
 		encryptOnUpload: false,
 
 		consensus:     consensus,
-		uploadTimeOut: DefaultUploadTimeOut,
-		commitTimeOut: DefaultUploadTimeOut,
+		uploadTimeOut: allocationObj.GetTimeouts().UploadChunk,
+		commitTimeOut: allocationObj.GetTimeouts().Commit,
 		maskMu:        &sync.Mutex{},
 		ctx:           allocationObj.ctx,
 		opCode:        opCode,
@@ -192,6 +194,35 @@ func CreateChunkedUpload(
 	for _, opt := range opts {
 		opt(su)
 	}
+	if su.ctx == nil {
+		su.ctx = context.Background()
+	}
+
+	if !isValidCustomMeta(su.fileMeta.CustomMeta) {
+		return nil, ErrInvalidCustomMeta
+	}
+
+	if !isRepair {
+		if err := su.hashContent(fileReader); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := su.runPreflight(); err != nil {
+		return nil, err
+	}
+
+	if err := su.detectContent(); err != nil {
+		return nil, err
+	}
+
+	if err := su.generateThumbnail(); err != nil {
+		return nil, err
+	}
+
+	if err := su.setupCompression(); err != nil {
+		return nil, err
+	}
 
 	if su.progressStorer == nil {
 		su.progressStorer = createFsChunkedUploadProgress(context.Background())
@@ -243,11 +274,12 @@ func CreateChunkedUpload(
 					Type:         fileref.FILE,
 					AllocationID: su.allocationObj.ID,
 				},
+				CustomMeta: encodeCustomMeta(su.fileMeta.CustomMeta),
 			},
 		}
 	}
 
-	cReader, err := createChunkReader(su.fileReader, fileMeta.ActualSize, int64(su.chunkSize), su.allocationObj.DataShards, su.encryptOnUpload, su.uploadMask, su.fileErasureEncoder, su.fileEncscheme, su.fileHasher)
+	cReader, err := createChunkReader(su.fileReader, su.fileMeta.ActualSize, int64(su.chunkSize), su.allocationObj.DataShards, su.encryptOnUpload, su.uploadMask, su.fileErasureEncoder, su.fileEncscheme, su.fileHasher)
 
 	if err != nil {
 		return nil, err
@@ -288,6 +320,28 @@ type ChunkedUpload struct {
 
 	thumbnailBytes         []byte
 	thumbailErasureEncoder reedsolomon.Encoder
+	// thumbnailMaxSize is the size of the thumbnail generated for images, 0
+	// for none
+	thumbnailMaxSize int
+	// extractMetadata adds the size and EXIF tags of images to the custom meta
+	extractMetadata bool
+	// compression is the algorithm the file is compressed with, none if empty
+	compression      string
+	compressionLevel int
+	// uncompressedSize and uncompressedRead are the size of the file before
+	// compression and the bytes of it compressed so far, reported to the
+	// status callback
+	uncompressedSize int64
+	uncompressedRead *countingReader
+	// deduplicate looks for the content in the allocation before uploading
+	// it, contentHash is the hash of the content, compressedContentHash of
+	// the content compressed
+	deduplicate           bool
+	contentHash           string
+	compressedContentHash string
+	// deltaChunks are the indexes of the chunks a delta update sends, nil
+	// if it's not one
+	deltaChunks map[int]bool
 
 	chunkReader ChunkedUploadChunkReader
 	formBuilder ChunkedUploadFormBuilder
@@ -298,6 +352,12 @@ type ChunkedUpload struct {
 	chunkSize int64
 	// chunkNumber the number of chunks in a http upload request. 1 is default value
 	chunkNumber int
+	// maxUploadWindow the maximum number of upload requests in flight per blobber
+	maxUploadWindow int
+	// windows the upload windows of the blobbers, by position
+	windows []*uploadWindow
+	// readAheadSize the number of upload requests read ahead of the ones sent
+	readAheadSize int
 
 	// shardUploadedSize how much bytes a shard has. it is original size
 	shardUploadedSize int64
@@ -314,6 +374,10 @@ type ChunkedUpload struct {
 	// isRepair identifies if upload is repair operation
 	isRepair bool
 
+	// preflightCheck inspects the head of the file before upload starts
+	preflightCheck    UploadPreflightFunc
+	preflightHeadSize int
+
 	opCode        int
 	uploadTimeOut time.Duration
 	commitTimeOut time.Duration
@@ -403,34 +467,88 @@ func (su *ChunkedUpload) createEncscheme() encryption.EncryptionScheme {
 
 // Start start/resume upload
 func (su *ChunkedUpload) Start() error {
+	err := su.start()
+	recordError("upload", err)
+	return err
+}
+
+func (su *ChunkedUpload) start() error {
+
+	if su.contentHash != "" && !su.isRepair {
+		if done, err := su.runDeduplication(); done {
+			if err != nil && su.statusCallback != nil {
+				su.statusCallback.Error(su.allocationObj.ID, su.fileMeta.Path, su.opCode, err)
+			}
+			return err
+		}
+	}
 
 	if su.statusCallback != nil {
-		su.statusCallback.Started(su.allocationObj.ID, su.fileMeta.RemotePath, su.opCode, int(su.fileMeta.ActualSize)+int(su.fileMeta.ActualThumbnailSize))
+		total, _ := su.statusSize()
+		su.statusCallback.Started(su.allocationObj.ID, su.fileMeta.RemotePath, su.opCode, total+int(su.fileMeta.ActualThumbnailSize))
 	}
 
-	for {
+	fail := func(err error) error {
+		if su.statusCallback != nil {
+			su.statusCallback.Error(su.allocationObj.ID, su.fileMeta.Path, su.opCode, err)
+		}
+		return err
+	}
+
+	next := func() (*batchChunksData, error) {
+		return su.readChunks(su.chunkNumber)
+	}
+	if su.readAheadSize > 0 {
+		done := make(chan struct{})
+		defer close(done)
+
+		batches := su.readAhead(su.readAheadSize, done)
+		next = func() (*batchChunksData, error) {
+			r := <-batches
+			return r.chunks, r.err
+		}
+	}
 
-		chunks, err := su.readChunks(su.chunkNumber)
+	su.windows = make([]*uploadWindow, len(su.blobbers))
+	for pos := range su.windows {
+		su.windows[pos] = newUploadWindow(su.maxUploadWindow)
+	}
+	var (
+		inflight []*uploadBatch
+		last     *batchChunksData
+	)
+	defer func() {
+		for _, b := range inflight {
+			b.cancel()
+			b.wg.Wait()
+		}
+	}()
+
+	for {
 
-		// chunk, err := su.chunkReader.Next()
+		chunks, err := next()
 		if err != nil {
-			if su.statusCallback != nil {
-				su.statusCallback.Error(su.allocationObj.ID, su.fileMeta.Path, su.opCode, err)
-			}
-			return err
+			return fail(err)
 		}
 		//logger.Logger.Debug("Read chunk #", chunk.Index)
 
+		// the final chunk is sent once the blobbers took all the others
+		if chunks.isFinal && len(inflight) > 0 {
+			err = flushUploads(inflight)
+			inflight = nil
+			if err != nil {
+				return fail(err)
+			}
+			su.checkpoint(last)
+		}
+
 		su.shardUploadedSize += chunks.totalFragmentSize
 		su.progress.UploadLength += chunks.totalReadSize
 
 		if chunks.isFinal {
 			su.fileMeta.ActualHash, err = su.fileHasher.GetFileHash()
 			if err != nil {
-				if su.statusCallback != nil {
-					su.statusCallback.Error(su.allocationObj.ID, su.fileMeta.Path, su.opCode, err)
-				}
-				return err
+				return fail(err)
 			}
 
 			if su.fileMeta.ActualSize == 0 {
@@ -440,25 +558,31 @@ func (su *ChunkedUpload) Start() error {
 
 		//chunk has not be uploaded yet
 		if chunks.chunkEndIndex > su.progress.ChunkIndex {
-
-			err = su.processUpload(chunks.chunkStartIndex, chunks.chunkEndIndex, chunks.fileShards, chunks.thumbnailShards, chunks.isFinal, chunks.totalReadSize)
+			b, err := su.processUpload(chunks.chunkStartIndex, chunks.chunkEndIndex, chunks.fileShards, chunks.thumbnailShards, chunks.isFinal, chunks.totalReadSize)
 			if err != nil {
-				if su.statusCallback != nil {
-					su.statusCallback.Error(su.allocationObj.ID, su.fileMeta.Path, su.opCode, err)
-				}
-				return err
+				return fail(err)
 			}
+			inflight = append(inflight, b)
+		}
+		last = chunks
+
+		// the windows of the blobbers hold the requests back, the progress
+		// is saved once the most requests a window allows are over
+		if len(inflight) > 0 && len(inflight) < su.maxUploadWindow && !chunks.isFinal {
+			continue
 		}
 
-		// last chunk might 0 with io.EOF
-		// https://stackoverflow.com/questions/41208359/how-to-test-eof-on-io-reader-in-go
-		if chunks.totalReadSize > 0 {
-			su.progress.ChunkIndex = chunks.chunkEndIndex
-			su.saveProgress()
+		err = flushUploads(inflight)
+		inflight = nil
+		if err != nil {
+			return fail(err)
+		}
+		su.checkpoint(chunks)
 
-			if su.statusCallback != nil {
-				su.statusCallback.InProgress(su.allocationObj.ID, su.fileMeta.RemotePath, su.opCode, int(su.progress.UploadLength), nil)
-			}
+		// the progress is saved, the upload resumes from here once the sdk
+		// is started again
+		if !chunks.isFinal && isShuttingDown() {
+			return fail(ErrShutdown)
 		}
 
 		if chunks.isFinal {
@@ -544,24 +668,51 @@ func (su *ChunkedUpload) readChunks(num int) (*batchChunksData, error) {
 	return data, nil
 }
 
-// processUpload process upload fragment to its blobber
+// checkpoint saves the progress once the blobbers took the chunks up to chunks
+func (su *ChunkedUpload) checkpoint(chunks *batchChunksData) {
+	// last chunk might 0 with io.EOF
+	// https://stackoverflow.com/questions/41208359/how-to-test-eof-on-io-reader-in-go
+	if chunks.totalReadSize == 0 {
+		return
+	}
+
+	su.progress.ChunkIndex = chunks.chunkEndIndex
+	su.saveProgress()
+
+	if su.statusCallback != nil {
+		_, uploaded := su.statusSize()
+		su.statusCallback.InProgress(su.allocationObj.ID, su.fileMeta.RemotePath, su.opCode, uploaded, nil)
+	}
+}
+
+// processUpload sends upload fragment to its blobber, the responses are waited with uploadBatch.wait
 func (su *ChunkedUpload) processUpload(chunkStartIndex, chunkEndIndex int,
 	fileShards []blobberShards, thumbnailShards blobberShards,
-	isFinal bool, uploadLength int64) error {
+	isFinal bool, uploadLength int64) (*uploadBatch, error) {
 
-	su.consensus.Reset()
-
-	ctx, cancel := context.WithCancel(context.TODO())
-	defer cancel()
+	ctx, cancel := context.WithCancel(su.ctx)
+	batch := &uploadBatch{
+		consensus: &Consensus{
+			consensusThresh: su.consensus.consensusThresh,
+			fullconsensus:   su.consensus.fullconsensus,
+		},
+		throttled: make([]int32, len(su.blobbers)),
+		cancel:    cancel,
+	}
 
 	encryptedKey := ""
 	if su.fileEncscheme != nil {
 		encryptedKey = su.fileEncscheme.GetEncryptedKey()
 	}
 
-	wg := &sync.WaitGroup{}
+	skipped := su.isDeltaSkipped(chunkStartIndex, chunkEndIndex, isFinal)
+
+	su.maskMu.Lock()
+	uploadMask := su.uploadMask
+	su.maskMu.Unlock()
+
 	var pos uint64
-	for i := su.uploadMask; !i.Equals64(0); i = i.And(zboxutil.NewUint128(1).Lsh(pos).Not()) {
+	for i := uploadMask; !i.Equals64(0); i = i.And(zboxutil.NewUint128(1).Lsh(pos).Not()) {
 		pos = uint64(i.TrailingZeros())
 
 		blobber := su.blobbers[pos]
@@ -580,27 +731,33 @@ func (su *ChunkedUpload) processUpload(chunkStartIndex, chunkEndIndex int,
 		)
 
 		if err != nil {
-			return err
+			batch.wait() //nolint: errcheck
+			return nil, err
+		}
+		if skipped {
+			// the chunks are hashed, the blobber has them already
+			continue
 		}
 
-		wg.Add(1)
+		window := su.windows[pos]
+		if err := window.acquire(ctx); err != nil {
+			batch.wait() //nolint: errcheck
+			return nil, err
+		}
+
+		batch.sent++
+		batch.wg.Add(1)
 		go func(b *ChunkedUploadBlobber, body *bytes.Buffer, formData ChunkedUploadFormMetadata, pos uint64) {
-			defer wg.Done()
-			err = b.sendUploadRequest(ctx, su, chunkEndIndex, isFinal, encryptedKey, body, formData, pos)
+			defer batch.wg.Done()
+			err := b.sendUploadRequest(ctx, su, batch, chunkEndIndex, isFinal, encryptedKey, body, formData, pos)
 			if err != nil {
 				logger.Logger.Error("error during sendUploadRequest", err)
 			}
+			window.release(err == nil && !batch.isThrottled(pos))
 		}(blobber, body, formData, pos)
 	}
 
-	wg.Wait()
-
-	if !su.consensus.isConsensusOk() {
-		return thrown.New("consensus_not_met", fmt.Sprintf("Upload failed. Required consensus atleast %d, got %d",
-			su.consensus.consensusThresh, su.consensus.getConsensus()))
-	}
-
-	return nil
+	return batch, nil
 }
 
 // processCommit commit shard upload on its blobber
@@ -626,7 +783,7 @@ func (su *ChunkedUpload) processCommit() error {
 		wg.Add(1)
 		go func(b *ChunkedUploadBlobber, pos uint64) {
 			defer wg.Done()
-			err := b.processCommit(context.TODO(), su, pos)
+			err := b.processCommit(su.ctx, su, pos)
 			if err != nil {
 				b.commitResult = ErrorCommitResult(err.Error())
 			}
@@ -635,12 +792,12 @@ func (su *ChunkedUpload) processCommit() error {
 	}
 
 	wg.Wait()
+	InvalidateMetadataCache(su.allocationObj.ID)
 
 	if !su.consensus.isConsensusOk() {
 		consensus := su.consensus.getConsensus()
-		err := thrown.New("consensus_not_met",
-			fmt.Sprintf("Upload commit failed. Required consensus atleast %d, got %d",
-				su.consensus.consensusThresh, consensus))
+		err := &constants.ErrConsensusNotMet{Op: "Upload commit",
+			Got: consensus, Need: su.consensus.consensusThresh}
 
 		if consensus != 0 {
 			logger.Logger.Info("Commit consensus failed, Deleting remote file....")
@@ -653,7 +810,8 @@ func (su *ChunkedUpload) processCommit() error {
 	}
 
 	if su.statusCallback != nil {
-		su.statusCallback.Completed(su.allocationObj.ID, su.fileMeta.RemotePath, su.fileMeta.RemoteName, su.fileMeta.MimeType, int(su.progress.UploadLength), su.opCode)
+		_, uploaded := su.statusSize()
+		su.statusCallback.Completed(su.allocationObj.ID, su.fileMeta.RemotePath, su.fileMeta.RemoteName, su.fileMeta.MimeType, uploaded, su.opCode)
 	}
 
 	return nil
@@ -14,8 +14,8 @@ import (
 	"github.com/0chain/errors"
 	thrown "github.com/0chain/errors"
 	"github.com/0chain/gosdk/constants"
-	"github.com/0chain/gosdk/core/common"
 	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/telemetry"
 	"github.com/0chain/gosdk/zboxcore/allocationchange"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
 	"github.com/0chain/gosdk/zboxcore/client"
@@ -37,13 +37,17 @@ type ChunkedUploadBlobber struct {
 }
 
 func (sb *ChunkedUploadBlobber) sendUploadRequest(
-	ctx context.Context, su *ChunkedUpload,
+	ctx context.Context, su *ChunkedUpload, batch *uploadBatch,
 	chunkIndex int, isFinal bool,
 	encryptedKey string, body *bytes.Buffer,
 	formData ChunkedUploadFormMetadata,
 	pos uint64) (err error) {
 
+	ctx, span := telemetry.StartSpan(ctx, "upload.chunk",
+		telemetry.String(telemetry.AttrNode, sb.blobber.Baseurl),
+		telemetry.Int(telemetry.AttrChunk, chunkIndex))
 	defer func() {
+		telemetry.EndSpan(span, err)
 
 		if err != nil {
 			su.maskMu.Lock()
@@ -63,7 +67,7 @@ func (sb *ChunkedUploadBlobber) sendUploadRequest(
 
 			sb.fileRef.EncryptedKey = encryptedKey
 			sb.fileRef.CalculateHash()
-			su.consensus.Done()
+			batch.consensus.Done()
 		}
 
 		return nil
@@ -75,15 +79,28 @@ func (sb *ChunkedUploadBlobber) sendUploadRequest(
 	}
 
 	req.Header.Add("Content-Type", formData.ContentType)
+	if su.deltaChunks != nil {
+		req.Header.Set(DeltaUpdateHeader, "true")
+	}
+	// the retries of the chunk are applied once by the blobber
+	key := zboxutil.NewIdempotencyKey()
+	zboxutil.SetIdempotencyKey(req, key)
 
 	var (
 		resp             *http.Response
 		shouldContinue   bool
+		latestErr        error
 		latestRespMsg    string
 		latestStatusCode int
 	)
 
 	for i := 0; i < 3; i++ {
+		if i > 0 {
+			telemetry.AddRetry(ctx, sb.blobber.Baseurl, "upload.chunk")
+			if err = zboxutil.RewindBody(req); err != nil {
+				return
+			}
+		}
 		err, shouldContinue = func() (err error, shouldContinue bool) {
 			reqCtx, ctxCncl := context.WithTimeout(ctx, su.uploadTimeOut)
 			resp, err = su.client.Do(req.WithContext(reqCtx))
@@ -91,7 +108,12 @@ func (sb *ChunkedUploadBlobber) sendUploadRequest(
 
 			if err != nil {
 				logger.Logger.Error("Upload : ", err)
-				return
+				if ctx.Err() != nil {
+					return
+				}
+				// the blobber might have got the chunk, the retry tells
+				latestErr = zboxutil.Unreachable(req, err)
+				return nil, true
 			}
 
 			if resp.Body != nil {
@@ -109,15 +131,24 @@ func (sb *ChunkedUploadBlobber) sendUploadRequest(
 			latestRespMsg = string(respbody)
 			latestStatusCode = resp.StatusCode
 
+			if zboxutil.IsAlreadyApplied(resp, key) {
+				logger.Logger.Info(sb.blobber.Baseurl, " already got the chunk")
+				return
+			}
+
 			if resp.StatusCode == http.StatusTooManyRequests {
 				logger.Logger.Error("Got too many request error")
+				batch.throttle(pos)
 				var r int
 				r, err = zboxutil.GetRateLimitValue(resp)
 				if err != nil {
 					logger.Logger.Error(err)
 					return
 				}
-				time.Sleep(time.Duration(r) * time.Second)
+				uploadBackoff.delay(sb.blobber.ID, time.Duration(r)*time.Second)
+				if err = uploadBackoff.wait(ctx, sb.blobber.ID); err != nil {
+					return
+				}
 				shouldContinue = true
 				return
 			}
@@ -151,7 +182,7 @@ func (sb *ChunkedUploadBlobber) sendUploadRequest(
 			continue
 		}
 
-		su.consensus.Done()
+		batch.consensus.Done()
 
 		if formData.ThumbnailBytesLen > 0 {
 
@@ -179,6 +210,9 @@ func (sb *ChunkedUploadBlobber) sendUploadRequest(
 		return
 	}
 
+	if latestErr != nil {
+		return latestErr
+	}
 	return thrown.New("upload_error",
 		fmt.Sprintf("latest status code: %d, latest response message: %s",
 			latestStatusCode, latestRespMsg))
@@ -200,74 +234,63 @@ func (sb *ChunkedUploadBlobber) processCommit(ctx context.Context, su *ChunkedUp
 		return err
 	}
 
-	wm := &marker.WriteMarker{}
-	timestamp := int64(common.Now())
-	wm.AllocationRoot = encryption.Hash(rootRef.Hash + ":" + strconv.FormatInt(timestamp, 10))
-	if latestWM != nil {
-		wm.PreviousAllocationRoot = latestWM.AllocationRoot
-	} else {
-		wm.PreviousAllocationRoot = ""
-	}
-
-	wm.AllocationID = su.allocationObj.ID
-	wm.Size = size
-	wm.BlobberID = sb.blobber.ID
-
-	wm.Timestamp = timestamp
-	wm.ClientID = client.GetClientID()
-
-	wm.LookupHash = fileref.GetReferenceLookup(su.allocationObj.ID, sb.fileRef.Path)
-	wm.Name = sb.fileRef.Name
-	wm.ContentHash = sb.fileRef.ContentHash
-
-	err = wm.Sign()
-	if err != nil {
-		logger.Logger.Error("Signing writemarker failed: ", err)
-		return err
-	}
-	body := new(bytes.Buffer)
-	formWriter := multipart.NewWriter(body)
-	wmData, err := json.Marshal(wm)
-	if err != nil {
-		logger.Logger.Error("Creating writemarker failed: ", err)
-		return err
-	}
-	formWriter.WriteField("connection_id", su.progress.ConnectionID)
-	formWriter.WriteField("write_marker", string(wmData))
-
-	formWriter.Close()
-
-	req, err := zboxutil.NewCommitRequest(sb.blobber.Baseurl, su.allocationObj.Tx, body)
+	// the retries of the commit are applied once by the blobber
+	key := zboxutil.NewIdempotencyKey()
+	req, err := sb.newCommitRequest(su, rootRef, latestWM, size, key)
 	if err != nil {
-		logger.Logger.Error("Error creating commit req: ", err)
 		return err
 	}
-	req.Header.Add("Content-Type", formWriter.FormDataContentType())
 
 	logger.Logger.Info("Committing to blobber. " + sb.blobber.Baseurl)
 
 	var (
 		resp           *http.Response
 		shouldContinue bool
+		resigned       bool
+		latestErr      error
 	)
 
+	if err = defaultCommitPacer.wait(ctx, sb.blobber.ID); err != nil {
+		return
+	}
+
 	for retries := 0; retries < 3; retries++ {
+		if retries > 0 {
+			if err = zboxutil.RewindBody(req); err != nil {
+				return
+			}
+		}
 		err, shouldContinue = func() (err error, shouldContinue bool) {
-			reqCtx, ctxCncl := context.WithTimeout(ctx, su.commitTimeOut)
+			reqCtx, ctxCncl := commitContext(ctx, su.commitTimeOut)
+			sent := time.Now()
 			resp, err = su.client.Do(req.WithContext(reqCtx))
 			defer ctxCncl()
 
 			if err != nil {
 				logger.Logger.Error("Commit: ", err)
-				return
+				if ctx.Err() != nil {
+					return
+				}
+				// the blobber might have committed, the retry tells
+				latestErr = err
+				return nil, true
 			}
 
 			if resp.Body != nil {
 				defer resp.Body.Close()
 			}
 
+			zboxutil.DefaultClockSkew.Observe(sb.blobber.Baseurl, resp, sent, time.Now())
+			if !resigned && zboxutil.IsTimestampRejected(resp) {
+				// signed again with the time of the blobber
+				logger.Logger.Info(sb.blobber.Baseurl, " rejected the write marker timestamp, signing again")
+				resigned = true
+				req, err = sb.newCommitRequest(su, rootRef, latestWM, size, key)
+				return err, err == nil
+			}
+
 			var respBody []byte
-			if resp.StatusCode == http.StatusOK {
+			if resp.StatusCode == http.StatusOK || zboxutil.IsAlreadyApplied(resp, key) {
 				logger.Logger.Info(sb.blobber.Baseurl, su.progress.ConnectionID, " committed")
 				su.consensus.Done()
 				return
@@ -284,7 +307,10 @@ func (sb *ChunkedUploadBlobber) processCommit(ctx context.Context, su *ChunkedUp
 					return
 				}
 
-				time.Sleep(time.Duration(r) * time.Second)
+				defaultCommitPacer.delay(sb.blobber.ID, time.Duration(r)*time.Second)
+				if err = defaultCommitPacer.wait(ctx, sb.blobber.ID); err != nil {
+					return
+				}
 				shouldContinue = true
 				return
 			}
@@ -309,9 +335,64 @@ func (sb *ChunkedUploadBlobber) processCommit(ctx context.Context, su *ChunkedUp
 		return
 
 	}
+	if latestErr != nil {
+		return thrown.New("commit_error", latestErr.Error())
+	}
 	return thrown.New("commit_error", fmt.Sprintf("Commit failed with response status %d", resp.StatusCode))
 }
 
+// newCommitRequest creates the commit request of the write marker signed
+// with the time of the blobber, see zboxutil.ServerNow.
+func (sb *ChunkedUploadBlobber) newCommitRequest(su *ChunkedUpload, rootRef *fileref.Ref,
+	latestWM *marker.WriteMarker, size int64, key string) (*http.Request, error) {
+
+	wm := &marker.WriteMarker{}
+	timestamp := int64(zboxutil.ServerNow(sb.blobber.Baseurl))
+	wm.AllocationRoot = encryption.Hash(rootRef.Hash + ":" + strconv.FormatInt(timestamp, 10))
+	if latestWM != nil {
+		wm.PreviousAllocationRoot = latestWM.AllocationRoot
+	} else {
+		wm.PreviousAllocationRoot = ""
+	}
+
+	wm.AllocationID = su.allocationObj.ID
+	wm.Size = size
+	wm.BlobberID = sb.blobber.ID
+
+	wm.Timestamp = timestamp
+	wm.ClientID = client.GetClientID()
+
+	wm.LookupHash = fileref.GetReferenceLookup(su.allocationObj.ID, sb.fileRef.Path)
+	wm.Name = sb.fileRef.Name
+	wm.ContentHash = sb.fileRef.ContentHash
+
+	err := wm.Sign()
+	if err != nil {
+		logger.Logger.Error("Signing writemarker failed: ", err)
+		return nil, err
+	}
+	body := new(bytes.Buffer)
+	formWriter := multipart.NewWriter(body)
+	wmData, err := json.Marshal(wm)
+	if err != nil {
+		logger.Logger.Error("Creating writemarker failed: ", err)
+		return nil, err
+	}
+	formWriter.WriteField("connection_id", su.progress.ConnectionID)
+	formWriter.WriteField("write_marker", string(wmData))
+
+	formWriter.Close()
+
+	req, err := zboxutil.NewCommitRequest(sb.blobber.Baseurl, su.allocationObj.Tx, body)
+	if err != nil {
+		logger.Logger.Error("Error creating commit req: ", err)
+		return nil, err
+	}
+	req.Header.Add("Content-Type", formWriter.FormDataContentType())
+	zboxutil.SetIdempotencyKey(req, key)
+	return req, nil
+}
+
 func (sb *ChunkedUploadBlobber) processWriteMarker(
 	ctx context.Context, su *ChunkedUpload) (*fileref.Ref, *marker.WriteMarker, int64, error) {
 
@@ -328,7 +409,9 @@ func (sb *ChunkedUploadBlobber) processWriteMarker(
 		return nil, nil, 0, err
 	}
 
-	resp, err := su.client.Do(req)
+	reqCtx, ctxCncl := commitContext(ctx, su.commitTimeOut)
+	defer ctxCncl()
+	resp, err := su.client.Do(req.WithContext(reqCtx))
 
 	if err != nil {
 		logger.Logger.Error("Ref path error:", err)
@@ -139,12 +139,21 @@ func (r *chunkedUploadChunkReader) Next() (*ChunkData, error) {
 		FragmentSize: 0,
 	}
 
-	chunkBytes := make([]byte, r.chunkDataSizePerRead, r.chunkDataSizePerRead*2)
-	readLen, err := r.fileReader.Read(chunkBytes)
+	// room for the parity shards too, so Split encodes the stripe in place
+	capacity := r.chunkDataSizePerRead * 2
+	if ext, ok := r.erasureEncoder.(reedsolomon.Extensions); ok {
+		shardSize := (r.chunkDataSizePerRead + int64(r.dataShards) - 1) / int64(r.dataShards)
+		capacity = shardSize * int64(ext.TotalShards())
+	}
+	chunkBytes := make([]byte, r.chunkDataSizePerRead, capacity)
+	// read a full stripe, a single Read may return less before the end. The
+	// file hash is computed as it is read.
+	fileHash := &fileHashWriter{hasher: r.hasher, chunkIndex: chunk.Index}
+	readLen, err := io.ReadFull(io.TeeReader(r.fileReader, fileHash), chunkBytes)
 
 	if err != nil {
 
-		if !errors.Is(err, io.EOF) {
+		if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
 			return nil, err
 		}
 
@@ -172,11 +181,6 @@ func (r *chunkedUploadChunkReader) Next() (*ChunkData, error) {
 		}
 	}
 
-	err = r.hasher.WriteToFile(chunkBytes, chunk.Index)
-	if err != nil {
-		return chunk, err
-	}
-
 	fragments, err := r.erasureEncoder.Split(chunkBytes)
 	if err != nil {
 		return nil, err
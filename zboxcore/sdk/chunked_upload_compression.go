@@ -0,0 +1,220 @@
+package sdk
+
+import (
+	"compress/gzip"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/0chain/errors"
+	"github.com/klauspost/compress/zstd"
+)
+
+// The compression algorithms of WithCompression.
+const (
+	CompressionZstd = "zstd"
+	CompressionGzip = "gzip"
+)
+
+// The custom meta keys of compressed files. MetaCompression is the algorithm
+// the file is stored with, downloads decompress the files that have it.
+const (
+	MetaCompression      = "compression"
+	MetaUncompressedSize = "uncompressed_size"
+)
+
+// ErrUnsupportedCompression is returned for compression algorithms other
+// than CompressionZstd and CompressionGzip.
+var ErrUnsupportedCompression = errors.New("unsupported_compression", "compression must be zstd or gzip")
+
+// compressedMimeTypes are the mime types, or their prefix when they end with
+// "/", that compress too little to be worth it.
+var compressedMimeTypes = []string{
+	"image/jpeg", "image/png", "image/gif", "image/webp", "image/heic", "image/avif",
+	"video/", "audio/",
+	"application/zip", "application/gzip", "application/x-gzip", "application/zstd",
+	"application/x-bzip2", "application/x-xz", "application/x-7z-compressed",
+	"application/x-rar-compressed", "application/vnd.rar", "application/x-lzip",
+}
+
+// WithCompression compresses the file with algorithm, CompressionZstd or
+// CompressionGzip, at level before it's uploaded. Level 0 is the default
+// level of the algorithm, zstd levels go from 1 to 22 and gzip levels from 1
+// to 9. The algorithm is stored in the custom meta of the file, so full
+// downloads decompress it; the blocks of ranged downloads are returned as
+// stored. Files of already compressed mime types are uploaded as they are.
+func WithCompression(algorithm string, level int) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		su.compression = algorithm
+		su.compressionLevel = level
+	}
+}
+
+func isSupportedCompression(algorithm string) bool {
+	return algorithm == CompressionZstd || algorithm == CompressionGzip
+}
+
+func isCompressedMimeType(mimeType string) bool {
+	mimeType = strings.TrimSpace(strings.SplitN(mimeType, ";", 2)[0])
+	for _, t := range compressedMimeTypes {
+		if mimeType == t || (strings.HasSuffix(t, "/") && strings.HasPrefix(mimeType, t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// setupCompression replaces the file reader with one compressing the file,
+// unless the file is compressed already. The size of the file is then
+// unknown until it's read, the status callback is given the size before
+// compression, see statusSize.
+func (su *ChunkedUpload) setupCompression() error {
+	if su.compression == "" {
+		return nil
+	}
+	if !isSupportedCompression(su.compression) {
+		return ErrUnsupportedCompression
+	}
+	if isCompressedMimeType(su.fileMeta.MimeType) {
+		return nil
+	}
+
+	compressor, err := newCompressWriter(io.Discard, su.compression, su.compressionLevel)
+	if err != nil {
+		return err
+	}
+	compressor.Close()
+
+	meta := make(map[string]string, len(su.fileMeta.CustomMeta)+2)
+	for k, v := range su.fileMeta.CustomMeta {
+		meta[k] = v
+	}
+	meta[MetaCompression] = su.compression
+	if su.fileMeta.ActualSize > 0 {
+		meta[MetaUncompressedSize] = strconv.FormatInt(su.fileMeta.ActualSize, 10)
+	}
+	if !isValidCustomMeta(meta) {
+		return ErrInvalidCustomMeta
+	}
+	su.fileMeta.CustomMeta = meta
+
+	su.uncompressedSize = su.fileMeta.ActualSize
+	su.uncompressedRead = &countingReader{r: su.fileReader}
+	su.fileReader = compressReader(su.uncompressedRead, su.compression, su.compressionLevel)
+	su.fileMeta.ActualSize = 0
+	return nil
+}
+
+// statusSize returns the size of the file and the bytes of it uploaded so
+// far for the status callback, before compression for compressed uploads.
+func (su *ChunkedUpload) statusSize() (total, uploaded int) {
+	if su.uncompressedRead != nil {
+		return int(su.uncompressedSize), int(atomic.LoadInt64(&su.uncompressedRead.n))
+	}
+	return int(su.fileMeta.ActualSize), int(su.progress.UploadLength)
+}
+
+// countingReader counts the bytes read from r, by the goroutine of
+// compressReader.
+type countingReader struct {
+	r io.Reader
+	n int64 // atomic
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	atomic.AddInt64(&cr.n, int64(n))
+	return n, err
+}
+
+func newCompressWriter(w io.Writer, algorithm string, level int) (io.WriteCloser, error) {
+	switch algorithm {
+	case CompressionZstd:
+		opts := []zstd.EOption{zstd.WithEncoderConcurrency(1)}
+		if level != 0 {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+		}
+		return zstd.NewWriter(w, opts...)
+	case CompressionGzip:
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		zw, err := gzip.NewWriterLevel(w, level)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid gzip level")
+		}
+		return zw, nil
+	}
+	return nil, ErrUnsupportedCompression
+}
+
+// compressReader returns a reader of the content of r compressed.
+func compressReader(r io.Reader, algorithm string, level int) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		compressor, err := newCompressWriter(pw, algorithm, level)
+		if err == nil {
+			_, err = io.Copy(compressor, r)
+			if closeErr := compressor.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// decompressWriter decompresses what is written to it into w.
+type decompressWriter struct {
+	pw   *io.PipeWriter
+	done chan error
+
+	closeOnce sync.Once
+	err       error
+}
+
+func newDecompressWriter(w io.Writer, algorithm string) (*decompressWriter, error) {
+	if !isSupportedCompression(algorithm) {
+		return nil, ErrUnsupportedCompression
+	}
+
+	pr, pw := io.Pipe()
+	dw := &decompressWriter{pw: pw, done: make(chan error, 1)}
+	go func() {
+		var err error
+		switch algorithm {
+		case CompressionZstd:
+			var d *zstd.Decoder
+			if d, err = zstd.NewReader(pr, zstd.WithDecoderConcurrency(1)); err == nil {
+				_, err = io.Copy(w, d)
+				d.Close()
+			}
+		case CompressionGzip:
+			var d *gzip.Reader
+			if d, err = gzip.NewReader(pr); err == nil {
+				_, err = io.Copy(w, d)
+			}
+		}
+		// unblock the writes left if the content can't be decompressed
+		pr.CloseWithError(err)
+		dw.done <- err
+	}()
+	return dw, nil
+}
+
+func (dw *decompressWriter) Write(p []byte) (int, error) {
+	return dw.pw.Write(p)
+}
+
+// Close waits for the content written to be decompressed.
+func (dw *decompressWriter) Close() error {
+	dw.closeOnce.Do(func() {
+		dw.pw.Close()
+		if err := <-dw.done; err != nil {
+			dw.err = errors.Wrap(err, "decompression failed")
+		}
+	})
+	return dw.err
+}
@@ -0,0 +1,157 @@
+package sdk
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/0chain/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+	content := []byte(strings.Repeat("compressible content ", 10000))
+
+	for _, tc := range []struct {
+		name      string
+		algorithm string
+		level     int
+	}{
+		{name: "Test_Zstd", algorithm: CompressionZstd},
+		{name: "Test_Zstd_Level", algorithm: CompressionZstd, level: 19},
+		{name: "Test_Gzip", algorithm: CompressionGzip},
+		{name: "Test_Gzip_Level", algorithm: CompressionGzip, level: 9},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			compressed, err := io.ReadAll(compressReader(bytes.NewReader(content), tc.algorithm, tc.level))
+			require.NoError(t, err)
+			require.Less(t, len(compressed), len(content))
+
+			var out bytes.Buffer
+			dw, err := newDecompressWriter(&out, tc.algorithm)
+			require.NoError(t, err)
+			// written in blocks, as downloaded
+			for len(compressed) > 0 {
+				n := 1000
+				if n > len(compressed) {
+					n = len(compressed)
+				}
+				_, err = dw.Write(compressed[:n])
+				require.NoError(t, err)
+				compressed = compressed[n:]
+			}
+			require.NoError(t, dw.Close())
+			require.NoError(t, dw.Close(), "closing twice is fine")
+			require.True(t, bytes.Equal(content, out.Bytes()))
+		})
+	}
+}
+
+func TestDecompressWriterCorrupted(t *testing.T) {
+	dw, err := newDecompressWriter(io.Discard, CompressionGzip)
+	require.NoError(t, err)
+	_, _ = dw.Write([]byte("not gzip"))
+	require.Error(t, dw.Close())
+
+	_, err = newDecompressWriter(io.Discard, "lz4")
+	require.True(t, errors.Is(err, ErrUnsupportedCompression))
+}
+
+func TestChunkedUploadSetupCompression(t *testing.T) {
+	content := []byte(strings.Repeat("compressible content ", 1000))
+
+	for _, tc := range []struct {
+		name       string
+		meta       FileMeta
+		opts       []ChunkedUploadOption
+		wantMeta   map[string]string
+		compressed bool
+		wantErr    error
+	}{
+		{
+			name:       "Test_Compressed",
+			meta:       FileMeta{MimeType: "text/plain", ActualSize: int64(len(content))},
+			opts:       []ChunkedUploadOption{WithCompression(CompressionZstd, 0)},
+			wantMeta:   map[string]string{MetaCompression: CompressionZstd, MetaUncompressedSize: "21000"},
+			compressed: true,
+		},
+		{
+			name:       "Test_Custom_Meta_Kept",
+			meta:       FileMeta{MimeType: "text/plain", CustomMeta: map[string]string{"album": "holidays"}},
+			opts:       []ChunkedUploadOption{WithCompression(CompressionGzip, 5)},
+			wantMeta:   map[string]string{"album": "holidays", MetaCompression: CompressionGzip},
+			compressed: true,
+		},
+		{
+			name: "Test_Already_Compressed",
+			meta: FileMeta{MimeType: "video/mp4", ActualSize: int64(len(content))},
+			opts: []ChunkedUploadOption{WithCompression(CompressionZstd, 0)},
+		},
+		{
+			name: "Test_Disabled",
+			meta: FileMeta{MimeType: "text/plain", ActualSize: int64(len(content))},
+		},
+		{
+			name:    "Test_Unsupported",
+			meta:    FileMeta{MimeType: "text/plain"},
+			opts:    []ChunkedUploadOption{WithCompression("lz4", 0)},
+			wantErr: ErrUnsupportedCompression,
+		},
+		{
+			name:    "Test_Invalid_Level",
+			meta:    FileMeta{MimeType: "text/plain"},
+			opts:    []ChunkedUploadOption{WithCompression(CompressionGzip, 42)},
+			wantErr: errors.New("", "invalid gzip level"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			su := &ChunkedUpload{
+				allocationObj: &Allocation{DataShards: 2, ParityShards: 1},
+				fileMeta:      tc.meta,
+				fileReader:    bytes.NewReader(content),
+			}
+			for _, opt := range tc.opts {
+				opt(su)
+			}
+
+			err := su.setupCompression()
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantMeta, su.fileMeta.CustomMeta)
+
+			uploaded, err := io.ReadAll(su.fileReader)
+			require.NoError(t, err)
+			if !tc.compressed {
+				require.Equal(t, int64(len(content)), su.fileMeta.ActualSize)
+				require.Equal(t, content, uploaded)
+				return
+			}
+			require.Zero(t, su.fileMeta.ActualSize, "the compressed size is known once read")
+			total, read := su.statusSize()
+			require.Equal(t, int(tc.meta.ActualSize), total, "the status is in bytes before compression")
+			require.Equal(t, len(content), read)
+
+			var out bytes.Buffer
+			dw, err := newDecompressWriter(&out, su.compression)
+			require.NoError(t, err)
+			_, err = dw.Write(uploaded)
+			require.NoError(t, err)
+			require.NoError(t, dw.Close())
+			require.Equal(t, content, out.Bytes())
+		})
+	}
+}
+
+func TestIsCompressedMimeType(t *testing.T) {
+	require.True(t, isCompressedMimeType("image/jpeg"))
+	require.True(t, isCompressedMimeType("video/mp4"))
+	require.True(t, isCompressedMimeType("application/zip"))
+	require.False(t, isCompressedMimeType("text/plain; charset=utf-8"))
+	require.False(t, isCompressedMimeType("image/bmp"))
+	require.False(t, isCompressedMimeType(""))
+}
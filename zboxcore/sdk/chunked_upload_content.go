@@ -0,0 +1,133 @@
+package sdk
+
+import (
+	"bytes"
+	"image"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/h2non/filetype"
+)
+
+const (
+	// mimeSniffSize is how many leading bytes the mime type is detected from.
+	mimeSniffSize = 512
+
+	// metadataHeadSize is how many leading bytes the metadata is extracted
+	// from. The EXIF segment of a JPEG is at most 64KB and comes first.
+	metadataHeadSize = 128 * 1024
+)
+
+// The custom meta keys of the metadata extracted from images, see
+// WithMetadataExtraction.
+const (
+	MetaImageWidth      = "image_width"
+	MetaImageHeight     = "image_height"
+	MetaExifMake        = "exif_make"
+	MetaExifModel       = "exif_model"
+	MetaExifOrientation = "exif_orientation"
+	MetaExifDateTime    = "exif_datetime"
+)
+
+// WithMimeType sets the mime type of the file instead of detecting it from
+// its first 512 bytes.
+func WithMimeType(mimeType string) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		su.fileMeta.MimeType = mimeType
+	}
+}
+
+// WithMetadataExtraction turn on/off the extraction of the size and the EXIF
+// tags of images to the custom meta of the file, so listings show them. Keys
+// set with WithCustomMeta are kept. It is turn off as default.
+func WithMetadataExtraction(on bool) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		su.extractMetadata = on
+	}
+}
+
+// detectContent detects the mime type of the file when it isn't given and
+// extracts its metadata if asked to, from the head of the file. The head is
+// stitched back in front of the reader so no bytes are lost.
+func (su *ChunkedUpload) detectContent() error {
+	headSize := 0
+	if su.fileMeta.MimeType == "" {
+		headSize = mimeSniffSize
+	}
+	if su.extractMetadata {
+		headSize = metadataHeadSize
+	}
+	if headSize == 0 {
+		return nil
+	}
+
+	head := make([]byte, headSize)
+	n, err := io.ReadFull(su.fileReader, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	head = head[:n]
+	su.fileReader = io.MultiReader(bytes.NewReader(head), su.fileReader)
+
+	if su.fileMeta.MimeType == "" && n > 0 {
+		su.fileMeta.MimeType = detectMimeType(head)
+	}
+	if su.extractMetadata && strings.HasPrefix(su.fileMeta.MimeType, "image/") {
+		su.addExtractedMeta(extractImageMeta(head))
+	}
+	return nil
+}
+
+// addExtractedMeta adds the keys of meta the custom meta doesn't have, as
+// long as it stays within the custom meta limit.
+func (su *ChunkedUpload) addExtractedMeta(meta map[string]string) {
+	if len(meta) == 0 {
+		return
+	}
+	merged := make(map[string]string, len(su.fileMeta.CustomMeta)+len(meta))
+	for k, v := range meta {
+		merged[k] = v
+	}
+	for k, v := range su.fileMeta.CustomMeta {
+		merged[k] = v
+	}
+	if isValidCustomMeta(merged) {
+		su.fileMeta.CustomMeta = merged
+	}
+}
+
+// detectMimeType returns the mime type of the content starting with head.
+func detectMimeType(head []byte) string {
+	if kind, _ := filetype.Match(head); kind != filetype.Unknown {
+		return kind.MIME.Value
+	}
+	return http.DetectContentType(head)
+}
+
+// extractImageMeta returns the size and the EXIF tags of the image starting
+// with head. Whatever can't be read is left out.
+func extractImageMeta(head []byte) map[string]string {
+	meta := make(map[string]string)
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(head)); err == nil {
+		meta[MetaImageWidth] = strconv.Itoa(cfg.Width)
+		meta[MetaImageHeight] = strconv.Itoa(cfg.Height)
+	}
+
+	tags := readJPEGExif(head)
+	for key, tag := range map[string]uint16{
+		MetaExifMake:        exifTagMake,
+		MetaExifModel:       exifTagModel,
+		MetaExifOrientation: exifTagOrientation,
+		MetaExifDateTime:    exifTagDateTimeOriginal,
+	} {
+		if v, ok := tags[tag]; ok && v != "" {
+			meta[key] = v
+		}
+	}
+	if _, ok := meta[MetaExifDateTime]; !ok && tags[exifTagDateTime] != "" {
+		meta[MetaExifDateTime] = tags[exifTagDateTime]
+	}
+	return meta
+}
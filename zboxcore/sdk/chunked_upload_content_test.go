@@ -0,0 +1,156 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// encodeExifJPEG returns a w x h JPEG with an EXIF segment carrying a make,
+// an orientation and, in the EXIF IFD, the original date time.
+func encodeExifJPEG(t *testing.T, w, h int) []byte {
+	var img bytes.Buffer
+	require.NoError(t, jpeg.Encode(&img, image.NewGray(image.Rect(0, 0, w, h)), nil))
+
+	order := binary.BigEndian
+	entry := func(tag, typ uint16, count, value uint32) []byte {
+		b := make([]byte, 12)
+		order.PutUint16(b, tag)
+		order.PutUint16(b[2:], typ)
+		order.PutUint32(b[4:], count)
+		order.PutUint32(b[8:], value)
+		return b
+	}
+
+	const (
+		ifd0    = 8
+		exifIFD = ifd0 + 2 + 3*12 + 4
+		strs    = exifIFD + 2 + 12 + 4
+	)
+	camera := "Canon\x00"
+	date := "2022:10:01 12:00:00\x00"
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = append(tiff, 0, 3)
+	tiff = append(tiff, entry(exifTagMake, exifTypeASCII, uint32(len(camera)), strs)...)
+	tiff = append(tiff, entry(exifTagOrientation, exifTypeShort, 1, 6<<16)...)
+	tiff = append(tiff, entry(exifTagExifIFD, exifTypeLong, 1, exifIFD)...)
+	tiff = append(tiff, 0, 0, 0, 0)
+	tiff = append(tiff, 0, 1)
+	tiff = append(tiff, entry(exifTagDateTimeOriginal, exifTypeASCII, uint32(len(date)), strs+uint32(len(camera)))...)
+	tiff = append(tiff, 0, 0, 0, 0)
+	tiff = append(tiff, camera+date...)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xff, 0xe1, 0, 0}
+	order.PutUint16(app1[2:], uint16(len(segment)+2))
+
+	out := append([]byte{}, img.Bytes()[:2]...)
+	out = append(out, app1...)
+	out = append(out, segment...)
+	return append(out, img.Bytes()[2:]...)
+}
+
+func TestChunkedUploadDetectContent(t *testing.T) {
+	exifJPEG := encodeExifJPEG(t, 64, 32)
+	png := encodePNG(t, 30, 20)
+
+	for _, tc := range []struct {
+		name     string
+		meta     FileMeta
+		content  []byte
+		opts     []ChunkedUploadOption
+		mimeType string
+		wantMeta map[string]string
+	}{
+		{name: "Test_Sniff_PNG", content: png, mimeType: "image/png"},
+		{name: "Test_Sniff_Text", content: []byte("plain text"), mimeType: "text/plain; charset=utf-8"},
+		{name: "Test_Empty", mimeType: ""},
+		{name: "Test_Given_Mime_Type", meta: FileMeta{MimeType: "application/x-custom"}, content: png, mimeType: "application/x-custom"},
+		{name: "Test_Override_Mime_Type", content: png, opts: []ChunkedUploadOption{WithMimeType("image/x-icon")}, mimeType: "image/x-icon"},
+		{
+			name:     "Test_Image_Size",
+			content:  png,
+			opts:     []ChunkedUploadOption{WithMetadataExtraction(true)},
+			mimeType: "image/png",
+			wantMeta: map[string]string{MetaImageWidth: "30", MetaImageHeight: "20"},
+		},
+		{
+			name:     "Test_Exif",
+			content:  exifJPEG,
+			opts:     []ChunkedUploadOption{WithMetadataExtraction(true)},
+			mimeType: "image/jpeg",
+			wantMeta: map[string]string{
+				MetaImageWidth:      "64",
+				MetaImageHeight:     "32",
+				MetaExifMake:        "Canon",
+				MetaExifOrientation: "6",
+				MetaExifDateTime:    "2022:10:01 12:00:00",
+			},
+		},
+		{
+			name:     "Test_Custom_Meta_Kept",
+			meta:     FileMeta{CustomMeta: map[string]string{MetaExifMake: "mine", "album": "holidays"}},
+			content:  exifJPEG,
+			opts:     []ChunkedUploadOption{WithMetadataExtraction(true)},
+			mimeType: "image/jpeg",
+			wantMeta: map[string]string{
+				"album":             "holidays",
+				MetaImageWidth:      "64",
+				MetaImageHeight:     "32",
+				MetaExifMake:        "mine",
+				MetaExifOrientation: "6",
+				MetaExifDateTime:    "2022:10:01 12:00:00",
+			},
+		},
+		{
+			name:     "Test_Custom_Meta_Too_Big",
+			meta:     FileMeta{CustomMeta: map[string]string{"notes": strings.Repeat("a", maxCustomMetaSize-20)}},
+			content:  png,
+			opts:     []ChunkedUploadOption{WithMetadataExtraction(true)},
+			mimeType: "image/png",
+			wantMeta: map[string]string{"notes": strings.Repeat("a", maxCustomMetaSize-20)},
+		},
+		{name: "Test_Not_An_Image", content: []byte("plain text"), opts: []ChunkedUploadOption{WithMetadataExtraction(true)}, mimeType: "text/plain; charset=utf-8"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			su := &ChunkedUpload{
+				allocationObj: &Allocation{DataShards: 2, ParityShards: 1},
+				fileMeta:      tc.meta,
+				fileReader:    bytes.NewReader(tc.content),
+			}
+			for _, opt := range tc.opts {
+				opt(su)
+			}
+
+			require.NoError(t, su.detectContent())
+			require.Equal(t, tc.mimeType, su.fileMeta.MimeType)
+			require.Equal(t, tc.wantMeta, su.fileMeta.CustomMeta)
+
+			content, err := io.ReadAll(su.fileReader)
+			require.NoError(t, err)
+			require.Equal(t, len(tc.content), len(content))
+			require.True(t, bytes.Equal(tc.content, content), "the file is uploaded whole")
+		})
+	}
+}
+
+func TestReadJPEGExif(t *testing.T) {
+	exifJPEG := encodeExifJPEG(t, 8, 8)
+
+	require.Equal(t, map[uint16]string{
+		exifTagMake:             "Canon",
+		exifTagOrientation:      "6",
+		exifTagDateTimeOriginal: "2022:10:01 12:00:00",
+	}, readJPEGExif(exifJPEG))
+
+	// a truncated segment is ignored
+	require.Nil(t, readJPEGExif(exifJPEG[:40]))
+	require.Nil(t, readJPEGExif(encodePNG(t, 8, 8)))
+}
@@ -0,0 +1,142 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/logger"
+)
+
+var (
+	// ErrAlreadyExists is returned by deduplicated uploads when a file with
+	// the same content is in the allocation already, see WithDeduplication.
+	ErrAlreadyExists = errors.New("already_exists", "a file with the same content is in the allocation already")
+
+	// ErrDeduplicationNotSeekable is returned for deduplicated uploads of
+	// readers that can't be read twice.
+	ErrDeduplicationNotSeekable = errors.New("dedup_not_seekable", "deduplication needs a seekable file reader")
+)
+
+// WithDeduplication turn on/off the deduplication of the upload. The file is
+// hashed before the upload starts, which needs an io.ReadSeeker, and the
+// allocation searched for files with the same content:
+//   - if the remote path has it already, nothing is uploaded and
+//     ErrAlreadyExists is returned.
+//   - if a file of the same name has it, it is copied to the remote path on
+//     the blobbers and no data is transferred.
+//   - if another file has it, nothing is uploaded and ErrAlreadyExists is
+//     returned with its path.
+//
+// Encrypted uploads and repairs aren't deduplicated. It is turn off as
+// default.
+func WithDeduplication(on bool) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		su.deduplicate = on
+	}
+}
+
+// hashContent hashes the content of source, as it is uploaded, and seeks
+// back to where the content starts. The content is hashed compressed too, as
+// whether it's compressed is only known once its mime type is.
+func (su *ChunkedUpload) hashContent(source io.Reader) error {
+	if !su.deduplicate || su.encryptOnUpload {
+		return nil
+	}
+	seeker, ok := source.(io.ReadSeeker)
+	if !ok {
+		return ErrDeduplicationNotSeekable
+	}
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return errors.Wrap(ErrDeduplicationNotSeekable, err)
+	}
+
+	raw := sha256.New()
+	w := io.Writer(raw)
+	var compressed hash.Hash
+	var compressor io.WriteCloser
+	if isSupportedCompression(su.compression) {
+		compressed = sha256.New()
+		if compressor, err = newCompressWriter(compressed, su.compression, su.compressionLevel); err != nil {
+			return err
+		}
+		w = io.MultiWriter(raw, compressor)
+	}
+
+	if _, err := io.Copy(w, seeker); err != nil {
+		return err
+	}
+	if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+		return err
+	}
+
+	su.contentHash = hex.EncodeToString(raw.Sum(nil))
+	if compressor != nil {
+		if err := compressor.Close(); err != nil {
+			return err
+		}
+		su.compressedContentHash = hex.EncodeToString(compressed.Sum(nil))
+	}
+	return nil
+}
+
+// uploadedHash returns the hash of the content as stored by the blobbers.
+func (su *ChunkedUpload) uploadedHash() string {
+	if su.fileMeta.CustomMeta[MetaCompression] != "" {
+		return su.compressedContentHash
+	}
+	return su.contentHash
+}
+
+// runDeduplication looks for the content in the allocation. It returns true
+// if the upload is done without transferring the content, with
+// ErrAlreadyExists if it's not at the remote path. A failed search doesn't
+// fail the upload, the content is uploaded then.
+func (su *ChunkedUpload) runDeduplication() (bool, error) {
+	results, err := su.allocationObj.Search(SearchQuery{Type: fileref.FILE, Hash: su.uploadedHash()})
+	if err != nil {
+		logger.Logger.Error("deduplication search failed, uploading: ", err)
+		return false, nil
+	}
+
+	var sameName, other *SearchResult
+	for _, r := range results {
+		if r.Encrypted {
+			continue
+		}
+		if r.Path == su.fileMeta.RemotePath {
+			return true, errors.Wrap(ErrAlreadyExists, r.Path)
+		}
+		if sameName == nil && r.Name == su.fileMeta.RemoteName {
+			sameName = r
+		}
+		if other == nil {
+			other = r
+		}
+	}
+
+	// a copy references the content the blobbers have, it's not possible
+	// over an existing file though
+	if sameName != nil && su.httpMethod == http.MethodPost {
+		err := su.allocationObj.CopyObject(sameName.Path, path.Dir(su.fileMeta.RemotePath))
+		if err == nil {
+			if su.statusCallback != nil {
+				su.statusCallback.Completed(su.allocationObj.ID, su.fileMeta.RemotePath, su.fileMeta.RemoteName, su.fileMeta.MimeType, int(sameName.Size), su.opCode)
+			}
+			return true, nil
+		}
+		logger.Logger.Error("deduplication copy of "+sameName.Path+" failed, uploading: ", err)
+		return false, nil
+	}
+
+	if other != nil {
+		return true, errors.Wrap(ErrAlreadyExists, other.Path)
+	}
+	return false, nil
+}
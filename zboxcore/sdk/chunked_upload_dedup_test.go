@@ -0,0 +1,85 @@
+package sdk
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/0chain/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkedUploadHashContent(t *testing.T) {
+	content := []byte(strings.Repeat("deduplicated content ", 1000))
+	sum := sha256.Sum256(content)
+	rawHash := hex.EncodeToString(sum[:])
+
+	compressedHash := func(algorithm string, level int) string {
+		compressed, err := io.ReadAll(compressReader(bytes.NewReader(content), algorithm, level))
+		require.NoError(t, err)
+		sum := sha256.Sum256(compressed)
+		return hex.EncodeToString(sum[:])
+	}
+
+	for _, tc := range []struct {
+		name           string
+		opts           []ChunkedUploadOption
+		reader         io.Reader
+		contentHash    string
+		compressedHash string
+		wantErr        error
+	}{
+		{name: "Test_Disabled", reader: bytes.NewReader(content)},
+		{name: "Test_Hash", opts: []ChunkedUploadOption{WithDeduplication(true)}, reader: bytes.NewReader(content), contentHash: rawHash},
+		{
+			name:           "Test_Compressed_Hash",
+			opts:           []ChunkedUploadOption{WithDeduplication(true), WithCompression(CompressionZstd, 3)},
+			reader:         bytes.NewReader(content),
+			contentHash:    rawHash,
+			compressedHash: compressedHash(CompressionZstd, 3),
+		},
+		{
+			name:           "Test_Gzip_Compressed_Hash",
+			opts:           []ChunkedUploadOption{WithDeduplication(true), WithCompression(CompressionGzip, 0)},
+			reader:         bytes.NewReader(content),
+			contentHash:    rawHash,
+			compressedHash: compressedHash(CompressionGzip, 0),
+		},
+		{name: "Test_Encrypted", opts: []ChunkedUploadOption{WithDeduplication(true), WithEncrypt(true)}, reader: bytes.NewReader(content)},
+		{name: "Test_Not_Seekable", opts: []ChunkedUploadOption{WithDeduplication(true)}, reader: io.MultiReader(bytes.NewReader(content)), wantErr: ErrDeduplicationNotSeekable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			su := &ChunkedUpload{
+				allocationObj: &Allocation{DataShards: 2, ParityShards: 1},
+				fileReader:    tc.reader,
+			}
+			for _, opt := range tc.opts {
+				opt(su)
+			}
+
+			err := su.hashContent(tc.reader)
+			if tc.wantErr != nil {
+				require.True(t, errors.Is(err, tc.wantErr), err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.contentHash, su.contentHash)
+			require.Equal(t, tc.compressedHash, su.compressedContentHash)
+
+			uploaded, err := io.ReadAll(su.fileReader)
+			require.NoError(t, err)
+			require.True(t, bytes.Equal(content, uploaded), "the file is read from its start again")
+		})
+	}
+}
+
+func TestChunkedUploadUploadedHash(t *testing.T) {
+	su := &ChunkedUpload{contentHash: "raw", compressedContentHash: "compressed"}
+	require.Equal(t, "raw", su.uploadedHash())
+
+	su.fileMeta.CustomMeta = map[string]string{MetaCompression: CompressionZstd}
+	require.Equal(t, "compressed", su.uploadedHash())
+}
@@ -56,7 +56,8 @@ func (b *chunkedUploadFormBuilder) Build(fileMeta *FileMeta, hasher Hasher, conn
 		ActualThumbHash: fileMeta.ActualThumbnailHash,
 		ActualThumbSize: fileMeta.ActualThumbnailSize,
 
-		MimeType: fileMeta.MimeType,
+		MimeType:   fileMeta.MimeType,
+		CustomMeta: encodeCustomMeta(fileMeta.CustomMeta),
 
 		IsFinal:         isFinal,
 		ChunkSize:       chunkSize,
@@ -77,13 +78,15 @@ func (b *chunkedUploadFormBuilder) Build(fileMeta *FileMeta, hasher Hasher, conn
 	chunksHashWriter := sha256.New()
 	chunksWriters := io.MultiWriter(uploadFile, chunkHashWriter, chunksHashWriter)
 
+	// the chunks are written to the form and to the content hashes in a
+	// single pass, the default hasher computing the challenge hash meanwhile
 	for i, chunkBytes := range fileChunksData {
-		_, err = chunksWriters.Write(chunkBytes)
+		err = hasher.WriteToChallenge(chunkBytes, chunkStartIndex+i)
 		if err != nil {
 			return nil, metadata, err
 		}
 
-		err = hasher.WriteToChallenge(chunkBytes, chunkStartIndex+i)
+		_, err = chunksWriters.Write(chunkBytes)
 		if err != nil {
 			return nil, metadata, err
 		}
@@ -34,6 +34,10 @@ type hasher struct {
 	File      hash.Hash               `json:"-"`
 	Challenge *util.FixedMerkleTree   `json:"challenge"`
 	Content   *util.CompactMerkleTree `json:"content"`
+
+	// challengeDone is the outcome of the challenge hash of the last chunk,
+	// computed while its content hash is
+	challengeDone chan error
 }
 
 // CreateHasher creat Hasher instance
@@ -87,10 +91,16 @@ func (h *hasher) GetChallengeHash() (string, error) {
 		return "", errors.Throw(constants.ErrInvalidParameter, "h.Challenge")
 	}
 
+	if err := h.waitChallenge(); err != nil {
+		return "", err
+	}
+
 	return h.Challenge.GetMerkleRoot(), nil
 }
 
-// WriteToChallenge write bytes to challenge hasher
+// WriteToChallenge write bytes to challenge hasher. The bytes are hashed in
+// the background until WriteHashToContent of the chunk or the next call of
+// WriteToChallenge, buf must not be modified until then.
 func (h *hasher) WriteToChallenge(buf []byte, chunkIndex int) error {
 	if h == nil {
 		return errors.Throw(constants.ErrInvalidParameter, "h")
@@ -100,7 +110,26 @@ func (h *hasher) WriteToChallenge(buf []byte, chunkIndex int) error {
 		return errors.Throw(constants.ErrInvalidParameter, "h.Challenge")
 	}
 
-	return h.Challenge.Write(buf, chunkIndex)
+	if err := h.waitChallenge(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	h.challengeDone = done
+	go func() {
+		done <- h.Challenge.Write(buf, chunkIndex)
+	}()
+	return nil
+}
+
+// waitChallenge waits for the challenge hash of the last chunk.
+func (h *hasher) waitChallenge() error {
+	if h.challengeDone == nil {
+		return nil
+	}
+	err := <-h.challengeDone
+	h.challengeDone = nil
+	return err
 }
 
 // GetContentHash get content hash
@@ -116,7 +145,8 @@ func (h *hasher) GetContentHash() (string, error) {
 	return h.Content.GetMerkleRoot(), nil
 }
 
-// WriteHashToContent write hash leaf to content hasher
+// WriteHashToContent write hash leaf to content hasher, and waits for the
+// challenge hash of the chunk
 func (h *hasher) WriteHashToContent(hash string, chunkIndex int) error {
 	if h == nil {
 		return errors.Throw(constants.ErrInvalidParameter, "h")
@@ -126,5 +156,23 @@ func (h *hasher) WriteHashToContent(hash string, chunkIndex int) error {
 		return errors.Throw(constants.ErrInvalidParameter, "h.Content")
 	}
 
-	return h.Content.AddLeaf(hash, chunkIndex)
+	err := h.Content.AddLeaf(hash, chunkIndex)
+	if cerr := h.waitChallenge(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// fileHashWriter writes to the file hasher of a Hasher, so the file hash is
+// computed while the chunk is read, see io.TeeReader.
+type fileHashWriter struct {
+	hasher     Hasher
+	chunkIndex int
+}
+
+func (w *fileHashWriter) Write(p []byte) (int, error) {
+	if err := w.hasher.WriteToFile(p, w.chunkIndex); err != nil {
+		return 0, err
+	}
+	return len(p), nil
 }
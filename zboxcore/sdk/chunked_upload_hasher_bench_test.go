@@ -0,0 +1,78 @@
+package sdk
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"testing"
+)
+
+// BenchmarkChunkedUploadHashing compares hashing the chunks of a blobber in
+// separate passes, as the uploads used to, with the single-pass pipeline of
+// the form builder.
+func BenchmarkChunkedUploadHashing(b *testing.B) {
+	benchmarks := []struct {
+		Name      string
+		Size      int64
+		ChunkSize int
+	}{
+		{Name: "10M 64K", Size: MB * 10, ChunkSize: KB * 64},
+		{Name: "100M 64K", Size: MB * 100, ChunkSize: KB * 64},
+		{Name: "100M 6M", Size: MB * 100, ChunkSize: MB * 6},
+	}
+
+	for _, bm := range benchmarks {
+		buf := generateRandomBytes(bm.Size)
+		var chunks [][]byte
+		for begin := int64(0); begin < bm.Size; begin += int64(bm.ChunkSize) {
+			end := begin + int64(bm.ChunkSize)
+			if end > bm.Size {
+				end = bm.Size
+			}
+			chunks = append(chunks, buf[begin:end])
+		}
+		fileMeta := &FileMeta{ActualSize: bm.Size, RemoteName: "test.txt", RemotePath: "/test.txt"}
+
+		b.Run(bm.Name+" Separate Passes", func(b *testing.B) {
+			b.SetBytes(bm.Size)
+			for i := 0; i < b.N; i++ {
+				hasher := CreateHasher(bm.ChunkSize)
+				body := &bytes.Buffer{}
+				chunkHash := sha256.New()
+				chunksHash := sha256.New()
+				for index, chunk := range chunks {
+					if err := hasher.WriteToFile(chunk, index); err != nil {
+						b.Fatal(err)
+					}
+					if _, err := io.MultiWriter(body, chunkHash, chunksHash).Write(chunk); err != nil {
+						b.Fatal(err)
+					}
+					if err := hasher.WriteToChallenge(chunk, index); err != nil {
+						b.Fatal(err)
+					}
+					if err := hasher.WriteHashToContent(hex.EncodeToString(chunkHash.Sum(nil)), index); err != nil {
+						b.Fatal(err)
+					}
+					chunkHash.Reset()
+				}
+			}
+		})
+
+		b.Run(bm.Name+" Single Pass", func(b *testing.B) {
+			b.SetBytes(bm.Size)
+			builder := CreateChunkedUploadFormBuilder()
+			for i := 0; i < b.N; i++ {
+				hasher := CreateHasher(bm.ChunkSize)
+				_, err := io.Copy(&fileHashWriter{hasher: hasher}, bytes.NewReader(buf))
+				if err != nil {
+					b.Fatal(err)
+				}
+				_, _, err = builder.Build(fileMeta, hasher, "connectionID", int64(bm.ChunkSize), 0, len(chunks)-1, true, "", chunks, nil)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
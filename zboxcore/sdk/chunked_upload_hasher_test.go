@@ -0,0 +1,57 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+// orderedHasher is a custom Hasher failing if its challenge and content
+// hashes are written out of order or concurrently.
+type orderedHasher struct {
+	Hasher
+	t       *testing.T
+	writing bool
+	calls   []string
+}
+
+func (h *orderedHasher) WriteToChallenge(buf []byte, chunkIndex int) error {
+	require.False(h.t, h.writing)
+	h.writing = true
+	defer func() { h.writing = false }()
+	h.calls = append(h.calls, "challenge")
+	return h.Hasher.WriteToChallenge(buf, chunkIndex)
+}
+
+func (h *orderedHasher) WriteHashToContent(hash string, chunkIndex int) error {
+	require.False(h.t, h.writing)
+	h.writing = true
+	defer func() { h.writing = false }()
+	h.calls = append(h.calls, "content")
+	return h.Hasher.WriteHashToContent(hash, chunkIndex)
+}
+
+func TestChunkedUploadFormBuilder_Hasher(t *testing.T) {
+	const chunkSize = 64 * KB
+	data := generateRandomBytes(4 * chunkSize)
+	chunks := [][]byte{data[:chunkSize], data[chunkSize : 2*chunkSize], data[2*chunkSize : 3*chunkSize], data[3*chunkSize:]}
+	fileMeta := &FileMeta{ActualSize: int64(len(data)), RemoteName: "test.txt", RemotePath: "/test.txt"}
+
+	want := &util.FixedMerkleTree{ChunkSize: chunkSize}
+	for i, chunk := range chunks {
+		require.NoError(t, want.Write(chunk, i))
+	}
+
+	builder := CreateChunkedUploadFormBuilder()
+	h := &orderedHasher{Hasher: CreateHasher(chunkSize), t: t}
+	_, _, err := builder.Build(fileMeta, h, "connectionID", chunkSize, 0, 1, false, "", chunks[:2], nil)
+	require.NoError(t, err)
+	_, _, err = builder.Build(fileMeta, h, "connectionID", chunkSize, 2, 3, true, "", chunks[2:], nil)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"challenge", "content", "challenge", "content", "challenge", "content", "challenge", "content"}, h.calls)
+	got, err := h.GetChallengeHash()
+	require.NoError(t, err)
+	require.Equal(t, want.GetMerkleRoot(), got, "the challenge hash of the default hasher is computed in the background")
+}
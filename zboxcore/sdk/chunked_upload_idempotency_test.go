@@ -0,0 +1,85 @@
+package sdk
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/dev/sdktest"
+	zclient "github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/require"
+)
+
+// lossyClient loses the response of the first attempt of every write with
+// an idempotency key, after the blobber applied it.
+type lossyClient struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func (c *lossyClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultClient.Do(req)
+	key := req.Header.Get(zboxutil.IDEMPOTENCY_KEY_HEADER)
+	if err != nil || key == "" {
+		return resp, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.seen[key] {
+		return resp, nil
+	}
+	c.seen[key] = true
+	resp.Body.Close()
+	return nil, errors.New("connection reset by peer")
+}
+
+func TestChunkedUpload_IdempotentRetries(t *testing.T) {
+	prev := zboxutil.Client
+	zboxutil.Client = &lossyClient{seen: make(map[string]bool)}
+	defer func() { zboxutil.Client = prev }()
+
+	zclient.GetClient().Wallet = &zcncrypto.Wallet{
+		ClientID:  mockClientId,
+		ClientKey: mockClientKey,
+	}
+
+	a := &Allocation{
+		ID:           "TestChunkedUpload_IdempotentRetries",
+		Tx:           "TestChunkedUpload_IdempotentRetries",
+		DataShards:   2,
+		ParityShards: 1,
+		Size:         2 * GB,
+	}
+	setupMockAllocation(t, a)
+	var blobbers []*sdktest.Blobber
+	for i := 0; i < a.DataShards+a.ParityShards; i++ {
+		b := sdktest.NewBlobber(mockBlobberId + strconv.Itoa(i))
+		defer b.Close()
+		blobbers = append(blobbers, b)
+		a.Blobbers = append(a.Blobbers, b.StorageNode())
+	}
+
+	content := []byte("applied once")
+	fileMeta := FileMeta{
+		Path:       "/tmp/once.txt",
+		ActualSize: int64(len(content)),
+		MimeType:   "text/plain",
+		RemoteName: "once.txt",
+		RemotePath: "/once.txt",
+	}
+	chunkedUpload, err := CreateChunkedUpload(t.TempDir(), a, fileMeta, bytes.NewReader(content), false, false)
+	require.NoError(t, err)
+	chunkedUpload.progressStorer = &nopeChunkedUploadProgressStorer{}
+	require.NoError(t, chunkedUpload.Start())
+
+	for _, b := range blobbers {
+		require.Equal(t, []string{"/once.txt"}, b.Files(a.ID))
+		require.Equal(t, 2, b.Replays(), "the chunk and the commit are retried")
+	}
+}
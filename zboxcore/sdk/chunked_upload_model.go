@@ -32,6 +32,9 @@ type FileMeta struct {
 	RemoteName string
 	// RemotePath remote path
 	RemotePath string
+
+	// CustomMeta user defined key/value metadata stored with the file
+	CustomMeta map[string]string
 }
 
 // FileID generate id of progress on local cache
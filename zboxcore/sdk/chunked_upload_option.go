@@ -1,12 +1,13 @@
 package sdk
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"math"
-	"os"
 	"time"
 
+	"github.com/0chain/gosdk/core/sys"
 	"github.com/klauspost/reedsolomon"
 )
 
@@ -39,7 +40,7 @@ func WithThumbnail(buf []byte) ChunkedUploadOption {
 // WithThumbnailFile add thumbnail from file. stream mode is unnecessary for thumbnail
 func WithThumbnailFile(fileName string) ChunkedUploadOption {
 
-	buf, _ := os.ReadFile(fileName)
+	buf, _ := sys.Files.ReadFile(fileName)
 
 	return WithThumbnail(buf)
 }
@@ -53,6 +54,38 @@ func WithChunkNumber(num int) ChunkedUploadOption {
 	}
 }
 
+// WithChunkSize set how much bytes a chunk has. ignore if size <=0
+func WithChunkSize(size int64) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		if size > 0 {
+			su.chunkSize = size
+		}
+	}
+}
+
+// WithUploadWindow set the maximum number of upload requests in flight per blobber. Each blobber
+// has its own window, growing up to it while the blobber takes the requests and shrinking once
+// one fails or is throttled. It is DefaultUploadWindow if not set; 1 sends the requests one
+// after the other, as the uploads used to. ignore if num <=0
+func WithUploadWindow(num int) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		if num > 0 {
+			su.maxUploadWindow = num
+		}
+	}
+}
+
+// WithReadAhead set the number of upload requests read and encoded ahead of the ones sent,
+// holding their chunks in memory. It is DefaultReadAhead if not set; 0 reads them as they are
+// sent, as the uploads used to. ignore if num <0
+func WithReadAhead(num int) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		if num >= 0 {
+			su.readAheadSize = num
+		}
+	}
+}
+
 // WithEncrypt turn on/off encrypt on upload. It is turn off as default.
 func WithEncrypt(on bool) ChunkedUploadOption {
 	return func(su *ChunkedUpload) {
@@ -79,8 +112,30 @@ func WithUploadTimeout(t time.Duration) ChunkedUploadOption {
 	}
 }
 
+// WithCommitTimeout bounds each commit request when the upload context has
+// neither a deadline nor a commit deadline, see WithCommitDeadline.
 func WithCommitTimeout(t time.Duration) ChunkedUploadOption {
 	return func(su *ChunkedUpload) {
 		su.commitTimeOut = t
 	}
 }
+
+// WithContext runs the upload with ctx instead of the allocation context. Its
+// deadline and commit deadline (see WithCommitDeadline) bound both the upload
+// and the commit phase.
+func WithContext(ctx context.Context) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		su.ctx = ctx
+	}
+}
+
+// WithCustomMeta attach user defined key/value metadata to the file. It is sent to
+// every blobber and returned by GetFileMeta.
+func WithCustomMeta(meta map[string]string) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		su.fileMeta.CustomMeta = make(map[string]string, len(meta))
+		for k, v := range meta {
+			su.fileMeta.CustomMeta[k] = v
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/0chain/gosdk/constants"
+)
+
+const (
+	// DefaultUploadWindow is the default maximum number of upload requests
+	// in flight per blobber, see WithUploadWindow.
+	DefaultUploadWindow = 8
+	// DefaultReadAhead is the default number of upload requests read and
+	// encoded ahead of the ones sent, see WithReadAhead.
+	DefaultReadAhead = 4
+)
+
+// uploadWindow is the number of upload requests sent to a blobber before
+// waiting for their responses, each blobber of an upload having its own. It
+// starts at 1, doubles up to max once the blobber took a whole window of
+// requests and halves once one of them failed or was throttled.
+type uploadWindow struct {
+	mu       sync.Mutex
+	size     int
+	max      int
+	inflight int
+	// taken is the number of requests taken since the size last changed
+	taken int
+	// released is closed once a request in flight is over
+	released chan struct{}
+}
+
+func newUploadWindow(max int) *uploadWindow {
+	if max < 1 {
+		max = 1
+	}
+	return &uploadWindow{size: 1, max: max, released: make(chan struct{})}
+}
+
+func (w *uploadWindow) grow() {
+	w.size *= 2
+	if w.size > w.max {
+		w.size = w.max
+	}
+}
+
+func (w *uploadWindow) shrink() {
+	w.size /= 2
+	if w.size < 1 {
+		w.size = 1
+	}
+}
+
+// acquire waits for the window to have room for a request, or ctx to be
+// done. The request must be released once it is over.
+func (w *uploadWindow) acquire(ctx context.Context) error {
+	for {
+		w.mu.Lock()
+		if w.inflight < w.size {
+			w.inflight++
+			w.mu.Unlock()
+			return nil
+		}
+		released := w.released
+		w.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// release ends a request in flight, the blobber having taken it or not.
+func (w *uploadWindow) release(taken bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.inflight--
+	if !taken {
+		w.shrink()
+		w.taken = 0
+	} else if w.taken++; w.taken >= w.size {
+		w.grow()
+		w.taken = 0
+	}
+	close(w.released)
+	w.released = make(chan struct{})
+}
+
+// uploadBatch is an upload request of a batch of chunks sent to the blobbers.
+type uploadBatch struct {
+	consensus *Consensus
+	// sent is the number of blobbers the request is sent to
+	sent int
+	// throttled tells, by position, the blobbers answering 429 Too Many
+	// Requests
+	throttled []int32
+	wg        sync.WaitGroup
+	cancel    context.CancelFunc
+}
+
+// wait waits for the responses of the blobbers.
+func (b *uploadBatch) wait() error {
+	b.wg.Wait()
+	b.cancel()
+
+	if b.sent > 0 && !b.consensus.isConsensusOk() {
+		return &constants.ErrConsensusNotMet{Op: "Upload",
+			Got: b.consensus.getConsensus(), Need: b.consensus.consensusThresh}
+	}
+	return nil
+}
+
+func (b *uploadBatch) throttle(pos uint64) {
+	atomic.StoreInt32(&b.throttled[pos], 1)
+}
+
+func (b *uploadBatch) isThrottled(pos uint64) bool {
+	return atomic.LoadInt32(&b.throttled[pos]) == 1
+}
+
+// flushUploads waits for the requests in flight.
+func flushUploads(inflight []*uploadBatch) error {
+	var err error
+	for _, b := range inflight {
+		if e := b.wait(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// batchResult is a batch of chunks read ahead or the error reading it.
+type batchResult struct {
+	chunks *batchChunksData
+	err    error
+}
+
+// readAhead reads the batches of chunks in a goroutine, up to n of them ahead
+// of the ones taken, until the final one, an error or done is closed.
+func (su *ChunkedUpload) readAhead(n int, done <-chan struct{}) <-chan batchResult {
+	batches := make(chan batchResult, n)
+	go func() {
+		for {
+			chunks, err := su.readChunks(su.chunkNumber)
+			select {
+			case batches <- batchResult{chunks: chunks, err: err}:
+			case <-done:
+				return
+			}
+			if err != nil || chunks.isFinal {
+				return
+			}
+		}
+	}()
+	return batches
+}
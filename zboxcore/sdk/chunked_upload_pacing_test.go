@@ -0,0 +1,207 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/dev/sdktest"
+	zclient "github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadWindow(t *testing.T) {
+	tests := []struct {
+		name  string
+		max   int
+		steps string
+		want  []int
+	}{
+		{name: "Test_Grow_Up_To_Max", max: 8, steps: "++++", want: []int{2, 4, 8, 8}},
+		{name: "Test_Shrink_Down_To_One", max: 8, steps: "+++--", want: []int{2, 4, 8, 4, 2}},
+		{name: "Test_Shrink_At_One", max: 8, steps: "-+", want: []int{1, 2}},
+		{name: "Test_Sequential", max: 1, steps: "++", want: []int{1, 1}},
+		{name: "Test_Invalid_Max", max: 0, steps: "+", want: []int{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newUploadWindow(tt.max)
+			require.Equal(t, 1, w.size)
+			var got []int
+			for _, s := range tt.steps {
+				if s == '+' {
+					w.grow()
+				} else {
+					w.shrink()
+				}
+				got = append(got, w.size)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUploadWindow_acquire(t *testing.T) {
+	require := require.New(t)
+	w := newUploadWindow(4)
+
+	require.NoError(w.acquire(context.Background()))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(w.acquire(ctx), context.DeadlineExceeded, "the window is full")
+
+	acquired := make(chan error, 1)
+	go func() { acquired <- w.acquire(context.Background()) }()
+	w.release(true)
+	require.NoError(<-acquired)
+	require.Equal(2, w.size, "grown once a whole window is taken")
+
+	require.NoError(w.acquire(context.Background()))
+	w.release(true)
+	w.release(true)
+	require.Equal(4, w.size)
+	require.Equal(0, w.inflight)
+
+	require.NoError(w.acquire(context.Background()))
+	w.release(false)
+	require.Equal(2, w.size, "shrunk once a request failed")
+}
+
+// inflightClient records the most upload requests in flight per blobber.
+type inflightClient struct {
+	mu       sync.Mutex
+	inflight map[string]int
+	max      map[string]int
+	// failing is the host failing the uploads
+	failing string
+}
+
+func (c *inflightClient) Do(req *http.Request) (*http.Response, error) {
+	if !strings.Contains(req.URL.Path, "/file/upload/") {
+		return http.DefaultClient.Do(req)
+	}
+	if req.URL.Host == c.failing {
+		c.mu.Lock()
+		c.max[req.URL.Host] = 1
+		c.mu.Unlock()
+		return &http.Response{StatusCode: http.StatusInternalServerError, Body: ioutil.NopCloser(strings.NewReader("failed"))}, nil
+	}
+
+	c.mu.Lock()
+	c.inflight[req.URL.Host]++
+	if c.inflight[req.URL.Host] > c.max[req.URL.Host] {
+		c.max[req.URL.Host] = c.inflight[req.URL.Host]
+	}
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.inflight[req.URL.Host]--
+		c.mu.Unlock()
+	}()
+	return http.DefaultClient.Do(req)
+}
+
+func TestChunkedUpload_Pacing(t *testing.T) {
+	prev := zboxutil.Client
+	defer func() { zboxutil.Client = prev }()
+
+	zclient.GetClient().Wallet = &zcncrypto.Wallet{
+		ClientID:  mockClientId,
+		ClientKey: mockClientKey,
+	}
+
+	const chunkSize = 1024
+	tests := []struct {
+		name    string
+		opts    []ChunkedUploadOption
+		failing bool
+		wantMax func(t *testing.T, max int)
+	}{
+		{
+			name: "Test_Window",
+			opts: []ChunkedUploadOption{WithUploadWindow(4), WithReadAhead(2)},
+			wantMax: func(t *testing.T, max int) {
+				require.Greater(t, max, 1)
+				require.LessOrEqual(t, max, 4)
+			},
+		},
+		{
+			// the blobber failing the uploads doesn't shrink the windows of
+			// the others
+			name:    "Test_Window_Failing_Blobber",
+			opts:    []ChunkedUploadOption{WithUploadWindow(4), WithReadAhead(2)},
+			failing: true,
+			wantMax: func(t *testing.T, max int) {
+				require.Greater(t, max, 1)
+				require.LessOrEqual(t, max, 4)
+			},
+		},
+		{
+			name: "Test_Sequential",
+			opts: []ChunkedUploadOption{WithUploadWindow(1), WithReadAhead(0)},
+			wantMax: func(t *testing.T, max int) {
+				require.Equal(t, 1, max)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &inflightClient{inflight: make(map[string]int), max: make(map[string]int)}
+			zboxutil.Client = client
+
+			a := &Allocation{
+				ID:           "TestChunkedUpload_Pacing",
+				Tx:           "TestChunkedUpload_Pacing",
+				DataShards:   2,
+				ParityShards: 1,
+				Size:         2 * GB,
+			}
+			if tt.failing {
+				// the upload goes on without one of the blobbers
+				a.ParityShards = 2
+			}
+			setupMockAllocation(t, a)
+			for i := 0; i < a.DataShards+a.ParityShards; i++ {
+				b := sdktest.NewBlobber(mockBlobberId + strconv.Itoa(i))
+				defer b.Close()
+				a.Blobbers = append(a.Blobbers, b.StorageNode())
+			}
+
+			content := bytes.Repeat([]byte("paced"), 40*chunkSize*a.DataShards/5)
+			fileMeta := FileMeta{
+				Path:       "/tmp/paced.txt",
+				ActualSize: int64(len(content)),
+				MimeType:   "text/plain",
+				RemoteName: "paced.txt",
+				RemotePath: "/paced.txt",
+			}
+			if tt.failing {
+				u, err := url.Parse(a.Blobbers[0].Baseurl)
+				require.NoError(t, err)
+				client.failing = u.Host
+			}
+			opts := append([]ChunkedUploadOption{WithChunkSize(chunkSize)}, tt.opts...)
+			chunkedUpload, err := CreateChunkedUpload(t.TempDir(), a, fileMeta, bytes.NewReader(content), false, false, opts...)
+			require.NoError(t, err)
+			chunkedUpload.progressStorer = &nopeChunkedUploadProgressStorer{}
+			require.NoError(t, chunkedUpload.Start())
+
+			require.Len(t, client.max, len(a.Blobbers))
+			for host, max := range client.max {
+				if host != client.failing {
+					tt.wantMax(t, max)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package sdk
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	thrown "github.com/0chain/errors"
+)
+
+// DefaultPreflightHeadSize is how many leading bytes are handed to an
+// UploadPreflightFunc when no size is given. It matches the 512 bytes used
+// by http.DetectContentType.
+const DefaultPreflightHeadSize = 512
+
+// ErrUploadRejected is returned when an UploadPreflightFunc rejects an upload.
+var ErrUploadRejected = errors.New("upload: rejected by preflight check")
+
+// UploadPreflightFunc inspects an upload before anything is sent to blobbers.
+// meta carries the declared size, mime type and remote path; head holds the
+// first bytes of the content (fewer for small files). Returning an error
+// aborts the upload with ErrUploadRejected.
+type UploadPreflightFunc func(meta FileMeta, head []byte) error
+
+// WithUploadPreflight register a content policy hook that is invoked with the first
+// headSize bytes of the file before the upload begins. headSize <= 0 uses
+// DefaultPreflightHeadSize.
+func WithUploadPreflight(headSize int, check UploadPreflightFunc) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		if headSize <= 0 {
+			headSize = DefaultPreflightHeadSize
+		}
+		su.preflightHeadSize = headSize
+		su.preflightCheck = check
+	}
+}
+
+// runPreflight reads the head of the file, runs the preflight check on it and
+// stitches the head back in front of the reader so no bytes are lost.
+func (su *ChunkedUpload) runPreflight() error {
+	if su.preflightCheck == nil {
+		return nil
+	}
+
+	head := make([]byte, su.preflightHeadSize)
+	n, err := io.ReadFull(su.fileReader, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	head = head[:n]
+	su.fileReader = io.MultiReader(bytes.NewReader(head), su.fileReader)
+
+	if err := su.preflightCheck(su.fileMeta, head); err != nil {
+		return thrown.Throw(ErrUploadRejected, err.Error())
+	}
+	return nil
+}
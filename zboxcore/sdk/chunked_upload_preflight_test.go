@@ -0,0 +1,57 @@
+package sdk
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkedUpload_runPreflight(t *testing.T) {
+	content := []byte("#!/bin/sh\necho hello\n")
+
+	t.Run("Test_Head_Is_Preserved", func(t *testing.T) {
+		su := &ChunkedUpload{
+			fileMeta:   FileMeta{ActualSize: int64(len(content))},
+			fileReader: bytes.NewReader(content),
+		}
+		var gotHead []byte
+		WithUploadPreflight(4, func(meta FileMeta, head []byte) error {
+			gotHead = append(gotHead, head...)
+			require.Equal(t, int64(len(content)), meta.ActualSize)
+			return nil
+		})(su)
+
+		require.NoError(t, su.runPreflight())
+		require.Equal(t, content[:4], gotHead)
+
+		all, err := ioutil.ReadAll(su.fileReader)
+		require.NoError(t, err)
+		require.Equal(t, content, all)
+	})
+
+	t.Run("Test_Short_File", func(t *testing.T) {
+		su := &ChunkedUpload{fileReader: bytes.NewReader(content)}
+		WithUploadPreflight(0, func(meta FileMeta, head []byte) error {
+			require.Equal(t, content, head)
+			return nil
+		})(su)
+		require.NoError(t, su.runPreflight())
+	})
+
+	t.Run("Test_Rejected", func(t *testing.T) {
+		su := &ChunkedUpload{fileReader: bytes.NewReader(content)}
+		WithUploadPreflight(0, func(meta FileMeta, head []byte) error {
+			if http.DetectContentType(head) == "text/plain; charset=utf-8" && bytes.HasPrefix(head, []byte("#!")) {
+				return errors.New("scripts are not allowed")
+			}
+			return nil
+		})(su)
+		err := su.runPreflight()
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrUploadRejected))
+	})
+}
@@ -0,0 +1,145 @@
+package sdk
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	_ "image/gif" // register the decoders of the supported images
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"strings"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/logger"
+)
+
+const (
+	// DefaultThumbnailSize is the largest side, in pixels, of the generated
+	// thumbnails when no size is given.
+	DefaultThumbnailSize = 256
+
+	// maxThumbnailSourceSize bounds the images thumbnails are generated for,
+	// as they are decoded in memory.
+	maxThumbnailSourceSize = 32 * 1024 * 1024
+
+	thumbnailQuality = 85
+)
+
+// WithThumbnailGeneration generates a JPEG thumbnail that fits in maxSize x
+// maxSize pixels when the uploaded file is a png, jpeg or gif image and no
+// thumbnail is given with WithThumbnail. maxSize <= 0 uses
+// DefaultThumbnailSize. Other files, and images over 32MB, are uploaded
+// without thumbnail.
+func WithThumbnailGeneration(maxSize int) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		if maxSize <= 0 {
+			maxSize = DefaultThumbnailSize
+		}
+		su.thumbnailMaxSize = maxSize
+	}
+}
+
+// generateThumbnail reads the image being uploaded, generates its thumbnail
+// and stitches the image back in front of the reader so no bytes are lost.
+func (su *ChunkedUpload) generateThumbnail() error {
+	if su.thumbnailMaxSize <= 0 || len(su.thumbnailBytes) > 0 {
+		return nil
+	}
+	if su.fileMeta.ActualSize > maxThumbnailSourceSize {
+		return nil
+	}
+
+	// the mime type is detected from the content if it isn't given
+	if !strings.HasPrefix(su.fileMeta.MimeType, "image/") {
+		return nil
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(su.fileReader, maxThumbnailSourceSize+1))
+	su.fileReader = io.MultiReader(bytes.NewReader(buf), su.fileReader)
+	if err != nil {
+		return err
+	}
+	if len(buf) > maxThumbnailSourceSize {
+		return nil
+	}
+
+	thumbnail, err := GenerateThumbnail(bytes.NewReader(buf), su.thumbnailMaxSize)
+	if err != nil {
+		// an image format that can't be decoded isn't a reason to fail the upload
+		logger.Logger.Info("upload without thumbnail: ", err)
+		return nil
+	}
+	WithThumbnail(thumbnail)(su)
+	return nil
+}
+
+// GenerateThumbnail decodes the png, jpeg or gif image of r and returns it
+// scaled down to fit in maxSize x maxSize pixels, encoded as JPEG. Images
+// that already fit keep their size. Transparent pixels are drawn on white.
+func GenerateThumbnail(r io.Reader, maxSize int) ([]byte, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultThumbnailSize
+	}
+
+	src, _, err := image.Decode(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "thumbnail: can't decode image")
+	}
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, scaleImage(src, maxSize), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, errors.Wrap(err, "thumbnail: can't encode image")
+	}
+	return out.Bytes(), nil
+}
+
+// scaleImage scales src down to fit in maxSize x maxSize, keeping its aspect
+// ratio. Every pixel is the average of the source pixels it covers.
+func scaleImage(src image.Image, maxSize int) *image.RGBA {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	w, h := sw, sh
+	if w > maxSize || h > maxSize {
+		if sw >= sh {
+			w, h = maxSize, sh*maxSize/sw
+		} else {
+			w, h = sw*maxSize/sh, maxSize
+		}
+		if w < 1 {
+			w = 1
+		}
+		if h < 1 {
+			h = 1
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0 := bounds.Min.Y + y*sh/h
+		y1 := bounds.Min.Y + (y+1)*sh/h
+		for x := 0; x < w; x++ {
+			x0 := bounds.Min.X + x*sw/w
+			x1 := bounds.Min.X + (x+1)*sw/w
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			// the colors are premultiplied, so the white background shows
+			// through what the alpha leaves
+			bg := 0xffff - a/n
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8((r/n + bg) >> 8),
+				G: uint8((g/n + bg) >> 8),
+				B: uint8((b/n + bg) >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,107 @@
+package sdk
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 0x80, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestGenerateThumbnail(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		input         []byte
+		maxSize       int
+		width, height int
+		wantErr       bool
+	}{
+		{name: "Test_Landscape", input: encodePNG(t, 400, 200), maxSize: 100, width: 100, height: 50},
+		{name: "Test_Portrait", input: encodePNG(t, 90, 300), maxSize: 100, width: 30, height: 100},
+		{name: "Test_Already_Small", input: encodePNG(t, 40, 20), maxSize: 100, width: 40, height: 20},
+		{name: "Test_Default_Size", input: encodePNG(t, 1024, 512), width: DefaultThumbnailSize, height: DefaultThumbnailSize / 2},
+		{name: "Test_Not_An_Image", input: []byte("plain text"), maxSize: 100, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			thumbnail, err := GenerateThumbnail(bytes.NewReader(tc.input), tc.maxSize)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			cfg, err := jpeg.DecodeConfig(bytes.NewReader(thumbnail))
+			require.NoError(t, err)
+			require.Equal(t, tc.width, cfg.Width)
+			require.Equal(t, tc.height, cfg.Height)
+		})
+	}
+}
+
+func TestChunkedUploadGenerateThumbnail(t *testing.T) {
+	img := encodePNG(t, 300, 300)
+
+	for _, tc := range []struct {
+		name          string
+		meta          FileMeta
+		content       []byte
+		opts          []ChunkedUploadOption
+		wantThumbnail bool
+	}{
+		{name: "Test_Image", meta: FileMeta{MimeType: "image/png"}, content: img, opts: []ChunkedUploadOption{WithThumbnailGeneration(64)}, wantThumbnail: true},
+		{name: "Test_Detected_Image", content: img, opts: []ChunkedUploadOption{WithThumbnailGeneration(64)}, wantThumbnail: true},
+		{name: "Test_Not_An_Image", content: []byte("plain text"), opts: []ChunkedUploadOption{WithThumbnailGeneration(64)}},
+		{name: "Test_Declared_Not_An_Image", meta: FileMeta{MimeType: "video/mp4"}, content: img, opts: []ChunkedUploadOption{WithThumbnailGeneration(64)}},
+		{name: "Test_Disabled", meta: FileMeta{MimeType: "image/png"}, content: img},
+		{name: "Test_Given_Thumbnail", meta: FileMeta{MimeType: "image/png"}, content: img, opts: []ChunkedUploadOption{WithThumbnail([]byte("thumb")), WithThumbnailGeneration(64)}, wantThumbnail: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			su := &ChunkedUpload{
+				allocationObj: &Allocation{DataShards: 2, ParityShards: 1},
+				fileMeta:      tc.meta,
+				fileReader:    bytes.NewReader(tc.content),
+			}
+			for _, opt := range tc.opts {
+				opt(su)
+			}
+			given := su.thumbnailBytes
+
+			require.NoError(t, su.detectContent())
+			require.NoError(t, su.generateThumbnail())
+
+			content, err := io.ReadAll(su.fileReader)
+			require.NoError(t, err)
+			require.Equal(t, tc.content, content, "the file is uploaded whole")
+
+			if !tc.wantThumbnail {
+				require.Empty(t, su.thumbnailBytes)
+				return
+			}
+			require.NotEmpty(t, su.thumbnailBytes)
+			require.Equal(t, int64(len(su.thumbnailBytes)), su.fileMeta.ActualThumbnailSize)
+			if given != nil {
+				require.Equal(t, given, su.thumbnailBytes, "a given thumbnail isn't replaced")
+				return
+			}
+			cfg, err := jpeg.DecodeConfig(bytes.NewReader(su.thumbnailBytes))
+			require.NoError(t, err)
+			require.Equal(t, 64, cfg.Width)
+		})
+	}
+}
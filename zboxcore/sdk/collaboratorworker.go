@@ -7,7 +7,6 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
-	"time"
 
 	"github.com/0chain/gosdk/zboxcore/blockchain"
 	l "github.com/0chain/gosdk/zboxcore/logger"
@@ -18,10 +17,20 @@ type CollaboratorRequest struct {
 	a              *Allocation
 	path           string
 	collaboratorID string
+	ctx            context.Context
 	consensus      Consensus
 	wg             *sync.WaitGroup
 }
 
+// context returns the context of the request, falling back to the one of the
+// allocation for requests built without one.
+func (req *CollaboratorRequest) context() context.Context {
+	if req.ctx != nil {
+		return req.ctx
+	}
+	return req.a.ctx
+}
+
 func (req *CollaboratorRequest) UpdateCollaboratorToBlobbers() bool {
 	numList := len(req.a.Blobbers)
 	req.wg = &sync.WaitGroup{}
@@ -57,8 +66,8 @@ func (req *CollaboratorRequest) updateCollaboratorToBlobber(blobber *blockchain.
 	}
 
 	httpreq.Header.Add("Content-Type", formWriter.FormDataContentType())
-	ctx, cncl := context.WithTimeout(req.a.ctx, (time.Second * 30))
-	err = zboxutil.HttpDo(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
+	ctx, cncl := context.WithTimeout(req.context(), req.a.GetTimeouts().Meta)
+	err = zboxutil.HttpDoBlobber(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
 		if err != nil {
 			l.Logger.Error("Update Collaborator : ", err)
 			rspCh <- false
@@ -112,9 +121,9 @@ func (req *CollaboratorRequest) removeCollaboratorFromBlobber(blobber *blockchai
 		return
 	}
 
-	ctx, cncl := context.WithTimeout(req.a.ctx, DefaultUploadTimeOut)
+	ctx, cncl := context.WithTimeout(req.context(), req.a.GetTimeouts().Commit)
 
-	zboxutil.HttpDo(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
+	zboxutil.HttpDoBlobber(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
 		if err != nil {
 			l.Logger.Error("Delete Collaborator : ", err)
 			rspCh <- false
@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"context"
+	"time"
+)
+
+type commitDeadlineKey struct{}
+
+// WithCommitDeadline returns a copy of ctx carrying a deadline for the commit
+// phase of the operations run with it, i.e. the reference path lookup and the
+// write marker submission. It is separate from the upload phase, but the
+// deadline of ctx itself still applies, whichever comes first.
+func WithCommitDeadline(ctx context.Context, deadline time.Time) context.Context {
+	return context.WithValue(ctx, commitDeadlineKey{}, deadline)
+}
+
+// CommitDeadline returns the deadline set with WithCommitDeadline, if any.
+func CommitDeadline(ctx context.Context) (time.Time, bool) {
+	if ctx == nil {
+		return time.Time{}, false
+	}
+	deadline, ok := ctx.Value(commitDeadlineKey{}).(time.Time)
+	return deadline, ok
+}
+
+// commitContext derives the context of a commit phase request from the
+// operation context. The commit deadline wins if set, then the operation
+// deadline. fallback only applies when the caller set neither.
+func commitContext(ctx context.Context, fallback time.Duration) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if deadline, ok := CommitDeadline(ctx); ok {
+		return context.WithDeadline(ctx, deadline)
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, fallback)
+}
@@ -0,0 +1,36 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitContext(t *testing.T) {
+	now := time.Now()
+	opCtx, cancel := context.WithDeadline(context.Background(), now.Add(time.Hour))
+	defer cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want time.Time
+	}{
+		{name: "Test_Fallback", ctx: context.Background(), want: now.Add(time.Minute)},
+		{name: "Test_Nil_Context", ctx: nil, want: now.Add(time.Minute)},
+		{name: "Test_Operation_Deadline", ctx: opCtx, want: now.Add(time.Hour)},
+		{name: "Test_Commit_Deadline", ctx: WithCommitDeadline(opCtx, now.Add(10*time.Minute)), want: now.Add(10 * time.Minute)},
+		{name: "Test_Commit_Deadline_After_Operation_Deadline", ctx: WithCommitDeadline(opCtx, now.Add(2*time.Hour)), want: now.Add(time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := commitContext(tt.ctx, time.Minute)
+			defer cancel()
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			require.WithinDuration(t, tt.want, deadline, time.Second)
+		})
+	}
+}
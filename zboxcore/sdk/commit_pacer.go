@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/core/sys"
+)
+
+// commitPacer spaces out consecutive commits to the same blobber so that
+// bursty batch jobs queue on the client instead of tripping the blobber's
+// write-marker rate limit.
+type commitPacer struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     map[string]time.Time
+}
+
+var defaultCommitPacer = &commitPacer{next: make(map[string]time.Time)}
+
+// uploadBackoff holds back the chunk uploads to a blobber that answered with
+// 429 Too Many Requests. It is kept apart from defaultCommitPacer so that a
+// throttled upload doesn't hold back the commits to that blobber, and the
+// pacing of the commits doesn't slow the uploads down. It never paces on its
+// own, its interval is always zero.
+var uploadBackoff = &commitPacer{next: make(map[string]time.Time)}
+
+// SetCommitPacing sets the minimum gap between two commits sent to the same
+// blobber. Commits issued faster than this are queued in order rather than
+// rejected. A zero interval (the default) disables pacing.
+func SetCommitPacing(interval time.Duration) {
+	defaultCommitPacer.mu.Lock()
+	defer defaultCommitPacer.mu.Unlock()
+	if interval < 0 {
+		interval = 0
+	}
+	defaultCommitPacer.interval = interval
+}
+
+// GetCommitPacing returns the configured minimum gap between commits.
+func GetCommitPacing() time.Duration {
+	defaultCommitPacer.mu.Lock()
+	defer defaultCommitPacer.mu.Unlock()
+	return defaultCommitPacer.interval
+}
+
+// reserve books the next commit slot for blobberID and returns how long the
+// caller has to wait for it.
+func (p *commitPacer) reserve(blobberID string, now time.Time) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	slot := now
+	if next, ok := p.next[blobberID]; ok && next.After(now) {
+		slot = next
+	}
+	if p.interval == 0 && slot.Equal(now) {
+		return 0
+	}
+	p.next[blobberID] = slot.Add(p.interval)
+	return slot.Sub(now)
+}
+
+// delay pushes the next slot of blobberID back by d, e.g. after the blobber
+// answered with 429 Too Many Requests.
+func (p *commitPacer) delay(blobberID string, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	until := time.Now().Add(d)
+	if next, ok := p.next[blobberID]; !ok || next.Before(until) {
+		p.next[blobberID] = until
+	}
+}
+
+// wait blocks until the caller may commit to blobberID or ctx is done.
+func (p *commitPacer) wait(ctx context.Context, blobberID string) error {
+	d := p.reserve(blobberID, time.Now())
+	if d <= 0 {
+		return nil
+	}
+	return sys.SleepContext(ctx, d)
+}
@@ -0,0 +1,53 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitPacer_reserve(t *testing.T) {
+	p := &commitPacer{interval: time.Second, next: make(map[string]time.Time)}
+	now := time.Now()
+
+	require.Equal(t, time.Duration(0), p.reserve("b1", now))
+	require.Equal(t, time.Second, p.reserve("b1", now))
+	require.Equal(t, 2*time.Second, p.reserve("b1", now))
+	// other blobbers are paced independently
+	require.Equal(t, time.Duration(0), p.reserve("b2", now))
+	// the queue drains as time passes
+	require.Equal(t, time.Duration(0), p.reserve("b2", now.Add(5*time.Second)))
+}
+
+func TestCommitPacer_disabled(t *testing.T) {
+	p := &commitPacer{next: make(map[string]time.Time)}
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		require.Equal(t, time.Duration(0), p.reserve("b1", now))
+	}
+}
+
+func TestCommitPacer_delay(t *testing.T) {
+	p := &commitPacer{next: make(map[string]time.Time)}
+	p.delay("b1", time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, p.wait(ctx, "b1"), context.DeadlineExceeded)
+	require.NoError(t, p.wait(context.Background(), "b2"))
+}
+
+func TestCommitPacer_uploadBackoff(t *testing.T) {
+	uploadBackoff.delay("b1", time.Hour)
+	defer func() {
+		uploadBackoff.mu.Lock()
+		delete(uploadBackoff.next, "b1")
+		uploadBackoff.mu.Unlock()
+	}()
+
+	// a throttled upload doesn't hold back the commits to the blobber
+	require.Equal(t, time.Duration(0), defaultCommitPacer.reserve("b1", time.Now()))
+	require.Greater(t, uploadBackoff.reserve("b1", time.Now()), time.Duration(0))
+}
@@ -10,7 +10,6 @@ import (
 	"net/http"
 	"strconv"
 	"sync"
-	"time"
 
 	"github.com/0chain/errors"
 	"github.com/0chain/gosdk/core/common"
@@ -50,8 +49,12 @@ type CommitRequest struct {
 	allocationID string
 	allocationTx string
 	connectionID string
-	wg           *sync.WaitGroup
-	result       *CommitResult
+	// ctx of the operation, its deadline and commit deadline bound the commit
+	ctx    context.Context
+	wg     *sync.WaitGroup
+	result *CommitResult
+	// idempotencyKey is the same for the retries of the commit
+	idempotencyKey string
 }
 
 var commitChan map[string]chan *CommitRequest
@@ -100,6 +103,11 @@ func startCommitWorker(blobberChan chan *CommitRequest, blobberID string) {
 
 func (commitreq *CommitRequest) processCommit() {
 	defer commitreq.wg.Done()
+	defer func() {
+		if commitreq.result != nil && !commitreq.result.Success {
+			recordError("commit", errors.New("commit_failed", commitreq.result.ErrorMessage))
+		}
+	}()
 
 	l.Logger.Info("received a commit request")
 	paths := make([]string, 0)
@@ -113,8 +121,8 @@ func (commitreq *CommitRequest) processCommit() {
 		l.Logger.Error("Creating ref path req", err)
 		return
 	}
-	ctx, cncl := context.WithTimeout(context.Background(), (time.Second * 30))
-	err = zboxutil.HttpDo(ctx, cncl, req, func(resp *http.Response, err error) error {
+	ctx, cncl := commitContext(commitreq.ctx, timeoutsOf(commitreq.ctx).Meta)
+	err = zboxutil.HttpDoBlobber(ctx, cncl, req, func(resp *http.Response, err error) error {
 		if err != nil {
 			l.Logger.Error("Ref path error:", err)
 			return err
@@ -191,51 +199,67 @@ func (commitreq *CommitRequest) processCommit() {
 }
 
 func (req *CommitRequest) commitBlobber(rootRef *fileref.Ref, latestWM *marker.WriteMarker, size int64) error {
-	wm := &marker.WriteMarker{}
-	timestamp := int64(common.Now())
-	wm.AllocationRoot = encryption.Hash(rootRef.Hash + ":" + strconv.FormatInt(timestamp, 10))
-	if latestWM != nil {
-		wm.PreviousAllocationRoot = latestWM.AllocationRoot
-	} else {
-		wm.PreviousAllocationRoot = ""
-	}
+	sign := func(now common.Timestamp) (*http.Request, error) {
+		wm := &marker.WriteMarker{}
+		timestamp := int64(now)
+		wm.AllocationRoot = encryption.Hash(rootRef.Hash + ":" + strconv.FormatInt(timestamp, 10))
+		if latestWM != nil {
+			wm.PreviousAllocationRoot = latestWM.AllocationRoot
+		} else {
+			wm.PreviousAllocationRoot = ""
+		}
 
-	wm.AllocationID = req.allocationID
-	wm.Size = size
-	wm.BlobberID = req.blobber.ID
-	wm.Timestamp = timestamp
-	wm.ClientID = client.GetClientID()
-	err := wm.Sign()
-	if err != nil {
-		l.Logger.Error("Signing writemarker failed: ", err)
-		return err
+		wm.AllocationID = req.allocationID
+		wm.Size = size
+		wm.BlobberID = req.blobber.ID
+		wm.Timestamp = timestamp
+		wm.ClientID = client.GetClientID()
+		err := wm.Sign()
+		if err != nil {
+			l.Logger.Error("Signing writemarker failed: ", err)
+			return nil, err
+		}
+		body := new(bytes.Buffer)
+		formWriter := multipart.NewWriter(body)
+		wmData, err := json.Marshal(wm)
+		if err != nil {
+			l.Logger.Error("Creating writemarker failed: ", err)
+			return nil, err
+		}
+		formWriter.WriteField("connection_id", req.connectionID)
+		formWriter.WriteField("write_marker", string(wmData))
+
+		formWriter.Close()
+
+		httpreq, err := zboxutil.NewCommitRequest(req.blobber.Baseurl, req.allocationTx, body)
+		if err != nil {
+			l.Logger.Error("Error creating commit req: ", err)
+			return nil, err
+		}
+		httpreq.Header.Add("Content-Type", formWriter.FormDataContentType())
+		zboxutil.SetIdempotencyKey(httpreq, req.idempotencyKey)
+		return httpreq, nil
 	}
-	body := new(bytes.Buffer)
-	formWriter := multipart.NewWriter(body)
-	wmData, err := json.Marshal(wm)
-	if err != nil {
-		l.Logger.Error("Creating writemarker failed: ", err)
-		return err
+	if req.idempotencyKey == "" {
+		req.idempotencyKey = zboxutil.NewIdempotencyKey()
 	}
-	formWriter.WriteField("connection_id", req.connectionID)
-	formWriter.WriteField("write_marker", string(wmData))
-
-	formWriter.Close()
 
-	httpreq, err := zboxutil.NewCommitRequest(req.blobber.Baseurl, req.allocationTx, body)
-	if err != nil {
-		l.Logger.Error("Error creating commit req: ", err)
+	ctx, cncl := commitContext(req.ctx, timeoutsOf(req.ctx).Commit)
+	if err := defaultCommitPacer.wait(ctx, req.blobber.ID); err != nil {
+		cncl()
 		return err
 	}
-	httpreq.Header.Add("Content-Type", formWriter.FormDataContentType())
-	ctx, cncl := context.WithTimeout(context.Background(), (time.Second * 60))
 	l.Logger.Info("Committing to blobber." + req.blobber.Baseurl)
-	err = zboxutil.HttpDo(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
+	err := zboxutil.HttpDoSigned(ctx, cncl, req.blobber.Baseurl, sign, func(resp *http.Response, err error) error {
 		if err != nil {
 			l.Logger.Error("Commit: ", err)
 			return err
 		}
 		defer resp.Body.Close()
+		if zboxutil.IsAlreadyApplied(resp, req.idempotencyKey) {
+			l.Logger.Info(req.blobber.Baseurl, req.connectionID, " already committed")
+			return nil
+		}
 		if resp.StatusCode == http.StatusOK {
 			l.Logger.Info(req.blobber.Baseurl, req.connectionID, " committed")
 		} else {
@@ -267,8 +291,8 @@ func (commitreq *CommitRequest) calculateHashRequest(ctx context.Context, paths
 		l.Logger.Error("Creating calculate hash req", err)
 		return err
 	}
-	ctx, cncl := context.WithTimeout(ctx, (time.Second * 30))
-	err = zboxutil.HttpDo(ctx, cncl, req, func(resp *http.Response, err error) error {
+	ctx, cncl := context.WithTimeout(ctx, timeoutsOf(ctx).Meta)
+	err = zboxutil.HttpDoBlobber(ctx, cncl, req, func(resp *http.Response, err error) error {
 		if err != nil {
 			l.Logger.Error("Calculate hash error:", err)
 			return err
@@ -9,7 +9,6 @@ import (
 	"path"
 	"strconv"
 	"sync"
-	"time"
 
 	"github.com/0chain/errors"
 	"github.com/0chain/gosdk/constants"
@@ -26,8 +25,8 @@ func getObjectTreeFromBlobber(ctx context.Context, allocationID, allocationTx st
 		return nil, err
 	}
 	var lR ReferencePathResult
-	ctx, cncl := context.WithTimeout(ctx, (time.Second * 30))
-	err = zboxutil.HttpDo(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
+	ctx, cncl := context.WithTimeout(ctx, timeoutsOf(ctx).List)
+	err = zboxutil.HttpDoBlobber(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
 		if err != nil {
 			l.Logger.Error("Object tree:", err)
 			return err
@@ -63,7 +62,7 @@ func getObjectTreeFromBlobber(ctx context.Context, allocationID, allocationTx st
 	return lR.GetRefFromObjectTree(allocationID)
 }
 
-func getAllocationDataFromBlobber(blobber *blockchain.StorageNode, allocationTx string, respCh chan<- *BlobberAllocationStats, wg *sync.WaitGroup) {
+func getAllocationDataFromBlobber(ctx context.Context, blobber *blockchain.StorageNode, allocationTx string, respCh chan<- *BlobberAllocationStats, wg *sync.WaitGroup) {
 	defer wg.Done()
 	httpreq, err := zboxutil.NewAllocationRequest(blobber.Baseurl, allocationTx)
 	if err != nil {
@@ -72,8 +71,8 @@ func getAllocationDataFromBlobber(blobber *blockchain.StorageNode, allocationTx
 	}
 
 	var result BlobberAllocationStats
-	ctx, cncl := context.WithTimeout(context.Background(), (time.Second * 30))
-	err = zboxutil.HttpDo(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
+	ctx, cncl := context.WithTimeout(ctx, timeoutsOf(ctx).Meta)
+	err = zboxutil.HttpDoBlobber(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
 		if err != nil {
 			l.Logger.Error("Get allocation :", err)
 			return err
@@ -81,6 +80,7 @@ func getAllocationDataFromBlobber(blobber *blockchain.StorageNode, allocationTx
 		defer resp.Body.Close()
 		if resp.StatusCode != http.StatusOK {
 			l.Logger.Error("Get allocation response : ", resp.StatusCode)
+			return errors.New(strconv.Itoa(resp.StatusCode), "Get allocation error response")
 		}
 		resp_body, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
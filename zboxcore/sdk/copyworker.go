@@ -13,6 +13,7 @@ import (
 	"github.com/0chain/errors"
 
 	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/core/sys"
 	"github.com/0chain/gosdk/zboxcore/client"
 	"github.com/0chain/gosdk/zboxcore/fileref"
 	"github.com/0chain/gosdk/zboxcore/logger"
@@ -86,7 +87,7 @@ func (req *CopyRequest) copyBlobberObject(
 
 			httpreq.Header.Add("Content-Type", formWriter.FormDataContentType())
 			l.Logger.Info(httpreq.URL.Path)
-			ctx, cncl = context.WithTimeout(req.ctx, DefaultUploadTimeOut)
+			ctx, cncl = context.WithTimeout(req.ctx, timeoutsOf(req.ctx).Commit)
 			resp, err = zboxutil.Client.Do(httpreq.WithContext(ctx))
 			defer cncl()
 
@@ -121,7 +122,7 @@ func (req *CopyRequest) copyBlobberObject(
 					logger.Logger.Error(err)
 					return
 				}
-				time.Sleep(time.Duration(r) * time.Second)
+				sys.Sleep(time.Duration(r) * time.Second)
 				shouldContinue = true
 				return
 			}
@@ -166,9 +167,8 @@ func (req *CopyRequest) ProcessCopy() error {
 	wg.Wait()
 
 	if !req.isConsensusOk() {
-		return errors.New("consensus_not_met",
-			fmt.Sprintf("Copy failed. Required consensus %d, got %d",
-				req.Consensus.consensusThresh, req.Consensus.consensus))
+		return &constants.ErrConsensusNotMet{Op: "Copy",
+			Got: req.Consensus.consensus, Need: req.Consensus.consensusThresh}
 	}
 
 	writeMarkerMutex, err := CreateWriteMarkerMutex(client.GetClient(), req.allocationObj)
@@ -203,6 +203,7 @@ func (req *CopyRequest) ProcessCopy() error {
 			allocationTx: req.allocationTx,
 			blobber:      req.blobbers[pos],
 			connectionID: req.connectionID,
+			ctx:          req.ctx,
 			wg:           wg,
 		}
 		commitReq.changes = append(commitReq.changes, newChange)
@@ -226,9 +227,8 @@ func (req *CopyRequest) ProcessCopy() error {
 	}
 
 	if !req.isConsensusOk() {
-		return errors.New("consensus_not_met",
-			fmt.Sprintf("Commit on copy failed. Required consensus %d, got %d",
-				req.Consensus.consensusThresh, req.Consensus.consensus))
+		return &constants.ErrConsensusNotMet{Op: "Commit on copy",
+			Got: req.Consensus.consensus, Need: req.Consensus.consensusThresh}
 	}
 	return nil
 }
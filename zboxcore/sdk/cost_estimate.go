@@ -0,0 +1,184 @@
+package sdk
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+)
+
+// BlobberCost is the projected spend on one blobber.
+type BlobberCost struct {
+	BlobberID string `json:"blobber_id"`
+	// ShardSize is the size stored on the blobber, in bytes
+	ShardSize int64          `json:"shard_size"`
+	Write     common.Balance `json:"write"`
+	Read      common.Balance `json:"read"`
+}
+
+// CostEstimate is the projected write and read pool spend of storing data.
+// Prices are the blobber terms: the write price per GB and time unit of the
+// storage smart contract, the read price per GB read.
+type CostEstimate struct {
+	// Write is paid from the write pool to every blobber for its shard, for
+	// the duration of the allocation
+	Write common.Balance `json:"write"`
+	// Read is paid from the read pool to download the data once, from the
+	// cheapest blobbers holding enough shards to reconstruct it
+	Read common.Balance `json:"read"`
+	// MinLock is the minimum lock demand of a new allocation, it is only set
+	// by EstimateAllocationCost
+	MinLock    common.Balance `json:"min_lock,omitempty"`
+	PerBlobber []BlobberCost  `json:"per_blobber"`
+}
+
+// estimateCost computes the cost of size bytes erasure coded on blobbers
+// with the given terms, keyed by blobber id, and stored for duration. The
+// write prices are per timeUnit.
+func estimateCost(terms map[string]Terms, size int64, data, parity int, duration, timeUnit time.Duration) (*CostEstimate, error) {
+	if data <= 0 || parity < 0 {
+		return nil, errors.New("invalid_shards", "data shards must be positive")
+	}
+	if len(terms) < data+parity {
+		return nil, errors.New("not_enough_blobbers", "terms of all blobbers are required")
+	}
+	if timeUnit <= 0 {
+		return nil, errors.New("invalid_time_unit", "time unit must be positive")
+	}
+	if duration <= 0 {
+		return nil, errors.New("invalid_duration", "the allocation is expired")
+	}
+
+	shardSize := (size + int64(data) - 1) / int64(data)
+	shardGB := float64(shardSize) / GB
+	timeUnits := float64(duration) / float64(timeUnit)
+
+	estimate := &CostEstimate{PerBlobber: make([]BlobberCost, 0, len(terms))}
+	for id, t := range terms {
+		c := BlobberCost{
+			BlobberID: id,
+			ShardSize: shardSize,
+			Write:     common.Balance(float64(t.WritePrice) * shardGB * timeUnits),
+			Read:      common.Balance(float64(t.ReadPrice) * shardGB),
+		}
+		estimate.Write += c.Write
+		estimate.PerBlobber = append(estimate.PerBlobber, c)
+	}
+
+	sort.Slice(estimate.PerBlobber, func(i, j int) bool {
+		ci, cj := estimate.PerBlobber[i], estimate.PerBlobber[j]
+		if ci.Read != cj.Read {
+			return ci.Read < cj.Read
+		}
+		return ci.BlobberID < cj.BlobberID
+	})
+	for _, c := range estimate.PerBlobber[:data] {
+		estimate.Read += c.Read
+	}
+	return estimate, nil
+}
+
+// EstimateUploadCost returns the projected spend of uploading size bytes to
+// the allocation until it expires, using the current terms of its blobbers.
+func (a *Allocation) EstimateUploadCost(size int64) (*CostEstimate, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	current := &Allocation{ID: a.ID}
+	if err := GetAllocationUpdates(current); err != nil {
+		return nil, err
+	}
+	terms := make(map[string]Terms, len(current.BlobberDetails))
+	for _, d := range current.BlobberDetails {
+		terms[d.BlobberID] = d.Terms
+	}
+	duration := time.Until(time.Unix(current.Expiration, 0))
+	return estimateCost(terms, size, current.DataShards, current.ParityShards, duration, current.TimeUnit)
+}
+
+// EstimateAllocationCost returns the projected spend of filling an allocation
+// created with options until options.Expiry, and its minimum lock demand. The blobbers are
+// options.BlobberIds, or the ones the storage smart contract would pick.
+func EstimateAllocationCost(options CreateAllocationOptions) (*CostEstimate, error) {
+	if !sdkInitialized {
+		return nil, sdkNotInitialized
+	}
+
+	blobberIDs := options.BlobberIds
+	if len(blobberIDs) == 0 {
+		var err error
+		blobberIDs, err = GetAllocationBlobbers(options.DataShards, options.ParityShards,
+			options.Size, options.Expiry, options.ReadPrice, options.WritePrice)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get blobbers for allocation")
+		}
+	}
+
+	terms := make(map[string]Terms, len(blobberIDs))
+	for _, id := range blobberIDs {
+		blobber, err := GetBlobber(id)
+		if err != nil {
+			return nil, err
+		}
+		terms[id] = blobber.Terms
+	}
+	if n := options.DataShards + options.ParityShards; len(terms) > n {
+		terms = cheapestTerms(terms, n)
+	}
+
+	timeUnit, err := storageTimeUnit()
+	if err != nil {
+		return nil, err
+	}
+	duration := time.Until(time.Unix(options.Expiry, 0))
+	estimate, err := estimateCost(terms, options.Size, options.DataShards, options.ParityShards, duration, timeUnit)
+	if err != nil {
+		return nil, err
+	}
+	minLock, err := GetAllocationMinLock(options.DataShards, options.ParityShards,
+		options.Size, options.Expiry, options.ReadPrice, options.WritePrice)
+	if err != nil {
+		return nil, err
+	}
+	estimate.MinLock = common.Balance(minLock)
+	return estimate, nil
+}
+
+// storageTimeUnit returns the time unit of the write prices of the storage
+// smart contract.
+func storageTimeUnit() (time.Duration, error) {
+	conf, err := GetStorageSCConfig()
+	if err != nil {
+		return 0, err
+	}
+	v, ok := conf.Fields["time_unit"]
+	if !ok {
+		return 0, errors.New("invalid_time_unit", "time unit missing from the storage SC config")
+	}
+	timeUnit, err := time.ParseDuration(fmt.Sprint(v))
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid time unit of the storage SC config")
+	}
+	return timeUnit, nil
+}
+
+// cheapestTerms keeps the n blobbers with the lowest write price.
+func cheapestTerms(terms map[string]Terms, n int) map[string]Terms {
+	ids := make([]string, 0, len(terms))
+	for id := range terms {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if terms[ids[i]].WritePrice != terms[ids[j]].WritePrice {
+			return terms[ids[i]].WritePrice < terms[ids[j]].WritePrice
+		}
+		return ids[i] < ids[j]
+	})
+	kept := make(map[string]Terms, n)
+	for _, id := range ids[:n] {
+		kept[id] = terms[id]
+	}
+	return kept
+}
@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateCost(t *testing.T) {
+	terms := map[string]Terms{
+		"b1": {WritePrice: 1000, ReadPrice: 10},
+		"b2": {WritePrice: 2000, ReadPrice: 30},
+		"b3": {WritePrice: 3000, ReadPrice: 20},
+	}
+
+	const timeUnit = 720 * time.Hour
+
+	tests := []struct {
+		name      string
+		size      int64
+		data      int
+		parity    int
+		duration  time.Duration
+		wantWrite int64
+		wantRead  int64
+		wantErr   bool
+	}{
+		{name: "Test_Two_Data_One_Parity", size: 4 * GB, data: 2, parity: 1, duration: timeUnit, wantWrite: 12000, wantRead: 60},
+		{name: "Test_Single_Data_Shard", size: GB, data: 1, parity: 2, duration: timeUnit, wantWrite: 6000, wantRead: 10},
+		{name: "Test_Half_Time_Unit", size: GB, data: 1, parity: 2, duration: timeUnit / 2, wantWrite: 3000, wantRead: 10},
+		{name: "Test_Two_Time_Units", size: GB, data: 1, parity: 2, duration: 2 * timeUnit, wantWrite: 12000, wantRead: 10},
+		{name: "Test_Expired", size: GB, data: 1, parity: 2, duration: -time.Hour, wantErr: true},
+		{name: "Test_Not_Enough_Blobbers", size: GB, data: 2, parity: 2, duration: timeUnit, wantErr: true},
+		{name: "Test_No_Data_Shards", size: GB, data: 0, parity: 3, duration: timeUnit, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := estimateCost(terms, tt.size, tt.data, tt.parity, tt.duration, timeUnit)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, tt.wantWrite, got.Write)
+			require.EqualValues(t, tt.wantRead, got.Read)
+			require.Len(t, got.PerBlobber, 3)
+		})
+	}
+}
+
+func TestCheapestTerms(t *testing.T) {
+	terms := map[string]Terms{
+		"b1": {WritePrice: 3},
+		"b2": {WritePrice: 1},
+		"b3": {WritePrice: 2},
+	}
+	got := cheapestTerms(terms, 2)
+	require.Len(t, got, 2)
+	require.Contains(t, got, "b2")
+	require.Contains(t, got, "b3")
+}
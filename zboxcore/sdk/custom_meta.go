@@ -0,0 +1,50 @@
+package sdk
+
+import (
+	"encoding/json"
+)
+
+// maxCustomMetaSize is the max length of json encoded custom meta a file can carry.
+const maxCustomMetaSize = 4 * 1024
+
+// encodeCustomMeta encodes custom meta as json. encoding/json sorts map keys, so all
+// blobbers receive byte-identical meta and agree on it in consensus.
+func encodeCustomMeta(meta map[string]string) string {
+	if len(meta) == 0 {
+		return ""
+	}
+	buf, _ := json.Marshal(meta)
+	return string(buf)
+}
+
+// decodeCustomMeta decodes custom meta stored on blobbers. Meta that was not
+// written by WithCustomMeta is ignored.
+func decodeCustomMeta(s string) map[string]string {
+	if s == "" {
+		return nil
+	}
+	meta := make(map[string]string)
+	if err := json.Unmarshal([]byte(s), &meta); err != nil {
+		return nil
+	}
+	return meta
+}
+
+func isValidCustomMeta(meta map[string]string) bool {
+	for k := range meta {
+		if k == "" {
+			return false
+		}
+	}
+	return len(encodeCustomMeta(meta)) <= maxCustomMetaSize
+}
+
+// matchCustomMeta reports whether every key/value pair of want is present in have.
+func matchCustomMeta(want, have map[string]string) bool {
+	for k, v := range want {
+		if hv, ok := have[k]; !ok || hv != v {
+			return false
+		}
+	}
+	return true
+}
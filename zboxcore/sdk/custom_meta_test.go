@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomMeta_isValid(t *testing.T) {
+	tests := []struct {
+		name string
+		meta map[string]string
+		want bool
+	}{
+		{name: "Test_Nil", meta: nil, want: true},
+		{name: "Test_Empty", meta: map[string]string{}, want: true},
+		{name: "Test_Valid", meta: map[string]string{"album": "summer", "camera": "x100"}, want: true},
+		{name: "Test_Empty_Value", meta: map[string]string{"album": ""}, want: true},
+		{name: "Test_Empty_Key", meta: map[string]string{"": "summer"}, want: false},
+		{name: "Test_Max_Size", meta: map[string]string{"k": strings.Repeat("v", maxCustomMetaSize-len(`{"k":""}`))}, want: true},
+		{name: "Test_Too_Large", meta: map[string]string{"k": strings.Repeat("v", maxCustomMetaSize)}, want: false},
+		// the limit holds on the encoded meta, escaping counts
+		{name: "Test_Too_Large_Escaped", meta: map[string]string{"k": strings.Repeat(`"`, maxCustomMetaSize/2)}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isValidCustomMeta(tt.meta))
+		})
+	}
+}
+
+func TestCustomMeta_encodeDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		meta    map[string]string
+		encoded string
+	}{
+		{name: "Test_Nil", meta: nil, encoded: ""},
+		{name: "Test_Single", meta: map[string]string{"album": "summer"}, encoded: `{"album":"summer"}`},
+		{name: "Test_Sorted_Keys", meta: map[string]string{"camera": "x100", "album": "summer"}, encoded: `{"album":"summer","camera":"x100"}`},
+		{name: "Test_Escaped", meta: map[string]string{"note": `"quoted" & <tagged>`}, encoded: `{"note":"\"quoted\" \u0026 \u003ctagged\u003e"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			encoded := encodeCustomMeta(tt.meta)
+			require.Equal(tt.encoded, encoded)
+			require.Equal(tt.meta, decodeCustomMeta(encoded))
+		})
+	}
+}
+
+func TestCustomMeta_decodeInvalid(t *testing.T) {
+	for _, s := range []string{"", "not json", `["album"]`, `{"album":1}`} {
+		require.Nil(t, decodeCustomMeta(s), s)
+	}
+}
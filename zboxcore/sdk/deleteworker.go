@@ -12,6 +12,7 @@ import (
 	"github.com/0chain/errors"
 
 	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/core/sys"
 	"github.com/0chain/gosdk/zboxcore/allocationchange"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
 	"github.com/0chain/gosdk/zboxcore/client"
@@ -97,7 +98,7 @@ func (req *DeleteRequest) deleteBlobberFile(
 					logger.Logger.Error(err)
 					return
 				}
-				time.Sleep(time.Duration(r) * time.Second)
+				sys.Sleep(time.Duration(r) * time.Second)
 				shouldContinue = true
 				return
 			}
@@ -194,9 +195,8 @@ func (req *DeleteRequest) ProcessDelete() (err error) {
 	req.wg.Wait()
 
 	if !req.consensus.isConsensusOk() {
-		return errors.New("consensus_not_met",
-			fmt.Sprintf("Consensus on delete failed. Required consensus %d got %d",
-				req.consensus.consensusThresh, req.consensus.getConsensus()))
+		return &constants.ErrConsensusNotMet{Op: "Delete",
+			Got: req.consensus.getConsensus(), Need: req.consensus.consensusThresh}
 	}
 
 	writeMarkerMutex, err := CreateWriteMarkerMutex(client.GetClient(), req.allocationObj)
@@ -231,6 +231,7 @@ func (req *DeleteRequest) ProcessDelete() (err error) {
 			allocationTx: req.allocationTx,
 			blobber:      req.blobbers[pos],
 			connectionID: req.connectionID,
+			ctx:          req.ctx,
 			wg:           wg,
 		}
 		commitReq.changes = append(commitReq.changes, newChange)
@@ -254,9 +255,8 @@ func (req *DeleteRequest) ProcessDelete() (err error) {
 	}
 
 	if !req.consensus.isConsensusOk() {
-		return errors.New("consensus_not_met",
-			fmt.Sprintf("Consensus on commit not met. Required %d, got %d",
-				req.consensus.consensusThresh, req.consensus.getConsensus()))
+		return &constants.ErrConsensusNotMet{Op: "Commit on delete",
+			Got: req.consensus.getConsensus(), Need: req.consensus.consensusThresh}
 	}
 	return nil
 }
@@ -0,0 +1,293 @@
+package sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	l "github.com/0chain/gosdk/zboxcore/logger"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/klauspost/reedsolomon"
+	"github.com/mitchellh/go-homedir"
+)
+
+// DeltaUpdateHeader marks the upload requests of a delta update: the blobber
+// starts the file of the connection from its committed version, and only the
+// chunks that changed are sent. It's only sent to blobbers advertising delta
+// updates in their chunk hashes, a blobber ignoring it would commit the
+// changed chunks alone.
+const DeltaUpdateHeader = "X-Upload-Delta"
+
+// DeltaUpdateResult tells what Allocation.UpdateFileDelta uploaded.
+type DeltaUpdateResult struct {
+	// TotalChunks is the number of chunks of a shard of the file.
+	TotalChunks int `json:"total_chunks"`
+	// ChangedChunks are the indexes of the chunks uploaded.
+	ChangedChunks []int `json:"changed_chunks"`
+	// FullUpload is set when the file was uploaded whole, as the blobbers
+	// couldn't tell the hashes of their chunks or don't support delta
+	// updates, or as the chunks committed by the delta update didn't match
+	// the local ones.
+	FullUpload bool `json:"full_upload,omitempty"`
+}
+
+// withDeltaChunks uploads only the chunks at the indexes of changed, the
+// other chunks being committed on the blobbers already.
+func withDeltaChunks(changed []int) ChunkedUploadOption {
+	return func(su *ChunkedUpload) {
+		su.deltaChunks = make(map[int]bool, len(changed))
+		for _, i := range changed {
+			su.deltaChunks[i] = true
+		}
+	}
+}
+
+// isDeltaSkipped tells whether the chunks from start to end are committed on
+// the blobbers already, so they are hashed but not sent. The final chunks
+// are always sent, they carry the hashes of the file.
+func (su *ChunkedUpload) isDeltaSkipped(start, end int, isFinal bool) bool {
+	if su.deltaChunks == nil || isFinal {
+		return false
+	}
+	for i := start; i <= end; i++ {
+		if su.deltaChunks[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateFileDelta updates the file at remotePath with the file at localPath,
+// uploading only the chunks that changed: the hashes of the chunks of every
+// blobber are compared to the hashes of the local chunks, erasure coded the
+// same way. The file is uploaded whole if a blobber can't tell the hashes of
+// its chunks or doesn't advertise delta updates, or if it's encrypted. Once
+// committed, the hashes of the chunks are checked again and the file is
+// uploaded whole if they don't match.
+func (a *Allocation) UpdateFileDelta(localPath, remotePath string) (*DeltaUpdateResult, error) {
+	return a.UpdateFileDeltaCtx(context.Background(), localPath, remotePath)
+}
+
+// UpdateFileDeltaCtx uploads the chunks of localPath that changed since
+// remotePath was uploaded.
+func (a *Allocation) UpdateFileDeltaCtx(ctx context.Context, localPath, remotePath string) (*DeltaUpdateResult, error) {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	remotePath = zboxutil.RemoteClean(remotePath)
+	if !zboxutil.IsRemoteAbs(remotePath) {
+		return nil, errors.New("invalid_path", "Path should be valid and absolute")
+	}
+	remotePath = zboxutil.GetFullRemotePath(localPath, remotePath)
+
+	workdir, _ := homedir.Dir()
+	fullUpdate := func(result *DeltaUpdateResult) (*DeltaUpdateResult, error) {
+		result.FullUpload = true
+		result.ChangedChunks = nil
+		err := a.StartChunkedUploadCtx(ctx, workdir, localPath, remotePath, nil, true, false, "", false)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	ref, err := a.GetFileMetaCtx(ctx, remotePath)
+	if err != nil {
+		return nil, err
+	}
+	if ref.EncryptedKey != "" {
+		// the encrypted chunks can't be compared to the local ones
+		return fullUpdate(&DeltaUpdateResult{})
+	}
+
+	f, err := sys.Files.Open(localPath)
+	if err != nil {
+		return nil, err
+	}
+	localHashes, err := shardChunkHashes(f, DefaultChunkSize, a.DataShards, a.ParityShards)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+	result := &DeltaUpdateResult{}
+	if len(localHashes) > 0 {
+		result.TotalChunks = len(localHashes[0])
+	}
+
+	remoteHashes, deltaUpdates, err := a.getChunkHashes(ctx, remotePath)
+	if err != nil {
+		l.Logger.Info("delta update not possible, uploading ", remotePath, " whole: ", err)
+		return fullUpdate(result)
+	}
+	if !deltaUpdates {
+		l.Logger.Info("delta update not supported by the blobbers, uploading ", remotePath, " whole")
+		return fullUpdate(result)
+	}
+
+	result.ChangedChunks = diffChunkHashes(localHashes, remoteHashes)
+	resized := false
+	for _, hashes := range remoteHashes {
+		resized = resized || len(hashes) != result.TotalChunks
+	}
+	if len(result.ChangedChunks) == 0 && !resized {
+		return result, nil
+	}
+
+	err = a.StartChunkedUploadCtx(ctx, workdir, localPath, remotePath, nil, true, false, "", false,
+		withDeltaChunks(result.ChangedChunks))
+	if err != nil {
+		return nil, err
+	}
+
+	committed, _, err := a.getChunkHashes(ctx, remotePath)
+	if err != nil || !sameChunkHashes(localHashes, committed) {
+		l.Logger.Error("delta update of ", remotePath, " not committed as expected, uploading it whole: ", err)
+		return fullUpdate(result)
+	}
+	return result, nil
+}
+
+// shardChunkHashes returns the hashes of the chunks of every blobber, by
+// blobber position, of the content of r as uploaded. The hashes are the
+// leaves of the content hash of the blobbers.
+func shardChunkHashes(r io.Reader, chunkSize int64, dataShards, parityShards int) ([][]string, error) {
+	encoder, err := reedsolomon.New(dataShards, parityShards, reedsolomon.WithAutoGoroutines(int(chunkSize)))
+	if err != nil {
+		return nil, err
+	}
+	uploadMask := zboxutil.NewUint128(1).Lsh(uint64(dataShards + parityShards)).Sub64(1)
+	reader, err := createChunkReader(r, 0, chunkSize, dataShards, false, uploadMask, encoder, nil, CreateHasher(int(chunkSize)))
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([][]string, dataShards+parityShards)
+	for {
+		chunk, err := reader.Next()
+		if err != nil {
+			return nil, err
+		}
+		if chunk.ReadSize > 0 {
+			for pos, fragment := range chunk.Fragments {
+				sum := sha256.Sum256(fragment)
+				hashes[pos] = append(hashes[pos], hex.EncodeToString(sum[:]))
+			}
+		}
+		if chunk.IsFinal {
+			return hashes, nil
+		}
+	}
+}
+
+// diffChunkHashes returns the indexes of the local chunks that a blobber
+// doesn't have.
+func diffChunkHashes(local [][]string, remote map[int][]string) []int {
+	changed := []int{}
+	if len(local) == 0 {
+		return changed
+	}
+	for i := range local[0] {
+		for pos := range local {
+			if hashes := remote[pos]; i >= len(hashes) || hashes[i] != local[pos][i] {
+				changed = append(changed, i)
+				break
+			}
+		}
+	}
+	return changed
+}
+
+// sameChunkHashes tells whether every blobber has the local chunks, and
+// only them.
+func sameChunkHashes(local [][]string, remote map[int][]string) bool {
+	if len(diffChunkHashes(local, remote)) > 0 {
+		return false
+	}
+	for pos := range local {
+		if len(remote[pos]) != len(local[pos]) {
+			return false
+		}
+	}
+	return true
+}
+
+type chunkHashesResponse struct {
+	ChunkHashes []string `json:"chunk_hashes"`
+	// DeltaUpdates is set by the blobbers applying DeltaUpdateHeader
+	DeltaUpdates bool `json:"delta_updates"`
+}
+
+// getChunkHashes returns the hashes of the chunks of the file at remotePath
+// of every blobber, by blobber position, and whether they all advertise delta
+// updates. It fails if a blobber doesn't tell them.
+func (a *Allocation) getChunkHashes(ctx context.Context, remotePath string) (map[int][]string, bool, error) {
+	var (
+		mu           sync.Mutex
+		wg           sync.WaitGroup
+		firstErr     error
+		hashes       = make(map[int][]string, len(a.Blobbers))
+		deltaUpdates = true
+	)
+	for pos, blobber := range a.Blobbers {
+		wg.Add(1)
+		go func(pos int, blobber *blockchain.StorageNode) {
+			defer wg.Done()
+			h, err := a.getChunkHashesFromBlobber(ctx, blobber, remotePath)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = errors.Wrap(err, blobber.Baseurl)
+				}
+				return
+			}
+			hashes[pos] = h.ChunkHashes
+			deltaUpdates = deltaUpdates && h.DeltaUpdates
+		}(pos, blobber)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, false, firstErr
+	}
+	return hashes, deltaUpdates, nil
+}
+
+func (a *Allocation) getChunkHashesFromBlobber(ctx context.Context, blobber *blockchain.StorageNode, remotePath string) (*chunkHashesResponse, error) {
+	httpreq, err := zboxutil.NewChunkHashesRequest(blobber.Baseurl, a.Tx, remotePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var rsp chunkHashesResponse
+	ctx, cncl := context.WithTimeout(ctx, timeoutsOf(ctx).Meta)
+	err = zboxutil.HttpDoBlobber(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Wrap(err, "Error: Resp")
+		}
+		if resp.StatusCode != http.StatusOK {
+			return errors.New(resp.Status, string(body))
+		}
+		return json.Unmarshal(body, &rsp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
@@ -0,0 +1,118 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/mocks"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffChunkHashes(t *testing.T) {
+	local := [][]string{{"a", "b", "c"}, {"d", "e", "f"}}
+
+	for _, tc := range []struct {
+		name   string
+		remote map[int][]string
+		want   []int
+	}{
+		{name: "Test_Unchanged", remote: map[int][]string{0: {"a", "b", "c"}, 1: {"d", "e", "f"}}, want: []int{}},
+		{name: "Test_Changed_On_One_Blobber", remote: map[int][]string{0: {"a", "b", "c"}, 1: {"d", "x", "f"}}, want: []int{1}},
+		{name: "Test_Grown", remote: map[int][]string{0: {"a"}, 1: {"d"}}, want: []int{1, 2}},
+		{name: "Test_Shrunk", remote: map[int][]string{0: {"a", "b", "c", "z"}, 1: {"d", "e", "f", "z"}}, want: []int{}},
+		{name: "Test_Missing_Blobber", remote: map[int][]string{0: {"a", "b", "c"}}, want: []int{0, 1, 2}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, diffChunkHashes(local, tc.remote))
+		})
+	}
+}
+
+func TestChunkedUploadIsDeltaSkipped(t *testing.T) {
+	su := &ChunkedUpload{}
+	require.False(t, su.isDeltaSkipped(0, 0, false), "not a delta update")
+
+	withDeltaChunks([]int{2, 5})(su)
+	require.True(t, su.isDeltaSkipped(0, 1, false))
+	require.False(t, su.isDeltaSkipped(1, 2, false))
+	require.True(t, su.isDeltaSkipped(3, 4, false))
+	require.False(t, su.isDeltaSkipped(3, 4, true), "the final chunks are sent")
+}
+
+func TestShardChunkHashes(t *testing.T) {
+	const chunkSize = 64 * 1024
+	content := make([]byte, 5*2*chunkSize+1234)
+	rand.New(rand.NewSource(1)).Read(content)
+
+	original, err := shardChunkHashes(bytes.NewReader(content), chunkSize, 2, 1)
+	require.NoError(t, err)
+	require.Len(t, original, 3)
+	for _, hashes := range original {
+		require.Len(t, hashes, 6)
+	}
+
+	modified := append([]byte(nil), content...)
+	modified[3*2*chunkSize+10] ^= 0xff
+	changed, err := shardChunkHashes(bytes.NewReader(modified), chunkSize, 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, []int{3}, diffChunkHashes(changed, map[int][]string{0: original[0], 1: original[1], 2: original[2]}))
+}
+
+func TestSameChunkHashes(t *testing.T) {
+	local := [][]string{{"a", "b"}, {"c", "d"}}
+	require.True(t, sameChunkHashes(local, map[int][]string{0: {"a", "b"}, 1: {"c", "d"}}))
+	require.False(t, sameChunkHashes(local, map[int][]string{0: {"a", "b"}, 1: {"c", "x"}}))
+	require.False(t, sameChunkHashes(local, map[int][]string{0: {"a", "b"}, 1: {"c", "d", "e"}}), "stale chunks left")
+	require.False(t, sameChunkHashes(local, map[int][]string{0: {"a", "b"}}))
+}
+
+func TestAllocation_getChunkHashes(t *testing.T) {
+	tests := []struct {
+		name             string
+		bodies           map[string]string
+		wantDeltaUpdates bool
+	}{
+		{
+			name: "Test_Delta_Updates_Advertised",
+			bodies: map[string]string{
+				"b0": `{"chunk_hashes":["a"],"delta_updates":true}`,
+				"b1": `{"chunk_hashes":["b"],"delta_updates":true}`,
+			},
+			wantDeltaUpdates: true,
+		},
+		{
+			name: "Test_Delta_Updates_Not_Advertised",
+			bodies: map[string]string{
+				"b0": `{"chunk_hashes":["a"],"delta_updates":true}`,
+				"b1": `{"chunk_hashes":["b"]}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mockClient = mocks.HttpClient{}
+			mockClient.On("Do", mock.Anything).Return(func(req *http.Request) *http.Response {
+				require.True(t, strings.HasPrefix(req.URL.Path, zboxutil.CHUNK_HASHES_ENDPOINT))
+				return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(tt.bodies[req.URL.Host]))}
+			}, nil)
+			zboxutil.Client = &mockClient
+
+			a := &Allocation{Tx: mockAllocationTxId, Blobbers: []*blockchain.StorageNode{
+				{ID: "b0", Baseurl: "http://b0"},
+				{ID: "b1", Baseurl: "http://b1"},
+			}}
+			hashes, deltaUpdates, err := a.getChunkHashes(context.Background(), "/f")
+			require.NoError(t, err)
+			require.Equal(t, map[int][]string{0: {"a"}, 1: {"b"}}, hashes)
+			require.Equal(t, tt.wantDeltaUpdates, deltaUpdates)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package sdk
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/core/version"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	"github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/encryption"
+)
+
+// errorWindow is how far back Diagnostics counts errors
+const errorWindow = 15 * time.Minute
+
+// maxRecordedErrors bounds the memory used by the error counts
+const maxRecordedErrors = 1000
+
+type (
+	// DiagnosticsReport is the state of the sdk returned by Diagnostics.
+	DiagnosticsReport struct {
+		Version     string                `json:"version"`
+		Time        time.Time             `json:"time"`
+		Initialized bool                  `json:"initialized"`
+		Network     NetworkDiagnostics    `json:"network"`
+		Wallet      WalletDiagnostics     `json:"wallet"`
+		Workers     WorkerDiagnostics     `json:"workers"`
+		Errors      ErrorDiagnostics      `json:"errors"`
+		Encryption  encryption.CipherInfo `json:"encryption"`
+	}
+
+	// NetworkDiagnostics is the network profile and the age of the cached
+	// miners and sharders.
+	NetworkDiagnostics struct {
+		ChainID         string    `json:"chain_id"`
+		BlockWorker     string    `json:"block_worker"`
+		Miners          int       `json:"miners"`
+		Sharders        int       `json:"sharders"`
+		MinSubmit       int       `json:"min_submit"`
+		MinConfirmation int       `json:"min_confirmation"`
+		UpdatedAt       time.Time `json:"updated_at,omitempty"`
+		// AgeSeconds since the miners and sharders were refreshed, -1 if never
+		AgeSeconds int64 `json:"age_seconds"`
+	}
+
+	// WalletDiagnostics tells whether a wallet is set, without its keys.
+	WalletDiagnostics struct {
+		ClientID        string `json:"client_id,omitempty"`
+		SignatureScheme string `json:"signature_scheme,omitempty"`
+		HasKeys         bool   `json:"has_keys"`
+	}
+
+	// WorkerDiagnostics are the requests queued to the per blobber workers.
+	WorkerDiagnostics struct {
+		CommitWorkers   int            `json:"commit_workers"`
+		CommitQueued    map[string]int `json:"commit_queued,omitempty"`
+		DownloadWorkers int            `json:"download_workers"`
+		DownloadQueued  map[string]int `json:"download_queued,omitempty"`
+	}
+
+	// ErrorDiagnostics counts the failed operations of the last WindowSeconds.
+	ErrorDiagnostics struct {
+		WindowSeconds int64          `json:"window_seconds"`
+		Total         int            `json:"total"`
+		ByOperation   map[string]int `json:"by_operation,omitempty"`
+		LastError     string         `json:"last_error,omitempty"`
+		LastErrorAt   time.Time      `json:"last_error_at,omitempty"`
+	}
+)
+
+// Diagnostics returns the state of the sdk, e.g. to expose it on the health
+// endpoint of a service embedding it. It is cheap and makes no request.
+func Diagnostics() *DiagnosticsReport {
+	now := time.Now()
+	report := &DiagnosticsReport{
+		Version:     version.VERSIONSTR,
+		Time:        now,
+		Initialized: sdkInitialized,
+		Network:     networkDiagnostics(now),
+		Workers:     workerDiagnostics(),
+		Errors:      recentErrors.diagnostics(now),
+		Encryption:  encryption.GetCipherInfo(),
+	}
+
+	if c := client.GetClient(); c != nil && c.Wallet != nil {
+		report.Wallet = WalletDiagnostics{
+			ClientID:        c.ClientID,
+			SignatureScheme: c.SignatureScheme,
+			HasKeys:         len(c.Keys) > 0,
+		}
+	}
+	return report
+}
+
+var (
+	networkMu        sync.RWMutex
+	networkUpdatedAt time.Time
+)
+
+// markNetworkUpdated records that the miners and sharders were refreshed.
+func markNetworkUpdated() {
+	networkMu.Lock()
+	networkUpdatedAt = time.Now()
+	networkMu.Unlock()
+}
+
+func networkDiagnostics(now time.Time) NetworkDiagnostics {
+	networkMu.RLock()
+	updatedAt := networkUpdatedAt
+	networkMu.RUnlock()
+
+	nd := NetworkDiagnostics{
+		ChainID:         blockchain.GetChainID(),
+		BlockWorker:     blockchain.GetBlockWorker(),
+		Miners:          len(blockchain.GetMiners()),
+		Sharders:        len(blockchain.GetSharders()),
+		MinSubmit:       blockchain.GetMinSubmit(),
+		MinConfirmation: blockchain.GetMinConfirmation(),
+		UpdatedAt:       updatedAt,
+		AgeSeconds:      -1,
+	}
+	if !updatedAt.IsZero() {
+		nd.AgeSeconds = int64(now.Sub(updatedAt) / time.Second)
+	}
+	return nd
+}
+
+func workerDiagnostics() WorkerDiagnostics {
+	var wd WorkerDiagnostics
+
+	initCommitMutex.Lock()
+	wd.CommitWorkers = len(commitChan)
+	for id, ch := range commitChan {
+		if n := len(ch); n > 0 {
+			if wd.CommitQueued == nil {
+				wd.CommitQueued = make(map[string]int)
+			}
+			wd.CommitQueued[id] = n
+		}
+	}
+	initCommitMutex.Unlock()
+
+	initDownloadMutex.Lock()
+	wd.DownloadWorkers = len(downloadBlockChan)
+	for id, ch := range downloadBlockChan {
+		if n := len(ch); n > 0 {
+			if wd.DownloadQueued == nil {
+				wd.DownloadQueued = make(map[string]int)
+			}
+			wd.DownloadQueued[id] = n
+		}
+	}
+	initDownloadMutex.Unlock()
+
+	return wd
+}
+
+type errorEvent struct {
+	op  string
+	err string
+	at  time.Time
+}
+
+// errorLog keeps the errors of the last errorWindow, at most maxRecordedErrors.
+type errorLog struct {
+	mu     sync.Mutex
+	events []errorEvent
+}
+
+var recentErrors = &errorLog{}
+
+// recordError counts a failed operation in Diagnostics.
+func recordError(op string, err error) {
+	if err == nil {
+		return
+	}
+	recentErrors.record(op, err.Error(), time.Now())
+}
+
+func (l *errorLog) record(op, msg string, at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(at)
+	if len(l.events) >= maxRecordedErrors {
+		l.events = l.events[1:]
+	}
+	l.events = append(l.events, errorEvent{op: op, err: msg, at: at})
+}
+
+// prune drops the events older than errorWindow, l.mu is held.
+func (l *errorLog) prune(now time.Time) {
+	cutoff := now.Add(-errorWindow)
+	i := sort.Search(len(l.events), func(i int) bool {
+		return l.events[i].at.After(cutoff)
+	})
+	l.events = l.events[i:]
+}
+
+func (l *errorLog) diagnostics(now time.Time) ErrorDiagnostics {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(now)
+
+	ed := ErrorDiagnostics{
+		WindowSeconds: int64(errorWindow / time.Second),
+		Total:         len(l.events),
+	}
+	if len(l.events) == 0 {
+		return ed
+	}
+	ed.ByOperation = make(map[string]int)
+	for _, ev := range l.events {
+		ed.ByOperation[ev.op]++
+	}
+	last := l.events[len(l.events)-1]
+	ed.LastError, ed.LastErrorAt = last.err, last.at
+	return ed
+}
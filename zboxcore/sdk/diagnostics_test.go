@@ -0,0 +1,39 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorLog(t *testing.T) {
+	now := time.Now()
+	l := &errorLog{}
+	l.record("upload", "old", now.Add(-errorWindow-time.Minute))
+	l.record("upload", "first", now.Add(-time.Minute))
+	l.record("commit", "second", now)
+
+	got := l.diagnostics(now)
+	require.Equal(t, 2, got.Total)
+	require.Equal(t, map[string]int{"upload": 1, "commit": 1}, got.ByOperation)
+	require.Equal(t, "second", got.LastError)
+
+	for i := 0; i < maxRecordedErrors+10; i++ {
+		l.record("download", "err", now)
+	}
+	require.Equal(t, maxRecordedErrors, l.diagnostics(now).Total)
+
+	require.Equal(t, 0, l.diagnostics(now.Add(errorWindow+time.Second)).Total)
+}
+
+func TestDiagnostics(t *testing.T) {
+	markNetworkUpdated()
+	report := Diagnostics()
+	require.NotEmpty(t, report.Version)
+	require.GreaterOrEqual(t, report.Network.AgeSeconds, int64(0))
+
+	_, err := json.Marshal(report)
+	require.NoError(t, err)
+}
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/core/sys"
 	"github.com/0chain/gosdk/zboxcore/allocationchange"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
 	"github.com/0chain/gosdk/zboxcore/client"
@@ -66,7 +68,8 @@ func (req *DirRequest) ProcessDir(a *Allocation) error {
 	req.wg.Wait()
 
 	if !req.isConsensusOk() {
-		return errors.New("consensus_not_met", "directory creation failed due to consensus not met")
+		return &constants.ErrConsensusNotMet{Op: "Directory creation",
+			Got: req.getConsensus(), Need: req.consensusThresh}
 	}
 
 	writeMarkerMU, err := CreateWriteMarkerMutex(client.GetClient(), a)
@@ -107,6 +110,7 @@ func (req *DirRequest) commitRequest(existingDirCount int) error {
 
 		commitReq.changes = append(commitReq.changes, newChange)
 		commitReq.connectionID = req.connectionID
+		commitReq.ctx = req.ctx
 		commitReq.wg = wg
 		commitReqs[c] = commitReq
 		c++
@@ -128,7 +132,8 @@ func (req *DirRequest) commitRequest(existingDirCount int) error {
 	}
 
 	if !req.isConsensusOk() {
-		return errors.New("consensus_not_met", "directory creation failed due consensus not met")
+		return &constants.ErrConsensusNotMet{Op: "Commit on directory creation",
+			Got: req.getConsensus(), Need: req.consensusThresh}
 	}
 	return nil
 }
@@ -166,7 +171,7 @@ func (req *DirRequest) createDirInBlobber(blobber *blockchain.StorageNode, pos u
 
 	for i := 0; i < 3; i++ {
 		err, shouldContinue = func() (err error, shouldContinue bool) {
-			ctx, cncl := context.WithTimeout(req.ctx, (time.Second * 30))
+			ctx, cncl := context.WithTimeout(req.ctx, timeoutsOf(req.ctx).Commit)
 			resp, err = zboxutil.Client.Do(httpreq.WithContext(ctx))
 			cncl()
 			if err != nil {
@@ -194,7 +199,7 @@ func (req *DirRequest) createDirInBlobber(blobber *blockchain.StorageNode, pos u
 					return
 				}
 				l.Logger.Debug(fmt.Sprintf("Got too many request error. Retrying after %d seconds", r))
-				time.Sleep(time.Duration(r) * time.Second)
+				sys.Sleep(time.Duration(r) * time.Second)
 				shouldContinue = true
 				return
 			}
@@ -14,6 +14,8 @@ type DownloadRequestHeader struct {
 	ReadMarker   []byte
 	AuthToken    []byte
 	DownloadMode string
+	// VerifyDownload asks the blobber for the merkle proofs of the blocks
+	VerifyDownload bool
 }
 
 // ToHeader update header
@@ -47,4 +49,8 @@ func (h *DownloadRequestHeader) ToHeader(req *http.Request) {
 		req.Header.Set("X-Mode", h.DownloadMode)
 	}
 
+	if h.VerifyDownload {
+		req.Header.Set("X-Verify-Download", "true")
+	}
+
 }
@@ -0,0 +1,59 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/util"
+)
+
+// ErrInvalidMerkleProof is returned for the blocks of a blobber that don't
+// match the content hash the blobber committed for the file.
+var ErrInvalidMerkleProof = errors.New("invalid_merkle_proof", "downloaded block doesn't match the committed content hash")
+
+// VerifyDownloads sets whether the blocks downloaded from the blobbers are
+// verified against the content hash each blobber committed for the file. A
+// blobber returning a block whose merkle proof doesn't match is dropped and
+// the block is fetched from the other blobbers. It applies to the downloads
+// started afterwards.
+func (a *Allocation) VerifyDownloads(verify bool) {
+	var v int32
+	if verify {
+		v = 1
+	}
+	atomic.StoreInt32(&a.verifyDownloads, v)
+}
+
+func (a *Allocation) isVerifyingDownloads() bool {
+	return atomic.LoadInt32(&a.verifyDownloads) == 1
+}
+
+// verifyBlockProofs checks that the chunks of a blobber starting at
+// blockNum, counted from 1, are leaves of the merkle tree with root, the
+// content hash of the blobber. The leaves are the sha256 of the chunks, as
+// hashed on upload.
+func verifyBlockProofs(chunks [][]byte, proofs []*util.MTPath, blockNum int64, root string) error {
+	if root == "" {
+		return errors.Wrap(ErrInvalidMerkleProof, "no content hash committed")
+	}
+	if len(proofs) != len(chunks) {
+		return errors.Wrap(ErrInvalidMerkleProof,
+			fmt.Sprintf("got %d proofs for %d blocks", len(proofs), len(chunks)))
+	}
+
+	for i, chunk := range chunks {
+		proof := proofs[i]
+		index := int(blockNum) - 1 + i
+		if proof == nil || proof.LeafIndex != index {
+			return errors.Wrap(ErrInvalidMerkleProof, fmt.Sprintf("no proof for block %d", index+1))
+		}
+		sum := sha256.Sum256(chunk)
+		if !util.VerifyMerklePath(hex.EncodeToString(sum[:]), proof, root) {
+			return errors.Wrap(ErrInvalidMerkleProof, fmt.Sprintf("block %d", index+1))
+		}
+	}
+	return nil
+}
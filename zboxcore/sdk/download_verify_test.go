@@ -0,0 +1,74 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBlockProofs(t *testing.T) {
+	const chunkSize = 64
+	chunks := make([][]byte, 5)
+	leaves := make([]util.Hashable, len(chunks))
+	hasher := CreateHasher(chunkSize)
+	for i := range chunks {
+		chunks[i] = generateRandomBytes(chunkSize)
+		sum := sha256.Sum256(chunks[i])
+		leaves[i] = util.NewStringHashable(hex.EncodeToString(sum[:]))
+		require.NoError(t, hasher.WriteHashToContent(leaves[i].GetHash(), i))
+	}
+	root, err := hasher.GetContentHash()
+	require.NoError(t, err)
+
+	mt := &util.MerkleTree{}
+	mt.ComputeTree(leaves)
+	require.Equal(t, root, mt.GetRoot(), "the proofs are checked against the content hash of the upload")
+
+	proofs := func(from, to int) []*util.MTPath {
+		var p []*util.MTPath
+		for i := from; i < to; i++ {
+			p = append(p, mt.GetPathByIndex(i))
+		}
+		return p
+	}
+	tampered := append([]byte(nil), chunks[3]...)
+	tampered[0] ^= 0xff
+
+	for _, tc := range []struct {
+		name     string
+		chunks   [][]byte
+		proofs   []*util.MTPath
+		blockNum int64
+		root     string
+		wantErr  bool
+	}{
+		{name: "Test_All_Blocks", chunks: chunks, proofs: proofs(0, 5), blockNum: 1, root: root},
+		{name: "Test_Range", chunks: chunks[2:4], proofs: proofs(2, 4), blockNum: 3, root: root},
+		{name: "Test_Tampered_Block", chunks: [][]byte{chunks[2], tampered}, proofs: proofs(2, 4), blockNum: 3, root: root, wantErr: true},
+		{name: "Test_Proof_Of_Other_Block", chunks: chunks[2:4], proofs: proofs(1, 3), blockNum: 3, root: root, wantErr: true},
+		{name: "Test_Missing_Proofs", chunks: chunks[2:4], proofs: proofs(2, 3), blockNum: 3, root: root, wantErr: true},
+		{name: "Test_No_Content_Hash", chunks: chunks, proofs: proofs(0, 5), blockNum: 1, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyBlockProofs(tc.chunks, tc.proofs, tc.blockNum, tc.root)
+			if tc.wantErr {
+				require.True(t, errors.Is(err, ErrInvalidMerkleProof), err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAllocationVerifyDownloads(t *testing.T) {
+	a := &Allocation{}
+	require.False(t, a.isVerifyingDownloads())
+	a.VerifyDownloads(true)
+	require.True(t, a.isVerifyingDownloads())
+	a.VerifyDownloads(false)
+	require.False(t, a.isVerifyingDownloads())
+}
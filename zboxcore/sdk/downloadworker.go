@@ -7,10 +7,13 @@ import (
 	"io"
 	"math"
 	"os"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
 	"github.com/0chain/gosdk/core/sys"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
 	"github.com/0chain/gosdk/zboxcore/client"
@@ -47,10 +50,19 @@ type DownloadRequest struct {
 	ctxCncl            context.CancelFunc
 	authTicket         *marker.AuthTicket
 	downloadMask       zboxutil.Uint128
+	// blobberOrder lists blobber positions fastest first, nil for no preference
+	blobberOrder       []int
 	encryptedKey       string
-	isDownloadCanceled bool
+	reEncryptionKey    string // decrypts a file of a previous owner, see Allocation.SetReEncryptionKeys
+	isDownloadCanceled int32
 	completedCallback  func(remotepath string, remotepathhash string)
 	contentMode        string
+	// verifyDownloads checks the blocks against the content hashes of the
+	// blobbers, contentRoots by blobber position
+	verifyDownloads bool
+	contentRoots    map[int]string
+	// writer receives the content instead of the file at localpath if set
+	writer io.Writer
 	Consensus
 	effectiveChunkSize int
 	ecEncoder          reedsolomon.Encoder
@@ -104,15 +116,11 @@ func (req *DownloadRequest) getBlocksData(startBlock, totalBlock int64) ([]byte,
 		mask = remainingMask
 	}
 
-	// erasure decoding
-	// Can we benefit from goroutine for erasure decoding??
-	c := req.datashards * req.effectiveChunkSize
-	data := make([]byte, req.datashards*req.effectiveChunkSize*int(totalBlock))
+	// erasure decoding, straight into the returned buffer
+	data := make([]byte, 0, req.datashards*req.effectiveChunkSize*int(totalBlock))
 	var isValid bool
 	for i := range shards {
-		var d []byte
-		var err error
-		d, isValid, err = req.decodeEC(shards[i])
+		data, isValid, err = req.appendDecodedEC(data, shards[i])
 		if err != nil {
 			return nil, err
 		}
@@ -120,9 +128,8 @@ func (req *DownloadRequest) getBlocksData(startBlock, totalBlock int64) ([]byte,
 		if !isValid {
 			return nil, errors.New("invalid_data", "some blobber responded with wrong data")
 		}
-		index := i * c
-		copy(data[index:index+c], d)
-
+		// the shards of the block aren't needed anymore
+		shards[i] = nil
 	}
 	return data, nil
 }
@@ -145,11 +152,10 @@ func (req *DownloadRequest) downloadBlock(
 	}
 	rspCh := make(chan *downloadBlock, requiredDownloads)
 
-	var pos uint64
 	var c int
 
-	for i := req.downloadMask; !i.Equals64(0); i = i.And(zboxutil.NewUint128(1).Lsh(pos).Not()) {
-		pos = uint64(i.TrailingZeros())
+	positions := req.orderedPositions(req.downloadMask)
+	for k, pos := range positions {
 		blockDownloadReq := &BlockDownloadRequest{
 			allocationID:       req.allocationID,
 			allocationTx:       req.allocationTx,
@@ -166,12 +172,17 @@ func (req *DownloadRequest) downloadBlock(
 			remotefilepathhash: req.remotefilepathhash,
 			numBlocks:          totalBlock,
 			encryptedKey:       req.encryptedKey,
+			// re-encrypted blocks of a shared file differ from the ones stored
+			verify:      req.verifyDownloads && !(req.encryptedKey != "" && req.authTicket != nil),
+			contentRoot: req.contentRoots[int(pos)],
 		}
 
 		go AddBlockDownloadReq(blockDownloadReq)
 		c++
 		if c == requiredDownloads {
-			remainingMask = i
+			for _, p := range positions[k:] {
+				remainingMask = remainingMask.Or(zboxutil.NewUint128(1).Lsh(p))
+			}
 			break
 		}
 
@@ -211,25 +222,51 @@ func (req *DownloadRequest) downloadBlock(
 	return remainingMask, failed, downloadErrors, nil
 }
 
+// orderedPositions returns the blobber positions set in mask, fastest
+// blobbers first when the allocation has a latency ranking.
+func (req *DownloadRequest) orderedPositions(mask zboxutil.Uint128) []uint64 {
+	var positions []uint64
+	for i := mask; !i.Equals64(0); {
+		pos := uint64(i.TrailingZeros())
+		positions = append(positions, pos)
+		i = i.And(zboxutil.NewUint128(1).Lsh(pos).Not())
+	}
+	if len(req.blobberOrder) == 0 {
+		return positions
+	}
+
+	rank := make(map[uint64]int, len(req.blobberOrder))
+	for r, pos := range req.blobberOrder {
+		rank[uint64(pos)] = r
+	}
+	sort.SliceStable(positions, func(i, j int) bool {
+		return rank[positions[i]] < rank[positions[j]]
+	})
+	return positions
+}
+
 // decodeEC will reconstruct shards and verify it
 func (req *DownloadRequest) decodeEC(shards [][]byte) (data []byte, isValid bool, err error) {
+	return req.appendDecodedEC(nil, shards)
+}
+
+// appendDecodedEC reconstructs and verifies shards like decodeEC, and
+// appends their data to dst.
+func (req *DownloadRequest) appendDecodedEC(dst []byte, shards [][]byte) (data []byte, isValid bool, err error) {
 	err = req.ecEncoder.Reconstruct(shards)
 	if err != nil {
-		return
+		return dst, false, err
 	}
 
 	isValid, err = req.ecEncoder.Verify(shards)
 	if err != nil || !isValid {
-		return
+		return dst, isValid, err
 	}
 
-	c := len(shards[0])
-	data = make([]byte, req.datashards*c)
-	for i := 0; i < req.datashards; i++ {
-		index := i * c
-		copy(data[index:index+c], shards[i])
+	for _, shard := range shards[:req.datashards] {
+		dst = append(dst, shard...)
 	}
-	return data, true, nil
+	return dst, true, nil
 }
 
 // fillShards will fill `shards` with data from blobbers that belongs to specific
@@ -272,6 +309,7 @@ func (req *DownloadRequest) getDecryptedData(result *downloadBlock, blockNum int
 	encMsg.EncryptedData = result.BlockChunks[blockNum][EncryptionHeaderSize:]
 	encMsg.MessageChecksum, encMsg.OverallChecksum = string(headerBytes[:128]), string(headerBytes[128:])
 	encMsg.EncryptedKey = req.encScheme.GetEncryptedKey()
+	encMsg.ReEncryptionKey = req.reEncryptionKey
 	decryptedBytes, err := req.encScheme.Decrypt(encMsg)
 	if err != nil {
 		logger.Logger.Error("Block decryption failed", req.blobbers[result.idx].Baseurl, err)
@@ -341,24 +379,41 @@ func (req *DownloadRequest) processDownload(ctx context.Context) {
 			"Actual size per blobber: %d", size, req.startBlock, req.endBlock, actualPerShard),
 	)
 
-	f, err := req.openFile()
-	if err != nil {
-		logger.Logger.Error(err)
-		req.errorCB(
-			fmt.Errorf("Error while getting file handler. Error: %v",
-				err), remotePathCB)
-		return
+	var f sys.File
+	out := req.writer
+	if out == nil {
+		f, err = req.openFile()
+		if err != nil {
+			logger.Logger.Error(err)
+			req.errorCB(
+				fmt.Errorf("Error while getting file handler. Error: %v",
+					err), remotePathCB)
+			return
+		}
+		defer f.Close()
+		out = f
 	}
-	defer f.Close()
 
 	var isFullDownload bool
+	var decompressor *decompressWriter
 	fileHasher := createDownloadHasher(req.chunkSize, req.datashards, fRef.EncryptedKey != "")
 	var mW io.Writer
 	if req.startBlock == 0 && req.endBlock == chunksPerShard {
 		isFullDownload = true
-		mW = io.MultiWriter(fileHasher, f)
+		// the hash is of the content as stored, compressed
+		compression := decodeCustomMeta(fRef.CustomMeta)[MetaCompression]
+		if compression != "" && req.contentMode != DOWNLOAD_CONTENT_THUMB {
+			decompressor, err = newDecompressWriter(out, compression)
+			if err != nil {
+				req.errorCB(err, remotePathCB)
+				return
+			}
+			defer decompressor.Close()
+			out = decompressor
+		}
+		mW = io.MultiWriter(fileHasher, out)
 	} else {
-		mW = io.MultiWriter(f)
+		mW = io.MultiWriter(out)
 	}
 
 	err = req.initEC()
@@ -403,7 +458,7 @@ func (req *DownloadRequest) processDownload(ctx context.Context) {
 			req.errorCB(errors.Wrap(err, fmt.Sprintf("Download failed for block %d. ", startBlock+1)), remotePathCB)
 			return
 		}
-		if req.isDownloadCanceled {
+		if atomic.LoadInt32(&req.isDownloadCanceled) == 1 {
 			req.errorCB(errors.New("download_abort", "Download aborted by user"), remotePathCB)
 			return
 		}
@@ -439,7 +494,16 @@ func (req *DownloadRequest) processDownload(ctx context.Context) {
 		}
 	}
 
-	f.Sync()
+	if decompressor != nil {
+		if err := decompressor.Close(); err != nil {
+			req.errorCB(err, remotePathCB)
+			return
+		}
+	}
+
+	if f != nil {
+		f.Sync()
+	}
 
 	if req.statusCallback != nil {
 		req.statusCallback.Completed(
@@ -467,7 +531,10 @@ func (req *DownloadRequest) initEncryption() {
 }
 
 func (req *DownloadRequest) errorCB(err error, remotePathCB string) {
-	sys.Files.Remove(req.localpath) //nolint: errcheck
+	recordError("download", err)
+	if req.writer == nil {
+		sys.Files.Remove(req.localpath) //nolint: errcheck
+	}
 	if req.statusCallback != nil {
 		req.statusCallback.Error(
 			req.allocationID, remotePathCB, OpDownload, err)
@@ -506,6 +573,9 @@ func (req *DownloadRequest) calculateShardsParams(
 	size = fRef.ActualFileSize
 	if req.contentMode == DOWNLOAD_CONTENT_THUMB {
 		size = fRef.ActualThumbnailSize
+		if size == 0 {
+			return 0, 0, 0, errors.New("no_thumbnail", "file has no thumbnail")
+		}
 	}
 	req.encryptedKey = fRef.EncryptedKey
 	req.chunkSize = int(fRef.ChunkSize)
@@ -549,9 +619,11 @@ func (req *DownloadRequest) getFileRef(remotePathCB string) (fRef *fileref.FileR
 		ctx: req.ctx,
 	}
 
-	req.downloadMask, fRef, _ = listReq.getFileConsensusFromBlobbers()
+	var refs []*fileMetaResponse
+	req.downloadMask, fRef, refs = listReq.getFileConsensusFromBlobbers()
 	if req.downloadMask.Equals64(0) || fRef == nil {
-		err = errors.New("consensus_not_met", "No minimum consensus for file meta data of file")
+		err = &constants.ErrConsensusNotMet{Op: "File meta data",
+			Got: req.downloadMask.CountOnes(), Need: req.consensusThresh}
 		return
 	}
 
@@ -566,5 +638,12 @@ func (req *DownloadRequest) getFileRef(remotePathCB string) (fRef *fileref.FileR
 		return
 	}
 
+	req.contentRoots = make(map[int]string, len(refs))
+	for _, r := range refs {
+		if r.fileref != nil && r.fileref.ActualFileHash == fRef.ActualFileHash {
+			req.contentRoots[r.blobberIdx] = r.fileref.ContentHash
+		}
+	}
+
 	return
 }
@@ -0,0 +1,116 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strconv"
+)
+
+// The EXIF tags extracted from images.
+const (
+	exifTagMake             = 0x010f
+	exifTagModel            = 0x0110
+	exifTagOrientation      = 0x0112
+	exifTagDateTime         = 0x0132
+	exifTagExifIFD          = 0x8769
+	exifTagDateTimeOriginal = 0x9003
+)
+
+const (
+	exifTypeASCII = 2
+	exifTypeShort = 3
+	exifTypeLong  = 4
+)
+
+// readJPEGExif returns the ASCII and SHORT tags of IFD0 and of the EXIF IFD
+// of the JPEG starting with data, by tag. It returns nil if there is no EXIF
+// segment in data.
+func readJPEGExif(data []byte) map[uint16]string {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return nil
+	}
+
+	for pos := 2; pos+4 <= len(data); {
+		if data[pos] != 0xff {
+			return nil
+		}
+		marker := data[pos+1]
+		if marker == 0xda || marker == 0xd9 {
+			// start of scan or end of image, the metadata segments are before
+			return nil
+		}
+		size := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if size < 2 || pos+2+size > len(data) {
+			return nil
+		}
+		segment := data[pos+4 : pos+2+size]
+		if marker == 0xe1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return readTIFFTags(segment[6:])
+		}
+		pos += 2 + size
+	}
+	return nil
+}
+
+// readTIFFTags reads the tags of the TIFF structure of an EXIF segment.
+func readTIFFTags(tiff []byte) map[uint16]string {
+	if len(tiff) < 8 {
+		return nil
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return nil
+	}
+
+	tags := make(map[uint16]string)
+	readIFD(tiff, order, order.Uint32(tiff[4:]), tags)
+	if offset, ok := tags[exifTagExifIFD]; ok {
+		delete(tags, exifTagExifIFD)
+		if n, err := strconv.ParseUint(offset, 10, 32); err == nil {
+			readIFD(tiff, order, uint32(n), tags)
+		}
+	}
+	return tags
+}
+
+// readIFD reads the ASCII, SHORT and LONG entries of the IFD at offset into
+// tags. Entries out of tiff are skipped.
+func readIFD(tiff []byte, order binary.ByteOrder, offset uint32, tags map[uint16]string) {
+	if uint64(offset)+2 > uint64(len(tiff)) {
+		return
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	entries := tiff[offset+2:]
+	for i := 0; i < count && (i+1)*12 <= len(entries); i++ {
+		entry := entries[i*12 : (i+1)*12]
+		tag := order.Uint16(entry)
+		n := order.Uint32(entry[4:])
+		value := entry[8:12]
+
+		switch order.Uint16(entry[2:]) {
+		case exifTypeASCII:
+			if n > 4 {
+				start := uint64(order.Uint32(value))
+				if start+uint64(n) > uint64(len(tiff)) {
+					continue
+				}
+				value = tiff[start : start+uint64(n)]
+			} else {
+				value = value[:n]
+			}
+			tags[tag] = string(bytes.TrimRight(value, "\x00 "))
+		case exifTypeShort:
+			tags[tag] = strconv.Itoa(int(order.Uint16(value)))
+		case exifTypeLong:
+			tags[tag] = strconv.FormatUint(uint64(order.Uint32(value)), 10)
+		}
+	}
+}
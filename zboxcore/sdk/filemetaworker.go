@@ -9,7 +9,6 @@ import (
 	"net/http"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/0chain/errors"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
@@ -37,10 +36,8 @@ func (req *ListRequest) getFileMetaInfoFromBlobber(blobber *blockchain.StorageNo
 		rspCh <- &fileMetaResponse{fileref: fileRef, responseStr: s.String(), blobberIdx: blobberIdx, err: err}
 	}
 	defer fileMetaRetFn()
-	if len(req.remotefilepath) > 0 {
-		req.remotefilepathhash = fileref.GetReferenceLookup(req.allocationID, req.remotefilepath)
-	}
-	formWriter.WriteField("path_hash", req.remotefilepathhash)
+	pathHash := req.pathHash()
+	formWriter.WriteField("path_hash", pathHash)
 
 	if req.authToken != nil {
 		authTokenBytes, err := json.Marshal(req.authToken)
@@ -59,8 +56,8 @@ func (req *ListRequest) getFileMetaInfoFromBlobber(blobber *blockchain.StorageNo
 	}
 
 	httpreq.Header.Add("Content-Type", formWriter.FormDataContentType())
-	ctx, cncl := context.WithTimeout(req.ctx, (time.Second * 30))
-	err = zboxutil.HttpDo(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
+	ctx, cncl := context.WithTimeout(req.ctx, timeoutsOf(req.ctx).Meta)
+	err = zboxutil.HttpDoBlobber(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
 		if err != nil {
 			l.Logger.Error("GetFileMeta : ", err)
 			return err
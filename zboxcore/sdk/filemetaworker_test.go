@@ -17,6 +17,7 @@ import (
 
 	"github.com/0chain/errors"
 
+	"github.com/0chain/gosdk/constants"
 	"github.com/0chain/gosdk/core/zcncrypto"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
 	zclient "github.com/0chain/gosdk/zboxcore/client"
@@ -75,7 +76,7 @@ func TestListRequest_getFileMetaInfoFromBlobber(t *testing.T) {
 				}, errors.New("", mockErrorMessage))
 			},
 			wantErr: true,
-			errMsg:  mockErrorMessage,
+			errMsg:  constants.CodeBlobberUnreachable + ": " + mockErrorMessage,
 		},
 		{
 			name: "Test_Badly_Formatted",
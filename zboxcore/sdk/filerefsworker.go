@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"sync"
-	"time"
 
 	"github.com/0chain/errors"
 	"github.com/0chain/gosdk/core/common"
@@ -105,8 +105,8 @@ func (o *ObjectTreeRequest) getFileRefs(oTR *oTreeResponse, bUrl string) {
 		return
 	}
 	oResult := ObjectTreeResult{}
-	ctx, cncl := context.WithTimeout(o.ctx, time.Second*30)
-	err = zboxutil.HttpDo(ctx, cncl, oReq, func(resp *http.Response, err error) error {
+	ctx, cncl := context.WithTimeout(o.ctx, timeoutsOf(o.ctx).List)
+	err = zboxutil.HttpDoBlobber(ctx, cncl, oReq, func(resp *http.Response, err error) error {
 		if err != nil {
 			l.Logger.Error(err)
 			return err
@@ -125,6 +125,7 @@ func (o *ObjectTreeRequest) getFileRefs(oTR *oTreeResponse, bUrl string) {
 			}
 			return nil
 		} else {
+			err = errors.New(strconv.Itoa(resp.StatusCode), fmt.Sprintf("Refs error response: Body: %s ", string(respBody)))
 			l.Logger.Error(err)
 			return err
 		}
@@ -141,6 +142,8 @@ func (o *ObjectTreeRequest) getFileRefs(oTR *oTreeResponse, bUrl string) {
 type ORef struct {
 	SimilarField
 	ID int64 `json:"id"`
+	// EncryptedKey is set for encrypted files, blobbers may omit it
+	EncryptedKey string `json:"encrypted_key,omitempty"`
 }
 
 type SimilarField struct {
@@ -156,6 +159,7 @@ type SimilarField struct {
 	ActualFileSize      int64            `json:"actual_file_size"`
 	ActualFileHash      string           `json:"actual_file_hash"`
 	MimeType            string           `json:"mimetype"`
+	CustomMeta          string           `json:"custom_meta"`
 	ActualThumbnailSize int64            `json:"actual_thumbnail_size"`
 	ActualThumbnailHash string           `json:"actual_thumbnail_hash"`
 	CreatedAt           common.Timestamp `json:"created_at"`
@@ -249,8 +253,8 @@ func (r *RecentlyAddedRefRequest) getRecentlyAddedRefs(resp *RecentlyAddedRefRes
 	}
 
 	result := RecentlyAddedRefResult{}
-	ctx, cncl := context.WithTimeout(r.ctx, time.Second*30)
-	err = zboxutil.HttpDo(ctx, cncl, req, func(hResp *http.Response, err error) error {
+	ctx, cncl := context.WithTimeout(r.ctx, timeoutsOf(r.ctx).List)
+	err = zboxutil.HttpDoBlobber(ctx, cncl, req, func(hResp *http.Response, err error) error {
 		if err != nil {
 			l.Logger.Error(err)
 			return err
@@ -13,7 +13,6 @@ import (
 
 	"github.com/0chain/errors"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
-	"github.com/0chain/gosdk/zboxcore/fileref"
 	l "github.com/0chain/gosdk/zboxcore/logger"
 	"github.com/0chain/gosdk/zboxcore/zboxutil"
 )
@@ -60,10 +59,8 @@ func (req *ListRequest) getFileStatsInfoFromBlobber(blobber *blockchain.StorageN
 		rspCh <- &fileStatsResponse{filestats: fileStats, responseStr: s.String(), blobberIdx: blobberIdx, err: err}
 	}
 	defer fileMetaRetFn()
-	if len(req.remotefilepath) > 0 {
-		req.remotefilepathhash = fileref.GetReferenceLookup(req.allocationID, req.remotefilepath)
-	}
-	formWriter.WriteField("path_hash", req.remotefilepathhash)
+	pathHash := req.pathHash()
+	formWriter.WriteField("path_hash", pathHash)
 
 	formWriter.Close()
 	httpreq, err := zboxutil.NewFileStatsRequest(blobber.Baseurl, req.allocationTx, body)
@@ -73,8 +70,8 @@ func (req *ListRequest) getFileStatsInfoFromBlobber(blobber *blockchain.StorageN
 	}
 
 	httpreq.Header.Add("Content-Type", formWriter.FormDataContentType())
-	ctx, cncl := context.WithTimeout(req.ctx, (time.Second * 30))
-	err = zboxutil.HttpDo(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
+	ctx, cncl := context.WithTimeout(req.ctx, timeoutsOf(req.ctx).Meta)
+	err = zboxutil.HttpDoBlobber(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
 		if err != nil {
 			l.Logger.Error("GetFileStats : ", err)
 			return err
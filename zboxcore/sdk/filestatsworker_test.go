@@ -15,6 +15,7 @@ import (
 	"testing"
 
 	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
 	"github.com/0chain/gosdk/core/encryption"
 	"github.com/0chain/gosdk/core/zcncrypto"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
@@ -77,7 +78,7 @@ func TestListRequest_getFileStatsInfoFromBlobber(t *testing.T) {
 				}, errors.New("", mockErrorMessage))
 			},
 			wantErr: true,
-			errMsg:  mockErrorMessage,
+			errMsg:  constants.CodeBlobberUnreachable + ": " + mockErrorMessage,
 		},
 		{
 			name: "Test_Badly_Formatted",
@@ -0,0 +1,233 @@
+package sdk
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// ListSortField is the field a paged listing is ordered by.
+type ListSortField string
+
+const (
+	ListSortByName      ListSortField = "name"
+	ListSortBySize      ListSortField = "size"
+	ListSortByCreatedAt ListSortField = "created_at"
+	ListSortByUpdatedAt ListSortField = "updated_at"
+)
+
+// ListFilter narrows and orders the children returned by ListDirPaged.
+type ListFilter struct {
+	// NamePattern is a glob (path.Match syntax) matched against child names.
+	NamePattern string
+	// Type restricts children to fileref.FILE or fileref.DIRECTORY.
+	Type string
+	// SortBy defaults to ListSortByName.
+	SortBy     ListSortField
+	Descending bool
+}
+
+// ListPage is a single page of a directory listing.
+type ListPage struct {
+	*ListResult
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+	// Total is the number of children matching the filter.
+	Total   int  `json:"total"`
+	HasMore bool `json:"has_more"`
+}
+
+func (f ListFilter) validate() error {
+	if f.NamePattern != "" {
+		if _, err := path.Match(f.NamePattern, ""); err != nil {
+			return errors.New("invalid_filter", "bad name pattern: "+err.Error())
+		}
+	}
+	switch f.SortBy {
+	case "", ListSortByName, ListSortBySize, ListSortByCreatedAt, ListSortByUpdatedAt:
+	default:
+		return errors.New("invalid_filter", "unsupported sort field: "+string(f.SortBy))
+	}
+	return nil
+}
+
+func (f ListFilter) query(offset, limit int) url.Values {
+	q := url.Values{}
+	q.Set("offset", strconv.Itoa(offset))
+	q.Set("limit", strconv.Itoa(limit))
+	if f.NamePattern != "" {
+		q.Set("name", f.NamePattern)
+	}
+	if f.Type != "" {
+		q.Set("type", f.Type)
+	}
+	sortBy := f.SortBy
+	if sortBy == "" {
+		sortBy = ListSortByName
+	}
+	q.Set("sort_by", string(sortBy))
+	if f.Descending {
+		q.Set("sort_order", "desc")
+	} else {
+		q.Set("sort_order", "asc")
+	}
+	return q
+}
+
+func (f ListFilter) match(child *ListResult) bool {
+	if f.Type != "" && child.Type != f.Type {
+		return false
+	}
+	if f.NamePattern != "" {
+		ok, _ := path.Match(f.NamePattern, child.Name)
+		return ok
+	}
+	return true
+}
+
+func (f ListFilter) less(a, b *ListResult) bool {
+	if f.Descending {
+		a, b = b, a
+	}
+	switch f.SortBy {
+	case ListSortBySize:
+		if a.Size != b.Size {
+			return a.Size < b.Size
+		}
+	case ListSortByCreatedAt:
+		if a.CreatedAt != b.CreatedAt {
+			return a.CreatedAt < b.CreatedAt
+		}
+	case ListSortByUpdatedAt:
+		if a.UpdatedAt != b.UpdatedAt {
+			return a.UpdatedAt < b.UpdatedAt
+		}
+	}
+	return strings.Compare(a.Name, b.Name) < 0
+}
+
+// apply returns the children matching the filter in sort order.
+func (f ListFilter) apply(children []*ListResult) []*ListResult {
+	matched := make([]*ListResult, 0, len(children))
+	for _, child := range children {
+		if f.match(child) {
+			matched = append(matched, child)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return f.less(matched[i], matched[j])
+	})
+	return matched
+}
+
+// pageListResult applies the filter and the offset/limit window to a full
+// directory listing. It is used for blobbers without server-side paging.
+func pageListResult(result *ListResult, offset, limit int, filter ListFilter) *ListPage {
+	matched := filter.apply(result.Children)
+	total := len(matched)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	result.Children = matched[offset:end]
+	return &ListPage{
+		ListResult: result,
+		Offset:     offset,
+		Limit:      limit,
+		Total:      total,
+		HasMore:    end < total,
+	}
+}
+
+// ListDirPaged lists at most limit children of path, starting at offset,
+// after applying filter. Blobbers that support paged listing do the work
+// server-side; if any responding blobber returns the full directory the
+// page is cut client-side instead.
+func (a *Allocation) ListDirPaged(path string, offset, limit int, filter ListFilter) (*ListPage, error) {
+	return a.ListDirPagedCtx(context.Background(), path, offset, limit, filter)
+}
+
+// ListDirPagedCtx returns the page of the directory at path, see ListDirPaged.
+func (a *Allocation) ListDirPagedCtx(ctx context.Context, path string, offset, limit int, filter ListFilter) (*ListPage, error) {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	if len(path) == 0 {
+		return nil, errors.New("invalid_path", "Invalid path for the list")
+	}
+	if offset < 0 || limit <= 0 {
+		return nil, errors.New("invalid_params", "offset must be >= 0 and limit must be > 0")
+	}
+	if err := filter.validate(); err != nil {
+		return nil, err
+	}
+	path = zboxutil.RemoteClean(path)
+	if !zboxutil.IsRemoteAbs(path) {
+		return nil, errors.New("invalid_path", "Path should be valid and absolute")
+	}
+
+	listReq := &ListRequest{}
+	listReq.allocationID = a.ID
+	listReq.allocationTx = a.Tx
+	listReq.blobbers = a.Blobbers
+	listReq.fullconsensus = a.fullconsensus
+	listReq.consensusThresh = a.consensusThreshold
+	listReq.ctx = ctx
+	listReq.remotefilepath = path
+	listReq.query = filter.query(offset, limit)
+
+	lR := listReq.getlistFromBlobbers()
+	paginated := true
+	for _, rsp := range lR {
+		if rsp.err == nil && rsp.ref != nil && !rsp.paginated {
+			paginated = false
+			break
+		}
+	}
+
+	if !paginated {
+		// mixed or legacy blobbers: fetch the full listing and page locally
+		// so every blobber contributes the same children to consensus.
+		listReq.query = nil
+		lR = listReq.getlistFromBlobbers()
+	}
+
+	result, err := listReq.mergeListResponses(lR)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, errors.New("list_request_failed", "Failed to get list response from the blobbers")
+	}
+
+	if !paginated {
+		return pageListResult(result, offset, limit, filter), nil
+	}
+
+	// server-side pages are already windowed; re-applying the filter only
+	// fixes the ordering after consensus merging.
+	result.Children = filter.apply(result.Children)
+	page := &ListPage{
+		ListResult: result,
+		Offset:     offset,
+		Limit:      limit,
+	}
+	for _, rsp := range lR {
+		if rsp.err == nil && rsp.total > page.Total {
+			page.Total = rsp.total
+		}
+	}
+	page.HasMore = offset+len(result.Children) < page.Total
+	return page, nil
+}
@@ -0,0 +1,93 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageListResult(t *testing.T) {
+	newResult := func() *ListResult {
+		return &ListResult{
+			Type: fileref.DIRECTORY,
+			Children: []*ListResult{
+				{Name: "b.txt", Type: fileref.FILE, Size: 30},
+				{Name: "a.txt", Type: fileref.FILE, Size: 10},
+				{Name: "photos", Type: fileref.DIRECTORY},
+				{Name: "c.jpg", Type: fileref.FILE, Size: 20},
+			},
+		}
+	}
+	names := func(p *ListPage) []string {
+		var n []string
+		for _, c := range p.Children {
+			n = append(n, c.Name)
+		}
+		return n
+	}
+
+	tests := []struct {
+		name        string
+		offset      int
+		limit       int
+		filter      ListFilter
+		wantNames   []string
+		wantTotal   int
+		wantHasMore bool
+	}{
+		{
+			name:        "Test_First_Page_Sorted_By_Name",
+			offset:      0,
+			limit:       2,
+			wantNames:   []string{"a.txt", "b.txt"},
+			wantTotal:   4,
+			wantHasMore: true,
+		},
+		{
+			name:      "Test_Last_Page",
+			offset:    2,
+			limit:     2,
+			wantNames: []string{"c.jpg", "photos"},
+			wantTotal: 4,
+		},
+		{
+			name:      "Test_Offset_Past_End",
+			offset:    10,
+			limit:     2,
+			wantTotal: 4,
+		},
+		{
+			name:      "Test_Glob_Filter",
+			offset:    0,
+			limit:     10,
+			filter:    ListFilter{NamePattern: "*.txt"},
+			wantNames: []string{"a.txt", "b.txt"},
+			wantTotal: 2,
+		},
+		{
+			name:      "Test_Type_Filter_Size_Desc",
+			offset:    0,
+			limit:     10,
+			filter:    ListFilter{Type: fileref.FILE, SortBy: ListSortBySize, Descending: true},
+			wantNames: []string{"b.txt", "c.jpg", "a.txt"},
+			wantTotal: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			require.NoError(tt.filter.validate())
+			page := pageListResult(newResult(), tt.offset, tt.limit, tt.filter)
+			require.Equal(tt.wantNames, names(page))
+			require.Equal(tt.wantTotal, page.Total)
+			require.Equal(tt.wantHasMore, page.HasMore)
+		})
+	}
+}
+
+func TestListFilter_validate(t *testing.T) {
+	require.Error(t, ListFilter{NamePattern: "[a-"}.validate())
+	require.Error(t, ListFilter{SortBy: "owner"}.validate())
+	require.NoError(t, ListFilter{NamePattern: "*.jpg", SortBy: ListSortByUpdatedAt}.validate())
+}
@@ -6,9 +6,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/0chain/errors"
 	"github.com/0chain/gosdk/core/common"
@@ -29,6 +29,7 @@ type ListRequest struct {
 	remotefilepathhash string
 	remotefilepath     string
 	authToken          *marker.AuthTicket
+	query              url.Values
 	ctx                context.Context
 	wg                 *sync.WaitGroup
 	Consensus
@@ -38,27 +39,40 @@ type listResponse struct {
 	ref         *fileref.Ref
 	responseStr string
 	blobberIdx  int
+	paginated   bool
+	total       int
 	err         error
 }
 
 type ListResult struct {
-	Name            string           `json:"name"`
-	Path            string           `json:"path,omitempty"`
-	Type            string           `json:"type"`
-	Size            int64            `json:"size"`
-	Hash            string           `json:"hash,omitempty"`
-	MimeType        string           `json:"mimetype,omitempty"`
-	NumBlocks       int64            `json:"num_blocks"`
-	LookupHash      string           `json:"lookup_hash"`
-	EncryptionKey   string           `json:"encryption_key"`
-	ActualSize      int64            `json:"actual_size"`
-	ActualNumBlocks int64            `json:"actual_num_blocks"`
-	CreatedAt       common.Timestamp `json:"created_at"`
-	UpdatedAt       common.Timestamp `json:"updated_at"`
-	Children        []*ListResult    `json:"list"`
+	Name            string            `json:"name"`
+	Path            string            `json:"path,omitempty"`
+	Type            string            `json:"type"`
+	Size            int64             `json:"size"`
+	Hash            string            `json:"hash,omitempty"`
+	MimeType        string            `json:"mimetype,omitempty"`
+	CustomMeta      map[string]string `json:"custom_meta,omitempty"`
+	NumBlocks       int64             `json:"num_blocks"`
+	LookupHash      string            `json:"lookup_hash"`
+	EncryptionKey   string            `json:"encryption_key"`
+	ActualSize      int64             `json:"actual_size"`
+	ActualNumBlocks int64             `json:"actual_num_blocks"`
+	CreatedAt       common.Timestamp  `json:"created_at"`
+	UpdatedAt       common.Timestamp  `json:"updated_at"`
+	Children        []*ListResult     `json:"list"`
 	Consensus       `json:"-"`
 }
 
+// pathHash returns the lookup hash of remotefilepath, or remotefilepathhash
+// if the path isn't set. The request is shared by the blobber goroutines, so
+// it isn't stored.
+func (req *ListRequest) pathHash() string {
+	if len(req.remotefilepath) > 0 {
+		return fileref.GetReferenceLookup(req.allocationID, req.remotefilepath)
+	}
+	return req.remotefilepathhash
+}
+
 func (req *ListRequest) getListInfoFromBlobber(blobber *blockchain.StorageNode, blobberIdx int, rspCh chan<- *listResponse) {
 	defer req.wg.Done()
 	//body := new(bytes.Buffer)
@@ -67,16 +81,16 @@ func (req *ListRequest) getListInfoFromBlobber(blobber *blockchain.StorageNode,
 	ref := &fileref.Ref{}
 	var s strings.Builder
 	var err error
+	var paginated bool
+	var total int
 	listRetFn := func() {
-		rspCh <- &listResponse{ref: ref, responseStr: s.String(), blobberIdx: blobberIdx, err: err}
+		rspCh <- &listResponse{ref: ref, responseStr: s.String(), blobberIdx: blobberIdx, paginated: paginated, total: total, err: err}
 	}
 	defer listRetFn()
 
-	if len(req.remotefilepath) > 0 {
-		req.remotefilepathhash = fileref.GetReferenceLookup(req.allocationID, req.remotefilepath)
-	}
-	//formWriter.WriteField("path_hash", req.remotefilepathhash)
-	//Logger.Info("Path hash for list dir: ", req.remotefilepathhash)
+	pathHash := req.pathHash()
+	//formWriter.WriteField("path_hash", pathHash)
+	//Logger.Info("Path hash for list dir: ", pathHash)
 
 	authTokenBytes := make([]byte, 0)
 	if req.authToken != nil {
@@ -89,15 +103,15 @@ func (req *ListRequest) getListInfoFromBlobber(blobber *blockchain.StorageNode,
 	}
 
 	//formWriter.Close()
-	httpreq, err := zboxutil.NewListRequest(blobber.Baseurl, req.allocationTx, req.remotefilepath, req.remotefilepathhash, string(authTokenBytes))
+	httpreq, err := zboxutil.NewListRequestWithQuery(blobber.Baseurl, req.allocationTx, req.remotefilepath, pathHash, string(authTokenBytes), req.query)
 	if err != nil {
 		l.Logger.Error("List info request error: ", err.Error())
 		return
 	}
 
 	//httpreq.Header.Add("Content-Type", formWriter.FormDataContentType())
-	ctx, cncl := context.WithTimeout(req.ctx, (time.Second * 30))
-	err = zboxutil.HttpDo(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
+	ctx, cncl := context.WithTimeout(req.ctx, timeoutsOf(req.ctx).List)
+	err = zboxutil.HttpDoBlobber(ctx, cncl, httpreq, func(resp *http.Response, err error) error {
 		if err != nil {
 			l.Logger.Error("List : ", err)
 			return err
@@ -115,6 +129,10 @@ func (req *ListRequest) getListInfoFromBlobber(blobber *blockchain.StorageNode,
 			if err != nil {
 				return errors.Wrap(err, "list entities response parse error:")
 			}
+			if listResult.Pagination != nil {
+				paginated = true
+				total = listResult.Pagination.Total
+			}
 			ref, err = listResult.GetDirTree(req.allocationID)
 			if err != nil {
 				return errors.Wrap(err, "error getting the dir tree from list response:")
@@ -144,7 +162,10 @@ func (req *ListRequest) getlistFromBlobbers() []*listResponse {
 }
 
 func (req *ListRequest) GetListFromBlobbers() (*ListResult, error) {
-	lR := req.getlistFromBlobbers()
+	return req.mergeListResponses(req.getlistFromBlobbers())
+}
+
+func (req *ListRequest) mergeListResponses(lR []*listResponse) (*ListResult, error) {
 	result := &ListResult{}
 	selected := make(map[string]*ListResult)
 	childResultMap := make(map[string]*ListResult)
@@ -202,6 +223,7 @@ func (req *ListRequest) GetListFromBlobbers() (*ListResult, error) {
 			if child.GetType() == fileref.FILE {
 				childResult.Hash = (child.(*fileref.FileRef)).ActualFileHash
 				childResult.MimeType = (child.(*fileref.FileRef)).MimeType
+				childResult.CustomMeta = decodeCustomMeta((child.(*fileref.FileRef)).CustomMeta)
 				childResult.EncryptionKey = (child.(*fileref.FileRef)).EncryptedKey
 				childResult.ActualSize = (child.(*fileref.FileRef)).ActualFileSize
 				if childResult.ActualSize > 0 {
@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
 	"github.com/0chain/gosdk/core/zcncrypto"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
 	zclient "github.com/0chain/gosdk/zboxcore/client"
@@ -83,7 +84,7 @@ func TestListRequest_getListInfoFromBlobber(t *testing.T) {
 				}, errors.New("", mockErrorMessage))
 			},
 			wantErr: true,
-			errMsg:  mockErrorMessage,
+			errMsg:  constants.CodeBlobberUnreachable + ": " + mockErrorMessage,
 		},
 		{
 			name: "Test_HTTP_Response_Failed",
@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/marker"
+)
+
+// maxReadMarkerLog caps how many read markers are kept per allocation.
+const maxReadMarkerLog = 1000
+
+type readMarkerLog struct {
+	mu sync.RWMutex
+	m  map[string][]*marker.ReadMarker
+}
+
+var rml = &readMarkerLog{m: make(map[string][]*marker.ReadMarker)}
+
+// recordReadMarker keeps a read marker that a blobber accepted for a download.
+func recordReadMarker(rm *marker.ReadMarker) {
+	rml.mu.Lock()
+	defer rml.mu.Unlock()
+	markers := append(rml.m[rm.AllocationID], rm)
+	if len(markers) > maxReadMarkerLog {
+		markers = markers[len(markers)-maxReadMarkerLog:]
+	}
+	rml.m[rm.AllocationID] = markers
+}
+
+// GetLatestWriteMarkers returns the latest signed write marker of every
+// blobber, keyed by blobber id. Blobbers that never received a write are
+// left out. An error is returned only if no blobber could be reached.
+func (a *Allocation) GetLatestWriteMarkers() (map[string]*marker.WriteMarker, error) {
+	return a.GetLatestWriteMarkersCtx(context.Background())
+}
+
+// GetLatestWriteMarkersCtx returns the latest write markers, the blobbers
+// that didn't respond before ctx is done being left out.
+func (a *Allocation) GetLatestWriteMarkersCtx(ctx context.Context) (map[string]*marker.WriteMarker, error) {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+
+	oTreeReq := &ObjectTreeRequest{
+		allocationID:   a.ID,
+		allocationTx:   a.Tx,
+		blobbers:       a.Blobbers,
+		remotefilepath: "/",
+		pageLimit:      1,
+		refType:        "regular",
+		wg:             &sync.WaitGroup{},
+		ctx:            ctx,
+	}
+	responses := make([]oTreeResponse, len(a.Blobbers))
+	oTreeReq.wg.Add(len(a.Blobbers))
+	for i, blobber := range a.Blobbers {
+		go oTreeReq.getFileRefs(&responses[i], blobber.Baseurl)
+	}
+	oTreeReq.wg.Wait()
+
+	var lastErr error
+	markers := make(map[string]*marker.WriteMarker)
+	for i, resp := range responses {
+		if resp.err != nil {
+			lastErr = resp.err
+			continue
+		}
+		if resp.oTResult.LatestWM != nil {
+			markers[a.Blobbers[i].ID] = resp.oTResult.LatestWM
+		}
+	}
+	if lastErr != nil && len(markers) == 0 {
+		return nil, errors.Wrap(lastErr, "get latest write markers failed")
+	}
+	return markers, nil
+}
+
+// GetReadMarkers returns the read markers this client signed for the
+// allocation and blobbers accepted since the given time, keyed by blobber id
+// and ordered by counter. Only downloads made by this process are tracked.
+func (a *Allocation) GetReadMarkers(since time.Time) map[string][]*marker.ReadMarker {
+	sinceTs := since.Unix()
+
+	rml.mu.RLock()
+	defer rml.mu.RUnlock()
+	markers := make(map[string][]*marker.ReadMarker)
+	for _, rm := range rml.m[a.ID] {
+		if int64(rm.Timestamp) < sinceTs {
+			continue
+		}
+		markers[rm.BlobberID] = append(markers[rm.BlobberID], rm)
+	}
+	return markers
+}
@@ -0,0 +1,35 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/zboxcore/marker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocation_GetReadMarkers(t *testing.T) {
+	const allocID = "read_markers_alloc"
+	now := time.Now()
+	for i, ts := range []time.Time{now.Add(-time.Hour), now, now.Add(time.Minute)} {
+		recordReadMarker(&marker.ReadMarker{
+			AllocationID: allocID,
+			BlobberID:    "blobber1",
+			Timestamp:    common.Timestamp(ts.Unix()),
+			ReadCounter:  int64(i + 1),
+		})
+	}
+	recordReadMarker(&marker.ReadMarker{AllocationID: allocID, BlobberID: "blobber2", Timestamp: common.Now(), ReadCounter: 7})
+	recordReadMarker(&marker.ReadMarker{AllocationID: "other", BlobberID: "blobber1", Timestamp: common.Now()})
+
+	a := &Allocation{ID: allocID}
+	markers := a.GetReadMarkers(now)
+	require.Len(t, markers, 2)
+	require.Len(t, markers["blobber1"], 2)
+	require.EqualValues(t, 2, markers["blobber1"][0].ReadCounter)
+	require.EqualValues(t, 3, markers["blobber1"][1].ReadCounter)
+	require.Len(t, markers["blobber2"], 1)
+
+	require.Len(t, a.GetReadMarkers(time.Time{})["blobber1"], 3)
+}
@@ -0,0 +1,170 @@
+package sdk
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// CacheConfig configures the local metadata cache used for allocation
+// objects, file metas and list results. A zero TTL disables the cache.
+type CacheConfig struct {
+	// TTL is how long an entry is served before it is fetched again.
+	TTL time.Duration
+	// Size caps the number of entries kept per kind of metadata. It defaults
+	// to DefaultMetadataCacheSize.
+	Size int
+}
+
+// DefaultMetadataCacheSize is used when CacheConfig.Size is not set.
+const DefaultMetadataCacheSize = 1000
+
+type cachedEntry[T any] struct {
+	Expiration time.Time
+	Value      T
+}
+
+type ttlCache[T any] struct {
+	ttl   time.Duration
+	items *lru.Cache[string, *cachedEntry[T]]
+}
+
+func newTTLCache[T any](c CacheConfig) *ttlCache[T] {
+	size := c.Size
+	if size <= 0 {
+		size = DefaultMetadataCacheSize
+	}
+	items, _ := lru.New[string, *cachedEntry[T]](size)
+	return &ttlCache[T]{ttl: c.TTL, items: items}
+}
+
+func (c *ttlCache[T]) get(key string) (T, bool) {
+	it, ok := c.items.Get(key)
+	if !ok || !it.Expiration.After(time.Now()) {
+		var zero T
+		return zero, false
+	}
+	return it.Value, true
+}
+
+func (c *ttlCache[T]) add(key string, v T) {
+	c.items.Add(key, &cachedEntry[T]{Expiration: time.Now().Add(c.ttl), Value: v})
+}
+
+// removePrefix drops every key starting with prefix.
+func (c *ttlCache[T]) removePrefix(prefix string) {
+	for _, key := range c.items.Keys() {
+		if strings.HasPrefix(key, prefix) {
+			c.items.Remove(key)
+		}
+	}
+}
+
+// metadataCache keeps the allocations as returned by the sharders, each
+// caller decoding its own Allocation, and the file metas and list results
+// which are copied in and out, see clone.
+type metadataCache struct {
+	allocations *ttlCache[[]byte]
+	fileMetas   *ttlCache[*ConsolidatedFileMeta]
+	lists       *ttlCache[*ListResult]
+}
+
+var (
+	metaCacheMu sync.RWMutex
+	metaCache   *metadataCache
+)
+
+// SetMetadataCache enables the local metadata cache with the given TTL and
+// size cap, dropping anything cached so far. Passing a zero TTL disables it.
+func SetMetadataCache(c CacheConfig) {
+	metaCacheMu.Lock()
+	defer metaCacheMu.Unlock()
+
+	if c.TTL <= 0 {
+		metaCache = nil
+		return
+	}
+	metaCache = &metadataCache{
+		allocations: newTTLCache[[]byte](c),
+		fileMetas:   newTTLCache[*ConsolidatedFileMeta](c),
+		lists:       newTTLCache[*ListResult](c),
+	}
+}
+
+// InvalidateMetadataCache drops the cached allocation, file metas and list
+// results of allocationID. An empty allocationID clears the whole cache.
+func InvalidateMetadataCache(allocationID string) {
+	mc := getMetadataCache()
+	if mc == nil {
+		return
+	}
+	if allocationID == "" {
+		mc.allocations.items.Purge()
+		mc.fileMetas.items.Purge()
+		mc.lists.items.Purge()
+		return
+	}
+	mc.allocations.items.Remove(allocationID)
+	mc.fileMetas.removePrefix(pathCacheKey(allocationID, ""))
+	mc.lists.removePrefix(pathCacheKey(allocationID, ""))
+}
+
+func getMetadataCache() *metadataCache {
+	metaCacheMu.RLock()
+	defer metaCacheMu.RUnlock()
+	return metaCache
+}
+
+func pathCacheKey(allocationID, remotePath string) string {
+	return allocationID + ":" + remotePath
+}
+
+// clone returns a copy of m sharing nothing with it.
+func (m *ConsolidatedFileMeta) clone() *ConsolidatedFileMeta {
+	c := *m
+	c.CommitMetaTxns = append([]fileref.CommitMetaTxn(nil), m.CommitMetaTxns...)
+	c.Collaborators = append([]fileref.Collaborator(nil), m.Collaborators...)
+	if m.CustomMeta != nil {
+		c.CustomMeta = make(map[string]string, len(m.CustomMeta))
+		for k, v := range m.CustomMeta {
+			c.CustomMeta[k] = v
+		}
+	}
+	return &c
+}
+
+// clone returns a copy of r and of its children sharing nothing with them,
+// but the consensus which isn't copied.
+func (r *ListResult) clone() *ListResult {
+	c := &ListResult{
+		Name:            r.Name,
+		Path:            r.Path,
+		Type:            r.Type,
+		Size:            r.Size,
+		Hash:            r.Hash,
+		MimeType:        r.MimeType,
+		NumBlocks:       r.NumBlocks,
+		LookupHash:      r.LookupHash,
+		EncryptionKey:   r.EncryptionKey,
+		ActualSize:      r.ActualSize,
+		ActualNumBlocks: r.ActualNumBlocks,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+	}
+	if r.CustomMeta != nil {
+		c.CustomMeta = make(map[string]string, len(r.CustomMeta))
+		for k, v := range r.CustomMeta {
+			c.CustomMeta[k] = v
+		}
+	}
+	if r.Children != nil {
+		c.Children = make([]*ListResult, len(r.Children))
+		for i, child := range r.Children {
+			c.Children[i] = child.clone()
+		}
+	}
+	return c
+}
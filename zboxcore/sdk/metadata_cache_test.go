@@ -0,0 +1,88 @@
+package sdk
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataCache(t *testing.T) {
+	defer SetMetadataCache(CacheConfig{})
+
+	require.Nil(t, getMetadataCache(), "the cache is disabled by default")
+
+	SetMetadataCache(CacheConfig{TTL: time.Minute, Size: 2})
+	mc := getMetadataCache()
+	require.NotNil(t, mc)
+
+	mc.allocations.add("a1", []byte(`{"id":"a1"}`))
+	mc.lists.add(pathCacheKey("a1", "/"), &ListResult{Path: "/"})
+	mc.lists.add(pathCacheKey("a2", "/"), &ListResult{Path: "/"})
+	mc.fileMetas.add(pathCacheKey("a1", "/f"), &ConsolidatedFileMeta{Path: "/f"})
+
+	got, ok := mc.allocations.get("a1")
+	require.True(t, ok)
+	require.Equal(t, `{"id":"a1"}`, string(got))
+
+	InvalidateMetadataCache("a1")
+	_, ok = mc.allocations.get("a1")
+	require.False(t, ok)
+	_, ok = mc.lists.get(pathCacheKey("a1", "/"))
+	require.False(t, ok)
+	_, ok = mc.fileMetas.get(pathCacheKey("a1", "/f"))
+	require.False(t, ok)
+	_, ok = mc.lists.get(pathCacheKey("a2", "/"))
+	require.True(t, ok, "other allocations are kept")
+
+	mc.lists.add(pathCacheKey("a1", "/"), &ListResult{})
+	mc.lists.add(pathCacheKey("a1", "/d"), &ListResult{})
+	_, ok = mc.lists.get(pathCacheKey("a2", "/"))
+	require.False(t, ok, "the size cap evicts the oldest entry")
+
+	InvalidateMetadataCache("")
+	require.Zero(t, mc.lists.items.Len())
+
+	SetMetadataCache(CacheConfig{TTL: time.Nanosecond})
+	mc = getMetadataCache()
+	mc.allocations.add("a1", []byte("{}"))
+	time.Sleep(time.Millisecond)
+	_, ok = mc.allocations.get("a1")
+	require.False(t, ok, "expired entries are not served")
+}
+
+func TestMetadataCache_copies(t *testing.T) {
+	defer SetMetadataCache(CacheConfig{})
+	SetMetadataCache(CacheConfig{TTL: time.Minute})
+
+	s := setupChallengesSharder(t)
+	var fetched int
+	s.HandleSCRestFunc(STORAGE_SCADDRESS, "allocation", func(url.Values) interface{} {
+		fetched++
+		return map[string]interface{}{"id": "a1", "curators": []string{"c1"}}
+	})
+
+	a1, err := GetAllocation("a1")
+	require.NoError(t, err)
+	a2, err := GetAllocation("a1")
+	require.NoError(t, err)
+	require.Equal(t, 1, fetched, "the allocation is cached")
+	require.NotSame(t, a1, a2, "each caller gets its own allocation")
+	a1.Curators[0] = "changed"
+	a1.ctxCancelF()
+	require.Equal(t, []string{"c1"}, a2.Curators)
+	require.NoError(t, a2.ctx.Err(), "stopping a1 doesn't stop a2")
+
+	list := &ListResult{Path: "/", CustomMeta: map[string]string{"k": "v"}, Children: []*ListResult{{Path: "/f"}}}
+	c := list.clone()
+	c.Children[0].Path = "/g"
+	c.CustomMeta["k"] = "w"
+	require.Equal(t, "/f", list.Children[0].Path)
+	require.Equal(t, "v", list.CustomMeta["k"])
+
+	meta := &ConsolidatedFileMeta{Path: "/f", CustomMeta: map[string]string{"k": "v"}}
+	mc := meta.clone()
+	mc.CustomMeta["k"] = "w"
+	require.Equal(t, "v", meta.CustomMeta["k"])
+}
@@ -0,0 +1,278 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+)
+
+// The statuses of the files of a migration.
+const (
+	MigrationCopied  = "copied"
+	MigrationSkipped = "skipped"
+	MigrationFailed  = "failed"
+)
+
+// DefaultMigrationParallelism is the number of files migrated at once if
+// MigrationOptions.Parallelism isn't set.
+const DefaultMigrationParallelism = 4
+
+// MigrationOptions are the options of MigrateAllocation.
+type MigrationOptions struct {
+	// Parallelism is the number of files migrated at once.
+	Parallelism int
+	// Overwrite updates the files of the destination whose content differs.
+	// They fail the migration of the file otherwise.
+	Overwrite bool
+	// SkipVerify doesn't check the content of the files migrated.
+	SkipVerify bool
+}
+
+// MigrationFileResult is the migration of a file.
+type MigrationFileResult struct {
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Hash     string `json:"hash"`
+	Status   string `json:"status"`
+	Verified bool   `json:"verified"`
+	Error    string `json:"error,omitempty"`
+}
+
+// MigrationReport is the result of MigrateAllocation.
+type MigrationReport struct {
+	StartedAt   time.Time              `json:"started_at"`
+	FinishedAt  time.Time              `json:"finished_at"`
+	Directories int                    `json:"directories"`
+	Files       []*MigrationFileResult `json:"files"`
+	// BytesCopied is the size of the files copied, as downloaded.
+	BytesCopied int64 `json:"bytes_copied"`
+}
+
+// Failed returns the files that couldn't be migrated.
+func (r *MigrationReport) Failed() []*MigrationFileResult {
+	var failed []*MigrationFileResult
+	for _, f := range r.Files {
+		if f.Status == MigrationFailed {
+			failed = append(failed, f)
+		}
+	}
+	return failed
+}
+
+// MigrateAllocation copies the tree of src to dst, which may have other
+// blobbers or data shards: the directories are created, then the files are
+// downloaded from src and uploaded to dst, opts.Parallelism at once, with
+// their thumbnails, mime types, custom meta and collaborators. Encrypted
+// files are encrypted again with the key of the client. The files that dst
+// has already are skipped, so a migration can be run again until it has no
+// failures.
+//
+// The content of every file copied is checked on dst: its hash must be the
+// hash of the file on src, or, for the files whose stored content differs,
+// as they are compressed or encrypted, the content downloaded from dst must
+// be the content downloaded from src.
+//
+// The files that couldn't be migrated are reported and don't stop the
+// migration. An error is returned if the tree of src can't be listed, or if
+// ctx is done, with the report of the files migrated so far.
+func MigrateAllocation(ctx context.Context, src, dst *Allocation, opts MigrationOptions) (*MigrationReport, error) {
+	if !src.isInitialized() || !dst.isInitialized() {
+		return nil, notInitialized
+	}
+	if opts.Parallelism <= 0 {
+		opts.Parallelism = DefaultMigrationParallelism
+	}
+
+	report := &MigrationReport{StartedAt: time.Now()}
+	defer func() {
+		report.FinishedAt = time.Now()
+	}()
+
+	refs, err := src.listAllRefs()
+	if err != nil {
+		return report, errors.Wrap(err, "failed to list the source allocation")
+	}
+
+	var files []*SnapshotEntry
+	for i := range refs {
+		entry := newSnapshotEntry(&refs[i])
+		switch entry.Type {
+		case fileref.DIRECTORY:
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+			if err := dst.CreateDir(entry.Path); err != nil {
+				return report, errors.Wrap(err, "failed to create "+entry.Path)
+			}
+			report.Directories++
+		case fileref.FILE:
+			files = append(files, entry)
+		}
+	}
+
+	report.Files = make([]*MigrationFileResult, len(files))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Parallelism && w < len(files); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m := &fileMigration{ctx: ctx, src: src, dst: dst, opts: opts}
+			defer m.close()
+			for i := range next {
+				report.Files[i] = m.migrate(files[i])
+			}
+		}()
+	}
+
+	for i := range files {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	migrated := report.Files[:0]
+	for _, f := range report.Files {
+		if f == nil {
+			continue
+		}
+		if f.Status == MigrationCopied {
+			report.BytesCopied += f.Size
+		}
+		migrated = append(migrated, f)
+	}
+	report.Files = migrated
+	return report, ctx.Err()
+}
+
+// fileMigration migrates files one after the other, through its temporary
+// file.
+type fileMigration struct {
+	ctx  context.Context
+	src  *Allocation
+	dst  *Allocation
+	opts MigrationOptions
+
+	tmp     sys.File
+	tmpName string
+}
+
+func (m *fileMigration) close() {
+	if m.tmp != nil {
+		m.tmp.Close()
+		sys.Files.Remove(m.tmpName) //nolint
+	}
+}
+
+func (m *fileMigration) migrate(entry *SnapshotEntry) *MigrationFileResult {
+	result := &MigrationFileResult{Path: entry.Path, Hash: entry.Hash}
+	if err := m.copy(entry, result); err != nil {
+		result.Status = MigrationFailed
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (m *fileMigration) copy(entry *SnapshotEntry, result *MigrationFileResult) error {
+	isUpdate := false
+	if existing, err := m.dst.GetFileMeta(entry.Path); err == nil {
+		if existing.Hash == entry.Hash {
+			result.Status = MigrationSkipped
+			result.Size = existing.ActualFileSize
+			return nil
+		}
+		if !m.opts.Overwrite {
+			return errors.New("file_exists", "the destination has another file at "+entry.Path)
+		}
+		isUpdate = true
+	}
+
+	var thumbnail bytes.Buffer
+	if entry.Thumbnail {
+		if err := m.src.downloadTo(entry.Path, DOWNLOAD_CONTENT_THUMB, &thumbnail); err != nil {
+			return errors.Wrap(err, "failed to download the thumbnail")
+		}
+	}
+
+	if m.tmp == nil {
+		tmp, tmpName, err := sys.Files.TempFile("", "migration-*")
+		if err != nil {
+			return err
+		}
+		m.tmp, m.tmpName = tmp, tmpName
+	}
+	if _, err := m.tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	counter := &countingWriter{w: m.tmp}
+	contentHash := sha256.New()
+	if err := m.src.downloadTo(entry.Path, DOWNLOAD_CONTENT_FULL, io.MultiWriter(counter, contentHash)); err != nil {
+		return errors.Wrap(err, "failed to download")
+	}
+	if _, err := m.tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	err := m.dst.uploadEntry(entry, counter.n, io.LimitReader(m.tmp, counter.n), thumbnail.Bytes(), isUpdate, WithContext(m.ctx))
+	if err != nil {
+		return errors.Wrap(err, "failed to upload")
+	}
+	result.Status = MigrationCopied
+	result.Size = counter.n
+
+	if err := m.copyCollaborators(entry.Path); err != nil {
+		return err
+	}
+
+	if m.opts.SkipVerify {
+		return nil
+	}
+	return m.verify(entry, hex.EncodeToString(contentHash.Sum(nil)), result)
+}
+
+func (m *fileMigration) copyCollaborators(remotePath string) error {
+	meta, err := m.src.GetFileMeta(remotePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the collaborators")
+	}
+	for _, c := range meta.Collaborators {
+		if err := m.dst.AddCollaborator(remotePath, c.ClientID); err != nil {
+			return errors.Wrap(err, "failed to add the collaborator "+c.ClientID)
+		}
+	}
+	return nil
+}
+
+// verify checks the file uploaded to dst, contentHash being the hash of the
+// content downloaded from src.
+func (m *fileMigration) verify(entry *SnapshotEntry, contentHash string, result *MigrationFileResult) error {
+	meta, err := m.dst.GetFileMeta(entry.Path)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify")
+	}
+	if meta.Hash != entry.Hash {
+		h := sha256.New()
+		if err := m.dst.downloadTo(entry.Path, DOWNLOAD_CONTENT_FULL, h); err != nil {
+			return errors.Wrap(err, "failed to verify")
+		}
+		if hex.EncodeToString(h.Sum(nil)) != contentHash {
+			return errors.New("hash_mismatch", "the content of "+entry.Path+" differs on the destination")
+		}
+	}
+	result.Verified = true
+	return nil
+}
@@ -0,0 +1,28 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationReportFailed(t *testing.T) {
+	report := &MigrationReport{Files: []*MigrationFileResult{
+		{Path: "/a.txt", Status: MigrationCopied, Verified: true},
+		{Path: "/b.txt", Status: MigrationFailed, Error: "failed to upload"},
+		{Path: "/c.txt", Status: MigrationSkipped},
+	}}
+	require.Equal(t, []*MigrationFileResult{report.Files[1]}, report.Failed())
+	require.Empty(t, (&MigrationReport{}).Failed())
+}
+
+func TestMigrateAllocationNotInitialized(t *testing.T) {
+	src := &Allocation{DataShards: 2, ParityShards: 1}
+	setupMockAllocation(t, src)
+
+	_, err := MigrateAllocation(context.Background(), src, &Allocation{}, MigrationOptions{})
+	require.Equal(t, notInitialized, err)
+	_, err = MigrateAllocation(context.Background(), &Allocation{}, src, MigrationOptions{})
+	require.Equal(t, notInitialized, err)
+}
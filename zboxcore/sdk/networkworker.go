@@ -28,11 +28,15 @@ type Network struct {
 
 func UpdateNetworkDetailsWorker(ctx context.Context) {
 	ticker := time.NewTicker(time.Duration(networkWorkerTimerInHours) * time.Hour)
+	defer ticker.Stop()
+	stop := stopping()
 	for {
 		select {
 		case <-ctx.Done():
 			l.Logger.Info("Network stopped by user")
 			return
+		case <-stop:
+			return
 		case <-ticker.C:
 			err := UpdateNetworkDetails()
 			if err != nil {
@@ -64,6 +68,7 @@ func UpdateNetworkDetails() error {
 		})
 		sdkInitialized = true
 	}
+	markNetworkUpdated()
 	return nil
 }
 
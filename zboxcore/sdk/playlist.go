@@ -9,7 +9,6 @@ import (
 
 	"github.com/0chain/gosdk/core/encryption"
 	"github.com/0chain/gosdk/core/resty"
-	"github.com/0chain/gosdk/core/sys"
 	"github.com/0chain/gosdk/zboxcore/client"
 	"github.com/0chain/gosdk/zboxcore/fileref"
 	"github.com/0chain/gosdk/zboxcore/logger"
@@ -46,6 +45,25 @@ func GetPlaylistByAuthTicket(ctx context.Context, alloc *Allocation, authTicket,
 	return getPlaylistFromBlobbers(ctx, alloc, q.Encode())
 }
 
+// signPlaylistRequest signs the playlist requests to the blobbers of alloc.
+func signPlaylistRequest(alloc *Allocation) resty.Middleware {
+	return resty.RequestInterceptor(func(req *http.Request) error {
+		req.Header.Set("X-App-Client-ID", client.GetClientID())
+		req.Header.Set("X-App-Client-Key", client.GetClientPublicKey())
+
+		hash := encryption.Hash(alloc.ID)
+		sign, err := client.Sign(hash)
+		if err != nil {
+			return err
+		}
+
+		// ClientSignatureHeader represents http request header contains signature.
+		req.Header.Set("X-App-Client-Signature", sign)
+
+		return nil
+	})
+}
+
 func getPlaylistFromBlobbers(ctx context.Context, alloc *Allocation, query string) ([]PlaylistFile, error) {
 
 	urls := make([]string, len(alloc.Blobbers))
@@ -64,21 +82,7 @@ func getPlaylistFromBlobbers(ctx context.Context, alloc *Allocation, query strin
 
 	opts = append(opts, resty.WithRetry(resty.DefaultRetry))
 	opts = append(opts, resty.WithTimeout(resty.DefaultRequestTimeout))
-	opts = append(opts, resty.WithRequestInterceptor(func(req *http.Request) error {
-		req.Header.Set("X-App-Client-ID", client.GetClientID())
-		req.Header.Set("X-App-Client-Key", client.GetClientPublicKey())
-
-		hash := encryption.Hash(alloc.ID)
-		sign, err := sys.Sign(hash, client.GetClient().SignatureScheme, client.GetClientSysKeys())
-		if err != nil {
-			return err
-		}
-
-		// ClientSignatureHeader represents http request header contains signature.
-		req.Header.Set("X-App-Client-Signature", sign)
-
-		return nil
-	}))
+	opts = append(opts, resty.WithMiddleware(signPlaylistRequest(alloc), resty.Trace("playlist")))
 
 	c := createPlaylistConsensus(alloc.getConsensuses())
 
@@ -146,21 +150,7 @@ func getPlaylistFileFromBlobbers(ctx context.Context, alloc *Allocation, query s
 
 	opts = append(opts, resty.WithRetry(resty.DefaultRetry))
 	opts = append(opts, resty.WithTimeout(resty.DefaultRequestTimeout))
-	opts = append(opts, resty.WithRequestInterceptor(func(req *http.Request) error {
-		req.Header.Set("X-App-Client-ID", client.GetClientID())
-		req.Header.Set("X-App-Client-Key", client.GetClientPublicKey())
-
-		hash := encryption.Hash(alloc.ID)
-		sign, err := sys.Sign(hash, client.GetClient().SignatureScheme, client.GetClientSysKeys())
-		if err != nil {
-			return err
-		}
-
-		// ClientSignatureHeader represents http request header contains signature.
-		req.Header.Set("X-App-Client-Signature", sign)
-
-		return nil
-	}))
+	opts = append(opts, resty.WithMiddleware(signPlaylistRequest(alloc), resty.Trace("playlist")))
 
 	c := createPlaylistConsensus(alloc.getConsensuses())
 
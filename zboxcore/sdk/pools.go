@@ -0,0 +1,174 @@
+package sdk
+
+import (
+	"encoding/json"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/core/transaction"
+	"github.com/0chain/gosdk/zboxcore/client"
+)
+
+// PoolTxn is the confirmed transaction of a pool operation.
+type PoolTxn struct {
+	Hash  string `json:"hash"`
+	Nonce int64  `json:"nonce"`
+	// Amount moved by the operation when the smart contract reports it
+	Amount common.Balance `json:"amount,omitempty"`
+}
+
+// AllocationPools is the balance of the pools funding an allocation.
+type AllocationPools struct {
+	AllocationID string         `json:"allocation_id"`
+	WritePool    common.Balance `json:"write_pool"`
+	// ReadPool of the client, read pools are not tied to an allocation
+	ReadPool      common.Balance     `json:"read_pool"`
+	ChallengePool *ChallengePoolInfo `json:"challenge_pool"`
+}
+
+// BlobberStake is the stake pool of a blobber and the stake of a client in it.
+type BlobberStake struct {
+	BlobberID string         `json:"blobber_id"`
+	Pool      *StakePoolInfo `json:"pool"`
+	// Delegate of the client, nil if the client doesn't stake on the blobber
+	Delegate *StakePoolDelegatePoolInfo `json:"delegate,omitempty"`
+}
+
+// LockReadPool locks tokens in the read pool of the client.
+func LockReadPool(tokens, fee uint64) (*PoolTxn, error) {
+	hash, nonce, err := ReadPoolLock(tokens, fee)
+	if err != nil {
+		return nil, err
+	}
+	return &PoolTxn{Hash: hash, Nonce: nonce, Amount: common.Balance(tokens)}, nil
+}
+
+// UnlockReadPool unlocks the tokens of the read pool of the client.
+func UnlockReadPool(fee uint64) (*PoolTxn, error) {
+	hash, nonce, err := ReadPoolUnlock(fee)
+	if err != nil {
+		return nil, err
+	}
+	return &PoolTxn{Hash: hash, Nonce: nonce}, nil
+}
+
+// LockWritePool locks tokens in the write pool of the allocation.
+func LockWritePool(allocID string, tokens, fee uint64) (*PoolTxn, error) {
+	hash, nonce, err := WritePoolLock(allocID, tokens, fee)
+	if err != nil {
+		return nil, err
+	}
+	return &PoolTxn{Hash: hash, Nonce: nonce, Amount: common.Balance(tokens)}, nil
+}
+
+// UnlockWritePool unlocks the write pool tokens of a finalized allocation.
+func UnlockWritePool(allocID string, fee uint64) (*PoolTxn, error) {
+	hash, nonce, err := WritePoolUnlock(allocID, fee)
+	if err != nil {
+		return nil, err
+	}
+	return &PoolTxn{Hash: hash, Nonce: nonce}, nil
+}
+
+// StakeOnBlobber stakes value tokens on the blobber.
+func StakeOnBlobber(blobberID string, value, fee uint64) (*PoolTxn, error) {
+	hash, nonce, err := StakePoolLock(ProviderBlobber, blobberID, value, fee)
+	if err != nil {
+		return nil, err
+	}
+	return &PoolTxn{Hash: hash, Nonce: nonce, Amount: common.Balance(value)}, nil
+}
+
+// UnstakeFromBlobber unstakes the tokens of the client from the blobber.
+// Amount is what was unstaked, see StakePoolUnlock.
+func UnstakeFromBlobber(blobberID string, fee uint64) (*PoolTxn, error) {
+	if !sdkInitialized {
+		return nil, sdkNotInitialized
+	}
+	if blobberID == "" {
+		return nil, errors.New("stake_pool_lock", "provider_id is required")
+	}
+
+	var sn = transaction.SmartContractTxnData{
+		Name:      transaction.STORAGESC_STAKE_POOL_UNLOCK,
+		InputArgs: &stakePoolRequest{ProviderType: ProviderBlobber, ProviderID: blobberID},
+	}
+	hash, out, nonce, _, err := smartContractTxnValueFee(sn, 0, fee)
+	if err != nil {
+		return nil, err
+	}
+
+	var spuu stakePoolLock
+	if err := json.Unmarshal([]byte(out), &spuu); err != nil {
+		return nil, errors.Wrap(err, "error decoding unstake response")
+	}
+	return &PoolTxn{Hash: hash, Nonce: nonce, Amount: common.Balance(spuu.Amount)}, nil
+}
+
+// CollectBlobberRewards collects the rewards of the client from the blobber
+// stake pool. Amount is the reward the client had before collecting.
+func CollectBlobberRewards(blobberID string) (*PoolTxn, error) {
+	stake, err := GetBlobberStake(blobberID, "")
+	if err != nil {
+		return nil, err
+	}
+	hash, nonce, err := CollectRewards(blobberID, ProviderBlobber)
+	if err != nil {
+		return nil, err
+	}
+	txn := &PoolTxn{Hash: hash, Nonce: nonce}
+	if stake.Delegate != nil {
+		txn.Amount = stake.Delegate.Rewards
+	}
+	return txn, nil
+}
+
+// GetAllocationPools returns the write and challenge pools of the allocation
+// and the read pool of the client.
+func GetAllocationPools(allocID string) (*AllocationPools, error) {
+	if !sdkInitialized {
+		return nil, sdkNotInitialized
+	}
+	alloc := &Allocation{ID: allocID}
+	if err := GetAllocationUpdates(alloc); err != nil {
+		return nil, err
+	}
+	readPool, err := GetReadPoolInfo("")
+	if err != nil {
+		return nil, err
+	}
+	challengePool, err := GetChallengePoolInfo(allocID)
+	if err != nil {
+		return nil, err
+	}
+	return &AllocationPools{
+		AllocationID:  allocID,
+		WritePool:     alloc.WritePool,
+		ReadPool:      readPool.Balance,
+		ChallengePool: challengePool,
+	}, nil
+}
+
+// GetBlobberStake returns the stake pool of the blobber and the stake of
+// clientID in it, or of the current client if clientID is empty.
+func GetBlobberStake(blobberID, clientID string) (*BlobberStake, error) {
+	if clientID == "" {
+		clientID = client.GetClientID()
+	}
+	pool, err := GetStakePoolInfo(ProviderBlobber, blobberID)
+	if err != nil {
+		return nil, err
+	}
+	return newBlobberStake(blobberID, clientID, pool), nil
+}
+
+func newBlobberStake(blobberID, clientID string, pool *StakePoolInfo) *BlobberStake {
+	stake := &BlobberStake{BlobberID: blobberID, Pool: pool}
+	for i := range pool.Delegate {
+		if string(pool.Delegate[i].DelegateID) == clientID {
+			stake.Delegate = &pool.Delegate[i]
+			break
+		}
+	}
+	return stake
+}
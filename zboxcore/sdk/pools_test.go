@@ -0,0 +1,39 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBlobberStake(t *testing.T) {
+	pool := &StakePoolInfo{
+		ID: "blobber",
+		Delegate: []StakePoolDelegatePoolInfo{
+			{ID: "p1", DelegateID: "alice", Balance: 10, Rewards: 1},
+			{ID: "p2", DelegateID: "bob", Balance: 20, Rewards: 2},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		clientID    string
+		wantRewards int64
+		wantNil     bool
+	}{
+		{name: "Test_Delegate_Found", clientID: "bob", wantRewards: 2},
+		{name: "Test_Not_A_Delegate", clientID: "carol", wantNil: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stake := newBlobberStake("blobber", tt.clientID, pool)
+			require.Equal(t, pool, stake.Pool)
+			if tt.wantNil {
+				require.Nil(t, stake.Delegate)
+				return
+			}
+			require.NotNil(t, stake.Delegate)
+			require.EqualValues(t, tt.wantRewards, stake.Delegate.Rewards)
+		})
+	}
+}
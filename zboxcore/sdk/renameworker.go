@@ -13,6 +13,7 @@ import (
 	"github.com/0chain/errors"
 
 	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/core/sys"
 	"github.com/0chain/gosdk/zboxcore/client"
 	"github.com/0chain/gosdk/zboxcore/fileref"
 	"github.com/0chain/gosdk/zboxcore/logger"
@@ -83,7 +84,7 @@ func (req *RenameRequest) renameBlobberObject(
 			}
 
 			httpreq.Header.Add("Content-Type", formWriter.FormDataContentType())
-			ctx, cncl := context.WithTimeout(req.ctx, DefaultUploadTimeOut)
+			ctx, cncl := context.WithTimeout(req.ctx, timeoutsOf(req.ctx).Commit)
 			resp, err = zboxutil.Client.Do(httpreq.WithContext(ctx))
 			defer cncl()
 
@@ -119,7 +120,7 @@ func (req *RenameRequest) renameBlobberObject(
 					logger.Logger.Error(err)
 					return
 				}
-				time.Sleep(time.Duration(r) * time.Second)
+				sys.Sleep(time.Duration(r) * time.Second)
 				shouldContinue = true
 				return
 			}
@@ -164,9 +165,8 @@ func (req *RenameRequest) ProcessRename() error {
 	req.wg.Wait()
 
 	if !req.consensus.isConsensusOk() {
-		return errors.New("consensus_not_met",
-			fmt.Sprintf("Rename failed. Required consensus %d got %d",
-				req.consensus.consensusThresh, req.consensus.getConsensus()))
+		return &constants.ErrConsensusNotMet{Op: "Rename",
+			Got: req.consensus.getConsensus(), Need: req.consensus.consensusThresh}
 	}
 
 	writeMarkerMutex, err := CreateWriteMarkerMutex(client.GetClient(), req.allocationObj)
@@ -204,6 +204,7 @@ func (req *RenameRequest) ProcessRename() error {
 			allocationTx: req.allocationTx,
 			blobber:      req.blobbers[pos],
 			connectionID: req.connectionID,
+			ctx:          req.ctx,
 			wg:           wg,
 		}
 		commitReq.changes = append(commitReq.changes, newChange)
@@ -232,9 +233,8 @@ func (req *RenameRequest) ProcessRename() error {
 	}
 
 	if !req.consensus.isConsensusOk() {
-		return errors.New("consensus_not_met",
-			fmt.Sprintf("Required consensus %d got %d. Error: %s",
-				req.consensus.consensusThresh, req.consensus.consensus, errMessages))
+		return &constants.ErrConsensusNotMet{Op: "Commit on rename",
+			Got: req.consensus.consensus, Need: req.consensus.consensusThresh, Reason: errMessages}
 	}
 	return nil
 }
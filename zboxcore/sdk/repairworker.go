@@ -3,6 +3,7 @@ package sdk
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 
 	"github.com/0chain/gosdk/core/sys"
 	"github.com/0chain/gosdk/zboxcore/fileref"
@@ -12,12 +13,22 @@ import (
 
 type RepairRequest struct {
 	listDir           *ListResult
-	isRepairCanceled  bool
+	isRepairCanceled  int32
 	localRootPath     string
 	statusCB          StatusCallback
 	completedCallback func()
 	filesRepaired     int
 	wg                *sync.WaitGroup
+	ctx               context.Context
+	ctxCncl           context.CancelFunc
+	opDone            func() // tells Shutdown the repair is over, see beginOp
+
+	mu sync.Mutex
+	// changed is set when the allocation changed on chain during the repair
+	changed  *AllocationChangedError
+	reported bool
+	// lastPath is the last file checked and repaired if needed
+	lastPath string
 }
 
 type RepairStatusCB struct {
@@ -46,6 +57,7 @@ func (cb *RepairStatusCB) Completed(allocationId, filePath string, filename stri
 }
 
 func (cb *RepairStatusCB) Error(allocationID string, filePath string, op int, err error) {
+	recordError("repair", err)
 	cb.statusCB.Error(allocationID, filePath, op, err)
 	cb.success = false
 	cb.err = err
@@ -53,21 +65,39 @@ func (cb *RepairStatusCB) Error(allocationID string, filePath string, op int, er
 }
 
 func (r *RepairRequest) processRepair(ctx context.Context, a *Allocation) {
+	defer r.opDone()
+	defer r.ctxCncl()
 	if r.completedCallback != nil {
 		defer r.completedCallback()
 	}
 
+	watchCtx, stopWatch := context.WithCancel(r.ctx)
+	defer stopWatch()
+	go a.watchTopology(watchCtx, r.allocationChanged)
+
 	if r.checkForCancel(a) {
 		return
 	}
 
 	r.iterateDir(a, r.listDir)
 
-	if r.statusCB != nil {
+	if r.statusCB != nil && !r.isStopped() {
 		r.statusCB.RepairCompleted(r.filesRepaired)
 	}
 }
 
+func (r *RepairRequest) allocationChanged(err *AllocationChangedError) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changed = err
+}
+
+func (r *RepairRequest) isStopped() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.changed != nil
+}
+
 func (r *RepairRequest) iterateDir(a *Allocation, dir *ListResult) {
 	switch dir.Type {
 	case fileref.DIRECTORY:
@@ -105,7 +135,9 @@ func (r *RepairRequest) repairFile(a *Allocation, file *ListResult) {
 		return
 	}
 
-	if repairRequired {
+	if !repairRequired {
+		r.lastPath = file.Path
+	} else {
 		l.Logger.Info("Repair required for the path :", zap.Any("path", file.Path))
 		if found.CountOnes() >= a.DataShards {
 			l.Logger.Info("Repair by upload", zap.Any("path", file.Path))
@@ -168,6 +200,7 @@ func (r *RepairRequest) repairFile(a *Allocation, file *ListResult) {
 		}
 		l.Logger.Info("Repair file success", zap.Any("remotepath", file.Path))
 		r.filesRepaired++
+		r.lastPath = file.Path
 	}
 
 }
@@ -185,7 +218,10 @@ func checkFileExists(localPath string) bool {
 }
 
 func (r *RepairRequest) checkForCancel(a *Allocation) bool {
-	if r.isRepairCanceled {
+	if r.checkForChange(a) {
+		return true
+	}
+	if atomic.LoadInt32(&r.isRepairCanceled) == 1 || r.ctx.Err() != nil || isShuttingDown() {
 		l.Logger.Info("Repair Cancelled by the user")
 		if r.statusCB != nil {
 			r.statusCB.RepairCompleted(r.filesRepaired)
@@ -194,3 +230,27 @@ func (r *RepairRequest) checkForCancel(a *Allocation) bool {
 	}
 	return false
 }
+
+// checkForChange stops the repair once the allocation changed on chain, the
+// StatusCallback gets the *AllocationChangedError with the checkpoint once.
+func (r *RepairRequest) checkForChange(a *Allocation) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.changed == nil {
+		return false
+	}
+	if r.reported {
+		return true
+	}
+	r.reported = true
+	r.changed.Checkpoint = &RepairCheckpoint{
+		RootPath:      r.listDir.Path,
+		LastPath:      r.lastPath,
+		FilesRepaired: r.filesRepaired,
+	}
+	l.Logger.Info("Repair stopped: ", r.changed.Error())
+	if r.statusCB != nil {
+		r.statusCB.Error(a.ID, r.listDir.Path, OpRepair, r.changed)
+	}
+	return true
+}
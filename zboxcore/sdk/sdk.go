@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -12,9 +13,12 @@ import (
 	"time"
 
 	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
 	"github.com/0chain/gosdk/core/conf"
 	"github.com/0chain/gosdk/core/logger"
+	"github.com/0chain/gosdk/core/netconf"
 	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/core/telemetry"
 	"github.com/0chain/gosdk/zcncore"
 
 	"github.com/0chain/gosdk/core/common"
@@ -26,6 +30,8 @@ import (
 	l "github.com/0chain/gosdk/zboxcore/logger"
 	"github.com/0chain/gosdk/zboxcore/marker"
 	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const STORAGE_SCADDRESS = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7"
@@ -74,6 +80,35 @@ func SetLogFile(logFile string, verbose bool) {
 	l.Logger.Info("******* Storage SDK Version: ", version.VERSIONSTR, " *******")
 }
 
+// SetLogger routes the logs of all the sdk packages to s, e.g. an adapter
+// to the logger of the app. nil restores the log writers.
+func SetLogger(s logger.Sink) {
+	logger.SetSink(s)
+}
+
+// SetSubsystemLogLevel sets the log level of a subsystem, e.g. "0box-sdk" or
+// "0chain-core-sdk", see logger.Subsystems.
+func SetSubsystemLogLevel(subsystem string, lvl int) {
+	logger.SetSubsystemLevel(subsystem, lvl)
+}
+
+// EnableTelemetry traces the blobber requests, the transactions and the
+// upload and download chunks with tp, and records the per-blobber latency,
+// errors, retries and bytes transferred with mp. Either may be nil; both nil
+// disables the telemetry.
+func EnableTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) {
+	telemetry.Enable(telemetry.OTel(tp, mp))
+}
+
+// SetTLSConfig sets the TLS config of the connections to the blobbers,
+// miners, sharders and Ethereum nodes, e.g. with a client certificate and
+// the CA of a private network behind mTLS gateways, see
+// netconf.NewTLSConfig. Blobbers can be given their own config with
+// zboxutil.SetTransportOptions.
+func SetTLSConfig(cfg *tls.Config) {
+	netconf.SetTLSConfig(cfg)
+}
+
 func GetLogger() *logger.Logger {
 	return &l.Logger
 }
@@ -85,6 +120,7 @@ func InitStorageSDK(walletJSON string, blockWorker, chainID, signatureScheme str
 		return err
 	}
 	client.SetClientNonce(nonce)
+	resetLifecycle()
 
 	blockchain.SetChainID(chainID)
 	blockchain.SetPreferredBlobbers(preferredBlobbers)
@@ -138,6 +174,7 @@ func SetNetwork(miners []string, sharders []string) {
 	blockchain.SetMiners(miners)
 	blockchain.SetSharders(sharders)
 	transaction.InitCache(sharders)
+	markNetworkUpdated()
 }
 
 //
@@ -575,6 +612,7 @@ type Blobber struct {
 	Capacity                 common.Size                  `json:"capacity"`
 	Allocated                common.Size                  `json:"allocated"`
 	LastHealthCheck          common.Timestamp             `json:"last_health_check"`
+	Geolocation              Geolocation                  `json:"geolocation"`
 	PublicKey                string                       `json:"-"`
 	StakePoolSettings        blockchain.StakePoolSettings `json:"stake_pool_settings"`
 	TotalStake               int64                        `json:"total_stake"`
@@ -584,6 +622,12 @@ type Blobber struct {
 	UncollectedServiceCharge int64                        `json:"uncollected_service_charge"`
 }
 
+// Geolocation of a blobber as declared on registration
+type Geolocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
 type Validator struct {
 	ID                       common.Key     `json:"validator_id"`
 	BaseURL                  string         `json:"url"`
@@ -765,12 +809,12 @@ func GetAllocationFromAuthTicket(authTicket string) (*Allocation, error) {
 	}
 	sEnc, err := base64.StdEncoding.DecodeString(authTicket)
 	if err != nil {
-		return nil, errors.New("auth_ticket_decode_error", "Error decoding the auth ticket."+err.Error())
+		return nil, errors.Throw(constants.ErrInvalidAuthTicket, "Error decoding the auth ticket."+err.Error())
 	}
 	at := &marker.AuthTicket{}
 	err = json.Unmarshal(sEnc, at)
 	if err != nil {
-		return nil, errors.New("auth_ticket_decode_error", "Error unmarshaling the auth ticket."+err.Error())
+		return nil, errors.Throw(constants.ErrInvalidAuthTicket, "Error unmarshaling the auth ticket."+err.Error())
 	}
 	return GetAllocation(at.AllocationID)
 }
@@ -779,22 +823,36 @@ func GetAllocation(allocationID string) (*Allocation, error) {
 	if !sdkInitialized {
 		return nil, sdkNotInitialized
 	}
-	params := make(map[string]string)
-	params["allocation"] = allocationID
-	allocationBytes, err := zcncore.MakeSCRestAPICall(STORAGE_SCADDRESS, "/allocation", params)
-	if err != nil {
-		return nil, errors.New("allocation_fetch_error", "Error fetching the allocation."+err.Error())
+	// every caller gets its own Allocation, decoded from the cached response
+	mc := getMetadataCache()
+	allocationBytes, ok := []byte(nil), false
+	if mc != nil {
+		allocationBytes, ok = mc.allocations.get(allocationID)
+	}
+	if !ok {
+		params := make(map[string]string)
+		params["allocation"] = allocationID
+		var err error
+		allocationBytes, err = zcncore.MakeSCRestAPICall(STORAGE_SCADDRESS, "/allocation", params)
+		if err != nil {
+			return nil, errors.New("allocation_fetch_error", "Error fetching the allocation."+err.Error())
+		}
 	}
 	allocationObj := &Allocation{}
-	err = json.Unmarshal(allocationBytes, allocationObj)
+	err := json.Unmarshal(allocationBytes, allocationObj)
 	if err != nil {
 		return nil, errors.New("allocation_decode_error", "Error decoding the allocation."+err.Error())
 	}
+	if mc != nil && !ok {
+		mc.allocations.add(allocationID, allocationBytes)
+	}
 	allocationObj.numBlockDownloads = numBlockDownloads
 	allocationObj.InitAllocation()
 	return allocationObj, nil
 }
 
+// GetAllocationUpdates refreshes allocation from the chain. It must not be
+// called while operations on allocation are running.
 func GetAllocationUpdates(allocation *Allocation) error {
 	if allocation == nil {
 		return errors.New("allocation_not_initialized", "")
@@ -821,6 +879,7 @@ func GetAllocationUpdates(allocation *Allocation) error {
 	allocation.Stats = updatedAllocationObj.Stats
 	allocation.TimeUnit = updatedAllocationObj.TimeUnit
 	allocation.IsImmutable = updatedAllocationObj.IsImmutable
+	allocation.WritePool = updatedAllocationObj.WritePool
 	allocation.BlobberDetails = updatedAllocationObj.BlobberDetails
 	allocation.ReadPriceRange = updatedAllocationObj.ReadPriceRange
 	allocation.WritePriceRange = updatedAllocationObj.WritePriceRange
@@ -832,6 +891,7 @@ func GetAllocationUpdates(allocation *Allocation) error {
 	allocation.MovedBack = updatedAllocationObj.MovedBack
 	allocation.MovedToValidators = updatedAllocationObj.MovedToValidators
 	allocation.Curators = updatedAllocationObj.Curators
+	allocation.fullconsensus, allocation.consensusThreshold = allocation.getConsensuses()
 	return nil
 }
 
@@ -873,11 +933,18 @@ type CreateAllocationOptions struct {
 	WritePrice   PriceRange
 	Lock         uint64
 	BlobberIds   []string
+	// BlobberSelector picks the blobbers client side. It is ignored when
+	// BlobberIds is set.
+	BlobberSelector BlobberSelector
 }
 
 func CreateAllocationWith(options CreateAllocationOptions) (
 	string, int64, *transaction.Transaction, error) {
 
+	if len(options.BlobberIds) == 0 && options.BlobberSelector != nil {
+		return createAllocationWithSelector(options)
+	}
+
 	if len(options.BlobberIds) > 0 {
 		return CreateAllocationForOwner(options.Name, client.GetClientID(),
 			client.GetClientPublicKey(), options.DataShards, options.ParityShards,
@@ -1071,6 +1138,9 @@ func AddFreeStorageAssigner(name, publicKey string, individualLimit, totalLimit
 	return hash, n, err
 }
 
+// CreateFreeAllocation creates an allocation for the client from a free
+// storage marker signed by a free storage assigner. value is locked in the
+// write pool in addition to the tokens granted by the marker.
 func CreateFreeAllocation(marker string, value uint64) (string, int64, error) {
 	if !sdkInitialized {
 		return "", 0, sdkNotInitialized
@@ -1126,6 +1196,7 @@ func UpdateAllocation(name string,
 		InputArgs: updateAllocationRequest,
 	}
 	hash, _, nonce, _, err = smartContractTxnValue(sn, lock)
+	InvalidateMetadataCache(allocationID)
 	return
 }
 
@@ -1156,6 +1227,7 @@ func FinalizeAllocation(allocID string) (hash string, nonce int64, err error) {
 		InputArgs: map[string]interface{}{"allocation_id": allocID},
 	}
 	hash, _, nonce, _, err = smartContractTxn(sn)
+	InvalidateMetadataCache(allocID)
 	return
 }
 
@@ -1168,6 +1240,7 @@ func CancelAllocation(allocID string) (hash string, nonce int64, err error) {
 		InputArgs: map[string]interface{}{"allocation_id": allocID},
 	}
 	hash, _, nonce, _, err = smartContractTxn(sn)
+	InvalidateMetadataCache(allocID)
 	return
 }
 
@@ -1232,6 +1305,9 @@ func CollectRewards(providerId string, providerType ProviderType) (string, int64
 	return hash, n, err
 }
 
+// CuratorTransferAllocation makes newOwner the owner of the allocation. The
+// client must be a curator of the allocation, see AddCurator.
+// Allocation.TransferOwnership also hands over the encrypted files.
 func CuratorTransferAllocation(allocationId, newOwner, newOwnerPublicKey string) (string, int64, error) {
 	if !sdkInitialized {
 		return "", 0, sdkNotInitialized
@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/dev/sdktest"
+	zclient "github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocation_UploadToSdktestBlobbers(t *testing.T) {
+	prev := zboxutil.Client
+	zboxutil.Client = &http.Client{}
+	defer func() { zboxutil.Client = prev }()
+
+	zclient.GetClient().Wallet = &zcncrypto.Wallet{
+		ClientID:  mockClientId,
+		ClientKey: mockClientKey,
+	}
+
+	a := &Allocation{
+		ID:           "TestAllocation_UploadToSdktestBlobbers",
+		Tx:           "TestAllocation_UploadToSdktestBlobbers",
+		DataShards:   2,
+		ParityShards: 1,
+		Size:         2 * GB,
+	}
+	setupMockAllocation(t, a)
+
+	var blobbers []*sdktest.Blobber
+	for i := 0; i < a.DataShards+a.ParityShards; i++ {
+		b := sdktest.NewBlobber(mockBlobberId + strconv.Itoa(i))
+		defer b.Close()
+		blobbers = append(blobbers, b)
+		a.Blobbers = append(a.Blobbers, b.StorageNode())
+	}
+
+	content := []byte("hello sdktest")
+	fileMeta := FileMeta{
+		Path:       "/tmp/hello.txt",
+		ActualSize: int64(len(content)),
+		MimeType:   "text/plain",
+		RemoteName: "hello.txt",
+		RemotePath: "/docs/hello.txt",
+	}
+	chunkedUpload, err := CreateChunkedUpload(t.TempDir(), a, fileMeta, bytes.NewReader(content), false, false)
+	require.NoError(t, err)
+	chunkedUpload.progressStorer = &nopeChunkedUploadProgressStorer{}
+	require.NoError(t, chunkedUpload.Start())
+
+	for _, b := range blobbers {
+		require.Equal(t, []string{"/docs/hello.txt"}, b.Files(a.Tx))
+	}
+
+	list, err := a.ListDir("/docs")
+	require.NoError(t, err)
+	require.Len(t, list.Children, 1)
+	require.Equal(t, "hello.txt", list.Children[0].Name)
+
+	meta, err := a.GetFileMeta("/docs/hello.txt")
+	require.NoError(t, err)
+	require.Equal(t, int64(len(content)), meta.Size)
+	require.Equal(t, "text/plain", meta.MimeType)
+}
@@ -0,0 +1,164 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// ErrShutdown is returned by the operations started once Shutdown was called.
+var ErrShutdown = errors.New("sdk_shutdown", "the sdk is shut down")
+
+// shutdownGracePeriod is how long Shutdown waits for the operations it
+// cancelled to return, once its ctx is done.
+var shutdownGracePeriod = 5 * time.Second
+
+// lifecycle tracks the operations in flight of all the allocations so that
+// Shutdown can drain them. It is replaced by InitStorageSDK once shut down.
+type lifecycle struct {
+	mu sync.Mutex
+	// stopping is closed by Shutdown, stopping the background workers and
+	// the uploads at their next checkpoint
+	stopping chan struct{}
+	// ctx is the parent of the contexts of the allocations, cancelled once
+	// the operations are drained or Shutdown gives up on them
+	ctx    context.Context
+	cancel context.CancelFunc
+	ops    int
+	// drained is closed when ops drops to 0
+	drained chan struct{}
+}
+
+func newLifecycle() *lifecycle {
+	lc := &lifecycle{stopping: make(chan struct{})}
+	lc.ctx, lc.cancel = context.WithCancel(context.Background())
+	return lc
+}
+
+var (
+	lifecycleMu  sync.Mutex
+	sdkLifecycle = newLifecycle()
+)
+
+func currentLifecycle() *lifecycle {
+	lifecycleMu.Lock()
+	defer lifecycleMu.Unlock()
+	return sdkLifecycle
+}
+
+// resetLifecycle starts a new lifecycle if the current one is shut down.
+func resetLifecycle() {
+	lifecycleMu.Lock()
+	defer lifecycleMu.Unlock()
+	select {
+	case <-sdkLifecycle.stopping:
+		sdkLifecycle = newLifecycle()
+	default:
+	}
+}
+
+// lifecycleContext is the parent of the contexts of the allocations.
+func lifecycleContext() context.Context {
+	return currentLifecycle().ctx
+}
+
+// stopping returns the channel closed when the sdk is shutting down, for
+// the background workers to return.
+func stopping() <-chan struct{} {
+	return currentLifecycle().stopping
+}
+
+func isShuttingDown() bool {
+	select {
+	case <-stopping():
+		return true
+	default:
+		return false
+	}
+}
+
+// beginOp tracks an operation Shutdown waits for, failing with ErrShutdown
+// once the sdk is shutting down. done must be called when it is over.
+func beginOp() (done func(), err error) {
+	lc := currentLifecycle()
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	select {
+	case <-lc.stopping:
+		return nil, ErrShutdown
+	default:
+	}
+	return lc.add(), nil
+}
+
+func (lc *lifecycle) add() func() {
+	if lc.ops == 0 {
+		lc.drained = make(chan struct{})
+	}
+	lc.ops++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			lc.mu.Lock()
+			defer lc.mu.Unlock()
+			lc.ops--
+			if lc.ops == 0 {
+				close(lc.drained)
+			}
+		})
+	}
+}
+
+// wait returns once no operation is in flight or ctx is done.
+func (lc *lifecycle) wait(ctx context.Context) error {
+	lc.mu.Lock()
+	if lc.ops == 0 {
+		lc.mu.Unlock()
+		return nil
+	}
+	drained := lc.drained
+	lc.mu.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown stops the sdk for the process to exit cleanly. The operations
+// started afterwards fail with ErrShutdown and the background workers, e.g.
+// repairs, blobber probers and the network and change watchers, return.
+// Uploads in flight go on until they complete or reach a checkpoint they
+// can be resumed from, then fail with ErrShutdown. Once they are drained,
+// or ctx is done, the requests still in flight of every allocation are
+// cancelled and the idle connections to the blobbers closed. The error of
+// ctx is returned if it was done before the uploads were drained, the
+// cancelled operations being given a short grace period to return.
+//
+// The sdk is started again by InitStorageSDK, the allocations must then be
+// got again.
+func Shutdown(ctx context.Context) error {
+	lc := currentLifecycle()
+	lc.mu.Lock()
+	select {
+	case <-lc.stopping:
+	default:
+		close(lc.stopping)
+	}
+	lc.mu.Unlock()
+
+	err := lc.wait(ctx)
+	lc.cancel()
+	if err != nil {
+		grace, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+		_ = lc.wait(grace)
+		cancel()
+	}
+	zboxutil.CloseIdleConnections()
+	return err
+}
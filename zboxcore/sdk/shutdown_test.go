@@ -0,0 +1,201 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/0chain/gosdk/dev/sdktest"
+	"github.com/0chain/gosdk/zboxcore/blockchain"
+	zclient "github.com/0chain/gosdk/zboxcore/client"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+	"github.com/stretchr/testify/require"
+)
+
+type shutdownStatusCallback struct {
+	discardStatusCallback
+	onProgress func()
+}
+
+func (cb *shutdownStatusCallback) InProgress(allocationId, filePath string, op int, completedBytes int, data []byte) {
+	cb.onProgress()
+}
+
+func TestShutdown(t *testing.T) {
+	t.Run("Test_Drains_Operations", func(t *testing.T) {
+		defer resetLifecycle()
+		done, err := beginOp()
+		require.NoError(t, err)
+
+		shutdown := make(chan error, 1)
+		go func() { shutdown <- Shutdown(context.Background()) }()
+		require.Eventually(t, isShuttingDown, time.Second, time.Millisecond)
+
+		_, err = beginOp()
+		require.ErrorIs(t, err, ErrShutdown)
+		select {
+		case <-shutdown:
+			require.Fail(t, "returned with an operation in flight")
+		case <-time.After(50 * time.Millisecond):
+		}
+		require.NoError(t, lifecycleContext().Err(), "operations aren't cancelled while draining")
+
+		done()
+		require.NoError(t, <-shutdown)
+		require.Error(t, lifecycleContext().Err())
+	})
+
+	t.Run("Test_Cancels_Operations_When_Ctx_Done", func(t *testing.T) {
+		defer resetLifecycle()
+		done, err := beginOp()
+		require.NoError(t, err)
+		opCtx := lifecycleContext()
+		go func() {
+			<-opCtx.Done()
+			done()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, Shutdown(ctx), context.DeadlineExceeded)
+		require.Error(t, opCtx.Err())
+	})
+
+	t.Run("Test_Bounded_When_Operations_Hang", func(t *testing.T) {
+		defer resetLifecycle()
+		prev := shutdownGracePeriod
+		shutdownGracePeriod = 20 * time.Millisecond
+		defer func() { shutdownGracePeriod = prev }()
+
+		// never done, e.g. an upload stuck on a blobber ignoring cancellation
+		_, err := beginOp()
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		shutdown := make(chan error, 1)
+		go func() { shutdown <- Shutdown(ctx) }()
+		select {
+		case err := <-shutdown:
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+		case <-time.After(time.Second):
+			require.Fail(t, "not returned once the grace period is over")
+		}
+	})
+
+	t.Run("Test_Waits_For_Queued_Uploads", func(t *testing.T) {
+		defer resetLifecycle()
+		setupMocks()
+		a := &Allocation{
+			Blobbers:     make([]*blockchain.StorageNode, 4),
+			DataShards:   2,
+			ParityShards: 2,
+		}
+		a.fullconsensus, a.consensusThreshold = a.getConsensuses()
+		a.mutex = &sync.Mutex{}
+		a.uploadProgressMap = make(map[string]*UploadRequest)
+		a.initialized = true
+		sdkInitialized = true
+		// no dispatcher, the upload stays queued
+		a.uploadChan = make(chan *UploadRequest, 1)
+
+		require.NoError(t, a.uploadOrUpdateFile("", "/", nil, false, "", false, false))
+
+		shutdown := make(chan error, 1)
+		go func() { shutdown <- Shutdown(context.Background()) }()
+		require.Eventually(t, isShuttingDown, time.Second, time.Millisecond)
+		require.ErrorIs(t, a.uploadOrUpdateFile("", "/", nil, false, "", false, false), ErrShutdown)
+		select {
+		case <-shutdown:
+			require.Fail(t, "returned with an upload queued")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		req := <-a.uploadChan
+		req.opDone()
+		require.NoError(t, <-shutdown)
+	})
+
+	t.Run("Test_Stops_Background_Workers", func(t *testing.T) {
+		defer resetLifecycle()
+		a := &Allocation{}
+		a.ctx, a.ctxCancelF = context.WithCancel(withAllocationTimeouts(lifecycleContext(), a))
+		stopped := make(chan struct{})
+		go func() {
+			a.watchTopology(context.Background(), func(*AllocationChangedError) {})
+			close(stopped)
+		}()
+
+		require.NoError(t, Shutdown(context.Background()))
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			require.Fail(t, "topology watcher not stopped")
+		}
+		require.Error(t, a.ctx.Err(), "allocation contexts are cancelled")
+	})
+
+	t.Run("Test_Restarted", func(t *testing.T) {
+		require.NoError(t, Shutdown(context.Background()))
+		resetLifecycle()
+		done, err := beginOp()
+		require.NoError(t, err)
+		done()
+		require.NoError(t, lifecycleContext().Err())
+	})
+}
+
+func TestShutdown_UploadCheckpoint(t *testing.T) {
+	defer resetLifecycle()
+	prev := zboxutil.Client
+	zboxutil.Client = &http.Client{}
+	defer func() { zboxutil.Client = prev }()
+
+	zclient.GetClient().Wallet = &zcncrypto.Wallet{
+		ClientID:  mockClientId,
+		ClientKey: mockClientKey,
+	}
+
+	a := &Allocation{
+		ID:           "TestShutdown_UploadCheckpoint",
+		Tx:           "TestShutdown_UploadCheckpoint",
+		DataShards:   2,
+		ParityShards: 1,
+		Size:         2 * GB,
+	}
+	setupMockAllocation(t, a)
+	for i := 0; i < a.DataShards+a.ParityShards; i++ {
+		b := sdktest.NewBlobber(mockBlobberId + strconv.Itoa(i))
+		defer b.Close()
+		a.Blobbers = append(a.Blobbers, b.StorageNode())
+	}
+
+	content := bytes.Repeat([]byte("0chain"), 4*DefaultChunkSize)
+	fileMeta := FileMeta{
+		Path:       "/tmp/big.txt",
+		ActualSize: int64(len(content)),
+		MimeType:   "text/plain",
+		RemoteName: "big.txt",
+		RemotePath: "/big.txt",
+	}
+	progress := 0
+	status := &shutdownStatusCallback{onProgress: func() {
+		progress++
+		if progress == 1 {
+			require.NoError(t, Shutdown(context.Background()))
+		}
+	}}
+	chunkedUpload, err := CreateChunkedUpload(t.TempDir(), a, fileMeta, bytes.NewReader(content), false, false,
+		WithChunkNumber(1), WithStatusCallback(status))
+	require.NoError(t, err)
+	chunkedUpload.progressStorer = &nopeChunkedUploadProgressStorer{}
+
+	require.ErrorIs(t, chunkedUpload.Start(), ErrShutdown)
+	require.Equal(t, 1, progress, "stopped at the first checkpoint")
+	require.Less(t, chunkedUpload.progress.UploadLength, int64(len(content)))
+}
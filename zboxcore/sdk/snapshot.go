@@ -0,0 +1,311 @@
+package sdk
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/mitchellh/go-homedir"
+)
+
+// SnapshotVersion is the version of the archives of ExportSnapshot.
+const SnapshotVersion = 1
+
+// The entries of a snapshot archive: the manifest first, then the content of
+// every file, preceded by its thumbnail if it has one.
+const (
+	snapshotManifestName = "snapshot.json"
+	snapshotFilesDir     = "files"
+	snapshotThumbsDir    = "thumbnails"
+)
+
+// ErrInvalidSnapshot is returned by ImportSnapshot for archives that weren't
+// made by ExportSnapshot.
+var ErrInvalidSnapshot = errors.New("invalid_snapshot", "not a snapshot archive")
+
+// Snapshot is the manifest of a snapshot archive.
+type Snapshot struct {
+	Version      int              `json:"version"`
+	AllocationID string           `json:"allocation_id"`
+	DataShards   int              `json:"data_shards"`
+	ParityShards int              `json:"parity_shards"`
+	CreatedAt    common.Timestamp `json:"created_at"`
+	Entries      []*SnapshotEntry `json:"entries"`
+}
+
+// SnapshotEntry is a directory or a file of a snapshot.
+type SnapshotEntry struct {
+	Path       string            `json:"path"`
+	Type       string            `json:"type"`
+	MimeType   string            `json:"mimetype,omitempty"`
+	Hash       string            `json:"hash,omitempty"`
+	CustomMeta map[string]string `json:"custom_meta,omitempty"`
+	// EncryptedKey is the encryption header of an encrypted file. The
+	// content is archived decrypted and encrypted again by ImportSnapshot,
+	// with the key of the importing client.
+	EncryptedKey string           `json:"encrypted_key,omitempty"`
+	Thumbnail    bool             `json:"thumbnail,omitempty"`
+	CreatedAt    common.Timestamp `json:"created_at"`
+	UpdatedAt    common.Timestamp `json:"updated_at"`
+}
+
+func newSnapshotEntry(ref *ORef) *SnapshotEntry {
+	return &SnapshotEntry{
+		Path:         ref.Path,
+		Type:         ref.Type,
+		MimeType:     ref.MimeType,
+		Hash:         ref.ActualFileHash,
+		CustomMeta:   decodeCustomMeta(ref.CustomMeta),
+		EncryptedKey: ref.EncryptedKey,
+		Thumbnail:    ref.ActualThumbnailSize > 0,
+		CreatedAt:    ref.CreatedAt,
+		UpdatedAt:    ref.UpdatedAt,
+	}
+}
+
+// listAllRefs returns the refs of all the directories and files of the
+// allocation, but the root.
+func (a *Allocation) listAllRefs() ([]ORef, error) {
+	var refs []ORef
+	offsetPath := ""
+	for {
+		oResult, err := a.GetRefs("/", offsetPath, "", "", "", "regular", 0, defaultSearchPageLimit)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range oResult.Refs {
+			if ref.Path != "/" {
+				refs = append(refs, ref)
+			}
+		}
+		if len(oResult.Refs) < defaultSearchPageLimit || oResult.OffsetPath == "" || oResult.OffsetPath == offsetPath {
+			return refs, nil
+		}
+		offsetPath = oResult.OffsetPath
+	}
+}
+
+// ExportSnapshot writes a tar archive of the allocation to w: a manifest
+// with the directories and the metadata of the files, then the content of
+// the files and their thumbnails. Files are archived as downloaded, so
+// encrypted files are decrypted and compressed files decompressed. The
+// content is downloaded to a temporary file before it's archived, as tar
+// entries need their size first.
+func (a *Allocation) ExportSnapshot(w io.Writer) error {
+	if !a.isInitialized() {
+		return notInitialized
+	}
+
+	snapshot := &Snapshot{
+		Version:      SnapshotVersion,
+		AllocationID: a.ID,
+		DataShards:   a.DataShards,
+		ParityShards: a.ParityShards,
+		CreatedAt:    common.Now(),
+	}
+	refs, err := a.listAllRefs()
+	if err != nil {
+		return errors.Wrap(err, "failed to list the allocation")
+	}
+	for i := range refs {
+		snapshot.Entries = append(snapshot.Entries, newSnapshotEntry(&refs[i]))
+	}
+
+	tw := tar.NewWriter(w)
+	manifest, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	err = tw.WriteHeader(&tar.Header{
+		Name:    snapshotManifestName,
+		Mode:    0644,
+		Size:    int64(len(manifest)),
+		ModTime: time.Unix(int64(snapshot.CreatedAt), 0),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := tw.Write(manifest); err != nil {
+		return err
+	}
+
+	tmp, tmpName, err := sys.Files.TempFile("", "snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tmp.Close()
+		sys.Files.Remove(tmpName) //nolint
+	}()
+
+	for _, entry := range snapshot.Entries {
+		if entry.Type != fileref.FILE {
+			continue
+		}
+		if entry.Thumbnail {
+			err := a.archiveContent(tw, tmp, snapshotThumbsDir+entry.Path, entry, DOWNLOAD_CONTENT_THUMB)
+			if err != nil {
+				return errors.Wrap(err, "failed to archive the thumbnail of "+entry.Path)
+			}
+		}
+		err := a.archiveContent(tw, tmp, snapshotFilesDir+entry.Path, entry, DOWNLOAD_CONTENT_FULL)
+		if err != nil {
+			return errors.Wrap(err, "failed to archive "+entry.Path)
+		}
+	}
+	return tw.Close()
+}
+
+// archiveContent downloads the content of the file of entry to tmp, and
+// copies it to the archive as name.
+func (a *Allocation) archiveContent(tw *tar.Writer, tmp sys.File, name string, entry *SnapshotEntry, contentMode string) error {
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	counter := &countingWriter{w: tmp}
+	if err := a.downloadTo(entry.Path, contentMode, counter); err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    counter.n,
+		ModTime: time.Unix(int64(entry.UpdatedAt), 0),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.CopyN(tw, tmp, counter.n)
+	return err
+}
+
+// countingWriter counts the bytes written to w. The temporary file of
+// ExportSnapshot is reused, it may be longer than the content written.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// ImportSnapshot restores the archive of ExportSnapshot to the allocation,
+// which is expected to be empty: the directories are created and the files
+// uploaded, with their thumbnails, mime types and custom meta. Encrypted
+// files are encrypted again and compressed files compressed again.
+func (a *Allocation) ImportSnapshot(r io.Reader) error {
+	if !a.isInitialized() {
+		return notInitialized
+	}
+
+	tr := tar.NewReader(r)
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != snapshotManifestName {
+		return ErrInvalidSnapshot
+	}
+	snapshot := &Snapshot{}
+	if err := json.NewDecoder(tr).Decode(snapshot); err != nil {
+		return errors.Wrap(ErrInvalidSnapshot, err)
+	}
+	if snapshot.Version != SnapshotVersion {
+		return errors.Wrap(ErrInvalidSnapshot, "unsupported snapshot version")
+	}
+
+	files := make(map[string]*SnapshotEntry, len(snapshot.Entries))
+	for _, entry := range snapshot.Entries {
+		switch entry.Type {
+		case fileref.DIRECTORY:
+			if err := a.CreateDir(entry.Path); err != nil {
+				return errors.Wrap(err, "failed to create "+entry.Path)
+			}
+		case fileref.FILE:
+			files[entry.Path] = entry
+		}
+	}
+
+	var thumbnail []byte
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(ErrInvalidSnapshot, err)
+		}
+
+		switch {
+		case strings.HasPrefix(hdr.Name, snapshotThumbsDir+"/"):
+			if thumbnail, err = io.ReadAll(tr); err != nil {
+				return err
+			}
+		case strings.HasPrefix(hdr.Name, snapshotFilesDir+"/"):
+			remotePath := strings.TrimPrefix(hdr.Name, snapshotFilesDir)
+			entry, ok := files[remotePath]
+			if !ok {
+				return errors.Wrap(ErrInvalidSnapshot, "no entry for "+remotePath)
+			}
+			if err := a.uploadEntry(entry, hdr.Size, tr, thumbnail, false); err != nil {
+				return errors.Wrap(err, "failed to import "+remotePath)
+			}
+			thumbnail = nil
+		default:
+			return errors.Wrap(ErrInvalidSnapshot, "unexpected entry "+hdr.Name)
+		}
+	}
+}
+
+// uploadEntry uploads the content of the file of entry, its metadata as
+// archived.
+func (a *Allocation) uploadEntry(entry *SnapshotEntry, size int64, content io.Reader, thumbnail []byte, isUpdate bool, extra ...ChunkedUploadOption) error {
+	meta := make(map[string]string, len(entry.CustomMeta))
+	for k, v := range entry.CustomMeta {
+		meta[k] = v
+	}
+	opts := []ChunkedUploadOption{
+		WithEncrypt(entry.EncryptedKey != ""),
+		WithMimeType(entry.MimeType),
+	}
+	// the content is archived decompressed, the compression meta is set
+	// again when it's compressed
+	if algorithm := meta[MetaCompression]; algorithm != "" {
+		delete(meta, MetaCompression)
+		delete(meta, MetaUncompressedSize)
+		opts = append(opts, WithCompression(algorithm, 0))
+	}
+	if len(meta) > 0 {
+		opts = append(opts, WithCustomMeta(meta))
+	}
+	if len(thumbnail) > 0 {
+		opts = append(opts, WithThumbnail(thumbnail))
+	}
+
+	opts = append(opts, extra...)
+
+	fileMeta := FileMeta{
+		Path:       entry.Path,
+		ActualSize: size,
+		MimeType:   entry.MimeType,
+		RemoteName: path.Base(entry.Path),
+		RemotePath: entry.Path,
+	}
+	workdir, _ := homedir.Dir()
+	upload, err := CreateChunkedUpload(workdir, a, fileMeta, content, isUpdate, false, opts...)
+	if err != nil {
+		return err
+	}
+	return upload.Start()
+}
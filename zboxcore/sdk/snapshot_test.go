@@ -0,0 +1,80 @@
+package sdk
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/0chain/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocation_ImportSnapshotInvalid(t *testing.T) {
+	a := &Allocation{DataShards: 2, ParityShards: 1}
+	setupMockAllocation(t, a)
+
+	archive := func(entries ...[2]string) []byte {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, e := range entries {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: e[0], Mode: 0644, Size: int64(len(e[1]))}))
+			_, err := tw.Write([]byte(e[1]))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		return buf.Bytes()
+	}
+	manifest := func(s *Snapshot) string {
+		buf, err := json.Marshal(s)
+		require.NoError(t, err)
+		return string(buf)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		archive []byte
+		wantErr bool
+	}{
+		{name: "Test_Not_A_Tar", archive: []byte("not a tar archive"), wantErr: true},
+		{name: "Test_No_Manifest", archive: archive([2]string{"files/a.txt", "a"}), wantErr: true},
+		{name: "Test_Invalid_Manifest", archive: archive([2]string{snapshotManifestName, "{"}), wantErr: true},
+		{name: "Test_Unsupported_Version", archive: archive([2]string{snapshotManifestName, manifest(&Snapshot{Version: 42})}), wantErr: true},
+		{
+			name: "Test_File_Not_In_Manifest",
+			archive: archive(
+				[2]string{snapshotManifestName, manifest(&Snapshot{Version: SnapshotVersion})},
+				[2]string{"files/a.txt", "a"},
+			),
+			wantErr: true,
+		},
+		{
+			name: "Test_Unexpected_Entry",
+			archive: archive(
+				[2]string{snapshotManifestName, manifest(&Snapshot{Version: SnapshotVersion})},
+				[2]string{"other/a.txt", "a"},
+			),
+			wantErr: true,
+		},
+		{name: "Test_Empty_Allocation", archive: archive([2]string{snapshotManifestName, manifest(&Snapshot{Version: SnapshotVersion})})},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := a.ImportSnapshot(bytes.NewReader(tc.archive))
+			if tc.wantErr {
+				require.True(t, errors.Is(err, ErrInvalidSnapshot), err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCountingWriter(t *testing.T) {
+	var buf bytes.Buffer
+	cw := &countingWriter{w: &buf}
+	_, err := cw.Write([]byte("hello "))
+	require.NoError(t, err)
+	_, err = cw.Write([]byte("snapshot"))
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), cw.n)
+}
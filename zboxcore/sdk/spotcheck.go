@@ -0,0 +1,217 @@
+package sdk
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// SpotCheckResult is the outcome of Allocation.SpotCheck.
+type SpotCheckResult struct {
+	RemotePath string `json:"remote_path"`
+	// Blocks are the block numbers sampled, from 1, checked on every
+	// blobber.
+	Blocks   []int64             `json:"blocks"`
+	Blobbers []*BlobberSpotCheck `json:"blobbers"`
+}
+
+// BlobberSpotCheck is the outcome of a spot check for a blobber.
+type BlobberSpotCheck struct {
+	BlobberID string `json:"blobber_id"`
+	Baseurl   string `json:"url"`
+	// Verified is the number of blocks that matched their merkle proof.
+	Verified int `json:"verified"`
+	// FailedBlocks are the blocks that didn't match, or that the blobber
+	// returned no proof for.
+	FailedBlocks []int64 `json:"failed_blocks,omitempty"`
+	// Errors are the errors of the blocks that couldn't be downloaded, by
+	// block number. They don't fail the verification.
+	Errors map[int64]string `json:"errors,omitempty"`
+	// MissingFile is set when the blobber doesn't have the file committed by
+	// the other blobbers.
+	MissingFile bool `json:"missing_file,omitempty"`
+}
+
+// Failed tells whether the blobber failed the verification.
+func (b *BlobberSpotCheck) Failed() bool {
+	return b.MissingFile || len(b.FailedBlocks) > 0
+}
+
+// FailedBlobbers returns the IDs of the blobbers that failed the
+// verification.
+func (r *SpotCheckResult) FailedBlobbers() []string {
+	var ids []string
+	for _, b := range r.Blobbers {
+		if b.Failed() {
+			ids = append(ids, b.BlobberID)
+		}
+	}
+	return ids
+}
+
+// SpotCheck challenges the blobbers of the allocation on the file at
+// remotePath: nSamples random blocks are downloaded from each blobber with
+// their merkle proofs and verified against the content hash the blobber
+// committed, see VerifyDownloads. The blocks are paid for like any download.
+func (a *Allocation) SpotCheck(remotePath string, nSamples int) (*SpotCheckResult, error) {
+	return a.SpotCheckCtx(context.Background(), remotePath, nSamples)
+}
+
+// SpotCheckCtx verifies nSamples random blocks of remotePath on each
+// blobber.
+func (a *Allocation) SpotCheckCtx(ctx context.Context, remotePath string, nSamples int) (*SpotCheckResult, error) {
+	ctx, cancel := a.opContext(ctx)
+	defer cancel()
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	if nSamples <= 0 {
+		return nil, errors.New("invalid_samples", "number of samples must be positive")
+	}
+	remotePath = zboxutil.RemoteClean(remotePath)
+	if !zboxutil.IsRemoteAbs(remotePath) {
+		return nil, errors.New("invalid_path", "Path should be valid and absolute")
+	}
+
+	listReq := &ListRequest{
+		remotefilepath: remotePath,
+		allocationID:   a.ID,
+		allocationTx:   a.Tx,
+		blobbers:       a.Blobbers,
+		Consensus: Consensus{
+			fullconsensus:   a.fullconsensus,
+			consensusThresh: a.consensusThreshold,
+		},
+		ctx: ctx,
+	}
+	_, fRef, refs := listReq.getFileConsensusFromBlobbers()
+	if fRef == nil {
+		return nil, errors.New("file_meta_error", "Error getting the file meta data from blobbers")
+	}
+	if fRef.Type == fileref.DIRECTORY {
+		return nil, errors.New("invalid_operation", "cannot spot check a directory")
+	}
+	if fRef.ChunkSize <= 0 {
+		return nil, errors.New("invalid_chunk_size", "File ChunkSize value is not permitted")
+	}
+
+	blocks := randomBlocks(nSamples, shardBlocks(fRef, a.DataShards))
+	result := &SpotCheckResult{RemotePath: remotePath, Blocks: blocks}
+	for _, b := range a.Blobbers {
+		result.Blobbers = append(result.Blobbers, &BlobberSpotCheck{BlobberID: b.ID, Baseurl: b.Baseurl})
+	}
+
+	roots := make(map[int]string)
+	for _, r := range refs {
+		if r.fileref != nil && r.fileref.ActualFileHash == fRef.ActualFileHash {
+			roots[r.blobberIdx] = r.fileref.ContentHash
+		}
+	}
+
+	var wg sync.WaitGroup
+	for pos, check := range result.Blobbers {
+		root, ok := roots[pos]
+		if !ok {
+			check.MissingFile = true
+			continue
+		}
+		wg.Add(1)
+		go func(pos int, check *BlobberSpotCheck, root string) {
+			defer wg.Done()
+			a.spotCheckBlobber(ctx, pos, fRef, root, blocks, check)
+		}(pos, check, root)
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+// spotCheckBlobber downloads and verifies the blocks from the blobber at
+// pos, one after the other.
+func (a *Allocation) spotCheckBlobber(ctx context.Context, pos int, fRef *fileref.FileRef, root string, blocks []int64, check *BlobberSpotCheck) {
+	rspCh := make(chan *downloadBlock, 1)
+	for _, block := range blocks {
+		AddBlockDownloadReq(&BlockDownloadRequest{
+			allocationID:   a.ID,
+			allocationTx:   a.Tx,
+			allocOwnerID:   a.Owner,
+			blobber:        a.Blobbers[pos],
+			blobberIdx:     pos,
+			chunkSize:      int(fRef.ChunkSize),
+			blockNum:       block,
+			numBlocks:      1,
+			remotefilepath: fRef.Path,
+			verify:         true,
+			contentRoot:    root,
+			ctx:            ctx,
+			result:         rspCh,
+		})
+
+		rsp := <-rspCh
+		switch {
+		case rsp.Success:
+			check.Verified++
+		case errors.Is(rsp.err, ErrInvalidMerkleProof):
+			check.FailedBlocks = append(check.FailedBlocks, block)
+		default:
+			if check.Errors == nil {
+				check.Errors = make(map[int64]string)
+			}
+			msg := "download failed"
+			if rsp.err != nil {
+				msg = rsp.err.Error()
+			}
+			check.Errors[block] = msg
+		}
+	}
+}
+
+// shardBlocks returns the number of blocks of a shard of the file.
+func shardBlocks(fRef *fileref.FileRef, dataShards int) int64 {
+	chunkSize := fRef.ChunkSize
+	if fRef.EncryptedKey != "" {
+		chunkSize -= EncryptionHeaderSize + EncryptedDataPaddingSize
+	}
+	perShard := (fRef.ActualFileSize + int64(dataShards) - 1) / int64(dataShards)
+	blocks := (perShard + chunkSize - 1) / chunkSize
+	if blocks < 1 {
+		blocks = 1
+	}
+	return blocks
+}
+
+// randomBlocks picks n distinct blocks in [1, total] at random, sorted, or
+// all of them if there aren't more than n.
+func randomBlocks(n int, total int64) []int64 {
+	if int64(n) >= total {
+		blocks := make([]int64, total)
+		for i := range blocks {
+			blocks[i] = int64(i) + 1
+		}
+		return blocks
+	}
+
+	picked := make(map[int64]struct{}, n)
+	blocks := make([]int64, 0, n)
+	for len(blocks) < n {
+		// a blobber can't predict the blocks, so they can't be faked ahead
+		r, err := rand.Int(rand.Reader, big.NewInt(total))
+		if err != nil {
+			panic(err)
+		}
+		block := r.Int64() + 1
+		if _, ok := picked[block]; ok {
+			continue
+		}
+		picked[block] = struct{}{}
+		blocks = append(blocks, block)
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+	return blocks
+}
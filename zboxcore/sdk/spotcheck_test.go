@@ -0,0 +1,92 @@
+package sdk
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomBlocks(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		n     int
+		total int64
+		want  int
+	}{
+		{name: "Test_Sample", n: 5, total: 100, want: 5},
+		{name: "Test_All_Blocks", n: 10, total: 10, want: 10},
+		{name: "Test_Fewer_Blocks_Than_Samples", n: 10, total: 3, want: 3},
+		{name: "Test_Single_Block", n: 1, total: 1, want: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			blocks := randomBlocks(tc.n, tc.total)
+			require.Len(t, blocks, tc.want)
+			require.True(t, sort.SliceIsSorted(blocks, func(i, j int) bool { return blocks[i] < blocks[j] }))
+
+			seen := make(map[int64]bool)
+			for _, b := range blocks {
+				require.GreaterOrEqual(t, b, int64(1))
+				require.LessOrEqual(t, b, tc.total)
+				require.False(t, seen[b], "block %d picked twice", b)
+				seen[b] = true
+			}
+		})
+	}
+}
+
+func TestShardBlocks(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		ref        *fileref.FileRef
+		dataShards int
+		want       int64
+	}{
+		{
+			name:       "Test_Exact_Blocks",
+			ref:        &fileref.FileRef{Ref: fileref.Ref{ChunkSize: 64 * KB}, ActualFileSize: 4 * 64 * KB * 2},
+			dataShards: 4,
+			want:       2,
+		},
+		{
+			name:       "Test_Partial_Block",
+			ref:        &fileref.FileRef{Ref: fileref.Ref{ChunkSize: 64 * KB}, ActualFileSize: 4*64*KB*2 + 1},
+			dataShards: 4,
+			want:       3,
+		},
+		{
+			name:       "Test_Empty_File",
+			ref:        &fileref.FileRef{Ref: fileref.Ref{ChunkSize: 64 * KB}},
+			dataShards: 4,
+			want:       1,
+		},
+		{
+			name: "Test_Encrypted",
+			ref: &fileref.FileRef{
+				Ref:            fileref.Ref{ChunkSize: 64 * KB},
+				ActualFileSize: 2 * (64*KB - EncryptionHeaderSize - EncryptedDataPaddingSize),
+				EncryptedKey:   "key",
+			},
+			dataShards: 1,
+			want:       2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, shardBlocks(tc.ref, tc.dataShards))
+		})
+	}
+}
+
+func TestSpotCheckResultFailedBlobbers(t *testing.T) {
+	result := &SpotCheckResult{
+		Blocks: []int64{1, 5},
+		Blobbers: []*BlobberSpotCheck{
+			{BlobberID: "ok", Verified: 2},
+			{BlobberID: "tampered", Verified: 1, FailedBlocks: []int64{5}},
+			{BlobberID: "missing", MissingFile: true},
+			{BlobberID: "offline", Errors: map[int64]string{1: "timeout", 5: "timeout"}},
+		},
+	}
+	require.Equal(t, []string{"tampered", "missing"}, result.FailedBlobbers())
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"io"
-	"io/ioutil"
 	"log"
 	"sort"
 	"time"
@@ -96,7 +95,7 @@ func (a *Allocation) GetRemoteFileMap(exclMap map[string]int) (map[string]fileIn
 }
 
 func calcFileHash(filePath string) string {
-	fp, err := os.Open(filePath)
+	fp, err := sys.Files.Open(filePath)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -117,7 +116,7 @@ func getRemoteExcludeMap(exclPath []string) map[string]int {
 	return exclMap
 }
 
-func addLocalFileList(root string, fMap map[string]fileInfo, dirList *[]string, filter map[string]bool, exclMap map[string]int) filepath.WalkFunc {
+func addLocalFileList(root string, fMap map[string]fileInfo, dirList *[]string, filter map[string]bool, exclMap map[string]int, index *LocalIndex) filepath.WalkFunc {
 	return func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			l.Logger.Error("Local file list error for path", path, err.Error())
@@ -139,6 +138,12 @@ func addLocalFileList(root string, fMap map[string]fileInfo, dirList *[]string,
 		// Add to list
 		if info.IsDir() {
 			*dirList = append(*dirList, lPath)
+		} else if index != nil {
+			hash, err := index.Hash(path)
+			if err != nil {
+				return err
+			}
+			fMap[lPath] = fileInfo{Size: info.Size(), Hash: hash, Type: fileref.FILE}
 		} else {
 			fMap[lPath] = fileInfo{Size: info.Size(), Hash: calcFileHash(path), Type: fileref.FILE}
 		}
@@ -146,14 +151,14 @@ func addLocalFileList(root string, fMap map[string]fileInfo, dirList *[]string,
 	}
 }
 
-func getLocalFileMap(rootPath string, filters []string, exclMap map[string]int) (map[string]fileInfo, error) {
+func getLocalFileMap(rootPath string, filters []string, exclMap map[string]int, index *LocalIndex) (map[string]fileInfo, error) {
 	localMap := make(map[string]fileInfo)
 	var dirList []string
 	filterMap := make(map[string]bool)
 	for _, f := range filters {
 		filterMap[f] = true
 	}
-	err := filepath.Walk(rootPath, addLocalFileList(rootPath, localMap, &dirList, filterMap, exclMap))
+	err := filepath.Walk(rootPath, addLocalFileList(rootPath, localMap, &dirList, filterMap, exclMap, index))
 	// Add the dirs at the end of the list for dir deletiion after all file deletion
 	for _, d := range dirList {
 		localMap[d] = fileInfo{Type: fileref.DIRECTORY}
@@ -282,6 +287,13 @@ func findDelta(rMap map[string]fileInfo, lMap map[string]fileInfo, prevMap map[s
 }
 
 func (a *Allocation) GetAllocationDiff(lastSyncCachePath string, localRootPath string, localFileFilters []string, remoteExcludePath []string) ([]FileDiff, error) {
+	return a.GetAllocationDiffWithIndex(lastSyncCachePath, localRootPath, localFileFilters, remoteExcludePath, nil)
+}
+
+// GetAllocationDiffWithIndex is GetAllocationDiff hashing the local files with index, the files
+// unchanged since the previous sync aren't hashed again. A nil index hashes all of them.
+// The index is saved once the diff is done.
+func (a *Allocation) GetAllocationDiffWithIndex(lastSyncCachePath string, localRootPath string, localFileFilters []string, remoteExcludePath []string, index *LocalIndex) ([]FileDiff, error) {
 	var lFdiff []FileDiff
 	prevRemoteFileMap := make(map[string]fileInfo)
 	// 1. Validate localSycnCachePath
@@ -292,7 +304,7 @@ func (a *Allocation) GetAllocationDiff(lastSyncCachePath string, localRootPath s
 			if fileInfo.IsDir() {
 				return lFdiff, errors.Wrap(err, "invalid file cache.")
 			}
-			content, err := ioutil.ReadFile(lastSyncCachePath)
+			content, err := sys.Files.ReadFile(lastSyncCachePath)
 			if err != nil {
 				return lFdiff, errors.New("", "can't read cache file.")
 			}
@@ -314,10 +326,15 @@ func (a *Allocation) GetAllocationDiff(lastSyncCachePath string, localRootPath s
 
 	// 4. Get flat file list on the local filesystem
 	localRootPath = strings.TrimRight(localRootPath, "/")
-	localFileList, err := getLocalFileMap(localRootPath, localFileFilters, exclMap)
+	localFileList, err := getLocalFileMap(localRootPath, localFileFilters, exclMap, index)
 	if err != nil {
 		return lFdiff, errors.Wrap(err, "error getting list dir from local.")
 	}
+	if index != nil {
+		if err := index.Save(); err != nil {
+			return lFdiff, err
+		}
+	}
 
 	// 5. Get the file diff with operation
 	lFdiff = findDelta(remoteFileMap, localFileList, prevRemoteFileMap, localRootPath)
@@ -347,7 +364,7 @@ func (a *Allocation) SaveRemoteSnapshot(pathToSave string, remoteExcludePath []s
 
 	// Now we got the list from remote, delete the file if exists
 	if bIsFileExists {
-		err = os.Remove(pathToSave)
+		err = sys.Files.Remove(pathToSave)
 		if err != nil {
 			return errors.Wrap(err, "error deleting previous cache.")
 		}
@@ -356,7 +373,7 @@ func (a *Allocation) SaveRemoteSnapshot(pathToSave string, remoteExcludePath []s
 	if err != nil {
 		return errors.Wrap(err, "failed to convert JSON.")
 	}
-	err = ioutil.WriteFile(pathToSave, by, 0644)
+	err = sys.Files.WriteFile(pathToSave, by, 0644)
 	if err != nil {
 		return errors.Wrap(err, "error saving file.")
 	}
@@ -0,0 +1,160 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"hash/crc64"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/sys"
+)
+
+// quickHashBlockSize is how much of the head and of the tail of a file the
+// quick hash of a LocalIndexEntry reads
+const quickHashBlockSize = 64 * 1024
+
+var quickHashTable = crc64.MakeTable(crc64.ECMA)
+
+// LocalIndexEntry is what a LocalIndex knows of a local file.
+type LocalIndexEntry struct {
+	Size int64 `json:"size"`
+	// ModTime is the modification time of the file in unix nanoseconds
+	ModTime int64 `json:"mod_time"`
+	// QuickHash is the crc64 of the size, the head and the tail of the file
+	QuickHash string `json:"quick_hash"`
+	// Hash is the hex sha256 of the content
+	Hash string `json:"hash"`
+}
+
+// LocalIndex remembers the hashes of the local files of a sync between runs,
+// so that the files whose size, modification time and quick hash didn't
+// change aren't hashed again.
+type LocalIndex struct {
+	// FullVerify hashes every file, ignoring the entries of the index
+	FullVerify bool
+
+	mu      sync.Mutex
+	path    string
+	entries map[string]LocalIndexEntry
+	seen    map[string]bool
+}
+
+// LoadLocalIndex loads the index saved at path, an empty one if there is none.
+func LoadLocalIndex(path string) (*LocalIndex, error) {
+	x := &LocalIndex{
+		path:    path,
+		entries: make(map[string]LocalIndexEntry),
+		seen:    make(map[string]bool),
+	}
+
+	content, err := sys.Files.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return x, nil
+		}
+		return nil, errors.Wrap(err, "can't read local index.")
+	}
+	if err := json.Unmarshal(content, &x.entries); err != nil {
+		return nil, errors.Wrap(err, "invalid local index content.")
+	}
+	return x, nil
+}
+
+// Hash returns the hex sha256 of the content of the local file, the one of
+// the index if the file didn't change since it was hashed.
+func (x *LocalIndex) Hash(localPath string) (string, error) {
+	localPath = filepath.Clean(localPath)
+
+	f, err := sys.Files.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	quick, err := quickHash(f, info.Size())
+	if err != nil {
+		return "", err
+	}
+
+	x.mu.Lock()
+	x.seen[localPath] = true
+	entry, ok := x.entries[localPath]
+	x.mu.Unlock()
+
+	modTime := info.ModTime().UnixNano()
+	if ok && !x.FullVerify && entry.Size == info.Size() && entry.ModTime == modTime && entry.QuickHash == quick {
+		return entry.Hash, nil
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	hash := hex.EncodeToString(h.Sum(nil))
+
+	x.mu.Lock()
+	x.entries[localPath] = LocalIndexEntry{Size: info.Size(), ModTime: modTime, QuickHash: quick, Hash: hash}
+	x.mu.Unlock()
+	return hash, nil
+}
+
+// Save saves the index where it was loaded from. The entries of the files
+// that are gone are dropped.
+func (x *LocalIndex) Save() error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	for localPath := range x.entries {
+		if x.seen[localPath] {
+			continue
+		}
+		if _, err := sys.Files.Stat(localPath); os.IsNotExist(err) {
+			delete(x.entries, localPath)
+		}
+	}
+
+	by, err := json.Marshal(x.entries)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert JSON.")
+	}
+	if err := sys.Files.WriteFile(x.path, by, 0644); err != nil {
+		return errors.Wrap(err, "error saving local index.")
+	}
+	return nil
+}
+
+// quickHash returns the crc64 of size and of the head and the tail of r.
+func quickHash(r io.ReadSeeker, size int64) (string, error) {
+	h := crc64.New(quickHashTable)
+
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(size))
+	h.Write(buf[:]) //nolint: errcheck
+
+	if _, err := io.CopyN(h, r, quickHashBlockSize); err != nil && err != io.EOF {
+		return "", err
+	}
+	if size > 2*quickHashBlockSize {
+		if _, err := r.Seek(-quickHashBlockSize, io.SeekEnd); err != nil {
+			return "", err
+		}
+	}
+	if _, err := io.CopyN(h, r, quickHashBlockSize); err != nil && err != io.EOF {
+		return "", err
+	}
+	return strconv.FormatUint(h.Sum64(), 16), nil
+}
@@ -0,0 +1,111 @@
+package sdk
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalIndex(t *testing.T) {
+	const fake = "cached"
+	mtime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	write := func(t *testing.T, path string, content []byte) {
+		require.NoError(t, os.WriteFile(path, content, 0644))
+		require.NoError(t, os.Chtimes(path, mtime, mtime))
+	}
+	// indexed hashes path and replaces the hash of its entry, telling if the
+	// next Hash read the file again
+	indexed := func(t *testing.T, x *LocalIndex, path string) {
+		_, err := x.Hash(path)
+		require.NoError(t, err)
+		entry := x.entries[path]
+		entry.Hash = fake
+		x.entries[path] = entry
+	}
+
+	large := bytes.Repeat([]byte("0123456789abcdef"), 3*quickHashBlockSize/16)
+	tests := []struct {
+		name       string
+		content    []byte
+		change     func(content []byte) []byte
+		fullVerify bool
+		wantCached bool
+	}{
+		{name: "Test_Unchanged", content: []byte("unchanged"), wantCached: true},
+		{name: "Test_Unchanged_Large", content: large, wantCached: true},
+		{name: "Test_Full_Verify", content: []byte("unchanged"), fullVerify: true},
+		{name: "Test_Size_Changed", content: []byte("grown"), change: func(b []byte) []byte {
+			return append(b, '!')
+		}},
+		{name: "Test_Tail_Changed", content: large, change: func(b []byte) []byte {
+			b = append([]byte{}, b...)
+			b[len(b)-1] = '!'
+			return b
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "file")
+			write(t, path, tt.content)
+
+			x, err := LoadLocalIndex(filepath.Join(t.TempDir(), "index.json"))
+			require.NoError(t, err)
+			indexed(t, x, path)
+
+			content := tt.content
+			if tt.change != nil {
+				content = tt.change(content)
+				write(t, path, content)
+			}
+			x.FullVerify = tt.fullVerify
+
+			got, err := x.Hash(path)
+			require.NoError(t, err)
+			if tt.wantCached {
+				require.Equal(t, fake, got)
+				return
+			}
+			want, err := hashLocalFile(path)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+		})
+	}
+
+	t.Run("Test_Save_Load", func(t *testing.T) {
+		dir := t.TempDir()
+		kept, removed := filepath.Join(dir, "kept"), filepath.Join(dir, "removed")
+		write(t, kept, []byte("kept"))
+		write(t, removed, []byte("removed"))
+
+		indexPath := filepath.Join(t.TempDir(), "index.json")
+		x, err := LoadLocalIndex(indexPath)
+		require.NoError(t, err)
+		indexed(t, x, kept)
+		indexed(t, x, removed)
+		require.NoError(t, x.Save())
+
+		require.NoError(t, os.Remove(removed))
+		x, err = LoadLocalIndex(indexPath)
+		require.NoError(t, err)
+		require.NoError(t, x.Save())
+
+		x, err = LoadLocalIndex(indexPath)
+		require.NoError(t, err)
+		require.Len(t, x.entries, 1)
+		got, err := x.Hash(kept)
+		require.NoError(t, err)
+		require.Equal(t, fake, got)
+	})
+
+	t.Run("Test_Invalid_Index", func(t *testing.T) {
+		indexPath := filepath.Join(t.TempDir(), "index.json")
+		require.NoError(t, os.WriteFile(indexPath, []byte("{"), 0644))
+		_, err := LoadLocalIndex(indexPath)
+		require.Error(t, err)
+	})
+}
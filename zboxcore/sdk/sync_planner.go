@@ -0,0 +1,172 @@
+package sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/zboxcore/fileref"
+)
+
+// defaultPlanBatchSize is how many remote lookups PlanSync runs in parallel
+const defaultPlanBatchSize = 10
+
+// SyncAction is what a sync has to do for a path of a SyncPlan.
+type SyncAction string
+
+const (
+	SyncUpload SyncAction = "upload"
+	SyncUpdate SyncAction = "update"
+	SyncSkip   SyncAction = "skip"
+	SyncDelete SyncAction = "delete"
+)
+
+// SyncCandidate is a file found by a local scan.
+type SyncCandidate struct {
+	RemotePath string `json:"remote_path"`
+	// LocalPath is only read to hash the file when Hash is empty
+	LocalPath string `json:"local_path,omitempty"`
+	Size      int64  `json:"size"`
+	// Hash is the hex sha256 of the content, the hash uploads store as the
+	// actual file hash
+	Hash string `json:"hash,omitempty"`
+	// Deleted is set for files removed locally since the previous sync
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// SyncPlanItem is the action planned for a candidate.
+type SyncPlanItem struct {
+	SyncCandidate
+	Action SyncAction `json:"action"`
+	// Remote is the metadata of the remote file, nil if there is none
+	Remote *ConsolidatedFileMeta `json:"remote,omitempty"`
+}
+
+// SyncPlan lists the actions of a sync ordered by remote path.
+type SyncPlan struct {
+	Items []SyncPlanItem `json:"items"`
+}
+
+// Count returns how many items have the action.
+func (p *SyncPlan) Count(action SyncAction) int {
+	n := 0
+	for _, item := range p.Items {
+		if item.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// PlanSync looks up the remote metadata of the candidates, batchSize at a
+// time (defaultPlanBatchSize if not positive), and returns what a sync has
+// to do for each of them. A candidate whose metadata can't be agreed on by
+// the blobbers is planned as an upload.
+func (a *Allocation) PlanSync(ctx context.Context, candidates []SyncCandidate, batchSize int) (*SyncPlan, error) {
+	return a.PlanSyncWithIndex(ctx, candidates, batchSize, nil)
+}
+
+// PlanSyncWithIndex is PlanSync hashing the local files of the candidates
+// with index, see LocalIndex. The index is saved once the plan is done.
+func (a *Allocation) PlanSyncWithIndex(ctx context.Context, candidates []SyncCandidate, batchSize int, index *LocalIndex) (*SyncPlan, error) {
+	if !a.isInitialized() {
+		return nil, notInitialized
+	}
+	if batchSize <= 0 {
+		batchSize = defaultPlanBatchSize
+	}
+
+	items := make([]SyncPlanItem, len(candidates))
+	errs := make([]error, len(candidates))
+	sem := make(chan struct{}, batchSize)
+	var wg sync.WaitGroup
+	for i := range candidates {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			items[i], errs[i] = a.planCandidate(candidates[i], index)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to plan "+candidates[i].RemotePath)
+		}
+	}
+	if index != nil {
+		if err := index.Save(); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].RemotePath < items[j].RemotePath
+	})
+	return &SyncPlan{Items: items}, nil
+}
+
+func (a *Allocation) planCandidate(c SyncCandidate, index *LocalIndex) (SyncPlanItem, error) {
+	remote, err := a.GetFileMeta(c.RemotePath)
+	if err != nil {
+		// no consensus on the ref, the file is missing on enough blobbers
+		remote = nil
+	}
+	if remote != nil && !c.Deleted && c.Hash == "" && c.Size == remote.Size && c.LocalPath != "" {
+		hash := hashLocalFile
+		if index != nil {
+			hash = index.Hash
+		}
+		if c.Hash, err = hash(c.LocalPath); err != nil {
+			return SyncPlanItem{}, err
+		}
+	}
+	return SyncPlanItem{SyncCandidate: c, Action: planAction(c, remote), Remote: remote}, nil
+}
+
+// planAction decides what to do with a candidate given its remote metadata.
+func planAction(c SyncCandidate, remote *ConsolidatedFileMeta) SyncAction {
+	switch {
+	case c.Deleted && remote == nil:
+		return SyncSkip
+	case c.Deleted:
+		return SyncDelete
+	case remote == nil:
+		return SyncUpload
+	case remote.Type == fileref.DIRECTORY:
+		// a directory can't be replaced by a file, leave it to the caller
+		return SyncSkip
+	case c.Size != remote.Size:
+		return SyncUpdate
+	case c.Hash != "" && c.Hash != remote.Hash:
+		return SyncUpdate
+	}
+	return SyncSkip
+}
+
+func hashLocalFile(localPath string) (string, error) {
+	f, err := sys.Files.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,32 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/zboxcore/fileref"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanAction(t *testing.T) {
+	remote := &ConsolidatedFileMeta{Type: fileref.FILE, Size: 10, Hash: "abc"}
+
+	tests := []struct {
+		name      string
+		candidate SyncCandidate
+		remote    *ConsolidatedFileMeta
+		want      SyncAction
+	}{
+		{name: "Test_New_File", candidate: SyncCandidate{Size: 10}, want: SyncUpload},
+		{name: "Test_Unchanged", candidate: SyncCandidate{Size: 10, Hash: "abc"}, remote: remote, want: SyncSkip},
+		{name: "Test_Size_Changed", candidate: SyncCandidate{Size: 11}, remote: remote, want: SyncUpdate},
+		{name: "Test_Content_Changed", candidate: SyncCandidate{Size: 10, Hash: "def"}, remote: remote, want: SyncUpdate},
+		{name: "Test_Deleted", candidate: SyncCandidate{Deleted: true}, remote: remote, want: SyncDelete},
+		{name: "Test_Deleted_Both_Sides", candidate: SyncCandidate{Deleted: true}, want: SyncSkip},
+		{name: "Test_Remote_Directory", candidate: SyncCandidate{Size: 10}, remote: &ConsolidatedFileMeta{Type: fileref.DIRECTORY}, want: SyncSkip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, planAction(tt.candidate, tt.remote))
+		})
+	}
+}
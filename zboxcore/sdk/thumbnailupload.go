@@ -5,12 +5,11 @@ import (
 	"encoding/hex"
 	"io"
 	"math"
-	"os"
 	"sync"
 
+	"github.com/0chain/gosdk/core/sys"
 	"github.com/0chain/gosdk/zboxcore/zboxutil"
 
-	"github.com/0chain/gosdk/zboxcore/encoder"
 	"github.com/0chain/gosdk/zboxcore/fileref"
 	. "github.com/0chain/gosdk/zboxcore/logger"
 )
@@ -22,11 +21,11 @@ func (req *UploadRequest) pushThumbnailData(data []byte) error {
 		req.thumbnailHashWr.Write(data[:n])
 	}
 	req.thumbRemaining = req.thumbRemaining - n
-	erasureencoder, err := encoder.NewEncoder(req.datashards, req.parityshards)
+	erasureEncoder, err := req.getErasureEncoder()
 	if err != nil {
 		return err
 	}
-	shards, err := erasureencoder.Encode(data)
+	shards, err := erasureEncoder.Encode(data)
 	if err != nil {
 		Logger.Error("Erasure coding failed.", err.Error())
 		return err
@@ -60,8 +59,7 @@ func (req *UploadRequest) pushThumbnailData(data []byte) error {
 
 func (req *UploadRequest) processThumbnail(a *Allocation, wg *sync.WaitGroup) {
 	defer wg.Done()
-	var inFile *os.File
-	inFile, err := os.Open(req.thumbnailpath)
+	inFile, err := sys.Files.Open(req.thumbnailpath)
 	if err != nil {
 		return
 	}
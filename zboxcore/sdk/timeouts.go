@@ -0,0 +1,117 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/zboxcore/zboxutil"
+)
+
+// Timeouts bound the requests to the blobbers and sharders by operation
+// class. Zero fields use the ones of DefaultTimeouts.
+type Timeouts struct {
+	// List bounds listing a directory and fetching refs
+	List time.Duration
+	// Meta bounds the file meta, stats and collaborators requests
+	Meta time.Duration
+	// UploadChunk bounds uploading a chunk to a blobber
+	UploadChunk time.Duration
+	// DownloadBlock bounds downloading blocks from a blobber
+	DownloadBlock time.Duration
+	// Commit bounds the write marker commits and the changes they commit,
+	// e.g. a copy or the creation of a directory
+	Commit time.Duration
+	// SCQuery bounds the smart contract queries to the sharders
+	SCQuery time.Duration
+}
+
+// DefaultTimeouts are the timeouts of the sdk until set with SetTimeouts.
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		List:          30 * time.Second,
+		Meta:          30 * time.Second,
+		UploadChunk:   DefaultUploadTimeOut,
+		DownloadBlock: 30 * time.Second,
+		Commit:        DefaultUploadTimeOut,
+		SCQuery:       30 * time.Second,
+	}
+}
+
+// withDefaults returns t with its zero fields set from d.
+func (t Timeouts) withDefaults(d Timeouts) Timeouts {
+	or := func(v, def time.Duration) time.Duration {
+		if v > 0 {
+			return v
+		}
+		return def
+	}
+	return Timeouts{
+		List:          or(t.List, d.List),
+		Meta:          or(t.Meta, d.Meta),
+		UploadChunk:   or(t.UploadChunk, d.UploadChunk),
+		DownloadBlock: or(t.DownloadBlock, d.DownloadBlock),
+		Commit:        or(t.Commit, d.Commit),
+		SCQuery:       or(t.SCQuery, d.SCQuery),
+	}
+}
+
+var (
+	timeoutsMu     sync.RWMutex
+	globalTimeouts = DefaultTimeouts()
+)
+
+// SetTimeouts sets the timeouts of all the allocations without their own,
+// see Allocation.SetTimeouts.
+func SetTimeouts(t Timeouts) {
+	t = t.withDefaults(DefaultTimeouts())
+	timeoutsMu.Lock()
+	globalTimeouts = t
+	timeoutsMu.Unlock()
+	zboxutil.SetSCRestAPITimeout(t.SCQuery)
+}
+
+// GetTimeouts returns the timeouts set with SetTimeouts.
+func GetTimeouts() Timeouts {
+	timeoutsMu.RLock()
+	defer timeoutsMu.RUnlock()
+	return globalTimeouts
+}
+
+// SetTimeouts sets the timeouts of the operations on the allocation, its
+// zero fields using the ones of the sdk.
+func (a *Allocation) SetTimeouts(t Timeouts) {
+	timeoutsMu.Lock()
+	a.timeouts = &t
+	timeoutsMu.Unlock()
+}
+
+// GetTimeouts returns the timeouts of the operations on the allocation.
+func (a *Allocation) GetTimeouts() Timeouts {
+	timeoutsMu.RLock()
+	defer timeoutsMu.RUnlock()
+	if a.timeouts == nil {
+		return globalTimeouts
+	}
+	return a.timeouts.withDefaults(globalTimeouts)
+}
+
+type timeoutsKey struct{}
+
+// withAllocationTimeouts makes the timeouts of a those of the requests run
+// with ctx, see timeoutsOf.
+func withAllocationTimeouts(ctx context.Context, a *Allocation) context.Context {
+	return context.WithValue(ctx, timeoutsKey{}, a)
+}
+
+// timeoutsOf returns the timeouts of the allocation of ctx, those of the sdk
+// for a nil context or one not derived from an allocation.
+func timeoutsOf(ctx context.Context) Timeouts {
+	if ctx == nil {
+		return GetTimeouts()
+	}
+	if a, ok := ctx.Value(timeoutsKey{}).(*Allocation); ok {
+		return a.GetTimeouts()
+	}
+	return GetTimeouts()
+}
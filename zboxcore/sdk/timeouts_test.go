@@ -0,0 +1,34 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeouts(t *testing.T) {
+	defer SetTimeouts(DefaultTimeouts())
+
+	require.Equal(t, DefaultTimeouts(), timeoutsOf(context.Background()))
+	require.Equal(t, DefaultTimeouts(), timeoutsOf(nil)) //nolint:staticcheck
+
+	SetTimeouts(Timeouts{List: time.Second})
+	global := GetTimeouts()
+	require.Equal(t, time.Second, global.List)
+	require.Equal(t, DefaultTimeouts().Meta, global.Meta)
+
+	a := &Allocation{}
+	ctx := withAllocationTimeouts(context.Background(), a)
+	require.Equal(t, global, timeoutsOf(ctx))
+
+	a.SetTimeouts(Timeouts{Commit: time.Minute})
+	got := timeoutsOf(ctx)
+	require.Equal(t, time.Minute, got.Commit)
+	require.Equal(t, time.Second, got.List, "zero fields use the ones of the sdk")
+
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	require.Equal(t, got, timeoutsOf(cctx), "derived contexts keep the timeouts")
+}
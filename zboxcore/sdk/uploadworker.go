@@ -13,11 +13,12 @@ import (
 	"math"
 	"mime/multipart"
 	"net/http"
-	"os"
 	"sync"
+	"sync/atomic"
 
 	"github.com/0chain/errors"
 	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/core/sys"
 	"github.com/0chain/gosdk/core/util"
 	"github.com/0chain/gosdk/zboxcore/allocationchange"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
@@ -104,15 +105,27 @@ type UploadRequest struct {
 	connectionID      string
 	datashards        int
 	parityshards      int
+	erasureEncoder    *encoder.StreamEncoder
+	encoderMu         sync.Mutex
 	uploadMask        zboxutil.Uint128
 	isEncrypted       bool
 	encscheme         encryption.EncryptionScheme
-	isUploadCanceled  bool
+	isUploadCanceled  int32
 	completedCallback func(filepath string)
+	ctx               context.Context
+	ctxCncl           context.CancelFunc
+	opDone            func() // tells Shutdown the upload is over, see beginOp
 	err               error
 	Consensus
 }
 
+// setErr sets the error of the upload to a blobber, called concurrently.
+func (req *UploadRequest) setErr(err error) {
+	req.Lock()
+	req.err = err
+	req.Unlock()
+}
+
 func (req *UploadRequest) setUploadMask(numBlobbers int) {
 	req.uploadMask = zboxutil.NewUint128(1).Lsh(uint64(numBlobbers)).Sub64(1)
 }
@@ -283,10 +296,10 @@ func (req *UploadRequest) prepareUpload(
 			bodyWriter.CloseWithError(formWriter.Close())
 		}
 	}()
-	_ = zboxutil.HttpDo(a.ctx, a.ctxCancelF, httpreq, func(resp *http.Response, err error) error {
+	_ = zboxutil.HttpDoBlobber(req.ctx, req.ctxCncl, httpreq, func(resp *http.Response, err error) error {
 		if err != nil {
 			l.Logger.Error("Upload : ", err)
-			req.err = err
+			req.setErr(err)
 			return err
 		}
 		defer resp.Body.Close()
@@ -294,29 +307,29 @@ func (req *UploadRequest) prepareUpload(
 		respbody, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
 			l.Logger.Error("Error: Resp ", err)
-			req.err = err
+			req.setErr(err)
 			return err
 		}
 		if resp.StatusCode != http.StatusOK {
 			l.Logger.Error(blobber.Baseurl, " Upload error response: ", resp.StatusCode, string(respbody))
-			req.err = errors.New("", string(respbody))
+			req.setErr(errors.New("", string(respbody)))
 			return err
 		}
 		var r UploadResult
 		err = json.Unmarshal(respbody, &r)
 		if err != nil {
 			l.Logger.Error(blobber.Baseurl, " Upload response parse error: ", err)
-			req.err = err
+			req.setErr(err)
 			return err
 		}
 		if r.Filename != formData.Filename || r.ShardSize != shardSize ||
 			r.Hash != formData.Hash || r.MerkleRoot != formData.MerkleRoot {
 			err = fmt.Errorf(blobber.Baseurl, "Unexpected upload response data", string(respbody))
 			l.Logger.Error(err)
-			req.err = err
+			req.setErr(err)
 			return err
 		}
-		req.consensus++
+		req.Done()
 		l.Logger.Info(blobber.Baseurl, formData.Path, " uploaded")
 		file.MerkleRoot = formData.MerkleRoot
 		file.ContentHash = formData.Hash
@@ -382,6 +395,21 @@ func (req *UploadRequest) setupUpload(a *Allocation) error {
 	return nil
 }
 
+// getErasureEncoder returns the encoder of the request, created on first
+// use and shared by the data and thumbnail blocks.
+func (req *UploadRequest) getErasureEncoder() (*encoder.StreamEncoder, error) {
+	req.encoderMu.Lock()
+	defer req.encoderMu.Unlock()
+	if req.erasureEncoder == nil {
+		e, err := encoder.NewEncoder(req.datashards, req.parityshards)
+		if err != nil {
+			return nil, err
+		}
+		req.erasureEncoder = e
+	}
+	return req.erasureEncoder, nil
+}
+
 // this will push the data to the blobber, encrypt if the using the encrypted upload mode
 func (req *UploadRequest) pushData(data []byte) error {
 	//TODO: Check for optimization
@@ -390,11 +418,11 @@ func (req *UploadRequest) pushData(data []byte) error {
 		req.fileHashWr.Write(data[:n])
 	}
 	req.remaining = req.remaining - n
-	erasureencoder, err := encoder.NewEncoder(req.datashards, req.parityshards)
+	erasureEncoder, err := req.getErasureEncoder()
 	if err != nil {
 		return err
 	}
-	shards, err := erasureencoder.Encode(data)
+	shards, err := erasureEncoder.Encode(data)
 	if err != nil {
 		l.Logger.Error("Erasure coding failed.", err.Error())
 		return err
@@ -445,12 +473,13 @@ func (req *UploadRequest) completePush() error {
 }
 
 func (req *UploadRequest) processUpload(ctx context.Context, a *Allocation) {
+	defer req.opDone()
+	defer req.ctxCncl()
 	if req.completedCallback != nil {
 		defer req.completedCallback(req.filepath)
 	}
 
-	var inFile *os.File
-	inFile, err := os.Open(req.filepath)
+	inFile, err := sys.Files.Open(req.filepath)
 	if err != nil && req.statusCallback != nil {
 		req.statusCallback.Error(a.ID, req.filepath, OpUpload, errors.New("open_file_failed", err.Error()))
 		return
@@ -488,7 +517,6 @@ func (req *UploadRequest) processUpload(ctx context.Context, a *Allocation) {
 		}
 		chunksPerShard := (perShard + chunkSizeWithHeader - 1) / chunkSizeWithHeader
 		l.Logger.Info("Size:", size, " perShard:", perShard, " chunks/shard:", chunksPerShard)
-		req.isUploadCanceled = false
 		if req.statusCallback != nil {
 			req.statusCallback.Started(a.ID, req.remotefilepath, OpUpload, int(perShard)*(a.DataShards+a.ParityShards))
 		}
@@ -501,8 +529,7 @@ func (req *UploadRequest) processUpload(ctx context.Context, a *Allocation) {
 				req.statusCallback.Error(a.ID, req.filepath, OpUpload, errors.New("read_failed", err.Error()))
 				return
 			}
-			if req.isUploadCanceled {
-				req.isUploadCanceled = false
+			if atomic.CompareAndSwapInt32(&req.isUploadCanceled, 1, 0) {
 				if !req.isUpdate && !req.isRepair {
 					go a.DeleteFile(req.remotefilepath)
 				}
@@ -565,6 +592,7 @@ func (req *UploadRequest) processUpload(ctx context.Context, a *Allocation) {
 		}
 
 		commitReq.connectionID = req.connectionID
+		commitReq.ctx = req.ctx
 		commitReq.wg = wg
 		commitReqs[c] = commitReq
 		go AddCommitRequest(commitReq)
@@ -602,6 +630,7 @@ func (req *UploadRequest) processUpload(ctx context.Context, a *Allocation) {
 		}
 		retries++
 	}
+	InvalidateMetadataCache(a.ID)
 	// for _, commitReq := range commitReqs {
 	// 	if commitReq.result != nil {
 	// 		if commitReq.result.Success {
@@ -12,6 +12,7 @@ import (
 
 	"github.com/0chain/errors"
 	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/core/sys"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
 	"github.com/0chain/gosdk/zboxcore/client"
 	"github.com/0chain/gosdk/zboxcore/logger"
@@ -133,7 +134,7 @@ func (wmMu *WriteMarkerMutex) UnlockBlobber(
 					logger.Logger.Error(err)
 					return
 				}
-				time.Sleep(time.Duration(r) * time.Second)
+				sys.Sleep(time.Duration(r) * time.Second)
 				shouldContinue = true
 				return
 			}
@@ -197,9 +198,8 @@ func (wmMu *WriteMarkerMutex) Lock(
 	if !consensus.isConsensusOk() {
 		wmMu.Unlock(ctx, *mask, blobbers, timeOut, connID)
 
-		return errors.New("lock_consensus_not_met",
-			fmt.Sprintf("Required consensus %d got %d",
-				consensus.consensusThresh, consensus.getConsensus()))
+		return &constants.ErrConsensusNotMet{Op: "Write marker lock",
+			Got: consensus.getConsensus(), Need: consensus.consensusThresh}
 	}
 
 	return nil
@@ -224,7 +224,7 @@ func (wmMu *WriteMarkerMutex) LockBlobber(
 		}
 	}()
 
-	requestTime := strconv.FormatInt(time.Now().Unix(), 10)
+	requestTime := strconv.FormatInt(int64(zboxutil.ServerNow(b.Baseurl)), 10)
 	var req *http.Request
 
 	req, err = zboxutil.NewWriteMarkerLockRequest(
@@ -235,10 +235,11 @@ func (wmMu *WriteMarkerMutex) LockBlobber(
 	}
 
 	var resp *http.Response
-	var shouldContinue bool
+	var shouldContinue, resigned bool
 	for retry := 0; retry < 3; retry++ {
 		err, shouldContinue = func() (err error, shouldContinue bool) {
 			reqCtx, ctxCncl := context.WithTimeout(ctx, timeOut)
+			sent := time.Now()
 			resp, err = zboxutil.Client.Do(req.WithContext(reqCtx))
 			defer ctxCncl()
 
@@ -249,6 +250,16 @@ func (wmMu *WriteMarkerMutex) LockBlobber(
 				defer resp.Body.Close()
 			}
 
+			zboxutil.DefaultClockSkew.Observe(b.Baseurl, resp, sent, time.Now())
+			if !resigned && zboxutil.IsTimestampRejected(resp) {
+				// requested again with the time of the blobber
+				resigned = true
+				requestTime = strconv.FormatInt(int64(zboxutil.ServerNow(b.Baseurl)), 10)
+				req, err = zboxutil.NewWriteMarkerLockRequest(
+					b.Baseurl, wmMu.allocationObj.Tx, connID, requestTime)
+				return err, err == nil
+			}
+
 			var data []byte
 			if resp.StatusCode == http.StatusOK {
 				data, err = io.ReadAll(resp.Body)
@@ -269,7 +280,7 @@ func (wmMu *WriteMarkerMutex) LockBlobber(
 				if wmLockRes.Status == WMLockStatusPending {
 					logger.Logger.Info("Lock pending for blobber ",
 						b.Baseurl, "with connection id: ", connID, " Retrying again")
-					time.Sleep(timeOut * 2)
+					sys.Sleep(timeOut * 2)
 					shouldContinue = true
 					return
 				}
@@ -279,7 +290,7 @@ func (wmMu *WriteMarkerMutex) LockBlobber(
 
 			if resp.StatusCode == http.StatusAccepted { // accepted but pending
 				logger.Logger.Info(b.Baseurl, connID, " lock pending. Retrying again")
-				time.Sleep(timeOut * 2) // wait twice the time of timeout
+				sys.Sleep(timeOut * 2) // wait twice the time of timeout
 				shouldContinue = true
 				return
 			}
@@ -296,7 +307,7 @@ func (wmMu *WriteMarkerMutex) LockBlobber(
 					return
 				}
 
-				time.Sleep(time.Duration(r) * time.Second)
+				sys.Sleep(time.Duration(r) * time.Second)
 				shouldContinue = true
 				return
 			}
@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0chain/gosdk/constants"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
 	"github.com/0chain/gosdk/zboxcore/client"
 	"github.com/0chain/gosdk/zboxcore/mocks"
@@ -205,6 +206,63 @@ func TestWriteMarkerMutext_Too_Less_Blobbers_Response_Should_Not_Lock(t *testing
 	err = mutex.Lock(context.TODO(), &mask, mu, a.Blobbers,
 		&consensus, 0, time.Minute, zboxutil.NewConnectionId())
 	if err != nil {
-		require.Contains(t, err.Error(), "lock_consensus_not_met")
+		var cerr *constants.ErrConsensusNotMet
+		require.ErrorAs(t, err, &cerr)
+		require.Equal(t, a.consensusThreshold, cerr.Need)
+	}
+}
+
+func TestWriteMarkerMutex_Rejected_Timestamp_Should_Lock(t *testing.T) {
+	var mockClient = mocks.HttpClient{}
+	zboxutil.Client = &mockClient
+
+	a := &Allocation{
+		ID:           "TestWriteMarkerMutex_Rejected_Timestamp",
+		Tx:           "TestWriteMarkerMutex_Rejected_Timestamp",
+		DataShards:   2,
+		ParityShards: 1,
+	}
+	setupMockAllocation(t, a)
+
+	var (
+		mu    sync.Mutex
+		locks = make(map[string][]string) // blobber -> request times
+	)
+	mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return req.Method == http.MethodPost && strings.Contains(req.URL.String(), t.Name())
+	})).Return(func(req *http.Request) *http.Response {
+		mu.Lock()
+		defer mu.Unlock()
+		locks[req.URL.Host] = append(locks[req.URL.Host], req.URL.Query().Get("request_time"))
+		if len(locks[req.URL.Host]) == 1 {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       io.NopCloser(strings.NewReader(`{"code":"invalid_timestamp","error":"request time is too old"}`)),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"status":2}`)),
+		}
+	}, func(*http.Request) error { return nil })
+
+	for i := 0; i < 3; i++ {
+		a.Blobbers = append(a.Blobbers, &blockchain.StorageNode{
+			ID:      "write_marker_mutex_" + strconv.Itoa(i),
+			Baseurl: "http://" + t.Name() + mockBlobberUrl + strconv.Itoa(i),
+		})
+	}
+
+	mask := zboxutil.NewUint128(1).Lsh(uint64(len(a.Blobbers))).Sub64(1)
+	maskMu := &sync.Mutex{}
+	mutex, _ := CreateWriteMarkerMutex(client.GetClient(), a)
+	consensus := &Consensus{}
+	consensus.Init(a.consensusThreshold, a.fullconsensus)
+
+	err := mutex.Lock(context.TODO(), &mask, maskMu, a.Blobbers,
+		consensus, 0, time.Minute, zboxutil.NewConnectionId())
+	require.NoError(t, err)
+	for _, b := range a.Blobbers {
+		require.Len(t, locks[strings.TrimPrefix(b.Baseurl, "http://")], 2, "requested again once")
 	}
 }
@@ -0,0 +1,158 @@
+package zboxutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/core/common"
+)
+
+// MinClockSkew is the smallest offset of the clock of a blobber applied to
+// the signed timestamps, smaller ones being within the precision of the
+// Date header.
+const MinClockSkew = 2 * time.Second
+
+// ClockSkew estimates the offset of the clocks of the blobbers from the
+// Date header of their responses, so that the timestamps signed in read
+// and write markers are the ones of the blobber. It's safe for concurrent
+// use.
+type ClockSkew struct {
+	mu      sync.RWMutex
+	offsets map[string]time.Duration
+}
+
+// NewClockSkew creates a ClockSkew without any offset.
+func NewClockSkew() *ClockSkew {
+	return &ClockSkew{offsets: make(map[string]time.Duration)}
+}
+
+// DefaultClockSkew is updated by HttpDo and HttpDoSigned.
+var DefaultClockSkew = NewClockSkew()
+
+// Observe updates the offset of the blobber at baseURL from resp, sent and
+// received being the times of the request and of the response.
+func (s *ClockSkew) Observe(baseURL string, resp *http.Response, sent, received time.Time) {
+	if resp == nil {
+		return
+	}
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	// the Date header is truncated to the second, and the server time is
+	// taken halfway through the round trip
+	offset := date.Add(time.Second / 2).Sub(sent.Add(received.Sub(sent) / 2))
+	if offset > -MinClockSkew && offset < MinClockSkew {
+		offset = 0
+	}
+
+	host := hostOf(baseURL)
+	s.mu.Lock()
+	s.offsets[host] = offset
+	s.mu.Unlock()
+}
+
+// Offset returns the offset of the clock of the blobber at baseURL, 0 if
+// unknown.
+func (s *ClockSkew) Offset(baseURL string) time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.offsets[hostOf(baseURL)]
+}
+
+// Now returns the time of the blobber at baseURL.
+func (s *ClockSkew) Now(baseURL string) common.Timestamp {
+	return common.Timestamp(time.Now().Add(s.Offset(baseURL)).Unix())
+}
+
+// Sync requests the blobber at baseURL to estimate its offset, for a
+// blobber not requested yet.
+func (s *ClockSkew) Sync(ctx context.Context, baseURL string) error {
+	req, err := http.NewRequest(http.MethodHead, baseURL, nil)
+	if err != nil {
+		return err
+	}
+	sent := time.Now()
+	resp, err := Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	s.Observe(baseURL, resp, sent, time.Now())
+	return nil
+}
+
+// ServerNow returns the time of the blobber at baseURL, estimated by
+// DefaultClockSkew. It's the timestamp to sign in markers.
+func ServerNow(baseURL string) common.Timestamp {
+	return DefaultClockSkew.Now(baseURL)
+}
+
+func hostOf(baseURL string) string {
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return baseURL
+}
+
+// SignedRequestFunc creates a request with a signature of the timestamp
+// now, e.g. a commit request with a write marker.
+type SignedRequestFunc func(now common.Timestamp) (*http.Request, error)
+
+// HttpDoSigned is HttpDoBlobber for a request signing the time of the blobber at
+// baseURL. A response rejecting the timestamp as expired updates the offset
+// of the blobber and the request is signed and sent again, once.
+func HttpDoSigned(ctx context.Context, cncl context.CancelFunc, baseURL string, sign SignedRequestFunc, f func(*http.Response, error) error) error {
+	for attempt := 0; ; attempt++ {
+		req, err := sign(ServerNow(baseURL))
+		if err != nil {
+			return err
+		}
+		rejected := false
+		err = HttpDoBlobber(ctx, cncl, req, func(resp *http.Response, err error) error {
+			if err == nil && attempt == 0 && IsTimestampRejected(resp) {
+				rejected = true
+				return nil
+			}
+			return f(resp, err)
+		})
+		if !rejected {
+			return err
+		}
+	}
+}
+
+// TimestampRejectedCode is the code of the error responses of the blobbers
+// rejecting the timestamp signed in a request as out of their clock window.
+const TimestampRejectedCode = "invalid_timestamp"
+
+// IsTimestampRejected tells whether resp is an error response of code
+// TimestampRejectedCode, the body being kept for the caller. Such a request
+// is to be signed again with ServerNow, once, the offset of the blobber
+// being updated from the Date header of resp.
+func IsTimestampRejected(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+	default:
+		return false
+	}
+	if resp.Body == nil {
+		return false
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	var e struct {
+		Code string `json:"code"`
+	}
+	return json.Unmarshal(body, &e) == nil && e.Code == TimestampRejectedCode
+}
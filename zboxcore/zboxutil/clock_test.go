@@ -0,0 +1,124 @@
+package zboxutil
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockSkew(t *testing.T) {
+	t.Run("Test_Observe", func(t *testing.T) {
+		s := NewClockSkew()
+		now := time.Now()
+		resp := &http.Response{Header: http.Header{}}
+
+		resp.Header.Set("Date", now.Add(time.Minute).UTC().Format(http.TimeFormat))
+		s.Observe("http://b1:5051", resp, now, now)
+		require.InDelta(t, time.Minute, s.Offset("http://b1:5051/v1"), float64(time.Second))
+		require.Zero(t, s.Offset("http://b2:5051"))
+
+		resp.Header.Set("Date", now.UTC().Format(http.TimeFormat))
+		s.Observe("http://b1:5051", resp, now, now)
+		require.Zero(t, s.Offset("http://b1:5051"), "skew under MinClockSkew")
+	})
+
+	t.Run("Test_HttpDoSigned_Retries_Rejected_Timestamp", func(t *testing.T) {
+		skew := int64(time.Hour / time.Second)
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.Header().Set("Date", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+			var ts int64
+			fmt.Sscan(r.URL.Query().Get("ts"), &ts) //nolint: errcheck
+			if ts < time.Now().Unix()+skew-5 {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":"invalid_timestamp","error":"write marker timestamp expired"}`)) //nolint: errcheck
+				return
+			}
+			w.Write([]byte("ok")) //nolint: errcheck
+		}))
+		defer srv.Close()
+
+		prev := DefaultClockSkew
+		DefaultClockSkew = NewClockSkew()
+		defer func() { DefaultClockSkew = prev }()
+
+		ctx, cncl := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cncl()
+		var body string
+		err := HttpDoSigned(ctx, cncl, srv.URL, func(now common.Timestamp) (*http.Request, error) {
+			return http.NewRequest(http.MethodGet, fmt.Sprintf("%s/commit?ts=%d", srv.URL, now), nil)
+		}, func(resp *http.Response, err error) error {
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			b, err := ioutil.ReadAll(resp.Body)
+			body = string(b)
+			return err
+		})
+		require.NoError(t, err)
+		require.Equal(t, "ok", body)
+		require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Test_HttpDoSigned_Retries_Once", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":"invalid_timestamp","error":"invalid timestamp"}`)) //nolint: errcheck
+		}))
+		defer srv.Close()
+
+		ctx, cncl := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cncl()
+		var status int
+		err := HttpDoSigned(ctx, cncl, srv.URL, func(now common.Timestamp) (*http.Request, error) {
+			return http.NewRequest(http.MethodGet, srv.URL, nil)
+		}, func(resp *http.Response, err error) error {
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			status = resp.StatusCode
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusUnauthorized, status)
+		require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	})
+}
+
+func TestIsTimestampRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   bool
+	}{
+		{name: "Test_Rejected", status: http.StatusBadRequest, body: `{"code":"invalid_timestamp","error":"expired"}`, want: true},
+		{name: "Test_Other_Code", status: http.StatusBadRequest, body: `{"code":"invalid_parameters","error":"invalid timestamp format"}`},
+		{name: "Test_Plain_Body", status: http.StatusUnauthorized, body: "timestamp expired"},
+		{name: "Test_Other_Status", status: http.StatusInternalServerError, body: `{"code":"invalid_timestamp"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Body: ioutil.NopCloser(strings.NewReader(tt.body))}
+			require.Equal(t, tt.want, IsTimestampRejected(resp))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, tt.body, string(body), "body kept")
+		})
+	}
+}
@@ -14,10 +14,13 @@ import (
 	"path"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
 	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/netconf"
 	"github.com/0chain/gosdk/core/util"
 	"github.com/0chain/gosdk/zboxcore/blockchain"
 	"github.com/0chain/gosdk/zboxcore/client"
@@ -54,6 +57,7 @@ const (
 	LATEST_READ_MARKER       = "/v1/readmarker/latest"
 	FILE_META_ENDPOINT       = "/v1/file/meta/"
 	FILE_STATS_ENDPOINT      = "/v1/file/stats/"
+	CHUNK_HASHES_ENDPOINT    = "/v1/file/chunkhashes/"
 	OBJECT_TREE_ENDPOINT     = "/v1/file/objecttree/"
 	REFS_ENDPOINT            = "/v1/file/refs/"
 	RECENT_REFS_ENDPOINT     = "/v1/file/refs/recent/"
@@ -128,9 +132,7 @@ func (pfe *proxyFromEnv) Proxy(req *http.Request) (proxy *url.URL, err error) {
 var envProxy proxyFromEnv
 
 func init() {
-	Client = &http.Client{
-		Transport: DefaultTransport,
-	}
+	Client = TracedClient(httpClient)
 	envProxy.initialize()
 }
 
@@ -391,6 +393,26 @@ func NewFileMetaRequest(baseUrl string, allocation string, body io.Reader) (*htt
 	return req, nil
 }
 
+func NewChunkHashesRequest(baseUrl string, allocation string, path string) (*http.Request, error) {
+	u, err := joinUrl(baseUrl, CHUNK_HASHES_ENDPOINT, allocation)
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Add("path", path)
+	u.RawQuery = params.Encode()
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setClientInfoWithSign(req, allocation)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 func NewFileStatsRequest(baseUrl string, allocation string, body io.Reader) (*http.Request, error) {
 	u, err := joinUrl(baseUrl, FILE_STATS_ENDPOINT, allocation)
 	if err != nil {
@@ -409,11 +431,20 @@ func NewFileStatsRequest(baseUrl string, allocation string, body io.Reader) (*ht
 }
 
 func NewListRequest(baseUrl, allocation string, path, pathHash string, auth_token string) (*http.Request, error) {
+	return NewListRequestWithQuery(baseUrl, allocation, path, pathHash, auth_token, nil)
+}
+
+// NewListRequestWithQuery creates a list request carrying extra query
+// parameters such as offset, limit, name filter and sort options.
+func NewListRequestWithQuery(baseUrl, allocation string, path, pathHash string, auth_token string, query url.Values) (*http.Request, error) {
 	nurl, err := joinUrl(baseUrl, LIST_ENDPOINT, allocation)
 	if err != nil {
 		return nil, err
 	}
 	params := url.Values{}
+	for k, v := range query {
+		params[k] = v
+	}
 	params.Add("path", path)
 	params.Add("path_hash", pathHash)
 	params.Add("auth_token", auth_token)
@@ -660,6 +691,24 @@ func NewRevokeShareRequest(baseUrl, allocation string, query *url.Values) (*http
 	return req, nil
 }
 
+// DefaultSCRestAPITimeout bounds the queries of MakeSCRestAPICall
+const DefaultSCRestAPITimeout = 30 * time.Second
+
+var scRestAPITimeoutNs = int64(DefaultSCRestAPITimeout)
+
+// SetSCRestAPITimeout sets the timeout of the queries of MakeSCRestAPICall,
+// DefaultSCRestAPITimeout if d isn't positive.
+func SetSCRestAPITimeout(d time.Duration) {
+	if d <= 0 {
+		d = DefaultSCRestAPITimeout
+	}
+	atomic.StoreInt64(&scRestAPITimeoutNs, int64(d))
+}
+
+func scRestAPITimeout() time.Duration {
+	return time.Duration(atomic.LoadInt64(&scRestAPITimeoutNs))
+}
+
 func MakeSCRestAPICall(scAddress string, relativePath string, params map[string]string, handler SCRestAPIHandler) ([]byte, error) {
 	numSharders := len(blockchain.GetSharders())
 	sharders := blockchain.GetSharders()
@@ -681,9 +730,16 @@ func MakeSCRestAPICall(scAddress string, relativePath string, params map[string]
 				q.Add(k, v)
 			}
 			urlObj.RawQuery = q.Encode()
-			client := &http.Client{Transport: DefaultTransport}
-
-			response, err := client.Get(urlObj.String())
+			ctx, cancel := context.WithTimeout(context.Background(), scRestAPITimeout())
+			defer cancel()
+			var response *http.Response
+			req, err := http.NewRequest(http.MethodGet, urlObj.String(), nil)
+			if err == nil {
+				err = netconf.WaitRateLimit(ctx, req.URL.String())
+			}
+			if err == nil {
+				response, err = httpClient.Do(req.WithContext(ctx))
+			}
 			if err == nil {
 				defer response.Body.Close()
 				entityBytes, _ := ioutil.ReadAll(response.Body)
@@ -738,15 +794,36 @@ func MakeSCRestAPICall(scAddress string, relativePath string, params map[string]
 	return nil, err
 }
 
+// HttpDo sends req and passes its response, or the error of the request, to f.
 func HttpDo(ctx context.Context, cncl context.CancelFunc, req *http.Request, f func(*http.Response, error) error) error {
+	return httpDo(ctx, cncl, req, f, nil)
+}
+
+// HttpDoBlobber is HttpDo for a request to a blobber, the error of a request
+// the blobber didn't respond to wraps constants.ErrBlobberUnreachable.
+func HttpDoBlobber(ctx context.Context, cncl context.CancelFunc, req *http.Request, f func(*http.Response, error) error) error {
+	return httpDo(ctx, cncl, req, f, Unreachable)
+}
+
+func httpDo(ctx context.Context, cncl context.CancelFunc, req *http.Request, f func(*http.Response, error) error, wrap func(*http.Request, error) error) error {
 	// Run the HTTP request in a goroutine and pass the response to f.
 	c := make(chan error, 1)
-	go func() { c <- f(Client.Do(req.WithContext(ctx))) }()
+	go func() {
+		sent := time.Now()
+		resp, err := Client.Do(req.WithContext(ctx))
+		if err != nil && ctx.Err() == nil && wrap != nil {
+			err = wrap(req, err)
+		}
+		if err == nil {
+			DefaultClockSkew.Observe(req.URL.String(), resp, sent, time.Now())
+		}
+		c <- f(resp, err)
+	}()
 	// TODO: Check cncl context required in any case
 	// defer cncl()
 	select {
 	case <-ctx.Done():
-		DefaultTransport.CancelRequest(req)
+		currentTransport().CancelRequest(req)
 		<-c // Wait for f to return.
 		return ctx.Err()
 	case err := <-c:
@@ -754,6 +831,15 @@ func HttpDo(ctx context.Context, cncl context.CancelFunc, req *http.Request, f f
 	}
 }
 
+// Unreachable wraps the error of a request to a blobber that didn't
+// respond with constants.ErrBlobberUnreachable.
+func Unreachable(req *http.Request, err error) error {
+	if req.URL.Host == "" {
+		return errors.Throw(constants.ErrBlobberUnreachable, err.Error())
+	}
+	return errors.Throw(constants.ErrBlobberUnreachable, req.URL.Host+": "+err.Error())
+}
+
 // isCurrentDominantStatus determines whether the current response status is the dominant status among responses.
 //
 // The dominant status is where the response status is counted the most.
@@ -1,9 +1,16 @@
 package zboxutil
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/0chain/gosdk/constants"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsCurrentDominantStatus(t *testing.T) {
@@ -65,3 +72,31 @@ func TestIsCurrentDominantStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestHttpDo_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close() // nothing listens on its address anymore
+
+	tests := []struct {
+		name            string
+		do              func(context.Context, context.CancelFunc, *http.Request, func(*http.Response, error) error) error
+		wantUnreachable bool
+	}{
+		{name: "Test_HttpDo", do: HttpDo},
+		{name: "Test_HttpDoBlobber", do: HttpDoBlobber, wantUnreachable: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+			require.NoError(t, err)
+			ctx, cncl := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cncl()
+
+			err = tt.do(ctx, cncl, req, func(resp *http.Response, err error) error {
+				return err
+			})
+			require.Error(t, err)
+			require.Equal(t, tt.wantUnreachable, errors.Is(err, constants.ErrBlobberUnreachable), err)
+		})
+	}
+}
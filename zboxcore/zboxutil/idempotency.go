@@ -0,0 +1,43 @@
+package zboxutil
+
+import (
+	"net/http"
+
+	"github.com/0chain/errors"
+)
+
+// IDEMPOTENCY_KEY_HEADER carries the key of a write to a blobber, the same
+// for all the retries of the write so that the blobber applies it once. A
+// blobber that already applied the write responds 409 Conflict with the key
+// in the same header.
+const IDEMPOTENCY_KEY_HEADER = "X-Idempotency-Key"
+
+// NewIdempotencyKey returns the key of a new write.
+func NewIdempotencyKey() string {
+	return NewConnectionId()
+}
+
+// SetIdempotencyKey sets the idempotency key of req.
+func SetIdempotencyKey(req *http.Request, key string) {
+	req.Header.Set(IDEMPOTENCY_KEY_HEADER, key)
+}
+
+// IsAlreadyApplied tells if resp is the response of a blobber that already
+// applied the write of idempotency key key, which is then a success.
+func IsAlreadyApplied(resp *http.Response, key string) bool {
+	return key != "" && resp.StatusCode == http.StatusConflict &&
+		resp.Header.Get(IDEMPOTENCY_KEY_HEADER) == key
+}
+
+// RewindBody resets the body of req to be sent again by a retry.
+func RewindBody(req *http.Request) error {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return errors.Wrap(err, "rewind request body")
+	}
+	req.Body = body
+	return nil
+}
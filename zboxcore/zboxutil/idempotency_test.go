@@ -0,0 +1,47 @@
+package zboxutil
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsAlreadyApplied(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		echo   string
+		key    string
+		want   bool
+	}{
+		{name: "Test_Conflict_With_Key", status: http.StatusConflict, echo: "k1", key: "k1", want: true},
+		{name: "Test_Conflict_Of_Another_Write", status: http.StatusConflict, echo: "k2", key: "k1"},
+		{name: "Test_Conflict_Without_Key", status: http.StatusConflict, key: "k1"},
+		{name: "Test_Write_Without_Key", status: http.StatusConflict},
+		{name: "Test_Ok", status: http.StatusOK, echo: "k1", key: "k1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			if tt.echo != "" {
+				resp.Header.Set(IDEMPOTENCY_KEY_HEADER, tt.echo)
+			}
+			require.Equal(t, tt.want, IsAlreadyApplied(resp, tt.key))
+		})
+	}
+}
+
+func TestRewindBody(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "http://b1:5051", bytes.NewBufferString("chunk"))
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(req.Body)
+	require.NoError(t, err)
+
+	require.NoError(t, RewindBody(req))
+	body, err := ioutil.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, "chunk", string(body))
+}
@@ -0,0 +1,119 @@
+package zboxutil
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/telemetry"
+)
+
+// tracedClient traces the requests of next to the blobbers and records
+// their latency, errors and bytes transferred once telemetry is enabled.
+type tracedClient struct {
+	next HttpClient
+}
+
+// TracedClient wraps c with the telemetry of the blobber requests.
+func TracedClient(c HttpClient) HttpClient {
+	if _, ok := c.(*tracedClient); ok {
+		return c
+	}
+	return &tracedClient{next: c}
+}
+
+func (c *tracedClient) Do(req *http.Request) (*http.Response, error) {
+	if !telemetry.Enabled() {
+		return c.next.Do(req)
+	}
+
+	node := req.URL.Host
+	route := blobberRoute(req.URL.Path)
+	op := req.Method + " " + route
+	ctx, span := telemetry.StartSpan(req.Context(), "blobber.http",
+		telemetry.String(telemetry.AttrNode, node),
+		telemetry.String(telemetry.AttrMethod, req.Method),
+		telemetry.String(telemetry.AttrOperation, route))
+
+	start := time.Now()
+	resp, err := c.next.Do(req.WithContext(ctx))
+	if err == nil && resp.StatusCode >= http.StatusInternalServerError {
+		err = errors.New("blobber_error", resp.Status)
+	}
+	telemetry.RecordRequest(ctx, node, op, time.Since(start), err)
+	if req.ContentLength > 0 {
+		telemetry.AddBytes(ctx, node, telemetry.DirectionSent, req.ContentLength)
+	}
+	if resp == nil {
+		telemetry.EndSpan(span, err)
+		return nil, err
+	}
+
+	span.SetAttributes(telemetry.Int(telemetry.AttrStatus, resp.StatusCode))
+	if err != nil {
+		span.RecordError(err)
+	}
+	if resp.Body == nil {
+		span.End()
+		return resp, nil
+	}
+	resp.Body = &countingBody{ReadCloser: resp.Body, onClose: func(n int64) {
+		telemetry.AddBytes(ctx, node, telemetry.DirectionReceived, n)
+		span.End()
+	}}
+	return resp, nil
+}
+
+// blobberRoutes are the endpoints of the blobber requests, the longer ones
+// first so that e.g. RECENT_REFS_ENDPOINT wins over REFS_ENDPOINT.
+var blobberRoutes = func() []string {
+	routes := []string{
+		ALLOCATION_ENDPOINT, UPLOAD_ENDPOINT, RENAME_ENDPOINT, COPY_ENDPOINT,
+		MOVE_ENDPOINT, LIST_ENDPOINT, REFERENCE_ENDPOINT, CONNECTION_ENDPOINT,
+		COMMIT_ENDPOINT, DOWNLOAD_ENDPOINT, LATEST_READ_MARKER,
+		FILE_META_ENDPOINT, FILE_STATS_ENDPOINT, CHUNK_HASHES_ENDPOINT,
+		OBJECT_TREE_ENDPOINT, REFS_ENDPOINT, RECENT_REFS_ENDPOINT,
+		COMMIT_META_TXN_ENDPOINT, COLLABORATOR_ENDPOINT,
+		CALCULATE_HASH_ENDPOINT, SHARE_ENDPOINT, DIR_ENDPOINT,
+		PLAYLIST_LATEST_ENDPOINT, PLAYLIST_FILE_ENDPOINT, WM_LOCK_ENDPOINT,
+	}
+	sort.Slice(routes, func(i, j int) bool { return len(routes[i]) > len(routes[j]) })
+	return routes
+}()
+
+// blobberRoute returns the endpoint of path without the allocation and the
+// other ids following it, to keep the cardinality of the operations low.
+func blobberRoute(path string) string {
+	for _, r := range blobberRoutes {
+		if strings.HasPrefix(path, r) {
+			return r
+		}
+	}
+	return "other"
+}
+
+// countingBody reports the bytes read from a response body once closed.
+type countingBody struct {
+	io.ReadCloser
+	n       int64
+	closed  int32
+	onClose func(n int64)
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	atomic.AddInt64(&b.n, int64(n))
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	if atomic.CompareAndSwapInt32(&b.closed, 0, 1) {
+		b.onClose(atomic.LoadInt64(&b.n))
+	}
+	return err
+}
@@ -0,0 +1,26 @@
+package zboxutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobberRoute(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "Test_Upload", path: "/v1/file/upload/allocation_tx", want: UPLOAD_ENDPOINT},
+		{name: "Test_Recent_Refs", path: "/v1/file/refs/recent/allocation_tx", want: RECENT_REFS_ENDPOINT},
+		{name: "Test_Refs", path: "/v1/file/refs/allocation_tx", want: REFS_ENDPOINT},
+		{name: "Test_Read_Marker", path: "/v1/readmarker/latest", want: LATEST_READ_MARKER},
+		{name: "Test_Unknown", path: "/v1/unknown/allocation_tx", want: "other"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, blobberRoute(tt.path))
+		})
+	}
+}
@@ -7,18 +7,20 @@ import (
 	"net"
 	"net/http"
 	"time"
+
+	"github.com/0chain/gosdk/core/netconf"
 )
 
-var DefaultTransport = &http.Transport{
-	Proxy: envProxy.Proxy,
-	DialContext: (&net.Dialer{
+var DefaultTransport = netconf.RegisterTransport(&http.Transport{
+	Proxy: netconf.Proxy(envProxy.Proxy),
+	DialContext: netconf.DialContext(&net.Dialer{
 		Timeout:   45 * time.Second,
 		KeepAlive: 45 * time.Second,
 		DualStack: true,
-	}).DialContext,
+	}),
 	MaxIdleConns:          100,
 	IdleConnTimeout:       90 * time.Second,
 	TLSHandshakeTimeout:   10 * time.Second,
 	ExpectContinueTimeout: 1 * time.Second,
 	MaxIdleConnsPerHost:   100,
-}
+})
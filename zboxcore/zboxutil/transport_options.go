@@ -0,0 +1,123 @@
+package zboxutil
+
+import (
+	"crypto/tls"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0chain/gosdk/core/netconf"
+)
+
+// TransportOptions tune the transport shared by the requests to the
+// blobbers and sharders.
+type TransportOptions struct {
+	// MaxIdleConns is the number of idle connections kept for all hosts
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the number of idle connections kept per blobber,
+	// to be raised to the number of parallel uploads or downloads
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the connections per blobber, 0 for no limit
+	MaxConnsPerHost int
+	// IdleConnTimeout closes the connections idle for longer
+	IdleConnTimeout time.Duration
+	// EnableHTTP2 negotiates HTTP/2 with the blobbers served over TLS, the
+	// requests then being multiplexed on one connection per blobber
+	EnableHTTP2 bool
+	// TLSConfig of the connections to the blobbers, e.g. with a client
+	// certificate, instead of the one of netconf.SetTLSConfig
+	TLSConfig *tls.Config
+}
+
+// DefaultTransportOptions are the options of DefaultTransport.
+func DefaultTransportOptions() TransportOptions {
+	return TransportOptions{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 100,
+		IdleConnTimeout:     90 * time.Second,
+	}
+}
+
+var (
+	transportMu      sync.Mutex
+	transportOptions = DefaultTransportOptions()
+	// transport holds the *http.Transport of the requests to the blobbers,
+	// DefaultTransport until SetTransportOptions replaces it
+	transport atomic.Value
+)
+
+func init() {
+	transport.Store(DefaultTransport)
+}
+
+// currentTransport returns the transport of the requests to the blobbers.
+func currentTransport() *http.Transport {
+	return transport.Load().(*http.Transport)
+}
+
+// swappableTransport sends the requests with the current transport, so that
+// SetTransportOptions can replace it while requests are sent.
+type swappableTransport struct{}
+
+func (swappableTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return currentTransport().RoundTrip(req)
+}
+
+func (swappableTransport) CloseIdleConnections() {
+	currentTransport().CloseIdleConnections()
+}
+
+// httpClient is the client of Client, sending the requests with the
+// transport set by SetTransportOptions.
+var httpClient = &http.Client{
+	Transport: swappableTransport{},
+}
+
+// SetTransportOptions replaces the transport used by all the requests to the
+// blobbers, DefaultTransport at first, with a transport tuned with opts. The
+// idle connections of the previous transport are closed, the requests in
+// flight complete on their connection.
+func SetTransportOptions(opts TransportOptions) {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+
+	prev := currentTransport()
+	t := prev.Clone()
+	t.MaxIdleConns = opts.MaxIdleConns
+	t.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	t.MaxConnsPerHost = opts.MaxConnsPerHost
+	t.IdleConnTimeout = opts.IdleConnTimeout
+	t.ForceAttemptHTTP2 = opts.EnableHTTP2
+	if opts.EnableHTTP2 {
+		t.TLSNextProto = nil
+	} else {
+		// a non-nil map disables HTTP/2
+		t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+
+	netconf.UnregisterTransport(prev)
+	if opts.TLSConfig != nil {
+		t.TLSClientConfig = opts.TLSConfig.Clone()
+	} else {
+		t.TLSClientConfig = nil
+		netconf.RegisterTransport(t)
+	}
+
+	transport.Store(t)
+	transportOptions = opts
+	prev.CloseIdleConnections()
+}
+
+// CloseIdleConnections closes the idle connections of the transport of the
+// requests to the blobbers.
+func CloseIdleConnections() {
+	currentTransport().CloseIdleConnections()
+}
+
+// GetTransportOptions returns the options set with SetTransportOptions.
+func GetTransportOptions() TransportOptions {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	return transportOptions
+}
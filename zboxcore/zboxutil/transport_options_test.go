@@ -0,0 +1,69 @@
+package zboxutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTransportOptions(t *testing.T) {
+	prev := GetTransportOptions()
+	defer SetTransportOptions(prev)
+
+	before := currentTransport()
+	opts := TransportOptions{
+		MaxIdleConns:        500,
+		MaxIdleConnsPerHost: 64,
+		MaxConnsPerHost:     128,
+		IdleConnTimeout:     time.Minute,
+		EnableHTTP2:         true,
+	}
+	SetTransportOptions(opts)
+
+	tr := currentTransport()
+	require.NotSame(t, before, tr)
+	require.Same(t, DefaultTransport, before, "DefaultTransport isn't replaced")
+	require.Equal(t, opts, GetTransportOptions())
+	require.Equal(t, 64, tr.MaxIdleConnsPerHost)
+	require.Equal(t, 128, tr.MaxConnsPerHost)
+	require.True(t, tr.ForceAttemptHTTP2)
+	require.Nil(t, tr.TLSNextProto)
+
+	opts.EnableHTTP2 = false
+	SetTransportOptions(opts)
+	require.False(t, currentTransport().ForceAttemptHTTP2)
+	require.NotNil(t, currentTransport().TLSNextProto)
+}
+
+func TestSetTransportOptions_Concurrent_Requests(t *testing.T) {
+	prev := GetTransportOptions()
+	defer SetTransportOptions(prev)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	// run with -race: the transport is swapped while requests are sent
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				resp, err := httpClient.Get(srv.URL)
+				require.NoError(t, err)
+				resp.Body.Close()
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		opts := prev
+		opts.MaxIdleConnsPerHost = 10 + i
+		SetTransportOptions(opts)
+	}
+	wg.Wait()
+	require.Equal(t, 19, currentTransport().MaxIdleConnsPerHost)
+}
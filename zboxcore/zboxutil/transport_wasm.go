@@ -6,14 +6,16 @@ package zboxutil
 import (
 	"net/http"
 	"time"
+
+	"github.com/0chain/gosdk/core/netconf"
 )
 
-var DefaultTransport = &http.Transport{
-	Proxy: envProxy.Proxy,
+var DefaultTransport = netconf.RegisterTransport(&http.Transport{
+	Proxy: netconf.Proxy(envProxy.Proxy),
 
 	MaxIdleConns:          100,
 	IdleConnTimeout:       90 * time.Second,
 	TLSHandshakeTimeout:   10 * time.Second,
 	ExpectContinueTimeout: 1 * time.Second,
 	MaxIdleConnsPerHost:   100,
-}
+})
@@ -0,0 +1,205 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum/erc20"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// DefaultAllowanceMargin is how many percents above the queued amount the
+// allowance must stay before AllowanceLow is emitted.
+const DefaultAllowanceMargin = 10
+
+type (
+	// AllowanceEventType is the state of the bridge wallet reported by AllowanceWatcher
+	AllowanceEventType int
+
+	// AllowanceEvent is emitted by AllowanceWatcher when the state changes
+	AllowanceEvent struct {
+		Type AllowanceEventType
+		// Allowance granted to the bridge contract by the wallet, in wei
+		Allowance *big.Int
+		// Balance of the wallet, in wei
+		Balance *big.Int
+		// Required is the sum of the queued operations, in wei
+		Required *big.Int
+		Time     time.Time
+	}
+
+	// tokenStateReader returns the WZCN allowance of the bridge and the wallet balance
+	tokenStateReader func(ctx context.Context) (allowance, balance *big.Int, err error)
+
+	// AllowanceWatcher monitors the ERC-20 allowance and balance of the bridge
+	// wallet against the operations queued with it, so re-approval can be
+	// asked for before a burn reverts.
+	AllowanceWatcher struct {
+		// Margin in percents, see DefaultAllowanceMargin
+		Margin int
+
+		mu       sync.Mutex
+		read     tokenStateReader
+		queued   map[string]*big.Int
+		last     AllowanceEventType
+		reported bool
+	}
+)
+
+const (
+	// AllowanceOK allowance and balance cover the queued operations
+	AllowanceOK AllowanceEventType = iota
+	// AllowanceLow allowance is insufficient or within the margin of the queued operations
+	AllowanceLow
+	// BalanceLow balance doesn't cover the queued operations
+	BalanceLow
+)
+
+func (t AllowanceEventType) String() string {
+	switch t {
+	case AllowanceOK:
+		return "allowance_ok"
+	case AllowanceLow:
+		return "allowance_low"
+	case BalanceLow:
+		return "balance_low"
+	}
+	return "unknown"
+}
+
+// NewAllowanceWatcher creates a watcher of the WZCN allowance the bridge
+// wallet granted to the bridge contract.
+func (b *BridgeClient) NewAllowanceWatcher() *AllowanceWatcher {
+	return newAllowanceWatcher(func(ctx context.Context) (*big.Int, *big.Int, error) {
+		etherClient, err := b.CreateEthClient()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to create etherClient")
+		}
+		defer etherClient.Close()
+
+		token, err := erc20.NewERC20Caller(common.HexToAddress(b.WzcnAddress), etherClient)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to initialize WZCN-ERC20 instance")
+		}
+
+		opts := &bind.CallOpts{Context: ctx}
+		owner := common.HexToAddress(b.EthereumAddress)
+		allowance, err := token.Allowance(opts, owner, common.HexToAddress(b.BridgeAddress))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to get allowance")
+		}
+		balance, err := token.BalanceOf(opts, owner)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to get balance")
+		}
+		return allowance, balance, nil
+	})
+}
+
+func newAllowanceWatcher(read tokenStateReader) *AllowanceWatcher {
+	return &AllowanceWatcher{
+		Margin: DefaultAllowanceMargin,
+		read:   read,
+		queued: make(map[string]*big.Int),
+	}
+}
+
+// Queue registers an operation that will spend amount wei, e.g. a pending burn.
+func (w *AllowanceWatcher) Queue(id string, amount *big.Int) {
+	w.mu.Lock()
+	w.queued[id] = new(big.Int).Set(amount)
+	w.mu.Unlock()
+}
+
+// Done removes an operation once it was sent or dropped.
+func (w *AllowanceWatcher) Done(id string) {
+	w.mu.Lock()
+	delete(w.queued, id)
+	w.mu.Unlock()
+}
+
+// Required returns the sum of the queued operations.
+func (w *AllowanceWatcher) Required() *big.Int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.required()
+}
+
+func (w *AllowanceWatcher) required() *big.Int {
+	sum := new(big.Int)
+	for _, amount := range w.queued {
+		sum.Add(sum, amount)
+	}
+	return sum
+}
+
+// Check reads the allowance and balance once. It returns an event when the
+// state changed since the previous check, nil otherwise.
+func (w *AllowanceWatcher) Check(ctx context.Context) (*AllowanceEvent, error) {
+	allowance, balance, err := w.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	required := w.required()
+
+	withMargin := new(big.Int).Mul(required, big.NewInt(int64(100+w.Margin)))
+	withMargin.Div(withMargin, big.NewInt(100))
+
+	state := AllowanceOK
+	switch {
+	case balance.Cmp(required) < 0:
+		state = BalanceLow
+	case allowance.Cmp(withMargin) < 0:
+		state = AllowanceLow
+	}
+
+	if w.reported && state == w.last {
+		return nil, nil
+	}
+	w.reported, w.last = true, state
+	return &AllowanceEvent{
+		Type:      state,
+		Allowance: allowance,
+		Balance:   balance,
+		Required:  required,
+		Time:      time.Now(),
+	}, nil
+}
+
+// Watch checks every interval until ctx is done and emits the state changes.
+// The first check always emits the current state.
+func (w *AllowanceWatcher) Watch(ctx context.Context, interval time.Duration) <-chan AllowanceEvent {
+	events := make(chan AllowanceEvent, 1)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			ev, err := w.Check(ctx)
+			if err != nil {
+				Logger.Error("allowance watcher check failed", zap.Error(err))
+			} else if ev != nil {
+				select {
+				case events <- *ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return events
+}
@@ -0,0 +1,42 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowanceWatcher_Check(t *testing.T) {
+	allowance, balance := big.NewInt(1000), big.NewInt(5000)
+	w := newAllowanceWatcher(func(ctx context.Context) (*big.Int, *big.Int, error) {
+		return allowance, balance, nil
+	})
+
+	ev, err := w.Check(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, AllowanceOK, ev.Type)
+
+	ev, err = w.Check(context.TODO())
+	require.NoError(t, err)
+	require.Nil(t, ev, "unchanged state should not be emitted")
+
+	// 950 + 10% margin is more than the 1000 allowed
+	w.Queue("burn1", big.NewInt(950))
+	ev, err = w.Check(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, AllowanceLow, ev.Type)
+	require.Equal(t, int64(950), ev.Required.Int64())
+
+	w.Queue("burn2", big.NewInt(5000))
+	ev, err = w.Check(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, BalanceLow, ev.Type)
+
+	w.Done("burn1")
+	w.Done("burn2")
+	ev, err = w.Check(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, AllowanceOK, ev.Type)
+}
@@ -0,0 +1,269 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/authorizers"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrNotAuthorizersOwner is returned when the client changing the
+	// authorizers doesn't own the Authorizers contract.
+	ErrNotAuthorizersOwner = errors.New("not the owner of the authorizers contract")
+	// ErrAlreadyAuthorizer is returned when adding an authorizer twice.
+	ErrAlreadyAuthorizer = errors.New("already an authorizer")
+	// ErrNotAuthorizer is returned when removing an address that isn't an
+	// authorizer.
+	ErrNotAuthorizer = errors.New("not an authorizer")
+)
+
+// AuthorizerInfo is an authorizer of the Authorizers contract.
+type AuthorizerInfo struct {
+	Address string `json:"address"`
+	Index   uint64 `json:"index"`
+}
+
+// AuthorizerList is the result of ListAuthorizers.
+type AuthorizerList struct {
+	Authorizers  []*AuthorizerInfo `json:"authorizers"`
+	Count        uint64            `json:"count"`
+	MinThreshold uint64            `json:"min_threshold"`
+	// Complete tells whether all the Count authorizers of the contract were
+	// found.
+	Complete bool `json:"complete"`
+}
+
+// authorizersRegistry is the part of the Authorizers contract managing the
+// authorizers.
+type authorizersRegistry interface {
+	authorizersCaller
+	AuthorizerCount(opts *bind.CallOpts) (*big.Int, error)
+	Owner(opts *bind.CallOpts) (common.Address, error)
+}
+
+// mintSource is the part of the Ethereum client finding the mints of the
+// bridge contract.
+type mintSource interface {
+	FilterLogs(ctx context.Context, q eth.FilterQuery) ([]types.Log, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+}
+
+// ListAuthorizers returns the authorizers of the Authorizers contract. The
+// contract has no list of its authorizers, so the candidates are the signers
+// of the mints of the bridge contract from fromBlock, and the known
+// addresses, and the authorizers are the candidates the contract has. The
+// list is Complete once as many authorizers as the contract counts are found,
+// authorizers that didn't sign any mint yet must be known otherwise.
+func (b *BridgeClientConfig) ListAuthorizers(ctx context.Context, fromBlock uint64, known ...common.Address) (*AuthorizerList, error) {
+	etherClient, err := b.CreateEthClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etherClient")
+	}
+	defer etherClient.Close()
+
+	caller, err := authorizers.NewAuthorizersCaller(common.HexToAddress(b.AuthorizersAddress), etherClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create authorizers instance")
+	}
+
+	signers, err := mintSigners(ctx, etherClient, common.HexToAddress(b.BridgeAddress), fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	return listAuthorizers(ctx, caller, append(known, signers...))
+}
+
+// mintSigners returns the signers of the mints of the bridge contract from
+// fromBlock.
+func mintSigners(ctx context.Context, client mintSource, bridgeAddress common.Address, fromBlock uint64) ([]common.Address, error) {
+	decoder, err := ethereum.NewBridgeLogDecoder()
+	if err != nil {
+		return nil, err
+	}
+	topics, err := decoder.Topics("Minted")
+	if err != nil {
+		return nil, err
+	}
+	bridgeABI, err := bridge.BridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get ABI")
+	}
+
+	logs, err := client.FilterLogs(ctx, eth.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		Addresses: []common.Address{bridgeAddress},
+		Topics:    topics,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the mints")
+	}
+
+	var signers []common.Address
+	seen := make(map[common.Hash]bool)
+	for _, l := range logs {
+		if seen[l.TxHash] {
+			continue
+		}
+		seen[l.TxHash] = true
+
+		tx, _, err := client.TransactionByHash(ctx, l.TxHash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the mint %s", l.TxHash.Hex())
+		}
+		if len(tx.Data()) < 4 {
+			continue
+		}
+		method, err := bridgeABI.MethodById(tx.Data()[:4])
+		if err != nil || (method.Name != "mint" && method.Name != "mintFor") {
+			// minted by another contract calling the bridge
+			continue
+		}
+		args, err := method.Inputs.Unpack(tx.Data()[4:])
+		if err != nil {
+			Logger.Error("failed to decode the mint", zap.String("hash", l.TxHash.Hex()), zap.Error(err))
+			continue
+		}
+
+		to, _ := args[0].(common.Address)
+		amount, _ := args[1].(*big.Int)
+		txid, _ := args[2].([]byte)
+		nonce, _ := args[3].(*big.Int)
+		signatures, _ := args[4].([][]byte)
+		hash := MessageHash(to, amount, txid, nonce)
+		for _, sig := range signatures {
+			if signer, err := recoverSigner(hash, sig); err == nil {
+				signers = append(signers, signer)
+			}
+		}
+	}
+	return signers, nil
+}
+
+// listAuthorizers returns the candidates that are authorizers of the
+// contract, ordered by their index.
+func listAuthorizers(ctx context.Context, caller authorizersRegistry, candidates []common.Address) (*AuthorizerList, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	count, err := caller.AuthorizerCount(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the authorizer count")
+	}
+	minThreshold, err := caller.MinThreshold(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the min threshold")
+	}
+
+	list := &AuthorizerList{Count: count.Uint64(), MinThreshold: minThreshold.Uint64()}
+	checked := make(map[common.Address]bool, len(candidates))
+	for _, candidate := range candidates {
+		if checked[candidate] {
+			continue
+		}
+		checked[candidate] = true
+
+		authorizer, err := caller.Authorizers(opts, candidate)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check %s", candidate.Hex())
+		}
+		if !authorizer.IsAuthorizer {
+			continue
+		}
+		list.Authorizers = append(list.Authorizers, &AuthorizerInfo{
+			Address: candidate.Hex(),
+			Index:   authorizer.Index.Uint64(),
+		})
+	}
+
+	sort.Slice(list.Authorizers, func(i, j int) bool { return list.Authorizers[i].Index < list.Authorizers[j].Index })
+	list.Complete = uint64(len(list.Authorizers)) == list.Count
+	return list, nil
+}
+
+// AddAuthorizer adds address to the authorizers of the Authorizers contract
+// and waits for the transaction to be confirmed, see EthereumConfirmations.
+// The client must own the contract, and address must not be an authorizer
+// already.
+func (b *BridgeOwner) AddAuthorizer(ctx context.Context, address common.Address) (*TxStatus, error) {
+	return b.changeAuthorizer(ctx, address, true)
+}
+
+// RemoveAuthorizer removes address from the authorizers of the Authorizers
+// contract and waits for the transaction to be confirmed, see
+// EthereumConfirmations. The client must own the contract, and address must
+// be an authorizer.
+func (b *BridgeOwner) RemoveAuthorizer(ctx context.Context, address common.Address) (*TxStatus, error) {
+	return b.changeAuthorizer(ctx, address, false)
+}
+
+func (b *BridgeOwner) changeAuthorizer(ctx context.Context, address common.Address, add bool) (*TxStatus, error) {
+	etherClient, err := b.CreateEthClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etherClient")
+	}
+	defer etherClient.Close()
+
+	caller, err := authorizers.NewAuthorizersCaller(common.HexToAddress(b.AuthorizersAddress), etherClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create authorizers instance")
+	}
+	if err := checkAuthorizerChange(ctx, caller, common.HexToAddress(b.EthereumAddress), address, add); err != nil {
+		return nil, err
+	}
+
+	var tx *types.Transaction
+	if add {
+		tx, err = b.AddEthereumAuthorizer(ctx, address)
+	} else {
+		tx, err = b.RemoveEthereumAuthorizer(ctx, address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := b.waitConfirmed(ctx, tx)
+	if err != nil {
+		return status, err
+	}
+
+	authorizer, err := caller.Authorizers(&bind.CallOpts{Context: ctx}, address)
+	if err != nil {
+		return status, errors.Wrapf(err, "failed to check %s", address.Hex())
+	}
+	if authorizer.IsAuthorizer != add {
+		return status, errors.Errorf("transaction %s confirmed, but %s wasn't changed", status.Hash, address.Hex())
+	}
+	return status, nil
+}
+
+// checkAuthorizerChange checks owner can add, or remove, address.
+func checkAuthorizerChange(ctx context.Context, caller authorizersRegistry, owner, address common.Address, add bool) error {
+	opts := &bind.CallOpts{Context: ctx}
+	contractOwner, err := caller.Owner(opts)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the owner of the authorizers contract")
+	}
+	if contractOwner != owner {
+		return errors.Wrapf(ErrNotAuthorizersOwner, "owned by %s", contractOwner.Hex())
+	}
+
+	authorizer, err := caller.Authorizers(opts, address)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check %s", address.Hex())
+	}
+	if add && authorizer.IsAuthorizer {
+		return errors.Wrap(ErrAlreadyAuthorizer, address.Hex())
+	}
+	if !add && !authorizer.IsAuthorizer {
+		return errors.Wrap(ErrNotAuthorizer, address.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package zcnbridge
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistry is an Authorizers contract owned by owner, with the
+// authorizers at their index.
+type fakeRegistry struct {
+	fakeAuthorizersContract
+	owner   common.Address
+	indexes map[common.Address]int64
+}
+
+func (r *fakeRegistry) Authorizers(opts *bind.CallOpts, signer common.Address) (struct {
+	Index        *big.Int
+	IsAuthorizer bool
+}, error) {
+	index, ok := r.indexes[signer]
+	return struct {
+		Index        *big.Int
+		IsAuthorizer bool
+	}{Index: big.NewInt(index), IsAuthorizer: ok}, nil
+}
+
+func (r *fakeRegistry) AuthorizerCount(opts *bind.CallOpts) (*big.Int, error) {
+	return big.NewInt(int64(len(r.indexes))), nil
+}
+
+func (r *fakeRegistry) MinThreshold(opts *bind.CallOpts) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (r *fakeRegistry) Owner(opts *bind.CallOpts) (common.Address, error) {
+	return r.owner, nil
+}
+
+func TestListAuthorizers(t *testing.T) {
+	a, b, c := common.HexToAddress("0xa"), common.HexToAddress("0xb"), common.HexToAddress("0xc")
+	registry := &fakeRegistry{indexes: map[common.Address]int64{a: 1, b: 0}}
+
+	t.Run("Test_Complete", func(t *testing.T) {
+		list, err := listAuthorizers(context.Background(), registry, []common.Address{a, c, b, a})
+		require.NoError(t, err)
+		require.True(t, list.Complete)
+		require.EqualValues(t, 2, list.Count)
+		require.Len(t, list.Authorizers, 2)
+		require.Equal(t, b.Hex(), list.Authorizers[0].Address)
+		require.Equal(t, a.Hex(), list.Authorizers[1].Address)
+	})
+
+	t.Run("Test_Incomplete", func(t *testing.T) {
+		list, err := listAuthorizers(context.Background(), registry, []common.Address{a})
+		require.NoError(t, err)
+		require.False(t, list.Complete)
+		require.Len(t, list.Authorizers, 1)
+	})
+}
+
+// fakeMints are the mint transactions of a bridge contract.
+type fakeMints map[common.Hash]*types.Transaction
+
+func (m fakeMints) FilterLogs(ctx context.Context, q eth.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	for hash := range m {
+		logs = append(logs, types.Log{TxHash: hash}, types.Log{TxHash: hash})
+	}
+	return logs, nil
+}
+
+func (m fakeMints) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	return m[hash], false, nil
+}
+
+func TestMintSigners(t *testing.T) {
+	key1, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key2, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	contract := &fakeAuthorizersContract{}
+	bridgeABI, err := bridge.BridgeMetaData.GetAbi()
+	require.NoError(t, err)
+
+	to := common.HexToAddress("0xaa")
+	amount, txid, nonce := big.NewInt(10), []byte("burn"), big.NewInt(1)
+	hash, err := contract.MessageHash(nil, to, amount, txid, nonce)
+	require.NoError(t, err)
+	var sigs [][]byte
+	for _, key := range []*ecdsa.PrivateKey{key1, key2} {
+		sig, err := crypto.Sign(accounts.TextHash(hash[:]), key)
+		require.NoError(t, err)
+		sigs = append(sigs, sig)
+	}
+	data, err := bridgeABI.Pack("mint", to, amount, txid, nonce, sigs)
+	require.NoError(t, err)
+	tx := types.NewTx(&types.LegacyTx{Data: data})
+
+	signers, err := mintSigners(context.Background(), fakeMints{tx.Hash(): tx}, common.Address{}, 0)
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{crypto.PubkeyToAddress(key1.PublicKey), crypto.PubkeyToAddress(key2.PublicKey)}, signers)
+}
+
+func TestCheckAuthorizerChange(t *testing.T) {
+	owner, authorizer, other := common.HexToAddress("0x1"), common.HexToAddress("0xa"), common.HexToAddress("0xb")
+	registry := &fakeRegistry{owner: owner, indexes: map[common.Address]int64{authorizer: 0}}
+
+	tests := []struct {
+		name    string
+		from    common.Address
+		address common.Address
+		add     bool
+		wantErr error
+	}{
+		{name: "Test_Add", from: owner, address: other, add: true},
+		{name: "Test_Remove", from: owner, address: authorizer},
+		{name: "Test_Not_Owner", from: other, address: other, add: true, wantErr: ErrNotAuthorizersOwner},
+		{name: "Test_Add_Twice", from: owner, address: authorizer, add: true, wantErr: ErrAlreadyAuthorizer},
+		{name: "Test_Remove_Not_Authorizer", from: owner, address: other, wantErr: ErrNotAuthorizer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkAuthorizerChange(context.Background(), registry, tt.from, tt.address, tt.add)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package zcnbridge
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/0chain/gosdk/zcnbridge/errors"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// authorizersCaller is the part of the Authorizers contract verifying the
+// signers of the burn tickets.
+type authorizersCaller interface {
+	Authorizers(opts *bind.CallOpts, arg0 common.Address) (struct {
+		Index        *big.Int
+		IsAuthorizer bool
+	}, error)
+	MinThreshold(opts *bind.CallOpts) (*big.Int, error)
+}
+
+// requiredSignatures returns the number of the total authorizers that must
+// sign a burn for the consensus threshold, a percentage, to be reached.
+func requiredSignatures(total int, threshold float64) int {
+	for n := 1; n < total; n++ {
+		if math.Ceil(float64(n)*100/float64(total)) >= threshold {
+			return n
+		}
+	}
+	return total
+}
+
+// ticketVerifier accepts the burn tickets of the authorizers whose signature
+// is of an authorizer of the Authorizers contract, for the message hash of
+// the ticket, see MessageHash. The first ticket accepted is the ticket
+// minted, the tickets of the other authorizers must be the same.
+type ticketVerifier struct {
+	caller authorizersCaller
+	txHash string
+
+	ticket      *ProofZCNBurn
+	messageHash [32]byte
+	signers     map[common.Address]string
+}
+
+func newTicketVerifier(caller authorizersCaller, txHash string) *ticketVerifier {
+	return &ticketVerifier{caller: caller, txHash: txHash, signers: make(map[common.Address]string)}
+}
+
+func (v *ticketVerifier) accept(result JobResult) error {
+	ticket, ok := result.(*ProofZCNBurn)
+	if !ok {
+		return errors.New("type_cast", "failed to convert to *ProofZCNBurn")
+	}
+	if ticket.TxnID != v.txHash {
+		return errors.New("invalid_ticket", "ticket of another burn: "+ticket.TxnID)
+	}
+
+	if v.ticket == nil {
+		v.messageHash = MessageHash(
+			common.HexToAddress(ticket.To),
+			big.NewInt(ticket.Amount),
+			DefaultClientIDEncoder(ticket.TxnID),
+			big.NewInt(ticket.Nonce),
+		)
+	} else if ticket.To != v.ticket.To || ticket.Amount != v.ticket.Amount || ticket.Nonce != v.ticket.Nonce {
+		return errors.New("invalid_ticket", "ticket differs from the ticket of "+v.ticket.GetAuthorizerID())
+	}
+
+	signer, err := recoverSigner(v.messageHash, ticket.Signature)
+	if err != nil {
+		return errors.Wrap("invalid_signature", "failed to recover the signer", err)
+	}
+	if id, ok := v.signers[signer]; ok {
+		return errors.New("duplicate_signature", "already signed by "+id)
+	}
+	authorizer, err := v.caller.Authorizers(&bind.CallOpts{}, signer)
+	if err != nil {
+		return errors.Wrap("authorizers", "failed to check the signer", err)
+	}
+	if !authorizer.IsAuthorizer {
+		return errors.New("invalid_signature", "signed by "+signer.Hex()+", not an authorizer")
+	}
+
+	v.signers[signer] = ticket.GetAuthorizerID()
+	if v.ticket == nil {
+		v.ticket = ticket
+	}
+	return nil
+}
+
+// recoverSigner returns the address signing messageHash as an Ethereum
+// signed message, as the Authorizers contract recovers it.
+func recoverSigner(messageHash [32]byte, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, errors.New("invalid_signature", "signature must be 65 bytes long")
+	}
+	sig := append([]byte(nil), signature...)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(accounts.TextHash(messageHash[:]), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
@@ -1,21 +1,24 @@
 package zcnbridge
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"math"
 	"net/http"
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/0chain/gosdk/core/common"
 	"github.com/0chain/gosdk/zcnbridge/errors"
 	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	authorizerscontract "github.com/0chain/gosdk/zcnbridge/ethereum/authorizers"
 	h "github.com/0chain/gosdk/zcnbridge/http"
 	"github.com/0chain/gosdk/zcnbridge/log"
 	"github.com/0chain/gosdk/zcnbridge/wallet"
 	"github.com/0chain/gosdk/zcnbridge/zcnsc"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"go.uber.org/zap"
 )
 
@@ -37,15 +40,20 @@ type (
 	}
 
 	responseChannelType chan *authorizerResponse
-	eventsChannelType   chan []JobResult
 )
 
 var (
 	client *http.Client
+
+	// AuthorizerQueryTimeout is the timeout of the request of a burn ticket
+	// to an authorizer.
+	AuthorizerQueryTimeout = 30 * time.Second
 )
 
 // QueryEthereumMintPayload gets burn ticket and creates mint payload to be minted in the Ethereum chain
 // zchainBurnHash - Ethereum burn transaction hash
+// The signatures are collected until enough authorizers signed, each checked against the message hash
+// of the Authorizers contract, so the mint isn't sent with signatures the contract would reject.
 func (b *BridgeClient) QueryEthereumMintPayload(zchainBurnHash string) (*ethereum.MintPayload, error) {
 	client = h.CleanClient()
 	authorizers, err := getAuthorizers()
@@ -71,16 +79,34 @@ func (b *BridgeClient) QueryEthereumMintPayload(zchainBurnHash string) (*ethereu
 		},
 	}
 
-	thresh := b.ConsensusThreshold
-	results := queryAllAuthorizers(authorizers, handler)
+	if DefaultClientIDEncoder == nil {
+		return nil, errors.New("client_id_encoder", "DefaultClientIDEncoder must be setup")
+	}
+	etherClient, err := b.CreateEthClient()
+	if err != nil {
+		return nil, errors.Wrap("eth_client", "failed to create etherClient", err)
+	}
+	defer etherClient.Close()
+	caller, err := authorizerscontract.NewAuthorizersCaller(ethcommon.HexToAddress(b.AuthorizersAddress), etherClient)
+	if err != nil {
+		return nil, errors.Wrap("authorizers", "failed to create the authorizers contract", err)
+	}
+
+	needed := requiredSignatures(totalWorkers, b.ConsensusThreshold)
+	minThreshold, err := caller.MinThreshold(&bind.CallOpts{})
+	if err != nil {
+		return nil, errors.Wrap("authorizers", "failed to get the min threshold", err)
+	}
+	if minThreshold.IsInt64() && int(minThreshold.Int64()) > needed {
+		needed = int(minThreshold.Int64())
+	}
+
+	verifier := newTicketVerifier(caller, zchainBurnHash)
+	results := queryAllAuthorizers(authorizers, handler, needed, verifier.accept)
 	numSuccess := len(results)
-	quorum := math.Ceil((float64(numSuccess) * 100) / float64(totalWorkers))
 
-	if numSuccess > 0 && quorum >= thresh {
-		burnTicket, ok := results[0].(*ProofZCNBurn)
-		if !ok {
-			return nil, errors.Wrap("type_cast", "failed to convert to *proofEthereumBurn", err)
-		}
+	if numSuccess > 0 && numSuccess >= needed {
+		burnTicket := verifier.ticket
 
 		var sigs []*ethereum.AuthorizerSignature
 		for _, result := range results {
@@ -103,7 +129,7 @@ func (b *BridgeClient) QueryEthereumMintPayload(zchainBurnHash string) (*ethereu
 		return payload, nil
 	}
 
-	text := fmt.Sprintf("failed to reach the quorum. #Success: %d from #Total: %d", numSuccess, totalWorkers)
+	text := fmt.Sprintf("failed to reach the quorum. #Success: %d of #Needed: %d from #Total: %d", numSuccess, needed, totalWorkers)
 	return nil, errors.New("get_burn_ticket", text)
 }
 
@@ -136,12 +162,11 @@ func (b *BridgeClient) QueryZChainMintPayload(ethBurnHash string) (*zcnsc.MintPa
 		},
 	}
 
-	thresh := b.ConsensusThreshold
-	results := queryAllAuthorizers(authorizers, handler)
+	needed := requiredSignatures(totalWorkers, b.ConsensusThreshold)
+	results := queryAllAuthorizers(authorizers, handler, needed, nil)
 	numSuccess := len(results)
-	quorum := math.Ceil((float64(numSuccess) * 100) / float64(totalWorkers))
 
-	if numSuccess > 0 && quorum >= thresh {
+	if numSuccess > 0 && numSuccess >= needed {
 		burnTicket, ok := results[0].Data().(*ProofEthereumBurn)
 		if !ok {
 			return nil, errors.Wrap("type_cast", "failed to convert to *proofEthereumBurn", err)
@@ -172,50 +197,55 @@ func (b *BridgeClient) QueryZChainMintPayload(ethBurnHash string) (*zcnsc.MintPa
 	return nil, errors.New("get_burn_ticket", text)
 }
 
-func queryAllAuthorizers(authorizers []*AuthorizerNode, handler *requestHandler) []JobResult {
-	var (
-		totalWorkers    = len(authorizers)
-		eventsChannel   = make(eventsChannelType)
-		responseChannel = make(responseChannelType, totalWorkers)
-	)
-	defer close(eventsChannel)
-
-	var wg sync.WaitGroup
-
+// queryAllAuthorizers asks the authorizers for their ticket in parallel,
+// each request timing out after AuthorizerQueryTimeout. The tickets are
+// checked by accept, if set, and only one ticket of an authorizer is kept.
+// It returns once needed tickets are accepted, cancelling the requests left,
+// or all the authorizers answered.
+func queryAllAuthorizers(authorizers []*AuthorizerNode, handler *requestHandler, needed int, accept func(JobResult) error) []JobResult {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// buffered, so the requests left don't block once it returns
+	responseChannel := make(responseChannelType, len(authorizers))
 	for _, authorizer := range authorizers {
-		wg.Add(1)
-		go queryAuthorizer(authorizer, handler, responseChannel)
+		go queryAuthorizer(ctx, authorizer, handler, responseChannel)
 	}
 
-	go handleResponse(responseChannel, eventsChannel, &wg)
-
-	wg.Wait()
-	close(responseChannel)
-	results := <-eventsChannel
-
-	return results
-}
-
-func handleResponse(responseChannel responseChannelType, eventsChannel eventsChannelType, wg *sync.WaitGroup) {
-	var events []JobResult
-	for job := range responseChannel {
-		if job.error == nil {
-			event := job.event
-			event.SetAuthorizerID(job.AuthorizerID)
-			events = append(events, event)
+	var (
+		results  []JobResult
+		accepted = make(map[string]bool)
+	)
+	for range authorizers {
+		job := <-responseChannel
+		if job.error != nil || accepted[job.AuthorizerID] {
+			continue
+		}
+		if accept != nil {
+			if err := accept(job.event); err != nil {
+				Logger.Error("ticket rejected", zap.Error(err), zap.String("node.id", job.AuthorizerID))
+				continue
+			}
+		}
+		accepted[job.AuthorizerID] = true
+		results = append(results, job.event)
+		if needed > 0 && len(results) >= needed {
+			break
 		}
-		wg.Done()
 	}
-	eventsChannel <- events
+	return results
 }
 
-func queryAuthorizer(au *AuthorizerNode, request *requestHandler, responseChannel responseChannelType) {
+func queryAuthorizer(ctx context.Context, au *AuthorizerNode, request *requestHandler, responseChannel responseChannelType) {
 	Logger.Info("Query from authorizer", zap.String("ID", au.ID), zap.String("URL", au.URL))
 	ticketURL := strings.TrimSuffix(au.URL, "/") + request.path
 
-	req, err := http.NewRequest("GET", ticketURL, nil)
+	ctx, cancel := context.WithTimeout(ctx, AuthorizerQueryTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", ticketURL, nil)
 	if err != nil {
 		log.Logger.Error("failed to create request", zap.Error(err))
+		responseChannel <- &authorizerResponse{AuthorizerID: au.ID, error: err}
 		return
 	}
 
@@ -237,11 +267,17 @@ func queryAuthorizer(au *AuthorizerNode, request *requestHandler, responseChanne
 		)
 	}
 
+	if resp.error != nil {
+		responseChannel <- resp
+		return
+	}
+
 	event, errEvent := request.bodyDecoder(body)
 	event.SetAuthorizerID(au.ID)
 
 	if errEvent != nil {
 		err := errors.Wrap("decode_message_body", "failed to decode message body", errEvent)
+		resp.error = err
 		log.Logger.Error(
 			"failed to decode event body",
 			zap.Error(err),
@@ -0,0 +1,197 @@
+package zcnbridge
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredSignatures(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		threshold float64
+		want      int
+	}{
+		{name: "Test_Two_Thirds_Of_Three", total: 3, threshold: 70, want: 3},
+		{name: "Test_Half_Of_Four", total: 4, threshold: 50, want: 2},
+		{name: "Test_All_Of_One", total: 1, threshold: 100, want: 1},
+		{name: "Test_No_Threshold", total: 10, threshold: 0, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, requiredSignatures(tt.total, tt.threshold))
+		})
+	}
+}
+
+// fakeAuthorizer answers the burn ticket requests with ticket, after delay,
+// or fails with status.
+func fakeAuthorizer(t *testing.T, ticket *ProofZCNBurn, delay time.Duration, status int) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(ticket))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func ticketHandler() *requestHandler {
+	return &requestHandler{
+		path:   "/v1/ether/burnticket/get",
+		values: map[string]string{"hash": "burn"},
+		bodyDecoder: func(body []byte) (JobResult, error) {
+			ev := &ProofZCNBurn{}
+			err := json.Unmarshal(body, ev)
+			return ev, err
+		},
+	}
+}
+
+func TestQueryAllAuthorizers(t *testing.T) {
+	client = &http.Client{}
+	timeout := AuthorizerQueryTimeout
+	AuthorizerQueryTimeout = 200 * time.Millisecond
+	defer func() { AuthorizerQueryTimeout = timeout }()
+
+	ticket := &ProofZCNBurn{TxnID: "burn", Amount: 10, Signature: []byte{1}}
+
+	t.Run("Test_Partial_Failures", func(t *testing.T) {
+		authorizers := []*AuthorizerNode{
+			{ID: "a1", URL: fakeAuthorizer(t, ticket, 0, http.StatusOK)},
+			{ID: "a2", URL: fakeAuthorizer(t, ticket, 0, http.StatusInternalServerError)},
+			{ID: "a3", URL: fakeAuthorizer(t, ticket, time.Minute, http.StatusOK)},
+			{ID: "a4", URL: fakeAuthorizer(t, ticket, 0, http.StatusOK)},
+		}
+
+		start := time.Now()
+		results := queryAllAuthorizers(authorizers, ticketHandler(), 0, nil)
+		require.Len(t, results, 2)
+		require.Less(t, int64(time.Since(start)), int64(time.Second))
+	})
+
+	t.Run("Test_Stop_At_Threshold", func(t *testing.T) {
+		authorizers := []*AuthorizerNode{
+			{ID: "a1", URL: fakeAuthorizer(t, ticket, 0, http.StatusOK)},
+			{ID: "a2", URL: fakeAuthorizer(t, ticket, 0, http.StatusOK)},
+			{ID: "a3", URL: fakeAuthorizer(t, ticket, time.Minute, http.StatusOK)},
+		}
+
+		start := time.Now()
+		results := queryAllAuthorizers(authorizers, ticketHandler(), 2, nil)
+		require.Len(t, results, 2)
+		require.Less(t, int64(time.Since(start)), int64(AuthorizerQueryTimeout))
+	})
+
+	t.Run("Test_Duplicate_Authorizers", func(t *testing.T) {
+		url := fakeAuthorizer(t, ticket, 0, http.StatusOK)
+		authorizers := []*AuthorizerNode{{ID: "a1", URL: url}, {ID: "a1", URL: url}}
+
+		results := queryAllAuthorizers(authorizers, ticketHandler(), 0, nil)
+		require.Len(t, results, 1)
+	})
+}
+
+// fakeAuthorizersContract computes the message hash of the tickets like the
+// Authorizers contract, with the authorizers of keys.
+type fakeAuthorizersContract struct {
+	authorizers map[common.Address]bool
+}
+
+func (c *fakeAuthorizersContract) MessageHash(opts *bind.CallOpts, to common.Address, amount *big.Int, txid []byte, nonce *big.Int) ([32]byte, error) {
+	var hash [32]byte
+	copy(hash[:], crypto.Keccak256(to.Bytes(), common.LeftPadBytes(amount.Bytes(), 32), txid, common.LeftPadBytes(nonce.Bytes(), 32)))
+	return hash, nil
+}
+
+func (c *fakeAuthorizersContract) Authorizers(opts *bind.CallOpts, signer common.Address) (struct {
+	Index        *big.Int
+	IsAuthorizer bool
+}, error) {
+	return struct {
+		Index        *big.Int
+		IsAuthorizer bool
+	}{Index: big.NewInt(0), IsAuthorizer: c.authorizers[signer]}, nil
+}
+
+func (c *fakeAuthorizersContract) MinThreshold(opts *bind.CallOpts) (*big.Int, error) {
+	return big.NewInt(int64(len(c.authorizers))), nil
+}
+
+func signedTicket(t *testing.T, contract *fakeAuthorizersContract, key *ecdsa.PrivateKey, id string, amount int64) *ProofZCNBurn {
+	ticket := &ProofZCNBurn{AuthorizerID: id, TxnID: "burn", To: "0x00000000000000000000000000000000000000aa", Amount: amount, Nonce: 1}
+	hash, err := contract.MessageHash(nil, common.HexToAddress(ticket.To), big.NewInt(ticket.Amount), DefaultClientIDEncoder(ticket.TxnID), big.NewInt(ticket.Nonce))
+	require.NoError(t, err)
+	ticket.Signature, err = crypto.Sign(accounts.TextHash(hash[:]), key)
+	require.NoError(t, err)
+	ticket.Signature[crypto.RecoveryIDOffset] += 27
+	return ticket
+}
+
+func TestTicketVerifier(t *testing.T) {
+	key1, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key2, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	outsider, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	contract := &fakeAuthorizersContract{authorizers: map[common.Address]bool{
+		crypto.PubkeyToAddress(key1.PublicKey): true,
+		crypto.PubkeyToAddress(key2.PublicKey): true,
+	}}
+
+	t.Run("Test_Valid_Signatures", func(t *testing.T) {
+		v := newTicketVerifier(contract, "burn")
+		require.NoError(t, v.accept(signedTicket(t, contract, key1, "a1", 10)))
+		require.NoError(t, v.accept(signedTicket(t, contract, key2, "a2", 10)))
+		require.Equal(t, "a1", v.ticket.AuthorizerID)
+	})
+
+	t.Run("Test_Duplicate_Signer", func(t *testing.T) {
+		v := newTicketVerifier(contract, "burn")
+		require.NoError(t, v.accept(signedTicket(t, contract, key1, "a1", 10)))
+		require.Error(t, v.accept(signedTicket(t, contract, key1, "a2", 10)))
+	})
+
+	t.Run("Test_Not_An_Authorizer", func(t *testing.T) {
+		v := newTicketVerifier(contract, "burn")
+		require.Error(t, v.accept(signedTicket(t, contract, outsider, "a1", 10)))
+		require.Nil(t, v.ticket)
+	})
+
+	t.Run("Test_Different_Ticket", func(t *testing.T) {
+		v := newTicketVerifier(contract, "burn")
+		require.NoError(t, v.accept(signedTicket(t, contract, key1, "a1", 10)))
+		require.Error(t, v.accept(signedTicket(t, contract, key2, "a2", 11)))
+	})
+
+	t.Run("Test_Signature_Of_Another_Message", func(t *testing.T) {
+		v := newTicketVerifier(contract, "burn")
+		ticket := signedTicket(t, contract, key1, "a1", 10)
+		ticket.Amount = 11
+		require.Error(t, v.accept(ticket))
+	})
+
+	t.Run("Test_Another_Burn", func(t *testing.T) {
+		v := newTicketVerifier(contract, "other")
+		require.Error(t, v.accept(signedTicket(t, contract, key1, "a1", 10)))
+	})
+}
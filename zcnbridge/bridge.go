@@ -95,18 +95,20 @@ func (b *BridgeClient) IncreaseBurnerAllowance(ctx context.Context, amountWei We
 		return nil, errors.Wrap(err, "failed to pack arguments")
 	}
 
-	gasLimitUnits, err := etherClient.EstimateGas(ctx, eth.CallMsg{
+	gasLimitUnits, err := b.estimateGas(ctx, etherClient, eth.CallMsg{
 		To:   &tokenAddress,
 		From: fromAddress,
 		Data: pack,
 	})
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to estimate gas limit")
+		return nil, err
 	}
 
-	gasLimitUnits = addPercents(gasLimitUnits, 10).Uint64()
-
 	transactOpts := b.CreateSignedTransactionFromKeyStore(etherClient, gasLimitUnits)
+	if err := b.checkGasPrice(transactOpts.GasPrice); err != nil {
+		releaseNonce(transactOpts)
+		return nil, err
+	}
 
 	wzcnTokenInstance, err := erc20.NewERC20(tokenAddress, etherClient)
 	if err != nil {
@@ -122,6 +124,7 @@ func (b *BridgeClient) IncreaseBurnerAllowance(ctx context.Context, amountWei We
 
 	tran, err := wzcnTokenInstance.IncreaseAllowance(transactOpts, spenderAddress, amount)
 	if err != nil {
+		releaseNonce(transactOpts)
 		Logger.Error(
 			"IncreaseAllowance FAILED",
 			zap.String("token", tokenAddress.String()),
@@ -266,6 +269,7 @@ func (b *BridgeClient) MintWZCN(ctx context.Context, payload *ethereum.MintPaylo
 	var tran *types.Transaction
 	tran, err = bridgeInstance.Mint(transactOpts, toAddress, amount, zcnTxd, nonce, sigs)
 	if err != nil {
+		releaseNonce(transactOpts)
 		Logger.Error("Mint WZCN FAILED", zap.Error(err))
 		msg := "failed to execute MintWZCN transaction, amount = %s, ZCN TrxID = %s"
 		return nil, errors.Wrapf(err, msg, amount, zcnTxd)
@@ -311,6 +315,7 @@ func (b *BridgeClient) BurnWZCN(ctx context.Context, amountTokens uint64) (*type
 
 	tran, err := bridgeInstance.Burn(transactOpts, amount, clientID)
 	if err != nil {
+		releaseNonce(transactOpts)
 		msg := "failed to execute Burn WZCN transaction to ClientID = %s with amount = %s"
 		return nil, errors.Wrapf(err, msg, b.ClientID(), amount)
 	}
@@ -427,19 +432,20 @@ func (b *BridgeClient) prepareBridge(ctx context.Context, ethereumAddress, metho
 	//Gas limits in units
 	fromAddress := common.HexToAddress(ethereumAddress)
 
-	gasLimitUnits, err := etherClient.EstimateGas(ctx, eth.CallMsg{
+	gasLimitUnits, err := b.estimateGas(ctx, etherClient, eth.CallMsg{
 		To:   &contractAddress,
 		From: fromAddress,
 		Data: pack,
 	})
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to estimate gas")
+		return nil, nil, err
 	}
 
-	//Update gas limits + 10%
-	gasLimitUnits = addPercents(gasLimitUnits, 10).Uint64()
-
 	transactOpts := b.CreateSignedTransactionFromKeyStore(etherClient, gasLimitUnits)
+	if err := b.checkGasPrice(transactOpts.GasPrice); err != nil {
+		releaseNonce(transactOpts)
+		return nil, nil, err
+	}
 
 	// BridgeClient instance
 	bridgeInstance, err := binding.NewBridge(contractAddress, etherClient)
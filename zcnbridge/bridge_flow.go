@@ -0,0 +1,308 @@
+package zcnbridge
+
+import (
+	"context"
+	"time"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	"github.com/0chain/gosdk/zcnbridge/zcnsc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var (
+	// MintPayloadPollInterval is how often the authorizers are asked for
+	// their signatures of a burn until enough of them signed it.
+	MintPayloadPollInterval = 5 * time.Second
+
+	// EthereumConfirmations is the number of blocks, the block of a
+	// transaction included, to wait for before an Ethereum transaction is
+	// considered final.
+	EthereumConfirmations uint64 = 1
+)
+
+// BridgeDirection is the direction tokens are moved by the bridge.
+type BridgeDirection string
+
+const (
+	// ZCNToWZCN burns ZCN tokens and mints WZCN tokens.
+	ZCNToWZCN BridgeDirection = "zcn_to_wzcn"
+	// WZCNToZCN burns WZCN tokens and mints ZCN tokens.
+	WZCNToZCN BridgeDirection = "wzcn_to_zcn"
+)
+
+// BridgeReceipt is the record of the transactions moving tokens across the
+// bridge. When the move fails, it has the transactions sent until then, so
+// the mint can be resumed from the burn.
+type BridgeReceipt struct {
+	Direction BridgeDirection `json:"direction"`
+	Amount    uint64          `json:"amount"`
+	// AllowanceHash is the hash of the increase of the allowance of the
+	// bridge contract, for WZCNToZCN.
+	AllowanceHash string `json:"allowance_hash,omitempty"`
+	BurnHash      string `json:"burn_hash,omitempty"`
+	MintHash      string `json:"mint_hash,omitempty"`
+	// Authorizers are the IDs of the authorizers that signed the burn.
+	Authorizers []string `json:"authorizers,omitempty"`
+	// MintBlock is the Ethereum block of the mint, for ZCNToWZCN.
+	MintBlock uint64 `json:"mint_block,omitempty"`
+}
+
+// bridgeSteps are the calls moving tokens across the bridge, replaced in
+// tests.
+type bridgeSteps struct {
+	burnZCN                  func(ctx context.Context, amount uint64) (string, error)
+	queryEthereumMintPayload func(burnHash string) (*ethereum.MintPayload, error)
+	mintWZCN                 func(ctx context.Context, payload *ethereum.MintPayload) (*types.Transaction, error)
+
+	increaseAllowance      func(ctx context.Context, amount Wei) (*types.Transaction, error)
+	burnWZCN               func(ctx context.Context, amount uint64) (*types.Transaction, error)
+	queryZChainMintPayload func(burnHash string) (*zcnsc.MintPayload, error)
+	mintZCN                func(ctx context.Context, payload *zcnsc.MintPayload) (string, error)
+	verifyZCN              func(ctx context.Context, hash string) error
+
+	waitConfirmed func(ctx context.Context, tx *types.Transaction) (*TxStatus, error)
+	waitHash      func(ctx context.Context, hash common.Hash) (*TxStatus, error)
+
+	// store records the operations, optional.
+	store OperationStore
+}
+
+func (b *BridgeClient) steps() *bridgeSteps {
+	return &bridgeSteps{
+		burnZCN: func(ctx context.Context, amount uint64) (string, error) {
+			tx, err := b.BurnZCN(ctx, amount)
+			if tx == nil {
+				return "", err
+			}
+			return tx.Hash, err
+		},
+		queryEthereumMintPayload: b.QueryEthereumMintPayload,
+		mintWZCN:                 b.MintWZCN,
+		increaseAllowance:        b.IncreaseBurnerAllowance,
+		burnWZCN:                 b.BurnWZCN,
+		queryZChainMintPayload:   b.QueryZChainMintPayload,
+		mintZCN:                  b.MintZCN,
+		verifyZCN: func(ctx context.Context, hash string) error {
+			_, err := b.VerifyZCNTransaction(ctx, hash)
+			return err
+		},
+		waitConfirmed: b.waitConfirmed,
+		waitHash:      b.waitConfirmedHash,
+		store:         b.Operations,
+	}
+}
+
+// BurnZCNAndMintWZCN moves amount ZCN tokens to the Ethereum address of the
+// client: the ZCN tokens are burnt, the authorizers asked for their
+// signatures of the burn until the consensus threshold signed it, and the
+// WZCN tokens minted with them. It returns once the mint is confirmed, see
+// EthereumConfirmations, or ctx is done. The steps are recorded by
+// Operations, if set, see ResumePending.
+func (b *BridgeClient) BurnZCNAndMintWZCN(ctx context.Context, amount uint64) (*BridgeReceipt, error) {
+	return b.steps().burnZCNAndMintWZCN(ctx, amount)
+}
+
+// BurnWZCNAndMintZCN moves amount WZCN tokens to the 0chain wallet of the
+// client: the allowance of the bridge contract is increased by amount, the
+// WZCN tokens are burnt, the authorizers asked for their signatures of the
+// burn until the consensus threshold signed it, and the ZCN tokens minted
+// with them. It returns once the mint is verified, or ctx is done. The steps
+// are recorded by Operations, if set, see ResumePending.
+func (b *BridgeClient) BurnWZCNAndMintZCN(ctx context.Context, amount uint64) (*BridgeReceipt, error) {
+	return b.steps().burnWZCNAndMintZCN(ctx, amount)
+}
+
+func (s *bridgeSteps) burnZCNAndMintWZCN(ctx context.Context, amount uint64) (*BridgeReceipt, error) {
+	op := newBridgeOperation(ZCNToWZCN, amount)
+	s.save(op)
+	err := s.zcnToWZCN(ctx, op)
+	return op.Receipt, err
+}
+
+func (s *bridgeSteps) burnWZCNAndMintZCN(ctx context.Context, amount uint64) (*BridgeReceipt, error) {
+	op := newBridgeOperation(WZCNToZCN, amount)
+	s.save(op)
+	err := s.wzcnToZCN(ctx, op)
+	return op.Receipt, err
+}
+
+// zcnToWZCN runs the steps of op, a ZCNToWZCN operation, from its state.
+func (s *bridgeSteps) zcnToWZCN(ctx context.Context, op *BridgeOperation) error {
+	receipt := op.Receipt
+
+	if op.State == OpStarted {
+		burnHash, err := s.burnZCN(ctx, receipt.Amount)
+		receipt.BurnHash = burnHash
+		if err != nil {
+			return s.abandon(op, errors.Wrap(err, "failed to burn ZCN"))
+		}
+		s.advance(op, OpBurned)
+	}
+
+	if op.State == OpBurned {
+		var payload *ethereum.MintPayload
+		err := pollAuthorizers(ctx, func() (err error) {
+			payload, err = s.queryEthereumMintPayload(receipt.BurnHash)
+			return err
+		})
+		if err != nil {
+			return s.fail(op, errors.Wrapf(err, "failed to get the signatures of burn %s", receipt.BurnHash))
+		}
+		receipt.Authorizers = nil
+		for _, sig := range payload.Signatures {
+			receipt.Authorizers = append(receipt.Authorizers, sig.ID)
+		}
+		op.EthereumPayload = payload
+		s.advance(op, OpSigned)
+	}
+
+	var tx *types.Transaction
+	if op.State == OpSigned {
+		var err error
+		tx, err = s.mintWZCN(ctx, op.EthereumPayload)
+		if err != nil {
+			return s.fail(op, errors.Wrap(err, "failed to mint WZCN"))
+		}
+		receipt.MintHash = tx.Hash().Hex()
+		s.advance(op, OpMintSubmitted)
+	}
+
+	if op.State == OpMintSubmitted {
+		mined, err := s.wait(ctx, tx, receipt.MintHash)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to confirm mint %s", receipt.MintHash)
+			if errors.Is(err, ErrTransactionReverted) {
+				return s.abandon(op, err)
+			}
+			return s.fail(op, err)
+		}
+		receipt.MintBlock = mined.BlockNumber
+		s.advance(op, OpConfirmed)
+	}
+	return nil
+}
+
+// wzcnToZCN runs the steps of op, a WZCNToZCN operation, from its state.
+func (s *bridgeSteps) wzcnToZCN(ctx context.Context, op *BridgeOperation) error {
+	receipt := op.Receipt
+
+	if op.State == OpStarted {
+		var tx *types.Transaction
+		if receipt.AllowanceHash == "" {
+			var err error
+			tx, err = s.increaseAllowance(ctx, Wei(receipt.Amount))
+			if err != nil {
+				return s.abandon(op, errors.Wrap(err, "failed to increase the allowance of the bridge"))
+			}
+			receipt.AllowanceHash = tx.Hash().Hex()
+			s.save(op)
+		}
+		if _, err := s.wait(ctx, tx, receipt.AllowanceHash); err != nil {
+			return s.fail(op, errors.Wrapf(err, "failed to confirm allowance %s", receipt.AllowanceHash))
+		}
+		s.advance(op, OpAllowanceIncreased)
+	}
+
+	var tx *types.Transaction
+	if op.State == OpAllowanceIncreased {
+		var err error
+		tx, err = s.burnWZCN(ctx, receipt.Amount)
+		if err != nil {
+			return s.abandon(op, errors.Wrap(err, "failed to burn WZCN"))
+		}
+		receipt.BurnHash = tx.Hash().Hex()
+		s.advance(op, OpBurnSubmitted)
+	}
+
+	if op.State == OpBurnSubmitted {
+		if _, err := s.wait(ctx, tx, receipt.BurnHash); err != nil {
+			err = errors.Wrapf(err, "failed to confirm burn %s", receipt.BurnHash)
+			if errors.Is(err, ErrTransactionReverted) {
+				return s.abandon(op, err)
+			}
+			return s.fail(op, err)
+		}
+		s.advance(op, OpBurned)
+	}
+
+	if op.State == OpBurned {
+		var payload *zcnsc.MintPayload
+		err := pollAuthorizers(ctx, func() (err error) {
+			payload, err = s.queryZChainMintPayload(receipt.BurnHash)
+			return err
+		})
+		if err != nil {
+			return s.fail(op, errors.Wrapf(err, "failed to get the signatures of burn %s", receipt.BurnHash))
+		}
+		receipt.Authorizers = nil
+		for _, sig := range payload.Signatures {
+			receipt.Authorizers = append(receipt.Authorizers, sig.ID)
+		}
+		op.ZChainPayload = payload
+		s.advance(op, OpSigned)
+	}
+
+	if op.State == OpSigned {
+		mintHash, err := s.mintZCN(ctx, op.ZChainPayload)
+		receipt.MintHash = mintHash
+		if err != nil {
+			return s.fail(op, errors.Wrap(err, "failed to mint ZCN"))
+		}
+		s.advance(op, OpMintSubmitted)
+	}
+
+	if op.State == OpMintSubmitted {
+		if err := s.verifyZCN(ctx, receipt.MintHash); err != nil {
+			return s.fail(op, errors.Wrapf(err, "failed to verify mint %s", receipt.MintHash))
+		}
+		s.advance(op, OpConfirmed)
+	}
+	return nil
+}
+
+// wait waits for the Ethereum transaction of hash to be confirmed, tx if it
+// was sent by this run of the operation.
+func (s *bridgeSteps) wait(ctx context.Context, tx *types.Transaction, hash string) (*TxStatus, error) {
+	if tx != nil {
+		return s.waitConfirmed(ctx, tx)
+	}
+	return s.waitHash(ctx, common.HexToHash(hash))
+}
+
+// pollAuthorizers calls query every MintPayloadPollInterval until it
+// succeeds, the authorizers signing a burn once they saw it, or ctx is done.
+func pollAuthorizers(ctx context.Context, query func() error) error {
+	for {
+		err := query()
+		if err == nil {
+			return nil
+		}
+		Logger.Info("waiting for the signatures of the authorizers", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), err.Error())
+		case <-time.After(MintPayloadPollInterval):
+		}
+	}
+}
+
+// waitConfirmed waits for tx to be mined successfully and confirmed by the
+// Confirmations of the chain, see TrackTransaction.
+func (b *BridgeClientConfig) waitConfirmed(ctx context.Context, tx *types.Transaction) (*TxStatus, error) {
+	return b.waitConfirmedHash(ctx, tx.Hash())
+}
+
+// waitConfirmedHash is waitConfirmed for the transaction of hash.
+func (b *BridgeClientConfig) waitConfirmedHash(ctx context.Context, hash common.Hash) (*TxStatus, error) {
+	etherClient, err := b.CreateEthClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etherClient")
+	}
+	defer etherClient.Close()
+
+	return waitStatus(ctx, trackTransaction(ctx, etherClient, hash, int(b.confirmations())))
+}
@@ -0,0 +1,121 @@
+package zcnbridge
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	"github.com/0chain/gosdk/zcnbridge/zcnsc"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeTx(nonce uint64) *types.Transaction {
+	return types.NewTx(&types.LegacyTx{Nonce: nonce})
+}
+
+func fakeSteps() *bridgeSteps {
+	return &bridgeSteps{
+		burnZCN: func(ctx context.Context, amount uint64) (string, error) { return "zcn-burn", nil },
+		queryEthereumMintPayload: func(burnHash string) (*ethereum.MintPayload, error) {
+			return &ethereum.MintPayload{ZCNTxnID: burnHash, Signatures: []*ethereum.AuthorizerSignature{{ID: "a1"}, {ID: "a2"}}}, nil
+		},
+		mintWZCN: func(ctx context.Context, payload *ethereum.MintPayload) (*types.Transaction, error) {
+			return fakeTx(1), nil
+		},
+		increaseAllowance: func(ctx context.Context, amount Wei) (*types.Transaction, error) { return fakeTx(2), nil },
+		burnWZCN:          func(ctx context.Context, amount uint64) (*types.Transaction, error) { return fakeTx(3), nil },
+		queryZChainMintPayload: func(burnHash string) (*zcnsc.MintPayload, error) {
+			return &zcnsc.MintPayload{EthereumTxnID: burnHash, Signatures: []*zcnsc.AuthorizerSignature{{ID: "a1"}}}, nil
+		},
+		mintZCN:   func(ctx context.Context, payload *zcnsc.MintPayload) (string, error) { return "zcn-mint", nil },
+		verifyZCN: func(ctx context.Context, hash string) error { return nil },
+		waitConfirmed: func(ctx context.Context, tx *types.Transaction) (*TxStatus, error) {
+			return &TxStatus{State: TxConfirmed, BlockNumber: 42}, nil
+		},
+	}
+}
+
+func TestBurnZCNAndMintWZCN(t *testing.T) {
+	prev := MintPayloadPollInterval
+	MintPayloadPollInterval = time.Millisecond
+	defer func() { MintPayloadPollInterval = prev }()
+
+	t.Run("Test_Success_After_Polling", func(t *testing.T) {
+		s := fakeSteps()
+		query := s.queryEthereumMintPayload
+		calls := 0
+		s.queryEthereumMintPayload = func(burnHash string) (*ethereum.MintPayload, error) {
+			if calls++; calls < 3 {
+				return nil, errors.New("failed to reach the quorum")
+			}
+			return query(burnHash)
+		}
+
+		receipt, err := s.burnZCNAndMintWZCN(context.Background(), 100)
+		require.NoError(t, err)
+		require.Equal(t, &BridgeReceipt{
+			Direction:   ZCNToWZCN,
+			Amount:      100,
+			BurnHash:    "zcn-burn",
+			MintHash:    fakeTx(1).Hash().Hex(),
+			Authorizers: []string{"a1", "a2"},
+			MintBlock:   42,
+		}, receipt)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("Test_Signatures_Timeout", func(t *testing.T) {
+		s := fakeSteps()
+		s.queryEthereumMintPayload = func(burnHash string) (*ethereum.MintPayload, error) {
+			return nil, errors.New("failed to reach the quorum")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		receipt, err := s.burnZCNAndMintWZCN(ctx, 100)
+		require.Error(t, err)
+		require.Equal(t, "zcn-burn", receipt.BurnHash, "the burn is kept to resume the mint")
+		require.Empty(t, receipt.MintHash)
+	})
+
+	t.Run("Test_Mint_Reverted", func(t *testing.T) {
+		s := fakeSteps()
+		s.waitConfirmed = func(ctx context.Context, tx *types.Transaction) (*TxStatus, error) {
+			return nil, errors.New("transaction reverted")
+		}
+
+		receipt, err := s.burnZCNAndMintWZCN(context.Background(), 100)
+		require.Error(t, err)
+		require.Equal(t, fakeTx(1).Hash().Hex(), receipt.MintHash)
+	})
+}
+
+func TestBurnWZCNAndMintZCN(t *testing.T) {
+	t.Run("Test_Success", func(t *testing.T) {
+		receipt, err := fakeSteps().burnWZCNAndMintZCN(context.Background(), 100)
+		require.NoError(t, err)
+		require.Equal(t, &BridgeReceipt{
+			Direction:     WZCNToZCN,
+			Amount:        100,
+			AllowanceHash: fakeTx(2).Hash().Hex(),
+			BurnHash:      fakeTx(3).Hash().Hex(),
+			MintHash:      "zcn-mint",
+			Authorizers:   []string{"a1"},
+		}, receipt)
+	})
+
+	t.Run("Test_Burn_Failed", func(t *testing.T) {
+		s := fakeSteps()
+		s.burnWZCN = func(ctx context.Context, amount uint64) (*types.Transaction, error) {
+			return nil, errors.New("insufficient allowance")
+		}
+
+		receipt, err := s.burnWZCNAndMintZCN(context.Background(), 100)
+		require.Error(t, err)
+		require.Equal(t, fakeTx(2).Hash().Hex(), receipt.AllowanceHash)
+		require.Empty(t, receipt.BurnHash)
+	})
+}
@@ -2,7 +2,6 @@ package zcnbridge
 
 import (
 	"fmt"
-	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -57,17 +56,6 @@ func ConfirmEthereumTransaction(hash string, times int, duration time.Duration)
 	return res, nil
 }
 
-func addPercents(gasLimitUnits uint64, percents int) *big.Int {
-	gasLimitBig := big.NewInt(int64(gasLimitUnits))
-	factorBig := big.NewInt(int64(percents))
-	deltaBig := gasLimitBig.Div(gasLimitBig, factorBig)
-
-	origin := big.NewInt(int64(gasLimitUnits))
-	gasLimitBig = origin.Add(origin, deltaBig)
-
-	return gasLimitBig
-}
-
 func CreateHash(message string) common.Hash {
 	data := []byte(message)
 	hash := crypto.Keccak256Hash(data)
@@ -39,19 +39,20 @@ func (b *BridgeOwner) prepareAuthorizers(ctx context.Context, method string, par
 	from := common.HexToAddress(b.EthereumAddress)
 
 	// Gas limits in units
-	gasLimitUnits, err := etherClient.EstimateGas(ctx, eth.CallMsg{
+	gasLimitUnits, err := b.estimateGas(ctx, etherClient, eth.CallMsg{
 		To:   &contractAddress,
 		From: from,
 		Data: pack,
 	})
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to estimate gas")
+		return nil, nil, err
 	}
 
-	// Update gas limits + 10%
-	gasLimitUnits = addPercents(gasLimitUnits, 10).Uint64()
-
 	transactOpts := b.CreateSignedTransactionFromKeyStore(etherClient, gasLimitUnits)
+	if err := b.checkGasPrice(transactOpts.GasPrice); err != nil {
+		releaseNonce(transactOpts)
+		return nil, nil, err
+	}
 
 	// Authorizers instance
 	authorizersInstance, err := authorizers.NewAuthorizers(contractAddress, etherClient)
@@ -71,6 +72,7 @@ func (b *BridgeOwner) AddEthereumAuthorizer(ctx context.Context, address common.
 
 	tran, err := instance.AddAuthorizers(transactOpts, address)
 	if err != nil {
+		releaseNonce(transactOpts)
 		msg := "failed to execute AddAuthorizers transaction to ClientID = %s with amount = %s"
 		return nil, errors.Wrapf(err, msg, b.ClientID(), address.String())
 	}
@@ -87,6 +89,7 @@ func (b *BridgeOwner) RemoveEthereumAuthorizer(ctx context.Context, address comm
 
 	tran, err := instance.RemoveAuthorizers(transactOpts, address)
 	if err != nil {
+		releaseNonce(transactOpts)
 		msg := "failed to execute RemoveAuthorizers transaction to ClientID = %s with amount = %s"
 		return nil, errors.Wrapf(err, msg, b.ClientID(), address.String())
 	}
@@ -0,0 +1,119 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// UnmintedBurn is a ZCN burn that has no WZCN mint on Ethereum.
+type UnmintedBurn struct {
+	Ticket *BurnTicket `json:"ticket"`
+	// Payload mints the burn with MintWZCN. It's nil if the authorizers
+	// couldn't be asked for their signatures, see Error.
+	Payload *ethereum.MintPayload `json:"payload,omitempty"`
+	Error   string                `json:"error,omitempty"`
+}
+
+// maxBurnTicketPages bounds the pages of burn tickets FindUnmintedBurns lists.
+const maxBurnTicketPages = 1000
+
+// burnRecovery are the calls finding the unminted burns, replaced in tests.
+type burnRecovery struct {
+	listTickets  func(q BurnTicketsQuery) (*BurnTicketsPage, error)
+	nonceMinted  func(ctx context.Context, to common.Address) (*big.Int, error)
+	queryPayload func(burnHash string) (*ethereum.MintPayload, error)
+}
+
+// FindUnmintedBurns returns the ZCN burns to the Ethereum address of the
+// client that weren't minted, e.g. as the client stopped between the burn
+// and the mint, with the payloads to mint them. A burn is minted once the
+// bridge contract minted its nonce for its receiver, the nonces being minted
+// in order.
+func (b *BridgeClient) FindUnmintedBurns(ctx context.Context) ([]*UnmintedBurn, error) {
+	etherClient, err := b.CreateEthClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etherClient")
+	}
+	defer etherClient.Close()
+
+	caller, err := bridge.NewBridgeCaller(common.HexToAddress(b.BridgeAddress), etherClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create bridge instance")
+	}
+
+	r := &burnRecovery{
+		listTickets: b.QueryNotProcessedZCNBurnTickets,
+		nonceMinted: func(ctx context.Context, to common.Address) (*big.Int, error) {
+			return caller.GetUserNonceMinted(&bind.CallOpts{Context: ctx}, to)
+		},
+		queryPayload: b.QueryEthereumMintPayload,
+	}
+	return r.find(ctx, b.EthereumAddress)
+}
+
+func (r *burnRecovery) find(ctx context.Context, ethereumAddress string) ([]*UnmintedBurn, error) {
+	var (
+		tickets   []*BurnTicket
+		firstSeen = make(map[int64]bool)
+	)
+	q := BurnTicketsQuery{EthereumAddress: ethereumAddress}
+	for pages := 0; ; pages++ {
+		if pages == maxBurnTicketPages {
+			return nil, errors.Errorf("more than %d pages of burn tickets", maxBurnTicketPages)
+		}
+		page, err := r.listTickets(q)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list the burn tickets")
+		}
+		if len(page.Tickets) == 0 {
+			break
+		}
+		// a sharder ignoring the offset returns the same page again
+		if firstSeen[page.Tickets[0].Nonce] {
+			break
+		}
+		firstSeen[page.Tickets[0].Nonce] = true
+		tickets = append(tickets, page.Tickets...)
+		if !page.HasMore {
+			break
+		}
+		q.Offset += len(page.Tickets)
+	}
+
+	minted := make(map[common.Address]*big.Int)
+	var burns []*UnmintedBurn
+	for _, ticket := range tickets {
+		if err := ctx.Err(); err != nil {
+			return burns, err
+		}
+
+		to := common.HexToAddress(ticket.EthereumAddress)
+		nonce, ok := minted[to]
+		if !ok {
+			var err error
+			if nonce, err = r.nonceMinted(ctx, to); err != nil {
+				return burns, errors.Wrapf(err, "failed to get the nonce minted for %s", to.Hex())
+			}
+			minted[to] = nonce
+		}
+		if big.NewInt(ticket.Nonce).Cmp(nonce) <= 0 {
+			continue
+		}
+
+		burn := &UnmintedBurn{Ticket: ticket}
+		payload, err := r.queryPayload(ticket.Hash)
+		if err != nil {
+			burn.Error = err.Error()
+		} else {
+			burn.Payload = payload
+		}
+		burns = append(burns, burn)
+	}
+	return burns, nil
+}
@@ -0,0 +1,95 @@
+package zcnbridge
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBurnRecoveryFind(t *testing.T) {
+	tickets := []*BurnTicket{
+		{Hash: "b1", Nonce: 1, EthereumAddress: "0xa"},
+		{Hash: "b2", Nonce: 2, EthereumAddress: "0xa"},
+		{Hash: "b3", Nonce: 3, EthereumAddress: "0xa"},
+		{Hash: "b4", Nonce: 4, EthereumAddress: "0xa"},
+	}
+	newRecovery := func(minted int64, failing string) (*burnRecovery, *int) {
+		nonceCalls := new(int)
+		return &burnRecovery{
+			listTickets: func(q BurnTicketsQuery) (*BurnTicketsPage, error) {
+				require.Equal(t, "0xa", q.EthereumAddress)
+				q.Limit = 3
+				return q.page(tickets), nil
+			},
+			nonceMinted: func(ctx context.Context, to common.Address) (*big.Int, error) {
+				*nonceCalls++
+				return big.NewInt(minted), nil
+			},
+			queryPayload: func(burnHash string) (*ethereum.MintPayload, error) {
+				if burnHash == failing {
+					return nil, errors.New("failed to reach the quorum")
+				}
+				return &ethereum.MintPayload{ZCNTxnID: burnHash}, nil
+			},
+		}, nonceCalls
+	}
+
+	t.Run("Test_Unminted_Nonces", func(t *testing.T) {
+		r, nonceCalls := newRecovery(2, "")
+		burns, err := r.find(context.Background(), "0xa")
+		require.NoError(t, err)
+		require.Len(t, burns, 2)
+		require.Equal(t, "b3", burns[0].Ticket.Hash)
+		require.Equal(t, "b3", burns[0].Payload.ZCNTxnID)
+		require.Equal(t, "b4", burns[1].Ticket.Hash)
+		require.Equal(t, 1, *nonceCalls)
+	})
+
+	t.Run("Test_All_Minted", func(t *testing.T) {
+		r, _ := newRecovery(4, "")
+		burns, err := r.find(context.Background(), "0xa")
+		require.NoError(t, err)
+		require.Empty(t, burns)
+	})
+
+	t.Run("Test_Signatures_Unavailable", func(t *testing.T) {
+		r, _ := newRecovery(3, "b4")
+		burns, err := r.find(context.Background(), "0xa")
+		require.NoError(t, err)
+		require.Len(t, burns, 1)
+		require.Nil(t, burns[0].Payload)
+		require.NotEmpty(t, burns[0].Error)
+	})
+
+	t.Run("Test_Offset_Ignored", func(t *testing.T) {
+		r, _ := newRecovery(2, "")
+		var calls int
+		r.listTickets = func(q BurnTicketsQuery) (*BurnTicketsPage, error) {
+			calls++
+			// the same first page whatever the offset
+			return &BurnTicketsPage{Tickets: tickets[:3], HasMore: true}, nil
+		}
+		burns, err := r.find(context.Background(), "0xa")
+		require.NoError(t, err)
+		require.Equal(t, 2, calls)
+		require.Len(t, burns, 1)
+		require.Equal(t, "b3", burns[0].Ticket.Hash)
+	})
+
+	t.Run("Test_Too_Many_Pages", func(t *testing.T) {
+		r, _ := newRecovery(0, "")
+		var nonce int64
+		r.listTickets = func(q BurnTicketsQuery) (*BurnTicketsPage, error) {
+			nonce++
+			return &BurnTicketsPage{Tickets: []*BurnTicket{{Nonce: nonce, EthereumAddress: "0xa"}}, HasMore: true}, nil
+		}
+		_, err := r.find(context.Background(), "0xa")
+		require.Error(t, err)
+		require.Equal(t, int64(maxBurnTicketPages), nonce)
+	})
+}
@@ -0,0 +1,207 @@
+package zcnbridge
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/0chain/gosdk/zcnbridge/errors"
+	"github.com/0chain/gosdk/zcnbridge/http"
+	"github.com/0chain/gosdk/zcnbridge/wallet"
+	"github.com/0chain/gosdk/zcncore"
+)
+
+// defaultBurnTicketsLimit is the page size used when BurnTicketsQuery.Limit is not set
+const defaultBurnTicketsLimit = 50
+
+type (
+	// BurnTicket is a ZCN burn that has not been minted on Ethereum yet
+	BurnTicket struct {
+		Hash            string `json:"hash"`
+		Amount          int64  `json:"amount"`
+		Nonce           int64  `json:"nonce"`
+		EthereumAddress string `json:"ethereum_address"`
+		Timestamp       int64  `json:"timestamp"`
+	}
+
+	// BurnTicketsQuery filters and pages the not processed burn tickets.
+	// Zero values disable the corresponding filter.
+	BurnTicketsQuery struct {
+		// EthereumAddress receiver of the burn
+		EthereumAddress string
+		// Nonce returns tickets with a nonce strictly greater than this one
+		Nonce int64
+		// From, To bound the burn timestamp, inclusive
+		From time.Time
+		To   time.Time
+		// Offset, Limit page the result ordered by nonce. The paging is done on
+		// the client, sharders return all the tickets matching the filters.
+		Offset int
+		Limit  int
+	}
+
+	// BurnTicketsPage is a page of not processed burn tickets
+	BurnTicketsPage struct {
+		Tickets []*BurnTicket `json:"tickets"`
+		Offset  int           `json:"offset"`
+		Limit   int           `json:"limit"`
+		HasMore bool          `json:"has_more"`
+	}
+
+	// AuthorizerBurnStatus reports whether an authorizer has signed a burn ticket
+	AuthorizerBurnStatus struct {
+		AuthorizerID string `json:"authorizer_id"`
+		URL          string `json:"url"`
+		Signed       bool   `json:"signed"`
+		Error        string `json:"error,omitempty"`
+	}
+)
+
+func (q *BurnTicketsQuery) limit() int {
+	if q.Limit <= 0 {
+		return defaultBurnTicketsLimit
+	}
+	return q.Limit
+}
+
+func (q *BurnTicketsQuery) params() http.Params {
+	params := http.Params{}
+	if q.EthereumAddress != "" {
+		params["ethereum_address"] = q.EthereumAddress
+	}
+	if q.Nonce > 0 {
+		params["nonce"] = strconv.FormatInt(q.Nonce, 10)
+	}
+	if !q.From.IsZero() {
+		params["from"] = strconv.FormatInt(q.From.Unix(), 10)
+	}
+	if !q.To.IsZero() {
+		params["to"] = strconv.FormatInt(q.To.Unix(), 10)
+	}
+	return params
+}
+
+// page applies the filters on tickets and cuts the requested page. The
+// filters are applied again as sharders may ignore some of them.
+func (q *BurnTicketsQuery) page(tickets []*BurnTicket) *BurnTicketsPage {
+	filtered := tickets[:0:0]
+	for _, t := range tickets {
+		if q.EthereumAddress != "" && t.EthereumAddress != q.EthereumAddress {
+			continue
+		}
+		if t.Nonce <= q.Nonce {
+			continue
+		}
+		if !q.From.IsZero() && t.Timestamp < q.From.Unix() {
+			continue
+		}
+		if !q.To.IsZero() && t.Timestamp > q.To.Unix() {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Nonce < filtered[j].Nonce
+	})
+
+	limit := q.limit()
+	if q.Offset >= len(filtered) {
+		filtered = nil
+	} else {
+		filtered = filtered[q.Offset:]
+	}
+	page := &BurnTicketsPage{Offset: q.Offset, Limit: limit}
+	if len(filtered) > limit {
+		filtered, page.HasMore = filtered[:limit], true
+	}
+	page.Tickets = filtered
+	return page
+}
+
+// GetNotProcessedZCNBurnTickets returns the raw not processed burn tickets matching the query
+func GetNotProcessedZCNBurnTickets(q BurnTicketsQuery, cb zcncore.GetInfoCallback) (err error) {
+	err = zcncore.CheckConfig()
+	if err != nil {
+		return err
+	}
+
+	go http.MakeSCRestAPICall(
+		zcncore.OpZCNSCGetNotProcessedBurnTickets,
+		http.PathGetNotProcessedBurnTickets,
+		q.params(),
+		cb,
+	)
+
+	return
+}
+
+// QueryNotProcessedZCNBurnTickets returns a page of the ZCN burn tickets not minted yet
+func (b *BridgeClient) QueryNotProcessedZCNBurnTickets(q BurnTicketsQuery) (*BurnTicketsPage, error) {
+	var (
+		tickets []*BurnTicket
+		cb      = wallet.NewZCNStatus(&tickets)
+	)
+
+	cb.Begin()
+
+	if err := GetNotProcessedZCNBurnTickets(q, cb); err != nil {
+		return nil, err
+	}
+
+	if err := cb.Wait(); err != nil {
+		return nil, err
+	}
+
+	return q.page(tickets), nil
+}
+
+// QueryZCNBurnTicketStatus asks every authorizer whether it has signed the burn ticket of zchainBurnHash
+func (b *BridgeClient) QueryZCNBurnTicketStatus(zchainBurnHash string) ([]*AuthorizerBurnStatus, error) {
+	client = http.CleanClient()
+	authorizers, err := getAuthorizers()
+
+	if err != nil || len(authorizers) == 0 {
+		return nil, errors.Wrap("get_authorizers", "failed to get authorizers", err)
+	}
+
+	handler := &requestHandler{
+		path:   wallet.BurnNativeTicketPath,
+		values: map[string]string{"hash": zchainBurnHash},
+		bodyDecoder: func(body []byte) (JobResult, error) {
+			ev := &ProofZCNBurn{}
+			err := json.Unmarshal(body, ev)
+			return ev, err
+		},
+	}
+
+	responseChannel := make(responseChannelType, len(authorizers))
+	for _, authorizer := range authorizers {
+		go queryAuthorizer(context.Background(), authorizer, handler, responseChannel)
+	}
+
+	statuses := make(map[string]*AuthorizerBurnStatus, len(authorizers))
+	for range authorizers {
+		resp := <-responseChannel
+		status := &AuthorizerBurnStatus{AuthorizerID: resp.AuthorizerID}
+		if resp.error != nil {
+			status.Error = resp.error.Error()
+		} else if ticket, ok := resp.event.(*ProofZCNBurn); ok {
+			status.Signed = len(ticket.Signature) > 0
+		}
+		statuses[resp.AuthorizerID] = status
+	}
+
+	result := make([]*AuthorizerBurnStatus, 0, len(authorizers))
+	for _, authorizer := range authorizers {
+		status, ok := statuses[authorizer.ID]
+		if !ok {
+			status = &AuthorizerBurnStatus{AuthorizerID: authorizer.ID, Error: "no response"}
+		}
+		status.URL = authorizer.URL
+		result = append(result, status)
+	}
+
+	return result, nil
+}
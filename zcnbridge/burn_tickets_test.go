@@ -0,0 +1,73 @@
+package zcnbridge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBurnTicketsQuery_page(t *testing.T) {
+	now := time.Now()
+	tickets := []*BurnTicket{
+		{Hash: "5", Nonce: 5, EthereumAddress: "0xa", Timestamp: now.Unix()},
+		{Hash: "1", Nonce: 1, EthereumAddress: "0xa", Timestamp: now.Add(-2 * time.Hour).Unix()},
+		{Hash: "3", Nonce: 3, EthereumAddress: "0xb", Timestamp: now.Unix()},
+		{Hash: "2", Nonce: 2, EthereumAddress: "0xa", Timestamp: now.Unix()},
+		{Hash: "4", Nonce: 4, EthereumAddress: "0xa", Timestamp: now.Unix()},
+	}
+	hashes := func(p *BurnTicketsPage) (hs []string) {
+		for _, t := range p.Tickets {
+			hs = append(hs, t.Hash)
+		}
+		return
+	}
+
+	tests := []struct {
+		name    string
+		query   BurnTicketsQuery
+		want    []string
+		hasMore bool
+	}{
+		{
+			name:  "Test_All_Sorted_By_Nonce",
+			query: BurnTicketsQuery{},
+			want:  []string{"1", "2", "3", "4", "5"},
+		},
+		{
+			name:  "Test_Address_And_Time_Range",
+			query: BurnTicketsQuery{EthereumAddress: "0xa", From: now.Add(-time.Hour)},
+			want:  []string{"2", "4", "5"},
+		},
+		{
+			name:    "Test_Client_Side_Page",
+			query:   BurnTicketsQuery{Offset: 1, Limit: 2},
+			want:    []string{"2", "3"},
+			hasMore: true,
+		},
+		{
+			name:  "Test_Past_Last_Page",
+			query: BurnTicketsQuery{Offset: 10, Limit: 2},
+		},
+		{
+			name:  "Test_Page_After_Nonce",
+			query: BurnTicketsQuery{Offset: 2, Limit: 4, Nonce: 1},
+			want:  []string{"4", "5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := tt.query.page(tickets)
+			require.Equal(t, tt.want, hashes(page))
+			require.Equal(t, tt.hasMore, page.HasMore)
+		})
+	}
+}
+
+func TestBurnTicketsQuery_params(t *testing.T) {
+	params := (&BurnTicketsQuery{EthereumAddress: "0xa", Offset: 2, Limit: 4}).params()
+	require.Equal(t, "0xa", params["ethereum_address"])
+	require.NotContains(t, params, "offset", "the tickets are paged on the client")
+	require.NotContains(t, params, "limit")
+}
@@ -0,0 +1,144 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrChainIDMismatch is returned when the Ethereum node isn't a node of the
+// chain of BridgeConfig.ChainID.
+var ErrChainIDMismatch = errors.New("chain ID mismatch")
+
+// Chain is the configuration of the bridge on an EVM chain. Its settings are
+// the defaults of the clients selecting the chain, see BridgeConfig.ChainID,
+// the settings of the client configuration taking precedence.
+type Chain struct {
+	ID   int64
+	Name string
+	// Contracts deployed on the chain, empty where the bridge isn't
+	// deployed, or at addresses only the client configuration knows.
+	Contracts ContractsRegistry
+	// GasMultiplier and MaxGasPrice are the gas strategy of the chain, see
+	// EthereumConfig.
+	GasMultiplier float64
+	MaxGasPrice   uint64
+	// Confirmations are the blocks a transaction is final after, depending
+	// on the reorgs of the chain.
+	Confirmations uint64
+	// BlockTime is the average time between two blocks.
+	BlockTime time.Duration
+}
+
+var chains = struct {
+	sync.RWMutex
+	byID map[int64]Chain
+}{byID: map[int64]Chain{
+	1:        {ID: 1, Name: "ethereum", Confirmations: 12, BlockTime: 12 * time.Second},
+	5:        {ID: 5, Name: "goerli", Confirmations: 3, BlockTime: 12 * time.Second},
+	11155111: {ID: 11155111, Name: "sepolia", Confirmations: 3, BlockTime: 12 * time.Second},
+	137:      {ID: 137, Name: "polygon", GasMultiplier: 1.2, Confirmations: 128, BlockTime: 2 * time.Second},
+	80001:    {ID: 80001, Name: "mumbai", GasMultiplier: 1.2, Confirmations: 32, BlockTime: 2 * time.Second},
+	56:       {ID: 56, Name: "bsc", Confirmations: 15, BlockTime: 3 * time.Second},
+	97:       {ID: 97, Name: "bsc-testnet", Confirmations: 5, BlockTime: 3 * time.Second},
+}}
+
+// RegisterChain adds chain to the registry, replacing the chain of its ID,
+// e.g. to set the addresses of the contracts deployed on it.
+func RegisterChain(chain Chain) {
+	chains.Lock()
+	defer chains.Unlock()
+	chains.byID[chain.ID] = chain
+}
+
+// LookupChain returns the chain of id, false if it isn't registered.
+func LookupChain(id int64) (Chain, bool) {
+	chains.RLock()
+	defer chains.RUnlock()
+	chain, ok := chains.byID[id]
+	return chain, ok
+}
+
+// ListChains returns the registered chains, ordered by ID.
+func ListChains() []Chain {
+	chains.RLock()
+	defer chains.RUnlock()
+	list := make([]Chain, 0, len(chains.byID))
+	for _, chain := range chains.byID {
+		list = append(list, chain)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// applyChain sets the settings of cfg not set to the settings of the
+// registered chain of chainID, if any.
+func applyChain(cfg *BridgeClientConfig, chainID int64) {
+	chain, ok := LookupChain(chainID)
+	if !ok {
+		return
+	}
+	if cfg.BridgeAddress == "" {
+		cfg.BridgeAddress = chain.Contracts.BridgeAddress
+	}
+	if cfg.WzcnAddress == "" {
+		cfg.WzcnAddress = chain.Contracts.WzcnAddress
+	}
+	if cfg.AuthorizersAddress == "" {
+		cfg.AuthorizersAddress = chain.Contracts.AuthorizersAddress
+	}
+	if cfg.GasMultiplier == 0 {
+		cfg.GasMultiplier = chain.GasMultiplier
+	}
+	if cfg.MaxGasPrice == 0 {
+		cfg.MaxGasPrice = chain.MaxGasPrice
+	}
+	if cfg.Confirmations == 0 {
+		cfg.Confirmations = chain.Confirmations
+	}
+	if cfg.BlockTime == 0 {
+		cfg.BlockTime = chain.BlockTime
+	}
+}
+
+// confirmations returns the Confirmations of the chain, EthereumConfirmations
+// if they aren't set.
+func (c *EthereumConfig) confirmations() uint64 {
+	if c.Confirmations == 0 {
+		return EthereumConfirmations
+	}
+	return c.Confirmations
+}
+
+// ValidateChain checks the Ethereum node is a node of the chain of
+// BridgeConfig.ChainID, so the transactions aren't signed for another chain.
+// Any chain is valid if ChainID isn't set.
+func (b *BridgeClient) ValidateChain(ctx context.Context) error {
+	if b.BridgeConfig == nil || b.ChainID == 0 {
+		return nil
+	}
+	etherClient, err := b.CreateEthClient()
+	if err != nil {
+		return errors.Wrap(err, "failed to create etherClient")
+	}
+	defer etherClient.Close()
+
+	chainID, err := etherClient.ChainID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get chain ID")
+	}
+	return checkChainID(chainID, b.ChainID)
+}
+
+// checkChainID fails with ErrChainIDMismatch if chainID, of the node, isn't
+// want.
+func checkChainID(chainID *big.Int, want int64) error {
+	if !chainID.IsInt64() || chainID.Int64() != want {
+		return errors.Wrapf(ErrChainIDMismatch, "node of chain %s, configured for chain %d", chainID, want)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package zcnbridge
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChains(t *testing.T) {
+	t.Run("Test_Chain_Defaults", func(t *testing.T) {
+		cfg := &BridgeClientConfig{EthereumConfig: EthereumConfig{GasMultiplier: 1.5}}
+		applyChain(cfg, 137)
+
+		require.EqualValues(t, 128, cfg.confirmations())
+		require.Equal(t, 2*time.Second, cfg.BlockTime)
+		// the settings of the client take precedence
+		require.Equal(t, 1.5, cfg.GasMultiplier)
+	})
+
+	t.Run("Test_Unknown_Chain", func(t *testing.T) {
+		cfg := &BridgeClientConfig{}
+		applyChain(cfg, 424242)
+		require.Equal(t, EthereumConfirmations, cfg.confirmations())
+	})
+
+	t.Run("Test_Register_Chain", func(t *testing.T) {
+		RegisterChain(Chain{ID: 31337, Name: "local", Contracts: ContractsRegistry{BridgeAddress: "0x1"}, Confirmations: 1})
+		defer func() {
+			chains.Lock()
+			delete(chains.byID, 31337)
+			chains.Unlock()
+		}()
+
+		cfg := &BridgeClientConfig{}
+		applyChain(cfg, 31337)
+		require.Equal(t, "0x1", cfg.BridgeAddress)
+
+		chain, ok := LookupChain(31337)
+		require.True(t, ok)
+		require.Equal(t, "local", chain.Name)
+	})
+
+	t.Run("Test_Check_Chain_ID", func(t *testing.T) {
+		require.NoError(t, checkChainID(big.NewInt(56), 56))
+		require.ErrorIs(t, checkChainID(big.NewInt(97), 56), ErrChainIDMismatch)
+	})
+}
@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"time"
 
 	"github.com/0chain/gosdk/core/common"
 	"github.com/0chain/gosdk/core/zcncrypto"
 	"github.com/0chain/gosdk/zcnbridge/log"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/spf13/viper"
 )
 
@@ -33,15 +35,34 @@ type ContractsRegistry struct {
 
 type BridgeConfig struct {
 	ConsensusThreshold float64
+	// ChainID selects the chain of the bridge, its registered settings being
+	// the defaults of the client, see RegisterChain. The Ethereum node must
+	// be a node of the chain, see ValidateChain (default = 0, any chain)
+	ChainID int64
 }
 
 type EthereumConfig struct {
 	// URL of ethereum RPC node (infura or alchemy)
 	EthereumNodeURL string
+	// EthereumNodeURLs are the URLs of ethereum RPC nodes, in order of
+	// preference, failed over from one to the next. They replace EthereumNodeURL
+	EthereumNodeURLs []string
+	// CrossCheckReads is the number of nodes that must return the same
+	// result for the reads of the contracts (default = 0, no cross-checking)
+	CrossCheckReads int
 	// Gas limit to execute ethereum transaction
 	GasLimit uint64
 	// Value to execute Ethereum smart contracts (default = 0)
 	Value int64
+	// GasMultiplier is the safety margin of the estimated gas limit of the
+	// bridge transactions, see DefaultGasMultiplier
+	GasMultiplier float64
+	// MaxGasPrice in wei, transactions aren't sent above it (default = 0, no cap)
+	MaxGasPrice uint64
+	// Confirmations of the transactions of the bridge, see EthereumConfirmations
+	Confirmations uint64
+	// BlockTime of the chain, the polling interval of the event indexer
+	BlockTime time.Duration
 }
 
 type BridgeClientConfig struct {
@@ -50,6 +71,10 @@ type BridgeClientConfig struct {
 	EthereumAddress string
 	Password        string
 	Homedir         string
+	// Signer signs the Ethereum transactions of EthereumAddress instead of
+	// the key storage, e.g. a hardware wallet or a remote KMS, see
+	// WalletSigner and ExternalSigner.
+	Signer bind.SignerFn `json:"-"`
 }
 
 type Instance struct {
@@ -61,6 +86,9 @@ type BridgeClient struct {
 	*BridgeConfig
 	*BridgeClientConfig
 	*Instance
+	// Operations records the steps of the moves of tokens across the
+	// bridge, to resume them with ResumePending, optional.
+	Operations OperationStore
 }
 
 type BridgeOwner struct {
@@ -114,9 +142,14 @@ func CreateBridgeOwner(cfg *viper.Viper, walletFile ...string) *BridgeOwner {
 				AuthorizersAddress: cfg.GetString(fmt.Sprintf("%s.AuthorizersAddress", OwnerConfigKeyName)),
 			},
 			EthereumConfig: EthereumConfig{
-				EthereumNodeURL: cfg.GetString(fmt.Sprintf("%s.EthereumNodeURL", OwnerConfigKeyName)),
-				GasLimit:        cfg.GetUint64(fmt.Sprintf("%s.GasLimit", OwnerConfigKeyName)),
-				Value:           cfg.GetInt64(fmt.Sprintf("%s.Value", OwnerConfigKeyName)),
+				EthereumNodeURL:  cfg.GetString(fmt.Sprintf("%s.EthereumNodeURL", OwnerConfigKeyName)),
+				EthereumNodeURLs: cfg.GetStringSlice(fmt.Sprintf("%s.EthereumNodeURLs", OwnerConfigKeyName)),
+				CrossCheckReads:  cfg.GetInt(fmt.Sprintf("%s.CrossCheckReads", OwnerConfigKeyName)),
+				GasLimit:         cfg.GetUint64(fmt.Sprintf("%s.GasLimit", OwnerConfigKeyName)),
+				Value:            cfg.GetInt64(fmt.Sprintf("%s.Value", OwnerConfigKeyName)),
+				GasMultiplier:    cfg.GetFloat64(fmt.Sprintf("%s.GasMultiplier", OwnerConfigKeyName)),
+				MaxGasPrice:      cfg.GetUint64(fmt.Sprintf("%s.MaxGasPrice", OwnerConfigKeyName)),
+				Confirmations:    cfg.GetUint64(fmt.Sprintf("%s.Confirmations", OwnerConfigKeyName)),
 			},
 			EthereumAddress: cfg.GetString(fmt.Sprintf("%s.EthereumAddress", OwnerConfigKeyName)),
 			Password:        cfg.GetString(fmt.Sprintf("%s.Password", OwnerConfigKeyName)),
@@ -146,7 +179,7 @@ func CreateBridgeClient(cfg *viper.Viper, walletFile ...string) *BridgeClient {
 		ExitWithError(err)
 	}
 
-	return &BridgeClient{
+	client := &BridgeClient{
 		BridgeClientConfig: &BridgeClientConfig{
 			ContractsRegistry: ContractsRegistry{
 				BridgeAddress:      cfg.GetString(fmt.Sprintf("%s.BridgeAddress", ClientConfigKeyName)),
@@ -154,9 +187,14 @@ func CreateBridgeClient(cfg *viper.Viper, walletFile ...string) *BridgeClient {
 				AuthorizersAddress: cfg.GetString(fmt.Sprintf("%s.AuthorizersAddress", ClientConfigKeyName)),
 			},
 			EthereumConfig: EthereumConfig{
-				EthereumNodeURL: cfg.GetString(fmt.Sprintf("%s.EthereumNodeURL", ClientConfigKeyName)),
-				GasLimit:        cfg.GetUint64(fmt.Sprintf("%s.GasLimit", ClientConfigKeyName)),
-				Value:           cfg.GetInt64(fmt.Sprintf("%s.Value", ClientConfigKeyName)),
+				EthereumNodeURL:  cfg.GetString(fmt.Sprintf("%s.EthereumNodeURL", ClientConfigKeyName)),
+				EthereumNodeURLs: cfg.GetStringSlice(fmt.Sprintf("%s.EthereumNodeURLs", ClientConfigKeyName)),
+				CrossCheckReads:  cfg.GetInt(fmt.Sprintf("%s.CrossCheckReads", ClientConfigKeyName)),
+				GasLimit:         cfg.GetUint64(fmt.Sprintf("%s.GasLimit", ClientConfigKeyName)),
+				Value:            cfg.GetInt64(fmt.Sprintf("%s.Value", ClientConfigKeyName)),
+				GasMultiplier:    cfg.GetFloat64(fmt.Sprintf("%s.GasMultiplier", ClientConfigKeyName)),
+				MaxGasPrice:      cfg.GetUint64(fmt.Sprintf("%s.MaxGasPrice", ClientConfigKeyName)),
+				Confirmations:    cfg.GetUint64(fmt.Sprintf("%s.Confirmations", ClientConfigKeyName)),
 			},
 			EthereumAddress: cfg.GetString(fmt.Sprintf("%s.EthereumAddress", ClientConfigKeyName)),
 			Password:        cfg.GetString(fmt.Sprintf("%s.Password", ClientConfigKeyName)),
@@ -164,12 +202,15 @@ func CreateBridgeClient(cfg *viper.Viper, walletFile ...string) *BridgeClient {
 		},
 		BridgeConfig: &BridgeConfig{
 			ConsensusThreshold: cfg.GetFloat64(fmt.Sprintf("%s.ConsensusThreshold", ClientConfigKeyName)),
+			ChainID:            cfg.GetInt64(fmt.Sprintf("%s.ChainID", ClientConfigKeyName)),
 		},
 		Instance: &Instance{
 			startTime: common.Now(),
 			zcnWallet: wallet,
 		},
 	}
+	applyChain(client.BridgeClientConfig, client.ChainID)
+	return client
 }
 
 type BridgeClientYaml struct {
@@ -179,13 +220,19 @@ type BridgeClientYaml struct {
 	AuthorizersAddress string
 	WzcnAddress        string
 	EthereumNodeURL    string
+	EthereumNodeURLs   []string
+	CrossCheckReads    int
 	GasLimit           uint64
 	Value              int64
+	GasMultiplier      float64
+	MaxGasPrice        uint64
+	Confirmations      uint64
 	ConsensusThreshold float64
+	ChainID            int64
 }
 
 func CreateBridgeClientWithConfig(cfg BridgeClientYaml, wallet *zcncrypto.Wallet) *BridgeClient {
-	return &BridgeClient{
+	client := &BridgeClient{
 		BridgeClientConfig: &BridgeClientConfig{
 			ContractsRegistry: ContractsRegistry{
 				BridgeAddress:      cfg.BridgeAddress,
@@ -193,9 +240,14 @@ func CreateBridgeClientWithConfig(cfg BridgeClientYaml, wallet *zcncrypto.Wallet
 				AuthorizersAddress: cfg.AuthorizersAddress,
 			},
 			EthereumConfig: EthereumConfig{
-				EthereumNodeURL: cfg.EthereumNodeURL,
-				GasLimit:        cfg.GasLimit,
-				Value:           cfg.Value,
+				EthereumNodeURL:  cfg.EthereumNodeURL,
+				EthereumNodeURLs: cfg.EthereumNodeURLs,
+				CrossCheckReads:  cfg.CrossCheckReads,
+				GasLimit:         cfg.GasLimit,
+				Value:            cfg.Value,
+				GasMultiplier:    cfg.GasMultiplier,
+				MaxGasPrice:      cfg.MaxGasPrice,
+				Confirmations:    cfg.Confirmations,
 			},
 			EthereumAddress: cfg.EthereumAddress,
 			Password:        cfg.Password,
@@ -203,12 +255,15 @@ func CreateBridgeClientWithConfig(cfg BridgeClientYaml, wallet *zcncrypto.Wallet
 		},
 		BridgeConfig: &BridgeConfig{
 			ConsensusThreshold: cfg.ConsensusThreshold,
+			ChainID:            cfg.ChainID,
 		},
 		Instance: &Instance{
 			startTime: common.Now(),
 			zcnWallet: wallet,
 		},
 	}
+	applyChain(client.BridgeClientConfig, client.ChainID)
+	return client
 }
 
 func (b *BridgeClient) ClientID() string {
@@ -5,3 +5,10 @@ cd ./erc20
 #solc --bin ERC20.sol | awk '/Binary:/{x=1;next}x' > erc20.bin
 #abigen --bin=erc20.bin --abi=erc20.abi --pkg=erc20 --out=erc20.go
 abigen --pkg erc20 --sol ERC20.sol --out ./erc20.go
+cd ..
+
+# embed the contract version and deployments, see bindinfo
+for c in Bridge:bridge Authorizers:authorizers ERC20:erc20; do
+  go run ./bindinfo/cmd/bindinfo -contract "${c%%:*}" -networks networks.json \
+    -commit "$(git log -1 --format=%H -- "${c##*:}")" -out "${c##*:}/binding_info.go"
+done
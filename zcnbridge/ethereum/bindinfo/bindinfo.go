@@ -0,0 +1,89 @@
+// Package bindinfo describes which contract version a generated binding was
+// built from and where it is deployed on each network, so runtime code can
+// assert it is talking to the expected contract.
+package bindinfo
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Info is embedded into a generated binding package and returned by its BindingInfo().
+type Info struct {
+	// Contract name of the binding, e.g. "Bridge"
+	Contract string `json:"contract"`
+	// ABIHash keccak256 of the ABI the binding was generated from
+	ABIHash string `json:"abi_hash"`
+	// SourceCommit commit of the contract sources
+	SourceCommit string `json:"source_commit"`
+	// Deployments by network name
+	Deployments map[string]Deployment `json:"deployments"`
+}
+
+// Deployment of a contract on a network.
+type Deployment struct {
+	// ChainID of the network, 0 if not checked
+	ChainID uint64 `json:"chain_id"`
+	// Address of the contract
+	Address string `json:"address"`
+	// CodeHash keccak256 of the deployed runtime bytecode, empty if not checked
+	CodeHash string `json:"code_hash,omitempty"`
+}
+
+// CodeReader reads deployed bytecode, ethclient.Client implements it.
+type CodeReader interface {
+	CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// HashABI returns the hash embedded as Info.ABIHash. Whitespace is ignored so
+// the hash doesn't depend on how the ABI json was formatted.
+func HashABI(abi string) string {
+	compact := strings.Join(strings.Fields(abi), "")
+	return crypto.Keccak256Hash([]byte(compact)).Hex()
+}
+
+// CheckABI returns an error if the binding's ABI is not the one Info was generated from.
+func (i Info) CheckABI(abi string) error {
+	if got := HashABI(abi); got != i.ABIHash {
+		return fmt.Errorf("%s binding abi hash %s doesn't match generated %s, regenerate the binding info", i.Contract, got, i.ABIHash)
+	}
+	return nil
+}
+
+// Deployment returns the deployment of the contract on network.
+func (i Info) Deployment(network string) (Deployment, error) {
+	d, ok := i.Deployments[network]
+	if !ok {
+		return Deployment{}, fmt.Errorf("%s is not deployed on network %q", i.Contract, network)
+	}
+	return d, nil
+}
+
+// Verify asserts address is the expected deployment of the contract on
+// network and, if a code hash was recorded, that the deployed bytecode
+// matches it.
+func (i Info) Verify(ctx context.Context, reader CodeReader, network string, address common.Address) error {
+	d, err := i.Deployment(network)
+	if err != nil {
+		return err
+	}
+	if common.HexToAddress(d.Address) != address {
+		return fmt.Errorf("%s on %q is deployed at %s, not %s", i.Contract, network, d.Address, address.Hex())
+	}
+	if d.CodeHash == "" || reader == nil {
+		return nil
+	}
+	code, err := reader.CodeAt(ctx, address, nil)
+	if err != nil {
+		return err
+	}
+	if got := crypto.Keccak256Hash(code).Hex(); !strings.EqualFold(got, d.CodeHash) {
+		return fmt.Errorf("%s at %s has code hash %s, expected %s", i.Contract, address.Hex(), got, d.CodeHash)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package bindinfo
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+type codeReader []byte
+
+func (c codeReader) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return c, nil
+}
+
+const (
+	testABI     = `[{"type":"function","name":"burn"}]`
+	testAddress = "0xF26B52df8c6D9b9C20bfD7819Bed75a75258c7dB"
+)
+
+func testInfo(codeHash string) Info {
+	return Info{
+		Contract:     "Bridge",
+		ABIHash:      HashABI(testABI),
+		SourceCommit: "abc",
+		Deployments: map[string]Deployment{
+			"goerli": {ChainID: 5, Address: testAddress, CodeHash: codeHash},
+			"dev":    {Address: "0x930E1BE76461587969Cb7eB9BFe61166b1E70244"},
+		},
+	}
+}
+
+func TestCheckABI(t *testing.T) {
+	info := testInfo("")
+	require.NoError(t, info.CheckABI("[ {\"type\": \"function\",\n \"name\":\"burn\"} ]"))
+	require.Error(t, info.CheckABI(`[{"type":"function","name":"mint"}]`))
+}
+
+func TestVerify(t *testing.T) {
+	code := []byte{0x60, 0x80}
+	codeHash := crypto.Keccak256Hash(code).Hex()
+
+	tests := []struct {
+		name    string
+		info    Info
+		network string
+		address string
+		code    []byte
+		wantErr bool
+	}{
+		{name: "Test_Verify_Address_Only", info: testInfo(""), network: "goerli", address: testAddress},
+		{name: "Test_Verify_Code_Hash", info: testInfo(codeHash), network: "goerli", address: testAddress, code: code},
+		{name: "Test_Verify_Code_Mismatch", info: testInfo(codeHash), network: "goerli", address: testAddress, code: []byte{0x01}, wantErr: true},
+		{name: "Test_Verify_Wrong_Address", info: testInfo(""), network: "dev", address: testAddress, wantErr: true},
+		{name: "Test_Verify_Unknown_Network", info: testInfo(""), network: "mainnet", address: testAddress, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.info.Verify(context.Background(), codeReader(tt.code), tt.network, common.HexToAddress(tt.address))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	var first bytes.Buffer
+	require.NoError(t, Generate(&first, "bridge", testInfo("")))
+	for i := 0; i < 10; i++ {
+		var again bytes.Buffer
+		require.NoError(t, Generate(&again, "bridge", testInfo("")))
+		require.Equal(t, first.String(), again.String())
+	}
+	out := first.String()
+	require.Contains(t, out, "package bridge")
+	require.Contains(t, out, "func BindingInfo() bindinfo.Info")
+	require.Less(t, bytes.Index(first.Bytes(), []byte(`"dev"`)), bytes.Index(first.Bytes(), []byte(`"goerli"`)))
+}
@@ -0,0 +1,76 @@
+// Command bindinfo generates binding_info.go for the contract bindings.
+//
+//	bindinfo -contract Bridge -networks networks.json -commit <sha> -out ../bridge/binding_info.go
+//
+// networks.json maps contract name to its deployment per network:
+//
+//	{"Bridge": {"goerli": {"chain_id": 5, "address": "0x..."}}}
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum/authorizers"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bindinfo"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/erc20"
+)
+
+// contracts known to the generator: abi and package of the binding
+var contracts = map[string]struct {
+	abi string
+	pkg string
+}{
+	"Bridge":      {bridge.BridgeMetaData.ABI, "bridge"},
+	"Authorizers": {authorizers.AuthorizersMetaData.ABI, "authorizers"},
+	"ERC20":       {erc20.ERC20MetaData.ABI, "erc20"},
+}
+
+func main() {
+	contract := flag.String("contract", "", "contract name: Bridge, Authorizers or ERC20")
+	networksFile := flag.String("networks", "networks.json", "deployments per contract and network")
+	commit := flag.String("commit", "", "commit of the contract sources")
+	out := flag.String("out", "", "output file")
+	flag.Parse()
+
+	if err := run(*contract, *networksFile, *commit, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "bindinfo:", err)
+		os.Exit(1)
+	}
+}
+
+func run(contract, networksFile, commit, out string) error {
+	c, ok := contracts[contract]
+	if !ok {
+		return fmt.Errorf("unknown contract %q", contract)
+	}
+	if out == "" {
+		return fmt.Errorf("-out is required")
+	}
+
+	raw, err := ioutil.ReadFile(networksFile)
+	if err != nil {
+		return err
+	}
+	var networks map[string]map[string]bindinfo.Deployment
+	if err := json.Unmarshal(raw, &networks); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	err = bindinfo.Generate(&buf, c.pkg, bindinfo.Info{
+		Contract:     contract,
+		ABIHash:      bindinfo.HashABI(c.abi),
+		SourceCommit: commit,
+		Deployments:  networks[contract],
+	})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(out, buf.Bytes(), 0644)
+}
@@ -0,0 +1,65 @@
+package bindinfo
+
+import (
+	"bytes"
+	"go/format"
+	"io"
+	"sort"
+	"text/template"
+)
+
+var infoTemplate = template.Must(template.New("bindinfo").Parse(`// Code generated by bindinfo. DO NOT EDIT.
+
+package {{.Package}}
+
+import "github.com/0chain/gosdk/zcnbridge/ethereum/bindinfo"
+
+var bindingInfo = bindinfo.Info{
+	Contract:     {{printf "%q" .Info.Contract}},
+	ABIHash:      {{printf "%q" .Info.ABIHash}},
+	SourceCommit: {{printf "%q" .Info.SourceCommit}},
+	Deployments: map[string]bindinfo.Deployment{
+{{- range .Networks}}
+		{{printf "%q" .Name}}: {ChainID: {{.ChainID}}, Address: {{printf "%q" .Address}}, CodeHash: {{printf "%q" .CodeHash}}},
+{{- end}}
+	},
+}
+
+// BindingInfo returns the contract version and deployments the binding was generated for.
+func BindingInfo() bindinfo.Info {
+	return bindingInfo
+}
+`))
+
+type network struct {
+	Name string
+	Deployment
+}
+
+// Generate writes the binding_info.go source of a binding package. The
+// output only depends on its inputs, networks are written sorted by name.
+func Generate(w io.Writer, pkg string, info Info) error {
+	networks := make([]network, 0, len(info.Deployments))
+	for name, d := range info.Deployments {
+		networks = append(networks, network{Name: name, Deployment: d})
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].Name < networks[j].Name
+	})
+
+	var buf bytes.Buffer
+	err := infoTemplate.Execute(&buf, struct {
+		Package  string
+		Info     Info
+		Networks []network
+	}{pkg, info, networks})
+	if err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
@@ -0,0 +1,154 @@
+package ethereum
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum/authorizers"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/erc20"
+	geth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrUnknownEvent is returned for logs whose first topic is not an event of the contract
+var ErrUnknownEvent = errors.New("unknown event")
+
+type logParser func(types.Log) (interface{}, error)
+
+// LogDecoder decodes raw logs of a contract into the typed events of its
+// generated binding (e.g. *bridge.BridgeBurned) and builds topic filters for
+// its events, so indexers don't have to handle the ABI themselves.
+type LogDecoder struct {
+	abi     *abi.ABI
+	parsers map[common.Hash]logParser
+}
+
+func newLogDecoder(metaData string, parsers map[string]logParser) (*LogDecoder, error) {
+	parsed, err := abi.JSON(strings.NewReader(metaData))
+	if err != nil {
+		return nil, err
+	}
+	d := &LogDecoder{abi: &parsed, parsers: make(map[common.Hash]logParser, len(parsers))}
+	for name, parse := range parsers {
+		ev, ok := parsed.Events[name]
+		if !ok {
+			return nil, fmt.Errorf("event %s not found in abi", name)
+		}
+		d.parsers[ev.ID] = parse
+	}
+	return d, nil
+}
+
+// NewBridgeLogDecoder decodes Burned, Minted, AuthorizersTransferred and
+// OwnershipTransferred logs of the bridge contract.
+func NewBridgeLogDecoder() (*LogDecoder, error) {
+	f, err := bridge.NewBridgeFilterer(common.Address{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newLogDecoder(bridge.BridgeMetaData.ABI, map[string]logParser{
+		"Burned":                 func(l types.Log) (interface{}, error) { return f.ParseBurned(l) },
+		"Minted":                 func(l types.Log) (interface{}, error) { return f.ParseMinted(l) },
+		"AuthorizersTransferred": func(l types.Log) (interface{}, error) { return f.ParseAuthorizersTransferred(l) },
+		"OwnershipTransferred":   func(l types.Log) (interface{}, error) { return f.ParseOwnershipTransferred(l) },
+	})
+}
+
+// NewAuthorizersLogDecoder decodes logs of the authorizers contract.
+func NewAuthorizersLogDecoder() (*LogDecoder, error) {
+	f, err := authorizers.NewAuthorizersFilterer(common.Address{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newLogDecoder(authorizers.AuthorizersMetaData.ABI, map[string]logParser{
+		"OwnershipTransferred": func(l types.Log) (interface{}, error) { return f.ParseOwnershipTransferred(l) },
+	})
+}
+
+// NewERC20LogDecoder decodes Transfer and Approval logs of an ERC-20 token.
+func NewERC20LogDecoder() (*LogDecoder, error) {
+	f, err := erc20.NewERC20Filterer(common.Address{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newLogDecoder(erc20.ERC20MetaData.ABI, map[string]logParser{
+		"Transfer": func(l types.Log) (interface{}, error) { return f.ParseTransfer(l) },
+		"Approval": func(l types.Log) (interface{}, error) { return f.ParseApproval(l) },
+	})
+}
+
+// EventName returns the name of the event a log was emitted for.
+func (d *LogDecoder) EventName(log types.Log) (string, error) {
+	if len(log.Topics) == 0 {
+		return "", ErrUnknownEvent
+	}
+	ev, err := d.abi.EventByID(log.Topics[0])
+	if err != nil {
+		return "", ErrUnknownEvent
+	}
+	return ev.Name, nil
+}
+
+// Decode returns the typed event of a log, ErrUnknownEvent if the log is not
+// an event of the contract.
+func (d *LogDecoder) Decode(log types.Log) (interface{}, error) {
+	if len(log.Topics) == 0 {
+		return nil, ErrUnknownEvent
+	}
+	parse, ok := d.parsers[log.Topics[0]]
+	if !ok {
+		return nil, ErrUnknownEvent
+	}
+	return parse(log)
+}
+
+// DecodeAll decodes logs in order, skipping the ones of unknown events.
+func (d *LogDecoder) DecodeAll(logs []types.Log) ([]interface{}, error) {
+	events := make([]interface{}, 0, len(logs))
+	for _, log := range logs {
+		ev, err := d.Decode(log)
+		if errors.Is(err, ErrUnknownEvent) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+// Topics builds the topic filter of an event. query holds the accepted values
+// of each indexed argument in order; an empty or missing entry matches any
+// value. E.g. Topics("Burned", []interface{}{from}) matches burns of from.
+func (d *LogDecoder) Topics(event string, query ...[]interface{}) ([][]common.Hash, error) {
+	ev, ok := d.abi.Events[event]
+	if !ok {
+		return nil, fmt.Errorf("event %s not found in abi", event)
+	}
+	rules, err := abi.MakeTopics(query...)
+	if err != nil {
+		return nil, err
+	}
+	return append([][]common.Hash{{ev.ID}}, rules...), nil
+}
+
+// FilterQuery builds the query for eth_getLogs / SubscribeFilterLogs of an
+// event emitted by contract between the given blocks. nil blocks are open.
+func (d *LogDecoder) FilterQuery(contract common.Address, fromBlock, toBlock *big.Int, event string, query ...[]interface{}) (geth.FilterQuery, error) {
+	topics, err := d.Topics(event, query...)
+	if err != nil {
+		return geth.FilterQuery{}, err
+	}
+	return geth.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Addresses: []common.Address{contract},
+		Topics:    topics,
+	}, nil
+}
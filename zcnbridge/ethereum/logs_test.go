@@ -0,0 +1,57 @@
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogDecoder(t *testing.T) {
+	d, err := NewBridgeLogDecoder()
+	require.NoError(t, err)
+
+	from := common.HexToAddress("0x860FA46F170a87dF44D7bB867AA4a5D2813127c1")
+	clientID := []byte("5ad9b1e6f5c1e5a6ffe36d16fc00d1ed4f4c47ee1cc1c5c9f16d0b7b6d3b4a54")
+	nonce := big.NewInt(7)
+
+	topics, err := d.Topics("Burned", []interface{}{from}, []interface{}{clientID}, []interface{}{nonce})
+	require.NoError(t, err)
+	require.Len(t, topics, 4)
+	require.Equal(t, crypto.Keccak256Hash(clientID), topics[2][0])
+
+	amount := common.LeftPadBytes(big.NewInt(1e10).Bytes(), 32)
+	log := types.Log{
+		Topics: []common.Hash{topics[0][0], topics[1][0], topics[2][0], topics[3][0]},
+		Data:   amount,
+	}
+
+	name, err := d.EventName(log)
+	require.NoError(t, err)
+	require.Equal(t, "Burned", name)
+
+	ev, err := d.Decode(log)
+	require.NoError(t, err)
+	burned, ok := ev.(*bridge.BridgeBurned)
+	require.True(t, ok)
+	require.Equal(t, from, burned.From)
+	require.Equal(t, int64(1e10), burned.Amount.Int64())
+	require.Equal(t, nonce.Int64(), burned.Nonce.Int64())
+
+	unknown := types.Log{Topics: []common.Hash{crypto.Keccak256Hash([]byte("Unknown()"))}}
+	_, err = d.Decode(unknown)
+	require.ErrorIs(t, err, ErrUnknownEvent)
+
+	events, err := d.DecodeAll([]types.Log{unknown, log})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	q, err := d.FilterQuery(common.Address{1}, big.NewInt(10), nil, "Minted")
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{{1}}, q.Addresses)
+	require.Len(t, q.Topics, 1)
+}
@@ -4,9 +4,14 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"math/big"
+	"net"
+	"net/http"
 	"path"
+	"strings"
 	"time"
 
+	"github.com/0chain/gosdk/core/netconf"
+
 	"github.com/ethereum/go-ethereum/accounts"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
@@ -14,19 +19,55 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
 
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// CreateEthClient dials the Ethereum node, HTTP nodes being requested
+// through the proxy and DNS resolver of the netconf package. With several
+// EthereumNodeURLs, all HTTP, the client fails over from a node to the next.
 func (b *EthereumConfig) CreateEthClient() (*ethclient.Client, error) {
-	client, err := ethclient.Dial(b.EthereumNodeURL)
+	var (
+		client *ethclient.Client
+		err    error
+	)
+	urls := b.ethereumNodeURLs()
+	if failsOver(urls) {
+		var c *rpc.Client
+		c, err = rpc.DialHTTPWithClient(urls[0], &http.Client{Transport: endpointPools.get(urls, b.CrossCheckReads)})
+		if err == nil {
+			client = ethclient.NewClient(c)
+		}
+	} else if strings.HasPrefix(urls[0], "http://") || strings.HasPrefix(urls[0], "https://") {
+		var c *rpc.Client
+		c, err = rpc.DialHTTPWithClient(urls[0], &http.Client{Transport: ethTransport})
+		if err == nil {
+			client = ethclient.NewClient(c)
+		}
+	} else {
+		client, err = ethclient.Dial(urls[0])
+	}
 	if err != nil {
 		Logger.Error(err)
 	}
 	return client, err
 }
 
+// ethTransport is shared by the clients of the Ethereum nodes
+var ethTransport = netconf.RegisterTransport(&http.Transport{
+	Proxy: netconf.Proxy(http.ProxyFromEnvironment),
+	DialContext: netconf.DialContext(&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}),
+	MaxIdleConns:        100,
+	IdleConnTimeout:     90 * time.Second,
+	TLSHandshakeTimeout: 10 * time.Second,
+	ForceAttemptHTTP2:   true,
+})
+
 //  _allowances[owner][spender] = amount;
 // as a spender, ERC20 WZCN token must increase allowance for the bridge to make burn on behalf of WZCN owner
 
@@ -37,7 +78,7 @@ func CreateSignedTransaction(
 	privateKey *ecdsa.PrivateKey,
 	gasLimitUnits uint64,
 ) *bind.TransactOpts {
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	nonce, err := nonces.acquire(context.Background(), client, fromAddress)
 	if err != nil {
 		Logger.Fatal(err)
 	}
@@ -73,48 +114,24 @@ func CreateSignedTransaction(
 }
 
 func (b *BridgeClientConfig) CreateSignedTransactionFromKeyStore(client *ethclient.Client, gasLimitUnits uint64) *bind.TransactOpts {
-	var (
-		signerAddress = common.HexToAddress(b.EthereumAddress)
-		password      = b.Password
-		value         = b.Value
-	)
+	signerAddress := common.HexToAddress(b.EthereumAddress)
 
-	keyDir := path.Join(b.Homedir, EthereumWalletStorageDir)
-	ks := keystore.NewKeyStore(keyDir, keystore.StandardScryptN, keystore.StandardScryptP)
-	signer := accounts.Account{
-		Address: signerAddress,
-	}
-	signerAcc, err := ks.Find(signer)
-	if err != nil {
-		Logger.Fatal(errors.Wrapf(err, "signer: %s", signerAddress.Hex()))
-	}
-
-	chainID, err := client.ChainID(context.Background())
-	if err != nil {
-		Logger.Fatal(errors.Wrap(err, "failed to get chain ID"))
-	}
-
-	nonce, err := client.PendingNonceAt(context.Background(), signerAddress)
+	opts, err := b.transactor(client)
 	if err != nil {
 		Logger.Fatal(err)
 	}
 
-	gasPriceWei, err := client.SuggestGasPrice(context.Background())
+	nonce, err := nonces.acquire(context.Background(), client, signerAddress)
 	if err != nil {
 		Logger.Fatal(err)
 	}
 
-	err = ks.TimedUnlock(signer, password, time.Second*2)
-	if err != nil {
-		Logger.Fatal(err)
-	}
-
-	opts, err := bind.NewKeyStoreTransactorWithChainID(ks, signerAcc, chainID)
+	gasPriceWei, err := client.SuggestGasPrice(context.Background())
 	if err != nil {
 		Logger.Fatal(err)
 	}
 
-	valueWei := new(big.Int).Mul(big.NewInt(value), big.NewInt(params.Wei))
+	valueWei := new(big.Int).Mul(big.NewInt(b.Value), big.NewInt(params.Wei))
 
 	opts.Nonce = big.NewInt(int64(nonce))
 	opts.Value = valueWei         // in wei
@@ -123,3 +140,31 @@ func (b *BridgeClientConfig) CreateSignedTransactionFromKeyStore(client *ethclie
 
 	return opts
 }
+
+// keyStoreTransactor returns the transactor signing with the key of the
+// Ethereum address of the client, from the key storage.
+func (b *BridgeClientConfig) keyStoreTransactor(client *ethclient.Client) (*bind.TransactOpts, error) {
+	signerAddress := common.HexToAddress(b.EthereumAddress)
+
+	keyDir := path.Join(b.Homedir, EthereumWalletStorageDir)
+	ks := keystore.NewKeyStore(keyDir, keystore.StandardScryptN, keystore.StandardScryptP)
+	signer := accounts.Account{
+		Address: signerAddress,
+	}
+	signerAcc, err := ks.Find(signer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "signer: %s", signerAddress.Hex())
+	}
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get chain ID")
+	}
+
+	err = ks.TimedUnlock(signer, b.Password, time.Second*2)
+	if err != nil {
+		return nil, err
+	}
+
+	return bind.NewKeyStoreTransactorWithChainID(ks, signerAcc, chainID)
+}
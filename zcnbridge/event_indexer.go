@@ -0,0 +1,345 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultIndexerChunkSize is the number of blocks of a FilterLogs call of
+	// EventIndexer if EventIndexer.ChunkSize isn't set.
+	DefaultIndexerChunkSize = 2000
+	// DefaultIndexerPollInterval is how often EventIndexer looks for new
+	// blocks if EventIndexer.PollInterval isn't set.
+	DefaultIndexerPollInterval = 15 * time.Second
+)
+
+// BridgeEvent is an event of the bridge or authorizers contracts, see
+// EventIndexer.
+type BridgeEvent struct {
+	// Contract is the address of the contract emitting the event.
+	Contract string `json:"contract"`
+	// Name is the name of the event, e.g. Burned or Minted.
+	Name string `json:"name"`
+	// Event is the event of the generated binding, e.g. *bridge.BridgeBurned.
+	Event       interface{} `json:"event"`
+	BlockNumber uint64      `json:"block_number"`
+	TxHash      string      `json:"tx_hash"`
+	LogIndex    uint        `json:"log_index"`
+}
+
+// CursorStore persists the next block EventIndexer scans, so a restarted
+// indexer resumes where it stopped.
+type CursorStore interface {
+	// Load returns the block saved, false if none is.
+	Load() (uint64, bool, error)
+	Save(block uint64) error
+}
+
+// FileCursor is a CursorStore saving the block to the file at its path.
+type FileCursor string
+
+// Load reads the block of the file, false if the file doesn't exist.
+func (f FileCursor) Load() (uint64, bool, error) {
+	data, err := os.ReadFile(string(f))
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	block, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "invalid cursor %s", string(f))
+	}
+	return block, true, nil
+}
+
+// Save writes the block to a temporary file renamed to the file, so the
+// cursor isn't lost by a crash.
+func (f FileCursor) Save(block uint64) error {
+	tmp := string(f) + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatUint(block, 10)), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, string(f))
+}
+
+// logScanner is the part of the Ethereum client EventIndexer scans with.
+type logScanner interface {
+	FilterLogs(ctx context.Context, q eth.FilterQuery) ([]types.Log, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// EventIndexer scans the Burned and Minted events of the bridge contract,
+// and the events of the authorizers contract, from StartBlock, for the
+// reconciliation of the burns and mints. Only the blocks with Confirmations
+// are scanned, so the events aren't dropped by reorgs.
+type EventIndexer struct {
+	// StartBlock is the first block scanned, if Cursor has no block.
+	StartBlock uint64
+	// ChunkSize is the number of blocks of a FilterLogs call, halved while
+	// the node rejects the range, see DefaultIndexerChunkSize.
+	ChunkSize uint64
+	// Confirmations of the blocks scanned, see EthereumConfirmations.
+	Confirmations uint64
+	// PollInterval, see DefaultIndexerPollInterval.
+	PollInterval time.Duration
+	// Cursor persists the next block scanned, optional.
+	Cursor CursorStore
+
+	connect  func() (logScanner, func(), error)
+	decoders map[common.Address]*ethereum.LogDecoder
+}
+
+// NewEventIndexer creates an indexer of the events of the bridge and the
+// authorizers contracts of the config, scanning from startBlock on the first
+// run, then from the block of cursor.
+func (b *BridgeClientConfig) NewEventIndexer(startBlock uint64, cursor CursorStore) (*EventIndexer, error) {
+	x, err := newEventIndexer(
+		func() (logScanner, func(), error) {
+			etherClient, err := b.CreateEthClient()
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "failed to create etherClient")
+			}
+			return etherClient, etherClient.Close, nil
+		},
+		common.HexToAddress(b.BridgeAddress),
+		common.HexToAddress(b.AuthorizersAddress),
+		startBlock,
+		cursor,
+	)
+	if err != nil {
+		return nil, err
+	}
+	x.Confirmations = b.confirmations()
+	if b.BlockTime > 0 {
+		x.PollInterval = b.BlockTime
+	}
+	return x, nil
+}
+
+func newEventIndexer(connect func() (logScanner, func(), error), bridgeAddress, authorizersAddress common.Address, startBlock uint64, cursor CursorStore) (*EventIndexer, error) {
+	bridgeDecoder, err := ethereum.NewBridgeLogDecoder()
+	if err != nil {
+		return nil, err
+	}
+	authorizersDecoder, err := ethereum.NewAuthorizersLogDecoder()
+	if err != nil {
+		return nil, err
+	}
+	return &EventIndexer{
+		StartBlock:    startBlock,
+		ChunkSize:     DefaultIndexerChunkSize,
+		Confirmations: EthereumConfirmations,
+		PollInterval:  DefaultIndexerPollInterval,
+		Cursor:        cursor,
+		connect:       connect,
+		decoders: map[common.Address]*ethereum.LogDecoder{
+			bridgeAddress:      bridgeDecoder,
+			authorizersAddress: authorizersDecoder,
+		},
+	}, nil
+}
+
+// SubscribeBridgeEvents scans the events until ctx is done, sending them in
+// the order of the chain. The cursor is saved once the events of the blocks
+// scanned are received, so they are sent at least once. The channel is
+// closed once ctx is done, the errors of the node are logged and retried.
+func (x *EventIndexer) SubscribeBridgeEvents(ctx context.Context) (<-chan BridgeEvent, error) {
+	next := x.StartBlock
+	if x.Cursor != nil {
+		block, ok, err := x.Cursor.Load()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load the cursor")
+		}
+		if ok {
+			next = block
+		}
+	}
+
+	client, closeClient, err := x.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	// unbuffered, the events sent are received before the cursor is saved
+	events := make(chan BridgeEvent)
+	go func() {
+		defer close(events)
+		defer closeClient()
+
+		for ctx.Err() == nil {
+			scanned, err := x.scan(ctx, client, next, events)
+			if err != nil && ctx.Err() == nil {
+				Logger.Error("bridge event indexer failed", zap.Uint64("block", next), zap.Error(err))
+			}
+			if scanned > next {
+				next = scanned
+				if x.Cursor != nil {
+					if err := x.Cursor.Save(next); err != nil {
+						Logger.Error("failed to save the cursor", zap.Uint64("block", next), zap.Error(err))
+					}
+				}
+				// more blocks may be confirmed already
+				if err == nil {
+					continue
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(x.pollInterval()):
+			}
+		}
+	}()
+	return events, nil
+}
+
+func (x *EventIndexer) pollInterval() time.Duration {
+	if x.PollInterval <= 0 {
+		return DefaultIndexerPollInterval
+	}
+	return x.PollInterval
+}
+
+// scan sends the events of a chunk of the confirmed blocks from next, and
+// returns the block after the chunk, next if there is no confirmed block.
+func (x *EventIndexer) scan(ctx context.Context, client logScanner, next uint64, events chan<- BridgeEvent) (uint64, error) {
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return next, errors.Wrap(err, "failed to get the block number")
+	}
+	// the blocks after head+1-Confirmations have less confirmations
+	var last uint64
+	switch {
+	case x.Confirmations == 0:
+		last = head
+	case head+1 < x.Confirmations:
+		return next, nil
+	default:
+		last = head + 1 - x.Confirmations
+	}
+	if last < next {
+		return next, nil
+	}
+
+	logs, to, err := x.filterLogs(ctx, client, next, last)
+	if err != nil {
+		return next, err
+	}
+	for _, l := range logs {
+		ev, ok := x.decode(l)
+		if !ok {
+			continue
+		}
+		select {
+		case events <- ev:
+		case <-ctx.Done():
+			return next, ctx.Err()
+		}
+	}
+	return to + 1, nil
+}
+
+// filterLogs returns the logs of the contracts from the block from, in a
+// chunk up to the block last, and the last block of the chunk. The chunk is
+// halved while the node rejects the range, and kept for the next calls.
+func (x *EventIndexer) filterLogs(ctx context.Context, client logScanner, from, last uint64) ([]types.Log, uint64, error) {
+	if x.ChunkSize == 0 {
+		x.ChunkSize = DefaultIndexerChunkSize
+	}
+	addresses := make([]common.Address, 0, len(x.decoders))
+	for address := range x.decoders {
+		addresses = append(addresses, address)
+	}
+
+	for {
+		to := last
+		if to-from >= x.ChunkSize {
+			to = from + x.ChunkSize - 1
+		}
+		logs, err := client.FilterLogs(ctx, eth.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: addresses,
+		})
+		if err == nil {
+			sort.SliceStable(logs, func(i, j int) bool {
+				if logs[i].BlockNumber != logs[j].BlockNumber {
+					return logs[i].BlockNumber < logs[j].BlockNumber
+				}
+				return logs[i].Index < logs[j].Index
+			})
+			return logs, to, nil
+		}
+		if !isLogRangeError(err) || x.ChunkSize == 1 {
+			return nil, from, errors.Wrapf(err, "failed to filter the logs of blocks %d-%d", from, to)
+		}
+		x.ChunkSize /= 2
+		Logger.Info("log range rejected, scanning smaller chunks", zap.Uint64("chunk", x.ChunkSize))
+	}
+}
+
+// decode returns the event of l, false if it isn't an event of the
+// contracts.
+func (x *EventIndexer) decode(l types.Log) (BridgeEvent, bool) {
+	decoder, ok := x.decoders[l.Address]
+	if !ok || l.Removed {
+		return BridgeEvent{}, false
+	}
+	name, err := decoder.EventName(l)
+	if err != nil {
+		return BridgeEvent{}, false
+	}
+	event, err := decoder.Decode(l)
+	if err != nil {
+		if !errors.Is(err, ethereum.ErrUnknownEvent) {
+			Logger.Error("failed to decode the event", zap.String("tx", l.TxHash.Hex()), zap.Error(err))
+		}
+		return BridgeEvent{}, false
+	}
+	return BridgeEvent{
+		Contract:    l.Address.Hex(),
+		Name:        name,
+		Event:       event,
+		BlockNumber: l.BlockNumber,
+		TxHash:      l.TxHash.Hex(),
+		LogIndex:    l.Index,
+	}, true
+}
+
+// logRangeErrors are the errors of the providers limiting the block range
+// or the results of eth_getLogs.
+var logRangeErrors = []string{
+	"block range",
+	"range is too large",
+	"query returned more than",
+	"too many",
+	"limit exceeded",
+	"exceed maximum",
+	"response size",
+}
+
+func isLogRangeError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range logRangeErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package zcnbridge
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	indexedBridge      = common.HexToAddress("0xb1")
+	indexedAuthorizers = common.HexToAddress("0xa1")
+)
+
+// fakeLogChain has the logs of its blocks, and rejects the FilterLogs of more
+// than maxRange blocks.
+type fakeLogChain struct {
+	head     uint64
+	logs     []types.Log
+	maxRange uint64
+}
+
+func (c *fakeLogChain) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.head, nil
+}
+
+func (c *fakeLogChain) FilterLogs(ctx context.Context, q eth.FilterQuery) ([]types.Log, error) {
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	if c.maxRange > 0 && to-from+1 > c.maxRange {
+		return nil, fmt.Errorf("query exceeds max block range %d", c.maxRange)
+	}
+	var logs []types.Log
+	for _, l := range c.logs {
+		if l.BlockNumber >= from && l.BlockNumber <= to {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+func burnedLog(t *testing.T, block uint64, nonce int64) types.Log {
+	d, err := ethereum.NewBridgeLogDecoder()
+	require.NoError(t, err)
+	topics, err := d.Topics("Burned", []interface{}{common.HexToAddress("0x1")}, []interface{}{[]byte("client")}, []interface{}{big.NewInt(nonce)})
+	require.NoError(t, err)
+	return types.Log{
+		Address:     indexedBridge,
+		Topics:      []common.Hash{topics[0][0], topics[1][0], topics[2][0], topics[3][0]},
+		Data:        common.LeftPadBytes(big.NewInt(100).Bytes(), 32),
+		BlockNumber: block,
+	}
+}
+
+// indexEvents runs the indexer until want events are received, and returns
+// them once it stopped.
+func indexEvents(t *testing.T, x *EventIndexer, want int) []BridgeEvent {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := x.SubscribeBridgeEvents(ctx)
+	require.NoError(t, err)
+	var received []BridgeEvent
+	for ev := range events {
+		received = append(received, ev)
+		if len(received) == want {
+			cancel()
+		}
+	}
+	return received
+}
+
+func TestEventIndexer(t *testing.T) {
+	newIndexer := func(t *testing.T, chain *fakeLogChain, start uint64, cursor CursorStore) *EventIndexer {
+		connect := func() (logScanner, func(), error) { return chain, func() {}, nil }
+		x, err := newEventIndexer(connect, indexedBridge, indexedAuthorizers, start, cursor)
+		require.NoError(t, err)
+		x.PollInterval = time.Millisecond
+		x.Confirmations = 1
+		return x
+	}
+
+	t.Run("Test_Chunked_Scan", func(t *testing.T) {
+		chain := &fakeLogChain{head: 31, maxRange: 5}
+		for i, block := range []uint64{3, 12, 12, 31} {
+			l := burnedLog(t, block, int64(i))
+			l.Index = uint(i)
+			chain.logs = append(chain.logs, l)
+		}
+		// not an event of the contracts
+		chain.logs = append(chain.logs, types.Log{Address: common.HexToAddress("0xff"), BlockNumber: 4})
+
+		cursor := FileCursor(filepath.Join(t.TempDir(), "cursor"))
+		x := newIndexer(t, chain, 0, cursor)
+		events := indexEvents(t, x, 4)
+
+		require.Len(t, events, 4)
+		for i, ev := range events {
+			require.Equal(t, "Burned", ev.Name)
+			burned, ok := ev.Event.(*bridge.BridgeBurned)
+			require.True(t, ok)
+			require.EqualValues(t, i, burned.Nonce.Int64())
+		}
+		require.LessOrEqual(t, x.ChunkSize, uint64(5))
+
+		block, ok, err := cursor.Load()
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.EqualValues(t, 32, block)
+	})
+
+	t.Run("Test_Unconfirmed_Blocks", func(t *testing.T) {
+		chain := &fakeLogChain{head: 10, logs: []types.Log{burnedLog(t, 8, 0), burnedLog(t, 11, 1)}}
+		x := newIndexer(t, chain, 0, nil)
+		x.Confirmations = 3
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events := make(chan BridgeEvent, 10)
+		next, err := x.scan(ctx, chain, 0, events)
+		cancel()
+		require.NoError(t, err)
+		require.EqualValues(t, 9, next)
+		require.Len(t, events, 1)
+	})
+
+	t.Run("Test_Resume_From_Cursor", func(t *testing.T) {
+		chain := &fakeLogChain{head: 20, logs: []types.Log{burnedLog(t, 5, 0), burnedLog(t, 15, 1)}}
+		cursor := FileCursor(filepath.Join(t.TempDir(), "cursor"))
+		require.NoError(t, cursor.Save(10))
+
+		events := indexEvents(t, newIndexer(t, chain, 0, cursor), 1)
+		require.Len(t, events, 1)
+		require.EqualValues(t, 15, events[0].BlockNumber)
+	})
+}
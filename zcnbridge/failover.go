@@ -0,0 +1,317 @@
+package zcnbridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// EndpointRetryInterval is how long an Ethereum node that failed is only
+// requested once the other nodes failed too.
+var EndpointRetryInterval = 30 * time.Second
+
+// ErrEndpointsDisagree is returned for the reads cross-checked when the
+// Ethereum nodes requested don't return the same result.
+var ErrEndpointsDisagree = errors.New("ethereum nodes disagree")
+
+// crossCheckedMethods are the reads whose result doesn't depend on the sync
+// of the node, so nodes returning different results are faulty.
+var crossCheckedMethods = map[string]bool{
+	"eth_chainId":      true,
+	"eth_call":         true,
+	"eth_getBalance":   true,
+	"eth_getCode":      true,
+	"eth_getStorageAt": true,
+}
+
+// NodeHealth is the state of an Ethereum node, see CheckEthereumNodes.
+type NodeHealth struct {
+	URL         string        `json:"url"`
+	Healthy     bool          `json:"healthy"`
+	BlockNumber uint64        `json:"block_number,omitempty"`
+	Latency     time.Duration `json:"latency,omitempty"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// ethereumNodeURLs returns the Ethereum nodes of the config, in order of
+// preference.
+func (c *EthereumConfig) ethereumNodeURLs() []string {
+	if len(c.EthereumNodeURLs) > 0 {
+		return c.EthereumNodeURLs
+	}
+	return []string{c.EthereumNodeURL}
+}
+
+// CheckEthereumNodes requests the block number of every Ethereum node of
+// the config. The nodes that fail are requested last by the clients of
+// CreateEthClient until EndpointRetryInterval, the nodes that succeed are
+// requested again.
+func (c *EthereumConfig) CheckEthereumNodes(ctx context.Context) []NodeHealth {
+	urls := c.ethereumNodeURLs()
+	pool := endpointPools.get(urls, c.CrossCheckReads)
+
+	health := make([]NodeHealth, len(urls))
+	for i, e := range pool.endpoints {
+		health[i].URL = e.url
+
+		start := time.Now()
+		client, err := (&EthereumConfig{EthereumNodeURL: e.url}).CreateEthClient()
+		if err == nil {
+			health[i].BlockNumber, err = client.BlockNumber(ctx)
+			client.Close()
+		}
+		health[i].Latency = time.Since(start)
+		if err != nil {
+			health[i].Error = err.Error()
+			pool.markDown(e, err)
+			continue
+		}
+		health[i].Healthy = true
+		pool.markUp(e)
+	}
+	return health
+}
+
+// failsOver tells whether the clients of urls fail over from a node to the
+// next, which only HTTP nodes do.
+func failsOver(urls []string) bool {
+	if len(urls) < 2 {
+		return false
+	}
+	for _, u := range urls {
+		if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+			return false
+		}
+	}
+	return true
+}
+
+// endpointPools are the states of the nodes of the configs, shared by the
+// clients created for them.
+var endpointPools = &poolRegistry{pools: make(map[string]*endpointPool)}
+
+type poolRegistry struct {
+	mu    sync.Mutex
+	pools map[string]*endpointPool
+}
+
+func (r *poolRegistry) get(urls []string, crossCheck int) *endpointPool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := strings.Join(urls, ",")
+	pool, ok := r.pools[key]
+	if !ok {
+		pool = &endpointPool{transport: ethTransport}
+		for _, u := range urls {
+			pool.endpoints = append(pool.endpoints, &endpoint{url: u})
+		}
+		r.pools[key] = pool
+	}
+	pool.crossCheck = crossCheck
+	return pool
+}
+
+type endpoint struct {
+	url       string
+	downUntil time.Time
+	lastErr   error
+}
+
+// endpointPool is the transport of the clients of several Ethereum nodes:
+// every request is sent to the first node up, and to the next ones while it
+// fails. The reads of crossCheckedMethods are sent to crossCheck nodes,
+// which must return the same result.
+type endpointPool struct {
+	mu         sync.Mutex
+	endpoints  []*endpoint
+	crossCheck int
+	transport  http.RoundTripper
+}
+
+// ordered returns the nodes up in order of preference, then the nodes down
+// in the order they are retried.
+func (p *endpointPool) ordered() []*endpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var up, down []*endpoint
+	for _, e := range p.endpoints {
+		if now.Before(e.downUntil) {
+			down = append(down, e)
+		} else {
+			up = append(up, e)
+		}
+	}
+	sort.SliceStable(down, func(i, j int) bool { return down[i].downUntil.Before(down[j].downUntil) })
+	return append(up, down...)
+}
+
+func (p *endpointPool) markDown(e *endpoint, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e.downUntil = time.Now().Add(EndpointRetryInterval)
+	e.lastErr = err
+	Logger.Error("ethereum node failed, failing over", zap.String("url", e.url), zap.Error(err))
+}
+
+func (p *endpointPool) markUp(e *endpoint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e.downUntil = time.Time{}
+	e.lastErr = nil
+}
+
+func (p *endpointPool) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.crossCheck > 1 && isCrossChecked(body) {
+		return p.crossChecked(req, body)
+	}
+
+	var lastErr error
+	for _, e := range p.ordered() {
+		resp, err := p.send(req, e, body)
+		if err == nil {
+			p.markUp(e)
+			return resp, nil
+		}
+		if req.Context().Err() != nil {
+			return nil, err
+		}
+		p.markDown(e, err)
+		lastErr = err
+	}
+	return nil, errors.Wrap(lastErr, "all the ethereum nodes failed")
+}
+
+// crossChecked sends the read body to the nodes until p.crossCheck of them
+// answered, and returns the answer if they all returned the same result.
+func (p *endpointPool) crossChecked(req *http.Request, body []byte) (*http.Response, error) {
+	var (
+		answer  []byte
+		result  rpcResult
+		first   string
+		answers int
+		lastErr error
+	)
+	for _, e := range p.ordered() {
+		if answers == p.crossCheck {
+			break
+		}
+		resp, err := p.send(req, e, body)
+		if err == nil {
+			var data []byte
+			data, err = io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err == nil {
+				var r rpcResult
+				if err = json.Unmarshal(data, &r); err == nil {
+					p.markUp(e)
+					if answers > 0 && !r.equal(result) {
+						return nil, errors.Wrapf(ErrEndpointsDisagree, "%s and %s", first, e.url)
+					}
+					if answers == 0 {
+						answer, result, first = data, r, e.url
+					}
+					answers++
+					continue
+				}
+			}
+		}
+		if req.Context().Err() != nil {
+			return nil, err
+		}
+		p.markDown(e, err)
+		lastErr = err
+	}
+	if answers < p.crossCheck {
+		err := errors.Errorf("only %d of %d ethereum nodes answered", answers, p.crossCheck)
+		if lastErr != nil {
+			err = errors.Wrap(lastErr, err.Error())
+		}
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(answer)),
+		ContentLength: int64(len(answer)),
+		Request:       req,
+	}, nil
+}
+
+// send sends the request to the node e, failing if the node isn't available.
+func (p *endpointPool) send(req *http.Request, e *endpoint, body []byte) (*http.Response, error) {
+	u, err := url.Parse(e.url)
+	if err != nil {
+		return nil, err
+	}
+	r := req.Clone(req.Context())
+	r.URL = u
+	r.Host = u.Host
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+
+	resp, err := p.transport.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		resp.Body.Close()
+		return nil, errors.Errorf("%s: %s", e.url, resp.Status)
+	}
+	return resp, nil
+}
+
+// isCrossChecked tells whether body is a single call of crossCheckedMethods.
+func isCrossChecked(body []byte) bool {
+	var call struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(body, &call); err != nil {
+		return false
+	}
+	return crossCheckedMethods[call.Method]
+}
+
+type rpcResult struct {
+	Result json.RawMessage `json:"result"`
+	Error  json.RawMessage `json:"error"`
+}
+
+func (r rpcResult) equal(other rpcResult) bool {
+	return compactJSON(r.Result) == compactJSON(other.Result) && compactJSON(r.Error) == compactJSON(other.Error)
+}
+
+func compactJSON(data json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return string(data)
+	}
+	return buf.String()
+}
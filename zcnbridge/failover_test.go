@@ -0,0 +1,127 @@
+package zcnbridge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNode is an Ethereum node answering eth_blockNumber and eth_chainId,
+// or failing with status.
+func fakeNode(t *testing.T, chainID string, status int) (*httptest.Server, *int) {
+	calls := new(int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		var call struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&call))
+
+		result := `"0x10"`
+		if call.Method == "eth_chainId" {
+			result = `"` + chainID + `"`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(call.ID) + `,"result":` + result + `}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, calls
+}
+
+func TestEthereumNodeFailover(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	t.Run("Test_Fail_Over_To_The_Next_Node", func(t *testing.T) {
+		down, downCalls := fakeNode(t, "0x5", http.StatusBadGateway)
+		up, upCalls := fakeNode(t, "0x5", http.StatusOK)
+		cfg := &EthereumConfig{EthereumNodeURLs: []string{down.URL, up.URL}}
+
+		client, err := cfg.CreateEthClient()
+		require.NoError(t, err)
+		defer client.Close()
+
+		block, err := client.BlockNumber(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 16, block)
+		require.Equal(t, 1, *downCalls)
+
+		// the failed node is skipped until EndpointRetryInterval
+		_, err = client.BlockNumber(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, *downCalls)
+		require.Equal(t, 2, *upCalls)
+	})
+
+	t.Run("Test_All_Nodes_Failing", func(t *testing.T) {
+		first, _ := fakeNode(t, "0x5", http.StatusInternalServerError)
+		second, _ := fakeNode(t, "0x5", http.StatusTooManyRequests)
+		cfg := &EthereumConfig{EthereumNodeURLs: []string{first.URL, second.URL}}
+
+		client, err := cfg.CreateEthClient()
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.BlockNumber(ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("Test_Cross_Checked_Reads", func(t *testing.T) {
+		first, _ := fakeNode(t, "0x5", http.StatusOK)
+		second, _ := fakeNode(t, "0x5", http.StatusOK)
+		cfg := &EthereumConfig{EthereumNodeURLs: []string{first.URL, second.URL}, CrossCheckReads: 2}
+
+		client, err := cfg.CreateEthClient()
+		require.NoError(t, err)
+		defer client.Close()
+
+		chainID, err := client.ChainID(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 5, chainID.Int64())
+	})
+
+	t.Run("Test_Cross_Checked_Reads_Disagree", func(t *testing.T) {
+		first, _ := fakeNode(t, "0x5", http.StatusOK)
+		second, secondCalls := fakeNode(t, "0x1", http.StatusOK)
+		cfg := &EthereumConfig{EthereumNodeURLs: []string{first.URL, second.URL}, CrossCheckReads: 2}
+
+		client, err := cfg.CreateEthClient()
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.ChainID(ctx)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), ErrEndpointsDisagree.Error())
+
+		// the reads not cross-checked are sent to the first node only
+		_, err = client.BlockNumber(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, *secondCalls)
+	})
+
+	t.Run("Test_Check_Ethereum_Nodes", func(t *testing.T) {
+		down, _ := fakeNode(t, "0x5", http.StatusServiceUnavailable)
+		up, _ := fakeNode(t, "0x5", http.StatusOK)
+		cfg := &EthereumConfig{EthereumNodeURLs: []string{down.URL, up.URL}}
+
+		health := cfg.CheckEthereumNodes(ctx)
+		require.Len(t, health, 2)
+		require.False(t, health[0].Healthy)
+		require.NotEmpty(t, health[0].Error)
+		require.True(t, health[1].Healthy)
+		require.EqualValues(t, 16, health[1].BlockNumber)
+
+		pool := endpointPools.get(cfg.EthereumNodeURLs, 0)
+		require.Equal(t, up.URL, pool.ordered()[0].url)
+	})
+}
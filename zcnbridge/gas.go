@@ -0,0 +1,57 @@
+package zcnbridge
+
+import (
+	"context"
+	"math"
+	"math/big"
+
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// DefaultGasMultiplier is the factor the estimated gas limit of the bridge
+// transactions is multiplied with if EthereumConfig.GasMultiplier isn't set.
+const DefaultGasMultiplier = 1.1
+
+// ErrGasPriceCapExceeded is returned instead of sending a transaction when
+// the gas price suggested by the node is above EthereumConfig.MaxGasPrice.
+var ErrGasPriceCapExceeded = errors.New("gas price cap exceeded")
+
+// estimateGas estimates the gas limit of msg with eth_estimateGas, with the
+// safety margin of GasMultiplier.
+func (c *EthereumConfig) estimateGas(ctx context.Context, estimator eth.GasEstimator, msg eth.CallMsg) (uint64, error) {
+	gasLimitUnits, err := estimator.EstimateGas(ctx, msg)
+	if err != nil {
+		return 0, errors.Wrap(DecodeRevert(err), "failed to estimate gas")
+	}
+
+	multiplier := c.GasMultiplier
+	if multiplier <= 0 {
+		multiplier = DefaultGasMultiplier
+	}
+	// in thousandths, so the margin isn't off by float rounding
+	estimated := new(big.Int).SetUint64(gasLimitUnits)
+	estimated.Mul(estimated, big.NewInt(int64(math.Round(multiplier*1000))))
+	estimated.Add(estimated, big.NewInt(999))
+	estimated.Div(estimated, big.NewInt(1000))
+	if !estimated.IsUint64() {
+		return 0, errors.Errorf("gas limit overflow: %d * %v", gasLimitUnits, multiplier)
+	}
+	return estimated.Uint64(), nil
+}
+
+// checkGasPrice fails with ErrGasPriceCapExceeded if gasPrice, in wei, is
+// above MaxGasPrice. A MaxGasPrice of 0 is no cap.
+func (c *EthereumConfig) checkGasPrice(gasPrice *big.Int) error {
+	if c.MaxGasPrice == 0 || gasPrice == nil {
+		return nil
+	}
+	if gasPrice.Cmp(new(big.Int).SetUint64(c.MaxGasPrice)) > 0 {
+		Logger.Error("gas price above the cap",
+			zap.String("gas_price", gasPrice.String()),
+			zap.Uint64("max_gas_price", c.MaxGasPrice))
+		return errors.Wrapf(ErrGasPriceCapExceeded, "gas price %s wei, cap %d wei", gasPrice, c.MaxGasPrice)
+	}
+	return nil
+}
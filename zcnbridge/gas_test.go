@@ -0,0 +1,56 @@
+package zcnbridge
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	eth "github.com/ethereum/go-ethereum"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGasEstimator struct {
+	gas uint64
+	err error
+}
+
+func (e fakeGasEstimator) EstimateGas(ctx context.Context, msg eth.CallMsg) (uint64, error) {
+	return e.gas, e.err
+}
+
+func TestEthereumConfig_estimateGas(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		multiplier float64
+		estimator  fakeGasEstimator
+		want       uint64
+		wantErr    bool
+	}{
+		{name: "Test_Default_Multiplier", estimator: fakeGasEstimator{gas: 100000}, want: 110000},
+		{name: "Test_Multiplier", multiplier: 1.5, estimator: fakeGasEstimator{gas: 100001}, want: 150002},
+		{name: "Test_Estimate_Failed", estimator: fakeGasEstimator{err: errors.New("execution reverted")}, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &EthereumConfig{GasMultiplier: tc.multiplier}
+			got, err := c.estimateGas(context.Background(), tc.estimator, eth.CallMsg{})
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEthereumConfig_checkGasPrice(t *testing.T) {
+	c := &EthereumConfig{}
+	require.NoError(t, c.checkGasPrice(big.NewInt(1e15)), "no cap")
+
+	c.MaxGasPrice = 50e9
+	require.NoError(t, c.checkGasPrice(big.NewInt(50e9)))
+	err := c.checkGasPrice(big.NewInt(50e9 + 1))
+	require.Equal(t, ErrGasPriceCapExceeded, pkgerrors.Cause(err))
+}
@@ -26,6 +26,8 @@ const (
 	PathGetAuthorizerNodes = "/getAuthorizerNodes"
 	PathGetGlobalConfig    = "/getGlobalConfig"
 	PathGetAuthorizer      = "/getAuthorizer"
+
+	PathGetNotProcessedBurnTickets = "/v1/not_processed_burn_tickets"
 )
 
 type Params map[string]string
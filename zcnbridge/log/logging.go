@@ -1,6 +1,7 @@
 package log
 
 import (
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -11,8 +12,11 @@ import (
 )
 
 var (
-	// Logger represents main logger implementation used in app.
-	Logger = zap.NewNop()
+	// Logger represents main logger implementation used in app, its entries
+	// go to the sink set with logger.SetSink if any. Until InitLogging, the
+	// info entries are discarded when there is no sink.
+	Logger = zap.New(withSink(zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{}), zapcore.AddSync(io.Discard), zapcore.InfoLevel)))
 
 	// logName
 	logName string
@@ -48,7 +52,7 @@ func InitLogging(development bool, logDir, level string) {
 	logCfg.EncoderConfig.TimeKey = "timestamp"
 	logCfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 
-	l, err := logCfg.Build(setOutput(logWriter, logCfg))
+	l, err := logCfg.Build(setOutput(logWriter, logCfg), zap.WrapCore(withSink))
 	if err != nil {
 		errors.ExitErr("error while build logger config", err, 2)
 	}
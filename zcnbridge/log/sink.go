@@ -0,0 +1,87 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/0chain/gosdk/core/logger"
+	"go.uber.org/zap/zapcore"
+)
+
+// SinkSubsystem is the subsystem of the zcnbridge entries sent to the sink
+// set with logger.SetSink.
+const SinkSubsystem = "zcnbridge"
+
+// sinkCore sends the entries to the sink set with logger.SetSink instead of
+// next, as the loggers of core/logger do.
+type sinkCore struct {
+	next   zapcore.Core
+	fields []zapcore.Field
+}
+
+func withSink(next zapcore.Core) zapcore.Core {
+	return sinkCore{next: next}
+}
+
+func (c sinkCore) Enabled(lvl zapcore.Level) bool {
+	return c.next.Enabled(lvl)
+}
+
+func (c sinkCore) With(fields []zapcore.Field) zapcore.Core {
+	return sinkCore{
+		next:   c.next.With(fields),
+		fields: append(append([]zapcore.Field(nil), c.fields...), fields...),
+	}
+}
+
+func (c sinkCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if logger.GetSink() != nil {
+		if !c.Enabled(e.Level) {
+			return ce
+		}
+		return ce.AddCore(e, c)
+	}
+	return c.next.Check(e, ce)
+}
+
+func (c sinkCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	s := logger.GetSink()
+	if s == nil {
+		return c.next.Write(e, fields)
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range append(append([]zapcore.Field(nil), c.fields...), fields...) {
+		f.AddTo(enc)
+	}
+	keys := make([]string, 0, len(enc.Fields))
+	for k := range enc.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString(e.Message)
+	for _, k := range keys {
+		fmt.Fprintf(&sb, " %s=%v", k, enc.Fields[k])
+	}
+	s.Log(sinkLevel(e.Level), SinkSubsystem, sb.String())
+	return nil
+}
+
+func (c sinkCore) Sync() error {
+	return c.next.Sync()
+}
+
+func sinkLevel(l zapcore.Level) int {
+	switch {
+	case l <= zapcore.DebugLevel:
+		return logger.DEBUG
+	case l <= zapcore.WarnLevel:
+		return logger.INFO
+	case l == zapcore.ErrorLevel:
+		return logger.ERROR
+	}
+	return logger.FATAL
+}
@@ -0,0 +1,29 @@
+package log
+
+import (
+	"io"
+	"testing"
+
+	"github.com/0chain/gosdk/core/logger"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSinkCore_level(t *testing.T) {
+	var got []int
+	logger.SetSink(logger.SinkFunc(func(level int, subsystem, msg string) {
+		got = append(got, level)
+	}))
+	defer logger.SetSink(nil)
+
+	l := zap.New(withSink(zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{}), zapcore.AddSync(io.Discard), zapcore.WarnLevel)))
+	require.False(t, l.Core().Enabled(zapcore.InfoLevel))
+
+	l.Debug("debug")
+	l.Info("info")
+	l.Warn("warn")
+	l.Error("error")
+	require.Equal(t, []int{logger.INFO, logger.ERROR}, got)
+}
@@ -0,0 +1,87 @@
+package zcnbridge
+
+import (
+	"math/big"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// MessageHash returns the hash the authorizers sign for the mint of amount
+// to the address to, for the burn txid of nonce, computed as the messageHash
+// of the Authorizers contract does: the keccak256 of
+// abi.encodePacked(to, amount, txid, nonce). The signatures are of the
+// Ethereum signed message of the hash (EIP-191).
+func MessageHash(to common.Address, amount *big.Int, txid []byte, nonce *big.Int) [32]byte {
+	var hash [32]byte
+	copy(hash[:], crypto.Keccak256(
+		to.Bytes(),
+		math.U256Bytes(new(big.Int).Set(amount)),
+		txid,
+		math.U256Bytes(new(big.Int).Set(nonce)),
+	))
+	return hash
+}
+
+// MintMessageHash returns the MessageHash of the mint of payload.
+func MintMessageHash(payload *ethereum.MintPayload) ([32]byte, error) {
+	if DefaultClientIDEncoder == nil {
+		return [32]byte{}, errors.New("DefaultClientIDEncoder must be setup")
+	}
+	return MessageHash(
+		common.HexToAddress(payload.To),
+		big.NewInt(payload.Amount),
+		DefaultClientIDEncoder(payload.ZCNTxnID),
+		big.NewInt(payload.Nonce),
+	), nil
+}
+
+// VerifySignature checks signature is the signature of messageHash by
+// signer, as the Authorizers contract recovers it.
+func VerifySignature(messageHash [32]byte, signature []byte, signer common.Address) error {
+	recovered, err := recoverSigner(messageHash, signature)
+	if err != nil {
+		return errors.Wrap(ErrInvalidSignature, err.Error())
+	}
+	if recovered != signer {
+		return errors.Wrapf(ErrSignaturesNotAuthorized, "signed by %s, not %s", recovered.Hex(), signer.Hex())
+	}
+	return nil
+}
+
+// VerifyMintPayload returns the signers of the signatures of payload, off
+// chain, so a payload the contract would reject isn't minted. It fails if a
+// signature is malformed, if a signer signed twice, or, if authorizers are
+// given, if a signer isn't one of them. The contract also requires its
+// minimal threshold of signatures, see QueryEthereumMintPayload.
+func VerifyMintPayload(payload *ethereum.MintPayload, authorizers ...common.Address) ([]common.Address, error) {
+	hash, err := MintMessageHash(payload)
+	if err != nil {
+		return nil, err
+	}
+	allowed := make(map[common.Address]bool, len(authorizers))
+	for _, a := range authorizers {
+		allowed[a] = true
+	}
+
+	signers := make([]common.Address, 0, len(payload.Signatures))
+	seen := make(map[common.Address]bool, len(payload.Signatures))
+	for _, sig := range payload.Signatures {
+		signer, err := recoverSigner(hash, sig.Signature)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidSignature, "signature of %s: %v", sig.ID, err)
+		}
+		if seen[signer] {
+			return nil, errors.Wrapf(ErrDuplicateSignature, "%s signed twice", signer.Hex())
+		}
+		if len(authorizers) > 0 && !allowed[signer] {
+			return nil, errors.Wrapf(ErrSignaturesNotAuthorized, "signature of %s by %s", sig.ID, signer.Hex())
+		}
+		seen[signer] = true
+		signers = append(signers, signer)
+	}
+	return signers, nil
+}
@@ -0,0 +1,92 @@
+package zcnbridge
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func signPayload(t *testing.T, payload *ethereum.MintPayload, id string, key *ecdsa.PrivateKey) {
+	hash, err := MintMessageHash(payload)
+	require.NoError(t, err)
+	sig, err := crypto.Sign(accounts.TextHash(hash[:]), key)
+	require.NoError(t, err)
+	sig[crypto.RecoveryIDOffset] += 27
+	payload.Signatures = append(payload.Signatures, &ethereum.AuthorizerSignature{ID: id, Signature: sig})
+}
+
+func TestMessageHash(t *testing.T) {
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	// keccak256(abi.encodePacked(to, uint256(100), "burn", uint256(1)))
+	contract := &fakeAuthorizersContract{}
+	want, err := contract.MessageHash(nil, to, big.NewInt(100), []byte("burn"), big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, want, MessageHash(to, big.NewInt(100), []byte("burn"), big.NewInt(1)))
+}
+
+func TestVerifyMintPayload(t *testing.T) {
+	key1, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key2, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr1, addr2 := crypto.PubkeyToAddress(key1.PublicKey), crypto.PubkeyToAddress(key2.PublicKey)
+
+	newPayload := func() *ethereum.MintPayload {
+		return &ethereum.MintPayload{ZCNTxnID: "burn", Amount: 100, To: "0x00000000000000000000000000000000000000aa", Nonce: 1}
+	}
+
+	t.Run("Test_Valid_Signatures", func(t *testing.T) {
+		payload := newPayload()
+		signPayload(t, payload, "a1", key1)
+		signPayload(t, payload, "a2", key2)
+
+		signers, err := VerifyMintPayload(payload, addr1, addr2)
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{addr1, addr2}, signers)
+
+		hash, err := MintMessageHash(payload)
+		require.NoError(t, err)
+		require.NoError(t, VerifySignature(hash, payload.Signatures[0].Signature, addr1))
+		require.ErrorIs(t, VerifySignature(hash, payload.Signatures[0].Signature, addr2), ErrSignaturesNotAuthorized)
+	})
+
+	t.Run("Test_Not_Authorizer", func(t *testing.T) {
+		payload := newPayload()
+		signPayload(t, payload, "a2", key2)
+
+		_, err := VerifyMintPayload(payload, addr1)
+		require.ErrorIs(t, err, ErrSignaturesNotAuthorized)
+	})
+
+	t.Run("Test_Duplicate_Signer", func(t *testing.T) {
+		payload := newPayload()
+		signPayload(t, payload, "a1", key1)
+		signPayload(t, payload, "a1-again", key1)
+
+		_, err := VerifyMintPayload(payload)
+		require.ErrorIs(t, err, ErrDuplicateSignature)
+	})
+
+	t.Run("Test_Tampered_Payload", func(t *testing.T) {
+		payload := newPayload()
+		signPayload(t, payload, "a1", key1)
+		payload.Amount = 1000
+
+		_, err := VerifyMintPayload(payload, addr1)
+		require.ErrorIs(t, err, ErrSignaturesNotAuthorized)
+	})
+
+	t.Run("Test_Malformed_Signature", func(t *testing.T) {
+		payload := newPayload()
+		payload.Signatures = []*ethereum.AuthorizerSignature{{ID: "a1", Signature: []byte{1, 2, 3}}}
+
+		_, err := VerifyMintPayload(payload)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
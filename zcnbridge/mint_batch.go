@@ -0,0 +1,182 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	binding "github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// MintSubmission is the mint of a payload of MintWZCNBatch.
+type MintSubmission struct {
+	Payload *ethereum.MintPayload `json:"payload"`
+	Hash    string                `json:"hash,omitempty"`
+	Error   string                `json:"error,omitempty"`
+}
+
+// mintSubmitter are the calls sending the mints of a batch, replaced in
+// tests.
+type mintSubmitter struct {
+	estimate func(ctx context.Context, payload *ethereum.MintPayload) (uint64, error)
+	send     func(ctx context.Context, payload *ethereum.MintPayload, gasLimit uint64) (*types.Transaction, error)
+}
+
+// MintWZCNBatch mints the payloads, e.g. of FindUnmintedBurns, without
+// waiting for a mint to be mined before sending the next. The bridge
+// contract has no batch mint, so a transaction is sent per payload, but the
+// key storage is unlocked and the gas price suggested once for the batch,
+// and the nonces are consecutive. The payloads are minted in the order of
+// their nonces. Once a mint of a receiver fails, its later mints aren't
+// sent, the contract minting the nonces of a receiver in order.
+func (b *BridgeClient) MintWZCNBatch(ctx context.Context, payloads []*ethereum.MintPayload) ([]*MintSubmission, error) {
+	if DefaultClientIDEncoder == nil {
+		return nil, errors.New("DefaultClientIDEncoder must be setup")
+	}
+	etherClient, err := b.CreateEthClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etherClient")
+	}
+	defer etherClient.Close()
+
+	contractAddress := common.HexToAddress(b.BridgeAddress)
+	bridgeInstance, err := binding.NewBridge(contractAddress, etherClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create bridge instance")
+	}
+	abi, err := binding.BridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get ABI")
+	}
+
+	opts, err := b.transactor(etherClient)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, err := etherClient.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the gas price")
+	}
+	if err := b.checkGasPrice(gasPrice); err != nil {
+		return nil, err
+	}
+	value := new(big.Int).Mul(big.NewInt(b.Value), big.NewInt(params.Wei))
+
+	s := &mintSubmitter{
+		estimate: func(ctx context.Context, p *ethereum.MintPayload) (uint64, error) {
+			pack, err := abi.Pack("mint", mintArgs(p)...)
+			if err != nil {
+				return 0, errors.Wrap(err, "failed to pack arguments")
+			}
+			return b.estimateGas(ctx, etherClient, eth.CallMsg{
+				To:   &contractAddress,
+				From: opts.From,
+				Data: pack,
+			})
+		},
+		send: func(ctx context.Context, p *ethereum.MintPayload, gasLimit uint64) (*types.Transaction, error) {
+			nonce, err := nonces.acquire(ctx, etherClient, opts.From)
+			if err != nil {
+				return nil, err
+			}
+			txOpts := *opts
+			txOpts.Context = ctx
+			txOpts.Nonce = new(big.Int).SetUint64(nonce)
+			txOpts.GasLimit = gasLimit
+			txOpts.GasPrice = gasPrice
+			txOpts.Value = value
+
+			args := mintArgs(p)
+			tx, err := bridgeInstance.Mint(&txOpts, args[0].(common.Address), args[1].(*big.Int), args[2].([]byte), args[3].(*big.Int), args[4].([][]byte))
+			if err != nil {
+				releaseNonce(&txOpts)
+				return nil, err
+			}
+			return tx, nil
+		},
+	}
+	return s.submit(ctx, payloads), nil
+}
+
+// mintArgs returns the arguments of the mint of the bridge contract minting
+// p.
+func mintArgs(p *ethereum.MintPayload) []interface{} {
+	sigs := make([][]byte, 0, len(p.Signatures))
+	for _, signature := range p.Signatures {
+		sigs = append(sigs, signature.Signature)
+	}
+	return []interface{}{
+		common.HexToAddress(p.To),
+		big.NewInt(p.Amount),
+		DefaultClientIDEncoder(p.ZCNTxnID),
+		big.NewInt(p.Nonce),
+		sigs,
+	}
+}
+
+func (s *mintSubmitter) submit(ctx context.Context, payloads []*ethereum.MintPayload) []*MintSubmission {
+	ordered := make([]*MintSubmission, 0, len(payloads))
+	for _, p := range payloads {
+		ordered = append(ordered, &MintSubmission{Payload: p})
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		pi, pj := ordered[i].Payload, ordered[j].Payload
+		if pi.To != pj.To {
+			return pi.To < pj.To
+		}
+		return pi.Nonce < pj.Nonce
+	})
+
+	// the mints after the first of a receiver may fail the estimation until
+	// the first is mined, they cost the same but for the signatures
+	var maxGas uint64
+	failed := make(map[common.Address]bool)
+	for _, m := range ordered {
+		to := common.HexToAddress(m.Payload.To)
+		if err := ctx.Err(); err != nil {
+			m.Error = err.Error()
+			continue
+		}
+		if failed[to] {
+			m.Error = "an earlier mint of the receiver failed"
+			continue
+		}
+
+		gasLimit, err := s.estimate(ctx, m.Payload)
+		if err != nil {
+			if maxGas == 0 {
+				m.Error = err.Error()
+				failed[to] = true
+				continue
+			}
+			gasLimit = maxGas
+		}
+		if gasLimit > maxGas {
+			maxGas = gasLimit
+		}
+
+		tx, err := s.send(ctx, m.Payload, gasLimit)
+		if err != nil {
+			Logger.Error("Mint WZCN FAILED", zap.String("zcnTxd", m.Payload.ZCNTxnID), zap.Error(err))
+			m.Error = err.Error()
+			failed[to] = true
+			continue
+		}
+		m.Hash = tx.Hash().Hex()
+		Logger.Info(
+			"Posted Mint WZCN",
+			zap.String("hash", m.Hash),
+			zap.Int64("amount", m.Payload.Amount),
+			zap.String("zcnTxd", m.Payload.ZCNTxnID),
+			zap.Int64("nonce", m.Payload.Nonce),
+		)
+	}
+	return ordered
+}
@@ -0,0 +1,67 @@
+package zcnbridge
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMintSubmitter(t *testing.T) {
+	const alice, bob = "0x00000000000000000000000000000000000000a1", "0x00000000000000000000000000000000000000b0"
+
+	t.Run("Test_Mints_In_Nonce_Order", func(t *testing.T) {
+		var sent []int64
+		var gasLimits []uint64
+		s := &mintSubmitter{
+			estimate: func(_ context.Context, p *ethereum.MintPayload) (uint64, error) {
+				if p.Nonce > 1 {
+					// not mintable before the earlier nonce is mined
+					return 0, errors.New("execution reverted")
+				}
+				return 100000, nil
+			},
+			send: func(_ context.Context, p *ethereum.MintPayload, gasLimit uint64) (*types.Transaction, error) {
+				sent = append(sent, p.Nonce)
+				gasLimits = append(gasLimits, gasLimit)
+				return types.NewTx(&types.LegacyTx{Nonce: uint64(len(sent))}), nil
+			},
+		}
+
+		mints := s.submit(context.Background(), []*ethereum.MintPayload{
+			{To: alice, Nonce: 3}, {To: alice, Nonce: 1}, {To: alice, Nonce: 2},
+		})
+		require.Equal(t, []int64{1, 2, 3}, sent)
+		require.Equal(t, []uint64{100000, 100000, 100000}, gasLimits)
+		for _, m := range mints {
+			require.NotEmpty(t, m.Hash)
+			require.Empty(t, m.Error)
+		}
+	})
+
+	t.Run("Test_Failed_Mint_Skips_Receiver", func(t *testing.T) {
+		var sent []string
+		s := &mintSubmitter{
+			estimate: func(context.Context, *ethereum.MintPayload) (uint64, error) { return 100000, nil },
+			send: func(_ context.Context, p *ethereum.MintPayload, _ uint64) (*types.Transaction, error) {
+				if p.To == alice && p.Nonce == 1 {
+					return nil, errors.New("nonce too low")
+				}
+				sent = append(sent, p.To)
+				return types.NewTx(&types.LegacyTx{}), nil
+			},
+		}
+
+		mints := s.submit(context.Background(), []*ethereum.MintPayload{
+			{To: alice, Nonce: 1}, {To: alice, Nonce: 2}, {To: bob, Nonce: 1},
+		})
+		require.Equal(t, []string{bob}, sent)
+		require.Len(t, mints, 3)
+		require.Contains(t, mints[0].Error, "nonce too low")
+		require.NotEmpty(t, mints[1].Error)
+		require.NotEmpty(t, mints[2].Hash)
+	})
+}
@@ -0,0 +1,80 @@
+package zcnbridge
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// pendingNoncer returns the nonce of the next transaction of an account,
+// pending transactions included.
+type pendingNoncer interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// nonceTracker hands out the nonces of the transactions of the Ethereum
+// accounts, so the transactions sent at once by concurrent bridge operations
+// don't get the same nonce: the node only knows of a nonce once the
+// transaction using it is sent.
+type nonceTracker struct {
+	mu   sync.Mutex
+	next map[common.Address]uint64
+}
+
+var nonces = &nonceTracker{next: make(map[common.Address]uint64)}
+
+// acquire returns the nonce of the next transaction of account, the pending
+// nonce of the node unless a higher one was handed out already.
+func (t *nonceTracker) acquire(ctx context.Context, client pendingNoncer, account common.Address) (uint64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	nonce, err := client.PendingNonceAt(ctx, account)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get the nonce of %s", account.Hex())
+	}
+	if next, ok := t.next[account]; ok && next > nonce {
+		nonce = next
+	}
+	t.next[account] = nonce + 1
+	return nonce, nil
+}
+
+// release gives back nonce, acquired for a transaction that couldn't be
+// sent. It's handed out again if no nonce was acquired after it, the node
+// not knowing of it otherwise.
+func (t *nonceTracker) release(account common.Address, nonce uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.next[account] == nonce+1 {
+		t.next[account] = nonce
+	}
+}
+
+// reset forgets the nonces handed out to account, the next one being the
+// pending nonce of the node.
+func (t *nonceTracker) reset(account common.Address) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.next, account)
+}
+
+// ResetNonce forgets the nonces handed out for the transactions of the
+// Ethereum account address, after its transactions were sent by another
+// client or dropped by the nodes.
+func ResetNonce(address string) {
+	nonces.reset(common.HexToAddress(address))
+}
+
+// releaseNonce gives back the nonce of opts, for a transaction that couldn't
+// be sent.
+func releaseNonce(opts *bind.TransactOpts) {
+	if opts.Nonce != nil {
+		nonces.release(opts.From, opts.Nonce.Uint64())
+	}
+}
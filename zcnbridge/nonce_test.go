@@ -0,0 +1,76 @@
+package zcnbridge
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNoncer uint64
+
+func (n fakeNoncer) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return uint64(n), nil
+}
+
+func TestNonceTracker(t *testing.T) {
+	account := common.HexToAddress("0x860FA46F170a87dF44D7bB867AA4a5D2813127c1")
+
+	t.Run("Test_Concurrent_Acquire", func(t *testing.T) {
+		tracker := &nonceTracker{next: make(map[common.Address]uint64)}
+		var (
+			mu   sync.Mutex
+			wg   sync.WaitGroup
+			seen = make(map[uint64]bool)
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				nonce, err := tracker.acquire(context.Background(), fakeNoncer(5), account)
+				require.NoError(t, err)
+				mu.Lock()
+				defer mu.Unlock()
+				seen[nonce] = true
+			}()
+		}
+		wg.Wait()
+		for nonce := uint64(5); nonce < 15; nonce++ {
+			require.True(t, seen[nonce], nonce)
+		}
+	})
+
+	t.Run("Test_Node_Ahead", func(t *testing.T) {
+		tracker := &nonceTracker{next: make(map[common.Address]uint64)}
+		nonce, _ := tracker.acquire(context.Background(), fakeNoncer(5), account)
+		require.Equal(t, uint64(5), nonce)
+		nonce, _ = tracker.acquire(context.Background(), fakeNoncer(9), account)
+		require.Equal(t, uint64(9), nonce, "sent by another client")
+	})
+
+	t.Run("Test_Release", func(t *testing.T) {
+		tracker := &nonceTracker{next: make(map[common.Address]uint64)}
+		first, _ := tracker.acquire(context.Background(), fakeNoncer(5), account)
+		second, _ := tracker.acquire(context.Background(), fakeNoncer(5), account)
+
+		tracker.release(account, first)
+		nonce, _ := tracker.acquire(context.Background(), fakeNoncer(5), account)
+		require.Equal(t, uint64(7), nonce, "a nonce was acquired after the one released")
+
+		tracker.release(account, nonce)
+		tracker.release(account, second)
+		nonce, _ = tracker.acquire(context.Background(), fakeNoncer(5), account)
+		require.Equal(t, second, nonce)
+	})
+
+	t.Run("Test_Reset", func(t *testing.T) {
+		tracker := &nonceTracker{next: make(map[common.Address]uint64)}
+		_, _ = tracker.acquire(context.Background(), fakeNoncer(5), account)
+		_, _ = tracker.acquire(context.Background(), fakeNoncer(5), account)
+		tracker.reset(account)
+		nonce, _ := tracker.acquire(context.Background(), fakeNoncer(5), account)
+		require.Equal(t, uint64(5), nonce)
+	})
+}
@@ -0,0 +1,212 @@
+package zcnbridge
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	"github.com/0chain/gosdk/zcnbridge/zcnsc"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ErrNoOperationStore is returned by ResumePending when the client has no
+// BridgeClient.Operations.
+var ErrNoOperationStore = errors.New("no operation store")
+
+// OperationState is the last step of a BridgeOperation done.
+type OperationState string
+
+const (
+	OpStarted OperationState = "started"
+	// OpAllowanceIncreased is the confirmation of the allowance, WZCNToZCN.
+	OpAllowanceIncreased OperationState = "allowance_increased"
+	// OpBurnSubmitted is the burn sent, but not confirmed, WZCNToZCN.
+	OpBurnSubmitted OperationState = "burn_submitted"
+	OpBurned        OperationState = "burned"
+	OpSigned        OperationState = "signatures_collected"
+	OpMintSubmitted OperationState = "mint_submitted"
+	OpConfirmed     OperationState = "confirmed"
+	// OpFailed operations can't be resumed, see BridgeOperation.Error.
+	OpFailed OperationState = "failed"
+)
+
+// BridgeOperation is the state of a move of tokens across the bridge,
+// recorded by the OperationStore of the client at each step, so an
+// interrupted move is resumed by ResumePending.
+type BridgeOperation struct {
+	ID      string         `json:"id"`
+	State   OperationState `json:"state"`
+	Receipt *BridgeReceipt `json:"receipt"`
+	// EthereumPayload, or ZChainPayload, are the signatures of the burn
+	// collected, minting the tokens.
+	EthereumPayload *ethereum.MintPayload `json:"ethereum_payload,omitempty"`
+	ZChainPayload   *zcnsc.MintPayload    `json:"zchain_payload,omitempty"`
+	// Error is the error of the last step tried.
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newBridgeOperation(direction BridgeDirection, amount uint64) *BridgeOperation {
+	now := time.Now()
+	return &BridgeOperation{
+		ID:        string(direction) + "-" + strconv.FormatInt(now.UnixNano(), 10),
+		State:     OpStarted,
+		Receipt:   &BridgeReceipt{Direction: direction, Amount: amount},
+		CreatedAt: now,
+	}
+}
+
+// Pending tells whether the operation can be resumed.
+func (op *BridgeOperation) Pending() bool {
+	return op.State != OpConfirmed && op.State != OpFailed
+}
+
+// OperationStore persists the bridge operations, e.g. in files, see
+// FileOperationStore, or in a database.
+type OperationStore interface {
+	// Save creates, or replaces, the operation of op.ID.
+	Save(op *BridgeOperation) error
+	// List returns the operations, in the order they were created.
+	List() ([]*BridgeOperation, error)
+}
+
+// FileOperationStore is an OperationStore saving each operation to a JSON
+// file of the directory at its path.
+type FileOperationStore string
+
+// Save writes op to a temporary file renamed to its file, so the operation
+// isn't lost by a crash.
+func (d FileOperationStore) Save(op *BridgeOperation) error {
+	if err := os.MkdirAll(string(d), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+	file := filepath.Join(string(d), op.ID+".json")
+	if err := os.WriteFile(file+".tmp", data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(file+".tmp", file)
+}
+
+// List reads the operations of the directory, none if it doesn't exist.
+func (d FileOperationStore) List() ([]*BridgeOperation, error) {
+	entries, err := os.ReadDir(string(d))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []*BridgeOperation
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(string(d), entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		op := &BridgeOperation{}
+		if err := json.Unmarshal(data, op); err != nil {
+			return nil, errors.Wrapf(err, "invalid operation %s", entry.Name())
+		}
+		ops = append(ops, op)
+	}
+	sort.SliceStable(ops, func(i, j int) bool { return ops[i].CreatedAt.Before(ops[j].CreatedAt) })
+	return ops, nil
+}
+
+// ResumePending continues the operations of BridgeClient.Operations that
+// were interrupted, e.g. by a restart, from their last step done, in the
+// order they were created. It returns the operations resumed, the errors
+// failing them being in BridgeOperation.Error. The operations interrupted
+// while burning, not knowing whether the burn was sent, are failed rather
+// than burnt twice, see FindUnmintedBurns.
+func (b *BridgeClient) ResumePending(ctx context.Context) ([]*BridgeOperation, error) {
+	if b.Operations == nil {
+		return nil, ErrNoOperationStore
+	}
+	ops, err := b.Operations.List()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the bridge operations")
+	}
+
+	s := b.steps()
+	var resumed []*BridgeOperation
+	for _, op := range ops {
+		if !op.Pending() {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return resumed, err
+		}
+		resumed = append(resumed, op)
+		if err := s.resume(ctx, op); err != nil {
+			Logger.Error("failed to resume the bridge operation", zap.String("id", op.ID), zap.Error(err))
+		}
+	}
+	return resumed, nil
+}
+
+// resume continues op from its last step done.
+func (s *bridgeSteps) resume(ctx context.Context, op *BridgeOperation) error {
+	switch op.Receipt.Direction {
+	case ZCNToWZCN:
+		if op.State == OpStarted {
+			return s.abandon(op, errors.New("interrupted while burning ZCN, the burn may have been sent"))
+		}
+		return s.zcnToWZCN(ctx, op)
+	case WZCNToZCN:
+		if op.State == OpAllowanceIncreased {
+			return s.abandon(op, errors.New("interrupted while burning WZCN, the burn may have been sent"))
+		}
+		return s.wzcnToZCN(ctx, op)
+	default:
+		return s.abandon(op, errors.Errorf("unknown direction %q", op.Receipt.Direction))
+	}
+}
+
+// save records op in the store, if any. The errors of the store are logged,
+// not failing the move of the tokens.
+func (s *bridgeSteps) save(op *BridgeOperation) {
+	if s.store == nil {
+		return
+	}
+	op.UpdatedAt = time.Now()
+	if err := s.store.Save(op); err != nil {
+		Logger.Error("failed to save the bridge operation", zap.String("id", op.ID), zap.Error(err))
+	}
+}
+
+// advance records state, the step of op done.
+func (s *bridgeSteps) advance(op *BridgeOperation, state OperationState) {
+	op.State = state
+	op.Error = ""
+	s.save(op)
+}
+
+// fail records err, failing the next step of op, and returns it. The
+// operation stays pending.
+func (s *bridgeSteps) fail(op *BridgeOperation, err error) error {
+	op.Error = err.Error()
+	s.save(op)
+	return err
+}
+
+// abandon records err and fails op, which can't be resumed.
+func (s *bridgeSteps) abandon(op *BridgeOperation, err error) error {
+	op.State = OpFailed
+	return s.fail(op, err)
+}
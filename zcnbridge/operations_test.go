@@ -0,0 +1,103 @@
+package zcnbridge
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridgeOperations(t *testing.T) {
+	t.Run("Test_File_Store", func(t *testing.T) {
+		store := FileOperationStore(t.TempDir())
+		first := newBridgeOperation(ZCNToWZCN, 100)
+		second := newBridgeOperation(WZCNToZCN, 200)
+		require.NoError(t, store.Save(second))
+		require.NoError(t, store.Save(first))
+
+		first.State = OpBurned
+		require.NoError(t, store.Save(first))
+
+		ops, err := store.List()
+		require.NoError(t, err)
+		require.Len(t, ops, 2)
+		require.Equal(t, first.ID, ops[0].ID)
+		require.Equal(t, OpBurned, ops[0].State)
+		require.EqualValues(t, 200, ops[1].Receipt.Amount)
+	})
+
+	t.Run("Test_Resume_Interrupted_Mint", func(t *testing.T) {
+		store := FileOperationStore(t.TempDir())
+		s := fakeSteps()
+		s.store = store
+		s.mintWZCN = func(ctx context.Context, payload *ethereum.MintPayload) (*types.Transaction, error) {
+			return nil, errors.New("connection refused")
+		}
+		_, err := s.burnZCNAndMintWZCN(context.Background(), 100)
+		require.Error(t, err)
+
+		ops, err := store.List()
+		require.NoError(t, err)
+		require.Len(t, ops, 1)
+		require.Equal(t, OpSigned, ops[0].State)
+		require.Contains(t, ops[0].Error, "connection refused")
+
+		// restarted, the burn isn't sent again
+		s = fakeSteps()
+		s.store = store
+		s.burnZCN = func(ctx context.Context, amount uint64) (string, error) {
+			t.Fatal("burnt twice")
+			return "", nil
+		}
+		require.NoError(t, s.resume(context.Background(), ops[0]))
+
+		ops, err = store.List()
+		require.NoError(t, err)
+		require.Equal(t, OpConfirmed, ops[0].State)
+		require.Empty(t, ops[0].Error)
+		require.Equal(t, fakeTx(1).Hash().Hex(), ops[0].Receipt.MintHash)
+		require.EqualValues(t, 42, ops[0].Receipt.MintBlock)
+	})
+
+	t.Run("Test_Resume_Submitted_Mint", func(t *testing.T) {
+		s := fakeSteps()
+		s.mintWZCN = func(ctx context.Context, payload *ethereum.MintPayload) (*types.Transaction, error) {
+			t.Fatal("minted twice")
+			return nil, nil
+		}
+		var waited common.Hash
+		s.waitHash = func(ctx context.Context, hash common.Hash) (*TxStatus, error) {
+			waited = hash
+			return &TxStatus{State: TxConfirmed, BlockNumber: 7}, nil
+		}
+		op := newBridgeOperation(ZCNToWZCN, 100)
+		op.State = OpMintSubmitted
+		op.Receipt.MintHash = fakeTx(1).Hash().Hex()
+
+		require.NoError(t, s.resume(context.Background(), op))
+		require.Equal(t, fakeTx(1).Hash(), waited)
+		require.Equal(t, OpConfirmed, op.State)
+	})
+
+	t.Run("Test_Resume_Interrupted_Burn", func(t *testing.T) {
+		s := fakeSteps()
+		s.burnZCN = func(ctx context.Context, amount uint64) (string, error) {
+			t.Fatal("burnt again")
+			return "", nil
+		}
+		op := newBridgeOperation(ZCNToWZCN, 100)
+
+		require.Error(t, s.resume(context.Background(), op))
+		require.Equal(t, OpFailed, op.State)
+		require.False(t, op.Pending())
+	})
+
+	t.Run("Test_Resume_Without_Store", func(t *testing.T) {
+		_, err := (&BridgeClient{}).ResumePending(context.Background())
+		require.ErrorIs(t, err, ErrNoOperationStore)
+	})
+}
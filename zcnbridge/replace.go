@@ -0,0 +1,173 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ReplacementFeeBump is the least increase, in percents, of the fees of a
+// replacement transaction the nodes accept.
+const ReplacementFeeBump = 10
+
+var (
+	// ErrTransactionNotPending is returned when replacing a transaction
+	// that's mined already or unknown to the node.
+	ErrTransactionNotPending = errors.New("transaction is not pending")
+
+	// ErrReplacementUnderpriced is returned when the fees of a replacement
+	// transaction aren't ReplacementFeeBump percents above the fees of the
+	// transaction replaced.
+	ErrReplacementUnderpriced = errors.New("replacement transaction underpriced")
+)
+
+// SpeedUp sends again the pending transaction txHash of the client with a
+// higher fee: newTip is the priority fee per gas, in wei, of dynamic fee
+// transactions, and the gas price of legacy ones. It must be at least
+// ReplacementFeeBump percents above the fee of txHash. The transaction
+// replacing txHash is returned.
+func (b *BridgeClientConfig) SpeedUp(ctx context.Context, txHash string, newTip *big.Int) (*types.Transaction, error) {
+	return b.replace(ctx, txHash, func(tx *types.Transaction) (types.TxData, error) {
+		return speedUpTx(tx, newTip)
+	})
+}
+
+// Cancel replaces the pending transaction txHash of the client with a
+// transfer of 0 ETH to itself, with fees ReplacementFeeBump percents above
+// the fees of txHash. The transaction replacing txHash is returned, txHash is
+// cancelled once it's mined.
+func (b *BridgeClientConfig) Cancel(ctx context.Context, txHash string) (*types.Transaction, error) {
+	from := common.HexToAddress(b.EthereumAddress)
+	return b.replace(ctx, txHash, func(tx *types.Transaction) (types.TxData, error) {
+		return cancelTx(tx, from), nil
+	})
+}
+
+func (b *BridgeClientConfig) replace(ctx context.Context, txHash string, replacement func(*types.Transaction) (types.TxData, error)) (*types.Transaction, error) {
+	etherClient, err := b.CreateEthClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etherClient")
+	}
+	defer etherClient.Close()
+
+	tx, pending, err := etherClient.TransactionByHash(ctx, common.HexToHash(txHash))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get transaction %s", txHash)
+	}
+	if !pending {
+		return nil, errors.Wrap(ErrTransactionNotPending, txHash)
+	}
+
+	opts, err := b.transactor(etherClient)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil || sender != opts.From {
+		return nil, errors.Errorf("transaction %s wasn't sent by %s", txHash, opts.From.Hex())
+	}
+
+	data, err := replacement(tx)
+	if err != nil {
+		return nil, err
+	}
+	replaced := types.NewTx(data)
+	if err := b.checkGasPrice(replaced.GasFeeCap()); err != nil {
+		return nil, err
+	}
+
+	signed, err := opts.Signer(opts.From, replaced)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign the replacement transaction")
+	}
+	if err := etherClient.SendTransaction(ctx, signed); err != nil {
+		return nil, errors.Wrapf(err, "failed to replace transaction %s", txHash)
+	}
+
+	Logger.Info(
+		"Replaced transaction",
+		zap.String("hash", txHash),
+		zap.String("replacement", signed.Hash().Hex()),
+		zap.Uint64("nonce", signed.Nonce()),
+	)
+	return signed, nil
+}
+
+// bumpFee returns fee increased by ReplacementFeeBump percents, rounded up.
+func bumpFee(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+ReplacementFeeBump))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+func maxBig(x, y *big.Int) *big.Int {
+	if x.Cmp(y) >= 0 {
+		return x
+	}
+	return y
+}
+
+// speedUpTx returns tx with the tip, or the gas price, newTip.
+func speedUpTx(tx *types.Transaction, newTip *big.Int) (types.TxData, error) {
+	if newTip == nil {
+		return nil, errors.Wrap(ErrReplacementUnderpriced, "tip is required")
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		if newTip.Cmp(bumpFee(tx.GasTipCap())) < 0 {
+			return nil, errors.Wrapf(ErrReplacementUnderpriced, "tip %s wei, at least %s wei", newTip, bumpFee(tx.GasTipCap()))
+		}
+		// the fee cap keeps the room of tx for the base fee
+		feeCap := new(big.Int).Sub(tx.GasFeeCap(), tx.GasTipCap())
+		feeCap.Add(feeCap, newTip)
+		return &types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  newTip,
+			GasFeeCap:  maxBig(feeCap, bumpFee(tx.GasFeeCap())),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}, nil
+	}
+
+	if newTip.Cmp(bumpFee(tx.GasPrice())) < 0 {
+		return nil, errors.Wrapf(ErrReplacementUnderpriced, "gas price %s wei, at least %s wei", newTip, bumpFee(tx.GasPrice()))
+	}
+	return &types.LegacyTx{
+		Nonce:    tx.Nonce(),
+		GasPrice: newTip,
+		Gas:      tx.Gas(),
+		To:       tx.To(),
+		Value:    tx.Value(),
+		Data:     tx.Data(),
+	}, nil
+}
+
+// cancelTx returns the transfer of 0 ETH from from to itself replacing tx.
+func cancelTx(tx *types.Transaction, from common.Address) types.TxData {
+	if tx.Type() == types.DynamicFeeTxType {
+		return &types.DynamicFeeTx{
+			ChainID:   tx.ChainId(),
+			Nonce:     tx.Nonce(),
+			GasTipCap: bumpFee(tx.GasTipCap()),
+			GasFeeCap: bumpFee(tx.GasFeeCap()),
+			Gas:       params.TxGas,
+			To:        &from,
+			Value:     new(big.Int),
+		}
+	}
+	return &types.LegacyTx{
+		Nonce:    tx.Nonce(),
+		GasPrice: bumpFee(tx.GasPrice()),
+		Gas:      params.TxGas,
+		To:       &from,
+		Value:    new(big.Int),
+	}
+}
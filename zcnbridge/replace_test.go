@@ -0,0 +1,67 @@
+package zcnbridge
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpFee(t *testing.T) {
+	require.Equal(t, big.NewInt(110), bumpFee(big.NewInt(100)))
+	require.Equal(t, big.NewInt(13), bumpFee(big.NewInt(11)), "rounded up")
+	require.Zero(t, bumpFee(big.NewInt(0)).Sign())
+}
+
+func TestSpeedUpTx(t *testing.T) {
+	to := common.HexToAddress("0xF26B52df8c6D9b9C20bfD7819Bed75a75258c7dB")
+	legacy := types.NewTx(&types.LegacyTx{Nonce: 7, GasPrice: big.NewInt(100), Gas: 50000, To: &to, Data: []byte{1, 2}})
+	dynamic := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(5), Nonce: 7, GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(110), Gas: 50000, To: &to, Data: []byte{1, 2}})
+
+	t.Run("Test_Legacy", func(t *testing.T) {
+		data, err := speedUpTx(legacy, big.NewInt(120))
+		require.NoError(t, err)
+		tx := types.NewTx(data)
+		require.Equal(t, uint64(7), tx.Nonce())
+		require.Equal(t, big.NewInt(120), tx.GasPrice())
+		require.Equal(t, legacy.Data(), tx.Data())
+		require.Equal(t, legacy.Gas(), tx.Gas())
+	})
+
+	t.Run("Test_Dynamic_Fee", func(t *testing.T) {
+		data, err := speedUpTx(dynamic, big.NewInt(20))
+		require.NoError(t, err)
+		tx := types.NewTx(data)
+		require.Equal(t, uint64(7), tx.Nonce())
+		require.Equal(t, big.NewInt(20), tx.GasTipCap())
+		require.Equal(t, big.NewInt(121), tx.GasFeeCap(), "bumped above the room for the base fee")
+	})
+
+	t.Run("Test_Underpriced", func(t *testing.T) {
+		_, err := speedUpTx(legacy, big.NewInt(109))
+		require.Equal(t, ErrReplacementUnderpriced, pkgerrors.Cause(err))
+		_, err = speedUpTx(dynamic, big.NewInt(10))
+		require.Equal(t, ErrReplacementUnderpriced, pkgerrors.Cause(err))
+		_, err = speedUpTx(dynamic, nil)
+		require.Equal(t, ErrReplacementUnderpriced, pkgerrors.Cause(err))
+	})
+}
+
+func TestCancelTx(t *testing.T) {
+	from := common.HexToAddress("0x860FA46F170a87dF44D7bB867AA4a5D2813127c1")
+	to := common.HexToAddress("0xF26B52df8c6D9b9C20bfD7819Bed75a75258c7dB")
+	dynamic := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(5), Nonce: 7, GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(110), Gas: 50000, To: &to, Value: big.NewInt(1), Data: []byte{1, 2}})
+
+	tx := types.NewTx(cancelTx(dynamic, from))
+	require.Equal(t, uint64(7), tx.Nonce())
+	require.Equal(t, from, *tx.To())
+	require.Zero(t, tx.Value().Sign())
+	require.Empty(t, tx.Data())
+	require.Equal(t, params.TxGas, tx.Gas())
+	require.Equal(t, big.NewInt(11), tx.GasTipCap())
+	require.Equal(t, big.NewInt(121), tx.GasFeeCap())
+}
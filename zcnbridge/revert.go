@@ -0,0 +1,192 @@
+package zcnbridge
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum/authorizers"
+	binding "github.com/0chain/gosdk/zcnbridge/ethereum/bridge"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/erc20"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// The errors of the reverts of the bridge, authorizers and token contracts,
+// see RevertError.
+var (
+	// ErrAlreadyMinted is the revert of a mint of a nonce that isn't the
+	// next nonce of the receiver, i.e. the burn was minted already, or an
+	// earlier burn wasn't.
+	ErrAlreadyMinted = errors.New("already minted")
+	// ErrInvalidSignatureCount is the revert of a mint with less signatures
+	// than the minimal threshold of the authorizers contract.
+	ErrInvalidSignatureCount = errors.New("invalid signature count")
+	// ErrSignaturesNotAuthorized is the revert of a mint whose signatures
+	// aren't of authorizers, or not of the burn minted.
+	ErrSignaturesNotAuthorized = errors.New("signatures not authorized")
+	// ErrDuplicateSignature is the revert of a mint signed twice by an
+	// authorizer.
+	ErrDuplicateSignature = errors.New("duplicate signature")
+	// ErrInvalidSignature is the revert of a mint with a malformed signature.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrNotContractOwner is the revert of the calls of the owner of a
+	// contract by another address.
+	ErrNotContractOwner = errors.New("not the owner of the contract")
+	// ErrInsufficientBalance is the revert of a burn or transfer of more
+	// tokens than the balance.
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	// ErrInsufficientAllowance is the revert of a burn or transfer of more
+	// tokens than the allowance of the bridge.
+	ErrInsufficientAllowance = errors.New("insufficient allowance")
+)
+
+// revertReasons are the errors of the reasons of the reverts of the
+// contracts, by a prefix of the reason.
+var revertReasons = []struct {
+	prefix string
+	err    error
+}{
+	{"ifNotMinted: nonce provided", ErrAlreadyMinted},
+	{"Sig count too low", ErrInvalidSignatureCount},
+	{"Authorizers: signatures not auth", ErrSignaturesNotAuthorized},
+	{"Message is not authorized", ErrSignaturesNotAuthorized},
+	{"Duplicated authorizer is used", ErrDuplicateSignature},
+	{"ECDSA: invalid signature", ErrInvalidSignature},
+	{"Address not an Authorizers", ErrNotAuthorizer},
+	{"Address is already authorizer", ErrAlreadyAuthorizer},
+	{"Ownable: caller is not the owner", ErrNotContractOwner},
+	{"ERC20: burn amount exceeds balance", ErrInsufficientBalance},
+	{"ERC20: transfer amount exceeds balance", ErrInsufficientBalance},
+	{"ERC20: transfer amount exceeds allowance", ErrInsufficientAllowance},
+	{"ERC20: insufficient allowance", ErrInsufficientAllowance},
+	{"Bridge: transfer into burn pool", ErrInsufficientAllowance},
+}
+
+var (
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0} // Error(string)
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256)
+)
+
+// RevertError is the decoded revert of a call of the contracts. It unwraps
+// to the typed error of its reason, e.g. ErrAlreadyMinted, if it's known.
+type RevertError struct {
+	// Reason of the revert, the message of Error(string), or the name and
+	// the arguments of a custom error.
+	Reason string
+	// Data is the revert data, if the node returned it.
+	Data []byte
+	err  error
+	// mined is set for the reverts of mined transactions, which are
+	// ErrTransactionReverted too.
+	mined bool
+}
+
+func (e *RevertError) Error() string {
+	return "execution reverted: " + e.Reason
+}
+
+// Unwrap returns the typed error of the reason, nil if it isn't known.
+func (e *RevertError) Unwrap() error {
+	return e.err
+}
+
+// Is tells whether target is ErrTransactionReverted, for the reverts of
+// mined transactions.
+func (e *RevertError) Is(target error) bool {
+	return e.mined && target == ErrTransactionReverted
+}
+
+// DecodeRevert returns err, an error of a node for a call of the contracts,
+// as a *RevertError if it's a revert, err otherwise.
+func DecodeRevert(err error) error {
+	if err == nil {
+		return nil
+	}
+	var revert *RevertError
+	if errors.As(err, &revert) {
+		return err
+	}
+
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if hex, ok := dataErr.ErrorData().(string); ok {
+			if data, decodeErr := hexutil.Decode(hex); decodeErr == nil && len(data) >= 4 {
+				return decodeRevertData(data)
+			}
+		}
+	}
+
+	// nodes not returning the data have the reason in the message
+	msg := err.Error()
+	i := strings.Index(msg, "execution reverted")
+	if i < 0 {
+		return err
+	}
+	reason := strings.TrimPrefix(strings.TrimPrefix(msg[i:], "execution reverted"), ": ")
+	if reason == "" {
+		reason = "unknown"
+	}
+	return &RevertError{Reason: reason, err: reasonError(reason)}
+}
+
+// decodeRevertData decodes the revert data against Error(string),
+// Panic(uint256) and the custom errors of the contracts.
+func decodeRevertData(data []byte) *RevertError {
+	revert := &RevertError{Data: data, Reason: hexutil.Encode(data)}
+	switch {
+	case bytes.Equal(data[:4], errorSelector):
+		if reason, err := abi.UnpackRevert(data); err == nil {
+			revert.Reason = reason
+			revert.err = reasonError(reason)
+		}
+	case bytes.Equal(data[:4], panicSelector):
+		if len(data) == 36 {
+			revert.Reason = fmt.Sprintf("panic 0x%x", new(big.Int).SetBytes(data[4:]))
+		}
+	default:
+		if name, args, ok := decodeCustomError(data); ok {
+			revert.Reason = fmt.Sprintf("%s%v", name, args)
+		}
+	}
+	return revert
+}
+
+// decodeCustomError decodes data against the custom errors of the ABIs of
+// the contracts.
+func decodeCustomError(data []byte) (string, []interface{}, bool) {
+	for _, getABI := range []func() (*abi.ABI, error){
+		binding.BridgeMetaData.GetAbi,
+		authorizers.AuthorizersMetaData.GetAbi,
+		erc20.ERC20MetaData.GetAbi,
+	} {
+		contractABI, err := getABI()
+		if err != nil {
+			continue
+		}
+		for _, e := range contractABI.Errors {
+			if !bytes.Equal(e.ID[:4], data[:4]) {
+				continue
+			}
+			args, err := e.Inputs.Unpack(data[4:])
+			if err != nil {
+				continue
+			}
+			return e.Name, args, true
+		}
+	}
+	return "", nil, false
+}
+
+// reasonError returns the typed error of reason, nil if it isn't known.
+func reasonError(reason string) error {
+	for _, r := range revertReasons {
+		if strings.HasPrefix(reason, r.prefix) {
+			return r.err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package zcnbridge
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+// dataError is an error of a node with the revert data.
+type dataError struct{ data string }
+
+func (e *dataError) Error() string          { return "execution reverted" }
+func (e *dataError) ErrorCode() int         { return 3 }
+func (e *dataError) ErrorData() interface{} { return e.data }
+
+func errorData(t *testing.T, reason string) string {
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	packed, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	require.NoError(t, err)
+	return hexutil.Encode(append([]byte{0x08, 0xc3, 0x79, 0xa0}, packed...))
+}
+
+func TestDecodeRevert(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantReason string
+		wantErr    error
+	}{
+		{
+			name:       "Test_Error_Data_Already_Minted",
+			err:        &dataError{errorData(t, "ifNotMinted: nonce provided must be 1 greater than the previous burn nonce")},
+			wantReason: "ifNotMinted: nonce provided must be 1 greater than the previous burn nonce",
+			wantErr:    ErrAlreadyMinted,
+		},
+		{
+			name:       "Test_Error_Data_Signature_Count",
+			err:        &dataError{errorData(t, "Sig count too low")},
+			wantReason: "Sig count too low",
+			wantErr:    ErrInvalidSignatureCount,
+		},
+		{
+			name:       "Test_Message_Not_Authorizer",
+			err:        errors.New("execution reverted: Address not an Authorizers"),
+			wantReason: "Address not an Authorizers",
+			wantErr:    ErrNotAuthorizer,
+		},
+		{
+			name:       "Test_Panic_Data",
+			err:        &dataError{"0x4e487b710000000000000000000000000000000000000000000000000000000000000011"},
+			wantReason: "panic 0x11",
+		},
+		{
+			name:       "Test_Unknown_Reason",
+			err:        errors.New("execution reverted: something else"),
+			wantReason: "something else",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var revert *RevertError
+			require.True(t, errors.As(DecodeRevert(tt.err), &revert))
+			require.Equal(t, tt.wantReason, revert.Reason)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, revert, tt.wantErr)
+			} else {
+				require.NoError(t, revert.Unwrap())
+			}
+			require.False(t, errors.Is(revert, ErrTransactionReverted))
+		})
+	}
+
+	t.Run("Test_Not_A_Revert", func(t *testing.T) {
+		err := errors.New("connection refused")
+		require.Equal(t, err, DecodeRevert(err))
+	})
+
+	t.Run("Test_Mined_Revert", func(t *testing.T) {
+		revert := &RevertError{Reason: "Sig count too low", err: ErrInvalidSignatureCount, mined: true}
+		require.ErrorIs(t, revert, ErrTransactionReverted)
+		require.ErrorIs(t, revert, ErrInvalidSignatureCount)
+	})
+}
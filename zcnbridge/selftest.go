@@ -0,0 +1,260 @@
+package zcnbridge
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum/authorizers"
+	"github.com/0chain/gosdk/zcnbridge/ethereum/erc20"
+	"github.com/0chain/gosdk/zcncore"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+)
+
+// selfTestTimeout bounds each network call of SelfTest
+const selfTestTimeout = 10 * time.Second
+
+type (
+	// SelfTestCheck is the outcome of one SelfTest step
+	SelfTestCheck struct {
+		Name     string        `json:"name"`
+		OK       bool          `json:"ok"`
+		Detail   string        `json:"detail,omitempty"`
+		Error    string        `json:"error,omitempty"`
+		Duration time.Duration `json:"duration"`
+	}
+
+	// SelfTestReport is the diagnostic report returned by SelfTest
+	SelfTestReport struct {
+		StartedAt time.Time        `json:"started_at"`
+		OK        bool             `json:"ok"`
+		Checks    []*SelfTestCheck `json:"checks"`
+	}
+)
+
+func (r *SelfTestReport) run(name string, check func() (string, error)) bool {
+	start := time.Now()
+	detail, err := check()
+	c := &SelfTestCheck{Name: name, OK: err == nil, Detail: detail, Duration: time.Since(start)}
+	if err != nil {
+		c.Error = err.Error()
+		r.OK = false
+	}
+	r.Checks = append(r.Checks, c)
+	return c.OK
+}
+
+func (r *SelfTestReport) skip(name, reason string) {
+	r.OK = false
+	r.Checks = append(r.Checks, &SelfTestCheck{Name: name, Error: "skipped: " + reason})
+}
+
+// String renders the report one check per line, for support tickets.
+func (r *SelfTestReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "bridge self test at %s: ok=%v\n", r.StartedAt.Format(time.RFC3339), r.OK)
+	for _, c := range r.Checks {
+		status := "OK  "
+		if !c.OK {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&sb, "[%s] %-24s %8s %s%s\n", status, c.Name, c.Duration.Round(time.Millisecond), c.Detail, c.Error)
+	}
+	return sb.String()
+}
+
+// SelfTest runs a minimal end-to-end check of the bridge setup: Ethereum RPC
+// reachability, deployed contracts, authorizers reachability, wallet balances
+// and an eth_call of the authorizers messageHash. Failures are reported in
+// the returned report, not as an error.
+func (b *BridgeClient) SelfTest(ctx context.Context) *SelfTestReport {
+	report := &SelfTestReport{StartedAt: time.Now(), OK: true}
+
+	var etherClient *ethclient.Client
+	rpcOK := report.run("ethereum_rpc", func() (string, error) {
+		var err error
+		etherClient, err = b.CreateEthClient()
+		if err != nil {
+			return "", err
+		}
+		cctx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+		defer cancel()
+		chainID, err := etherClient.ChainID(cctx)
+		if err != nil {
+			return "", err
+		}
+		if b.BridgeConfig != nil && b.ChainID != 0 {
+			if err := checkChainID(chainID, b.ChainID); err != nil {
+				return "", err
+			}
+		}
+		block, err := etherClient.BlockNumber(cctx)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("chain %s, block %d", chainID, block), nil
+	})
+	if etherClient != nil {
+		defer etherClient.Close()
+	}
+
+	contracts := []struct{ name, address string }{
+		{"bridge_contract", b.BridgeAddress},
+		{"wzcn_contract", b.WzcnAddress},
+		{"authorizers_contract", b.AuthorizersAddress},
+	}
+	for _, contract := range contracts {
+		if !rpcOK {
+			report.skip(contract.name, "ethereum rpc unreachable")
+			continue
+		}
+		address := contract.address
+		report.run(contract.name, func() (string, error) {
+			if !common.IsHexAddress(address) {
+				return "", fmt.Errorf("invalid address %q", address)
+			}
+			cctx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+			defer cancel()
+			code, err := etherClient.CodeAt(cctx, common.HexToAddress(address), nil)
+			if err != nil {
+				return "", err
+			}
+			if len(code) == 0 {
+				return "", fmt.Errorf("no contract deployed at %s", address)
+			}
+			return address, nil
+		})
+	}
+
+	if rpcOK {
+		report.run("ethereum_balances", func() (string, error) {
+			cctx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+			defer cancel()
+			owner := common.HexToAddress(b.EthereumAddress)
+			eth, err := etherClient.BalanceAt(cctx, owner, nil)
+			if err != nil {
+				return "", errors.Wrap(err, "eth balance")
+			}
+			token, err := erc20.NewERC20Caller(common.HexToAddress(b.WzcnAddress), etherClient)
+			if err != nil {
+				return "", err
+			}
+			wzcn, err := token.BalanceOf(&bind.CallOpts{Context: cctx}, owner)
+			if err != nil {
+				return "", errors.Wrap(err, "wzcn balance")
+			}
+			return fmt.Sprintf("%s: %s wei, %s wzcn", owner.Hex(), eth, wzcn), nil
+		})
+
+		report.run("message_hash_call", func() (string, error) {
+			caller, err := authorizers.NewAuthorizersCaller(common.HexToAddress(b.AuthorizersAddress), etherClient)
+			if err != nil {
+				return "", err
+			}
+			cctx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+			defer cancel()
+			hash, err := caller.MessageHash(&bind.CallOpts{Context: cctx},
+				common.HexToAddress(b.EthereumAddress), big.NewInt(1), []byte("selftest"), big.NewInt(0))
+			if err != nil {
+				return "", err
+			}
+			return common.Hash(hash).Hex(), nil
+		})
+	} else {
+		report.skip("ethereum_balances", "ethereum rpc unreachable")
+		report.skip("message_hash_call", "ethereum rpc unreachable")
+	}
+
+	report.run("zcn_balance", func() (string, error) {
+		cb := &selfTestBalance{}
+		cb.wg.Add(1)
+		if err := zcncore.GetBalance(cb); err != nil {
+			return "", err
+		}
+		cb.wg.Wait()
+		if cb.status != zcncore.StatusSuccess {
+			return "", errors.New(cb.info)
+		}
+		return fmt.Sprintf("%v ZCN", zcncore.ConvertToToken(cb.value)), nil
+	})
+
+	report.run("authorizers", func() (string, error) {
+		nodes, err := getAuthorizers()
+		if err != nil {
+			return "", err
+		}
+		if len(nodes) == 0 {
+			return "", errors.New("no authorizers registered")
+		}
+		reachable, failures := pingAuthorizers(ctx, nodes)
+		detail := fmt.Sprintf("%d/%d reachable", reachable, len(nodes))
+		if reachable == 0 {
+			return detail, errors.New(strings.Join(failures, "; "))
+		}
+		return detail, nil
+	})
+
+	return report
+}
+
+type selfTestBalance struct {
+	wg     sync.WaitGroup
+	status int
+	value  int64
+	info   string
+}
+
+func (s *selfTestBalance) OnBalanceAvailable(status int, value int64, info string) {
+	defer s.wg.Done()
+	s.status, s.value, s.info = status, value, info
+}
+
+// pingAuthorizers checks that every authorizer answers http requests.
+func pingAuthorizers(ctx context.Context, nodes []*AuthorizerNode) (reachable int, failures []string) {
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node *AuthorizerNode) {
+			defer wg.Done()
+			cctx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+			defer cancel()
+			err := pingURL(cctx, node.URL)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", node.ID, err))
+				return
+			}
+			reachable++
+		}(node)
+	}
+	wg.Wait()
+	return
+}
+
+func pingURL(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
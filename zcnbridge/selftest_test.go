@@ -0,0 +1,39 @@
+package zcnbridge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfTestReport(t *testing.T) {
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ok.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer broken.Close()
+
+	reachable, failures := pingAuthorizers(context.TODO(), []*AuthorizerNode{
+		{ID: "ok", URL: ok.URL},
+		{ID: "broken", URL: broken.URL},
+	})
+	require.Equal(t, 1, reachable)
+	require.Len(t, failures, 1)
+	require.True(t, strings.HasPrefix(failures[0], "broken:"))
+
+	report := &SelfTestReport{OK: true}
+	report.run("passing", func() (string, error) { return "fine", nil })
+	require.True(t, report.OK)
+	report.run("failing", func() (string, error) { return "", errors.New("boom") })
+	report.skip("skipped", "no rpc")
+	require.False(t, report.OK)
+	require.Len(t, report.Checks, 3)
+	require.Equal(t, "skipped: no rpc", report.Checks[2].Error)
+	require.Contains(t, report.String(), "[FAIL] failing")
+}
@@ -0,0 +1,74 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+)
+
+// ErrSignerMismatch is returned when an external signer signs for another
+// address than the address of the client.
+var ErrSignerMismatch = errors.New("transaction signed by another address")
+
+// HashSigner signs the digest of a transaction, returning the 65 bytes
+// [R || S || V] signature, V being 0 or 1, e.g. with a remote KMS.
+type HashSigner func(ctx context.Context, digest []byte) ([]byte, error)
+
+// WalletSigner signs the transactions with account of wallet, e.g. a Ledger
+// or a Trezor opened with go-ethereum accounts/usbwallet, for the chain
+// chainID.
+func WalletSigner(wallet accounts.Wallet, account accounts.Account, chainID *big.Int) bind.SignerFn {
+	return func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != account.Address {
+			return nil, bind.ErrNotAuthorized
+		}
+		return wallet.SignTx(account, tx, chainID)
+	}
+}
+
+// ExternalSigner signs the transactions of address for the chain chainID with
+// sign. The signature is checked to be of address, so a misconfigured signer
+// fails before the transaction is sent.
+func ExternalSigner(address common.Address, chainID *big.Int, sign HashSigner) bind.SignerFn {
+	signer := types.LatestSignerForChainID(chainID)
+	return func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if from != address {
+			return nil, bind.ErrNotAuthorized
+		}
+		signature, err := sign(context.Background(), signer.Hash(tx).Bytes())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to sign the transaction")
+		}
+		signed, err := tx.WithSignature(signer, signature)
+		if err != nil {
+			return nil, err
+		}
+		sender, err := types.Sender(signer, signed)
+		if err != nil {
+			return nil, err
+		}
+		if sender != address {
+			return nil, errors.Wrapf(ErrSignerMismatch, "signed by %s, not %s", sender.Hex(), address.Hex())
+		}
+		return signed, nil
+	}
+}
+
+// transactor returns the transactor of the Ethereum address of the client,
+// signing with Signer if it's set, with the key storage otherwise.
+func (b *BridgeClientConfig) transactor(client *ethclient.Client) (*bind.TransactOpts, error) {
+	if b.Signer == nil {
+		return b.keyStoreTransactor(client)
+	}
+	return &bind.TransactOpts{
+		From:    common.HexToAddress(b.EthereumAddress),
+		Signer:  b.Signer,
+		Context: context.Background(),
+	}, nil
+}
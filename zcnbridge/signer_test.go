@@ -0,0 +1,53 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalSigner(t *testing.T) {
+	chainID := big.NewInt(5)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	kms := func(_ context.Context, digest []byte) ([]byte, error) {
+		return crypto.Sign(digest, key)
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 1, Gas: 21000})
+
+	t.Run("Test_Signed_By_The_Address", func(t *testing.T) {
+		signed, err := ExternalSigner(address, chainID, kms)(address, tx)
+		require.NoError(t, err)
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		require.NoError(t, err)
+		require.Equal(t, address, sender)
+	})
+
+	t.Run("Test_Signed_By_Another_Address", func(t *testing.T) {
+		other := common.HexToAddress("0x1")
+		_, err := ExternalSigner(other, chainID, kms)(other, tx)
+		require.ErrorIs(t, err, ErrSignerMismatch)
+	})
+
+	t.Run("Test_Other_Sender", func(t *testing.T) {
+		_, err := ExternalSigner(address, chainID, kms)(common.HexToAddress("0x1"), tx)
+		require.ErrorIs(t, err, bind.ErrNotAuthorized)
+	})
+
+	t.Run("Test_Transactor_Uses_Signer", func(t *testing.T) {
+		cfg := &BridgeClientConfig{EthereumAddress: address.Hex(), Signer: ExternalSigner(address, chainID, kms)}
+		opts, err := cfg.transactor(nil)
+		require.NoError(t, err)
+		require.Equal(t, address, opts.From)
+
+		_, err = opts.Signer(opts.From, tx)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,28 @@
+package swap
+
+// The parts of the Uniswap contracts the swaps use, the v3 ones being the
+// SwapRouter and the Quoter of the periphery v1.
+const (
+	routerV2ABI = `[
+{"name":"getAmountsOut","type":"function","stateMutability":"view",
+ "inputs":[{"name":"amountIn","type":"uint256"},{"name":"path","type":"address[]"}],
+ "outputs":[{"name":"amounts","type":"uint256[]"}]},
+{"name":"swapExactTokensForTokens","type":"function","stateMutability":"nonpayable",
+ "inputs":[{"name":"amountIn","type":"uint256"},{"name":"amountOutMin","type":"uint256"},{"name":"path","type":"address[]"},{"name":"to","type":"address"},{"name":"deadline","type":"uint256"}],
+ "outputs":[{"name":"amounts","type":"uint256[]"}]}
+]`
+
+	routerV3ABI = `[
+{"name":"exactInputSingle","type":"function","stateMutability":"payable",
+ "inputs":[{"name":"params","type":"tuple","components":[
+  {"name":"tokenIn","type":"address"},{"name":"tokenOut","type":"address"},{"name":"fee","type":"uint24"},{"name":"recipient","type":"address"},
+  {"name":"deadline","type":"uint256"},{"name":"amountIn","type":"uint256"},{"name":"amountOutMinimum","type":"uint256"},{"name":"sqrtPriceLimitX96","type":"uint160"}]}],
+ "outputs":[{"name":"amountOut","type":"uint256"}]}
+]`
+
+	quoterV3ABI = `[
+{"name":"quoteExactInputSingle","type":"function","stateMutability":"nonpayable",
+ "inputs":[{"name":"tokenIn","type":"address"},{"name":"tokenOut","type":"address"},{"name":"fee","type":"uint24"},{"name":"amountIn","type":"uint256"},{"name":"sqrtPriceLimitX96","type":"uint160"}],
+ "outputs":[{"name":"amountOut","type":"uint256"}]}
+]`
+)
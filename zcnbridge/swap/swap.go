@@ -0,0 +1,227 @@
+// Package swap swaps the tokens of the bridge, e.g. WZCN to a stablecoin
+// once minted, through a Uniswap v2 or v3 router, with the quotes, slippage
+// limits and deadlines of the swaps.
+package swap
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// RouterVersion is the interface of the router of Config.
+type RouterVersion int
+
+const (
+	// UniswapV2 routers swap along the path of pairs of the tokens.
+	UniswapV2 RouterVersion = iota
+	// UniswapV3 routers swap in the pool of the fee tier of the tokens,
+	// quoted by the Quoter.
+	UniswapV3
+)
+
+const (
+	// DefaultSlippageBps is the slippage limit if Config.SlippageBps isn't set.
+	DefaultSlippageBps = 50
+	// DefaultDeadline is the deadline of the swaps if Config.Deadline isn't set.
+	DefaultDeadline = 20 * time.Minute
+	// DefaultFee is the v3 pool fee tier if Config.Fee isn't set, 0.3%.
+	DefaultFee = 3000
+)
+
+// ErrQuoteExpired is returned when swapping with a quote past its deadline.
+var ErrQuoteExpired = errors.New("quote expired")
+
+// Config is the DEX the swaps go through.
+type Config struct {
+	Version RouterVersion
+	// Router is the address of the UniswapV2Router02, or the v3 SwapRouter.
+	Router string
+	// Quoter is the address of the v3 Quoter, unused by v2.
+	Quoter string
+	// Fee is the fee tier of the v3 pool, in hundredths of a bip.
+	Fee uint32
+	// SlippageBps is the maximal difference, in basis points, between the
+	// amount quoted and the amount swapped.
+	SlippageBps uint64
+	// Deadline is the time after the quote a swap is rejected by the router.
+	Deadline time.Duration
+}
+
+// Quote is the amount a swap returns.
+type Quote struct {
+	TokenIn  common.Address `json:"token_in"`
+	TokenOut common.Address `json:"token_out"`
+	AmountIn *big.Int       `json:"amount_in"`
+	// AmountOut is the amount quoted, MinAmountOut the amount the swap fails
+	// below, within the slippage limit.
+	AmountOut    *big.Int  `json:"amount_out"`
+	MinAmountOut *big.Int  `json:"min_amount_out"`
+	Deadline     time.Time `json:"deadline"`
+}
+
+// Swapper swaps through the router of its Config.
+type Swapper struct {
+	Config
+	router *bind.BoundContract
+	quoter *bind.BoundContract
+	abi    abi.ABI
+}
+
+// New binds the router, and quoter, of cfg.
+func New(cfg Config, backend bind.ContractBackend) (*Swapper, error) {
+	if !common.IsHexAddress(cfg.Router) {
+		return nil, errors.Errorf("invalid router address %q", cfg.Router)
+	}
+	if cfg.SlippageBps == 0 {
+		cfg.SlippageBps = DefaultSlippageBps
+	}
+	if cfg.SlippageBps >= 10000 {
+		return nil, errors.Errorf("invalid slippage %d bps", cfg.SlippageBps)
+	}
+	if cfg.Deadline <= 0 {
+		cfg.Deadline = DefaultDeadline
+	}
+	if cfg.Fee == 0 {
+		cfg.Fee = DefaultFee
+	}
+
+	s := &Swapper{Config: cfg}
+	routerABI := routerV2ABI
+	if cfg.Version == UniswapV3 {
+		if !common.IsHexAddress(cfg.Quoter) {
+			return nil, errors.Errorf("invalid quoter address %q", cfg.Quoter)
+		}
+		quoterABI, err := abi.JSON(strings.NewReader(quoterV3ABI))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse quoter ABI")
+		}
+		s.quoter = bind.NewBoundContract(common.HexToAddress(cfg.Quoter), quoterABI, backend, backend, backend)
+		routerABI = routerV3ABI
+	}
+
+	var err error
+	if s.abi, err = abi.JSON(strings.NewReader(routerABI)); err != nil {
+		return nil, errors.Wrap(err, "failed to parse router ABI")
+	}
+	s.router = bind.NewBoundContract(common.HexToAddress(cfg.Router), s.abi, backend, backend, backend)
+	return s, nil
+}
+
+// RouterAddress is the address of the router, the spender of the tokens
+// swapped.
+func (s *Swapper) RouterAddress() common.Address {
+	return common.HexToAddress(s.Router)
+}
+
+// Quote returns the amount of tokenOut swapping amountIn of tokenIn returns,
+// and the minimal amount within the slippage limit.
+func (s *Swapper) Quote(ctx context.Context, tokenIn, tokenOut common.Address, amountIn *big.Int) (*Quote, error) {
+	if amountIn == nil || amountIn.Sign() <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+
+	var out []interface{}
+	opts := &bind.CallOpts{Context: ctx}
+	switch s.Version {
+	case UniswapV3:
+		err := s.quoter.Call(opts, &out, "quoteExactInputSingle", tokenIn, tokenOut, big.NewInt(int64(s.Fee)), amountIn, new(big.Int))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get the quote")
+		}
+	default:
+		err := s.router.Call(opts, &out, "getAmountsOut", amountIn, []common.Address{tokenIn, tokenOut})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get the quote")
+		}
+	}
+
+	amountOut, err := quotedAmount(out)
+	if err != nil {
+		return nil, err
+	}
+	minAmountOut := new(big.Int).Mul(amountOut, big.NewInt(int64(10000-s.SlippageBps)))
+	minAmountOut.Div(minAmountOut, big.NewInt(10000))
+	return &Quote{
+		TokenIn:      tokenIn,
+		TokenOut:     tokenOut,
+		AmountIn:     amountIn,
+		AmountOut:    amountOut,
+		MinAmountOut: minAmountOut,
+		Deadline:     time.Now().Add(s.Deadline),
+	}, nil
+}
+
+// quotedAmount returns the amount out of the result of the quote call, the
+// last amount of the path for v2.
+func quotedAmount(out []interface{}) (*big.Int, error) {
+	if len(out) != 1 {
+		return nil, errors.Errorf("invalid quote of %d values", len(out))
+	}
+	switch amount := out[0].(type) {
+	case *big.Int:
+		return amount, nil
+	case []*big.Int:
+		if len(amount) > 0 {
+			return amount[len(amount)-1], nil
+		}
+	}
+	return nil, errors.Errorf("invalid quote %v", out[0])
+}
+
+// Data returns the call data of the router swapping as quoted by q, to
+// recipient.
+func (s *Swapper) Data(q *Quote, recipient common.Address) ([]byte, error) {
+	if !time.Now().Before(q.Deadline) {
+		return nil, errors.Wrapf(ErrQuoteExpired, "deadline %s", q.Deadline.Format(time.RFC3339))
+	}
+	deadline := big.NewInt(q.Deadline.Unix())
+
+	if s.Version == UniswapV3 {
+		params := struct {
+			TokenIn           common.Address
+			TokenOut          common.Address
+			Fee               *big.Int
+			Recipient         common.Address
+			Deadline          *big.Int
+			AmountIn          *big.Int
+			AmountOutMinimum  *big.Int
+			SqrtPriceLimitX96 *big.Int
+		}{q.TokenIn, q.TokenOut, big.NewInt(int64(s.Fee)), recipient, deadline, q.AmountIn, q.MinAmountOut, new(big.Int)}
+		return s.abi.Pack("exactInputSingle", params)
+	}
+	return s.abi.Pack("swapExactTokensForTokens", q.AmountIn, q.MinAmountOut, []common.Address{q.TokenIn, q.TokenOut}, recipient, deadline)
+}
+
+// CallMsg returns the message of the swap of q sent by from, for the gas
+// estimation.
+func (s *Swapper) CallMsg(q *Quote, from common.Address) (eth.CallMsg, error) {
+	data, err := s.Data(q, from)
+	if err != nil {
+		return eth.CallMsg{}, err
+	}
+	router := s.RouterAddress()
+	return eth.CallMsg{From: from, To: &router, Data: data}, nil
+}
+
+// Swap sends the swap of q to recipient. The router must be allowed to
+// transfer q.AmountIn of q.TokenIn from the sender of opts.
+func (s *Swapper) Swap(opts *bind.TransactOpts, q *Quote, recipient common.Address) (*types.Transaction, error) {
+	data, err := s.Data(q, recipient)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.router.RawTransact(opts, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send the swap")
+	}
+	return tx, nil
+}
@@ -0,0 +1,107 @@
+package swap
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBackend answers the calls of the contracts with result, the calls of
+// the other methods panic.
+type fakeBackend struct {
+	bind.ContractBackend
+	result []byte
+	calls  []eth.CallMsg
+}
+
+func (f *fakeBackend) CallContract(_ context.Context, msg eth.CallMsg, _ *big.Int) ([]byte, error) {
+	f.calls = append(f.calls, msg)
+	return f.result, nil
+}
+
+func (f *fakeBackend) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func pack(t *testing.T, contractABI, method string, values ...interface{}) []byte {
+	parsed, err := abi.JSON(strings.NewReader(contractABI))
+	require.NoError(t, err)
+	out, err := parsed.Methods[method].Outputs.Pack(values...)
+	require.NoError(t, err)
+	return out
+}
+
+func TestSwapper(t *testing.T) {
+	ctx := context.Background()
+	wzcn := common.HexToAddress("0x1")
+	usdc := common.HexToAddress("0x2")
+	router := common.HexToAddress("0x3").Hex()
+	quoter := common.HexToAddress("0x4").Hex()
+
+	t.Run("Test_Quote_V2", func(t *testing.T) {
+		backend := &fakeBackend{result: pack(t, routerV2ABI, "getAmountsOut", []*big.Int{big.NewInt(100), big.NewInt(1000)})}
+		s, err := New(Config{Router: router, SlippageBps: 100}, backend)
+		require.NoError(t, err)
+
+		q, err := s.Quote(ctx, wzcn, usdc, big.NewInt(100))
+		require.NoError(t, err)
+		require.EqualValues(t, 1000, q.AmountOut.Int64())
+		require.EqualValues(t, 990, q.MinAmountOut.Int64())
+		require.Equal(t, common.HexToAddress(router), *backend.calls[0].To)
+	})
+
+	t.Run("Test_Quote_V3", func(t *testing.T) {
+		backend := &fakeBackend{result: pack(t, quoterV3ABI, "quoteExactInputSingle", big.NewInt(2000))}
+		s, err := New(Config{Version: UniswapV3, Router: router, Quoter: quoter}, backend)
+		require.NoError(t, err)
+
+		q, err := s.Quote(ctx, wzcn, usdc, big.NewInt(100))
+		require.NoError(t, err)
+		require.EqualValues(t, 2000, q.AmountOut.Int64())
+		require.EqualValues(t, 1990, q.MinAmountOut.Int64())
+		require.Equal(t, common.HexToAddress(quoter), *backend.calls[0].To)
+
+		_, err = s.Data(q, wzcn)
+		require.NoError(t, err)
+	})
+
+	t.Run("Test_Swap_Data", func(t *testing.T) {
+		s, err := New(Config{Router: router}, &fakeBackend{})
+		require.NoError(t, err)
+		q := &Quote{TokenIn: wzcn, TokenOut: usdc, AmountIn: big.NewInt(100), MinAmountOut: big.NewInt(90), Deadline: time.Now().Add(time.Minute)}
+
+		data, err := s.Data(q, wzcn)
+		require.NoError(t, err)
+		args, err := s.abi.Methods["swapExactTokensForTokens"].Inputs.Unpack(data[4:])
+		require.NoError(t, err)
+		require.EqualValues(t, 90, args[1].(*big.Int).Int64())
+		require.Equal(t, []common.Address{wzcn, usdc}, args[2])
+		require.EqualValues(t, q.Deadline.Unix(), args[4].(*big.Int).Int64())
+	})
+
+	t.Run("Test_Quote_Expired", func(t *testing.T) {
+		s, err := New(Config{Router: router}, &fakeBackend{})
+		require.NoError(t, err)
+		q := &Quote{TokenIn: wzcn, TokenOut: usdc, AmountIn: big.NewInt(100), MinAmountOut: big.NewInt(90), Deadline: time.Now().Add(-time.Second)}
+
+		_, err = s.Data(q, wzcn)
+		require.ErrorIs(t, err, ErrQuoteExpired)
+	})
+
+	t.Run("Test_Invalid_Config", func(t *testing.T) {
+		_, err := New(Config{Router: "router"}, &fakeBackend{})
+		require.Error(t, err)
+		_, err = New(Config{Version: UniswapV3, Router: router}, &fakeBackend{})
+		require.Error(t, err)
+		_, err = New(Config{Router: router, SlippageBps: 10000}, &fakeBackend{})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,123 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/0chain/gosdk/zcnbridge/swap"
+	"github.com/0chain/gosdk/zcnbridge/tokens"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// SwapReceipt is the record of the transactions of a swap. When the swap
+// fails, it has the transactions sent until then.
+type SwapReceipt struct {
+	Quote *swap.Quote `json:"quote"`
+	// ApprovalHash is the hash of the approval of the router, if its
+	// allowance wasn't enough.
+	ApprovalHash string `json:"approval_hash,omitempty"`
+	SwapHash     string `json:"swap_hash,omitempty"`
+	SwapBlock    uint64 `json:"swap_block,omitempty"`
+}
+
+// CashOutReceipt is the record of CashOut.
+type CashOutReceipt struct {
+	Bridge *BridgeReceipt `json:"bridge"`
+	Swap   *SwapReceipt   `json:"swap,omitempty"`
+}
+
+// SwapTokens swaps amountIn, in base units, of the ERC-20 token at tokenIn
+// to the token at tokenOut through the DEX of cfg, for the Ethereum address
+// of the client. The router is approved for amountIn if needed, and the swap
+// fails on chain if it returns less than the quote within the slippage
+// limit, or after the deadline. It returns once the swap is confirmed.
+func (b *BridgeClientConfig) SwapTokens(ctx context.Context, cfg swap.Config, tokenIn, tokenOut string, amountIn *big.Int) (*SwapReceipt, error) {
+	etherClient, err := b.CreateEthClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etherClient")
+	}
+	defer etherClient.Close()
+
+	swapper, err := swap.New(cfg, etherClient)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := swapper.Quote(ctx, common.HexToAddress(tokenIn), common.HexToAddress(tokenOut), amountIn)
+	if err != nil {
+		return nil, err
+	}
+	receipt := &SwapReceipt{Quote: quote}
+
+	tx, err := b.approveIfNeeded(ctx, quote.TokenIn, swapper.RouterAddress(), amountIn, tokens.ApproveExact)
+	if err != nil {
+		return receipt, errors.Wrap(err, "failed to approve the router")
+	}
+	if tx != nil {
+		receipt.ApprovalHash = tx.Hash().Hex()
+		if _, err := b.waitConfirmed(ctx, tx); err != nil {
+			return receipt, errors.Wrapf(err, "failed to confirm approval %s", receipt.ApprovalHash)
+		}
+	}
+
+	from := common.HexToAddress(b.EthereumAddress)
+	msg, err := swapper.CallMsg(quote, from)
+	if err != nil {
+		return receipt, err
+	}
+	gasLimitUnits, err := b.estimateGas(ctx, etherClient, msg)
+	if err != nil {
+		return receipt, err
+	}
+
+	transactOpts := b.CreateSignedTransactionFromKeyStore(etherClient, gasLimitUnits)
+	if err := b.checkGasPrice(transactOpts.GasPrice); err != nil {
+		releaseNonce(transactOpts)
+		return receipt, err
+	}
+	transactOpts.Context = ctx
+
+	tx, err = swapper.Swap(transactOpts, quote, from)
+	if err != nil {
+		releaseNonce(transactOpts)
+		Logger.Error(
+			"Swap FAILED",
+			zap.String("token_in", quote.TokenIn.String()),
+			zap.String("token_out", quote.TokenOut.String()),
+			zap.String("amount_in", amountIn.String()),
+			zap.Error(err))
+		return receipt, err
+	}
+	receipt.SwapHash = tx.Hash().Hex()
+	Logger.Info(
+		"Posted Swap",
+		zap.String("hash", receipt.SwapHash),
+		zap.String("amount_in", amountIn.String()),
+		zap.String("min_amount_out", quote.MinAmountOut.String()),
+	)
+
+	mined, err := b.waitConfirmed(ctx, tx)
+	if err != nil {
+		return receipt, errors.Wrapf(err, "failed to confirm swap %s", receipt.SwapHash)
+	}
+	receipt.SwapBlock = mined.BlockNumber
+	return receipt, nil
+}
+
+// CashOut moves amount ZCN tokens to the Ethereum address of the client, see
+// BurnZCNAndMintWZCN, and swaps the WZCN minted to the token at tokenOut,
+// e.g. a stablecoin, see SwapTokens.
+func (b *BridgeClient) CashOut(ctx context.Context, amount uint64, cfg swap.Config, tokenOut string) (*CashOutReceipt, error) {
+	bridged, err := b.BurnZCNAndMintWZCN(ctx, amount)
+	receipt := &CashOutReceipt{Bridge: bridged}
+	if err != nil {
+		return receipt, err
+	}
+
+	receipt.Swap, err = b.SwapTokens(ctx, cfg, b.WzcnAddress, tokenOut, new(big.Int).SetUint64(amount))
+	if err != nil {
+		return receipt, errors.Wrap(err, "failed to swap WZCN")
+	}
+	return receipt, nil
+}
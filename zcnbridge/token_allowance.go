@@ -0,0 +1,133 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum/erc20"
+	"github.com/0chain/gosdk/zcnbridge/tokens"
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// TokenBalance returns the balance of the client of the ERC-20 token at
+// tokenAddress, e.g. WzcnAddress, in base units.
+func (b *BridgeClient) TokenBalance(ctx context.Context, tokenAddress string) (*big.Int, error) {
+	etherClient, err := b.CreateEthClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etherClient")
+	}
+	defer etherClient.Close()
+
+	token, err := tokens.New(common.HexToAddress(tokenAddress), etherClient)
+	if err != nil {
+		return nil, err
+	}
+	return token.BalanceOf(ctx, common.HexToAddress(b.EthereumAddress))
+}
+
+// BridgeAllowance returns the amount of the ERC-20 token at tokenAddress the
+// bridge contract may transfer from the client, in base units.
+func (b *BridgeClient) BridgeAllowance(ctx context.Context, tokenAddress string) (*big.Int, error) {
+	etherClient, err := b.CreateEthClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etherClient")
+	}
+	defer etherClient.Close()
+
+	token, err := tokens.New(common.HexToAddress(tokenAddress), etherClient)
+	if err != nil {
+		return nil, err
+	}
+	return token.Allowance(ctx, common.HexToAddress(b.EthereumAddress), common.HexToAddress(b.BridgeAddress))
+}
+
+// ApproveBridgeIfNeeded approves the bridge contract to transfer amount, in
+// base units, of the ERC-20 token at tokenAddress from the client, so a
+// deposit doesn't need a manual approve. No transaction is sent, and nil is
+// returned, if the allowance of the bridge is enough already.
+func (b *BridgeClient) ApproveBridgeIfNeeded(ctx context.Context, tokenAddress string, amount *big.Int, mode tokens.ApprovalMode) (*types.Transaction, error) {
+	return b.approveIfNeeded(ctx, common.HexToAddress(tokenAddress), common.HexToAddress(b.BridgeAddress), amount, mode)
+}
+
+// approveIfNeeded approves spender to transfer amount of the token at
+// tokenAddr from the client, unless it may already.
+func (b *BridgeClientConfig) approveIfNeeded(ctx context.Context, tokenAddr, spenderAddress common.Address, amount *big.Int, mode tokens.ApprovalMode) (*types.Transaction, error) {
+	etherClient, err := b.CreateEthClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etherClient")
+	}
+	defer etherClient.Close()
+
+	fromAddress := common.HexToAddress(b.EthereumAddress)
+
+	token, err := tokens.New(tokenAddr, etherClient)
+	if err != nil {
+		return nil, err
+	}
+	allowance, err := token.Allowance(ctx, fromAddress, spenderAddress)
+	if err != nil {
+		return nil, err
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return nil, nil
+	}
+
+	abi, err := erc20.ERC20MetaData.GetAbi()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get erc20 abi")
+	}
+	approved := amount
+	if mode == tokens.ApproveInfinite {
+		approved = tokens.MaxAllowance
+	}
+	pack, err := abi.Pack("approve", spenderAddress, approved)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to pack arguments")
+	}
+
+	gasLimitUnits, err := b.estimateGas(ctx, etherClient, eth.CallMsg{
+		To:   &tokenAddr,
+		From: fromAddress,
+		Data: pack,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactOpts := b.CreateSignedTransactionFromKeyStore(etherClient, gasLimitUnits)
+	if err := b.checkGasPrice(transactOpts.GasPrice); err != nil {
+		releaseNonce(transactOpts)
+		return nil, err
+	}
+	transactOpts.Context = ctx
+
+	tran, err := token.EnsureAllowance(ctx, transactOpts, spenderAddress, amount, mode)
+	if tran == nil {
+		// not sent, either failing or approved meanwhile
+		releaseNonce(transactOpts)
+	}
+	if err != nil {
+		Logger.Error(
+			"Approve FAILED",
+			zap.String("token", tokenAddr.String()),
+			zap.String("spender", spenderAddress.String()),
+			zap.String("amount", approved.String()),
+			zap.Error(err))
+
+		return nil, errors.Wrapf(err, "failed to send `Approve` transaction")
+	}
+	if tran != nil {
+		Logger.Info(
+			"Posted Approve",
+			zap.String("hash", tran.Hash().String()),
+			zap.String("token", tokenAddr.String()),
+			zap.String("spender", spenderAddress.String()),
+			zap.String("amount", approved.String()),
+		)
+	}
+	return tran, nil
+}
@@ -0,0 +1,160 @@
+// Package tokens wraps the ERC-20 contracts the bridge moves, WZCN and USDC,
+// with the balance and allowance calls, the approval of a spender only when
+// its allowance is short, and the conversions between the amounts in tokens
+// and in the base units of the contracts.
+package tokens
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/0chain/gosdk/zcnbridge/ethereum/erc20"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// ApprovalMode is the allowance EnsureAllowance approves.
+type ApprovalMode int
+
+const (
+	// ApproveExact approves the amount needed, so each deposit approves again.
+	ApproveExact ApprovalMode = iota
+	// ApproveInfinite approves MaxAllowance, so the spender is approved once.
+	ApproveInfinite
+)
+
+// MaxAllowance is the allowance of ApproveInfinite, the contracts don't
+// decrease it on transfers.
+var MaxAllowance = new(big.Int).Set(math.MaxBig256)
+
+// contract is the part of the ERC-20 binding of Token, replaced in tests.
+type contract interface {
+	BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error)
+	Allowance(opts *bind.CallOpts, owner common.Address, spender common.Address) (*big.Int, error)
+	Decimals(opts *bind.CallOpts) (uint8, error)
+	Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error)
+}
+
+// Token is an ERC-20 contract.
+type Token struct {
+	Address  common.Address
+	contract contract
+
+	mu       sync.Mutex
+	decimals *uint8
+}
+
+// New binds the ERC-20 contract at address.
+func New(address common.Address, backend bind.ContractBackend) (*Token, error) {
+	instance, err := erc20.NewERC20(address, backend)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to initialize ERC-20 instance %s", address.Hex())
+	}
+	return &Token{Address: address, contract: instance}, nil
+}
+
+// BalanceOf returns the balance of owner, in base units.
+func (t *Token) BalanceOf(ctx context.Context, owner common.Address) (*big.Int, error) {
+	balance, err := t.contract.BalanceOf(&bind.CallOpts{Context: ctx}, owner)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the balance of %s", owner.Hex())
+	}
+	return balance, nil
+}
+
+// Allowance returns the amount spender may transfer from owner, in base
+// units.
+func (t *Token) Allowance(ctx context.Context, owner, spender common.Address) (*big.Int, error) {
+	allowance, err := t.contract.Allowance(&bind.CallOpts{Context: ctx}, owner, spender)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the allowance of %s", spender.Hex())
+	}
+	return allowance, nil
+}
+
+// Decimals returns the decimals of the token, cached after the first call.
+func (t *Token) Decimals(ctx context.Context) (uint8, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.decimals != nil {
+		return *t.decimals, nil
+	}
+	decimals, err := t.contract.Decimals(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get the decimals")
+	}
+	t.decimals = &decimals
+	return decimals, nil
+}
+
+// EnsureAllowance approves spender to transfer amount, in base units, from
+// the sender of opts, unless its allowance is enough already, in which case
+// the transaction returned is nil. The allowance approved depends on mode.
+func (t *Token) EnsureAllowance(ctx context.Context, opts *bind.TransactOpts, spender common.Address, amount *big.Int, mode ApprovalMode) (*types.Transaction, error) {
+	allowance, err := t.Allowance(ctx, opts.From, spender)
+	if err != nil {
+		return nil, err
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return nil, nil
+	}
+
+	approved := amount
+	if mode == ApproveInfinite {
+		approved = MaxAllowance
+	}
+	tx, err := t.contract.Approve(opts, spender, approved)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to approve %s", spender.Hex())
+	}
+	return tx, nil
+}
+
+// ToBaseUnits converts amount, a decimal number of tokens such as "12.5", to
+// the base units of a token with decimals. Amounts with more fractional
+// digits than decimals are rejected rather than rounded.
+func ToBaseUnits(amount string, decimals uint8) (*big.Int, error) {
+	whole, fraction := strings.TrimSpace(amount), ""
+	if i := strings.IndexByte(whole, '.'); i >= 0 {
+		whole, fraction = whole[:i], whole[i+1:]
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > int(decimals) {
+		return nil, errors.Errorf("amount %s has more than %d decimals", amount, decimals)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	digits := whole + fraction + strings.Repeat("0", int(decimals)-len(fraction))
+	units, ok := new(big.Int).SetString(digits, 10)
+	if !ok || strings.ContainsAny(digits, "+-") {
+		return nil, errors.Errorf("invalid amount %s", amount)
+	}
+	return units, nil
+}
+
+// FromBaseUnits converts units, base units of a token with decimals, to a
+// decimal number of tokens, without trailing zeros.
+func FromBaseUnits(units *big.Int, decimals uint8) string {
+	digits := new(big.Int).Abs(units).String()
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+
+	point := len(digits) - int(decimals)
+	amount := digits[:point]
+	if fraction := strings.TrimRight(digits[point:], "0"); fraction != "" {
+		amount += "." + fraction
+	}
+	if units.Sign() < 0 {
+		amount = "-" + amount
+	}
+	return amount
+}
@@ -0,0 +1,132 @@
+package tokens
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeContract struct {
+	allowance     *big.Int
+	decimalsCalls int
+	approved      *big.Int
+}
+
+func (f *fakeContract) BalanceOf(*bind.CallOpts, common.Address) (*big.Int, error) {
+	return big.NewInt(42), nil
+}
+
+func (f *fakeContract) Allowance(*bind.CallOpts, common.Address, common.Address) (*big.Int, error) {
+	return f.allowance, nil
+}
+
+func (f *fakeContract) Decimals(*bind.CallOpts) (uint8, error) {
+	f.decimalsCalls++
+	return 6, nil
+}
+
+func (f *fakeContract) Approve(_ *bind.TransactOpts, _ common.Address, amount *big.Int) (*types.Transaction, error) {
+	f.approved = amount
+	return types.NewTx(&types.LegacyTx{}), nil
+}
+
+func TestToBaseUnits(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		decimals uint8
+		want     string
+		wantErr  bool
+	}{
+		{name: "Test_Whole_Amount", amount: "12", decimals: 6, want: "12000000"},
+		{name: "Test_Fractional_Amount", amount: "1.5", decimals: 18, want: "1500000000000000000"},
+		{name: "Test_Leading_Point", amount: ".25", decimals: 2, want: "25"},
+		{name: "Test_Trailing_Zeros", amount: "0.100000", decimals: 1, want: "1"},
+		{name: "Test_Too_Many_Decimals", amount: "0.0000001", decimals: 6, wantErr: true},
+		{name: "Test_Negative_Amount", amount: "-1", decimals: 6, wantErr: true},
+		{name: "Test_Invalid_Amount", amount: "1e3", decimals: 6, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToBaseUnits(tt.amount, tt.decimals)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestFromBaseUnits(t *testing.T) {
+	tests := []struct {
+		name     string
+		units    int64
+		decimals uint8
+		want     string
+	}{
+		{name: "Test_Whole_Amount", units: 12000000, decimals: 6, want: "12"},
+		{name: "Test_Fractional_Amount", units: 1500000, decimals: 6, want: "1.5"},
+		{name: "Test_Less_Than_One", units: 25, decimals: 6, want: "0.000025"},
+		{name: "Test_No_Decimals", units: 7, decimals: 0, want: "7"},
+		{name: "Test_Negative_Amount", units: -25, decimals: 2, want: "-0.25"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, FromBaseUnits(big.NewInt(tt.units), tt.decimals))
+		})
+	}
+}
+
+func TestTokenEnsureAllowance(t *testing.T) {
+	ctx := context.Background()
+	opts := &bind.TransactOpts{From: common.HexToAddress("0x1")}
+	spender := common.HexToAddress("0x2")
+
+	t.Run("Test_Allowance_Enough", func(t *testing.T) {
+		fake := &fakeContract{allowance: big.NewInt(100)}
+		token := &Token{contract: fake}
+
+		tx, err := token.EnsureAllowance(ctx, opts, spender, big.NewInt(100), ApproveExact)
+		require.NoError(t, err)
+		require.Nil(t, tx)
+		require.Nil(t, fake.approved)
+	})
+
+	t.Run("Test_Approve_Exact", func(t *testing.T) {
+		fake := &fakeContract{allowance: big.NewInt(10)}
+		token := &Token{contract: fake}
+
+		tx, err := token.EnsureAllowance(ctx, opts, spender, big.NewInt(100), ApproveExact)
+		require.NoError(t, err)
+		require.NotNil(t, tx)
+		require.EqualValues(t, 100, fake.approved.Int64())
+	})
+
+	t.Run("Test_Approve_Infinite", func(t *testing.T) {
+		fake := &fakeContract{allowance: big.NewInt(0)}
+		token := &Token{contract: fake}
+
+		_, err := token.EnsureAllowance(ctx, opts, spender, big.NewInt(100), ApproveInfinite)
+		require.NoError(t, err)
+		require.Equal(t, MaxAllowance, fake.approved)
+	})
+
+	t.Run("Test_Decimals_Cached", func(t *testing.T) {
+		fake := &fakeContract{}
+		token := &Token{contract: fake}
+
+		for i := 0; i < 2; i++ {
+			decimals, err := token.Decimals(ctx)
+			require.NoError(t, err)
+			require.EqualValues(t, 6, decimals)
+		}
+		require.Equal(t, 1, fake.decimalsCalls)
+	})
+}
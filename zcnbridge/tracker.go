@@ -0,0 +1,271 @@
+package zcnbridge
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/0chain/gosdk/zcncore"
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// TrackPollInterval is how often TrackTransaction polls the Ethereum node.
+var TrackPollInterval = 2 * time.Second
+
+// TxState is the state of a transaction tracked by TrackTransaction.
+type TxState string
+
+const (
+	// TxPending the transaction isn't mined yet.
+	TxPending TxState = "pending"
+	// TxIncluded the transaction is mined, with less confirmations than
+	// tracked for.
+	TxIncluded TxState = "included"
+	// TxReorged the block of the transaction was replaced by a reorg, the
+	// transaction is pending again or included in another block.
+	TxReorged TxState = "reorged"
+	// TxConfirmed the transaction is mined successfully with the
+	// confirmations tracked for. It's final.
+	TxConfirmed TxState = "confirmed"
+	// TxReverted the transaction is mined but failed. It's final.
+	TxReverted TxState = "reverted"
+	// TxDropped the node doesn't know of the transaction, it was dropped
+	// from the pool or by a reorg. It's final.
+	TxDropped TxState = "dropped"
+)
+
+var (
+	// ErrTransactionReverted is the error of TxReverted transactions.
+	ErrTransactionReverted = errors.New("transaction reverted")
+	// ErrTransactionDropped is the error of TxDropped transactions.
+	ErrTransactionDropped = errors.New("transaction dropped")
+)
+
+// TxStatus is a state of a transaction tracked by TrackTransaction.
+type TxStatus struct {
+	Hash  string  `json:"hash"`
+	State TxState `json:"state"`
+	// BlockNumber and BlockHash are the block the transaction is included
+	// in, for TxIncluded, TxConfirmed and TxReverted.
+	BlockNumber   uint64 `json:"block_number,omitempty"`
+	BlockHash     string `json:"block_hash,omitempty"`
+	Confirmations int    `json:"confirmations,omitempty"`
+	// RevertReason is the reason of TxReverted transactions, as given by
+	// the node replaying the transaction.
+	RevertReason string `json:"revert_reason,omitempty"`
+	// Err is set for the final states other than TxConfirmed, and when the
+	// tracking is cancelled.
+	Err error `json:"-"`
+}
+
+// Final tells whether the transaction won't change state anymore.
+func (s *TxStatus) Final() bool {
+	return s.State == TxConfirmed || s.State == TxReverted || s.State == TxDropped
+}
+
+// txBackend is the part of the Ethereum client tracking transactions.
+type txBackend interface {
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	CallContract(ctx context.Context, msg eth.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// TrackTransaction tracks the Ethereum transaction txHash until it's mined
+// with confirmations blocks, the block of the transaction included, reverted
+// or dropped. Every change of its state is sent to the channel returned,
+// which is closed after the final state, or once ctx is done. The channel
+// must be read until it's closed, see WaitTransaction otherwise.
+//
+// Reorgs are detected by checking the block of the transaction is still the
+// block at its height: the transaction is then TxReorged, and tracked again.
+// The reason of reverted transactions is found by replaying them.
+func TrackTransaction(ctx context.Context, txHash string, confirmations int) (<-chan TxStatus, error) {
+	client, err := zcncore.GetEthClient()
+	if err != nil {
+		return nil, err
+	}
+	return trackTransaction(ctx, client, common.HexToHash(txHash), confirmations), nil
+}
+
+// WaitTransaction tracks txHash like TrackTransaction, and returns its final
+// state. The error is the error of the final state, or the error of ctx.
+func WaitTransaction(ctx context.Context, txHash string, confirmations int) (*TxStatus, error) {
+	updates, err := TrackTransaction(ctx, txHash, confirmations)
+	if err != nil {
+		return nil, err
+	}
+	return waitStatus(ctx, updates)
+}
+
+func waitStatus(ctx context.Context, updates <-chan TxStatus) (*TxStatus, error) {
+	var last *TxStatus
+	for status := range updates {
+		status := status
+		last = &status
+	}
+	if last == nil || !last.Final() {
+		if ctx.Err() != nil {
+			return last, ctx.Err()
+		}
+		return last, errors.New("transaction tracking stopped")
+	}
+	return last, last.Err
+}
+
+func trackTransaction(ctx context.Context, client txBackend, hash common.Hash, confirmations int) <-chan TxStatus {
+	if confirmations < 1 {
+		confirmations = 1
+	}
+	updates := make(chan TxStatus, 1)
+
+	go func() {
+		defer close(updates)
+
+		t := &txTracking{client: client, hash: hash, confirmations: confirmations}
+		var last TxStatus
+		for {
+			status, ok := t.poll(ctx)
+			if ok && (status.State != last.State || status.Confirmations != last.Confirmations || status.BlockHash != last.BlockHash) {
+				select {
+				case updates <- status:
+				case <-ctx.Done():
+					return
+				}
+				last = status
+				if status.Final() {
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(TrackPollInterval):
+			}
+		}
+	}()
+	return updates
+}
+
+// txTracking is the state of the tracking of a transaction between polls.
+type txTracking struct {
+	client        txBackend
+	hash          common.Hash
+	confirmations int
+
+	// included is the block hash the transaction was last seen in.
+	included common.Hash
+}
+
+// poll returns the state of the transaction, false if it couldn't be told,
+// the node failing.
+func (t *txTracking) poll(ctx context.Context) (TxStatus, bool) {
+	status := TxStatus{Hash: t.hash.Hex()}
+
+	receipt, err := t.client.TransactionReceipt(ctx, t.hash)
+	if err == eth.NotFound {
+		_, _, err := t.client.TransactionByHash(ctx, t.hash)
+		if err == eth.NotFound {
+			status.State = TxDropped
+			status.Err = ErrTransactionDropped
+			return status, true
+		}
+		if err != nil {
+			return status, false
+		}
+		return t.unincluded(status), true
+	}
+	if err != nil {
+		return status, false
+	}
+
+	// the receipt is of the canonical chain only if the block at its
+	// height is its block
+	header, err := t.client.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return status, false
+	}
+	if header.Hash() != receipt.BlockHash {
+		return t.unincluded(status), true
+	}
+	if t.included != (common.Hash{}) && t.included != receipt.BlockHash {
+		t.included = receipt.BlockHash
+		status.State = TxReorged
+		return status, true
+	}
+	t.included = receipt.BlockHash
+
+	status.BlockNumber = receipt.BlockNumber.Uint64()
+	status.BlockHash = receipt.BlockHash.Hex()
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		status.State = TxReverted
+		status.RevertReason, status.Err = t.revertReason(ctx, receipt)
+		return status, true
+	}
+
+	head, err := t.client.BlockNumber(ctx)
+	if err != nil {
+		return status, false
+	}
+	if head >= status.BlockNumber {
+		status.Confirmations = int(head-status.BlockNumber) + 1
+	}
+	if status.Confirmations >= t.confirmations {
+		status.State = TxConfirmed
+	} else {
+		status.State = TxIncluded
+	}
+	return status, true
+}
+
+// unincluded returns the state of a transaction not in the canonical chain,
+// reorged if it was.
+func (t *txTracking) unincluded(status TxStatus) TxStatus {
+	if t.included != (common.Hash{}) {
+		t.included = common.Hash{}
+		status.State = TxReorged
+		return status
+	}
+	status.State = TxPending
+	return status
+}
+
+// revertReason replays the transaction of receipt on the state of its block
+// and returns the error of the node, and the error of the transaction,
+// a *RevertError if the revert is decoded, see DecodeRevert.
+func (t *txTracking) revertReason(ctx context.Context, receipt *types.Receipt) (string, error) {
+	unknown := errors.Wrap(ErrTransactionReverted, "unknown")
+	tx, _, err := t.client.TransactionByHash(ctx, t.hash)
+	if err != nil {
+		return "unknown", unknown
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return "unknown", unknown
+	}
+
+	msg := eth.CallMsg{
+		From:     from,
+		To:       tx.To(),
+		Gas:      tx.Gas(),
+		GasPrice: tx.GasPrice(),
+		Value:    tx.Value(),
+		Data:     tx.Data(),
+	}
+	_, err = t.client.CallContract(ctx, msg, receipt.BlockNumber)
+	if err == nil {
+		// the state of the block end may not fail the transaction anymore
+		return "unknown", unknown
+	}
+	var revert *RevertError
+	if errors.As(DecodeRevert(err), &revert) {
+		revert.mined = true
+		return revert.Error(), revert
+	}
+	return err.Error(), errors.Wrap(ErrTransactionReverted, err.Error())
+}
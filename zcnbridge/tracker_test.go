@@ -0,0 +1,209 @@
+package zcnbridge
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	eth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChain is a txBackend with one transaction, the test moving the chain
+// between polls.
+type fakeChain struct {
+	tx      *types.Transaction
+	pending bool
+	receipt *types.Receipt
+	head    uint64
+	blocks  map[uint64]*types.Header
+	callErr error
+}
+
+func newFakeChain(t *testing.T) *fakeChain {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	to := common.HexToAddress("0x1")
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{To: &to, Gas: 21000, GasPrice: big.NewInt(1)}), types.NewEIP155Signer(big.NewInt(5)), key)
+	require.NoError(t, err)
+	return &fakeChain{tx: tx, pending: true, blocks: map[uint64]*types.Header{}}
+}
+
+// mine includes the transaction in a block at height, fork telling blocks
+// at the same height apart.
+func (c *fakeChain) mine(height uint64, fork string, status uint64) {
+	header := &types.Header{Number: new(big.Int).SetUint64(height), Extra: []byte(fork)}
+	c.blocks[height] = header
+	c.pending = false
+	c.receipt = &types.Receipt{Status: status, BlockNumber: header.Number, BlockHash: header.Hash()}
+	if c.head < height {
+		c.head = height
+	}
+}
+
+// fork replaces the block at height, without the transaction.
+func (c *fakeChain) fork(height uint64, fork string) {
+	c.blocks[height] = &types.Header{Number: new(big.Int).SetUint64(height), Extra: []byte(fork)}
+}
+
+func (c *fakeChain) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	if c.tx == nil {
+		return nil, false, eth.NotFound
+	}
+	return c.tx, c.pending, nil
+}
+
+func (c *fakeChain) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	if c.receipt == nil {
+		return nil, eth.NotFound
+	}
+	return c.receipt, nil
+}
+
+func (c *fakeChain) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.head, nil
+}
+
+func (c *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	header, ok := c.blocks[number.Uint64()]
+	if !ok {
+		return nil, eth.NotFound
+	}
+	return header, nil
+}
+
+func (c *fakeChain) CallContract(ctx context.Context, msg eth.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return nil, c.callErr
+}
+
+func TestTxTrackingPoll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Test_Pending_Included_Confirmed", func(t *testing.T) {
+		chain := newFakeChain(t)
+		tracking := &txTracking{client: chain, hash: chain.tx.Hash(), confirmations: 3}
+
+		status, ok := tracking.poll(ctx)
+		require.True(t, ok)
+		require.Equal(t, TxPending, status.State)
+
+		chain.mine(10, "a", types.ReceiptStatusSuccessful)
+		status, ok = tracking.poll(ctx)
+		require.True(t, ok)
+		require.Equal(t, TxIncluded, status.State)
+		require.EqualValues(t, 10, status.BlockNumber)
+		require.Equal(t, 1, status.Confirmations)
+
+		chain.head = 12
+		status, ok = tracking.poll(ctx)
+		require.True(t, ok)
+		require.Equal(t, TxConfirmed, status.State)
+		require.Equal(t, 3, status.Confirmations)
+		require.True(t, status.Final())
+		require.NoError(t, status.Err)
+	})
+
+	t.Run("Test_Reorg_Out_Of_The_Chain", func(t *testing.T) {
+		chain := newFakeChain(t)
+		tracking := &txTracking{client: chain, hash: chain.tx.Hash(), confirmations: 3}
+
+		chain.mine(10, "a", types.ReceiptStatusSuccessful)
+		status, _ := tracking.poll(ctx)
+		require.Equal(t, TxIncluded, status.State)
+
+		// the node still has the receipt of the replaced block
+		chain.fork(10, "b")
+		status, ok := tracking.poll(ctx)
+		require.True(t, ok)
+		require.Equal(t, TxReorged, status.State)
+		require.False(t, status.Final())
+
+		status, _ = tracking.poll(ctx)
+		require.Equal(t, TxPending, status.State)
+	})
+
+	t.Run("Test_Reorg_To_Another_Block", func(t *testing.T) {
+		chain := newFakeChain(t)
+		tracking := &txTracking{client: chain, hash: chain.tx.Hash(), confirmations: 5}
+
+		chain.mine(10, "a", types.ReceiptStatusSuccessful)
+		status, _ := tracking.poll(ctx)
+		require.Equal(t, TxIncluded, status.State)
+
+		chain.mine(11, "b", types.ReceiptStatusSuccessful)
+		status, _ = tracking.poll(ctx)
+		require.Equal(t, TxReorged, status.State)
+
+		status, _ = tracking.poll(ctx)
+		require.Equal(t, TxIncluded, status.State)
+		require.EqualValues(t, 11, status.BlockNumber)
+	})
+
+	t.Run("Test_Dropped", func(t *testing.T) {
+		chain := newFakeChain(t)
+		chain.tx = nil
+		tracking := &txTracking{client: chain, hash: common.HexToHash("0x2"), confirmations: 1}
+
+		status, ok := tracking.poll(ctx)
+		require.True(t, ok)
+		require.Equal(t, TxDropped, status.State)
+		require.ErrorIs(t, status.Err, ErrTransactionDropped)
+	})
+
+	t.Run("Test_Reverted", func(t *testing.T) {
+		chain := newFakeChain(t)
+		chain.callErr = errors.New("execution reverted: amount exceeds allowance")
+		chain.mine(10, "a", types.ReceiptStatusFailed)
+		tracking := &txTracking{client: chain, hash: chain.tx.Hash(), confirmations: 1}
+
+		status, ok := tracking.poll(ctx)
+		require.True(t, ok)
+		require.Equal(t, TxReverted, status.State)
+		require.Equal(t, "execution reverted: amount exceeds allowance", status.RevertReason)
+		require.ErrorIs(t, status.Err, ErrTransactionReverted)
+	})
+
+	t.Run("Test_Node_Failing", func(t *testing.T) {
+		chain := newFakeChain(t)
+		chain.mine(10, "a", types.ReceiptStatusSuccessful)
+		delete(chain.blocks, 10)
+		tracking := &txTracking{client: chain, hash: chain.tx.Hash(), confirmations: 1}
+
+		_, ok := tracking.poll(ctx)
+		require.False(t, ok)
+	})
+}
+
+func TestTrackTransaction(t *testing.T) {
+	interval := TrackPollInterval
+	TrackPollInterval = time.Millisecond
+	defer func() { TrackPollInterval = interval }()
+
+	t.Run("Test_Final_State", func(t *testing.T) {
+		chain := newFakeChain(t)
+		chain.mine(10, "a", types.ReceiptStatusSuccessful)
+		chain.head = 11
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		status, err := waitStatus(ctx, trackTransaction(ctx, chain, chain.tx.Hash(), 2))
+		require.NoError(t, err)
+		require.Equal(t, TxConfirmed, status.State)
+		require.Equal(t, 2, status.Confirmations)
+	})
+
+	t.Run("Test_Cancelled", func(t *testing.T) {
+		chain := newFakeChain(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		status, err := waitStatus(ctx, trackTransaction(ctx, chain, chain.tx.Hash(), 1))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, TxPending, status.State)
+	})
+}
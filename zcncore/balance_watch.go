@@ -0,0 +1,143 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"time"
+
+	"github.com/0chain/gosdk/core/common"
+)
+
+// DefaultBalancePollInterval is the interval between polls of the balance
+// by WatchBalance
+const DefaultBalancePollInterval = 10 * time.Second
+
+// BalanceUpdate is a change of the balance of a client.
+type BalanceUpdate struct {
+	ClientID string
+	Balance  common.Balance
+	// Previous balance, 0 for the first update
+	Previous common.Balance
+	// First is set for the balance of the first poll
+	First bool
+}
+
+// BalanceThreshold alerts of a balance crossing Value, e.g. to top up a
+// wallet. The callbacks are called from the goroutine of WatchBalance, before
+// the update is sent.
+type BalanceThreshold struct {
+	Value common.Balance
+	// OnBelow is called when the balance drops below Value, or is below it
+	// at the first poll
+	OnBelow func(BalanceUpdate)
+	// OnAbove is called when the balance gets back to Value or more
+	OnAbove func(BalanceUpdate)
+}
+
+// for tests
+var balanceOfClient = fetchBalanceOfClient
+
+// WatchBalance polls the balance of clientID every interval and sends it
+// when it changed, the first one included. The thresholds are checked at
+// every change. The channel is closed when ctx is done; query errors are
+// logged and the poll retried.
+func WatchBalance(ctx context.Context, clientID string, interval time.Duration, thresholds ...BalanceThreshold) (<-chan BalanceUpdate, error) {
+	if err := checkSdkInit(); err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		interval = DefaultBalancePollInterval
+	}
+
+	ch := make(chan BalanceUpdate, 1)
+	go func() {
+		defer close(ch)
+
+		w := newBalanceWatch(clientID, thresholds)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := w.poll(ctx, ch); err != nil && ctx.Err() == nil {
+				logging.Error("zcn: balance watch: ", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return ch, nil
+}
+
+type balanceWatch struct {
+	clientID   string
+	thresholds []BalanceThreshold
+	// below is whether the balance was below each threshold
+	below   []bool
+	polled  bool
+	balance common.Balance
+}
+
+func newBalanceWatch(clientID string, thresholds []BalanceThreshold) *balanceWatch {
+	return &balanceWatch{
+		clientID:   clientID,
+		thresholds: thresholds,
+		below:      make([]bool, len(thresholds)),
+	}
+}
+
+// poll sends the balance to ch if it changed since the last poll.
+func (w *balanceWatch) poll(ctx context.Context, ch chan<- BalanceUpdate) error {
+	balance, err := balanceOfClient(w.clientID)
+	if err != nil {
+		return err
+	}
+	if w.polled && balance == w.balance {
+		return nil
+	}
+
+	u := BalanceUpdate{ClientID: w.clientID, Balance: balance, Previous: w.balance, First: !w.polled}
+	w.polled, w.balance = true, balance
+	w.alert(u)
+
+	select {
+	case ch <- u:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// alert calls the callbacks of the thresholds crossed by u.
+func (w *balanceWatch) alert(u BalanceUpdate) {
+	for i, t := range w.thresholds {
+		below := u.Balance < t.Value
+		crossed := below != w.below[i] || (u.First && below)
+		w.below[i] = below
+		if !crossed {
+			continue
+		}
+		if below && t.OnBelow != nil {
+			t.OnBelow(u)
+		} else if !below && t.OnAbove != nil {
+			t.OnAbove(u)
+		}
+	}
+}
+
+func fetchBalanceOfClient(clientID string) (common.Balance, error) {
+	value, info, err := getBalanceFromSharders(clientID)
+	if err != nil {
+		if isValueNotPresent(info) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if value < 0 {
+		return 0, common.ErrNegativeBalance
+	}
+	return common.Balance(value), nil
+}
@@ -0,0 +1,54 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceWatchPoll(t *testing.T) {
+	prev := balanceOfClient
+	t.Cleanup(func() { balanceOfClient = prev })
+
+	var balance common.Balance
+	balanceOfClient = func(clientID string) (common.Balance, error) {
+		return balance, nil
+	}
+
+	var alerts []string
+	w := newBalanceWatch("client", []BalanceThreshold{{
+		Value:   100,
+		OnBelow: func(u BalanceUpdate) { alerts = append(alerts, "below") },
+		OnAbove: func(u BalanceUpdate) { alerts = append(alerts, "above") },
+	}})
+	ch := make(chan BalanceUpdate, 10)
+
+	for _, tt := range []struct {
+		name       string
+		balance    common.Balance
+		wantUpdate *BalanceUpdate
+		wantAlerts []string
+	}{
+		{"Test_First_Poll_Below", 50, &BalanceUpdate{ClientID: "client", Balance: 50, First: true}, []string{"below"}},
+		{"Test_Unchanged_Skipped", 50, nil, nil},
+		{"Test_Still_Below", 40, &BalanceUpdate{ClientID: "client", Balance: 40, Previous: 50}, nil},
+		{"Test_Back_Above", 100, &BalanceUpdate{ClientID: "client", Balance: 100, Previous: 40}, []string{"above"}},
+		{"Test_Drops_Below", 99, &BalanceUpdate{ClientID: "client", Balance: 99, Previous: 100}, []string{"below"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			balance, alerts = tt.balance, nil
+			require.NoError(t, w.poll(context.Background(), ch))
+			require.Equal(t, tt.wantAlerts, alerts)
+			if tt.wantUpdate == nil {
+				require.Empty(t, ch)
+				return
+			}
+			require.Equal(t, *tt.wantUpdate, <-ch)
+		})
+	}
+}
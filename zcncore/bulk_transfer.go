@@ -0,0 +1,100 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+)
+
+// DefaultBulkTransferConcurrency is the number of transfers of BulkTransfer
+// waiting for their verification at the same time
+const DefaultBulkTransferConcurrency = 10
+
+// Transfer is a transfer of Value SAS to ToClientID.
+type Transfer struct {
+	ToClientID  string         `json:"to_client_id"`
+	Value       common.Balance `json:"value"`
+	Description string         `json:"description,omitempty"`
+}
+
+// TransferResult is the outcome of a Transfer of BulkTransfer. Result is set
+// once the transfer was submitted, even if it failed to be verified.
+type TransferResult struct {
+	Transfer Transfer   `json:"transfer"`
+	Result   *TxnResult `json:"result,omitempty"`
+	Err      error      `json:"-"`
+}
+
+// BulkTransfer sends the transfers from the wallet set with SetWalletInfo,
+// see Client.BulkTransfer.
+func BulkTransfer(ctx context.Context, transfers []Transfer) ([]TransferResult, error) {
+	return NewClient().BulkTransfer(ctx, transfers, 0)
+}
+
+// BulkTransfer sends the transfers, e.g. of an airdrop, pipelined: they are
+// submitted one after the other with consecutive nonces, and up to
+// concurrency of them, DefaultBulkTransferConcurrency if 0, wait for their
+// verification at the same time. The nonces are the ones of Nonces or
+// Session, or of a NonceManager for this call. A failed transfer doesn't stop
+// the others, the results are in the order of transfers; the error is only
+// set when none could be sent.
+func (c *Client) BulkTransfer(ctx context.Context, transfers []Transfer, concurrency int) ([]TransferResult, error) {
+	if _, err := c.clientID(); err != nil {
+		return nil, err
+	}
+	bulk := *c
+	if bulk.Nonces == nil && bulk.Session != nil {
+		bulk.Nonces = bulk.Session.Nonces
+	}
+	if bulk.Nonces == nil {
+		bulk.Nonces = NewNonceManager(nil)
+	}
+	return bulkTransfer(ctx, transfers, concurrency, func(ctx context.Context, t Transfer) (*TxnResult, error) {
+		return bulk.Send(ctx, t.ToClientID, uint64(t.Value), t.Description)
+	})
+}
+
+func bulkTransfer(ctx context.Context, transfers []Transfer, concurrency int, send func(ctx context.Context, t Transfer) (*TxnResult, error)) ([]TransferResult, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultBulkTransferConcurrency
+	}
+
+	results := make([]TransferResult, len(transfers))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, t := range transfers {
+		results[i].Transfer = t
+		if t.ToClientID == "" || t.Value <= 0 {
+			results[i].Err = errors.New("bulk_transfer", "a transfer needs a receiver and a positive value")
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(r *TransferResult) {
+			defer func() { <-sem; wg.Done() }()
+			r.Result, r.Err = send(ctx, r.Transfer)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	for _, r := range results {
+		if r.Result != nil || r.Err == nil {
+			return results, nil
+		}
+	}
+	if len(results) > 0 {
+		return results, errors.Wrap(results[0].Err, "no transfer sent")
+	}
+	return results, nil
+}
@@ -0,0 +1,60 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkTransfer(t *testing.T) {
+	transfers := []Transfer{
+		{ToClientID: "a", Value: 1},
+		{ToClientID: "b", Value: 2},
+		{ToClientID: "", Value: 3},
+		{ToClientID: "fail", Value: 4},
+		{ToClientID: "c", Value: 5},
+	}
+
+	t.Run("Test_Per_Transfer_Results", func(t *testing.T) {
+		var running, maxRunning int32
+		results, err := bulkTransfer(context.Background(), transfers, 2, func(ctx context.Context, tr Transfer) (*TxnResult, error) {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+					break
+				}
+			}
+			if tr.ToClientID == "fail" {
+				return nil, errors.New("insufficient balance")
+			}
+			return &TxnResult{Hash: tr.ToClientID}, nil
+		})
+		require.NoError(t, err)
+		require.Len(t, results, len(transfers))
+		for i, r := range results {
+			require.Equal(t, transfers[i], r.Transfer)
+		}
+		require.Equal(t, "a", results[0].Result.Hash)
+		require.Equal(t, "b", results[1].Result.Hash)
+		require.Error(t, results[2].Err)
+		require.EqualError(t, results[3].Err, "insufficient balance")
+		require.Equal(t, "c", results[4].Result.Hash)
+		require.LessOrEqual(t, maxRunning, int32(2))
+	})
+
+	t.Run("Test_None_Sent", func(t *testing.T) {
+		results, err := bulkTransfer(context.Background(), transfers[3:4], 0, func(ctx context.Context, tr Transfer) (*TxnResult, error) {
+			return nil, errors.New("insufficient balance")
+		})
+		require.Error(t, err)
+		require.Len(t, results, 1)
+	})
+}
@@ -0,0 +1,322 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/constants"
+	"github.com/0chain/gosdk/core/transaction"
+)
+
+// Client is a synchronous API over the callback based functions of this
+// package, for Go services. Every method blocks until the operation completes
+// or ctx is done. Operations already sent to the network aren't cancelled with
+// ctx, their outcome is just no longer waited for.
+type Client struct {
+	// Fee of the transactions sent by the client, 0 for the minimal fee
+	Fee uint64
+	// Nonces queues the transactions of the client and sets their nonce,
+	// the ones of Session or else the sdk nonce cache are used if nil
+	Nonces *NonceManager
+	// Session wallet of the client, the one set with SetWalletInfo if nil
+	Session *Session
+}
+
+// Client returns the synchronous API of the session.
+func (s *Session) Client() *Client {
+	return &Client{Session: s}
+}
+
+// clientID returns the client id of the client wallet.
+func (c *Client) clientID() (string, error) {
+	s := c.Session
+	if s == nil {
+		s = globalSession
+	}
+	return s.ClientID(), s.checkConfig()
+}
+
+// NewClient returns a Client. Init and SetWalletInfo must be called first,
+// except for CreateWallet and RecoverWallet.
+func NewClient() *Client {
+	return &Client{}
+}
+
+// TxnResult is the outcome of a confirmed transaction.
+type TxnResult struct {
+	Hash   string             `json:"hash"`
+	Nonce  int64              `json:"nonce"`
+	Output string             `json:"output"`
+	Status ConfirmationStatus `json:"status"`
+	// Transaction as signed and sent
+	Transaction *transaction.Transaction `json:"transaction,omitempty"`
+}
+
+type walletResult struct {
+	wallet string
+	err    error
+}
+
+type syncWalletCallback chan walletResult
+
+func (cb syncWalletCallback) OnWalletCreateComplete(status int, wallet string, err string) {
+	r := walletResult{wallet: wallet}
+	if status != StatusSuccess {
+		r.err = errors.New("wallet_create_failed", err)
+	}
+	select {
+	case cb <- r:
+	default:
+	}
+}
+
+// CreateWallet creates a wallet, registers it to the miners and returns its JSON.
+func (c *Client) CreateWallet(ctx context.Context) (string, error) {
+	cb := make(syncWalletCallback, 1)
+	if err := CreateWallet(cb); err != nil {
+		return "", err
+	}
+	r, err := wait(ctx, cb)
+	if err != nil {
+		return "", err
+	}
+	return r.wallet, r.err
+}
+
+// RecoverWallet recovers the wallet of mnemonic, registers it to the miners
+// and returns its JSON.
+func (c *Client) RecoverWallet(ctx context.Context, mnemonic string) (string, error) {
+	cb := make(syncWalletCallback, 1)
+	if err := RecoverWallet(mnemonic, cb); err != nil {
+		return "", err
+	}
+	r, err := wait(ctx, cb)
+	if err != nil {
+		return "", err
+	}
+	return r.wallet, r.err
+}
+
+type balanceResult struct {
+	value int64
+	err   error
+}
+
+// Balance returns the balance of the client wallet, in SAS.
+func (c *Client) Balance(ctx context.Context) (int64, error) {
+	clientID, err := c.clientID()
+	if err != nil {
+		return 0, err
+	}
+	return c.BalanceOf(ctx, clientID)
+}
+
+// BalanceOf returns the balance of clientID, in SAS. A client without
+// transaction yet has a balance of 0.
+func (c *Client) BalanceOf(ctx context.Context, clientID string) (int64, error) {
+	ch := make(chan balanceResult, 1)
+	go func() {
+		value, info, err := getBalanceFromSharders(clientID)
+		if err != nil && isValueNotPresent(info) {
+			err = nil
+		}
+		ch <- balanceResult{value, err}
+	}()
+	r, err := wait(ctx, ch)
+	if err != nil {
+		return 0, err
+	}
+	return r.value, r.err
+}
+
+// Nonce returns the nonce of the last transaction of the client wallet.
+func (c *Client) Nonce(ctx context.Context) (int64, error) {
+	clientID, err := c.clientID()
+	if err != nil {
+		return 0, err
+	}
+	return fetchNonceFromSharders(ctx, clientID)
+}
+
+type syncTxnCallback struct {
+	submitted chan int
+	verified  chan int
+}
+
+func newSyncTxnCallback() *syncTxnCallback {
+	return &syncTxnCallback{submitted: make(chan int, 1), verified: make(chan int, 1)}
+}
+
+func (cb *syncTxnCallback) OnTransactionComplete(t *Transaction, status int) {
+	select {
+	case cb.submitted <- status:
+	default:
+	}
+}
+
+func (cb *syncTxnCallback) OnVerifyComplete(t *Transaction, status int) {
+	select {
+	case cb.verified <- status:
+	default:
+	}
+}
+
+func (cb *syncTxnCallback) OnAuthComplete(t *Transaction, status int) {}
+
+// Execute creates a transaction, runs submit with it, e.g. to call
+// StakePoolLock, and waits for the transaction to be verified. With Nonces
+// set, a transaction rejected for its nonce is created and submitted again.
+func (c *Client) Execute(ctx context.Context, submit func(txn TransactionScheme) error) (*TxnResult, error) {
+	var (
+		cb  *syncTxnCallback
+		txn TransactionScheme
+	)
+	// send submits a new transaction, 0 nonce to take it from the sdk cache
+	send := func(nonce int64) error {
+		cb = newSyncTxnCallback()
+		var err error
+		if c.Session != nil {
+			txn, err = c.Session.NewTransaction(cb, c.Fee, nonce)
+		} else {
+			txn, err = NewTransaction(cb, c.Fee, nonce)
+		}
+		if err != nil {
+			return err
+		}
+		if err := submit(txn); err != nil {
+			return err
+		}
+		status, err := wait(ctx, cb.submitted)
+		if err != nil {
+			return err
+		}
+		if status != StatusSuccess {
+			return &constants.ErrTxnRejected{Reason: txn.GetTransactionError()}
+		}
+		return nil
+	}
+
+	clientID, err := c.clientID()
+	if err != nil {
+		return nil, err
+	}
+	nonces := c.Nonces
+	if nonces == nil && c.Session != nil {
+		nonces = c.Session.Nonces
+	}
+	if nonces != nil {
+		err = nonces.Submit(ctx, clientID, send)
+	} else {
+		err = send(0)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := txn.Verify(); err != nil {
+		return nil, err
+	}
+	status, err := wait(ctx, cb.verified)
+	if err != nil {
+		return nil, err
+	}
+
+	t := txn.(*Transaction)
+	result := &TxnResult{
+		Hash:        txn.GetTransactionHash(),
+		Nonce:       txn.GetTransactionNonce(),
+		Output:      txn.GetVerifyOutput(),
+		Status:      t.GetVerifyConfirmationStatus(),
+		Transaction: t.txn,
+	}
+	if status != StatusSuccess {
+		return result, errors.New("verify_failed", txn.GetVerifyError())
+	}
+	if result.Status == ChargeableError {
+		return result, &constants.ErrTxnRejected{Status: "chargeable_error", Reason: txn.GetVerifyOutput()}
+	}
+	return result, nil
+}
+
+// Send transfers value SAS to toClientID.
+func (c *Client) Send(ctx context.Context, toClientID string, value uint64, desc string) (*TxnResult, error) {
+	return c.Execute(ctx, func(txn TransactionScheme) error {
+		return txn.Send(toClientID, value, desc)
+	})
+}
+
+// ExecuteSmartContract calls methodName of the smart contract at address.
+func (c *Client) ExecuteSmartContract(ctx context.Context, address, methodName string, input interface{}, value uint64) (*TxnResult, error) {
+	return c.Execute(ctx, func(txn TransactionScheme) error {
+		_, err := txn.ExecuteSmartContract(address, methodName, input, value)
+		return err
+	})
+}
+
+type infoResult struct {
+	info string
+	err  error
+}
+
+type syncInfoCallback chan infoResult
+
+func (cb syncInfoCallback) OnInfoAvailable(op int, status int, info string, err string) {
+	r := infoResult{info: info}
+	if status != StatusSuccess {
+		r.err = errors.New("get_info_failed", err)
+	}
+	select {
+	case cb <- r:
+	default:
+	}
+}
+
+// Query runs one of the GetInfoCallback functions of this package and
+// returns its JSON result, e.g.
+//
+//	c.Query(ctx, func(cb GetInfoCallback) error { return GetMinerSCConfig(cb) })
+func (c *Client) Query(ctx context.Context, query func(cb GetInfoCallback) error) (json.RawMessage, error) {
+	cb := make(syncInfoCallback, 1)
+	if err := query(cb); err != nil {
+		return nil, err
+	}
+	r, err := wait(ctx, cb)
+	if err != nil {
+		return nil, err
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return json.RawMessage(r.info), nil
+}
+
+// GetMiners returns the miners registered on the miner smart contract.
+func (c *Client) GetMiners(ctx context.Context) (json.RawMessage, error) {
+	return c.Query(ctx, GetMiners)
+}
+
+// GetSharders returns the sharders registered on the miner smart contract.
+func (c *Client) GetSharders(ctx context.Context) (json.RawMessage, error) {
+	return c.Query(ctx, GetSharders)
+}
+
+// GetAllocation returns the allocation allocID.
+func (c *Client) GetAllocation(ctx context.Context, allocID string) (json.RawMessage, error) {
+	return c.Query(ctx, func(cb GetInfoCallback) error { return GetAllocation(allocID, cb) })
+}
+
+// queryJSON runs query as Query and decodes its result into out.
+func (c *Client) queryJSON(ctx context.Context, query func(cb GetInfoCallback) error, out interface{}) error {
+	info, err := c.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(info, out); err != nil {
+		return errors.Wrap(err, "invalid response")
+	}
+	return nil
+}
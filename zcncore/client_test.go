@@ -0,0 +1,47 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientQuery(t *testing.T) {
+	c := NewClient()
+
+	t.Run("Test_Success", func(t *testing.T) {
+		info, err := c.Query(context.Background(), func(cb GetInfoCallback) error {
+			go cb.OnInfoAvailable(OpStorageSCGetConfig, StatusSuccess, `{"ok":true}`, "")
+			return nil
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"ok":true}`, string(info))
+	})
+
+	t.Run("Test_Failure", func(t *testing.T) {
+		_, err := c.Query(context.Background(), func(cb GetInfoCallback) error {
+			go cb.OnInfoAvailable(OpStorageSCGetConfig, StatusError, "", "not found")
+			return nil
+		})
+		require.EqualError(t, err, "get_info_failed: not found")
+	})
+
+	t.Run("Test_Query_Error", func(t *testing.T) {
+		want := errors.New("bad request")
+		_, err := c.Query(context.Background(), func(cb GetInfoCallback) error { return want })
+		require.Equal(t, want, err)
+	})
+
+	t.Run("Test_Context_Done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := c.Query(ctx, func(cb GetInfoCallback) error { return nil })
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
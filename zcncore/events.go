@@ -0,0 +1,168 @@
+package zcncore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/0chain/errors"
+)
+
+// DefaultEventLimit is the page size of QueryEvents
+const DefaultEventLimit = 20
+
+// EventType is the type of the events emitted by the smart contracts.
+type EventType int
+
+const (
+	EventTypeNone EventType = iota
+	// EventTypeStats events update the state of a provider or allocation
+	EventTypeStats
+	// EventTypeError events report failed smart contract calls
+	EventTypeError
+)
+
+// EventTag is what an event is about, e.g. a new allocation. Its values are
+// the ones of the event database of the sharders.
+type EventTag int
+
+// Event emitted by a smart contract while executing a transaction, stored
+// by the sharders.
+type Event struct {
+	BlockNumber int64     `json:"block_number"`
+	TxHash      string    `json:"tx_hash"`
+	Type        EventType `json:"type"`
+	Tag         EventTag  `json:"tag"`
+	// Index is the id of the object the event is about, e.g. an allocation id
+	Index string `json:"index"`
+	// Data is the JSON encoded object, see Decode
+	Data string `json:"data"`
+}
+
+// Decode unmarshals the data of the event into v, e.g. an
+// AllocationEventData for an allocation event.
+func (e *Event) Decode(v interface{}) error {
+	if err := json.Unmarshal([]byte(e.Data), v); err != nil {
+		return errors.Wrap(err, "decode_event")
+	}
+	return nil
+}
+
+// AllocationEventData is the data of the allocation events.
+type AllocationEventData struct {
+	AllocationID string `json:"allocation_id"`
+	Owner        string `json:"owner"`
+	Size         int64  `json:"size"`
+	DataShards   int    `json:"data_shards"`
+	ParityShards int    `json:"parity_shards"`
+	Expiration   int64  `json:"expiration"`
+}
+
+// StakeEventData is the data of the stake pool events, e.g. a lock.
+type StakeEventData struct {
+	ProviderID   string   `json:"provider_id"`
+	ProviderType Provider `json:"provider_type"`
+	DelegateID   string   `json:"delegate_id"`
+	PoolID       string   `json:"pool_id"`
+	Amount       int64    `json:"amount"`
+}
+
+// EventFilter selects the events returned by QueryEvents, zero fields
+// don't filter.
+type EventFilter struct {
+	Type   EventType
+	Tag    EventTag
+	TxHash string
+	// FromBlock and ToBlock are the block range, both included
+	FromBlock int64
+	ToBlock   int64
+	Offset    int
+	// Limit is the page size, DefaultEventLimit if 0
+	Limit int
+}
+
+func (f EventFilter) params() Params {
+	p := Params{
+		"offset": strconv.Itoa(f.Offset),
+		"limit":  strconv.Itoa(f.Limit),
+	}
+	if f.Type != EventTypeNone {
+		p["type"] = strconv.Itoa(int(f.Type))
+	}
+	if f.Tag != 0 {
+		p["tag"] = strconv.Itoa(int(f.Tag))
+	}
+	if f.TxHash != "" {
+		p["tx_hash"] = f.TxHash
+	}
+	switch {
+	case f.FromBlock > 0 && f.FromBlock == f.ToBlock:
+		p["block_number"] = strconv.FormatInt(f.FromBlock, 10)
+	default:
+		if f.FromBlock > 0 {
+			p["start"] = strconv.FormatInt(f.FromBlock, 10)
+		}
+		if f.ToBlock > 0 {
+			p["end"] = strconv.FormatInt(f.ToBlock, 10)
+		}
+	}
+	return p
+}
+
+// match filters the events of sharders that ignore a filter parameter.
+func (f EventFilter) match(e *Event) bool {
+	return (f.Type == EventTypeNone || e.Type == f.Type) &&
+		(f.Tag == 0 || e.Tag == f.Tag) &&
+		(f.TxHash == "" || e.TxHash == f.TxHash) &&
+		(f.FromBlock <= 0 || e.BlockNumber >= f.FromBlock) &&
+		(f.ToBlock <= 0 || e.BlockNumber <= f.ToBlock)
+}
+
+// EventPage is a page of events returned by QueryEvents.
+type EventPage struct {
+	Events []Event `json:"events"`
+	// NextOffset is the offset of the next page
+	NextOffset int `json:"next_offset"`
+	// More is false on the last page
+	More bool `json:"more"`
+}
+
+// QueryEvents returns a page of the events of the smart contracts matching
+// filter, as agreed by the quorum of sharders set with SetSharderQuorum.
+func QueryEvents(ctx context.Context, filter EventFilter) (*EventPage, error) {
+	if err := checkSdkInit(); err != nil {
+		return nil, err
+	}
+	tq, err := NewTransactionQuery(_config.chain.Sharders)
+	if err != nil {
+		return nil, err
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultEventLimit
+	}
+
+	qr, err := tq.FromQuorum(ctx, withParams(GET_MINERSC_EVENTS, filter.params()), quorumRequired(sharderQuorum(), tq.max), nil)
+	if err != nil {
+		return nil, err
+	}
+	if qr.StatusCode != http.StatusOK {
+		return nil, errors.New("get_events", string(qr.Content))
+	}
+
+	var all []Event
+	if err := json.Unmarshal(qr.Content, &all); err != nil {
+		return nil, errors.Wrap(err, "get_events")
+	}
+	page := &EventPage{
+		Events:     make([]Event, 0, len(all)),
+		NextOffset: filter.Offset + len(all),
+		More:       len(all) >= filter.Limit,
+	}
+	for i := range all {
+		if filter.match(&all[i]) {
+			page.Events = append(page.Events, all[i])
+		}
+	}
+	return page, nil
+}
@@ -0,0 +1,40 @@
+package zcncore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryEvents(t *testing.T) {
+	chain, configured := _config.chain, _config.isConfigured
+	t.Cleanup(func() { _config.chain, _config.isConfigured = chain, configured })
+	_config.isConfigured = true
+
+	const events = `[
+		{"block_number":5,"tx_hash":"t1","type":1,"tag":4,"index":"a1","data":"{\"allocation_id\":\"a1\",\"size\":1024}"},
+		{"block_number":12,"tx_hash":"t2","type":1,"tag":4,"index":"a2","data":"{}"}
+	]`
+	_config.chain = ChainConfig{Miners: []string{"m"}, Sharders: newTestSharders(t, []sharderResponse{
+		{200, events}, {200, events},
+	})}
+
+	page, err := QueryEvents(context.Background(), EventFilter{Tag: 4, FromBlock: 1, ToBlock: 10, Limit: 2})
+	require.NoError(t, err)
+	require.True(t, page.More)
+	require.Equal(t, 2, page.NextOffset)
+	require.Len(t, page.Events, 1, "events out of the block range are filtered")
+
+	var alloc AllocationEventData
+	require.NoError(t, page.Events[0].Decode(&alloc))
+	require.Equal(t, "a1", alloc.AllocationID)
+	require.EqualValues(t, 1024, alloc.Size)
+}
+
+func TestEventFilterParams(t *testing.T) {
+	require.Equal(t, "?block_number=7&limit=10&offset=0&tx_hash=h",
+		EventFilter{TxHash: "h", FromBlock: 7, ToBlock: 7, Limit: 10}.params().Query())
+	require.Equal(t, "?end=9&limit=10&offset=20&start=3&type=2",
+		EventFilter{Type: EventTypeError, FromBlock: 3, ToBlock: 9, Offset: 20, Limit: 10}.params().Query())
+}
@@ -0,0 +1,115 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/block"
+	"github.com/0chain/gosdk/core/transaction"
+)
+
+// ErrBlockNotFound is returned when the sharders don't have a block.
+var ErrBlockNotFound = errors.New("", "zcn: block not found")
+
+// TransactionInfo is a transaction finalized in a block, as returned to
+// explorers.
+type TransactionInfo struct {
+	*transaction.Transaction
+	BlockHash string `json:"block_hash"`
+	Round     int64  `json:"round"`
+}
+
+// DecodeOutput unmarshals the JSON output of the smart contract into v.
+func (t *TransactionInfo) DecodeOutput(v interface{}) error {
+	if err := json.Unmarshal([]byte(t.TransactionOutput), v); err != nil {
+		return errors.Wrap(err, "decode_output")
+	}
+	return nil
+}
+
+// Succeeded tells whether the transaction updated the state.
+func (t *TransactionInfo) Succeeded() bool {
+	return t.Status == transaction.TxnSuccess
+}
+
+type blockResponse struct {
+	Block  *block.Block  `json:"block"`
+	Header *block.Header `json:"header"`
+}
+
+// blockHasher makes the sharders agree on the hash of the returned block.
+func blockHasher(body []byte) (string, error) {
+	var resp blockResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", err
+	}
+	if resp.Block == nil {
+		return "", ErrBlockNotFound
+	}
+	return string(resp.Block.Hash), nil
+}
+
+// GetBlockByHash returns the block hash, with its transactions and their
+// outputs, as agreed by the quorum of sharders set with SetSharderQuorum.
+func GetBlockByHash(ctx context.Context, hash string) (*block.Block, error) {
+	if err := checkSdkInit(); err != nil {
+		return nil, err
+	}
+	tq, err := NewTransactionQuery(_config.chain.Sharders)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("%sblock=%s&content=full,header", GET_BLOCK_INFO, hash)
+	qr, err := tq.FromQuorum(ctx, query, quorumRequired(sharderQuorum(), tq.max), blockHasher)
+	if err != nil {
+		return nil, err
+	}
+	if qr.StatusCode != http.StatusOK {
+		return nil, ErrBlockNotFound
+	}
+
+	var resp blockResponse
+	if err := json.Unmarshal(qr.Content, &resp); err != nil {
+		return nil, err
+	}
+	if string(resp.Block.Hash) != hash {
+		return nil, errors.Newf("", "zcn: sharders returned block %s for %s", resp.Block.Hash, hash)
+	}
+	resp.Block.Header = resp.Header
+	return resp.Block, nil
+}
+
+// GetTransactionByHash returns the finalized transaction hash, its smart
+// contract output included, as confirmed by the quorum of sharders set with
+// SetSharderQuorum. ErrTransactionNotFound is returned for a transaction not
+// finalized yet.
+func GetTransactionByHash(ctx context.Context, hash string) (*TransactionInfo, error) {
+	if err := checkSdkInit(); err != nil {
+		return nil, err
+	}
+	tq, err := NewTransactionQuery(_config.chain.Sharders)
+	if err != nil {
+		return nil, err
+	}
+
+	header, cfmBlock, _, err := tq.getConsensusConfirmation(ctx, quorumRequired(sharderQuorum(), tq.max), hash)
+	if err != nil {
+		return nil, err
+	}
+	var cfm confirmation
+	if err := json.Unmarshal(cfmBlock["confirmation"], &cfm); err != nil {
+		return nil, errors.Wrap(err, "txn confirmation parse error")
+	}
+	return &TransactionInfo{
+		Transaction: cfm.Transaction,
+		BlockHash:   header.Hash,
+		Round:       header.Round,
+	}, nil
+}
@@ -0,0 +1,39 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBlockByHash(t *testing.T) {
+	chain, configured := _config.chain, _config.isConfigured
+	t.Cleanup(func() { _config.chain, _config.isConfigured = chain, configured })
+	_config.isConfigured = true
+
+	const found = `{"block":{"hash":"b1","round":5,"transactions":[{"hash":"t1","transaction_output":"{\"ok\":true}"}]},"header":{"hash":"b1","round":5}}`
+
+	t.Run("Test_Found", func(t *testing.T) {
+		_config.chain = ChainConfig{Miners: []string{"m"}, Sharders: newTestSharders(t, []sharderResponse{
+			{200, found}, {200, found}, {500, `boom`},
+		})}
+		b, err := GetBlockByHash(context.Background(), "b1")
+		require.NoError(t, err)
+		require.EqualValues(t, 5, b.Round)
+		require.NotNil(t, b.Header)
+		require.Len(t, b.Txns, 1)
+		require.Equal(t, `{"ok":true}`, b.Txns[0].TransactionOutput)
+	})
+
+	t.Run("Test_Not_Found", func(t *testing.T) {
+		_config.chain = ChainConfig{Miners: []string{"m"}, Sharders: newTestSharders(t, []sharderResponse{
+			{400, `{"error":"not found"}`}, {400, `{"error":"not found"}`},
+		})}
+		_, err := GetBlockByHash(context.Background(), "b1")
+		require.ErrorIs(t, err, ErrBlockNotFound)
+	})
+}
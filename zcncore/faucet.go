@@ -0,0 +1,108 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+	"github.com/0chain/gosdk/core/transaction"
+)
+
+// FaucetSC is the synchronous API of the faucet smart contract, handing out
+// tokens on test networks.
+type FaucetSC struct {
+	*Client
+}
+
+// FaucetSC returns the faucet smart contract API of the client.
+func (c *Client) FaucetSC() *FaucetSC {
+	return &FaucetSC{Client: c}
+}
+
+// FaucetSCConfig is the config of the faucet smart contract, the token
+// amounts parsed from the ZCN values of Fields.
+type FaucetSCConfig struct {
+	OwnerID       string
+	PourAmount    common.Balance
+	MaxPourAmount common.Balance
+	// PeriodicLimit is the amount a client can be poured per IndividualReset
+	PeriodicLimit common.Balance
+	// GlobalLimit is the amount poured to all clients per GlobalReset
+	GlobalLimit     common.Balance
+	IndividualReset time.Duration
+	GlobalReset     time.Duration
+	// Fields are the raw config fields
+	Fields map[string]string
+}
+
+// GetConfig returns the config of the faucet smart contract.
+func (f *FaucetSC) GetConfig(ctx context.Context) (*FaucetSCConfig, error) {
+	var ip InputMap
+	if err := f.queryJSON(ctx, GetFaucetSCConfig, &ip); err != nil {
+		return nil, err
+	}
+	return parseFaucetSCConfig(ip.Fields)
+}
+
+func parseFaucetSCConfig(fields map[string]string) (*FaucetSCConfig, error) {
+	conf := &FaucetSCConfig{OwnerID: fields["owner_id"], Fields: fields}
+	coins := map[string]*common.Balance{
+		"pour_amount":     &conf.PourAmount,
+		"max_pour_amount": &conf.MaxPourAmount,
+		"periodic_limit":  &conf.PeriodicLimit,
+		"global_limit":    &conf.GlobalLimit,
+	}
+	for name, c := range coins {
+		v, ok := fields[name]
+		if !ok {
+			continue
+		}
+		var err error
+		if *c, err = common.ParseAmount(v, common.ZCN); err != nil {
+			return nil, errors.Wrap(err, "faucet config "+name)
+		}
+	}
+	durations := map[string]*time.Duration{
+		"individual_reset": &conf.IndividualReset,
+		"global_reset":     &conf.GlobalReset,
+	}
+	for name, d := range durations {
+		v, ok := fields[name]
+		if !ok {
+			continue
+		}
+		var err error
+		if *d, err = time.ParseDuration(v); err != nil {
+			return nil, errors.Wrap(err, "faucet config "+name)
+		}
+	}
+	return conf, nil
+}
+
+// Pour asks the faucet for its pour amount of tokens.
+func (f *FaucetSC) Pour(ctx context.Context) (*TxnResult, error) {
+	return f.ExecuteSmartContract(ctx, FaucetSmartContractAddress, transaction.FAUCETSC_POUR, "{}", 0)
+}
+
+// Refill sends value SAS to the faucet.
+func (f *FaucetSC) Refill(ctx context.Context, value common.Balance) (*TxnResult, error) {
+	if value <= 0 {
+		return nil, errors.New("faucet_refill", "value must be positive")
+	}
+	return f.ExecuteSmartContract(ctx, FaucetSmartContractAddress, transaction.FAUCETSC_REFILL, "{}", uint64(value))
+}
+
+// UpdateConfig updates the config fields of the faucet smart contract, the
+// client must be its owner.
+func (f *FaucetSC) UpdateConfig(ctx context.Context, fields map[string]string) (*TxnResult, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("faucet_update_config", "no field to update")
+	}
+	return f.Execute(ctx, func(txn TransactionScheme) error {
+		return txn.FaucetUpdateConfig(&InputMap{Fields: fields})
+	})
+}
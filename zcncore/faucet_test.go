@@ -0,0 +1,31 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"testing"
+
+	"github.com/0chain/gosdk/core/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFaucetSCConfig(t *testing.T) {
+	conf, err := parseFaucetSCConfig(map[string]string{
+		"owner_id":         "owner",
+		"pour_amount":      "1",
+		"max_pour_amount":  "10",
+		"periodic_limit":   "0.5",
+		"individual_reset": "2h",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "owner", conf.OwnerID)
+	require.Equal(t, common.Balance(1e10), conf.PourAmount)
+	require.Equal(t, common.Balance(1e11), conf.MaxPourAmount)
+	require.Equal(t, common.Balance(5e9), conf.PeriodicLimit)
+	require.Zero(t, conf.GlobalLimit)
+	require.Equal(t, "2h0m0s", conf.IndividualReset.String())
+
+	_, err = parseFaucetSCConfig(map[string]string{"pour_amount": "abc"})
+	require.Error(t, err)
+}
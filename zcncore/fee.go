@@ -0,0 +1,249 @@
+package zcncore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/block"
+	"github.com/0chain/gosdk/core/resty"
+	"github.com/0chain/gosdk/core/util"
+)
+
+const (
+	ESTIMATE_TXN_FEE = `/v1/estimate_txn_fee`
+	GET_FEE_STATS    = `/v1/block/get/fee_stats`
+)
+
+// DefaultFeeTimeout bounds the fee policy applied to a transaction helper.
+const DefaultFeeTimeout = 10 * time.Second
+
+// FeeStats are the fees of the recent transactions seen by a miner.
+type FeeStats struct {
+	MaxFee  uint64 `json:"max_fee"`
+	MeanFee uint64 `json:"mean_fee"`
+	MinFee  uint64 `json:"min_fee"`
+}
+
+// EstimateTxnFee asks the miners for the minimal fee of txn and returns the
+// highest one, so that every miner accepts it.
+func EstimateTxnFee(ctx context.Context, txn *Transaction) (uint64, error) {
+	if txn == nil || txn.txn == nil {
+		return 0, errors.New("estimate_fee", "nil transaction")
+	}
+	body, err := json.Marshal(txn.txn)
+	if err != nil {
+		return 0, err
+	}
+
+	type estimate struct {
+		Fee uint64 `json:"fee"`
+	}
+	var (
+		mu    sync.Mutex
+		fee   uint64
+		found bool
+	)
+	urls := make([]string, 0, len(_config.chain.Miners))
+	for _, miner := range _config.chain.Miners {
+		urls = append(urls, miner+ESTIMATE_TXN_FEE)
+	}
+	r := resty.New(resty.WithHeader(map[string]string{"Content-Type": "application/json; charset=utf-8"})).
+		ThenJSON(func() interface{} { return &estimate{} }, func(url string, v interface{}, status int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			found = true
+			if est := v.(*estimate); est.Fee > fee {
+				fee = est.Fee
+			}
+			return nil
+		})
+	errs := r.DoPost(ctx, bytes.NewReader(body), urls...).Wait()
+
+	if !found {
+		var lastErr error = errors.New("estimate_fee", "no miners")
+		if len(errs) > 0 {
+			lastErr = errs[len(errs)-1]
+		}
+		return 0, errors.Wrap(lastErr, "estimate_fee")
+	}
+	return fee, nil
+}
+
+// GetFeeStats returns the fee statistics of the first miner that responds.
+func GetFeeStats(ctx context.Context) (*FeeStats, error) {
+	var lastErr error = errors.New("fee_stats", "no miners")
+	for _, miner := range util.Shuffle(_config.chain.Miners) {
+		var stats *FeeStats
+		r := resty.New().ThenJSON(func() interface{} { return &FeeStats{} }, func(url string, v interface{}, status int) error {
+			stats = v.(*FeeStats)
+			return nil
+		})
+		errs := r.DoGet(ctx, miner+GET_FEE_STATS).Wait()
+		if len(errs) == 0 && stats != nil {
+			return stats, nil
+		}
+		if len(errs) > 0 {
+			lastErr = errs[0]
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Wrap(lastErr, "fee_stats")
+}
+
+// GetRecentFees returns the fees of the transactions of the last blocks
+// finalized by the sharders.
+func GetRecentFees(ctx context.Context, blocks int) ([]uint64, error) {
+	tq, err := NewTransactionQuery(_config.chain.Sharders)
+	if err != nil {
+		return nil, err
+	}
+
+	qr, err := tq.FromAny(ctx, GET_LATEST_FINALIZED)
+	if err != nil {
+		return nil, errors.Wrap(err, "recent_fees")
+	}
+	var latest block.Header
+	if err := json.Unmarshal(qr.Content, &latest); err != nil {
+		return nil, errors.Wrap(err, "recent_fees")
+	}
+
+	var fees []uint64
+	for round := latest.Round; round > latest.Round-int64(blocks) && round > 0; round-- {
+		qr, err := tq.FromAny(ctx, fmt.Sprintf("%sround=%d&content=full", GET_BLOCK_INFO, round))
+		if err != nil {
+			return nil, errors.Wrap(err, "recent_fees")
+		}
+		var resp struct {
+			Block *block.Block `json:"block"`
+		}
+		if err := json.Unmarshal(qr.Content, &resp); err != nil || resp.Block == nil {
+			continue
+		}
+		for _, txn := range resp.Block.Txns {
+			fees = append(fees, txn.TransactionFee)
+		}
+	}
+	return fees, nil
+}
+
+// FeePolicy returns the fee of a transaction about to be submitted.
+type FeePolicy interface {
+	Fee(ctx context.Context, txn *Transaction) (uint64, error)
+}
+
+// FeePolicyFunc adapts a function to FeePolicy.
+type FeePolicyFunc func(ctx context.Context, txn *Transaction) (uint64, error)
+
+// Fee calls f.
+func (f FeePolicyFunc) Fee(ctx context.Context, txn *Transaction) (uint64, error) {
+	return f(ctx, txn)
+}
+
+// FixedFee always returns fee.
+func FixedFee(fee uint64) FeePolicy {
+	return FeePolicyFunc(func(context.Context, *Transaction) (uint64, error) {
+		return fee, nil
+	})
+}
+
+// EstimatedFee returns the minimal fee of the miners, see EstimateTxnFee.
+func EstimatedFee() FeePolicy {
+	return FeePolicyFunc(EstimateTxnFee)
+}
+
+// PercentileFee returns the percentile, from 0 to 100, of the fees of the
+// transactions of the last blocks, at least the minimal fee of the miners.
+func PercentileFee(percentile float64, blocks int) FeePolicy {
+	return FeePolicyFunc(func(ctx context.Context, txn *Transaction) (uint64, error) {
+		minFee, err := EstimateTxnFee(ctx, txn)
+		if err != nil {
+			return 0, err
+		}
+		fees, err := GetRecentFees(ctx, blocks)
+		if err != nil {
+			return 0, err
+		}
+		if fee := feePercentile(fees, percentile); fee > minFee {
+			return fee, nil
+		}
+		return minFee, nil
+	})
+}
+
+// CappedFee returns the fee of policy, at most max.
+func CappedFee(policy FeePolicy, max uint64) FeePolicy {
+	return FeePolicyFunc(func(ctx context.Context, txn *Transaction) (uint64, error) {
+		fee, err := policy.Fee(ctx, txn)
+		if err != nil {
+			return 0, err
+		}
+		if fee > max {
+			fee = max
+		}
+		return fee, nil
+	})
+}
+
+// feePercentile returns the nearest rank percentile of fees, 0 if empty.
+func feePercentile(fees []uint64, percentile float64) uint64 {
+	if len(fees) == 0 {
+		return 0
+	}
+	sorted := append([]uint64(nil), fees...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+var (
+	feePolicyMu sync.RWMutex
+	feePolicy   FeePolicy
+)
+
+// SetFeePolicy sets the policy the transaction helpers use for the fee of
+// the transactions created without one, nil to keep them at 0.
+func SetFeePolicy(policy FeePolicy) {
+	feePolicyMu.Lock()
+	feePolicy = policy
+	feePolicyMu.Unlock()
+}
+
+// GetFeePolicy returns the policy set by SetFeePolicy.
+func GetFeePolicy() FeePolicy {
+	feePolicyMu.RLock()
+	defer feePolicyMu.RUnlock()
+	return feePolicy
+}
+
+// applyFeePolicy sets the fee of t with the fee policy if it has none.
+// Failures are logged and leave the fee unset for the miners to reject.
+func (t *Transaction) applyFeePolicy() {
+	policy := GetFeePolicy()
+	if policy == nil || t.txn.TransactionFee != 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultFeeTimeout)
+	defer cancel()
+	fee, err := policy.Fee(ctx, t)
+	if err != nil {
+		logging.Error("fee policy failed: ", err)
+		return
+	}
+	t.txn.TransactionFee = fee
+}
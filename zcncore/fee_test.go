@@ -0,0 +1,91 @@
+package zcncore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/0chain/gosdk/core/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeePercentile(t *testing.T) {
+	fees := []uint64{50, 10, 40, 20, 30}
+	tests := []struct {
+		name       string
+		fees       []uint64
+		percentile float64
+		want       uint64
+	}{
+		{name: "Test_Empty", percentile: 50, want: 0},
+		{name: "Test_Median", fees: fees, percentile: 50, want: 30},
+		{name: "Test_Min", fees: fees, percentile: 0, want: 10},
+		{name: "Test_Max", fees: fees, percentile: 100, want: 50},
+		{name: "Test_P90", fees: fees, percentile: 90, want: 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, feePercentile(tt.fees, tt.percentile))
+		})
+	}
+	require.Equal(t, []uint64{50, 10, 40, 20, 30}, fees)
+}
+
+func TestEstimateTxnFee(t *testing.T) {
+	chain := _config.chain
+	t.Cleanup(func() { _config.chain = chain })
+
+	txn := &Transaction{txn: &transaction.Transaction{}}
+
+	t.Run("Test_Highest_Miner_Fee", func(t *testing.T) {
+		_config.chain = ChainConfig{Miners: newTestSharders(t, []sharderResponse{
+			{http.StatusOK, `{"fee":10}`}, {http.StatusOK, `{"fee":30}`}, {http.StatusInternalServerError, `{}`},
+		})}
+		fee, err := EstimateTxnFee(context.Background(), txn)
+		require.NoError(t, err)
+		require.EqualValues(t, 30, fee)
+
+		fee, err = CappedFee(EstimatedFee(), 20).Fee(context.Background(), txn)
+		require.NoError(t, err)
+		require.EqualValues(t, 20, fee)
+	})
+
+	t.Run("Test_All_Miners_Failed", func(t *testing.T) {
+		_config.chain = ChainConfig{Miners: newTestSharders(t, []sharderResponse{
+			{http.StatusNotFound, `not found`},
+		})}
+		_, err := EstimateTxnFee(context.Background(), txn)
+		require.Error(t, err)
+	})
+}
+
+func TestApplyFeePolicy(t *testing.T) {
+	t.Cleanup(func() { SetFeePolicy(nil) })
+
+	tests := []struct {
+		name   string
+		policy FeePolicy
+		fee    uint64
+		want   uint64
+	}{
+		{name: "Test_No_Policy", want: 0},
+		{name: "Test_Fixed", policy: FixedFee(7), want: 7},
+		{name: "Test_Fee_Already_Set", policy: FixedFee(7), fee: 3, want: 3},
+		{
+			name: "Test_Policy_Error",
+			policy: FeePolicyFunc(func(context.Context, *Transaction) (uint64, error) {
+				return 0, errors.New("no miners")
+			}),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetFeePolicy(tt.policy)
+			txn := &Transaction{txn: &transaction.Transaction{TransactionFee: tt.fee}}
+			txn.applyFeePolicy()
+			require.Equal(t, tt.want, txn.txn.TransactionFee)
+		})
+	}
+}
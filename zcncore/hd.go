@@ -0,0 +1,86 @@
+package zcncore
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/zcncrypto"
+)
+
+// HDAccount is an account of a mnemonic found by ScanHDWallets.
+type HDAccount struct {
+	Index    uint32 `json:"index"`
+	Path     string `json:"path"`
+	ClientID string `json:"client_id"`
+	Balance  int64  `json:"balance"`
+	Nonce    int64  `json:"nonce"`
+	// Wallet JSON, as returned by DeriveWallet
+	Wallet string `json:"wallet"`
+}
+
+// DeriveWallet returns the JSON of the wallet accountIndex of mnemonic, see
+// zcncrypto.HDPath for the derivation path. Account 0 is the wallet returned
+// by RecoverOfflineWallet. The wallet isn't registered to the miners.
+func DeriveWallet(mnemonic string, accountIndex uint32) (string, error) {
+	w, err := zcncrypto.DeriveKeys(_config.chain.SignatureScheme, mnemonic, accountIndex)
+	if err != nil {
+		return "", err
+	}
+	return w.Marshal()
+}
+
+// ScanHDWallets derives the first accounts of mnemonic and returns the ones
+// used on chain, i.e. with a balance or a transaction.
+func ScanHDWallets(ctx context.Context, mnemonic string, accounts int) ([]HDAccount, error) {
+	if err := checkSdkInit(); err != nil {
+		return nil, err
+	}
+
+	var found []HDAccount
+	for i := 0; i < accounts; i++ {
+		if err := ctx.Err(); err != nil {
+			return found, err
+		}
+
+		w, err := zcncrypto.DeriveKeys(_config.chain.SignatureScheme, mnemonic, uint32(i))
+		if err != nil {
+			return found, err
+		}
+		acc, used, err := scanHDAccount(w)
+		if err != nil {
+			return found, errors.Wrap(err, "scan account "+zcncrypto.HDPath(uint32(i)))
+		}
+		if !used {
+			continue
+		}
+		acc.Index = uint32(i)
+		acc.Path = zcncrypto.HDPath(uint32(i))
+		if acc.Wallet, err = w.Marshal(); err != nil {
+			return found, err
+		}
+		found = append(found, acc)
+	}
+	return found, nil
+}
+
+func scanHDAccount(w *zcncrypto.Wallet) (HDAccount, bool, error) {
+	acc := HDAccount{ClientID: w.ClientID}
+	_, info, err := getBalanceFromSharders(w.ClientID)
+	if err != nil {
+		if isValueNotPresent(info) {
+			return acc, false, nil
+		}
+		return acc, false, err
+	}
+
+	var resp struct {
+		Balance int64 `json:"balance"`
+		Nonce   int64 `json:"nonce"`
+	}
+	if err := json.Unmarshal([]byte(info), &resp); err != nil {
+		return acc, false, err
+	}
+	acc.Balance, acc.Nonce = resp.Balance, resp.Nonce
+	return acc, acc.Balance > 0 || acc.Nonce > 0, nil
+}
@@ -0,0 +1,52 @@
+package zcncore
+
+import (
+	"encoding/json"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/core/zcncrypto"
+)
+
+// SaveWalletEncrypted writes the wallet set with SetWalletInfo to path as a
+// keystore encrypted with passphrase, its key derived with scrypt.
+func SaveWalletEncrypted(path, passphrase string) error {
+	if err := checkWalletConfig(); err != nil {
+		return err
+	}
+	if passphrase == "" {
+		return errors.New("keystore", "empty passphrase")
+	}
+	data, err := zcncrypto.EncryptWallet(&_config.wallet, passphrase, zcncrypto.KDFScrypt, zcncrypto.DefaultScryptParams())
+	if err != nil {
+		return err
+	}
+	return sys.Files.WriteFile(path, data, 0600)
+}
+
+// LoadWalletEncrypted reads the keystore at path, decrypts it with
+// passphrase and sets its wallet as with SetWalletInfo.
+//
+// A plaintext wallet JSON is still loaded, but it is deprecated and logged:
+// it should be saved again with SaveWalletEncrypted.
+func LoadWalletEncrypted(path, passphrase string, splitKeyWallet bool) error {
+	data, err := sys.Files.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if !zcncrypto.IsKeystore(data) {
+		logging.Info("zcn: ", path, " is a plaintext wallet, deprecated, save it with SaveWalletEncrypted")
+		return SetWalletInfo(string(data), splitKeyWallet)
+	}
+
+	w, err := zcncrypto.DecryptWallet(data, passphrase)
+	if err != nil {
+		return err
+	}
+	walletJSON, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+	return SetWalletInfo(string(walletJSON), splitKeyWallet)
+}
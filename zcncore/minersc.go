@@ -0,0 +1,232 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+)
+
+// DefaultNodePageLimit is the page size of MinerSC.AllNodes
+const DefaultNodePageLimit = 20
+
+// MinerSC is the synchronous API of the miner smart contract: staking on
+// miners and sharders, their settings and their listing.
+type MinerSC struct {
+	*Client
+}
+
+// MinerSC returns the miner smart contract API of the client.
+func (c *Client) MinerSC() *MinerSC {
+	return &MinerSC{Client: c}
+}
+
+// NodeStat are the rewards and fees earned by a miner or a sharder.
+type NodeStat struct {
+	GeneratorRewards common.Balance `json:"generator_rewards,omitempty"`
+	GeneratorFees    common.Balance `json:"generator_fees,omitempty"`
+	SharderRewards   common.Balance `json:"sharder_rewards,omitempty"`
+	SharderFees      common.Balance `json:"sharder_fees,omitempty"`
+}
+
+// NodeDetails of a miner or a sharder.
+type NodeDetails struct {
+	ID              string           `json:"id"`
+	N2NHost         string           `json:"n2n_host"`
+	Host            string           `json:"host"`
+	Port            int              `json:"port"`
+	Path            string           `json:"path"`
+	PublicKey       string           `json:"public_key"`
+	ShortName       string           `json:"short_name"`
+	BuildTag        string           `json:"build_tag"`
+	TotalStake      common.Balance   `json:"total_stake"`
+	Delete          bool             `json:"delete"`
+	LastHealthCheck common.Timestamp `json:"last_health_check"`
+	Stat            NodeStat         `json:"stat"`
+}
+
+// NodeInfo is a miner or a sharder with its stake pool, the delegate pools
+// by id in StakePool.Pools.
+type NodeInfo struct {
+	NodeDetails `json:"simple_miner"`
+	StakePool   StakePool `json:"stake_pool"`
+}
+
+type nodeList struct {
+	Nodes []NodeInfo `json:"Nodes"`
+}
+
+// NodeListOptions paginate the list of miners or sharders.
+type NodeListOptions struct {
+	Offset int
+	// Limit is the page size, the sharders default if 0
+	Limit int
+	// Active lists only the nodes that passed their last health check
+	Active bool
+}
+
+func (o NodeListOptions) params() Params {
+	p := Params{}
+	if o.Offset > 0 {
+		p["offset"] = strconv.Itoa(o.Offset)
+	}
+	if o.Limit > 0 {
+		p["limit"] = strconv.Itoa(o.Limit)
+	}
+	if o.Active {
+		p["active"] = "true"
+	}
+	return p
+}
+
+func nodeListURL(provider Provider) (string, error) {
+	switch provider {
+	case ProviderMiner:
+		return GET_MINERSC_MINERS, nil
+	case ProviderSharder:
+		return GET_MINERSC_SHARDERS, nil
+	}
+	return "", errors.Newf("invalid_provider", "provider %d isn't a miner or a sharder", provider)
+}
+
+// ListNodes returns a page of the miners or sharders.
+func (m *MinerSC) ListNodes(ctx context.Context, provider Provider, opts NodeListOptions) ([]NodeInfo, error) {
+	url, err := nodeListURL(provider)
+	if err != nil {
+		return nil, err
+	}
+	var list nodeList
+	err = m.queryJSON(ctx, func(cb GetInfoCallback) error {
+		if err := CheckConfig(); err != nil {
+			return err
+		}
+		go GetInfoFromSharders(withParams(url, opts.params()), 0, cb)
+		return nil
+	}, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Nodes, nil
+}
+
+// AllNodes returns all the miners or sharders, listed by pages of
+// DefaultNodePageLimit.
+func (m *MinerSC) AllNodes(ctx context.Context, provider Provider, active bool) ([]NodeInfo, error) {
+	opts := NodeListOptions{Limit: DefaultNodePageLimit, Active: active}
+	return allNodePages(opts, func(opts NodeListOptions) ([]NodeInfo, error) {
+		return m.ListNodes(ctx, provider, opts)
+	})
+}
+
+// allNodePages lists the pages of nodes from opts.Offset until a short one.
+// Sharders ignoring the paging return all the nodes on every page, or the
+// same page again, which ends the listing.
+func allNodePages(opts NodeListOptions, listPage func(NodeListOptions) ([]NodeInfo, error)) ([]NodeInfo, error) {
+	var (
+		all     []NodeInfo
+		firstID string
+	)
+	for {
+		page, err := listPage(opts)
+		if err != nil {
+			return all, err
+		}
+		if len(page) > opts.Limit {
+			return page, nil
+		}
+		if len(page) > 0 {
+			if page[0].ID == firstID {
+				return all, nil
+			}
+			firstID = page[0].ID
+		}
+		all = append(all, page...)
+		if len(page) < opts.Limit {
+			return all, nil
+		}
+		opts.Offset += len(page)
+	}
+}
+
+// GetNode returns the miner or sharder id.
+func (m *MinerSC) GetNode(ctx context.Context, id string) (*NodeInfo, error) {
+	node := &NodeInfo{}
+	err := m.queryJSON(ctx, func(cb GetInfoCallback) error {
+		return GetMinerSCNodeInfo(id, cb)
+	}, node)
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// GetUserPools returns the delegate pools of clientID, the client wallet
+// if empty, by node.
+func (m *MinerSC) GetUserPools(ctx context.Context, clientID string) (*MinerSCUserPoolsInfo, error) {
+	if clientID == "" {
+		var err error
+		if clientID, err = m.clientID(); err != nil {
+			return nil, err
+		}
+	}
+	pools := &MinerSCUserPoolsInfo{}
+	err := m.queryJSON(ctx, func(cb GetInfoCallback) error {
+		return GetMinerSCUserInfo(clientID, cb)
+	}, pools)
+	if err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+// Lock stakes value SAS on the miner or sharder id.
+func (m *MinerSC) Lock(ctx context.Context, provider Provider, id string, value uint64) (*TxnResult, error) {
+	if _, err := nodeListURL(provider); err != nil {
+		return nil, err
+	}
+	return m.Execute(ctx, func(txn TransactionScheme) error {
+		return txn.MinerSCLock(id, provider, value)
+	})
+}
+
+// Unlock unstakes the tokens of the client from the miner or sharder id.
+func (m *MinerSC) Unlock(ctx context.Context, provider Provider, id string) (*TxnResult, error) {
+	if _, err := nodeListURL(provider); err != nil {
+		return nil, err
+	}
+	return m.Execute(ctx, func(txn TransactionScheme) error {
+		return txn.MinerSCUnlock(id, provider)
+	})
+}
+
+// CollectReward collects the rewards of the client on the miner or sharder id.
+func (m *MinerSC) CollectReward(ctx context.Context, provider Provider, id string) (*TxnResult, error) {
+	if _, err := nodeListURL(provider); err != nil {
+		return nil, err
+	}
+	return m.Execute(ctx, func(txn TransactionScheme) error {
+		return txn.MinerSCCollectReward(id, provider)
+	})
+}
+
+// UpdateSettings updates the stake pool settings of the miner or sharder id,
+// the client must be its delegate wallet.
+func (m *MinerSC) UpdateSettings(ctx context.Context, provider Provider, id string, settings StakePoolSettings) (*TxnResult, error) {
+	if _, err := nodeListURL(provider); err != nil {
+		return nil, err
+	}
+	info := &MinerSCMinerInfo{
+		SimpleMiner:         SimpleMiner{ID: id},
+		MinerSCDelegatePool: MinerSCDelegatePool{Settings: settings},
+	}
+	return m.Execute(ctx, func(txn TransactionScheme) error {
+		if provider == ProviderSharder {
+			return txn.MinerSCSharderSettings(info)
+		}
+		return txn.MinerSCMinerSettings(info)
+	})
+}
@@ -0,0 +1,88 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeInfo(t *testing.T) {
+	data := `{"Nodes":[{
+		"simple_miner":{"id":"m1","host":"example.com","port":7071,"total_stake":30,
+			"stat":{"generator_rewards":10,"generator_fees":2}},
+		"stake_pool":{"pools":{"p1":{"balance":30,"reward":1,"delegate_id":"d1"}},
+			"settings":{"delegate_wallet":"w","num_delegates":10,"service_charge":0.1}}
+	}]}`
+
+	var list nodeList
+	require.NoError(t, json.Unmarshal([]byte(data), &list))
+	require.Len(t, list.Nodes, 1)
+
+	node := list.Nodes[0]
+	require.Equal(t, "m1", node.ID)
+	require.EqualValues(t, 10, node.Stat.GeneratorRewards)
+	require.EqualValues(t, 30, node.StakePool.Pools["p1"].Balance)
+	require.Equal(t, "d1", node.StakePool.Pools["p1"].DelegateID)
+	require.Equal(t, 10, node.StakePool.Settings.NumDelegates)
+}
+
+func TestNodeListOptions(t *testing.T) {
+	require.Equal(t, "", NodeListOptions{}.params().Query())
+	require.Equal(t, "?active=true&limit=20&offset=40",
+		NodeListOptions{Offset: 40, Limit: 20, Active: true}.params().Query())
+}
+
+func TestMinerSCInvalidProvider(t *testing.T) {
+	m := NewClient().MinerSC()
+	_, err := m.Lock(context.Background(), ProviderBlobber, "b1", 10)
+	require.Error(t, err)
+	_, err = m.ListNodes(context.Background(), ProviderBlobber, NodeListOptions{})
+	require.Error(t, err)
+}
+
+func TestAllNodePages(t *testing.T) {
+	nodes := make([]NodeInfo, 5)
+	for i := range nodes {
+		nodes[i].ID = strconv.Itoa(i)
+	}
+	ids := func(nodes []NodeInfo) (ids []string) {
+		for _, n := range nodes {
+			ids = append(ids, n.ID)
+		}
+		return
+	}
+
+	tests := []struct {
+		name     string
+		listPage func(opts NodeListOptions) []NodeInfo
+		want     int
+	}{
+		{name: "Test_Paged", listPage: func(opts NodeListOptions) []NodeInfo {
+			end := opts.Offset + opts.Limit
+			if end > len(nodes) {
+				end = len(nodes)
+			}
+			return nodes[opts.Offset:end]
+		}, want: 5},
+		{name: "Test_All_At_Once", listPage: func(NodeListOptions) []NodeInfo { return nodes }, want: 5},
+		{name: "Test_Same_Page", listPage: func(NodeListOptions) []NodeInfo { return nodes[:2] }, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pages int
+			got, err := allNodePages(NodeListOptions{Limit: 2}, func(opts NodeListOptions) ([]NodeInfo, error) {
+				pages++
+				require.Less(t, pages, 10, "the listing must end")
+				return tt.listPage(opts), nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, ids(nodes[:tt.want]), ids(got))
+		})
+	}
+}
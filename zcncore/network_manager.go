@@ -0,0 +1,301 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/block"
+	"github.com/0chain/gosdk/core/conf"
+	"github.com/0chain/gosdk/core/resty"
+	"github.com/0chain/gosdk/core/transaction"
+	"go.uber.org/zap"
+)
+
+const (
+	// NodeEndpointWhoAmI is served by miners and sharders, it's the endpoint
+	// of CheckNodeHealth
+	NodeEndpointWhoAmI = "/_nh/whoami"
+	// DefaultNodeHealthTimeout bounds CheckNodeHealth
+	DefaultNodeHealthTimeout = 5 * time.Second
+)
+
+// NetworkManager keeps the miners and sharders of the network up to date:
+// it fetches them periodically, drops the ones failing their health check
+// and notifies the subscribers of the changes. It's safe for concurrent use.
+type NetworkManager struct {
+	// RefreshInterval between two refreshes of Run
+	RefreshInterval time.Duration
+	// Fetch returns the nodes of the network, FetchNetwork by default
+	Fetch func(ctx context.Context) (*Network, error)
+	// HealthCheck returns an error for a dead node, nil keeps all nodes
+	HealthCheck func(ctx context.Context, node string) error
+	// Apply is called with the network when it changes, before subscribers
+	// are notified
+	Apply func(n *Network)
+
+	refreshMu sync.Mutex
+	mu        sync.RWMutex
+	network   Network
+	subs      map[chan Network]struct{}
+}
+
+// NewNetworkManager creates a manager fetching the network with
+// FetchNetwork and checking the nodes with CheckNodeHealth.
+func NewNetworkManager() *NetworkManager {
+	return &NetworkManager{
+		RefreshInterval: time.Duration(networkWorkerTimerInHours) * time.Hour,
+		Fetch:           FetchNetwork,
+		HealthCheck:     CheckNodeHealth,
+	}
+}
+
+// defaultNetworkManager refreshes the network of the sdk config
+var defaultNetworkManager = func() *NetworkManager {
+	m := NewNetworkManager()
+	m.Apply = applyNetwork
+	return m
+}()
+
+// GetNetworkManager returns the manager of the network used by the sdk,
+// e.g. to subscribe to its changes or to force a refresh.
+func GetNetworkManager() *NetworkManager {
+	return defaultNetworkManager
+}
+
+// Network returns the current nodes.
+func (m *NetworkManager) Network() Network {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return Network{
+		Miners:   append([]string(nil), m.network.Miners...),
+		Sharders: append([]string(nil), m.network.Sharders...),
+	}
+}
+
+// Subscribe returns a channel receiving the network each time it changes,
+// only the latest change being kept for a slow receiver. cancel closes it.
+func (m *NetworkManager) Subscribe() (changes <-chan Network, cancel func()) {
+	ch := make(chan Network, 1)
+	m.mu.Lock()
+	if m.subs == nil {
+		m.subs = make(map[chan Network]struct{})
+	}
+	m.subs[ch] = struct{}{}
+	m.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			m.mu.Lock()
+			delete(m.subs, ch)
+			m.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Run refreshes the network every RefreshInterval until ctx is done.
+// Failed refreshes are logged and the current nodes kept.
+func (m *NetworkManager) Run(ctx context.Context) {
+	interval := m.RefreshInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logging.Info("Network stopped by user")
+			return
+		case <-ticker.C:
+			if err := m.ForceRefresh(ctx); err != nil {
+				logging.Error("Update network detail worker fail", zap.Error(err))
+			}
+		}
+	}
+}
+
+// ForceRefresh fetches the network now, drops the dead nodes and applies it
+// if it changed.
+func (m *NetworkManager) ForceRefresh(ctx context.Context) error {
+	m.refreshMu.Lock()
+	defer m.refreshMu.Unlock()
+
+	fetch := m.Fetch
+	if fetch == nil {
+		fetch = FetchNetwork
+	}
+	n, err := fetch(ctx)
+	if err != nil {
+		return err
+	}
+	if len(n.Miners) == 0 || len(n.Sharders) == 0 {
+		return errors.New("network_refresh", "network without miners or sharders")
+	}
+
+	if m.HealthCheck != nil {
+		n.Miners = m.alive(ctx, n.Miners)
+		n.Sharders = m.alive(ctx, n.Sharders)
+	}
+	m.set(n, true)
+	return nil
+}
+
+// alive returns the nodes passing their health check, all of them if none
+// does as the check may fail on the client side.
+func (m *NetworkManager) alive(ctx context.Context, nodes []string) []string {
+	var (
+		wg   sync.WaitGroup
+		dead = make([]bool, len(nodes))
+	)
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node string) {
+			defer wg.Done()
+			if err := m.HealthCheck(ctx, node); err != nil {
+				logging.Info("zcn: node ", node, " evicted: ", err)
+				dead[i] = true
+			}
+		}(i, node)
+	}
+	wg.Wait()
+
+	alive := make([]string, 0, len(nodes))
+	for i, node := range nodes {
+		if !dead[i] {
+			alive = append(alive, node)
+		}
+	}
+	if len(alive) == 0 {
+		return nodes
+	}
+	return alive
+}
+
+// set notifies the subscribers of n if it changed, after applying it.
+func (m *NetworkManager) set(n *Network, apply bool) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if reflect.DeepEqual(m.network.Miners, n.Miners) && reflect.DeepEqual(m.network.Sharders, n.Sharders) {
+		return false
+	}
+	m.network = Network{Miners: n.Miners, Sharders: n.Sharders}
+	if apply && m.Apply != nil {
+		m.Apply(&Network{Miners: n.Miners, Sharders: n.Sharders})
+	}
+	for ch := range m.subs {
+		select {
+		case <-ch: // drop the change not received yet
+		default:
+		}
+		ch <- Network{
+			Miners:   append([]string(nil), n.Miners...),
+			Sharders: append([]string(nil), n.Sharders...),
+		}
+	}
+	return true
+}
+
+// FetchNetwork returns the nodes of the network from the block worker, or
+// else from the latest magic block of the current sharders.
+func FetchNetwork(ctx context.Context) (*Network, error) {
+	n, err := GetNetworkDetails()
+	if err == nil {
+		return n, nil
+	}
+	if len(_config.chain.Sharders) == 0 {
+		return nil, err
+	}
+	logging.Error("zcn: block worker unavailable, using the latest magic block: ", err)
+	return fetchMagicBlockNetwork(ctx, _config.chain.Sharders)
+}
+
+func fetchMagicBlockNetwork(ctx context.Context, sharders []string) (*Network, error) {
+	tq, err := NewTransactionQuery(sharders)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := tq.FromAny(ctx, GET_LATEST_FINALIZED_MAGIC_BLOCK)
+	if err != nil {
+		return nil, err
+	}
+	if qr.StatusCode != http.StatusOK {
+		return nil, errors.Newf("get_network_details_error", "magic block: status %d", qr.StatusCode)
+	}
+	var resp struct {
+		MagicBlock *block.MagicBlock `json:"magic_block"`
+	}
+	if err := json.Unmarshal(qr.Content, &resp); err != nil {
+		return nil, errors.Wrap(err, "magic block")
+	}
+	if resp.MagicBlock == nil {
+		return nil, errors.New("get_network_details_error", "no magic block")
+	}
+	return &Network{
+		Miners:   nodeURLs(resp.MagicBlock.Miners),
+		Sharders: nodeURLs(resp.MagicBlock.Sharders),
+	}, nil
+}
+
+// nodeURLs returns the sorted urls of the nodes of pool, https behind a
+// proxy path, http on the node port otherwise.
+func nodeURLs(pool *block.NodePool) []string {
+	if pool == nil {
+		return nil
+	}
+	urls := make([]string, 0, len(pool.Nodes))
+	for _, n := range pool.Nodes {
+		if n.Path != "" {
+			urls = append(urls, fmt.Sprintf("https://%s/%s", n.Host, strings.Trim(n.Path, "/")))
+		} else {
+			urls = append(urls, fmt.Sprintf("http://%s:%d", n.Host, n.Port))
+		}
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+// CheckNodeHealth returns an error if the miner or sharder at url doesn't
+// respond, or responds with a server error.
+func CheckNodeHealth(ctx context.Context, url string) error {
+	r := resty.New(resty.WithTimeout(DefaultNodeHealthTimeout))
+	r.DoGet(ctx, strings.TrimSuffix(url, "/")+NodeEndpointWhoAmI).
+		Then(func(req *http.Request, resp *http.Response, respBody []byte, cf context.CancelFunc, err error) error {
+			if err != nil {
+				return err
+			}
+			if resp.StatusCode >= http.StatusInternalServerError {
+				return errors.New("node_offline", resp.Status)
+			}
+			return nil
+		})
+	if errs := r.Wait(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// applyNetwork sets the nodes of the sdk config.
+func applyNetwork(n *Network) {
+	_config.isConfigured = false
+	_config.chain.Miners = n.Miners
+	_config.chain.Sharders = n.Sharders
+	transaction.InitCache(n.Sharders)
+	conf.InitChainNetwork(&conf.Network{
+		Sharders: n.Sharders,
+		Miners:   n.Miners,
+	})
+	_config.isConfigured = true
+}
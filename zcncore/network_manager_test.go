@@ -0,0 +1,73 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0chain/gosdk/core/block"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkManager(t *testing.T) {
+	network := &Network{Miners: []string{"m1", "m2"}, Sharders: []string{"s1", "s2"}}
+	dead := map[string]bool{}
+	var applied int
+
+	m := &NetworkManager{
+		Fetch: func(ctx context.Context) (*Network, error) {
+			return &Network{Miners: network.Miners, Sharders: network.Sharders}, nil
+		},
+		HealthCheck: func(ctx context.Context, node string) error {
+			if dead[node] {
+				return errors.New("down")
+			}
+			return nil
+		},
+		Apply: func(n *Network) { applied++ },
+	}
+	changes, cancel := m.Subscribe()
+	defer cancel()
+
+	t.Run("Test_First_Refresh_Applied", func(t *testing.T) {
+		require.NoError(t, m.ForceRefresh(context.Background()))
+		require.Equal(t, 1, applied)
+		require.Equal(t, *network, <-changes)
+	})
+
+	t.Run("Test_Unchanged_Not_Notified", func(t *testing.T) {
+		require.NoError(t, m.ForceRefresh(context.Background()))
+		require.Equal(t, 1, applied)
+		require.Len(t, changes, 0)
+	})
+
+	t.Run("Test_Dead_Nodes_Evicted", func(t *testing.T) {
+		dead["m2"] = true
+		require.NoError(t, m.ForceRefresh(context.Background()))
+		require.Equal(t, []string{"m1"}, (<-changes).Miners)
+		require.Equal(t, []string{"m1"}, m.Network().Miners)
+	})
+
+	t.Run("Test_All_Dead_Kept", func(t *testing.T) {
+		dead["s1"], dead["s2"] = true, true
+		require.NoError(t, m.ForceRefresh(context.Background()))
+		require.Equal(t, []string{"s1", "s2"}, m.Network().Sharders)
+	})
+
+	t.Run("Test_Empty_Network_Rejected", func(t *testing.T) {
+		network = &Network{}
+		require.Error(t, m.ForceRefresh(context.Background()))
+		require.Equal(t, []string{"m1"}, m.Network().Miners)
+	})
+}
+
+func TestNodeURLs(t *testing.T) {
+	pool := &block.NodePool{Nodes: map[string]block.Node{
+		"a": {Host: "example.com", Path: "/sharder01/"},
+		"b": {Host: "10.0.0.1", Port: 7171},
+	}}
+	require.Equal(t, []string{"http://10.0.0.1:7171", "https://example.com/sharder01"}, nodeURLs(pool))
+}
@@ -8,7 +8,6 @@ import (
 	"encoding/json"
 	"net/http"
 	"reflect"
-	"time"
 
 	"github.com/0chain/gosdk/core/transaction"
 
@@ -28,44 +27,17 @@ type Network struct {
 }
 
 func updateNetworkDetailsWorker(ctx context.Context) {
-	ticker := time.NewTicker(time.Duration(networkWorkerTimerInHours) * time.Hour)
-	for {
-		select {
-		case <-ctx.Done():
-			logging.Info("Network stopped by user")
-			return
-		case <-ticker.C:
-			err := UpdateNetworkDetails()
-			if err != nil {
-				logging.Error("Update network detail worker fail", zap.Error(err))
-				return
-			}
-			logging.Info("Successfully updated network details")
-			return
-		}
-	}
+	defaultNetworkManager.Run(ctx)
 }
 
+// UpdateNetworkDetails refreshes the nodes of the sdk config, see
+// NetworkManager.ForceRefresh.
 func UpdateNetworkDetails() error {
-	networkDetails, err := GetNetworkDetails()
+	err := defaultNetworkManager.ForceRefresh(context.Background())
 	if err != nil {
 		logging.Error("Failed to update network details ", zap.Error(err))
-		return err
-	}
-
-	shouldUpdate := UpdateRequired(networkDetails)
-	if shouldUpdate {
-		_config.isConfigured = false
-		_config.chain.Miners = networkDetails.Miners
-		_config.chain.Sharders = networkDetails.Sharders
-		transaction.InitCache(networkDetails.Sharders)
-		conf.InitChainNetwork(&conf.Network{
-			Sharders: networkDetails.Sharders,
-			Miners:   networkDetails.Miners,
-		})
-		_config.isConfigured = true
 	}
-	return nil
+	return err
 }
 
 func UpdateRequired(networkDetails *Network) bool {
@@ -125,6 +97,7 @@ func SetNetwork(miners []string, sharders []string) {
 		Miners:   miners,
 		Sharders: sharders,
 	})
+	defaultNetworkManager.set(&Network{Miners: miners, Sharders: sharders}, false)
 }
 
 func GetNetworkJSON() string {
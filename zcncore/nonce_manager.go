@@ -0,0 +1,238 @@
+package zcncore
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/0chain/errors"
+)
+
+// DefaultNonceRetries is how many times NonceManager.Submit retries a
+// transaction rejected for its nonce.
+const DefaultNonceRetries = 2
+
+// NonceFetcher returns the nonce of the last transaction of clientID.
+type NonceFetcher func(ctx context.Context, clientID string) (int64, error)
+
+// NonceManager hands out the nonces of concurrent transactions of the same
+// wallets. Submissions of a wallet are queued so that its nonces are used in
+// order, and the next nonce is tracked locally, it is refreshed from the
+// sharders only on first use and when a transaction is rejected for its nonce,
+// see IsNonceError.
+type NonceManager struct {
+	// Retries of a transaction rejected for its nonce
+	Retries int
+
+	fetch NonceFetcher
+
+	mu      sync.Mutex
+	wallets map[string]*walletNonce
+}
+
+type walletNonce struct {
+	// queue is held while a transaction of the wallet is submitted
+	queue chan struct{}
+	// next nonce to use, 0 if it must be fetched
+	next int64
+}
+
+// NewNonceManager returns a NonceManager fetching nonces with fetch, from the
+// sharders if nil.
+func NewNonceManager(fetch NonceFetcher) *NonceManager {
+	if fetch == nil {
+		fetch = fetchNonceFromSharders
+	}
+	return &NonceManager{
+		Retries: DefaultNonceRetries,
+		fetch:   fetch,
+		wallets: make(map[string]*walletNonce),
+	}
+}
+
+func fetchNonceFromSharders(ctx context.Context, clientID string) (int64, error) {
+	type result struct {
+		nonce int64
+		err   error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		nonce, info, err := getNonceFromSharders(clientID)
+		if err != nil && isValueNotPresent(info) {
+			// no transaction yet
+			nonce, err = 0, nil
+		}
+		ch <- result{nonce, err}
+	}()
+	r, err := wait(ctx, ch)
+	if err != nil {
+		return 0, err
+	}
+	return r.nonce, r.err
+}
+
+func (m *NonceManager) wallet(clientID string) *walletNonce {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w, ok := m.wallets[clientID]
+	if !ok {
+		w = &walletNonce{queue: make(chan struct{}, 1)}
+		m.wallets[clientID] = w
+	}
+	return w
+}
+
+// lock waits for the turn of the wallet in its queue.
+func (w *walletNonce) lock(ctx context.Context) error {
+	select {
+	case w.queue <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *walletNonce) unlock() {
+	<-w.queue
+}
+
+// Submit waits for the previous transactions of clientID to be submitted,
+// then calls submit with the next nonce. submit must return once the
+// transaction is accepted or rejected by the miners. When it is rejected
+// for its nonce, the nonce is refreshed from the sharders and submit is
+// called again, up to Retries times.
+func (m *NonceManager) Submit(ctx context.Context, clientID string, submit func(nonce int64) error) error {
+	w := m.wallet(clientID)
+	if err := w.lock(ctx); err != nil {
+		return err
+	}
+	defer w.unlock()
+
+	for attempt := 0; ; attempt++ {
+		if err := m.load(ctx, clientID, w); err != nil {
+			return err
+		}
+
+		err := submit(w.next)
+		switch {
+		case err == nil:
+			w.next++
+			return nil
+		case IsNonceError(err) && attempt < m.Retries:
+			logging.Info("zcn: nonce ", w.next, " of ", clientID, " rejected, refreshing: ", err)
+			w.next = 0
+		default:
+			// the transaction may or may not have been accepted, the next
+			// nonce is kept: if it was, the next transaction is rejected
+			// for its nonce and that refreshes it
+			return err
+		}
+	}
+}
+
+// SubmitWithNonce submits a transaction of clientID with the given nonce,
+// e.g. to fill a gap left by a transaction that was dropped. It is queued
+// with the other transactions of the wallet but doesn't change its next
+// nonce.
+func (m *NonceManager) SubmitWithNonce(ctx context.Context, clientID string, nonce int64, submit func(nonce int64) error) error {
+	if nonce < 1 {
+		return errors.New("invalid_nonce", "nonce must be positive")
+	}
+	w := m.wallet(clientID)
+	if err := w.lock(ctx); err != nil {
+		return err
+	}
+	defer w.unlock()
+	return submit(nonce)
+}
+
+// SetNext overrides the next nonce of clientID, 0 to refresh it from the
+// sharders on next use.
+func (m *NonceManager) SetNext(ctx context.Context, clientID string, nonce int64) error {
+	w := m.wallet(clientID)
+	if err := w.lock(ctx); err != nil {
+		return err
+	}
+	defer w.unlock()
+	w.next = nonce
+	return nil
+}
+
+// Next returns the nonce the next transaction of clientID will be submitted
+// with, fetching it if unknown.
+func (m *NonceManager) Next(ctx context.Context, clientID string) (int64, error) {
+	w := m.wallet(clientID)
+	if err := w.lock(ctx); err != nil {
+		return 0, err
+	}
+	defer w.unlock()
+	if err := m.load(ctx, clientID, w); err != nil {
+		return 0, err
+	}
+	return w.next, nil
+}
+
+// load fetches the next nonce of w if unknown, w is locked.
+func (m *NonceManager) load(ctx context.Context, clientID string, w *walletNonce) error {
+	if w.next != 0 {
+		return nil
+	}
+	nonce, err := m.fetch(ctx, clientID)
+	if err != nil {
+		return errors.Wrap(err, "fetch nonce")
+	}
+	w.next = nonce + 1
+	return nil
+}
+
+// Refresh fetches the nonce of clientID from the sharders and returns the
+// next one.
+func (m *NonceManager) Refresh(ctx context.Context, clientID string) (int64, error) {
+	if err := m.SetNext(ctx, clientID, 0); err != nil {
+		return 0, err
+	}
+	return m.Next(ctx, clientID)
+}
+
+// nonceErrors are the messages of the miners rejecting a transaction for its
+// nonce, either already used or too far ahead.
+var nonceErrors = []string{
+	"invalid transaction nonce",
+	"invalid nonce",
+	"future transaction",
+}
+
+// IsNonceError tells whether the miners rejected a transaction for its nonce.
+func IsNonceError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, e := range nonceErrors {
+		if strings.Contains(msg, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// wait returns the value sent on ch, or ctx.Err() if ctx is done first.
+func wait[T any](ctx context.Context, ch <-chan T) (T, error) {
+	select {
+	case v := <-ch:
+		return v, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// isValueNotPresent tells whether a balance query failed because the client
+// has no transaction yet.
+func isValueNotPresent(info string) bool {
+	var resp struct {
+		Error string `json:"error"`
+	}
+	return json.Unmarshal([]byte(info), &resp) == nil && resp.Error == "value not present"
+}
@@ -0,0 +1,124 @@
+package zcncore
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceManager(t *testing.T) {
+	const clientID = "client"
+
+	t.Run("Test_Concurrent_Submit", func(t *testing.T) {
+		var fetched int
+		m := NewNonceManager(func(ctx context.Context, id string) (int64, error) {
+			fetched++
+			return 10, nil
+		})
+
+		var (
+			mu     sync.Mutex
+			wg     sync.WaitGroup
+			nonces = make(map[int64]bool)
+		)
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := m.Submit(context.Background(), clientID, func(nonce int64) error {
+					mu.Lock()
+					defer mu.Unlock()
+					nonces[nonce] = true
+					return nil
+				})
+				require.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		require.Equal(t, 1, fetched)
+		require.Len(t, nonces, 20)
+		for n := int64(11); n <= 30; n++ {
+			require.True(t, nonces[n], n)
+		}
+	})
+
+	t.Run("Test_Refresh_On_Nonce_Error", func(t *testing.T) {
+		onChain := int64(5)
+		m := NewNonceManager(func(ctx context.Context, id string) (int64, error) {
+			return onChain, nil
+		})
+		require.NoError(t, m.SetNext(context.Background(), clientID, 3))
+
+		var tried []int64
+		err := m.Submit(context.Background(), clientID, func(nonce int64) error {
+			tried = append(tried, nonce)
+			if nonce <= onChain {
+				return errors.New("invalid_request: invalid transaction nonce")
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []int64{3, 6}, tried)
+
+		next, err := m.Next(context.Background(), clientID)
+		require.NoError(t, err)
+		require.EqualValues(t, 7, next)
+	})
+
+	t.Run("Test_Other_Error", func(t *testing.T) {
+		m := NewNonceManager(func(ctx context.Context, id string) (int64, error) {
+			return 0, nil
+		})
+		calls := 0
+		err := m.Submit(context.Background(), clientID, func(nonce int64) error {
+			calls++
+			return errors.New("miners unreachable")
+		})
+		require.EqualError(t, err, "miners unreachable")
+		require.Equal(t, 1, calls)
+
+		next, err := m.Next(context.Background(), clientID)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, next, "not refreshed")
+	})
+
+	t.Run("Test_Submit_With_Nonce", func(t *testing.T) {
+		m := NewNonceManager(func(ctx context.Context, id string) (int64, error) {
+			return 8, nil
+		})
+		var got int64
+		err := m.SubmitWithNonce(context.Background(), clientID, 4, func(nonce int64) error {
+			got = nonce
+			return nil
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 4, got)
+
+		next, err := m.Next(context.Background(), clientID)
+		require.NoError(t, err)
+		require.EqualValues(t, 9, next)
+	})
+}
+
+func TestIsNonceError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Test_Invalid_Nonce", err: errors.New("invalid_request: invalid transaction nonce"), want: true},
+		{name: "Test_Future_Transaction", err: errors.New("submit transaction failed. future transaction"), want: true},
+		{name: "Test_Nil", err: nil},
+		{name: "Test_Fetch_Nonce_Failed", err: errors.New("fetch nonce: sharders unreachable")},
+		{name: "Test_Other", err: errors.New("insufficient balance to pay the fee of nonce 3")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsNonceError(tt.err))
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package zcncore
+
+import (
+	"encoding/json"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/transaction"
+	"github.com/0chain/gosdk/core/zcncrypto"
+)
+
+// Session is a wallet used independently of the wallet set with
+// SetWalletInfo, so that a server can act for several wallets at once. The
+// network is still the one set with Init. Split key wallets aren't supported.
+//
+// Sessions cover the transactions, the balance and the nonce of a wallet:
+// NewTransaction, GetBalance and GetNonce wrap the session of the wallet set
+// with SetWalletInfo. The rest of the API, e.g. the wallet management, only
+// uses the global wallet.
+type Session struct {
+	wallet zcncrypto.Wallet
+	signer zcncrypto.Signer
+	// global is set on the session of the wallet set with SetWalletInfo,
+	// read when used as it may be set again
+	global bool
+
+	// Callback is notified of every transaction of the session, in addition
+	// to the callback given to NewTransaction.
+	Callback TransactionCallback
+	// Nonces queues the transactions of the session sent with its Client.
+	Nonces *NonceManager
+}
+
+// NewSession returns a session for the wallet in JSON, see SetWalletInfo.
+func NewSession(walletJSON string) (*Session, error) {
+	s := &Session{Nonces: NewNonceManager(nil)}
+	if err := json.Unmarshal([]byte(walletJSON), &s.wallet); err != nil {
+		return nil, errors.Wrap(err, "invalid wallet")
+	}
+	if s.wallet.ClientID == "" || len(s.wallet.Keys) == 0 || s.wallet.Keys[0].PrivateKey == "" {
+		return nil, errors.New("invalid_wallet", "wallet must have a client id and a private key")
+	}
+	return s, nil
+}
+
+// NewSignerSession returns a session for the wallet clientID whose
+// transactions are signed by signer, e.g. an HSM or a remote signer.
+func NewSignerSession(clientID string, signer zcncrypto.Signer) (*Session, error) {
+	if clientID == "" || signer == nil {
+		return nil, errors.New("invalid_wallet", "client id and signer are required")
+	}
+	return &Session{
+		wallet: zcncrypto.Wallet{
+			ClientID:  clientID,
+			ClientKey: signer.PublicKey(),
+			Keys:      []zcncrypto.KeyPair{{PublicKey: signer.PublicKey()}},
+		},
+		signer: signer,
+		Nonces: NewNonceManager(nil),
+	}, nil
+}
+
+// globalSession is the session of the wallet set with SetWalletInfo, signing
+// with SignFn.
+var globalSession = &Session{global: true}
+
+// ClientID of the session wallet.
+func (s *Session) ClientID() string {
+	if s.global {
+		return _config.wallet.ClientID
+	}
+	return s.wallet.ClientID
+}
+
+// ClientKey is the public key of the session wallet.
+func (s *Session) ClientKey() string {
+	if s.global {
+		return _config.wallet.ClientKey
+	}
+	return s.wallet.ClientKey
+}
+
+// checkConfig checks the sdk is initialized, and the wallet set for the
+// global session.
+func (s *Session) checkConfig() error {
+	if s.global {
+		return CheckConfig()
+	}
+	return checkSdkInit()
+}
+
+// Sign signs hash with the session wallet.
+func (s *Session) Sign(hash string) (string, error) {
+	if s.global {
+		return SignFn(hash)
+	}
+	if s.signer != nil {
+		return s.signer.Sign(hash)
+	}
+	sigScheme := zcncrypto.NewSignatureScheme(_config.chain.SignatureScheme)
+	if err := sigScheme.SetPrivateKey(s.wallet.Keys[0].PrivateKey); err != nil {
+		return "", err
+	}
+	return sigScheme.Sign(hash)
+}
+
+// GetBalance retrieves the balance of the session wallet from the sharders.
+func (s *Session) GetBalance(cb GetBalanceCallback) error {
+	if err := s.checkConfig(); err != nil {
+		return err
+	}
+	getBalance(s.ClientID(), cb)
+	return nil
+}
+
+// GetNonce retrieves the nonce of the session wallet from the sharders.
+func (s *Session) GetNonce(cb GetNonceCallback) error {
+	if err := s.checkConfig(); err != nil {
+		return err
+	}
+	getNonce(s.ClientID(), cb)
+	return nil
+}
+
+func (s *Session) newTransaction(cb TransactionCallback, txnFee uint64, nonce int64) (*Transaction, error) {
+	if err := s.checkConfig(); err != nil {
+		return nil, err
+	}
+	if s.Callback != nil {
+		cb = teeTxnCallback{cb, s.Callback}
+	}
+	t := &Transaction{session: s}
+	t.txn = transaction.NewTransactionEntity(s.ClientID(), _config.chain.ChainID, s.ClientKey(), nonce)
+	t.txnStatus, t.verifyStatus = StatusUnknown, StatusUnknown
+	t.txnCb = cb
+	t.txn.TransactionFee = txnFee
+	return t, nil
+}
+
+// signFn returns the signer of the transaction wallet.
+func (t *Transaction) signFn() transaction.SignFunc {
+	if t.session != nil {
+		return t.session.Sign
+	}
+	return SignFn
+}
+
+// teeTxnCallback notifies both callbacks, either can be nil.
+type teeTxnCallback [2]TransactionCallback
+
+func (cbs teeTxnCallback) OnTransactionComplete(t *Transaction, status int) {
+	for _, cb := range cbs {
+		if cb != nil {
+			cb.OnTransactionComplete(t, status)
+		}
+	}
+}
+
+func (cbs teeTxnCallback) OnVerifyComplete(t *Transaction, status int) {
+	for _, cb := range cbs {
+		if cb != nil {
+			cb.OnVerifyComplete(t, status)
+		}
+	}
+}
+
+func (cbs teeTxnCallback) OnAuthComplete(t *Transaction, status int) {
+	for _, cb := range cbs {
+		if cb != nil {
+			cb.OnAuthComplete(t, status)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package zcncore
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/0chain/gosdk/core/zcncrypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		wallet  string
+		wantErr bool
+	}{
+		{name: "Test_Invalid_JSON", wallet: "{", wantErr: true},
+		{name: "Test_No_Keys", wallet: `{"client_id":"a"}`, wantErr: true},
+		{name: "Test_Valid", wallet: `{"client_id":"a","client_key":"b","keys":[{"public_key":"b","private_key":"c"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSession(tt.wallet)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "a", s.ClientID())
+			require.NotNil(t, s.Nonces)
+		})
+	}
+}
+
+func TestSessionTransaction(t *testing.T) {
+	config := _config
+	t.Cleanup(func() { _config = config })
+	_config.isConfigured = true
+	_config.chain.Miners = []string{"http://miner"}
+	_config.chain.Sharders = []string{"http://sharder"}
+	_config.chain.SignatureScheme = "ed25519"
+
+	newWallet := func() string {
+		w, err := zcncrypto.NewSignatureScheme("ed25519").GenerateKeys()
+		require.NoError(t, err)
+		b, err := json.Marshal(w)
+		require.NoError(t, err)
+		return string(b)
+	}
+	s1, err := NewSession(newWallet())
+	require.NoError(t, err)
+	s2, err := NewSession(newWallet())
+	require.NoError(t, err)
+
+	for _, s := range []*Session{s1, s2} {
+		txn, err := s.newTransaction(nil, 0, 1)
+		require.NoError(t, err)
+		require.Equal(t, s.ClientID(), txn.txn.ClientID)
+		require.Equal(t, s.ClientKey(), txn.txn.PublicKey)
+
+		require.NoError(t, txn.txn.ComputeHashAndSign(txn.signFn()))
+		ok, err := txn.txn.VerifyTransaction(func(signature, hash, publicKey string) (bool, error) {
+			scheme := zcncrypto.NewSignatureScheme("ed25519")
+			if err := scheme.SetPublicKey(publicKey); err != nil {
+				return false, err
+			}
+			return scheme.Verify(signature, hash)
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+}
+
+func TestNewSignerSession(t *testing.T) {
+	signer := zcncrypto.NewFuncSigner("key", func(hash string) (string, error) {
+		return "signed:" + hash, nil
+	})
+
+	_, err := NewSignerSession("", signer)
+	require.Error(t, err)
+
+	s, err := NewSignerSession("client", signer)
+	require.NoError(t, err)
+	require.Equal(t, "key", s.ClientKey())
+
+	sig, err := s.Sign("hash")
+	require.NoError(t, err)
+	require.Equal(t, "signed:hash", sig)
+}
+
+func TestGlobalSession(t *testing.T) {
+	config, signFn := _config, SignFn
+	t.Cleanup(func() { _config, SignFn = config, signFn })
+	_config.isConfigured = true
+	_config.chain.Miners = []string{"http://miner"}
+	_config.chain.Sharders = []string{"http://sharder"}
+
+	_, err := newTransaction(nil, 0, 1)
+	require.Error(t, err, "no wallet set")
+
+	_config.wallet = zcncrypto.Wallet{ClientID: "client", ClientKey: "key"}
+	_config.isValidWallet = true
+	SignFn = func(hash string) (string, error) { return "signed:" + hash, nil }
+
+	txn, err := newTransaction(nil, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, "client", txn.txn.ClientID)
+	require.Equal(t, "key", txn.txn.PublicKey)
+
+	// the wallet set again is the one used
+	_config.wallet.ClientID = "other"
+	require.Equal(t, "other", globalSession.ClientID())
+
+	sig, err := txn.signFn()("hash")
+	require.NoError(t, err)
+	require.Equal(t, "signed:hash", sig)
+}
@@ -0,0 +1,259 @@
+package zcncore
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/netconf"
+	"github.com/0chain/gosdk/core/util"
+)
+
+// ResponseHasher returns the hash sharders must agree on for a response
+// body. An error excludes the response from the consensus.
+type ResponseHasher func(body []byte) (string, error)
+
+// QuorumResult is the response most sharders agreed on.
+type QuorumResult struct {
+	Content    []byte `json:"content"`
+	StatusCode int    `json:"status_code"`
+	Hash       string `json:"hash"`
+	// Agreed sharders returned the response
+	Agreed []string `json:"agreed"`
+	// Disagreed maps the sharders that returned another response to its hash
+	Disagreed map[string]string `json:"disagreed,omitempty"`
+	// Failed maps the sharders that didn't respond to their error
+	Failed map[string]string `json:"failed,omitempty"`
+}
+
+// QuorumError is returned when fewer than Required sharders agreed on a
+// response. It matches ErrInvalidConsensus with errors.Is.
+type QuorumError struct {
+	Query     string            `json:"query"`
+	Required  int               `json:"required"`
+	Agreed    int               `json:"agreed"`
+	Total     int               `json:"total"`
+	Disagreed map[string]string `json:"disagreed,omitempty"`
+	Failed    map[string]string `json:"failed,omitempty"`
+}
+
+func (e *QuorumError) Error() string {
+	return fmt.Sprintf("zcn: quorum not reached for %s: %d of %d sharders agreed, %d required, disagreed: [%s], failed: [%s]",
+		e.Query, e.Agreed, e.Total, e.Required, strings.Join(sortedKeys(e.Disagreed), ", "), strings.Join(sortedKeys(e.Failed), ", "))
+}
+
+func (e *QuorumError) Unwrap() error {
+	return ErrInvalidConsensus
+}
+
+// SetSharderQuorum sets the percentage of sharders that must agree on the
+// response of a balance, transaction confirmation or smart contract query.
+// It defaults to 25. Queries already running keep the quorum they started
+// with.
+func SetSharderQuorum(percent int) error {
+	if percent < 1 || percent > 100 {
+		return errors.New("invalid_quorum", "sharder quorum must be between 1 and 100 percent")
+	}
+	atomic.StoreInt32(&consensusThresh, int32(percent))
+	return nil
+}
+
+// GetSharderQuorum returns the percentage set by SetSharderQuorum.
+func GetSharderQuorum() int {
+	return sharderQuorum()
+}
+
+func sharderQuorum() int {
+	return int(atomic.LoadInt32(&consensusThresh))
+}
+
+// SetQueryRateLimit limits the requests to each sharder and miner to
+// requestsPerSecond, with bursts of up to burst requests. Requests over the
+// limit are queued rather than failed. A requestsPerSecond of 0 removes the
+// limit, see netconf.SetRateLimit.
+func SetQueryRateLimit(requestsPerSecond float64, burst int) {
+	netconf.SetRateLimit(netconf.RateLimit{RequestsPerSecond: requestsPerSecond, Burst: burst})
+}
+
+// quorumRequired returns how many of total sharders are percent of them,
+// at least 1.
+func quorumRequired(percent, total int) int {
+	return util.MaxInt(calculateMinRequired(float64(percent), float64(total)/100), 1)
+}
+
+// HashBody hashes the raw response body.
+func HashBody(body []byte) (string, error) {
+	sum := sha1.Sum(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// JSONFieldsHasher hashes the given fields of a JSON object response, all of
+// them when none is given. Field order and the other fields, e.g. the round
+// the sharder answered at, don't change the hash.
+func JSONFieldsHasher(fields ...string) ResponseHasher {
+	return func(body []byte) (string, error) {
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(body, &m); err != nil {
+			return "", err
+		}
+		keys := fields
+		if len(keys) == 0 {
+			keys = make([]string, 0, len(m))
+			for k := range m {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+		}
+		h := sha1.New()
+		for _, k := range keys {
+			h.Write([]byte(k))
+			h.Write([]byte(":"))
+			h.Write(m[k])
+			h.Write([]byte(";"))
+		}
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+}
+
+// FromQuorum queries all sharders and returns the response at least required
+// of them agree on. Responses are compared by status code and hasher, nil
+// hashes the raw body; network and server errors count as failures. It
+// returns as soon as required sharders agree on a successful response, the
+// sharders that didn't answer by then aren't reported, see FromQuorumAll.
+func (tq *TransactionQuery) FromQuorum(ctx context.Context, query string, required int, hasher ResponseHasher) (*QuorumResult, error) {
+	return tq.fromQuorum(ctx, query, required, hasher, false)
+}
+
+// FromQuorumAll is FromQuorum waiting for every sharder, so that all the
+// ones disagreeing or failing are reported, in the result or in a
+// *QuorumError. It takes as long as the slowest sharder.
+func (tq *TransactionQuery) FromQuorumAll(ctx context.Context, query string, required int, hasher ResponseHasher) (*QuorumResult, error) {
+	return tq.fromQuorum(ctx, query, required, hasher, true)
+}
+
+func (tq *TransactionQuery) fromQuorum(ctx context.Context, query string, required int, hasher ResponseHasher, waitAll bool) (*QuorumResult, error) {
+	// all sharders are queried, offline ones included
+	switch {
+	case tq == nil || tq.max == 0:
+		return nil, ErrNoAvailableSharders
+	case required < 1:
+		return nil, ErrInvalidNumSharder
+	case required > tq.max:
+		return nil, ErrNoEnoughSharders
+	}
+	if hasher == nil {
+		hasher = HashBody
+	}
+
+	var (
+		mu      sync.Mutex
+		hashes  = make(map[string]string) // sharder -> key of its response
+		results = make(map[string]QueryResult)
+		counts  = make(map[string]int)
+		failed  = make(map[string]string)
+	)
+	err := tq.FromAll(ctx, query, func(qr QueryResult) bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if qr.Error != nil || qr.StatusCode >= http.StatusInternalServerError {
+			msg := http.StatusText(qr.StatusCode)
+			if qr.Error != nil {
+				msg = qr.Error.Error()
+			}
+			failed[qr.Sharder] = msg
+			return false
+		}
+
+		var hash string
+		if qr.StatusCode == http.StatusOK {
+			h, err := hasher(qr.Content)
+			if err != nil {
+				failed[qr.Sharder] = "invalid response: " + err.Error()
+				return false
+			}
+			hash = h
+		} else {
+			hash, _ = HashBody(qr.Content)
+		}
+		key := strconv.Itoa(qr.StatusCode) + ":" + hash
+
+		hashes[qr.Sharder] = key
+		counts[key]++
+		if _, ok := results[key]; !ok {
+			results[key] = qr
+		}
+		// the quorum is reached, the other requests are cancelled
+		return !waitAll && qr.StatusCode == http.StatusOK && counts[key] >= required
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the most agreed response wins, a successful one on ties
+	var best string
+	for key, count := range counts {
+		switch {
+		case best == "", count > counts[best]:
+			best = key
+		case count == counts[best] && results[key].StatusCode == http.StatusOK && results[best].StatusCode != http.StatusOK:
+			best = key
+		case count == counts[best] && results[key].StatusCode == results[best].StatusCode && key < best:
+			best = key // deterministic
+		}
+	}
+
+	var agreed []string
+	disagreed := make(map[string]string)
+	for sharder, key := range hashes {
+		if key == best {
+			agreed = append(agreed, sharder)
+		} else {
+			disagreed[sharder] = key
+		}
+	}
+	sort.Strings(agreed)
+
+	if len(disagreed) > 0 {
+		logging.Info("zcn: sharders disagreed on ", query, ": ", sortedKeys(disagreed))
+	}
+
+	if len(agreed) < required {
+		return nil, &QuorumError{
+			Query:     query,
+			Required:  required,
+			Agreed:    len(agreed),
+			Total:     tq.max,
+			Disagreed: disagreed,
+			Failed:    failed,
+		}
+	}
+
+	winner := results[best]
+	return &QuorumResult{
+		Content:    winner.Content,
+		StatusCode: winner.StatusCode,
+		Hash:       best,
+		Agreed:     agreed,
+		Disagreed:  disagreed,
+		Failed:     failed,
+	}, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
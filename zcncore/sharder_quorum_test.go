@@ -0,0 +1,223 @@
+package zcncore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sharderResponse struct {
+	status int
+	body   string
+}
+
+func newTestSharders(t *testing.T, responses []sharderResponse) []string {
+	sharders := make([]string, 0, len(responses))
+	for _, resp := range responses {
+		resp := resp
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(resp.status)
+			w.Write([]byte(resp.body)) //nolint
+		}))
+		t.Cleanup(srv.Close)
+		sharders = append(sharders, srv.URL)
+	}
+	return sharders
+}
+
+func TestFromQuorumAll(t *testing.T) {
+	tests := []struct {
+		name          string
+		responses     []sharderResponse
+		required      int
+		hasher        ResponseHasher
+		wantErr       error
+		wantContent   string
+		wantStatus    int
+		wantAgreed    int
+		wantDisagreed []int
+		wantFailed    []int
+	}{
+		{
+			name: "Test_Quorum_Reached",
+			responses: []sharderResponse{
+				{200, `{"balance":10}`}, {200, `{"balance":10}`}, {200, `{"balance":11}`},
+			},
+			required:      2,
+			wantContent:   `{"balance":10}`,
+			wantStatus:    200,
+			wantAgreed:    2,
+			wantDisagreed: []int{2},
+		},
+		{
+			name: "Test_Fields_Hasher_Ignores_Round",
+			responses: []sharderResponse{
+				{200, `{"balance":10,"round":1}`}, {200, `{"round":2,"balance":10}`},
+			},
+			required:   2,
+			hasher:     JSONFieldsHasher("balance"),
+			wantStatus: 200,
+			wantAgreed: 2,
+		},
+		{
+			name: "Test_Server_Errors_Fail",
+			responses: []sharderResponse{
+				{200, `{"balance":10}`}, {500, `boom`}, {502, `boom`},
+			},
+			required:    1,
+			wantContent: `{"balance":10}`,
+			wantStatus:  200,
+			wantAgreed:  1,
+			wantFailed:  []int{1, 2},
+		},
+		{
+			name: "Test_Agreed_Error_Response",
+			responses: []sharderResponse{
+				{400, `{"error":"value not present"}`}, {400, `{"error":"value not present"}`},
+			},
+			required:    2,
+			wantContent: `{"error":"value not present"}`,
+			wantStatus:  400,
+			wantAgreed:  2,
+		},
+		{
+			name: "Test_Quorum_Not_Reached",
+			responses: []sharderResponse{
+				{200, `{"balance":10}`}, {200, `{"balance":11}`}, {500, `boom`},
+			},
+			required: 2,
+			wantErr:  ErrInvalidConsensus,
+		},
+		{
+			name:      "Test_Required_Above_Sharders",
+			responses: []sharderResponse{{200, `{}`}},
+			required:  2,
+			wantErr:   ErrNoEnoughSharders,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sharders := newTestSharders(t, tt.responses)
+			tq, err := NewTransactionQuery(sharders)
+			require.NoError(t, err)
+
+			got, err := tq.FromQuorumAll(context.Background(), "/v1/client/get/balance", tt.required, tt.hasher)
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantContent != "" {
+				require.Equal(t, tt.wantContent, string(got.Content))
+			}
+			require.Equal(t, tt.wantStatus, got.StatusCode)
+			require.Len(t, got.Agreed, tt.wantAgreed)
+			require.Len(t, got.Disagreed, len(tt.wantDisagreed))
+			for _, i := range tt.wantDisagreed {
+				require.Contains(t, got.Disagreed, sharders[i])
+			}
+			require.Len(t, got.Failed, len(tt.wantFailed))
+			for _, i := range tt.wantFailed {
+				require.Contains(t, got.Failed, sharders[i])
+			}
+		})
+	}
+}
+
+func TestQuorumErrorReportsSharders(t *testing.T) {
+	sharders := newTestSharders(t, []sharderResponse{{200, `a`}, {200, `b`}})
+	tq, err := NewTransactionQuery(sharders)
+	require.NoError(t, err)
+
+	_, err = tq.FromQuorumAll(context.Background(), "/q", 2, nil)
+	var qerr *QuorumError
+	require.True(t, errors.As(err, &qerr))
+	require.Equal(t, 1, qerr.Agreed)
+	require.Equal(t, 2, qerr.Total)
+	require.Len(t, qerr.Disagreed, 1)
+}
+
+func TestFromQuorumReturnsOnQuorum(t *testing.T) {
+	sharders := newTestSharders(t, []sharderResponse{{200, `{"balance":10}`}, {200, `{"balance":10}`}})
+	// an offline sharder doesn't hold the query back once the quorum is reached
+	offline := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-offline:
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(offline) })
+	sharders = append(sharders, srv.URL)
+
+	tq, err := NewTransactionQuery(sharders)
+	require.NoError(t, err)
+
+	start := time.Now()
+	got, err := tq.FromQuorum(context.Background(), "/v1/client/get/balance", 2, nil)
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.Equal(t, `{"balance":10}`, string(got.Content))
+	require.Len(t, got.Agreed, 2)
+	require.NotContains(t, got.Failed, srv.URL)
+
+	// the quorum isn't reached without the offline sharder
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = tq.FromQuorum(ctx, "/v1/client/get/balance", 3, nil)
+	require.True(t, errors.Is(err, ErrInvalidConsensus), "got %v", err)
+}
+
+func TestSetSharderQuorum(t *testing.T) {
+	defer SetSharderQuorum(defaultConsensusThresh) //nolint: errcheck
+
+	require.Error(t, SetSharderQuorum(0))
+	require.Error(t, SetSharderQuorum(101))
+
+	// the quorum can be set while queries read it
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = quorumRequired(sharderQuorum(), 10)
+		}
+	}()
+	require.NoError(t, SetSharderQuorum(60))
+	<-done
+	require.Equal(t, 60, GetSharderQuorum())
+	require.Equal(t, 6, quorumRequired(GetSharderQuorum(), 10))
+}
+
+func TestMakeSCRestAPICall(t *testing.T) {
+	prev := _config.chain.Sharders
+	defer func() { _config.chain.Sharders = prev }()
+
+	t.Run("Test_Quorum_Response", func(t *testing.T) {
+		// 2 of the 3 sharders must agree
+		require.NoError(t, SetSharderQuorum(60))
+		defer SetSharderQuorum(defaultConsensusThresh) //nolint: errcheck
+
+		_config.chain.Sharders = newTestSharders(t, []sharderResponse{
+			{200, `{"id":"n1"}`}, {200, `{"id":"n1"}`}, {200, `{"id":"n2"}`},
+		})
+
+		got, err := MakeSCRestAPICall(MinerSmartContractAddress, "/nodeStat", map[string]string{"id": "n1"})
+		require.NoError(t, err)
+		require.Equal(t, `{"id":"n1"}`, string(got))
+	})
+
+	t.Run("Test_Error_Response", func(t *testing.T) {
+		_config.chain.Sharders = newTestSharders(t, []sharderResponse{
+			{400, `not found`}, {400, `not found`},
+		})
+
+		_, err := MakeSCRestAPICall(MinerSmartContractAddress, "/nodeStat", nil)
+		require.EqualError(t, err, "not found")
+	})
+}
@@ -0,0 +1,177 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/block"
+)
+
+const (
+	// DefaultBlockPollInterval is the interval between polls of the latest
+	// finalized block by SubscribeBlocks
+	DefaultBlockPollInterval = time.Second
+	// DefaultMaxBlockBackfill is the number of missed rounds fetched by
+	// SubscribeBlocks, older ones are skipped
+	DefaultMaxBlockBackfill = 100
+)
+
+// SubscribeOptions of SubscribeBlocksWith. Zero values use the defaults.
+type SubscribeOptions struct {
+	PollInterval time.Duration
+	// FromRound is the first round sent, the latest finalized one if 0
+	FromRound int64
+	// MaxBackfill is the number of rounds fetched when the latest finalized
+	// round is ahead of the last one sent
+	MaxBackfill int64
+	// Buffer of the returned channel
+	Buffer int
+}
+
+// for tests
+var (
+	latestFinalizedHeader = fetchLatestFinalizedHeader
+	blockHeaderByRound    = fetchBlockHeaderByRound
+)
+
+// SubscribeBlocks sends the header of every block finalized from now on,
+// see SubscribeBlocksWith.
+func SubscribeBlocks(ctx context.Context) (<-chan *block.Header, error) {
+	return SubscribeBlocksWith(ctx, SubscribeOptions{})
+}
+
+// SubscribeBlocksWith polls the sharders for the latest finalized block and
+// sends the headers of the new rounds in order, once each. Rounds finalized
+// between two polls are fetched one by one, up to MaxBackfill of them. The
+// channel is closed when ctx is done; query errors are logged and the poll
+// retried.
+func SubscribeBlocksWith(ctx context.Context, opts SubscribeOptions) (<-chan *block.Header, error) {
+	if err := checkSdkInit(); err != nil {
+		return nil, err
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultBlockPollInterval
+	}
+	if opts.MaxBackfill <= 0 {
+		opts.MaxBackfill = DefaultMaxBlockBackfill
+	}
+
+	ch := make(chan *block.Header, opts.Buffer)
+	go func() {
+		defer close(ch)
+
+		s := &blockSubscription{ch: ch, next: opts.FromRound, maxBackfill: opts.MaxBackfill}
+		ticker := time.NewTicker(opts.PollInterval)
+		defer ticker.Stop()
+		for {
+			if err := s.poll(ctx); err != nil && ctx.Err() == nil {
+				logging.Error("zcn: block subscription: ", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return ch, nil
+}
+
+type blockSubscription struct {
+	ch chan<- *block.Header
+	// next round to send, 0 until the first poll
+	next        int64
+	maxBackfill int64
+}
+
+// poll sends the rounds from next to the latest finalized one.
+func (s *blockSubscription) poll(ctx context.Context) error {
+	latest, err := latestFinalizedHeader(ctx)
+	if err != nil {
+		return err
+	}
+	if s.next == 0 {
+		s.next = latest.Round
+	}
+	if latest.Round < s.next {
+		return nil // already sent, or a sharder behind
+	}
+	if gap := latest.Round - s.next; gap > s.maxBackfill {
+		logging.Info(fmt.Sprintf("zcn: block subscription skipped rounds %d to %d", s.next, latest.Round-s.maxBackfill-1))
+		s.next = latest.Round - s.maxBackfill
+	}
+
+	for s.next < latest.Round {
+		h, err := blockHeaderByRound(ctx, s.next)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("round %d", s.next))
+		}
+		if !s.send(ctx, h) {
+			return ctx.Err()
+		}
+	}
+	if !s.send(ctx, latest) {
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (s *blockSubscription) send(ctx context.Context, h *block.Header) bool {
+	select {
+	case s.ch <- h:
+		s.next = h.Round + 1
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func fetchLatestFinalizedHeader(ctx context.Context) (*block.Header, error) {
+	tq, err := NewTransactionQuery(_config.chain.Sharders)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := tq.FromAny(ctx, GET_LATEST_FINALIZED)
+	if err != nil {
+		return nil, err
+	}
+	if qr.StatusCode != http.StatusOK {
+		return nil, errors.Newf("", "zcn: latest finalized block: status %d", qr.StatusCode)
+	}
+	h := &block.Header{}
+	if err := json.Unmarshal(qr.Content, h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func fetchBlockHeaderByRound(ctx context.Context, round int64) (*block.Header, error) {
+	tq, err := NewTransactionQuery(_config.chain.Sharders)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := tq.FromAny(ctx, fmt.Sprintf("%sround=%d&content=header", GET_BLOCK_INFO, round))
+	if err != nil {
+		return nil, err
+	}
+	if qr.StatusCode != http.StatusOK {
+		return nil, ErrBlockNotFound
+	}
+	var resp struct {
+		Header *block.Header `json:"header"`
+	}
+	if err := json.Unmarshal(qr.Content, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Header == nil || resp.Header.Round != round {
+		return nil, ErrBlockNotFound
+	}
+	return resp.Header, nil
+}
@@ -0,0 +1,52 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0chain/gosdk/core/block"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockSubscriptionPoll(t *testing.T) {
+	latest, byRound := latestFinalizedHeader, blockHeaderByRound
+	t.Cleanup(func() { latestFinalizedHeader, blockHeaderByRound = latest, byRound })
+
+	var round int64
+	latestFinalizedHeader = func(ctx context.Context) (*block.Header, error) {
+		return &block.Header{Round: round}, nil
+	}
+	blockHeaderByRound = func(ctx context.Context, r int64) (*block.Header, error) {
+		return &block.Header{Round: r}, nil
+	}
+
+	ch := make(chan *block.Header, 100)
+	s := &blockSubscription{ch: ch, maxBackfill: 5}
+	received := func() (rounds []int64) {
+		for len(ch) > 0 {
+			rounds = append(rounds, (<-ch).Round)
+		}
+		return
+	}
+
+	for _, tt := range []struct {
+		name   string
+		latest int64
+		want   []int64
+	}{
+		{"Test_First_Poll_Sends_Latest", 10, []int64{10}},
+		{"Test_Same_Round_Deduplicated", 10, nil},
+		{"Test_Gap_Backfilled", 13, []int64{11, 12, 13}},
+		{"Test_Sharder_Behind_Ignored", 9, nil},
+		{"Test_Large_Gap_Truncated", 100, []int64{95, 96, 97, 98, 99, 100}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			round = tt.latest
+			require.NoError(t, s.poll(context.Background()))
+			require.Equal(t, tt.want, received())
+		})
+	}
+}
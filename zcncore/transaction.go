@@ -15,6 +15,7 @@ import (
 	"github.com/0chain/gosdk/core/block"
 	"github.com/0chain/gosdk/core/common"
 	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/telemetry"
 	"github.com/0chain/gosdk/core/transaction"
 	"github.com/0chain/gosdk/core/util"
 )
@@ -249,6 +250,11 @@ func NewTransaction(cb TransactionCallback, txnFee uint64, nonce int64) (Transac
 	return t, err
 }
 
+// NewTransaction creates a transaction of the session wallet, see NewTransaction.
+func (s *Session) NewTransaction(cb TransactionCallback, txnFee uint64, nonce int64) (TransactionScheme, error) {
+	return s.newTransaction(cb, txnFee, nonce)
+}
+
 func (t *Transaction) ExecuteSmartContract(address, methodName string, input interface{}, val uint64) (*transaction.Transaction, error) {
 	err := t.createSmartContractTxn(address, methodName, input, val)
 	if err != nil {
@@ -893,6 +899,9 @@ func (t *Transaction) Verify() error {
 	}
 
 	go func() {
+		_, span := telemetry.StartSpan(context.Background(), "transaction.confirm",
+			telemetry.String(telemetry.AttrTxnHash, t.txnHash))
+		defer func() { telemetry.EndSpan(span, t.verifyError) }()
 
 		for {
 
@@ -969,11 +978,16 @@ func (t *Transaction) Verify() error {
 	return nil
 }
 
-// ConvertToValue converts ZCN tokens to SAS tokens
+// ConvertToValue converts ZCN tokens to SAS tokens, rounded to the nearest
+// SAS, 0 for negative or too large amounts, see common.BalanceFromTokens.
 // # Inputs
 //   - token: ZCN tokens
 func ConvertToValue(token float64) uint64 {
-	return uint64(token * float64(TOKEN_UNIT))
+	b, err := common.BalanceFromTokens(token)
+	if err != nil {
+		return 0
+	}
+	return uint64(b)
 }
 
 func GetLatestFinalized(ctx context.Context, numSharders int) (b *block.Header, err error) {
@@ -12,6 +12,7 @@ import (
 	"github.com/0chain/errors"
 	"github.com/0chain/gosdk/core/common"
 	"github.com/0chain/gosdk/core/sys"
+	"github.com/0chain/gosdk/core/telemetry"
 
 	"github.com/0chain/gosdk/core/conf"
 	"github.com/0chain/gosdk/core/encryption"
@@ -91,6 +92,7 @@ type TransactionCallback interface {
 type localConfig struct {
 	chain         ChainConfig
 	wallet        zcncrypto.Wallet
+	signer        zcncrypto.Signer
 	authUrl       string
 	isConfigured  bool
 	isValidWallet bool
@@ -193,6 +195,7 @@ func (bh *blockHeader) getCreationDate(defaultTime int64) int64 {
 
 type Transaction struct {
 	txn                      *transaction.Transaction
+	session                  *Session
 	txnOut                   string
 	txnHash                  string
 	txnStatus                int
@@ -208,20 +211,21 @@ type SendTxnData struct {
 	Note string `json:"note"`
 }
 
+// Sign signs hash with the signer set by SetSigner, or else with a KeySigner
+// over the keys of the wallet.
 func Sign(hash string) (string, error) {
-	sigScheme := zcncrypto.NewSignatureScheme(_config.chain.SignatureScheme)
-	err := sigScheme.SetPrivateKey(_config.wallet.Keys[0].PrivateKey)
-	if err != nil {
-		return "", err
+	s := _config.signer
+	if s == nil {
+		ks, err := zcncrypto.NewKeySigner(_config.chain.SignatureScheme, &_config.wallet)
+		if err != nil {
+			return "", err
+		}
+		s = ks
 	}
-	return sigScheme.Sign(hash)
+	return s.Sign(hash)
 }
 
-var SignFn = func(hash string) (string, error) {
-	sigScheme := zcncrypto.NewSignatureScheme(_config.chain.SignatureScheme)
-	sigScheme.SetPrivateKey(_config.wallet.Keys[0].PrivateKey)
-	return sigScheme.Sign(hash)
-}
+var SignFn = Sign
 
 func signWithWallet(hash string, wi interface{}) (string, error) {
 	w, ok := wi.(*zcncrypto.Wallet)
@@ -320,7 +324,8 @@ func (t *Transaction) submitTxn() {
 
 	// If Signature is not passed compute signature
 	if t.txn.Signature == "" {
-		err := t.txn.ComputeHashAndSign(SignFn)
+		t.applyFeePolicy()
+		err := t.txn.ComputeHashAndSign(t.signFn())
 		if err != nil {
 			t.completeTxn(StatusError, "", err)
 			transaction.Cache.Evict(t.txn.ClientID)
@@ -329,6 +334,10 @@ func (t *Transaction) submitTxn() {
 	}
 
 
+	ctx, span := telemetry.StartSpan(context.Background(), "transaction.submit",
+		telemetry.String(telemetry.AttrTxnHash, t.txn.Hash))
+	defer func() { telemetry.EndSpan(span, t.txnError) }()
+
 	var (
 		randomMiners = util.GetRandom(_config.chain.Miners, getMinMinersSubmit())
 		minersN     = len(randomMiners)
@@ -352,7 +361,9 @@ func (t *Transaction) submitTxn() {
 				return
 			}
 
+			start := time.Now()
 			res, err := req.Post()
+			telemetry.RecordRequest(ctx, minerurl, "transaction.submit", time.Since(start), err)
 			if err != nil {
 				logging.Error(minerurl, " submit transaction error. ", err.Error())
 				if int(atomic.AddInt32(&failedCount, 1)) == minersN {
@@ -381,14 +392,9 @@ func (t *Transaction) submitTxn() {
 	}
 }
 
+// newTransaction creates a transaction of the wallet set with SetWalletInfo.
 func newTransaction(cb TransactionCallback, txnFee uint64, nonce int64) (*Transaction, error) {
-	t := &Transaction{}
-	t.txn = transaction.NewTransactionEntity(_config.wallet.ClientID, _config.chain.ChainID, _config.wallet.ClientKey, nonce)
-	t.txnStatus, t.verifyStatus = StatusUnknown, StatusUnknown
-	t.txnCb = cb
-	t.txn.TransactionFee = txnFee
-	t.txn.TransactionNonce = nonce
-	return t, nil
+	return globalSession.newTransaction(cb, txnFee, nonce)
 }
 
 func (t *Transaction) SetTransactionCallback(cb TransactionCallback) error {
@@ -634,7 +640,7 @@ func getBlockInfoByRound(numSharders int, round int64, content string) (*blockHe
 			return nil, stdErrors.New("failed to get block info by round with consensus, timeout")
 		case rsp := <-resultC:
 			logging.Debug(rsp.Url, rsp.Status)
-			if failedCount * 100 / numSharders > 100 - consensusThresh {
+			if failedCount * 100 / numSharders > 100 - sharderQuorum() {
 				return nil, stdErrors.New("failed to get block info by round with consensus, too many failures")
 			}
 
@@ -661,7 +667,7 @@ func getBlockInfoByRound(numSharders int, round int64, content string) (*blockHe
 			roundConsensus[h]++
 			if roundConsensus[h] > maxConsensus {
 				maxConsensus = roundConsensus[h]
-				if maxConsensus*100/numSharders >= consensusThresh {
+				if maxConsensus*100/numSharders >= sharderQuorum() {
 					return &br.Header, nil
 				}
 			}
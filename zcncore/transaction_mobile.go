@@ -16,6 +16,7 @@ import (
 	"github.com/0chain/gosdk/core/block"
 	"github.com/0chain/gosdk/core/common"
 	"github.com/0chain/gosdk/core/encryption"
+	"github.com/0chain/gosdk/core/telemetry"
 	"github.com/0chain/gosdk/core/transaction"
 	"github.com/0chain/gosdk/core/util"
 )
@@ -353,6 +354,15 @@ func NewTransaction(cb TransactionCallback, txnFee string, nonce int64) (Transac
 	return t, err
 }
 
+// NewTransaction creates a transaction of the session wallet, see NewTransaction.
+func (s *Session) NewTransaction(cb TransactionCallback, txnFee string, nonce int64) (TransactionScheme, error) {
+	v, err := parseCoinStr(txnFee)
+	if err != nil {
+		return nil, err
+	}
+	return s.newTransaction(cb, v, nonce)
+}
+
 func (t *Transaction) ExecuteSmartContract(address, methodName string, input string, val string) error {
 	v, err := parseCoinStr(val)
 	if err != nil {
@@ -1026,6 +1036,9 @@ func (t *Transaction) Verify() error {
 	}
 
 	go func() {
+		_, span := telemetry.StartSpan(context.Background(), "transaction.confirm",
+			telemetry.String(telemetry.AttrTxnHash, t.txnHash))
+		defer func() { telemetry.EndSpan(span, t.verifyError) }()
 
 		for {
 
@@ -1114,11 +1127,16 @@ func (t *Transaction) ZCNSCAddAuthorizer(ip AddAuthorizerPayload) (err error) {
 	return
 }
 
-// ConvertTokenToSAS converts ZCN tokens to SAS tokens
+// ConvertTokenToSAS converts ZCN tokens to SAS tokens, rounded to the
+// nearest SAS, 0 for negative or too large amounts, see common.BalanceFromTokens.
 // # Inputs
 //   - token: ZCN tokens
 func ConvertTokenToSAS(token float64) uint64 {
-	return uint64(token * float64(TOKEN_UNIT))
+	b, err := common.BalanceFromTokens(token)
+	if err != nil {
+		return 0
+	}
+	return uint64(b)
 }
 
 // ConvertToValue converts ZCN tokens to SAS tokens with string format
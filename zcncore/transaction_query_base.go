@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
@@ -15,6 +16,7 @@ import (
 
 	thrown "github.com/0chain/errors"
 	"github.com/0chain/gosdk/core/resty"
+	"github.com/0chain/gosdk/core/screstapi"
 	"github.com/0chain/gosdk/core/util"
 )
 
@@ -35,6 +37,8 @@ const (
 )
 
 type QueryResult struct {
+	// Sharder the result comes from, only set by FromAll
+	Sharder    string
 	Content    []byte
 	StatusCode int
 	Error      error
@@ -246,18 +250,25 @@ func (tq *TransactionQuery) FromAll(ctx context.Context, query string, handle Qu
 	}
 
 	urls := make([]string, 0, tq.max)
+	hosts := make(map[string]string, tq.max)
 	for _, host := range tq.sharders {
-		urls = append(urls, tq.buildUrl(host, query))
+		u := tq.buildUrl(host, query)
+		urls = append(urls, u)
+		hosts[u] = host
 	}
 
 	r := resty.New(resty.WithTimeout(10 * time.Second))
 	r.DoGet(ctx, urls...).
 		Then(func(req *http.Request, resp *http.Response, respBody []byte, cf context.CancelFunc, err error) error {
 			res := QueryResult{
+				Sharder:    hosts[req.URL.String()],
 				Content:    respBody,
 				Error:      err,
 				StatusCode: http.StatusBadRequest,
 			}
+			if res.Sharder == "" {
+				res.Sharder = req.URL.Host
+			}
 
 			if resp != nil {
 				res.StatusCode = resp.StatusCode
@@ -285,81 +296,66 @@ func (tq *TransactionQuery) FromAll(ctx context.Context, query string, handle Qu
 }
 
 func (tq *TransactionQuery) getConsensusConfirmation(ctx context.Context, numSharders int, txnHash string) (*blockHeader, map[string]json.RawMessage, *blockHeader, error) {
-	maxConfirmation := int(0)
-	txnConfirmations := make(map[string]int)
-	var confirmationBlockHeader *blockHeader
-	var confirmationBlock map[string]json.RawMessage
 	var lfbBlockHeader *blockHeader
 	maxLfbBlockHeader := int(0)
 	lfbBlockHeaders := make(map[string]int)
+	confirmations := 0
 
-	// {host}/v1/transaction/get/confirmation?hash={txnHash}&content=lfb
-	err := tq.FromAll(ctx,
-		tq.buildUrl("", TXN_VERIFY_URL, txnHash, "&content=lfb"),
-		func(qr QueryResult) bool {
-			if qr.StatusCode != http.StatusOK {
-				return false
-			}
-
-			var cfmBlock map[string]json.RawMessage
-			err := json.Unmarshal([]byte(qr.Content), &cfmBlock)
-			if err != nil {
-				logging.Error("txn confirmation parse error", err)
-				return false
-			}
+	// sharders agree on the block the transaction was confirmed in, the
+	// latest_finalized_block of the ones that didn't confirm it is kept
+	hasher := func(body []byte) (string, error) {
+		var cfmBlock map[string]json.RawMessage
+		err := json.Unmarshal(body, &cfmBlock)
+		if err != nil {
+			logging.Error("txn confirmation parse error", err)
+			return "", err
+		}
 
-			// parse `confirmation` section as block header
-			cfmBlockHeader, err := getBlockHeaderFromTransactionConfirmation(txnHash, cfmBlock)
-			if err != nil {
-				logging.Error("txn confirmation parse header error", err)
-
-				// parse `latest_finalized_block` section
-				if lfbRaw, ok := cfmBlock["latest_finalized_block"]; ok {
-					var lfb blockHeader
-					err := json.Unmarshal([]byte(lfbRaw), &lfb)
-					if err != nil {
-						logging.Error("round info parse error.", err)
-						return false
-					}
-
-					lfbBlockHeaders[lfb.Hash]++
-					if lfbBlockHeaders[lfb.Hash] > maxLfbBlockHeader {
-						maxLfbBlockHeader = lfbBlockHeaders[lfb.Hash]
-						lfbBlockHeader = &lfb
-					}
+		// parse `confirmation` section as block header
+		cfmBlockHeader, err := getBlockHeaderFromTransactionConfirmation(txnHash, cfmBlock)
+		if err != nil {
+			logging.Error("txn confirmation parse header error", err)
+
+			// parse `latest_finalized_block` section
+			if lfbRaw, ok := cfmBlock["latest_finalized_block"]; ok {
+				var lfb blockHeader
+				if err := json.Unmarshal([]byte(lfbRaw), &lfb); err != nil {
+					logging.Error("round info parse error.", err)
+					return "", err
 				}
 
-				return false
-			}
-
-			txnConfirmations[cfmBlockHeader.Hash]++
-			if txnConfirmations[cfmBlockHeader.Hash] > maxConfirmation {
-				maxConfirmation = txnConfirmations[cfmBlockHeader.Hash]
-
-				if maxConfirmation >= numSharders {
-					confirmationBlockHeader = cfmBlockHeader
-					confirmationBlock = cfmBlock
-
-					// it is consensus by enough sharders, and latest_finalized_block is valid
-					// return true to cancel other requests
-					return true
+				lfbBlockHeaders[lfb.Hash]++
+				if lfbBlockHeaders[lfb.Hash] > maxLfbBlockHeader {
+					maxLfbBlockHeader = lfbBlockHeaders[lfb.Hash]
+					lfbBlockHeader = &lfb
 				}
 			}
+			return "", err
+		}
 
-			return false
-
-		})
+		confirmations++
+		return cfmBlockHeader.Hash, nil
+	}
 
+	// {host}/v1/transaction/get/confirmation?hash={txnHash}&content=lfb
+	qr, err := tq.FromQuorum(ctx, tq.buildUrl("", TXN_VERIFY_URL, txnHash, "&content=lfb"), numSharders, hasher)
+	if confirmations == 0 {
+		return nil, nil, lfbBlockHeader, ErrTransactionNotFound
+	}
 	if err != nil {
 		return nil, nil, lfbBlockHeader, err
 	}
-
-	if maxConfirmation == 0 {
-		return nil, nil, lfbBlockHeader, stderrors.New("zcn: transaction not found")
+	if qr.StatusCode != http.StatusOK {
+		return nil, nil, lfbBlockHeader, ErrInvalidConsensus
 	}
 
-	if maxConfirmation < numSharders {
-		return nil, nil, lfbBlockHeader, ErrInvalidConsensus
+	var confirmationBlock map[string]json.RawMessage
+	if err := json.Unmarshal(qr.Content, &confirmationBlock); err != nil {
+		return nil, nil, lfbBlockHeader, err
+	}
+	confirmationBlockHeader, err := getBlockHeaderFromTransactionConfirmation(txnHash, confirmationBlock)
+	if err != nil {
+		return nil, nil, lfbBlockHeader, err
 	}
 
 	return confirmationBlockHeader, confirmationBlock, lfbBlockHeader, nil
@@ -412,73 +408,43 @@ func (tq *TransactionQuery) getFastConfirmation(ctx context.Context, txnHash str
 	return nil, nil, nil, thrown.Throw(ErrTransactionNotFound, strconv.Itoa(result.StatusCode))
 }
 
+// GetInfo queries all sharders and returns the response at least
+// consensusThresh percent of them returned, see SetSharderQuorum.
 func (tq *TransactionQuery) GetInfo(ctx context.Context, query string) (*QueryResult, error) {
-
-	consensuses := make(map[int]int)
-	var maxConsensus int
-	var consensusesResp QueryResult
 	// {host}{query}
-	err := tq.FromAll(ctx, query,
-		func(qr QueryResult) bool {
-			//ignore response if it is network error
-			if qr.StatusCode >= 500 {
-				return false
-			}
-
-			consensuses[qr.StatusCode]++
-			if consensuses[qr.StatusCode] > maxConsensus {
-				maxConsensus = consensuses[qr.StatusCode]
-				consensusesResp = qr
-			}
-
-			// If number of 200's is equal to number of some other status codes, use 200's response. it fixes the random issue about 50% failed and 50% success on 2 sharders
-			if qr.StatusCode == http.StatusOK {
-
-				if consensuses[http.StatusOK] == maxConsensus {
-					maxConsensus = consensuses[qr.StatusCode]
-					consensusesResp = qr
-				}
-
-				// consensus has been reached, don't waiting for other requests
-				if maxConsensus*100/tq.max >= consensusThresh {
-					return true
-				}
-
-				// query success, but doesn't reach consensus
-				return false
-			}
-
-			// query fails
-			return false
-
-		})
-
+	qr, err := tq.FromQuorum(ctx, query, quorumRequired(sharderQuorum(), tq.max), nil)
 	if err != nil {
+		var qerr *QuorumError
+		if errors.As(err, &qerr) && qerr.Agreed == 0 && len(qerr.Disagreed) == 0 {
+			return nil, fmt.Errorf("zcn: object not found: %w", err)
+		}
 		return nil, err
 	}
 
-	if maxConsensus == 0 {
-		return nil, stderrors.New("zcn: object not found")
-	}
-
-	rate := maxConsensus * 100 / tq.max
-	if rate < consensusThresh {
-		return nil, ErrInvalidConsensus
-	}
-
-	if consensusesResp.StatusCode != http.StatusOK {
-		return nil, stderrors.New(string(consensusesResp.Content))
+	if qr.StatusCode != http.StatusOK {
+		return nil, stderrors.New(string(qr.Content))
 	}
 
-	return &consensusesResp, nil
+	return &QueryResult{Content: qr.Content, StatusCode: qr.StatusCode}, nil
 }
 
 func MakeSCRestAPICall(scAddress string, relativePath string, params map[string]string) ([]byte, error) {
+	values := make(url.Values, len(params))
+	for k, v := range params {
+		values.Set(k, v)
+	}
+	return screstapi.Get(context.TODO(), SCRestQuerier(), scAddress, relativePath, values)
+}
 
-	path := fmt.Sprintf("/v1/screst/%v%v", scAddress, relativePath)
-	query := withParams(path, Params(params))
+// SCRestQuerier returns the querier of the typed smart contract clients of
+// screstapi, sending the queries to up to 10 sharders of the network and
+// returning the response the sharder quorum agrees on, see GetInfo.
+func SCRestQuerier() screstapi.Querier {
+	return screstapi.QuerierFunc(querySharders)
+}
 
-	sharders := util.Shuffle(util.Shuffle(_config.chain.Sharders))
+func querySharders(ctx context.Context, path string) ([]byte, error) {
+	sharders := util.Shuffle(_config.chain.Sharders)
 
 	min := util.MinInt(10, len(sharders))
 
@@ -487,7 +453,7 @@ func MakeSCRestAPICall(scAddress string, relativePath string, params map[string]
 		return nil, err
 	}
 
-	qr, err := tq.GetInfo(context.TODO(), query)
+	qr, err := tq.GetInfo(ctx, path)
 	if err != nil {
 		return nil, err
 	}
@@ -533,6 +499,8 @@ func GetInfoFromAnySharder(urlSuffix string, op int, cb GetInfoCallback) {
 	cb.OnInfoAvailable(op, StatusSuccess, string(qr.Content), "")
 }
 
+// GetEvents returns the raw events matching filters, see QueryEvents for
+// typed events with pagination.
 func GetEvents(cb GetInfoCallback, filters map[string]string) (err error) {
 	if err = CheckConfig(); err != nil {
 		return
@@ -118,6 +118,7 @@ func (ta *TransactionWithAuth) submitTxn() {
 		transaction.Cache.Set(ta.t.txn.ClientID, nonce)
 	}
 	ta.t.txn.TransactionNonce = nonce
+	ta.t.applyFeePolicy()
 
 	authTxn, err := ta.getAuthorize()
 	if err != nil {
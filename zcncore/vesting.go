@@ -0,0 +1,123 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/0chain/errors"
+	"github.com/0chain/gosdk/core/common"
+)
+
+// VestingCreatePool creates a vesting pool locking value SAS, which must
+// cover the amounts of the destinations, and returns its id.
+func (c *Client) VestingCreatePool(ctx context.Context, ar *VestingAddRequest, value uint64) (string, *TxnResult, error) {
+	if ar == nil || len(ar.Destinations) == 0 {
+		return "", nil, errors.New("vesting_add", "at least one destination is required")
+	}
+	var total common.Balance
+	for _, d := range ar.Destinations {
+		if d.ID == "" || d.Amount <= 0 {
+			return "", nil, errors.New("vesting_add", "destinations need an id and a positive amount")
+		}
+		total += d.Amount
+	}
+	if uint64(total) > value {
+		return "", nil, errors.Newf("vesting_add", "value %d doesn't cover the %d vested", value, total)
+	}
+
+	result, err := c.Execute(ctx, func(txn TransactionScheme) error {
+		return txn.VestingAdd(ar, value)
+	})
+	if err != nil {
+		return "", result, err
+	}
+	return vestingPoolID(result.Output), result, nil
+}
+
+// vestingPoolID returns the pool id of the output of vesting add, either
+// the id or the pool JSON.
+func vestingPoolID(output string) string {
+	var pool struct {
+		ID string `json:"pool_id"`
+	}
+	if json.Unmarshal([]byte(output), &pool) == nil && pool.ID != "" {
+		return pool.ID
+	}
+	return strings.Trim(output, `" `)
+}
+
+// VestingStop stops vesting to destination, its unvested tokens going back
+// to the pool owner.
+func (c *Client) VestingStop(ctx context.Context, poolID, destination string) (*TxnResult, error) {
+	return c.Execute(ctx, func(txn TransactionScheme) error {
+		return txn.VestingStop(&VestingStopRequest{PoolID: poolID, Destination: destination})
+	})
+}
+
+// VestingUnlock unlocks the tokens of the pool poolID: the vested ones of a
+// destination, or the ones left by the owner.
+func (c *Client) VestingUnlock(ctx context.Context, poolID string) (*TxnResult, error) {
+	return c.Execute(ctx, func(txn TransactionScheme) error {
+		return txn.VestingUnlock(poolID)
+	})
+}
+
+// VestingTrigger sends the vested tokens of the pool poolID to all of its
+// destinations. Only the owner of the pool can trigger it.
+func (c *Client) VestingTrigger(ctx context.Context, poolID string) (*TxnResult, error) {
+	return c.Execute(ctx, func(txn TransactionScheme) error {
+		return txn.VestingTrigger(poolID)
+	})
+}
+
+// VestingDelete deletes the pool poolID, its vested tokens sent to the
+// destinations and the other ones to the owner.
+func (c *Client) VestingDelete(ctx context.Context, poolID string) (*TxnResult, error) {
+	return c.Execute(ctx, func(txn TransactionScheme) error {
+		return txn.VestingDelete(poolID)
+	})
+}
+
+// GetVestingSCConfig returns the config of the vesting smart contract.
+func (c *Client) GetVestingSCConfig(ctx context.Context) (*VestingSCConfig, error) {
+	conf := &VestingSCConfig{}
+	if err := c.queryJSON(ctx, GetVestingSCConfig, conf); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// GetVestingPoolInfo returns the vesting pool poolID.
+func (c *Client) GetVestingPoolInfo(ctx context.Context, poolID string) (*VestingPoolInfo, error) {
+	info := &VestingPoolInfo{}
+	err := c.queryJSON(ctx, func(cb GetInfoCallback) error {
+		return GetVestingPoolInfo(poolID, cb)
+	}, info)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// GetVestingClientPools returns the ids of the vesting pools of clientID,
+// the client wallet if empty.
+func (c *Client) GetVestingClientPools(ctx context.Context, clientID string) ([]common.Key, error) {
+	if clientID == "" {
+		var err error
+		if clientID, err = c.clientID(); err != nil {
+			return nil, err
+		}
+	}
+	list := &VestingClientList{}
+	err := c.queryJSON(ctx, func(cb GetInfoCallback) error {
+		return GetVestingClientList(clientID, cb)
+	}, list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Pools, nil
+}
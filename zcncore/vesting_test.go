@@ -0,0 +1,40 @@
+//go:build !mobile
+// +build !mobile
+
+package zcncore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVestingPoolID(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{"Test_Pool_JSON", `{"pool_id":"abc","balance":10}`, "abc"},
+		{"Test_Quoted_ID", `"abc"`, "abc"},
+		{"Test_Raw_ID", `abc`, "abc"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, vestingPoolID(tt.output))
+		})
+	}
+}
+
+func TestVestingCreatePoolValidation(t *testing.T) {
+	c := NewClient()
+	ctx := context.Background()
+
+	_, _, err := c.VestingCreatePool(ctx, &VestingAddRequest{}, 10)
+	require.Error(t, err)
+
+	_, _, err = c.VestingCreatePool(ctx, &VestingAddRequest{
+		Destinations: []*VestingDest{{ID: "a", Amount: 6}, {ID: "b", Amount: 6}},
+	}, 10)
+	require.Error(t, err)
+}
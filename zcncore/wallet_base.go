@@ -102,7 +102,12 @@ const (
 )
 
 // In percentage
-const consensusThresh = 25
+const defaultConsensusThresh = 25
+
+// consensusThresh is the percentage of sharders that must agree on the
+// response of a query, see SetSharderQuorum. Queries read it with
+// sharderQuorum as it can be set while they run.
+var consensusThresh int32 = defaultConsensusThresh
 
 const (
 	defaultMinSubmit               = int(50)
@@ -163,6 +168,7 @@ const (
 	OpZCNSCGetGlobalConfig
 	OpZCNSCGetAuthorizer
 	OpZCNSCGetAuthorizerNodes
+	OpZCNSCGetNotProcessedBurnTickets
 )
 
 // WalletCallback needs to be implemented for wallet creation.
@@ -428,9 +434,9 @@ func registerToMiners(wallet *zcncrypto.Wallet, statusCb WalletCallback) error {
 
 	}
 	rate := consensus * 100 / float32(len(_config.chain.Miners))
-	if rate < consensusThresh {
+	if rate < float32(sharderQuorum()) {
 		statusCb.OnWalletCreateComplete(StatusError, "", "rate is less than consensus")
-		return fmt.Errorf("Register consensus not met. Consensus: %f, Expected: %v", rate, consensusThresh)
+		return fmt.Errorf("Register consensus not met. Consensus: %f, Expected: %v", rate, sharderQuorum())
 	}
 
 	cw := &GetClientResponse{}
@@ -565,6 +571,14 @@ func SetWallet(w zcncrypto.Wallet, splitKeyWallet bool) error {
 	return nil
 }
 
+// SetSigner makes the transactions of the wallet set with SetWalletInfo be
+// signed by s, e.g. an HSM or a remote signer, so that the wallet needs no
+// private key. nil restores the signing with the wallet keys. Split key
+// wallets still sign with their keys.
+func SetSigner(s zcncrypto.Signer) {
+	_config.signer = s
+}
+
 func GetWalletRaw() zcncrypto.Wallet {
 	return _config.wallet
 }
@@ -641,12 +655,12 @@ func getWalletBalance(clientId string) (common.Balance, error) {
 //	# Inputs
 //	-	cb: callback for checking result
 func GetBalance(cb GetBalanceCallback) error {
-	err := CheckConfig()
-	if err != nil {
-		return err
-	}
+	return globalSession.GetBalance(cb)
+}
+
+func getBalance(clientID string, cb GetBalanceCallback) {
 	go func() {
-		value, info, err := getBalanceFromSharders(_config.wallet.ClientID)
+		value, info, err := getBalanceFromSharders(clientID)
 		if err != nil {
 			logging.Error(err)
 			cb.OnBalanceAvailable(StatusError, 0, info)
@@ -654,22 +668,19 @@ func GetBalance(cb GetBalanceCallback) error {
 		}
 		cb.OnBalanceAvailable(StatusSuccess, value, info)
 	}()
-	return nil
 }
 
 // GetBalance retrieve wallet nonce from sharders
 func GetNonce(cb GetNonceCallback) error {
+	return globalSession.GetNonce(cb)
+}
+
+func getNonce(clientID string, cb GetNonceCallback) {
 	if cb == nil {
 		cb = &GetNonceCallbackStub{}
 	}
-
-	err := CheckConfig()
-	if err != nil {
-		return err
-	}
-
 	go func() {
-		value, info, err := getNonceFromSharders(_config.wallet.ClientID)
+		value, info, err := getNonceFromSharders(clientID)
 		if err != nil {
 			logging.Error(err)
 			cb.OnNonceAvailable(StatusError, 0, info)
@@ -677,8 +688,6 @@ func GetNonce(cb GetNonceCallback) error {
 		}
 		cb.OnNonceAvailable(StatusSuccess, value, info)
 	}()
-
-	return nil
 }
 
 // GetWalletBalance retrieve wallet nonce from sharders
@@ -740,46 +749,34 @@ func getNonceFromSharders(clientID string) (int64, string, error) {
 }
 
 func getBalanceFieldFromSharders(clientID, name string) (int64, string, error) {
-	result := make(chan *util.GetResponse)
-	defer close(result)
-	// getMinShardersVerify
-	var numSharders = len(_config.chain.Sharders) // overwrite, use all
-	queryFromSharders(numSharders, fmt.Sprintf("%v%v", GET_BALANCE, clientID), result)
-
-	consensusMaps := NewHttpConsensusMaps(consensusThresh)
-
-	for i := 0; i < numSharders; i++ {
-		rsp := <-result
-
-		logging.Debug(rsp.Url, rsp.Status)
-		if rsp.StatusCode != http.StatusOK {
-			logging.Error(rsp.Body)
-
-		} else {
-			logging.Debug(rsp.Body)
-		}
-
-		if err := consensusMaps.Add(rsp.StatusCode, rsp.Body); err != nil {
-			logging.Error(rsp.Body)
-		}
+	tq, err := NewTransactionQuery(_config.chain.Sharders)
+	if err != nil {
+		return 0, "", err
 	}
 
-	rate := consensusMaps.MaxConsensus * 100 / len(_config.chain.Sharders)
-	if rate < consensusThresh {
-		return 0, consensusMaps.WinError, errors.New("", "get balance failed. consensus not reached")
+	// sharders may answer at different rounds, only the field is compared
+	required := quorumRequired(sharderQuorum(), len(_config.chain.Sharders))
+	qr, err := tq.FromQuorum(context.TODO(), GET_BALANCE+clientID, required, JSONFieldsHasher(name))
+	if err != nil {
+		return 0, "", errors.Wrap(err, "get balance failed. consensus not reached")
 	}
-
-	winValue, ok := consensusMaps.GetValue(name)
-	if ok {
-		winBalance, err := strconv.ParseInt(string(winValue), 10, 64)
-		if err != nil {
-			return 0, "", fmt.Errorf("get balance failed. %w", err)
-		}
-
-		return winBalance, consensusMaps.WinInfo, nil
+	if qr.StatusCode != http.StatusOK {
+		return 0, string(qr.Content), errors.New("", "get balance failed. "+http.StatusText(qr.StatusCode))
 	}
 
-	return 0, consensusMaps.WinInfo, errors.New("", "get balance failed. balance field is missed")
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(qr.Content, &fields); err != nil {
+		return 0, "", fmt.Errorf("get balance failed. %w", err)
+	}
+	winValue, ok := fields[name]
+	if !ok {
+		return 0, string(qr.Content), errors.New("", "get balance failed. balance field is missed")
+	}
+	winBalance, err := strconv.ParseInt(string(winValue), 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("get balance failed. %w", err)
+	}
+	return winBalance, string(qr.Content), nil
 }
 
 // ConvertToToken converts the SAS tokens to ZCN tokens